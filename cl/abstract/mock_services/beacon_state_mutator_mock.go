@@ -402,6 +402,114 @@ func (c *MockBeaconStateMutatorAddValidatorCall) DoAndReturn(f func(solid.Valida
 	return c
 }
 
+// AppendPendingConsolidation mocks base method.
+func (m *MockBeaconStateMutator) AppendPendingConsolidation(arg0 *cltypes.PendingConsolidation) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AppendPendingConsolidation", arg0)
+}
+
+// AppendPendingConsolidation indicates an expected call of AppendPendingConsolidation.
+func (mr *MockBeaconStateMutatorMockRecorder) AppendPendingConsolidation(arg0 any) *MockBeaconStateMutatorAppendPendingConsolidationCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendPendingConsolidation", reflect.TypeOf((*MockBeaconStateMutator)(nil).AppendPendingConsolidation), arg0)
+	return &MockBeaconStateMutatorAppendPendingConsolidationCall{Call: call}
+}
+
+// MockBeaconStateMutatorAppendPendingConsolidationCall wrap *gomock.Call
+type MockBeaconStateMutatorAppendPendingConsolidationCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBeaconStateMutatorAppendPendingConsolidationCall) Return() *MockBeaconStateMutatorAppendPendingConsolidationCall {
+	c.Call = c.Call.Return()
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBeaconStateMutatorAppendPendingConsolidationCall) Do(f func(*cltypes.PendingConsolidation)) *MockBeaconStateMutatorAppendPendingConsolidationCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBeaconStateMutatorAppendPendingConsolidationCall) DoAndReturn(f func(*cltypes.PendingConsolidation)) *MockBeaconStateMutatorAppendPendingConsolidationCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// AppendPendingDeposit mocks base method.
+func (m *MockBeaconStateMutator) AppendPendingDeposit(arg0 *cltypes.PendingDeposit) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AppendPendingDeposit", arg0)
+}
+
+// AppendPendingDeposit indicates an expected call of AppendPendingDeposit.
+func (mr *MockBeaconStateMutatorMockRecorder) AppendPendingDeposit(arg0 any) *MockBeaconStateMutatorAppendPendingDepositCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendPendingDeposit", reflect.TypeOf((*MockBeaconStateMutator)(nil).AppendPendingDeposit), arg0)
+	return &MockBeaconStateMutatorAppendPendingDepositCall{Call: call}
+}
+
+// MockBeaconStateMutatorAppendPendingDepositCall wrap *gomock.Call
+type MockBeaconStateMutatorAppendPendingDepositCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBeaconStateMutatorAppendPendingDepositCall) Return() *MockBeaconStateMutatorAppendPendingDepositCall {
+	c.Call = c.Call.Return()
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBeaconStateMutatorAppendPendingDepositCall) Do(f func(*cltypes.PendingDeposit)) *MockBeaconStateMutatorAppendPendingDepositCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBeaconStateMutatorAppendPendingDepositCall) DoAndReturn(f func(*cltypes.PendingDeposit)) *MockBeaconStateMutatorAppendPendingDepositCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// AppendPendingPartialWithdrawal mocks base method.
+func (m *MockBeaconStateMutator) AppendPendingPartialWithdrawal(arg0 *cltypes.PendingPartialWithdrawal) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AppendPendingPartialWithdrawal", arg0)
+}
+
+// AppendPendingPartialWithdrawal indicates an expected call of AppendPendingPartialWithdrawal.
+func (mr *MockBeaconStateMutatorMockRecorder) AppendPendingPartialWithdrawal(arg0 any) *MockBeaconStateMutatorAppendPendingPartialWithdrawalCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendPendingPartialWithdrawal", reflect.TypeOf((*MockBeaconStateMutator)(nil).AppendPendingPartialWithdrawal), arg0)
+	return &MockBeaconStateMutatorAppendPendingPartialWithdrawalCall{Call: call}
+}
+
+// MockBeaconStateMutatorAppendPendingPartialWithdrawalCall wrap *gomock.Call
+type MockBeaconStateMutatorAppendPendingPartialWithdrawalCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBeaconStateMutatorAppendPendingPartialWithdrawalCall) Return() *MockBeaconStateMutatorAppendPendingPartialWithdrawalCall {
+	c.Call = c.Call.Return()
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBeaconStateMutatorAppendPendingPartialWithdrawalCall) Do(f func(*cltypes.PendingPartialWithdrawal)) *MockBeaconStateMutatorAppendPendingPartialWithdrawalCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBeaconStateMutatorAppendPendingPartialWithdrawalCall) DoAndReturn(f func(*cltypes.PendingPartialWithdrawal)) *MockBeaconStateMutatorAppendPendingPartialWithdrawalCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // AppendValidator mocks base method.
 func (m *MockBeaconStateMutator) AppendValidator(arg0 solid.Validator) {
 	m.ctrl.T.Helper()
@@ -726,6 +834,42 @@ func (c *MockBeaconStateMutatorSetBlockRootAtCall) DoAndReturn(f func(int, commo
 	return c
 }
 
+// SetConsolidationBalanceToConsume mocks base method.
+func (m *MockBeaconStateMutator) SetConsolidationBalanceToConsume(arg0 uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetConsolidationBalanceToConsume", arg0)
+}
+
+// SetConsolidationBalanceToConsume indicates an expected call of SetConsolidationBalanceToConsume.
+func (mr *MockBeaconStateMutatorMockRecorder) SetConsolidationBalanceToConsume(arg0 any) *MockBeaconStateMutatorSetConsolidationBalanceToConsumeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetConsolidationBalanceToConsume", reflect.TypeOf((*MockBeaconStateMutator)(nil).SetConsolidationBalanceToConsume), arg0)
+	return &MockBeaconStateMutatorSetConsolidationBalanceToConsumeCall{Call: call}
+}
+
+// MockBeaconStateMutatorSetConsolidationBalanceToConsumeCall wrap *gomock.Call
+type MockBeaconStateMutatorSetConsolidationBalanceToConsumeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBeaconStateMutatorSetConsolidationBalanceToConsumeCall) Return() *MockBeaconStateMutatorSetConsolidationBalanceToConsumeCall {
+	c.Call = c.Call.Return()
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBeaconStateMutatorSetConsolidationBalanceToConsumeCall) Do(f func(uint64)) *MockBeaconStateMutatorSetConsolidationBalanceToConsumeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBeaconStateMutatorSetConsolidationBalanceToConsumeCall) DoAndReturn(f func(uint64)) *MockBeaconStateMutatorSetConsolidationBalanceToConsumeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // SetCurrentEpochParticipationFlags mocks base method.
 func (m *MockBeaconStateMutator) SetCurrentEpochParticipationFlags(arg0 []cltypes.ParticipationFlags) {
 	m.ctrl.T.Helper()
@@ -834,6 +978,150 @@ func (c *MockBeaconStateMutatorSetCurrentSyncCommitteeCall) DoAndReturn(f func(*
 	return c
 }
 
+// SetDepositBalanceToConsume mocks base method.
+func (m *MockBeaconStateMutator) SetDepositBalanceToConsume(arg0 uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetDepositBalanceToConsume", arg0)
+}
+
+// SetDepositBalanceToConsume indicates an expected call of SetDepositBalanceToConsume.
+func (mr *MockBeaconStateMutatorMockRecorder) SetDepositBalanceToConsume(arg0 any) *MockBeaconStateMutatorSetDepositBalanceToConsumeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDepositBalanceToConsume", reflect.TypeOf((*MockBeaconStateMutator)(nil).SetDepositBalanceToConsume), arg0)
+	return &MockBeaconStateMutatorSetDepositBalanceToConsumeCall{Call: call}
+}
+
+// MockBeaconStateMutatorSetDepositBalanceToConsumeCall wrap *gomock.Call
+type MockBeaconStateMutatorSetDepositBalanceToConsumeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBeaconStateMutatorSetDepositBalanceToConsumeCall) Return() *MockBeaconStateMutatorSetDepositBalanceToConsumeCall {
+	c.Call = c.Call.Return()
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBeaconStateMutatorSetDepositBalanceToConsumeCall) Do(f func(uint64)) *MockBeaconStateMutatorSetDepositBalanceToConsumeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBeaconStateMutatorSetDepositBalanceToConsumeCall) DoAndReturn(f func(uint64)) *MockBeaconStateMutatorSetDepositBalanceToConsumeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SetDepositRequestsStartIndex mocks base method.
+func (m *MockBeaconStateMutator) SetDepositRequestsStartIndex(arg0 uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetDepositRequestsStartIndex", arg0)
+}
+
+// SetDepositRequestsStartIndex indicates an expected call of SetDepositRequestsStartIndex.
+func (mr *MockBeaconStateMutatorMockRecorder) SetDepositRequestsStartIndex(arg0 any) *MockBeaconStateMutatorSetDepositRequestsStartIndexCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDepositRequestsStartIndex", reflect.TypeOf((*MockBeaconStateMutator)(nil).SetDepositRequestsStartIndex), arg0)
+	return &MockBeaconStateMutatorSetDepositRequestsStartIndexCall{Call: call}
+}
+
+// MockBeaconStateMutatorSetDepositRequestsStartIndexCall wrap *gomock.Call
+type MockBeaconStateMutatorSetDepositRequestsStartIndexCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBeaconStateMutatorSetDepositRequestsStartIndexCall) Return() *MockBeaconStateMutatorSetDepositRequestsStartIndexCall {
+	c.Call = c.Call.Return()
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBeaconStateMutatorSetDepositRequestsStartIndexCall) Do(f func(uint64)) *MockBeaconStateMutatorSetDepositRequestsStartIndexCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBeaconStateMutatorSetDepositRequestsStartIndexCall) DoAndReturn(f func(uint64)) *MockBeaconStateMutatorSetDepositRequestsStartIndexCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SetEarliestConsolidationEpoch mocks base method.
+func (m *MockBeaconStateMutator) SetEarliestConsolidationEpoch(arg0 uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetEarliestConsolidationEpoch", arg0)
+}
+
+// SetEarliestConsolidationEpoch indicates an expected call of SetEarliestConsolidationEpoch.
+func (mr *MockBeaconStateMutatorMockRecorder) SetEarliestConsolidationEpoch(arg0 any) *MockBeaconStateMutatorSetEarliestConsolidationEpochCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEarliestConsolidationEpoch", reflect.TypeOf((*MockBeaconStateMutator)(nil).SetEarliestConsolidationEpoch), arg0)
+	return &MockBeaconStateMutatorSetEarliestConsolidationEpochCall{Call: call}
+}
+
+// MockBeaconStateMutatorSetEarliestConsolidationEpochCall wrap *gomock.Call
+type MockBeaconStateMutatorSetEarliestConsolidationEpochCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBeaconStateMutatorSetEarliestConsolidationEpochCall) Return() *MockBeaconStateMutatorSetEarliestConsolidationEpochCall {
+	c.Call = c.Call.Return()
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBeaconStateMutatorSetEarliestConsolidationEpochCall) Do(f func(uint64)) *MockBeaconStateMutatorSetEarliestConsolidationEpochCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBeaconStateMutatorSetEarliestConsolidationEpochCall) DoAndReturn(f func(uint64)) *MockBeaconStateMutatorSetEarliestConsolidationEpochCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SetEarliestExitEpoch mocks base method.
+func (m *MockBeaconStateMutator) SetEarliestExitEpoch(arg0 uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetEarliestExitEpoch", arg0)
+}
+
+// SetEarliestExitEpoch indicates an expected call of SetEarliestExitEpoch.
+func (mr *MockBeaconStateMutatorMockRecorder) SetEarliestExitEpoch(arg0 any) *MockBeaconStateMutatorSetEarliestExitEpochCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEarliestExitEpoch", reflect.TypeOf((*MockBeaconStateMutator)(nil).SetEarliestExitEpoch), arg0)
+	return &MockBeaconStateMutatorSetEarliestExitEpochCall{Call: call}
+}
+
+// MockBeaconStateMutatorSetEarliestExitEpochCall wrap *gomock.Call
+type MockBeaconStateMutatorSetEarliestExitEpochCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBeaconStateMutatorSetEarliestExitEpochCall) Return() *MockBeaconStateMutatorSetEarliestExitEpochCall {
+	c.Call = c.Call.Return()
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBeaconStateMutatorSetEarliestExitEpochCall) Do(f func(uint64)) *MockBeaconStateMutatorSetEarliestExitEpochCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBeaconStateMutatorSetEarliestExitEpochCall) DoAndReturn(f func(uint64)) *MockBeaconStateMutatorSetEarliestExitEpochCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // SetEffectiveBalanceForValidatorAtIndex mocks base method.
 func (m *MockBeaconStateMutator) SetEffectiveBalanceForValidatorAtIndex(arg0 int, arg1 uint64) {
 	m.ctrl.T.Helper()
@@ -978,6 +1266,42 @@ func (c *MockBeaconStateMutatorSetEth1DepositIndexCall) DoAndReturn(f func(uint6
 	return c
 }
 
+// SetExitBalanceToConsume mocks base method.
+func (m *MockBeaconStateMutator) SetExitBalanceToConsume(arg0 uint64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetExitBalanceToConsume", arg0)
+}
+
+// SetExitBalanceToConsume indicates an expected call of SetExitBalanceToConsume.
+func (mr *MockBeaconStateMutatorMockRecorder) SetExitBalanceToConsume(arg0 any) *MockBeaconStateMutatorSetExitBalanceToConsumeCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExitBalanceToConsume", reflect.TypeOf((*MockBeaconStateMutator)(nil).SetExitBalanceToConsume), arg0)
+	return &MockBeaconStateMutatorSetExitBalanceToConsumeCall{Call: call}
+}
+
+// MockBeaconStateMutatorSetExitBalanceToConsumeCall wrap *gomock.Call
+type MockBeaconStateMutatorSetExitBalanceToConsumeCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBeaconStateMutatorSetExitBalanceToConsumeCall) Return() *MockBeaconStateMutatorSetExitBalanceToConsumeCall {
+	c.Call = c.Call.Return()
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBeaconStateMutatorSetExitBalanceToConsumeCall) Do(f func(uint64)) *MockBeaconStateMutatorSetExitBalanceToConsumeCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBeaconStateMutatorSetExitBalanceToConsumeCall) DoAndReturn(f func(uint64)) *MockBeaconStateMutatorSetExitBalanceToConsumeCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // SetExitEpochForValidatorAtIndex mocks base method.
 func (m *MockBeaconStateMutator) SetExitEpochForValidatorAtIndex(arg0 int, arg1 uint64) {
 	m.ctrl.T.Helper()
@@ -1338,6 +1662,150 @@ func (c *MockBeaconStateMutatorSetNextWithdrawalValidatorIndexCall) DoAndReturn(
 	return c
 }
 
+// SetPendingConsolidations mocks base method.
+func (m *MockBeaconStateMutator) SetPendingConsolidations(arg0 *solid.ListSSZ[*cltypes.PendingConsolidation]) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPendingConsolidations", arg0)
+}
+
+// SetPendingConsolidations indicates an expected call of SetPendingConsolidations.
+func (mr *MockBeaconStateMutatorMockRecorder) SetPendingConsolidations(arg0 any) *MockBeaconStateMutatorSetPendingConsolidationsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPendingConsolidations", reflect.TypeOf((*MockBeaconStateMutator)(nil).SetPendingConsolidations), arg0)
+	return &MockBeaconStateMutatorSetPendingConsolidationsCall{Call: call}
+}
+
+// MockBeaconStateMutatorSetPendingConsolidationsCall wrap *gomock.Call
+type MockBeaconStateMutatorSetPendingConsolidationsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBeaconStateMutatorSetPendingConsolidationsCall) Return() *MockBeaconStateMutatorSetPendingConsolidationsCall {
+	c.Call = c.Call.Return()
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBeaconStateMutatorSetPendingConsolidationsCall) Do(f func(*solid.ListSSZ[*cltypes.PendingConsolidation])) *MockBeaconStateMutatorSetPendingConsolidationsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBeaconStateMutatorSetPendingConsolidationsCall) DoAndReturn(f func(*solid.ListSSZ[*cltypes.PendingConsolidation])) *MockBeaconStateMutatorSetPendingConsolidationsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SetPendingDeposits mocks base method.
+func (m *MockBeaconStateMutator) SetPendingDeposits(arg0 *solid.ListSSZ[*cltypes.PendingDeposit]) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPendingDeposits", arg0)
+}
+
+// SetPendingDeposits indicates an expected call of SetPendingDeposits.
+func (mr *MockBeaconStateMutatorMockRecorder) SetPendingDeposits(arg0 any) *MockBeaconStateMutatorSetPendingDepositsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPendingDeposits", reflect.TypeOf((*MockBeaconStateMutator)(nil).SetPendingDeposits), arg0)
+	return &MockBeaconStateMutatorSetPendingDepositsCall{Call: call}
+}
+
+// MockBeaconStateMutatorSetPendingDepositsCall wrap *gomock.Call
+type MockBeaconStateMutatorSetPendingDepositsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBeaconStateMutatorSetPendingDepositsCall) Return() *MockBeaconStateMutatorSetPendingDepositsCall {
+	c.Call = c.Call.Return()
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBeaconStateMutatorSetPendingDepositsCall) Do(f func(*solid.ListSSZ[*cltypes.PendingDeposit])) *MockBeaconStateMutatorSetPendingDepositsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBeaconStateMutatorSetPendingDepositsCall) DoAndReturn(f func(*solid.ListSSZ[*cltypes.PendingDeposit])) *MockBeaconStateMutatorSetPendingDepositsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SetPendingPartialWithdrawals mocks base method.
+func (m *MockBeaconStateMutator) SetPendingPartialWithdrawals(arg0 *solid.ListSSZ[*cltypes.PendingPartialWithdrawal]) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPendingPartialWithdrawals", arg0)
+}
+
+// SetPendingPartialWithdrawals indicates an expected call of SetPendingPartialWithdrawals.
+func (mr *MockBeaconStateMutatorMockRecorder) SetPendingPartialWithdrawals(arg0 any) *MockBeaconStateMutatorSetPendingPartialWithdrawalsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPendingPartialWithdrawals", reflect.TypeOf((*MockBeaconStateMutator)(nil).SetPendingPartialWithdrawals), arg0)
+	return &MockBeaconStateMutatorSetPendingPartialWithdrawalsCall{Call: call}
+}
+
+// MockBeaconStateMutatorSetPendingPartialWithdrawalsCall wrap *gomock.Call
+type MockBeaconStateMutatorSetPendingPartialWithdrawalsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBeaconStateMutatorSetPendingPartialWithdrawalsCall) Return() *MockBeaconStateMutatorSetPendingPartialWithdrawalsCall {
+	c.Call = c.Call.Return()
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBeaconStateMutatorSetPendingPartialWithdrawalsCall) Do(f func(*solid.ListSSZ[*cltypes.PendingPartialWithdrawal])) *MockBeaconStateMutatorSetPendingPartialWithdrawalsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBeaconStateMutatorSetPendingPartialWithdrawalsCall) DoAndReturn(f func(*solid.ListSSZ[*cltypes.PendingPartialWithdrawal])) *MockBeaconStateMutatorSetPendingPartialWithdrawalsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SetPreviousEpochAttestations mocks base method.
+func (m *MockBeaconStateMutator) SetPreviousEpochAttestations(arg0 *solid.ListSSZ[*solid.PendingAttestation]) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetPreviousEpochAttestations", arg0)
+}
+
+// SetPreviousEpochAttestations indicates an expected call of SetPreviousEpochAttestations.
+func (mr *MockBeaconStateMutatorMockRecorder) SetPreviousEpochAttestations(arg0 any) *MockBeaconStateMutatorSetPreviousEpochAttestationsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPreviousEpochAttestations", reflect.TypeOf((*MockBeaconStateMutator)(nil).SetPreviousEpochAttestations), arg0)
+	return &MockBeaconStateMutatorSetPreviousEpochAttestationsCall{Call: call}
+}
+
+// MockBeaconStateMutatorSetPreviousEpochAttestationsCall wrap *gomock.Call
+type MockBeaconStateMutatorSetPreviousEpochAttestationsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBeaconStateMutatorSetPreviousEpochAttestationsCall) Return() *MockBeaconStateMutatorSetPreviousEpochAttestationsCall {
+	c.Call = c.Call.Return()
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBeaconStateMutatorSetPreviousEpochAttestationsCall) Do(f func(*solid.ListSSZ[*solid.PendingAttestation])) *MockBeaconStateMutatorSetPreviousEpochAttestationsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBeaconStateMutatorSetPreviousEpochAttestationsCall) DoAndReturn(f func(*solid.ListSSZ[*solid.PendingAttestation])) *MockBeaconStateMutatorSetPreviousEpochAttestationsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // SetPreviousEpochParticipationFlags mocks base method.
 func (m *MockBeaconStateMutator) SetPreviousEpochParticipationFlags(arg0 []cltypes.ParticipationFlags) {
 	m.ctrl.T.Helper()
@@ -2117,7 +2585,3 @@ func (c *MockBeaconStateMutatorSetWithdrawalCredentialForValidatorAtIndexCall) D
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
-
-func (c *MockBeaconStateMutator) SetPreviousEpochAttestations(attestations *solid.ListSSZ[*solid.PendingAttestation]) {
-
-}
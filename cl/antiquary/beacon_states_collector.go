@@ -27,6 +27,7 @@ import (
 	"github.com/erigontech/erigon-lib/etl"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types/ssz"
 	"github.com/erigontech/erigon/cl/clparams"
 	"github.com/erigontech/erigon/cl/cltypes"
 	"github.com/erigontech/erigon/cl/cltypes/solid"
@@ -36,7 +37,12 @@ import (
 	"github.com/erigontech/erigon/cl/transition/impl/eth2"
 )
 
-var stateAntiquaryBufSz = etl.BufferOptimalSize / 8 // 18 collectors * 256mb / 8 = 512mb in worst case
+type pendingQueue interface {
+	ssz.Marshaler
+	ssz.HashableSSZ
+}
+
+var stateAntiquaryBufSz = etl.BufferOptimalSize / 8 // 21 collectors * 256mb / 8 = 672mb in worst case
 
 // RATIONALE: MDBX locks the entire database when writing to it, so we need to minimize the time spent in the write lock.
 // so instead of writing the historical states on write transactions, we accumulate them in memory and write them in a single  write transaction.
@@ -62,6 +68,12 @@ type beaconStatesCollector struct {
 	activeValidatorIndiciesCollector *etl.Collector
 	balancesDumpsCollector           *etl.Collector
 	effectiveBalancesDumpCollector   *etl.Collector
+	pendingDepositsCollector         *etl.Collector
+	partialWithdrawalsCollector      *etl.Collector
+	pendingConsolidationsCollector   *etl.Collector
+
+	// roots of the electra queues last collected, so that we only dump them when they change.
+	pendingQueuesRoots [3]libcommon.Hash
 
 	buf        *bytes.Buffer
 	compressor *zstd.Encoder
@@ -95,6 +107,9 @@ func newBeaconStatesCollector(beaconCfg *clparams.BeaconChainConfig, tmpdir stri
 		activeValidatorIndiciesCollector: etl.NewCollector(kv.ActiveValidatorIndicies, tmpdir, etl.NewSortableBuffer(stateAntiquaryBufSz), logger).LogLvl(log.LvlTrace),
 		balancesDumpsCollector:           etl.NewCollector(kv.BalancesDump, tmpdir, etl.NewSortableBuffer(stateAntiquaryBufSz), logger).LogLvl(log.LvlTrace),
 		effectiveBalancesDumpCollector:   etl.NewCollector(kv.EffectiveBalancesDump, tmpdir, etl.NewSortableBuffer(stateAntiquaryBufSz), logger).LogLvl(log.LvlTrace),
+		pendingDepositsCollector:         etl.NewCollector(kv.PendingDeposits, tmpdir, etl.NewSortableBuffer(stateAntiquaryBufSz), logger).LogLvl(log.LvlTrace),
+		partialWithdrawalsCollector:      etl.NewCollector(kv.PendingPartialWithdrawals, tmpdir, etl.NewSortableBuffer(stateAntiquaryBufSz), logger).LogLvl(log.LvlTrace),
+		pendingConsolidationsCollector:   etl.NewCollector(kv.PendingConsolidations, tmpdir, etl.NewSortableBuffer(stateAntiquaryBufSz), logger).LogLvl(log.LvlTrace),
 		logger:                           logger,
 		beaconCfg:                        beaconCfg,
 
@@ -161,6 +176,9 @@ func (i *beaconStatesCollector) addGenesisState(ctx context.Context, state *stat
 	if err := i.storeSlotData(state, nil); err != nil {
 		return err
 	}
+	if err := i.collectPendingQueues(state); err != nil {
+		return err
+	}
 
 	return i.stateEventsCollector.Collect(base_encoding.Encode64ToBytes4(slot), events.CopyBytes())
 }
@@ -286,6 +304,39 @@ func (i *beaconStatesCollector) collectInactivityScores(slot uint64, inactivityS
 	return antiquateFullUint64List(i.inactivityScoresCollector, slot, inactivityScores, i.buf, i.compressor)
 }
 
+// collectPendingQueues dumps the electra pending deposits, partial withdrawals and consolidations which changed since the last dump.
+func (i *beaconStatesCollector) collectPendingQueues(st *state.CachingBeaconState) error {
+	if st.Version() < clparams.ElectraVersion {
+		return nil
+	}
+	if err := i.collectPendingQueue(st.Slot(), st.PendingDeposits(), &i.pendingQueuesRoots[0], i.pendingDepositsCollector); err != nil {
+		return err
+	}
+	if err := i.collectPendingQueue(st.Slot(), st.PendingPartialWithdrawals(), &i.pendingQueuesRoots[1], i.partialWithdrawalsCollector); err != nil {
+		return err
+	}
+	return i.collectPendingQueue(st.Slot(), st.PendingConsolidations(), &i.pendingQueuesRoots[2], i.pendingConsolidationsCollector)
+}
+
+func (i *beaconStatesCollector) collectPendingQueue(slot uint64, queue pendingQueue, lastRoot *libcommon.Hash, collector *etl.Collector) error {
+	root, err := queue.HashSSZ()
+	if err != nil {
+		return err
+	}
+	if root == *lastRoot {
+		return nil
+	}
+	encoded, err := queue.EncodeSSZ(nil)
+	if err != nil {
+		return err
+	}
+	if err := antiquateFullUint64List(collector, slot, encoded, i.buf, i.compressor); err != nil {
+		return err
+	}
+	*lastRoot = root
+	return nil
+}
+
 func (i *beaconStatesCollector) flush(ctx context.Context, tx kv.RwTx) error {
 	loadfunc := func(k, v []byte, table etl.CurrentTableReader, next etl.LoadNextFunc) error {
 		return next(k, k, v)
@@ -343,6 +394,15 @@ func (i *beaconStatesCollector) flush(ctx context.Context, tx kv.RwTx) error {
 		return err
 	}
 
+	if err := i.pendingDepositsCollector.Load(tx, kv.PendingDeposits, loadfunc, etl.TransformArgs{Quit: ctx.Done()}); err != nil {
+		return err
+	}
+	if err := i.partialWithdrawalsCollector.Load(tx, kv.PendingPartialWithdrawals, loadfunc, etl.TransformArgs{Quit: ctx.Done()}); err != nil {
+		return err
+	}
+	if err := i.pendingConsolidationsCollector.Load(tx, kv.PendingConsolidations, loadfunc, etl.TransformArgs{Quit: ctx.Done()}); err != nil {
+		return err
+	}
 	return i.balancesDumpsCollector.Load(tx, kv.BalancesDump, loadfunc, etl.TransformArgs{Quit: ctx.Done()})
 }
 
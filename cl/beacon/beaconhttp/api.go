@@ -26,7 +26,6 @@ import (
 	"strings"
 
 	"github.com/erigontech/erigon-lib/log/v3"
-	"github.com/erigontech/erigon-lib/types/ssz"
 	"github.com/erigontech/erigon/cl/phase1/forkchoice/fork_graph"
 )
 
@@ -49,6 +48,9 @@ func WrapEndpointError(err error) *EndpointError {
 	if errors.As(err, e) {
 		return e
 	}
+	if errors.As(err, &e) {
+		return e
+	}
 	if errors.Is(err, fork_graph.ErrStateNotFound) {
 		return NewEndpointError(http.StatusNotFound, ErrorCantFindBeaconState)
 	}
@@ -111,47 +113,66 @@ func HandleEndpoint[T any](h EndpointHandler[T]) http.HandlerFunc {
 			endpointError.WriteTo(w)
 			return
 		}
-		// TODO: potentially add a context option to buffer these
-		contentType := r.Header.Get("Accept")
-
 		// early return for event stream
-		if slices.Contains(w.Header().Values("Content-Type"), "text/event-stream") {
+		if slices.Contains(w.Header().Values("Content-Type"), ContentTypeEventStream) {
 			return
 		}
-		switch {
-		case contentType == "*/*", contentType == "", strings.Contains(contentType, "text/html"), strings.Contains(contentType, "application/json"):
-			if !isNil(ans) {
-				w.Header().Set("Content-Type", "application/json")
-				err := json.NewEncoder(w).Encode(ans)
-				if err != nil {
-					// this error is fatal, log to console
-					log.Error("beaconapi failed to encode json", "type", reflect.TypeOf(ans), "err", err)
-				}
-			} else {
+		contentType, ok := NegotiateContentType(r.Header.Get("Accept"))
+		if !ok {
+			http.Error(w, "content type must include application/json, application/octet-stream, or text/event-stream, got "+r.Header.Get("Accept"), http.StatusNotAcceptable)
+			return
+		}
+		if versioned, ok := any(ans).(*BeaconResponse); ok && versioned != nil && versioned.Version != nil {
+			w.Header().Set(EthConsensusVersionHeader, versioned.Version.String())
+		}
+		switch contentType {
+		case ContentTypeSSZ:
+			if resp, ok := any(ans).(*BeaconResponse); isNil(ans) || (ok && resp.Data == nil) {
 				w.WriteHeader(200)
-			}
-		case strings.Contains(contentType, "application/octet-stream"):
-			sszMarshaler, ok := any(ans).(ssz.Marshaler)
-			if !ok {
-				NewEndpointError(http.StatusBadRequest, ErrorSszNotSupported).WriteTo(w)
 				return
 			}
-			w.Header().Set("Content-Type", "application/octet-stream")
 			// TODO: we should probably figure out some way to stream this in the future :)
-			encoded, err := sszMarshaler.EncodeSSZ(nil)
+			encoded, err := EncodeSSZ(ans)
 			if err != nil {
+				// fall back to JSON when the client accepts it
+				if accept := r.Header.Get("Accept"); errors.Is(err, ErrorSszNotSupported) && acceptsJSON(accept) {
+					writeJSON(w, ans)
+					return
+				}
 				WrapEndpointError(err).WriteTo(w)
 				return
 			}
+			w.Header().Set("Content-Type", ContentTypeSSZ)
 			w.Write(encoded)
-		case strings.Contains(contentType, "text/event-stream"):
+		case ContentTypeEventStream:
 			return
 		default:
-			http.Error(w, "content type must include application/json, application/octet-stream, or text/event-stream, got "+contentType, http.StatusBadRequest)
+			writeJSON(w, ans)
 		}
 	})
 }
 
+func writeJSON(w http.ResponseWriter, ans any) {
+	if isNil(ans) {
+		w.WriteHeader(200)
+		return
+	}
+	w.Header().Set("Content-Type", ContentTypeJSON)
+	if err := json.NewEncoder(w).Encode(ans); err != nil {
+		// this error is fatal, log to console
+		log.Error("beaconapi failed to encode json", "type", reflect.TypeOf(ans), "err", err)
+	}
+}
+
+func acceptsJSON(accept string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		if contentType, ok := NegotiateContentType(part); ok && contentType == ContentTypeJSON {
+			return true
+		}
+	}
+	return false
+}
+
 func isNil[T any](t T) bool {
 	v := reflect.ValueOf(t)
 	kind := v.Kind()
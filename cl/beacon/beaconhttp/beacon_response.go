@@ -18,7 +18,6 @@ package beaconhttp
 
 import (
 	"encoding/json"
-	"net/http"
 
 	"github.com/erigontech/erigon-lib/types/ssz"
 	"github.com/erigontech/erigon/cl/clparams"
@@ -94,15 +93,11 @@ func (b *BeaconResponse) MarshalJSON() ([]byte, error) {
 }
 
 func (b *BeaconResponse) EncodeSSZ(xs []byte) ([]byte, error) {
-	marshaler, ok := b.Data.(ssz.Marshaler)
-	if !ok {
-		return nil, NewEndpointError(http.StatusBadRequest, ErrorSszNotSupported)
-	}
-	encoded, err := marshaler.EncodeSSZ(nil)
+	encoded, err := EncodeSSZ(b.Data)
 	if err != nil {
 		return nil, err
 	}
-	return encoded, nil
+	return append(xs, encoded...), nil
 }
 
 func (b *BeaconResponse) EncodingSizeSSZ() int {
@@ -0,0 +1,193 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package beaconhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/erigontech/erigon-lib/types/ssz"
+	"github.com/erigontech/erigon/cl/clparams"
+	ssz2 "github.com/erigontech/erigon/cl/ssz"
+)
+
+const (
+	ContentTypeJSON        = "application/json"
+	ContentTypeSSZ         = "application/octet-stream"
+	ContentTypeEventStream = "text/event-stream"
+
+	EthConsensusVersionHeader = "Eth-Consensus-Version"
+)
+
+var ErrorUnsupportedContentType = errors.New("content type must be application/json or application/octet-stream")
+
+// NegotiateContentType picks the content type of the response among application/json, application/octet-stream
+// and text/event-stream, honouring the quality values of the Accept header. An empty header, */*, application/* and
+// text/html all mean JSON. It returns false if none of the accepted types is supported.
+func NegotiateContentType(accept string) (string, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return ContentTypeJSON, true
+	}
+	var (
+		best   string
+		bestQ  = -1.0
+		parsed bool
+	)
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		parsed = true
+		q := 1.0
+		if qStr, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(qStr, 64); err != nil {
+				continue
+			}
+		}
+		var contentType string
+		switch mediaType {
+		case ContentTypeJSON, ContentTypeSSZ, ContentTypeEventStream:
+			contentType = mediaType
+		case "*/*", "application/*", "text/html":
+			contentType = ContentTypeJSON
+		default:
+			continue
+		}
+		// on equal quality the first listed type wins
+		if q > 0 && q > bestQ {
+			best, bestQ = contentType, q
+		}
+	}
+	if !parsed {
+		// be lenient with clients sending garbage, as we always did
+		return ContentTypeJSON, true
+	}
+	return best, best != ""
+}
+
+// EncodeSSZ encodes v if it supports SSZ encoding: either it implements ssz.Marshaler or it is a slice of
+// ssz.Marshaler, which is encoded as an SSZ list.
+func EncodeSSZ(v any) ([]byte, error) {
+	if marshaler, ok := v.(ssz.Marshaler); ok {
+		return marshaler.EncodeSSZ(nil)
+	}
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Slice || !value.Type().Elem().Implements(reflect.TypeOf((*ssz.Marshaler)(nil)).Elem()) {
+		return nil, NewEndpointError(http.StatusNotAcceptable, ErrorSszNotSupported)
+	}
+	elements := make([]ssz.Marshaler, value.Len())
+	for i := range elements {
+		elements[i] = value.Index(i).Interface().(ssz.Marshaler)
+	}
+	if len(elements) == 0 {
+		return []byte{}, nil
+	}
+	// objects which do not tell us their size are fixed-size containers (validators, sidecars, messages...)
+	if sized, ok := elements[0].(ssz2.Sized); ok && !sized.Static() {
+		return ssz.EncodeDynamicList(nil, elements)
+	}
+	var (
+		dst []byte
+		err error
+	)
+	for _, element := range elements {
+		if dst, err = element.EncodeSSZ(dst); err != nil {
+			return nil, err
+		}
+	}
+	return dst, nil
+}
+
+func isSSZRequest(r *http.Request) (bool, error) {
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		return false, nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false, NewEndpointError(http.StatusUnsupportedMediaType, err)
+	}
+	switch mediaType {
+	case ContentTypeJSON:
+		return false, nil
+	case ContentTypeSSZ:
+		return true, nil
+	}
+	return false, NewEndpointError(http.StatusUnsupportedMediaType, ErrorUnsupportedContentType)
+}
+
+// DecodeRequestBody decodes the body of r into v, as SSZ of the given version if the request is sent with
+// Content-Type application/octet-stream and as JSON otherwise.
+func DecodeRequestBody(r *http.Request, v ssz.Unmarshaler, version clparams.StateVersion) error {
+	isSSZ, err := isSSZRequest(r)
+	if err != nil {
+		return err
+	}
+	if !isSSZ {
+		if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+			return NewEndpointError(http.StatusBadRequest, err)
+		}
+		return nil
+	}
+	buf, err := io.ReadAll(r.Body)
+	if err != nil {
+		return NewEndpointError(http.StatusBadRequest, err)
+	}
+	if err := v.DecodeSSZ(buf, int(version)); err != nil {
+		return NewEndpointError(http.StatusBadRequest, fmt.Errorf("invalid ssz body: %w", err))
+	}
+	return nil
+}
+
+// DecodeRequestBodyList decodes a JSON array or an SSZ list of objects from the body of r. bytesPerElement is the SSZ
+// size of the objects if they have a fixed size, or 0 for variable-size objects.
+func DecodeRequestBodyList[T ssz.Unmarshaler](r *http.Request, version clparams.StateVersion, bytesPerElement int) ([]T, error) {
+	isSSZ, err := isSSZRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	if !isSSZ {
+		list := []T{}
+		if err := json.NewDecoder(r.Body).Decode(&list); err != nil {
+			return nil, NewEndpointError(http.StatusBadRequest, err)
+		}
+		return list, nil
+	}
+	buf, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, NewEndpointError(http.StatusBadRequest, err)
+	}
+	var list []T
+	if bytesPerElement > 0 {
+		list, err = ssz.DecodeStaticList[T](buf, 0, uint32(len(buf)), uint32(bytesPerElement), uint64(len(buf)), int(version))
+	} else {
+		// every element takes at least its 4 bytes offset
+		list, err = ssz.DecodeDynamicList[T](buf, 0, uint32(len(buf)), uint64(len(buf)/4), int(version))
+	}
+	if err != nil {
+		return nil, NewEndpointError(http.StatusBadRequest, fmt.Errorf("invalid ssz body: %w", err))
+	}
+	return list, nil
+}
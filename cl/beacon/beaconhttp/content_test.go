@@ -0,0 +1,144 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package beaconhttp
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/cltypes/solid"
+)
+
+func TestNegotiateContentType(t *testing.T) {
+	for accept, expected := range map[string]string{
+		"":    ContentTypeJSON,
+		"*/*": ContentTypeJSON,
+		"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8": ContentTypeJSON,
+		"application/octet-stream":                              ContentTypeSSZ,
+		"application/json;q=0.9,application/octet-stream":       ContentTypeSSZ,
+		"application/octet-stream;q=0.5,application/json;q=0.9": ContentTypeJSON,
+		"application/octet-stream,application/json":             ContentTypeSSZ,
+		"text/event-stream":                                     ContentTypeEventStream,
+	} {
+		contentType, ok := NegotiateContentType(accept)
+		require.True(t, ok, accept)
+		require.Equal(t, expected, contentType, accept)
+	}
+	_, ok := NegotiateContentType("application/xml")
+	require.False(t, ok)
+	_, ok = NegotiateContentType("application/octet-stream;q=0")
+	require.False(t, ok)
+}
+
+func testAttestations() []*solid.Attestation {
+	atts := make([]*solid.Attestation, 3)
+	for i := range atts {
+		data := solid.NewAttestionDataFromParameters(uint64(i), 0, libcommon.Hash{byte(i)}, solid.NewCheckpoint(), solid.NewCheckpoint())
+		atts[i] = solid.NewAttestionFromParameters(make([]byte, i+1), data, libcommon.Bytes96{byte(i)})
+	}
+	return atts
+}
+
+func TestHandleEndpointSSZ(t *testing.T) {
+	atts := testAttestations()
+	handler := HandleEndpointFunc(func(w http.ResponseWriter, r *http.Request) (*BeaconResponse, error) {
+		return NewBeaconResponse(atts).WithVersion(clparams.DenebVersion), nil
+	})
+
+	// SSZ lists of variable-size objects
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept", "application/octet-stream;q=1.0,application/json;q=0.9")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, ContentTypeSSZ, rec.Header().Get("Content-Type"))
+	require.Equal(t, "deneb", rec.Header().Get(EthConsensusVersionHeader))
+
+	// which can be posted back
+	post := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(rec.Body.Bytes()))
+	post.Header.Set("Content-Type", ContentTypeSSZ)
+	decoded, err := DecodeRequestBodyList[*solid.Attestation](post, clparams.DenebVersion, 0)
+	require.NoError(t, err)
+	require.Len(t, decoded, len(atts))
+	for i := range atts {
+		expected, err := atts[i].HashSSZ()
+		require.NoError(t, err)
+		actual, err := decoded[i].HashSSZ()
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+	}
+
+	// JSON is still the default
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, ContentTypeJSON, rec.Header().Get("Content-Type"))
+	require.Equal(t, "deneb", rec.Header().Get(EthConsensusVersionHeader))
+}
+
+func TestHandleEndpointSSZNotSupported(t *testing.T) {
+	handler := HandleEndpointFunc(func(w http.ResponseWriter, r *http.Request) (*BeaconResponse, error) {
+		return NewBeaconResponse(map[string]string{"foo": "bar"}), nil
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept", "application/octet-stream")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusNotAcceptable, rec.Code)
+
+	// falls back to JSON when accepted
+	req.Header.Set("Accept", "application/octet-stream,application/json;q=0.5")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, ContentTypeJSON, rec.Header().Get("Content-Type"))
+}
+
+func TestDecodeRequestBody(t *testing.T) {
+	exit := &cltypes.SignedVoluntaryExit{
+		VoluntaryExit: &cltypes.VoluntaryExit{Epoch: 5, ValidatorIndex: 7},
+		Signature:     libcommon.Bytes96{1},
+	}
+	encoded, err := exit.EncodeSSZ(nil)
+	require.NoError(t, err)
+	jsonEncoded, err := json.Marshal(exit)
+	require.NoError(t, err)
+
+	for contentType, body := range map[string][]byte{ContentTypeSSZ: encoded, ContentTypeJSON: jsonEncoded, "": jsonEncoded} {
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		decoded := &cltypes.SignedVoluntaryExit{}
+		require.NoError(t, DecodeRequestBody(req, decoded, clparams.DenebVersion))
+		require.Equal(t, exit, decoded)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/", io.NopCloser(bytes.NewReader(encoded)))
+	req.Header.Set("Content-Type", "text/plain")
+	err = DecodeRequestBody(req, &cltypes.SignedVoluntaryExit{}, clparams.DenebVersion)
+	var endpointError *EndpointError
+	require.ErrorAs(t, err, &endpointError)
+	require.Equal(t, http.StatusUnsupportedMediaType, endpointError.Code)
+}
@@ -93,20 +93,8 @@ func (a *ApiHandler) GetEthV1ValidatorAttestationData(
 			errors.New("slot and committee_index url params are required"),
 		)
 	}
-	headState := a.syncedData.HeadState()
-	if headState == nil {
-		return nil, beaconhttp.NewEndpointError(
-			http.StatusServiceUnavailable,
-			errors.New("beacon node is still syncing"),
-		)
-	}
-
-	attestationData, err := a.attestationProducer.ProduceAndCacheAttestationData(
-		headState,
-		*slot,
-		*committeeIndex,
-	)
-	if err == attestation_producer.ErrHeadStateBehind {
+	attestationData, err := a.ProduceAttestationData(*slot, *committeeIndex)
+	if err == attestation_producer.ErrHeadStateNotAvailable || err == attestation_producer.ErrHeadStateBehind {
 		return nil, beaconhttp.NewEndpointError(
 			http.StatusServiceUnavailable,
 			errors.New("beacon node is still syncing"),
@@ -118,6 +106,15 @@ func (a *ApiHandler) GetEthV1ValidatorAttestationData(
 	return newBeaconResponse(attestationData), nil
 }
 
+// ProduceAttestationData returns the attestation data to be signed by the committee at slot, from the head state.
+func (a *ApiHandler) ProduceAttestationData(slot, committeeIndex uint64) (solid.AttestationData, error) {
+	headState := a.syncedData.HeadState()
+	if headState == nil {
+		return solid.AttestationData{}, attestation_producer.ErrHeadStateNotAvailable
+	}
+	return a.attestationProducer.ProduceAndCacheAttestationData(headState, slot, committeeIndex)
+}
+
 func (a *ApiHandler) GetEthV3ValidatorBlock(
 	w http.ResponseWriter,
 	r *http.Request,
@@ -140,12 +137,6 @@ func (a *ApiHandler) GetEthV3ValidatorBlock(
 		graffiti = libcommon.HexToHash(hex.EncodeToString([]byte(defaultGraffitiString)))
 	}
 
-	tx, err := a.indiciesDB.BeginRo(ctx)
-	if err != nil {
-		return nil, err
-	}
-	defer tx.Rollback()
-
 	targetSlotStr := chi.URLParam(r, "slot")
 	targetSlot, err := strconv.ParseUint(targetSlotStr, 10, 64)
 	if err != nil {
@@ -168,6 +159,48 @@ func (a *ApiHandler) GetEthV3ValidatorBlock(
 		}
 	}
 
+	block, err := a.ProduceBlock(ctx, targetSlot, builderBoostFactor, randaoReveal, graffiti)
+	if err != nil {
+		return nil, err
+	}
+
+	// todo: consensusValue
+	rewardsCollector := &eth2.BlockRewardsCollector{}
+	consensusValue := rewardsCollector.Attestations + rewardsCollector.ProposerSlashings + rewardsCollector.AttesterSlashings + rewardsCollector.SyncAggregate
+	a.setupHeaderReponseForBlockProduction(
+		w,
+		block.Version(),
+		block.IsBlinded(),
+		block.GetExecutionValue().Uint64(),
+		consensusValue,
+	)
+
+	var resp *beaconhttp.BeaconResponse
+	if block.IsBlinded() {
+		resp = newBeaconResponse(block.ToBlinded())
+	} else {
+		resp = newBeaconResponse(block.ToExecution())
+	}
+	return resp.WithVersion(block.Version()).With("execution_payload_blinded", block.IsBlinded()).
+		With("execution_payload_value", strconv.FormatUint(block.GetExecutionValue().Uint64(), 10)).
+		With("consensus_block_value", strconv.FormatUint(consensusValue, 10)), nil
+}
+
+// ProduceBlock builds a block for targetSlot on top of the current head, with its state root already computed.
+// It is the shared path of the block production endpoint and of the embedded validator client.
+func (a *ApiHandler) ProduceBlock(
+	ctx context.Context,
+	targetSlot uint64,
+	builderBoostFactor uint64,
+	randaoReveal common.Bytes96,
+	graffiti common.Hash,
+) (*cltypes.BlindOrExecutionBeaconBlock, error) {
+	tx, err := a.indiciesDB.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
 	s := a.syncedData.HeadState()
 	if s == nil {
 		return nil, beaconhttp.NewEndpointError(
@@ -234,27 +267,7 @@ func (a *ApiHandler) GetEthV3ValidatorBlock(
 		"version", block.Version(),
 		"blinded", block.IsBlinded(),
 	)
-
-	// todo: consensusValue
-	rewardsCollector := &eth2.BlockRewardsCollector{}
-	consensusValue := rewardsCollector.Attestations + rewardsCollector.ProposerSlashings + rewardsCollector.AttesterSlashings + rewardsCollector.SyncAggregate
-	a.setupHeaderReponseForBlockProduction(
-		w,
-		block.Version(),
-		block.IsBlinded(),
-		block.GetExecutionValue().Uint64(),
-		consensusValue,
-	)
-
-	var resp *beaconhttp.BeaconResponse
-	if block.IsBlinded() {
-		resp = newBeaconResponse(block.ToBlinded())
-	} else {
-		resp = newBeaconResponse(block.ToExecution())
-	}
-	return resp.WithVersion(block.Version()).With("execution_payload_blinded", block.IsBlinded()).
-		With("execution_payload_value", strconv.FormatUint(block.GetExecutionValue().Uint64(), 10)).
-		With("consensus_block_value", strconv.FormatUint(consensusValue, 10)), nil
+	return block, nil
 }
 
 func (a *ApiHandler) produceBlock(
@@ -779,7 +792,7 @@ func (a *ApiHandler) postBeaconBlocks(w http.ResponseWriter, r *http.Request, ap
 	// Decode the block
 	block, err := a.parseRequestBeaconBlock(version, r)
 	if err != nil {
-		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, err)
+		return nil, err
 	}
 	_ = validation
 
@@ -920,26 +933,16 @@ func (a *ApiHandler) parseRequestBeaconBlock(
 	r *http.Request,
 ) (*cltypes.DenebSignedBeaconBlock, error) {
 	block := cltypes.NewDenebSignedBeaconBlock(a.beaconChainCfg)
-	// check content type
-	switch r.Header.Get("Content-Type") {
-	case "application/json":
-		if err := json.NewDecoder(r.Body).Decode(block); err != nil {
-			return nil, err
-		}
-		block.SignedBlock.Block.SetVersion(version)
-		return block, nil
-	case "application/octet-stream":
-		octect, err := io.ReadAll(r.Body)
-		if err != nil {
-			return nil, err
-		}
-		if err := block.DecodeSSZ(octect, int(version)); err != nil {
-			return nil, err
-		}
-		block.SignedBlock.Block.SetVersion(version)
-		return block, nil
+	if err := beaconhttp.DecodeRequestBody(r, block, version); err != nil {
+		return nil, err
 	}
-	return nil, errors.New("invalid content type")
+	block.SignedBlock.Block.SetVersion(version)
+	return block, nil
+}
+
+// PublishBlock broadcasts a signed block (and its blobs) and imports it into the node.
+func (a *ApiHandler) PublishBlock(ctx context.Context, blk *cltypes.SignedBeaconBlock) error {
+	return a.broadcastBlock(ctx, blk)
 }
 
 func (a *ApiHandler) broadcastBlock(ctx context.Context, blk *cltypes.SignedBeaconBlock) error {
@@ -0,0 +1,158 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package handler
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+	"net/http"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/cl/beacon/beaconhttp"
+	"github.com/erigontech/erigon/cl/cltypes/solid"
+	"github.com/erigontech/erigon/cl/utils"
+)
+
+// depositSnapshotMaxFinalized is the amount of finalized subtree roots of a 32 levels deposit tree.
+const depositSnapshotMaxFinalized = 32
+
+var errDepositSnapshotUnavailable = errors.New("deposit snapshot is not available")
+
+// depositSnapshot is the EIP-4881 snapshot of the finalized part of the deposit tree.
+type depositSnapshot struct {
+	blockRoot libcommon.Hash // finalized block the snapshot was computed from
+
+	Finalized            []libcommon.Hash `json:"finalized"`
+	DepositRoot          libcommon.Hash   `json:"deposit_root"`
+	DepositCount         uint64           `json:"deposit_count,string"`
+	ExecutionBlockHash   libcommon.Hash   `json:"execution_block_hash"`
+	ExecutionBlockHeight uint64           `json:"execution_block_height,string"`
+}
+
+func (d *depositSnapshot) EncodeSSZ(dst []byte) ([]byte, error) {
+	// finalized is the only variable-size field, it comes right after the fixed part.
+	dst = binary.LittleEndian.AppendUint32(dst, 4+32+8+32+8)
+	dst = append(dst, d.DepositRoot[:]...)
+	dst = binary.LittleEndian.AppendUint64(dst, d.DepositCount)
+	dst = append(dst, d.ExecutionBlockHash[:]...)
+	dst = binary.LittleEndian.AppendUint64(dst, d.ExecutionBlockHeight)
+	for _, node := range d.Finalized {
+		dst = append(dst, node[:]...)
+	}
+	return dst, nil
+}
+
+func (d *depositSnapshot) EncodingSizeSSZ() int {
+	return 4 + 32 + 8 + 32 + 8 + 32*len(d.Finalized)
+}
+
+// depositSnapshotFinalized computes the roots of the full subtrees of a deposit tree holding depositCount leaves,
+// largest first, from the merkle proof of its last leaf.
+func depositSnapshotFinalized(depositCount uint64, lastLeaf libcommon.Hash, proof solid.HashVectorSSZ) []libcommon.Hash {
+	finalized := []libcommon.Hash{}
+	if depositCount == 0 {
+		return finalized
+	}
+	// the subtree holding the last leaf is the one of the lowest set bit, all the leaves on its left are full subtrees.
+	lowest := bits.TrailingZeros64(depositCount)
+	node := lastLeaf
+	for level := 0; level < lowest; level++ {
+		sibling := proof.Get(level)
+		node = utils.Sha256(sibling[:], node[:])
+	}
+	for level := depositSnapshotMaxFinalized - 1; level > lowest; level-- {
+		if depositCount&(1<<level) != 0 {
+			finalized = append(finalized, proof.Get(level))
+		}
+	}
+	return append(finalized, node)
+}
+
+// https://ethereum.github.io/beacon-APIs/#/Beacon/getDepositSnapshot
+func (a *ApiHandler) GetEthV1BeaconDepositSnapshot(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	ctx := r.Context()
+	tx, err := a.indiciesDB.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	finalizedRoot := a.forkchoiceStore.FinalizedCheckpoint().BlockRoot()
+	if snapshot := a.lastDepositSnapshot.Load(); snapshot != nil && snapshot.blockRoot == finalizedRoot {
+		return newBeaconResponse(snapshot), nil
+	}
+	s, _, err := a.stateAtBlockRoot(ctx, tx, finalizedRoot)
+	if err != nil {
+		return nil, err
+	}
+	eth1Data := s.Eth1Data()
+	depositCount := eth1Data.DepositCount
+	// the finalized tree is only known once all of the deposits of the voted eth1 data are included.
+	if s.Eth1DepositIndex() != depositCount {
+		return nil, beaconhttp.NewEndpointError(http.StatusNotFound, fmt.Errorf("%w: %d of %d deposits included", errDepositSnapshotUnavailable, s.Eth1DepositIndex(), depositCount))
+	}
+
+	snapshot := &depositSnapshot{
+		blockRoot:          finalizedRoot,
+		Finalized:          []libcommon.Hash{},
+		DepositRoot:        eth1Data.Root,
+		DepositCount:       depositCount,
+		ExecutionBlockHash: eth1Data.BlockHash,
+	}
+	if depositCount > 0 {
+		// walk back to the block which included the last deposit.
+		blockRoot := finalizedRoot
+		for {
+			block, err := a.blockReader.ReadBlockByRoot(ctx, tx, blockRoot)
+			if err != nil {
+				return nil, err
+			}
+			if block == nil || block.Block.Slot == 0 {
+				// genesis deposits come without proofs.
+				return nil, beaconhttp.NewEndpointError(http.StatusNotFound, fmt.Errorf("%w: no block with deposits", errDepositSnapshotUnavailable))
+			}
+			deposits := block.Block.Body.Deposits
+			if deposits.Len() > 0 {
+				lastDeposit := deposits.Get(deposits.Len() - 1)
+				leaf, err := lastDeposit.Data.HashSSZ()
+				if err != nil {
+					return nil, err
+				}
+				snapshot.Finalized = depositSnapshotFinalized(depositCount, leaf, lastDeposit.Proof)
+				break
+			}
+			blockRoot = block.Block.ParentRoot
+		}
+	}
+	if eth1Data.BlockHash != (libcommon.Hash{}) {
+		if a.engine == nil {
+			return nil, beaconhttp.NewEndpointError(http.StatusServiceUnavailable, fmt.Errorf("%w: no execution engine", errDepositSnapshotUnavailable))
+		}
+		height, err := a.engine.HeaderNumber(ctx, eth1Data.BlockHash)
+		if err != nil {
+			return nil, err
+		}
+		if height == nil {
+			return nil, beaconhttp.NewEndpointError(http.StatusNotFound, fmt.Errorf("%w: execution block %x not found", errDepositSnapshotUnavailable, eth1Data.BlockHash))
+		}
+		snapshot.ExecutionBlockHeight = *height
+	}
+	a.lastDepositSnapshot.Store(snapshot)
+	return newBeaconResponse(snapshot), nil
+}
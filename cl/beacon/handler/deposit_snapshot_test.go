@@ -0,0 +1,68 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package handler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/cl/cltypes/solid"
+	"github.com/erigontech/erigon/cl/utils"
+)
+
+func TestDepositSnapshotFinalized(t *testing.T) {
+	var zeroHashes [depositSnapshotMaxFinalized + 1]libcommon.Hash
+	for i := 1; i < len(zeroHashes); i++ {
+		zeroHashes[i] = utils.Sha256(zeroHashes[i-1][:], zeroHashes[i-1][:])
+	}
+	leaves := make([]libcommon.Hash, 37)
+	for i := range leaves {
+		leaves[i] = libcommon.Hash{byte(i + 1)}
+	}
+	// node returns the root of the subtree at the given level and index of a tree holding the first n leaves.
+	var node func(n uint64, level int, index uint64) libcommon.Hash
+	node = func(n uint64, level int, index uint64) libcommon.Hash {
+		if index<<level >= n {
+			return zeroHashes[level]
+		}
+		if level == 0 {
+			return leaves[index]
+		}
+		left, right := node(n, level-1, 2*index), node(n, level-1, 2*index+1)
+		return utils.Sha256(left[:], right[:])
+	}
+
+	require.Empty(t, depositSnapshotFinalized(0, libcommon.Hash{}, solid.NewHashVector(33)))
+	for n := uint64(1); n <= uint64(len(leaves)); n++ {
+		proof := solid.NewHashVector(33)
+		for level := 0; level < depositSnapshotMaxFinalized; level++ {
+			proof.Set(level, node(n, level, ((n-1)>>level)^1))
+		}
+		// the finalized subtrees, largest first, cover exactly the first n leaves.
+		var expected []libcommon.Hash
+		var start uint64
+		for level := depositSnapshotMaxFinalized - 1; level >= 0; level-- {
+			if n&(1<<level) != 0 {
+				expected = append(expected, node(n, level, start>>level))
+				start += 1 << level
+			}
+		}
+		require.Equal(t, expected, depositSnapshotFinalized(n, leaves[n-1], proof), "deposit count %d", n)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package handler
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/erigontech/erigon-lib/types/clonable"
+	"github.com/erigontech/erigon-lib/types/ssz"
+	"github.com/erigontech/erigon/cl/beacon/beaconhttp"
+)
+
+// dutiesRequestIndex is a validator index of a duties request body, which is either a JSON array of strings or
+// an SSZ List[uint64].
+type dutiesRequestIndex uint64
+
+func (d *dutiesRequestIndex) UnmarshalJSON(buf []byte) error {
+	var str string
+	if err := json.Unmarshal(buf, &str); err != nil {
+		return err
+	}
+	idx, err := strconv.ParseUint(str, 10, 64)
+	if err != nil {
+		return fmt.Errorf("could not parse validator index: %w", err)
+	}
+	*d = dutiesRequestIndex(idx)
+	return nil
+}
+
+func (d *dutiesRequestIndex) DecodeSSZ(buf []byte, _ int) error {
+	if len(buf) < 8 {
+		return ssz.ErrLowBufferSize
+	}
+	*d = dutiesRequestIndex(binary.LittleEndian.Uint64(buf))
+	return nil
+}
+
+func (*dutiesRequestIndex) Clone() clonable.Clonable {
+	return new(dutiesRequestIndex)
+}
+
+// parseDutiesRequest reads the validator indicies of a duties request body.
+func (a *ApiHandler) parseDutiesRequest(r *http.Request) ([]uint64, error) {
+	req, err := beaconhttp.DecodeRequestBodyList[*dutiesRequestIndex](r, a.currentStateVersion(), 8)
+	if err != nil {
+		return nil, err
+	}
+	idxs := make([]uint64, len(req))
+	for i, idx := range req {
+		idxs[i] = uint64(*idx)
+	}
+	return idxs, nil
+}
@@ -17,11 +17,10 @@
 package handler
 
 import (
-	"encoding/json"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"net/http"
-	"strconv"
 
 	libcommon "github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon/cl/beacon/beaconhttp"
@@ -39,6 +38,18 @@ type attesterDutyResponse struct {
 	Slot                    uint64            `json:"slot,string"`
 }
 
+func (d attesterDutyResponse) EncodeSSZ(dst []byte) ([]byte, error) {
+	dst = append(dst, d.Pubkey[:]...)
+	for _, v := range []uint64{d.ValidatorIndex, d.CommitteeIndex, d.CommitteeLength, d.CommitteesAtSlot, d.ValidatorCommitteeIndex, d.Slot} {
+		dst = binary.LittleEndian.AppendUint64(dst, v)
+	}
+	return dst, nil
+}
+
+func (d attesterDutyResponse) EncodingSizeSSZ() int {
+	return 48 + 8*6
+}
+
 func (a *ApiHandler) getDependentRoot(s *state.CachingBeaconState, epoch uint64) libcommon.Hash {
 	dependentRootSlot := ((epoch - 1) * a.beaconChainCfg.SlotsPerEpoch) - 3
 	maxIterations := 2048
@@ -68,24 +79,15 @@ func (a *ApiHandler) getAttesterDuties(w http.ResponseWriter, r *http.Request) (
 	}
 	dependentRoot := a.getDependentRoot(s, epoch)
 
-	var idxsStr []string
-	if err := json.NewDecoder(r.Body).Decode(&idxsStr); err != nil {
-		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, fmt.Errorf("could not decode request body: %w. request body is required", err))
+	idxs, err := a.parseDutiesRequest(r)
+	if err != nil {
+		return nil, err
 	}
-	if len(idxsStr) == 0 {
-		return newBeaconResponse([]string{}).WithOptimistic(a.forkchoiceStore.IsHeadOptimistic()).With("dependent_root", dependentRoot), nil
+	if len(idxs) == 0 {
+		return newBeaconResponse([]attesterDutyResponse{}).WithOptimistic(a.forkchoiceStore.IsHeadOptimistic()).With("dependent_root", dependentRoot), nil
 	}
 	idxSet := map[int]struct{}{}
-	// convert the request to uint64
-	for _, idxStr := range idxsStr {
-
-		idx, err := strconv.ParseUint(idxStr, 10, 64)
-		if err != nil {
-			return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, fmt.Errorf("could not parse validator index: %w", err))
-		}
-		if _, ok := idxSet[int(idx)]; ok {
-			continue
-		}
+	for _, idx := range idxs {
 		idxSet[int(idx)] = struct{}{}
 	}
 
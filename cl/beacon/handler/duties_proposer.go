@@ -38,6 +38,16 @@ type proposerDuties struct {
 	Slot           uint64            `json:"slot,string"`
 }
 
+func (d proposerDuties) EncodeSSZ(dst []byte) ([]byte, error) {
+	dst = append(dst, d.Pubkey[:]...)
+	dst = binary.LittleEndian.AppendUint64(dst, d.ValidatorIndex)
+	return binary.LittleEndian.AppendUint64(dst, d.Slot), nil
+}
+
+func (d proposerDuties) EncodingSizeSSZ() int {
+	return 48 + 8 + 8
+}
+
 func (a *ApiHandler) getDutiesProposer(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
 	epoch, err := beaconhttp.EpochFromRequest(r)
 	if err != nil {
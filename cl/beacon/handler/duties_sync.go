@@ -17,7 +17,7 @@
 package handler
 
 import (
-	"encoding/json"
+	"encoding/binary"
 	"fmt"
 	"net/http"
 	"sort"
@@ -35,6 +35,29 @@ type syncDutyResponse struct {
 	ValidatorSyncCommitteeIndicies []string          `json:"validator_sync_committee_indices"`
 }
 
+func (d *syncDutyResponse) EncodeSSZ(dst []byte) ([]byte, error) {
+	dst = append(dst, d.Pubkey[:]...)
+	dst = binary.LittleEndian.AppendUint64(dst, d.ValidatorIndex)
+	// the sync committee indicies are the only variable-size field.
+	dst = binary.LittleEndian.AppendUint32(dst, 48+8+4)
+	for _, idxStr := range d.ValidatorSyncCommitteeIndicies {
+		idx, err := strconv.ParseUint(idxStr, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		dst = binary.LittleEndian.AppendUint64(dst, idx)
+	}
+	return dst, nil
+}
+
+func (d *syncDutyResponse) EncodingSizeSSZ() int {
+	return 48 + 8 + 4 + 8*len(d.ValidatorSyncCommitteeIndicies)
+}
+
+func (*syncDutyResponse) Static() bool {
+	return false
+}
+
 func (a *ApiHandler) getSyncDuties(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
 	epoch, err := beaconhttp.EpochFromRequest(r)
 	if err != nil {
@@ -44,22 +67,16 @@ func (a *ApiHandler) getSyncDuties(w http.ResponseWriter, r *http.Request) (*bea
 	// compute the sync committee period
 	period := epoch / a.beaconChainCfg.EpochsPerSyncCommitteePeriod
 
-	var idxsStr []string
-	if err := json.NewDecoder(r.Body).Decode(&idxsStr); err != nil {
-		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, fmt.Errorf("could not decode request body: %w. request body is required.", err))
+	reqIdxs, err := a.parseDutiesRequest(r)
+	if err != nil {
+		return nil, err
 	}
-	if len(idxsStr) == 0 {
-		return newBeaconResponse([]string{}).WithOptimistic(a.forkchoiceStore.IsHeadOptimistic()), nil
+	if len(reqIdxs) == 0 {
+		return newBeaconResponse([]*syncDutyResponse{}).WithOptimistic(a.forkchoiceStore.IsHeadOptimistic()), nil
 	}
 	duplicates := map[int]struct{}{}
-	// convert the request to uint64
-	idxs := make([]uint64, 0, len(idxsStr))
-	for _, idxStr := range idxsStr {
-
-		idx, err := strconv.ParseUint(idxStr, 10, 64)
-		if err != nil {
-			return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, fmt.Errorf("could not parse validator index: %w", err))
-		}
+	idxs := make([]uint64, 0, len(reqIdxs))
+	for _, idx := range reqIdxs {
 		if _, ok := duplicates[int(idx)]; ok {
 			continue
 		}
@@ -0,0 +1,55 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/erigontech/erigon/cl/beacon/beaconhttp"
+)
+
+type peerScoreResponse struct {
+	PeerId             string  `json:"peer_id"`
+	Gossip             float64 `json:"gossip"`
+	AppSpecific        float64 `json:"app_specific"`
+	IPColocationFactor float64 `json:"ip_colocation_factor"`
+	BehaviourPenalty   float64 `json:"behaviour_penalty"`
+	ReqResp            float64 `json:"req_resp"`
+	Application        float64 `json:"application"`
+}
+
+// GetCaplinPeerScores returns the score components of the sentinel peers, best first.
+func (a *ApiHandler) GetCaplinPeerScores(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	if a.peerScorer == nil {
+		return nil, beaconhttp.NewEndpointError(http.StatusServiceUnavailable, errors.New("peer scores are not available"))
+	}
+	scores := a.peerScorer.Scores()
+	resp := make([]peerScoreResponse, 0, len(scores))
+	for _, score := range scores {
+		resp = append(resp, peerScoreResponse{
+			PeerId:             score.Pid.String(),
+			Gossip:             score.Gossip,
+			AppSpecific:        score.AppSpecific(),
+			IPColocationFactor: score.IPColocationFactor,
+			BehaviourPenalty:   score.BehaviourPenalty,
+			ReqResp:            score.ReqResp,
+			Application:        score.Application,
+		})
+	}
+	return newBeaconResponse(resp), nil
+}
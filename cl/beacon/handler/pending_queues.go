@@ -0,0 +1,118 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/types/ssz"
+	"github.com/erigontech/erigon/cl/beacon/beaconhttp"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/cltypes/solid"
+	"github.com/erigontech/erigon/cl/persistence/beacon_indicies"
+	"github.com/erigontech/erigon/cl/phase1/core/state"
+)
+
+var errPreElectraState = errors.New("the requested state is before the electra fork")
+
+// pendingQueueResponse answers with one of the electra queues of the requested state. The queue is taken from the head
+// state or from forkchoice, and from the queue dumps of the historical states for the slots which forkchoice has pruned,
+// so that the full state is never reconstructed.
+func (a *ApiHandler) pendingQueueResponse(r *http.Request, bkt string, historicalQueue func() ssz.EncodableSSZ, queue func(s *state.CachingBeaconState) ssz.EncodableSSZ) (*beaconhttp.BeaconResponse, error) {
+	ctx := r.Context()
+	tx, err := a.indiciesDB.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	blockId, err := beaconhttp.StateIdFromRequest(r)
+	if err != nil {
+		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, err)
+	}
+	blockRoot, httpStatus, err := a.blockRootFromStateId(ctx, tx, blockId)
+	if err != nil {
+		return nil, beaconhttp.NewEndpointError(httpStatus, err)
+	}
+	isOptimistic := a.forkchoiceStore.IsRootOptimistic(blockRoot)
+
+	if blockId.Head() { // Lets see if we point to head, if yes then we need to look at the head state we always keep.
+		s := a.syncedData.HeadState()
+		if s == nil {
+			return nil, beaconhttp.NewEndpointError(http.StatusServiceUnavailable, errors.New("node is not synced"))
+		}
+		if s.Version() < clparams.ElectraVersion {
+			return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, errPreElectraState)
+		}
+		return newBeaconResponse(queue(s)).WithFinalized(false).WithOptimistic(isOptimistic).WithVersion(s.Version()), nil
+	}
+
+	slot, err := beacon_indicies.ReadBlockSlotByBlockRoot(tx, blockRoot)
+	if err != nil {
+		return nil, err
+	}
+	if slot == nil {
+		return nil, beaconhttp.NewEndpointError(http.StatusNotFound, fmt.Errorf("could not read block slot: %x", blockRoot))
+	}
+	version := a.beaconChainCfg.GetCurrentStateVersion(*slot / a.beaconChainCfg.SlotsPerEpoch)
+	if version < clparams.ElectraVersion {
+		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, errPreElectraState)
+	}
+
+	if *slot < a.forkchoiceStore.LowestAvaiableSlot() {
+		canonicalRoot, err := beacon_indicies.ReadCanonicalBlockRoot(tx, *slot)
+		if err != nil {
+			return nil, err
+		}
+		if canonicalRoot != blockRoot {
+			return nil, beaconhttp.NewEndpointError(http.StatusNotFound, fmt.Errorf("could not read state: %x", blockRoot))
+		}
+		out := historicalQueue()
+		if err := a.stateReader.ReadPendingQueue(tx, *slot, bkt, out); err != nil {
+			return nil, err
+		}
+		return newBeaconResponse(out).WithFinalized(true).WithOptimistic(isOptimistic).WithVersion(version), nil
+	}
+
+	s, finalized, err := a.stateAtBlockRoot(ctx, tx, blockRoot)
+	if err != nil {
+		return nil, err
+	}
+	return newBeaconResponse(queue(s)).WithFinalized(finalized).WithOptimistic(isOptimistic).WithVersion(s.Version()), nil
+}
+
+func (a *ApiHandler) GetEthV1BeaconStatesPendingDeposits(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	return a.pendingQueueResponse(r, kv.PendingDeposits, func() ssz.EncodableSSZ {
+		return solid.NewStaticListSSZ[*cltypes.PendingDeposit](int(a.beaconChainCfg.PendingDepositsLimit), 192)
+	}, func(s *state.CachingBeaconState) ssz.EncodableSSZ { return s.PendingDeposits() })
+}
+
+func (a *ApiHandler) GetEthV1BeaconStatesPendingPartialWithdrawals(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	return a.pendingQueueResponse(r, kv.PendingPartialWithdrawals, func() ssz.EncodableSSZ {
+		return solid.NewStaticListSSZ[*cltypes.PendingPartialWithdrawal](int(a.beaconChainCfg.PendingPartialWithdrawalsLimit), 24)
+	}, func(s *state.CachingBeaconState) ssz.EncodableSSZ { return s.PendingPartialWithdrawals() })
+}
+
+func (a *ApiHandler) GetEthV1BeaconStatesPendingConsolidations(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	return a.pendingQueueResponse(r, kv.PendingConsolidations, func() ssz.EncodableSSZ {
+		return solid.NewStaticListSSZ[*cltypes.PendingConsolidation](int(a.beaconChainCfg.PendingConsolidationsLimit), 16)
+	}, func(s *state.CachingBeaconState) ssz.EncodableSSZ { return s.PendingConsolidations() })
+}
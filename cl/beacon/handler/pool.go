@@ -18,13 +18,17 @@ package handler
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 
+	libcommon "github.com/erigontech/erigon-lib/common"
 	sentinel "github.com/erigontech/erigon-lib/gointerfaces/sentinelproto"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon/cl/beacon/beaconhttp"
+	"github.com/erigontech/erigon/cl/clparams"
 	"github.com/erigontech/erigon/cl/cltypes"
 	"github.com/erigontech/erigon/cl/cltypes/solid"
 	"github.com/erigontech/erigon/cl/gossip"
@@ -32,6 +36,19 @@ import (
 	"github.com/erigontech/erigon/cl/phase1/network/subnets"
 )
 
+var (
+	signedBLSToExecutionChangeSSZLength = (&cltypes.SignedBLSToExecutionChange{Message: &cltypes.BLSToExecutionChange{}}).EncodingSizeSSZ()
+	syncCommitteeMessageSSZLength       = (*cltypes.SyncCommitteeMessage)(nil).EncodingSizeSSZ()
+	signedContributionAndProofSSZLength = (&cltypes.SignedContributionAndProof{Message: &cltypes.ContributionAndProof{
+		Contribution: &cltypes.Contribution{AggregationBits: make([]byte, cltypes.SyncCommitteeAggregationBitsSize)},
+	}}).EncodingSizeSSZ()
+)
+
+// currentStateVersion is the version of the SSZ objects posted to the pools.
+func (a *ApiHandler) currentStateVersion() clparams.StateVersion {
+	return a.beaconChainCfg.GetCurrentStateVersion(a.ethClock.GetCurrentEpoch())
+}
+
 func (a *ApiHandler) GetEthV1BeaconPoolVoluntaryExits(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
 	return newBeaconResponse(a.operationsPool.VoluntaryExitsPool.Raw()), nil
 }
@@ -61,7 +78,7 @@ func (a *ApiHandler) GetEthV1BeaconPoolAttestations(w http.ResponseWriter, r *ht
 	if slot == nil && committeeIndex == nil {
 		return newBeaconResponse(atts), nil
 	}
-	ret := make([]any, 0, len(atts))
+	ret := make([]*solid.Attestation, 0, len(atts))
 	for i := range atts {
 		if slot != nil && atts[i].AttestantionData().Slot() != *slot {
 			continue
@@ -75,44 +92,81 @@ func (a *ApiHandler) GetEthV1BeaconPoolAttestations(w http.ResponseWriter, r *ht
 	return newBeaconResponse(ret), nil
 }
 
+// attestationCommitteeIndex returns the committee of an attestation, which Electra attestations carry in their
+// committee bits rather than in their data.
+func attestationCommitteeIndex(attestation *solid.Attestation) uint64 {
+	if attestation.IsElectra() {
+		if indices := attestation.CommitteeIndices(); len(indices) > 0 {
+			return indices[0]
+		}
+	}
+	return attestation.AttestantionData().CommitteeIndex()
+}
+
+// https://ethereum.github.io/beacon-APIs/#/Beacon/getPoolAttestationsV2
+func (a *ApiHandler) GetEthV2BeaconPoolAttestations(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	slot, err := beaconhttp.Uint64FromQueryParams(r, "slot")
+	if err != nil {
+		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, err)
+	}
+	committeeIndex, err := beaconhttp.Uint64FromQueryParams(r, "committee_index")
+	if err != nil {
+		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, err)
+	}
+	version := a.currentStateVersion()
+	atts := a.operationsPool.AttestationsPool.Raw()
+	ret := make([]*solid.Attestation, 0, len(atts))
+	for i := range atts {
+		// only return the attestations in the format of the current fork
+		if atts[i].IsElectra() != (version >= clparams.ElectraVersion) {
+			continue
+		}
+		if slot != nil && atts[i].AttestantionData().Slot() != *slot {
+			continue
+		}
+		if committeeIndex != nil && attestationCommitteeIndex(atts[i]) != *committeeIndex {
+			continue
+		}
+		ret = append(ret, atts[i])
+	}
+	return newBeaconResponse(ret).WithVersion(version), nil
+}
+
 func (a *ApiHandler) PostEthV1BeaconPoolAttestations(w http.ResponseWriter, r *http.Request) {
-	req := []*solid.Attestation{}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+	a.postBeaconPoolAttestations(w, r, a.currentStateVersion())
+}
+
+// https://ethereum.github.io/beacon-APIs/#/Beacon/submitPoolAttestationsV2
+func (a *ApiHandler) PostEthV2BeaconPoolAttestations(w http.ResponseWriter, r *http.Request) {
+	version, err := a.parseEthConsensusVersion(r.Header.Get(beaconhttp.EthConsensusVersionHeader), 2)
+	if err != nil {
 		beaconhttp.NewEndpointError(http.StatusBadRequest, err).WriteTo(w)
 		return
 	}
+	a.postBeaconPoolAttestations(w, r, version)
+}
 
-	headState := a.syncedData.HeadState()
-	if headState == nil {
+func (a *ApiHandler) postBeaconPoolAttestations(w http.ResponseWriter, r *http.Request, version clparams.StateVersion) {
+	req, err := beaconhttp.DecodeRequestBodyList[*solid.Attestation](r, version, 0)
+	if err != nil {
+		beaconhttp.WrapEndpointError(err).WriteTo(w)
+		return
+	}
+
+	if a.syncedData.HeadState() == nil {
 		beaconhttp.NewEndpointError(http.StatusServiceUnavailable, errors.New("head state not available")).WriteTo(w)
 		return
 	}
 	failures := []poolingFailure{}
 	for i, attestation := range req {
-		var (
-			slot                  = attestation.AttestantionData().Slot()
-			cIndex                = attestation.AttestantionData().CommitteeIndex()
-			committeeCountPerSlot = headState.CommitteeCount(slot / a.beaconChainCfg.SlotsPerEpoch)
-			subnet                = subnets.ComputeSubnetForAttestation(committeeCountPerSlot, slot, cIndex, a.beaconChainCfg.SlotsPerEpoch, a.netConfig.AttestationSubnetCount)
-		)
-		_ = i
-
-		encodedSSZ, err := attestation.EncodeSSZ(nil)
-		if err != nil {
-			beaconhttp.NewEndpointError(http.StatusInternalServerError, err).WriteTo(w)
-			return
-		}
-		attestationWithGossipData := &services.AttestationWithGossipData{
-			Attestation: attestation,
-			GossipData: &sentinel.GossipData{
-				Data:     encodedSSZ,
-				Name:     gossip.TopicNamePrefixBeaconAttestation,
-				SubnetId: &subnet,
-			},
-			ImmediateProcess: true, // we want to process attestation immediately
+		if attestation.IsElectra() != (version >= clparams.ElectraVersion) {
+			failures = append(failures, poolingFailure{
+				Index:   i,
+				Message: fmt.Sprintf("attestation format does not match the %s fork", version),
+			})
+			continue
 		}
-
-		if err := a.attestationService.ProcessMessage(r.Context(), &subnet, attestationWithGossipData); err != nil && !errors.Is(err, services.ErrIgnore) {
+		if err := a.SubmitAttestation(r.Context(), attestation); err != nil {
 			log.Warn("[Beacon REST] failed to process attestation in attestation service", "err", err)
 			failures = append(failures, poolingFailure{
 				Index:   i,
@@ -136,10 +190,41 @@ func (a *ApiHandler) PostEthV1BeaconPoolAttestations(w http.ResponseWriter, r *h
 	w.WriteHeader(http.StatusOK)
 }
 
+// SubmitAttestation validates a signed attestation, adds it to the pools and gossips it on its subnet.
+func (a *ApiHandler) SubmitAttestation(ctx context.Context, attestation *solid.Attestation) error {
+	headState := a.syncedData.HeadState()
+	if headState == nil {
+		return errors.New("head state not available")
+	}
+	var (
+		slot                  = attestation.AttestantionData().Slot()
+		cIndex                = attestationCommitteeIndex(attestation)
+		committeeCountPerSlot = headState.CommitteeCount(slot / a.beaconChainCfg.SlotsPerEpoch)
+		subnet                = subnets.ComputeSubnetForAttestation(committeeCountPerSlot, slot, cIndex, a.beaconChainCfg.SlotsPerEpoch, a.netConfig.AttestationSubnetCount)
+	)
+	encodedSSZ, err := attestation.EncodeSSZ(nil)
+	if err != nil {
+		return err
+	}
+	attestationWithGossipData := &services.AttestationWithGossipData{
+		Attestation: attestation,
+		GossipData: &sentinel.GossipData{
+			Data:     encodedSSZ,
+			Name:     gossip.TopicNamePrefixBeaconAttestation,
+			SubnetId: &subnet,
+		},
+		ImmediateProcess: true, // we want to process attestation immediately
+	}
+	if err := a.attestationService.ProcessMessage(ctx, &subnet, attestationWithGossipData); err != nil && !errors.Is(err, services.ErrIgnore) {
+		return err
+	}
+	return nil
+}
+
 func (a *ApiHandler) PostEthV1BeaconPoolVoluntaryExits(w http.ResponseWriter, r *http.Request) {
 	req := cltypes.SignedVoluntaryExit{}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := beaconhttp.DecodeRequestBody(r, &req, a.currentStateVersion()); err != nil {
+		beaconhttp.WrapEndpointError(err).WriteTo(w)
 		return
 	}
 	if err := a.voluntaryExitService.ProcessMessage(r.Context(), nil, &req); err != nil && !errors.Is(err, services.ErrIgnore) {
@@ -169,8 +254,8 @@ func (a *ApiHandler) PostEthV1BeaconPoolVoluntaryExits(w http.ResponseWriter, r
 
 func (a *ApiHandler) PostEthV1BeaconPoolAttesterSlashings(w http.ResponseWriter, r *http.Request) {
 	req := cltypes.NewAttesterSlashing()
-	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := beaconhttp.DecodeRequestBody(r, req, a.currentStateVersion()); err != nil {
+		beaconhttp.WrapEndpointError(err).WriteTo(w)
 		return
 	}
 	if err := a.forkchoiceStore.OnAttesterSlashing(req, false); err != nil {
@@ -198,8 +283,8 @@ func (a *ApiHandler) PostEthV1BeaconPoolAttesterSlashings(w http.ResponseWriter,
 
 func (a *ApiHandler) PostEthV1BeaconPoolProposerSlashings(w http.ResponseWriter, r *http.Request) {
 	req := cltypes.ProposerSlashing{}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := beaconhttp.DecodeRequestBody(r, &req, a.currentStateVersion()); err != nil {
+		beaconhttp.WrapEndpointError(err).WriteTo(w)
 		return
 	}
 	if err := a.proposerSlashingService.ProcessMessage(r.Context(), nil, &req); err != nil && !errors.Is(err, services.ErrIgnore) {
@@ -237,9 +322,9 @@ type poolingError struct {
 }
 
 func (a *ApiHandler) PostEthV1BeaconPoolBlsToExecutionChanges(w http.ResponseWriter, r *http.Request) {
-	req := []*cltypes.SignedBLSToExecutionChange{}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	req, err := beaconhttp.DecodeRequestBodyList[*cltypes.SignedBLSToExecutionChange](r, a.currentStateVersion(), signedBLSToExecutionChangeSSZLength)
+	if err != nil {
+		beaconhttp.WrapEndpointError(err).WriteTo(w)
 		return
 	}
 	failures := []poolingFailure{}
@@ -275,32 +360,15 @@ func (a *ApiHandler) PostEthV1BeaconPoolBlsToExecutionChanges(w http.ResponseWri
 }
 
 func (a *ApiHandler) PostEthV1ValidatorAggregatesAndProof(w http.ResponseWriter, r *http.Request) {
-	req := []*cltypes.SignedAggregateAndProof{}
-
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	req, err := beaconhttp.DecodeRequestBodyList[*cltypes.SignedAggregateAndProof](r, a.currentStateVersion(), 0)
+	if err != nil {
+		beaconhttp.WrapEndpointError(err).WriteTo(w)
 		return
 	}
 
 	failures := []poolingFailure{}
 	for _, v := range req {
-		encodedSSZ, err := v.EncodeSSZ(nil)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			log.Warn("[Beacon REST] failed to encode aggregate and proof", "err", err)
-			return
-		}
-		gossipData := &sentinel.GossipData{
-			Data: encodedSSZ,
-			Name: gossip.TopicNameBeaconAggregateAndProof,
-		}
-
-		// for this service we are not publishing gossipData as the service does it internally, we just pass that data as a parameter.
-		if err := a.aggregateAndProofsService.ProcessMessage(r.Context(), nil, &cltypes.SignedAggregateAndProofData{
-			SignedAggregateAndProof: v,
-			GossipData:              gossipData,
-			ImmediateProcess:        true, // we want to process aggregate and proof immediately
-		}); err != nil && !errors.Is(err, services.ErrIgnore) {
+		if err := a.SubmitAggregateAndProof(r.Context(), v); err != nil {
 			log.Warn("[Beacon REST] failed to process bls-change", "err", err)
 			failures = append(failures, poolingFailure{Index: len(failures), Message: err.Error()})
 			continue
@@ -308,12 +376,39 @@ func (a *ApiHandler) PostEthV1ValidatorAggregatesAndProof(w http.ResponseWriter,
 	}
 }
 
+// SubmitAggregateAndProof validates a signed aggregate, adds it to the pools and gossips it.
+func (a *ApiHandler) SubmitAggregateAndProof(ctx context.Context, aggregate *cltypes.SignedAggregateAndProof) error {
+	encodedSSZ, err := aggregate.EncodeSSZ(nil)
+	if err != nil {
+		return err
+	}
+	gossipData := &sentinel.GossipData{
+		Data: encodedSSZ,
+		Name: gossip.TopicNameBeaconAggregateAndProof,
+	}
+
+	// for this service we are not publishing gossipData as the service does it internally, we just pass that data as a parameter.
+	if err := a.aggregateAndProofsService.ProcessMessage(ctx, nil, &cltypes.SignedAggregateAndProofData{
+		SignedAggregateAndProof: aggregate,
+		GossipData:              gossipData,
+		ImmediateProcess:        true, // we want to process aggregate and proof immediately
+	}); err != nil && !errors.Is(err, services.ErrIgnore) {
+		return err
+	}
+	return nil
+}
+
+// GetAggregateAttestation returns the best aggregate we have for the given attestation data root, or nil.
+func (a *ApiHandler) GetAggregateAttestation(attestationDataRoot libcommon.Hash) *solid.Attestation {
+	return a.aggregatePool.GetAggregatationByRoot(attestationDataRoot)
+}
+
 // PostEthV1BeaconPoolSyncCommittees is a handler for POST /eth/v1/beacon/pool/sync_committees.
 // it receives a list of sync committee messages and adds them to the sync committee pool.
 func (a *ApiHandler) PostEthV1BeaconPoolSyncCommittees(w http.ResponseWriter, r *http.Request) {
-	msgs := []*cltypes.SyncCommitteeMessage{}
-	if err := json.NewDecoder(r.Body).Decode(&msgs); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	msgs, err := beaconhttp.DecodeRequestBodyList[*cltypes.SyncCommitteeMessage](r, a.currentStateVersion(), syncCommitteeMessageSSZLength)
+	if err != nil {
+		beaconhttp.WrapEndpointError(err).WriteTo(w)
 		return
 	}
 	s := a.syncedData.HeadState()
@@ -365,9 +460,9 @@ func (a *ApiHandler) PostEthV1BeaconPoolSyncCommittees(w http.ResponseWriter, r
 // PostEthV1ValidatorContributionsAndProofs is a handler for POST /eth/v1/validator/contributions_and_proofs.
 // it receives a list of signed contributions and proofs and adds them to the sync committee pool.
 func (a *ApiHandler) PostEthV1ValidatorContributionsAndProofs(w http.ResponseWriter, r *http.Request) {
-	msgs := []*cltypes.SignedContributionAndProof{}
-	if err := json.NewDecoder(r.Body).Decode(&msgs); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	msgs, err := beaconhttp.DecodeRequestBodyList[*cltypes.SignedContributionAndProof](r, a.currentStateVersion(), signedContributionAndProofSSZLength)
+	if err != nil {
+		beaconhttp.WrapEndpointError(err).WriteTo(w)
 		return
 	}
 	s := a.syncedData.HeadState()
@@ -376,7 +471,6 @@ func (a *ApiHandler) PostEthV1ValidatorContributionsAndProofs(w http.ResponseWri
 		return
 	}
 	failures := []poolingFailure{}
-	var err error
 	for idx, v := range msgs {
 		if bytes.Equal(v.Message.Contribution.AggregationBits, make([]byte, len(v.Message.Contribution.AggregationBits))) {
 			continue // skip empty contributions
@@ -31,6 +31,7 @@ import (
 	"github.com/erigontech/erigon/cl/cltypes/solid"
 	"github.com/erigontech/erigon/cl/persistence/beacon_indicies"
 	state_accessors "github.com/erigontech/erigon/cl/persistence/state"
+	"github.com/erigontech/erigon/cl/phase1/core/state"
 	"github.com/erigontech/erigon/cl/utils"
 )
 
@@ -172,56 +173,75 @@ func (a *ApiHandler) getStateRoot(w http.ResponseWriter, r *http.Request) (*beac
 		WithFinalized(canonicalRoot == root && *slot <= a.forkchoiceStore.FinalizedSlot()), nil
 }
 
-func (a *ApiHandler) getFullState(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
-	ctx := r.Context()
-
-	tx, err := a.indiciesDB.BeginRo(ctx)
+// stateFromRequest returns the state identified by the state_id of the request: recent states are taken from forkchoice,
+// older ones are reconstructed with the historical states reader. finalized reports where the state comes from.
+func (a *ApiHandler) stateFromRequest(ctx context.Context, tx kv.Tx, r *http.Request) (s *state.CachingBeaconState, finalized, optimistic bool, err error) {
+	blockId, err := beaconhttp.StateIdFromRequest(r)
 	if err != nil {
-		return nil, err
+		return nil, false, false, beaconhttp.NewEndpointError(http.StatusBadRequest, err)
 	}
-	defer tx.Rollback()
 
-	blockId, err := beaconhttp.StateIdFromRequest(r)
+	blockRoot, httpStatus, err := a.blockRootFromStateId(ctx, tx, blockId)
 	if err != nil {
-		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, err)
+		return nil, false, false, beaconhttp.NewEndpointError(httpStatus, err)
 	}
+	optimistic = a.forkchoiceStore.IsRootOptimistic(blockRoot)
+	s, finalized, err = a.stateAtBlockRoot(ctx, tx, blockRoot)
+	if err != nil {
+		return nil, false, false, err
+	}
+	return s, finalized, optimistic, nil
+}
 
-	blockRoot, httpStatus, err := a.blockRootFromStateId(ctx, tx, blockId)
+// stateAtBlockRoot returns the post state of a block, from forkchoice if it is recent enough and from the
+// historical states otherwise, in which case it is finalized.
+func (a *ApiHandler) stateAtBlockRoot(ctx context.Context, tx kv.Tx, blockRoot libcommon.Hash) (s *state.CachingBeaconState, finalized bool, err error) {
+	s, err = a.forkchoiceStore.GetStateAtBlockRoot(blockRoot, true)
 	if err != nil {
-		return nil, beaconhttp.NewEndpointError(httpStatus, err)
+		return nil, false, beaconhttp.NewEndpointError(http.StatusBadRequest, err)
 	}
-	isOptimistic := a.forkchoiceStore.IsRootOptimistic(blockRoot)
-	state, err := a.forkchoiceStore.GetStateAtBlockRoot(blockRoot, true)
+	if s != nil {
+		return s, false, nil
+	}
+	slot, err := beacon_indicies.ReadBlockSlotByBlockRoot(tx, blockRoot)
 	if err != nil {
-		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, err)
+		return nil, false, err
 	}
-	if state == nil {
-		slot, err := beacon_indicies.ReadBlockSlotByBlockRoot(tx, blockRoot)
-		if err != nil {
-			return nil, err
-		}
-		// Sanity checks slot and canonical data.
-		if slot == nil {
-			return nil, beaconhttp.NewEndpointError(http.StatusNotFound, fmt.Errorf("could not read block slot: %x", blockRoot))
-		}
-		canonicalRoot, err := beacon_indicies.ReadCanonicalBlockRoot(tx, *slot)
-		if err != nil {
-			return nil, err
-		}
-		if canonicalRoot != blockRoot {
-			return nil, beaconhttp.NewEndpointError(http.StatusNotFound, fmt.Errorf("could not read state: %x", blockRoot))
-		}
-		state, err := a.stateReader.ReadHistoricalState(ctx, tx, *slot)
-		if err != nil {
-			return nil, err
-		}
-		if state == nil {
-			return nil, beaconhttp.NewEndpointError(http.StatusNotFound, fmt.Errorf("could not read state: %x", blockRoot))
-		}
-		return newBeaconResponse(state).WithFinalized(true).WithVersion(state.Version()).WithOptimistic(isOptimistic), nil
+	// Sanity checks slot and canonical data.
+	if slot == nil {
+		return nil, false, beaconhttp.NewEndpointError(http.StatusNotFound, fmt.Errorf("could not read block slot: %x", blockRoot))
+	}
+	canonicalRoot, err := beacon_indicies.ReadCanonicalBlockRoot(tx, *slot)
+	if err != nil {
+		return nil, false, err
+	}
+	if canonicalRoot != blockRoot {
+		return nil, false, beaconhttp.NewEndpointError(http.StatusNotFound, fmt.Errorf("could not read state: %x", blockRoot))
+	}
+	s, err = a.stateReader.ReadHistoricalState(ctx, tx, *slot)
+	if err != nil {
+		return nil, false, err
+	}
+	if s == nil {
+		return nil, false, beaconhttp.NewEndpointError(http.StatusNotFound, fmt.Errorf("could not read state: %x", blockRoot))
 	}
+	return s, true, nil
+}
+
+func (a *ApiHandler) getFullState(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	ctx := r.Context()
 
-	return newBeaconResponse(state).WithFinalized(false).WithVersion(state.Version()).WithOptimistic(isOptimistic), nil
+	tx, err := a.indiciesDB.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	state, finalized, isOptimistic, err := a.stateFromRequest(ctx, tx, r)
+	if err != nil {
+		return nil, err
+	}
+	return newBeaconResponse(state).WithFinalized(finalized).WithVersion(state.Version()).WithOptimistic(isOptimistic), nil
 }
 
 type finalityCheckpointsResponse struct {
@@ -0,0 +1,144 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package handler
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/cl/beacon/beaconhttp"
+	"github.com/erigontech/erigon/cl/cltypes/solid"
+	"github.com/erigontech/erigon/cl/persistence/beacon_indicies"
+)
+
+const validatorIdentitySszSize = 8 + 48 + 8
+
+type validatorIdentity struct {
+	Index           uint64            `json:"index,string"`
+	Pubkey          libcommon.Bytes48 `json:"pubkey"`
+	ActivationEpoch uint64            `json:"activation_epoch,string"`
+}
+
+func (v validatorIdentity) EncodeSSZ(dst []byte) ([]byte, error) {
+	dst = binary.LittleEndian.AppendUint64(dst, v.Index)
+	dst = append(dst, v.Pubkey[:]...)
+	return binary.LittleEndian.AppendUint64(dst, v.ActivationEpoch), nil
+}
+
+func (v validatorIdentity) EncodingSizeSSZ() int {
+	return validatorIdentitySszSize
+}
+
+// https://ethereum.github.io/beacon-APIs/#/Beacon/postStateValidatorIdentities
+func (a *ApiHandler) PostEthV1BeaconStatesValidatorIdentities(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	ctx := r.Context()
+
+	tx, err := a.indiciesDB.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	blockId, err := beaconhttp.StateIdFromRequest(r)
+	if err != nil {
+		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, err)
+	}
+	blockRoot, httpStatus, err := a.blockRootFromStateId(ctx, tx, blockId)
+	if err != nil {
+		return nil, beaconhttp.NewEndpointError(httpStatus, err)
+	}
+
+	var ids []string
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&ids); err != nil {
+			return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, err)
+		}
+	}
+	if len(ids) > maxValidatorsLookupFilter {
+		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, errors.New("too many validators requested"))
+	}
+	filterIndicies, err := parseQueryValidatorIndicies(tx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	isOptimistic := a.forkchoiceStore.IsRootOptimistic(blockRoot)
+	if blockId.Head() { // Lets see if we point to head, if yes then we need to look at the head state we always keep.
+		s := a.syncedData.HeadState()
+		if s == nil {
+			return nil, beaconhttp.NewEndpointError(http.StatusServiceUnavailable, errors.New("node is not synced"))
+		}
+		return responseValidatorIdentities(filterIndicies, s.Validators(), false, isOptimistic), nil
+	}
+
+	slot, err := beacon_indicies.ReadBlockSlotByBlockRoot(tx, blockRoot)
+	if err != nil {
+		return nil, err
+	}
+	if slot == nil {
+		return nil, beaconhttp.NewEndpointError(http.StatusNotFound, errors.New("state not found"))
+	}
+	if *slot < a.forkchoiceStore.LowestAvaiableSlot() {
+		validatorSet, err := a.stateReader.ReadValidatorsForHistoricalState(tx, *slot)
+		if err != nil {
+			return nil, err
+		}
+		if validatorSet == nil {
+			return nil, beaconhttp.NewEndpointError(http.StatusNotFound, fmt.Errorf("state not found for slot %v", *slot))
+		}
+		return responseValidatorIdentities(filterIndicies, validatorSet, true, isOptimistic), nil
+	}
+	validators, err := a.forkchoiceStore.GetValidatorSet(blockRoot)
+	if err != nil {
+		return nil, err
+	}
+	if validators == nil {
+		return nil, beaconhttp.NewEndpointError(http.StatusNotFound, errors.New("validators not found"))
+	}
+	return responseValidatorIdentities(filterIndicies, validators, *slot <= a.forkchoiceStore.FinalizedSlot(), isOptimistic), nil
+}
+
+// responseValidatorIdentities lists the identities of the filtered validators, or of all of them if there is no filter.
+// Unknown validators are skipped.
+func responseValidatorIdentities(filterIndicies []uint64, validators *solid.ValidatorSet, finalized, optimistic bool) *beaconhttp.BeaconResponse {
+	identity := func(idx uint64) validatorIdentity {
+		v := validators.Get(int(idx))
+		return validatorIdentity{
+			Index:           idx,
+			Pubkey:          v.PublicKey(),
+			ActivationEpoch: v.ActivationEpoch(),
+		}
+	}
+	identities := []validatorIdentity{}
+	if len(filterIndicies) == 0 {
+		for idx := 0; idx < validators.Length(); idx++ {
+			identities = append(identities, identity(uint64(idx)))
+		}
+	}
+	for _, idx := range filterIndicies {
+		if idx == math.MaxUint64 || idx >= uint64(validators.Length()) {
+			continue
+		}
+		identities = append(identities, identity(idx))
+	}
+	return newBeaconResponse(identities).WithFinalized(finalized).WithOptimistic(optimistic)
+}
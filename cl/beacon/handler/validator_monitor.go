@@ -0,0 +1,91 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/erigontech/erigon/cl/beacon/beaconhttp"
+)
+
+func validatorIndexFromRequest(r *http.Request) (uint64, error) {
+	indexStr, err := beaconhttp.StringFromRequest(r, "index")
+	if err != nil {
+		return 0, beaconhttp.NewEndpointError(http.StatusBadRequest, err)
+	}
+	index, err := strconv.ParseUint(indexStr, 10, 64)
+	if err != nil {
+		return 0, beaconhttp.NewEndpointError(http.StatusBadRequest, fmt.Errorf("could not parse validator index: %w", err))
+	}
+	return index, nil
+}
+
+// GetCaplinValidatorMonitor lists the indicies of the validators observed by the validator monitor.
+func (a *ApiHandler) GetCaplinValidatorMonitor(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	observed := a.validatorsMonitor.ObservedValidators()
+	resp := make([]string, 0, len(observed))
+	for _, idx := range observed {
+		resp = append(resp, strconv.FormatUint(idx, 10))
+	}
+	return newBeaconResponse(resp), nil
+}
+
+// PostCaplinValidatorMonitor adds the given validator indicies to the validator monitor.
+func (a *ApiHandler) PostCaplinValidatorMonitor(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	var idxsStr []string
+	if err := json.NewDecoder(r.Body).Decode(&idxsStr); err != nil {
+		return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, fmt.Errorf("could not decode request body: %w. request body is required.", err))
+	}
+	idxs := make([]uint64, 0, len(idxsStr))
+	for _, idxStr := range idxsStr {
+		idx, err := strconv.ParseUint(idxStr, 10, 64)
+		if err != nil {
+			return nil, beaconhttp.NewEndpointError(http.StatusBadRequest, fmt.Errorf("could not parse validator index: %w", err))
+		}
+		idxs = append(idxs, idx)
+	}
+	for _, idx := range idxs {
+		a.validatorsMonitor.ObserveValidator(idx)
+	}
+	return newBeaconResponse(nil), nil
+}
+
+// GetCaplinValidatorMonitorValidator returns the performance of an observed validator over the last epochs.
+func (a *ApiHandler) GetCaplinValidatorMonitorValidator(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	index, err := validatorIndexFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	summary, ok := a.validatorsMonitor.ValidatorSummary(index)
+	if !ok {
+		return nil, beaconhttp.NewEndpointError(http.StatusNotFound, fmt.Errorf("validator %d is not observed by the validator monitor", index))
+	}
+	return newBeaconResponse(summary), nil
+}
+
+// DeleteCaplinValidatorMonitorValidator stops observing a validator.
+func (a *ApiHandler) DeleteCaplinValidatorMonitorValidator(w http.ResponseWriter, r *http.Request) (*beaconhttp.BeaconResponse, error) {
+	index, err := validatorIndexFromRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	a.validatorsMonitor.RemoveValidator(index)
+	return newBeaconResponse(nil), nil
+}
@@ -52,6 +52,23 @@ type CaplinConfig struct {
 	MevRelayUrl string
 	// EnableValidatorMonitor is used to enable the validator monitor metrics and corresponding logs
 	EnableValidatorMonitor bool
+	// EnableValidatorClient runs the embedded validator client with the keystores in ValidatorKeystoresDir
+	EnableValidatorClient bool
+	ValidatorKeystoresDir string
+	ValidatorPasswordFile string
+	ValidatorGraffiti     string
+	// SlashingProtectionImportFile and SlashingProtectionExportFile are optional EIP-3076 interchange files,
+	// imported at startup and exported at shutdown respectively
+	SlashingProtectionImportFile string
+	SlashingProtectionExportFile string
+	// ForkChoiceRecordFile is optional and is the file where every input of the forkchoice store is recorded,
+	// the log can be replayed offline with `caplin replay-forkchoice`
+	ForkChoiceRecordFile string
+	// EraDir is optional and is the directory of .era files which the beacon chain history is imported from, in place
+	// of downloading it from peers
+	EraDir string
+	// HistoricalStatesCacheSize is the amount of reconstructed historical states kept in memory, 0 disables the cache
+	HistoricalStatesCacheSize int
 
 	// Devnets config
 	CustomConfigPath       string
@@ -536,6 +553,7 @@ type BeaconChainConfig struct {
 	WhiskProposerSelectionGap    uint64 `yaml:"WHISK_PROPOSER_SELECTION_GAP" spec:"true" json:"WHISK_PROPOSER_SELECTION_GAP,string"`         // WhiskProposerSelectionGap defines the proposer selection gap.
 
 	// EIP7594
+	Eip7594ForkEpoch             uint64 `yaml:"EIP7594_FORK_EPOCH" spec:"true" json:"EIP7594_FORK_EPOCH,string"`                             // Eip7594ForkEpoch is the epoch from which data availability is sampled through data columns.
 	NumberOfColumns              uint64 `yaml:"NUMBER_OF_COLUMNS" spec:"true" json:"NUMBER_OF_COLUMNS,string"`                               // NumberOfColumns defines the number of columns in the extended matrix.
 	MaxCellsInExtendedMatrix     uint64 `yaml:"MAX_CELLS_IN_EXTENDED_MATRIX" spec:"true" json:"MAX_CELLS_IN_EXTENDED_MATRIX,string"`         // MaxCellsInExtendedMatrix defines the maximum number of cells in the extended matrix.
 	DataColumnSidecarSubnetCount uint64 `yaml:"DATA_COLUMN_SIDECAR_SUBNET_COUNT" spec:"true" json:"DATA_COLUMN_SIDECAR_SUBNET_COUNT,string"` // DataColumnSidecarSubnetCount defines the number of sidecars in the data column subnet.
@@ -545,8 +563,25 @@ type BeaconChainConfig struct {
 	TargetNumberOfPeers          uint64 `yaml:"TARGET_NUMBER_OF_PEERS" spec:"true" json:"TARGET_NUMBER_OF_PEERS,string"`                     // TargetNumberOfPeers defines the target number of peers.
 
 	// Electra
-	MinPerEpochChurnLimitElectra        uint64 `yaml:"MIN_PER_EPOCH_CHURN_LIMIT_ELECTRA" spec:"true" json:"MIN_PER_EPOCH_CHURN_LIMIT_ELECTRA,string"`                 // MinPerEpochChurnLimitElectra defines the minimum per epoch churn limit for Electra.
-	MaxPerEpochActivationExitChurnLimit uint64 `yaml:"MAX_PER_EPOCH_ACTIVATION_EXIT_CHURN_LIMIT" spec:"true" json:"MAX_PER_EPOCH_ACTIVATION_EXIT_CHURN_LIMIT,string"` // MaxPerEpochActivationExitChurnLimit defines the maximum per epoch activation exit churn limit for Electra.
+	MinPerEpochChurnLimitElectra          uint64     `yaml:"MIN_PER_EPOCH_CHURN_LIMIT_ELECTRA" spec:"true" json:"MIN_PER_EPOCH_CHURN_LIMIT_ELECTRA,string"`                   // MinPerEpochChurnLimitElectra defines the minimum per epoch churn limit for Electra.
+	MaxPerEpochActivationExitChurnLimit   uint64     `yaml:"MAX_PER_EPOCH_ACTIVATION_EXIT_CHURN_LIMIT" spec:"true" json:"MAX_PER_EPOCH_ACTIVATION_EXIT_CHURN_LIMIT,string"`   // MaxPerEpochActivationExitChurnLimit defines the maximum per epoch activation exit churn limit for Electra.
+	MinActivationBalance                  uint64     `yaml:"MIN_ACTIVATION_BALANCE" spec:"true" json:"MIN_ACTIVATION_BALANCE,string"`                                         // MinActivationBalance defines the minimum balance a validator needs to be activated.
+	MaxEffectiveBalanceElectra            uint64     `yaml:"MAX_EFFECTIVE_BALANCE_ELECTRA" spec:"true" json:"MAX_EFFECTIVE_BALANCE_ELECTRA,string"`                           // MaxEffectiveBalanceElectra is the maximal effective balance of compounding validators.
+	MinSlashingPenaltyQuotientElectra     uint64     `yaml:"MIN_SLASHING_PENALTY_QUOTIENT_ELECTRA" spec:"true" json:"MIN_SLASHING_PENALTY_QUOTIENT_ELECTRA,string"`           // MinSlashingPenaltyQuotientElectra for slashing penalties post Electra hard fork.
+	WhistleBlowerRewardQuotientElectra    uint64     `yaml:"WHISTLEBLOWER_REWARD_QUOTIENT_ELECTRA" spec:"true" json:"WHISTLEBLOWER_REWARD_QUOTIENT_ELECTRA,string"`           // WhistleBlowerRewardQuotientElectra is used to calculate whistle blower reward post Electra hard fork.
+	PendingDepositsLimit                  uint64     `yaml:"PENDING_DEPOSITS_LIMIT" spec:"true" json:"PENDING_DEPOSITS_LIMIT,string"`                                         // PendingDepositsLimit is the maximum length of the pending deposits queue.
+	PendingPartialWithdrawalsLimit        uint64     `yaml:"PENDING_PARTIAL_WITHDRAWALS_LIMIT" spec:"true" json:"PENDING_PARTIAL_WITHDRAWALS_LIMIT,string"`                   // PendingPartialWithdrawalsLimit is the maximum length of the pending partial withdrawals queue.
+	PendingConsolidationsLimit            uint64     `yaml:"PENDING_CONSOLIDATIONS_LIMIT" spec:"true" json:"PENDING_CONSOLIDATIONS_LIMIT,string"`                             // PendingConsolidationsLimit is the maximum length of the pending consolidations queue.
+	MaxAttesterSlashingsElectra           uint64     `yaml:"MAX_ATTESTER_SLASHINGS_ELECTRA" spec:"true" json:"MAX_ATTESTER_SLASHINGS_ELECTRA,string"`                         // MaxAttesterSlashingsElectra defines the maximum number of attester slashings in a block post Electra.
+	MaxAttestationsElectra                uint64     `yaml:"MAX_ATTESTATIONS_ELECTRA" spec:"true" json:"MAX_ATTESTATIONS_ELECTRA,string"`                                     // MaxAttestationsElectra defines the maximum number of attestations in a block post Electra.
+	MaxDepositRequestsPerPayload          uint64     `yaml:"MAX_DEPOSIT_REQUESTS_PER_PAYLOAD" spec:"true" json:"MAX_DEPOSIT_REQUESTS_PER_PAYLOAD,string"`                     // MaxDepositRequestsPerPayload defines the maximum number of deposit requests in a block.
+	MaxWithdrawalRequestsPerPayload       uint64     `yaml:"MAX_WITHDRAWAL_REQUESTS_PER_PAYLOAD" spec:"true" json:"MAX_WITHDRAWAL_REQUESTS_PER_PAYLOAD,string"`               // MaxWithdrawalRequestsPerPayload defines the maximum number of execution layer withdrawal requests in a block.
+	MaxConsolidationRequestsPerPayload    uint64     `yaml:"MAX_CONSOLIDATION_REQUESTS_PER_PAYLOAD" spec:"true" json:"MAX_CONSOLIDATION_REQUESTS_PER_PAYLOAD,string"`         // MaxConsolidationRequestsPerPayload defines the maximum number of consolidation requests in a block.
+	MaxPendingPartialsPerWithdrawalsSweep uint64     `yaml:"MAX_PENDING_PARTIALS_PER_WITHDRAWALS_SWEEP" spec:"true" json:"MAX_PENDING_PARTIALS_PER_WITHDRAWALS_SWEEP,string"` // MaxPendingPartialsPerWithdrawalsSweep bounds the number of pending partial withdrawals processed per block.
+	MaxPendingDepositsPerEpoch            uint64     `yaml:"MAX_PENDING_DEPOSITS_PER_EPOCH" spec:"true" json:"MAX_PENDING_DEPOSITS_PER_EPOCH,string"`                         // MaxPendingDepositsPerEpoch bounds the number of pending deposits processed per epoch.
+	CompoundingWithdrawalPrefixByte       ConfigByte `yaml:"COMPOUNDING_WITHDRAWAL_PREFIX" spec:"true" json:"COMPOUNDING_WITHDRAWAL_PREFIX"`                                  // CompoundingWithdrawalPrefixByte is used for compounding withdrawal credentials.
+	FullExitRequestAmount                 uint64     `yaml:"FULL_EXIT_REQUEST_AMOUNT" spec:"true" json:"FULL_EXIT_REQUEST_AMOUNT,string"`                                     // FullExitRequestAmount is the amount of a withdrawal request which asks for a full exit.
+	UnsetDepositRequestsStartIndex        uint64     `yaml:"UNSET_DEPOSIT_REQUESTS_START_INDEX" spec:"true" json:"UNSET_DEPOSIT_REQUESTS_START_INDEX,string"`                 // UnsetDepositRequestsStartIndex is the initial value of deposit_requests_start_index.
 }
 
 func (b *BeaconChainConfig) RoundSlotToEpoch(slot uint64) uint64 {
@@ -568,7 +603,7 @@ func (b *BeaconChainConfig) RoundSlotToVotePeriod(slot uint64) uint64 {
 }
 
 func (b *BeaconChainConfig) GetCurrentStateVersion(epoch uint64) StateVersion {
-	forkEpochList := []uint64{b.AltairForkEpoch, b.BellatrixForkEpoch, b.CapellaForkEpoch, b.DenebForkEpoch}
+	forkEpochList := []uint64{b.AltairForkEpoch, b.BellatrixForkEpoch, b.CapellaForkEpoch, b.DenebForkEpoch, b.ElectraForkEpoch}
 	stateVersion := Phase0Version
 	for _, forkEpoch := range forkEpochList {
 		if forkEpoch > epoch {
@@ -783,6 +818,7 @@ var MainnetBeaconConfig BeaconChainConfig = BeaconChainConfig{
 	WhiskEpochsPerShufflingPhase: 256,
 	WhiskProposerSelectionGap:    2,
 
+	Eip7594ForkEpoch:             math.MaxUint64,
 	NumberOfColumns:              128,
 	MaxCellsInExtendedMatrix:     768,
 	DataColumnSidecarSubnetCount: 32,
@@ -791,8 +827,25 @@ var MainnetBeaconConfig BeaconChainConfig = BeaconChainConfig{
 	CustodyRequirement:           1,
 	TargetNumberOfPeers:          70,
 
-	MinPerEpochChurnLimitElectra:        128000000000,
-	MaxPerEpochActivationExitChurnLimit: 256000000000,
+	MinPerEpochChurnLimitElectra:          128000000000,
+	MaxPerEpochActivationExitChurnLimit:   256000000000,
+	MinActivationBalance:                  32 * 1e9,
+	MaxEffectiveBalanceElectra:            2048 * 1e9,
+	MinSlashingPenaltyQuotientElectra:     4096,
+	WhistleBlowerRewardQuotientElectra:    4096,
+	PendingDepositsLimit:                  1 << 27,
+	PendingPartialWithdrawalsLimit:        1 << 27,
+	PendingConsolidationsLimit:            1 << 18,
+	MaxAttesterSlashingsElectra:           1,
+	MaxAttestationsElectra:                8,
+	MaxDepositRequestsPerPayload:          8192,
+	MaxWithdrawalRequestsPerPayload:       16,
+	MaxConsolidationRequestsPerPayload:    2,
+	MaxPendingPartialsPerWithdrawalsSweep: 8,
+	MaxPendingDepositsPerEpoch:            16,
+	CompoundingWithdrawalPrefixByte:       ConfigByte(2),
+	FullExitRequestAmount:                 0,
+	UnsetDepositRequestsStartIndex:        math.MaxUint64,
 }
 
 func mainnetConfig() BeaconChainConfig {
@@ -954,11 +1007,20 @@ func (b *BeaconChainConfig) GetMinSlashingPenaltyQuotient(version StateVersion)
 		return b.MinSlashingPenaltyQuotientBellatrix
 	case DenebVersion:
 		return b.MinSlashingPenaltyQuotientBellatrix
+	case ElectraVersion:
+		return b.MinSlashingPenaltyQuotientElectra
 	default:
 		panic("not implemented")
 	}
 }
 
+func (b *BeaconChainConfig) GetWhistleBlowerRewardQuotient(version StateVersion) uint64 {
+	if version >= ElectraVersion {
+		return b.WhistleBlowerRewardQuotientElectra
+	}
+	return b.WhistleBlowerRewardQuotient
+}
+
 func (b *BeaconChainConfig) GetPenaltyQuotient(version StateVersion) uint64 {
 	switch version {
 	case Phase0Version:
@@ -971,6 +1033,8 @@ func (b *BeaconChainConfig) GetPenaltyQuotient(version StateVersion) uint64 {
 		return b.InactivityPenaltyQuotientBellatrix
 	case DenebVersion:
 		return b.InactivityPenaltyQuotientBellatrix
+	case ElectraVersion:
+		return b.InactivityPenaltyQuotientBellatrix
 	default:
 		panic("not implemented")
 	}
@@ -1041,6 +1105,11 @@ func (b *BeaconChainConfig) GetForkEpochByVersion(v StateVersion) uint64 {
 	panic("invalid version")
 }
 
+// IsPeerDASEpoch returns whether blobs are made available through data column sidecars (EIP-7594) at the given epoch.
+func (b *BeaconChainConfig) IsPeerDASEpoch(epoch uint64) bool {
+	return epoch >= b.Eip7594ForkEpoch
+}
+
 func GetConfigsByNetwork(net NetworkType) (*NetworkConfig, *BeaconChainConfig) {
 	networkConfig := NetworkConfigs[net]
 	beaconConfig := BeaconConfigs[net]
@@ -47,8 +47,26 @@ const (
 	MaxVoluntaryExits            = 16
 	MaxExecutionChanges          = 16
 	MaxBlobsCommittmentsPerBlock = 4096
+
+	MaxAttesterSlashingsElectra = 1
+	MaxAttestationsElectra      = 8
 )
 
+// attesterSlashingsLimit and attestationsLimit return the block body list limits, reduced by EIP-7549 in Electra.
+func attesterSlashingsLimit(version clparams.StateVersion) int {
+	if version >= clparams.ElectraVersion {
+		return MaxAttesterSlashingsElectra
+	}
+	return MaxAttesterSlashings
+}
+
+func attestationsLimit(version clparams.StateVersion) int {
+	if version >= clparams.ElectraVersion {
+		return MaxAttestationsElectra
+	}
+	return MaxAttestations
+}
+
 var (
 	_ GenericBeaconBlock = (*BeaconBlock)(nil)
 	_ GenericBeaconBlock = (*DenebBeaconBlock)(nil)
@@ -224,6 +242,8 @@ type BeaconBody struct {
 	// The commitments for beacon chain blobs
 	// With a max of 4 per block
 	BlobKzgCommitments *solid.ListSSZ[*KZGCommitment] `json:"blob_kzg_commitments,omitempty"`
+	// Deposits, withdrawals and consolidations requested by the execution layer (Electra)
+	ExecutionRequests *ExecutionRequests `json:"execution_requests,omitempty"`
 	// The version of the beacon chain
 	Version   clparams.StateVersion `json:"-"`
 	beaconCfg *clparams.BeaconChainConfig
@@ -246,6 +266,29 @@ func NewBeaconBody(beaconCfg *clparams.BeaconChainConfig) *BeaconBody {
 func (b *BeaconBody) SetVersion(version clparams.StateVersion) {
 	b.Version = version
 	b.ExecutionPayload.SetVersion(version)
+	if version >= clparams.ElectraVersion {
+		b.AttesterSlashings = resizeListSSZ(b.AttesterSlashings, attesterSlashingsLimit(version))
+		b.Attestations = resizeListSSZ(b.Attestations, attestationsLimit(version))
+		if b.ExecutionRequests == nil {
+			b.ExecutionRequests = NewExecutionRequests(b.beaconCfg)
+		}
+	}
+}
+
+// resizeListSSZ copies the elements of a dynamic list into a list with a different limit.
+func resizeListSSZ[T interface {
+	ssz.EncodableSSZ
+	ssz.HashableSSZ
+}](l *solid.ListSSZ[T], limit int) *solid.ListSSZ[T] {
+	resized := solid.NewDynamicListSSZ[T](limit)
+	if l == nil {
+		return resized
+	}
+	l.Range(func(_ int, value T, _ int) bool {
+		resized.Append(value)
+		return true
+	})
+	return resized
 }
 
 func (b *BeaconBody) EncodeSSZ(dst []byte) ([]byte, error) {
@@ -265,10 +308,10 @@ func (b *BeaconBody) EncodingSizeSSZ() (size int) {
 		b.ProposerSlashings = solid.NewStaticListSSZ[*ProposerSlashing](MaxProposerSlashings, 416)
 	}
 	if b.AttesterSlashings == nil {
-		b.AttesterSlashings = solid.NewDynamicListSSZ[*AttesterSlashing](MaxAttesterSlashings)
+		b.AttesterSlashings = solid.NewDynamicListSSZ[*AttesterSlashing](attesterSlashingsLimit(b.Version))
 	}
 	if b.Attestations == nil {
-		b.Attestations = solid.NewDynamicListSSZ[*solid.Attestation](MaxAttestations)
+		b.Attestations = solid.NewDynamicListSSZ[*solid.Attestation](attestationsLimit(b.Version))
 	}
 	if b.Deposits == nil {
 		b.Deposits = solid.NewStaticListSSZ[*Deposit](MaxDeposits, 1240)
@@ -285,6 +328,9 @@ func (b *BeaconBody) EncodingSizeSSZ() (size int) {
 	if b.BlobKzgCommitments == nil {
 		b.BlobKzgCommitments = solid.NewStaticListSSZ[*KZGCommitment](MaxBlobsCommittmentsPerBlock, 48)
 	}
+	if b.ExecutionRequests == nil && b.Version >= clparams.ElectraVersion {
+		b.ExecutionRequests = NewExecutionRequests(b.beaconCfg)
+	}
 
 	size += b.ProposerSlashings.EncodingSizeSSZ()
 	size += b.AttesterSlashings.EncodingSizeSSZ()
@@ -300,6 +346,9 @@ func (b *BeaconBody) EncodingSizeSSZ() (size int) {
 	if b.Version >= clparams.DenebVersion {
 		size += b.ExecutionChanges.EncodingSizeSSZ()
 	}
+	if b.Version >= clparams.ElectraVersion {
+		size += b.ExecutionRequests.EncodingSizeSSZ()
+	}
 
 	return
 }
@@ -312,6 +361,8 @@ func (b *BeaconBody) DecodeSSZ(buf []byte, version int) error {
 	}
 
 	b.ExecutionPayload = NewEth1Block(b.Version, b.beaconCfg)
+	b.AttesterSlashings = solid.NewDynamicListSSZ[*AttesterSlashing](attesterSlashingsLimit(b.Version))
+	b.Attestations = solid.NewDynamicListSSZ[*solid.Attestation](attestationsLimit(b.Version))
 
 	err := ssz2.UnmarshalSSZ(buf, version, b.getSchema(false)...)
 	return err
@@ -335,6 +386,7 @@ func (b *BeaconBody) Blinded() (*BlindedBeaconBody, error) {
 		ExecutionPayload:   header,
 		ExecutionChanges:   b.ExecutionChanges,
 		BlobKzgCommitments: b.BlobKzgCommitments,
+		ExecutionRequests:  b.ExecutionRequests,
 		Version:            b.Version,
 		beaconCfg:          b.beaconCfg,
 	}, nil
@@ -358,6 +410,9 @@ func (b *BeaconBody) getSchema(storage bool) []interface{} {
 	if b.Version >= clparams.DenebVersion {
 		s = append(s, b.BlobKzgCommitments)
 	}
+	if b.Version >= clparams.ElectraVersion {
+		s = append(s, b.ExecutionRequests)
+	}
 	return s
 }
 
@@ -372,7 +427,7 @@ func (b *BeaconBody) KzgCommitmentMerkleProof(index int) ([][32]byte, error) {
 	if index >= b.BlobKzgCommitments.Len() {
 		return nil, errors.New("index out of range")
 	}
-	kzgCommitmentsProof, err := merkle_tree.MerkleProof(4, 11, b.getSchema(false)...)
+	kzgCommitmentsProof, err := b.KzgCommitmentsMerkleProof()
 	if err != nil {
 		return nil, err
 	}
@@ -380,6 +435,11 @@ func (b *BeaconBody) KzgCommitmentMerkleProof(index int) ([][32]byte, error) {
 	return append(branch, kzgCommitmentsProof...), nil
 }
 
+// KzgCommitmentsMerkleProof is the proof of the whole list of kzg commitments, which data column sidecars carry.
+func (b *BeaconBody) KzgCommitmentsMerkleProof() ([][32]byte, error) {
+	return merkle_tree.MerkleProof(4, 11, b.getSchema(false)...)
+}
+
 func (b *BeaconBody) UnmarshalJSON(buf []byte) error {
 	var tmp struct {
 		RandaoReveal       libcommon.Bytes96                           `json:"randao_reveal"`
@@ -394,10 +454,11 @@ func (b *BeaconBody) UnmarshalJSON(buf []byte) error {
 		ExecutionPayload   *Eth1Block                                  `json:"execution_payload,omitempty"`
 		ExecutionChanges   *solid.ListSSZ[*SignedBLSToExecutionChange] `json:"bls_to_execution_changes,omitempty"`
 		BlobKzgCommitments *solid.ListSSZ[*KZGCommitment]              `json:"blob_kzg_commitments,omitempty"`
+		ExecutionRequests  json.RawMessage                             `json:"execution_requests,omitempty"`
 	}
 	tmp.ProposerSlashings = solid.NewStaticListSSZ[*ProposerSlashing](MaxProposerSlashings, 416)
-	tmp.AttesterSlashings = solid.NewDynamicListSSZ[*AttesterSlashing](MaxAttesterSlashings)
-	tmp.Attestations = solid.NewDynamicListSSZ[*solid.Attestation](MaxAttestations)
+	tmp.AttesterSlashings = solid.NewDynamicListSSZ[*AttesterSlashing](attesterSlashingsLimit(b.Version))
+	tmp.Attestations = solid.NewDynamicListSSZ[*solid.Attestation](attestationsLimit(b.Version))
 	tmp.Deposits = solid.NewStaticListSSZ[*Deposit](MaxDeposits, 1240)
 	tmp.VoluntaryExits = solid.NewStaticListSSZ[*SignedVoluntaryExit](MaxVoluntaryExits, 112)
 	tmp.ExecutionChanges = solid.NewStaticListSSZ[*SignedBLSToExecutionChange](MaxExecutionChanges, 172)
@@ -419,6 +480,12 @@ func (b *BeaconBody) UnmarshalJSON(buf []byte) error {
 	b.ExecutionPayload = tmp.ExecutionPayload
 	b.ExecutionChanges = tmp.ExecutionChanges
 	b.BlobKzgCommitments = tmp.BlobKzgCommitments
+	if len(tmp.ExecutionRequests) > 0 {
+		b.ExecutionRequests = NewExecutionRequests(b.beaconCfg)
+		if err := json.Unmarshal(tmp.ExecutionRequests, b.ExecutionRequests); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -466,6 +533,10 @@ func (b *BeaconBody) GetExecutionChanges() *solid.ListSSZ[*SignedBLSToExecutionC
 	return b.ExecutionChanges
 }
 
+func (b *BeaconBody) GetExecutionRequests() *ExecutionRequests {
+	return b.ExecutionRequests
+}
+
 type DenebBeaconBlock struct {
 	Block     *BeaconBlock              `json:"block"`
 	KZGProofs *solid.ListSSZ[*KZGProof] `json:"kzg_proofs"`
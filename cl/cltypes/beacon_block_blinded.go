@@ -224,6 +224,8 @@ type BlindedBeaconBody struct {
 	// The commitments for beacon chain blobs
 	// With a max of 4 per block
 	BlobKzgCommitments *solid.ListSSZ[*KZGCommitment] `json:"blob_kzg_commitments"`
+	// Deposits, withdrawals and consolidations requested by the execution layer (Electra)
+	ExecutionRequests *ExecutionRequests `json:"execution_requests,omitempty"`
 	// The version of the beacon chain
 	Version   clparams.StateVersion `json:"-"`
 	beaconCfg *clparams.BeaconChainConfig
@@ -254,6 +256,13 @@ func (b *BlindedBeaconBody) SetVersion(version clparams.StateVersion) *BlindedBe
 	} else {
 		b.ExecutionPayload.SetVersion(version)
 	}
+	if version >= clparams.ElectraVersion {
+		b.AttesterSlashings = resizeListSSZ(b.AttesterSlashings, attesterSlashingsLimit(version))
+		b.Attestations = resizeListSSZ(b.Attestations, attestationsLimit(version))
+		if b.ExecutionRequests == nil {
+			b.ExecutionRequests = NewExecutionRequests(b.beaconCfg)
+		}
+	}
 	return b
 }
 
@@ -276,10 +285,10 @@ func (b *BlindedBeaconBody) EncodingSizeSSZ() (size int) {
 		b.ProposerSlashings = solid.NewStaticListSSZ[*ProposerSlashing](MaxProposerSlashings, 416)
 	}
 	if b.AttesterSlashings == nil {
-		b.AttesterSlashings = solid.NewDynamicListSSZ[*AttesterSlashing](MaxAttesterSlashings)
+		b.AttesterSlashings = solid.NewDynamicListSSZ[*AttesterSlashing](attesterSlashingsLimit(b.Version))
 	}
 	if b.Attestations == nil {
-		b.Attestations = solid.NewDynamicListSSZ[*solid.Attestation](MaxAttestations)
+		b.Attestations = solid.NewDynamicListSSZ[*solid.Attestation](attestationsLimit(b.Version))
 	}
 	if b.Deposits == nil {
 		b.Deposits = solid.NewStaticListSSZ[*Deposit](MaxDeposits, 1240)
@@ -296,6 +305,9 @@ func (b *BlindedBeaconBody) EncodingSizeSSZ() (size int) {
 	if b.BlobKzgCommitments == nil {
 		b.BlobKzgCommitments = solid.NewStaticListSSZ[*KZGCommitment](MaxBlobsCommittmentsPerBlock, 48)
 	}
+	if b.ExecutionRequests == nil && b.Version >= clparams.ElectraVersion {
+		b.ExecutionRequests = NewExecutionRequests(b.beaconCfg)
+	}
 
 	size += b.ProposerSlashings.EncodingSizeSSZ()
 	size += b.AttesterSlashings.EncodingSizeSSZ()
@@ -311,6 +323,9 @@ func (b *BlindedBeaconBody) EncodingSizeSSZ() (size int) {
 	if b.Version >= clparams.DenebVersion {
 		size += b.ExecutionChanges.EncodingSizeSSZ()
 	}
+	if b.Version >= clparams.ElectraVersion {
+		size += b.ExecutionRequests.EncodingSizeSSZ()
+	}
 
 	return
 }
@@ -323,6 +338,8 @@ func (b *BlindedBeaconBody) DecodeSSZ(buf []byte, version int) error {
 	}
 
 	b.ExecutionPayload = NewEth1Header(b.Version)
+	b.AttesterSlashings = solid.NewDynamicListSSZ[*AttesterSlashing](attesterSlashingsLimit(b.Version))
+	b.Attestations = solid.NewDynamicListSSZ[*solid.Attestation](attestationsLimit(b.Version))
 
 	err := ssz2.UnmarshalSSZ(buf, version, b.getSchema(false)...)
 	return err
@@ -346,6 +363,9 @@ func (b *BlindedBeaconBody) getSchema(storage bool) []interface{} {
 	if b.Version >= clparams.DenebVersion {
 		s = append(s, b.BlobKzgCommitments)
 	}
+	if b.Version >= clparams.ElectraVersion {
+		s = append(s, b.ExecutionRequests)
+	}
 	return s
 }
 
@@ -396,6 +416,7 @@ func (b *BlindedBeaconBody) Full(txs *solid.TransactionsSSZ, withdrawals *solid.
 		ExecutionPayload:   executionPayload,
 		ExecutionChanges:   b.ExecutionChanges,
 		BlobKzgCommitments: b.BlobKzgCommitments,
+		ExecutionRequests:  b.ExecutionRequests,
 		Version:            b.Version,
 		beaconCfg:          b.beaconCfg,
 	}
@@ -455,3 +476,7 @@ func (b *BlindedBeaconBody) GetBlobKzgCommitments() *solid.ListSSZ[*KZGCommitmen
 func (b *BlindedBeaconBody) GetExecutionChanges() *solid.ListSSZ[*SignedBLSToExecutionChange] {
 	return b.ExecutionChanges
 }
+
+func (b *BlindedBeaconBody) GetExecutionRequests() *ExecutionRequests {
+	return b.ExecutionRequests
+}
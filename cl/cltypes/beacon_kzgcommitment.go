@@ -20,7 +20,7 @@ import (
 	"encoding/json"
 	"reflect"
 
-	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	goethkzg "github.com/crate-crypto/go-eth-kzg"
 	libcommon "github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/hexutility"
 	"github.com/erigontech/erigon-lib/types/clonable"
@@ -35,8 +35,8 @@ var (
 	_ ssz2.SizedObjectSSZ = (*KZGProof)(nil)
 )
 
-type Blob gokzg4844.Blob
-type KZGProof gokzg4844.KZGProof // [48]byte
+type Blob goethkzg.Blob
+type KZGProof goethkzg.KZGProof // [48]byte
 
 const (
 	// https://github.com/ethereum/consensus-specs/blob/3a2304981a3b820a22b518fe4859f4bba0ebc83b/specs/deneb/polynomial-commitments.md#custom-types
@@ -46,7 +46,7 @@ const (
 	BYTES_PER_BLOB          = BYTES_PER_FIELD_ELEMENT * FIELD_ELEMENTS_PER_BLOB
 )
 
-type KZGCommitment gokzg4844.KZGCommitment
+type KZGCommitment goethkzg.KZGCommitment
 
 func (b KZGCommitment) MarshalJSON() ([]byte, error) {
 	return json.Marshal(libcommon.Bytes48(b))
@@ -0,0 +1,209 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package cltypes
+
+import (
+	"encoding/json"
+	"reflect"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutility"
+	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/crypto/kzg"
+	"github.com/erigontech/erigon-lib/types/clonable"
+	"github.com/erigontech/erigon/cl/cltypes/solid"
+	"github.com/erigontech/erigon/cl/merkle_tree"
+	ssz2 "github.com/erigontech/erigon/cl/ssz"
+	"github.com/erigontech/erigon/cl/utils"
+)
+
+const (
+	// https://github.com/ethereum/consensus-specs/blob/dev/specs/_features/eip7594/p2p-interface.md#preset
+	KzgCommitmentsInclusionProofDepth = 4
+	BytesPerCell                      = kzg.BytesPerCell
+	NumberOfColumns                   = kzg.CellsPerExtBlob
+
+	blobKzgCommitmentsGeneralizedIndexSubtree = 11
+)
+
+var (
+	cellT = reflect.TypeOf(Cell{})
+
+	_ ssz2.SizedObjectSSZ = (*Cell)(nil)
+	_ ssz2.SizedObjectSSZ = (*DataColumnSidecar)(nil)
+	_ ssz2.SizedObjectSSZ = (*DataColumnIdentifier)(nil)
+)
+
+// Cell is the part of an extended blob in a data column.
+type Cell kzg.Cell
+
+func (c *Cell) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hexutility.Bytes(c[:]))
+}
+
+func (c *Cell) UnmarshalJSON(in []byte) error {
+	return hexutility.UnmarshalFixedJSON(cellT, in, c[:])
+}
+
+func (c *Cell) Clone() clonable.Clonable {
+	return &Cell{}
+}
+
+func (c *Cell) DecodeSSZ(buf []byte, version int) error {
+	return ssz2.UnmarshalSSZ(buf, version, c[:])
+}
+
+func (c *Cell) EncodeSSZ(buf []byte) ([]byte, error) {
+	return append(buf, c[:]...), nil
+}
+
+func (c *Cell) EncodingSizeSSZ() int {
+	return BytesPerCell
+}
+
+func (c *Cell) Static() bool {
+	return true
+}
+
+func (c *Cell) HashSSZ() ([32]byte, error) {
+	return merkle_tree.BytesRoot(c[:])
+}
+
+// DataColumnSidecar holds a column of the extended blobs matrix of a block, with the proofs of its cells.
+type DataColumnSidecar struct {
+	Index                        uint64                         `json:"index,string"`
+	Column                       *solid.ListSSZ[*Cell]          `json:"column"`
+	KzgCommitments               *solid.ListSSZ[*KZGCommitment] `json:"kzg_commitments"`
+	KzgProofs                    *solid.ListSSZ[*KZGProof]      `json:"kzg_proofs"`
+	SignedBlockHeader            *SignedBeaconBlockHeader       `json:"signed_block_header"`
+	KzgCommitmentsInclusionProof solid.HashVectorSSZ            `json:"kzg_commitments_inclusion_proof"`
+}
+
+func NewDataColumnSidecar() *DataColumnSidecar {
+	return &DataColumnSidecar{
+		Column:                       solid.NewStaticListSSZ[*Cell](MaxBlobsCommittmentsPerBlock, BytesPerCell),
+		KzgCommitments:               solid.NewStaticListSSZ[*KZGCommitment](MaxBlobsCommittmentsPerBlock, length.Bytes48),
+		KzgProofs:                    solid.NewStaticListSSZ[*KZGProof](MaxBlobsCommittmentsPerBlock, length.Bytes48),
+		SignedBlockHeader:            &SignedBeaconBlockHeader{Header: &BeaconBlockHeader{}},
+		KzgCommitmentsInclusionProof: solid.NewHashVector(KzgCommitmentsInclusionProofDepth),
+	}
+}
+
+func (d *DataColumnSidecar) UnmarshalJSON(buf []byte) error {
+	type dataColumnSidecar DataColumnSidecar
+	tmp := (*dataColumnSidecar)(NewDataColumnSidecar())
+	if err := json.Unmarshal(buf, tmp); err != nil {
+		return err
+	}
+	*d = DataColumnSidecar(*tmp)
+	return nil
+}
+
+func (d *DataColumnSidecar) EncodeSSZ(buf []byte) ([]byte, error) {
+	return ssz2.MarshalSSZ(buf, d.getSchema()...)
+}
+
+func (d *DataColumnSidecar) DecodeSSZ(buf []byte, version int) error {
+	*d = *NewDataColumnSidecar()
+	return ssz2.UnmarshalSSZ(buf, version, d.getSchema()...)
+}
+
+func (d *DataColumnSidecar) EncodingSizeSSZ() int {
+	return length.BlockNum + 4*3 + d.Column.EncodingSizeSSZ() + d.KzgCommitments.EncodingSizeSSZ() + d.KzgProofs.EncodingSizeSSZ() +
+		d.SignedBlockHeader.EncodingSizeSSZ() + KzgCommitmentsInclusionProofDepth*length.Hash
+}
+
+func (*DataColumnSidecar) Static() bool {
+	return false
+}
+
+func (d *DataColumnSidecar) HashSSZ() ([32]byte, error) {
+	return merkle_tree.HashTreeRoot(d.getSchema()...)
+}
+
+func (*DataColumnSidecar) Clone() clonable.Clonable {
+	return NewDataColumnSidecar()
+}
+
+func (d *DataColumnSidecar) getSchema() []interface{} {
+	return []interface{}{&d.Index, d.Column, d.KzgCommitments, d.KzgProofs, d.SignedBlockHeader, d.KzgCommitmentsInclusionProof}
+}
+
+// BlockRoot is the root of the block the column belongs to.
+func (d *DataColumnSidecar) BlockRoot() (libcommon.Hash, error) {
+	return d.SignedBlockHeader.Header.HashSSZ()
+}
+
+// VerifyDataColumnSidecarInclusionProof checks that the kzg commitments of the sidecar are the ones of the block body.
+func VerifyDataColumnSidecarInclusionProof(sidecar *DataColumnSidecar) bool {
+	if sidecar.KzgCommitmentsInclusionProof == nil || sidecar.KzgCommitmentsInclusionProof.Length() != KzgCommitmentsInclusionProofDepth {
+		return false
+	}
+	leaf, err := sidecar.KzgCommitments.HashSSZ()
+	if err != nil {
+		return false
+	}
+	branch := make([]libcommon.Hash, KzgCommitmentsInclusionProofDepth)
+	for i := range branch {
+		branch[i] = sidecar.KzgCommitmentsInclusionProof.Get(i)
+	}
+	return utils.IsValidMerkleBranch(leaf, branch, KzgCommitmentsInclusionProofDepth, blobKzgCommitmentsGeneralizedIndexSubtree, sidecar.SignedBlockHeader.Header.BodyRoot)
+}
+
+// DataColumnIdentifier identifies a column of a block in DataColumnSidecarsByRoot requests.
+type DataColumnIdentifier struct {
+	BlockRoot libcommon.Hash `json:"block_root"`
+	Index     uint64         `json:"index,string"`
+}
+
+func NewDataColumnIdentifier(blockRoot libcommon.Hash, index uint64) *DataColumnIdentifier {
+	return &DataColumnIdentifier{
+		BlockRoot: blockRoot,
+		Index:     index,
+	}
+}
+
+func (d *DataColumnIdentifier) EncodeSSZ(buf []byte) ([]byte, error) {
+	return ssz2.MarshalSSZ(buf, d.getSchema()...)
+}
+
+func (d *DataColumnIdentifier) EncodingSizeSSZ() int {
+	return length.Hash + length.BlockNum
+}
+
+func (d *DataColumnIdentifier) DecodeSSZ(buf []byte, version int) error {
+	return ssz2.UnmarshalSSZ(buf, version, d.getSchema()...)
+}
+
+func (*DataColumnIdentifier) Static() bool {
+	return true
+}
+
+func (d *DataColumnIdentifier) HashSSZ() ([32]byte, error) {
+	return merkle_tree.HashTreeRoot(d.getSchema()...)
+}
+
+func (*DataColumnIdentifier) Clone() clonable.Clonable {
+	return &DataColumnIdentifier{}
+}
+
+func (d *DataColumnIdentifier) getSchema() []interface{} {
+	return []interface{}{
+		d.BlockRoot[:],
+		&d.Index,
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package cltypes
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/cl/clparams"
+)
+
+func TestDataColumnSidecar(t *testing.T) {
+	_, bc := clparams.GetConfigsByNetwork(clparams.GnosisNetwork)
+	block := NewSignedBeaconBlock(bc)
+	block.Block.Body.Version = clparams.DenebVersion
+	require.NoError(t, json.Unmarshal(beaconBodyJSON, block))
+	for i := 0; i < 3; i++ {
+		block.Block.Body.BlobKzgCommitments.Append(&KZGCommitment{byte(i + 1)})
+	}
+
+	proof, err := block.Block.Body.KzgCommitmentsMerkleProof()
+	require.NoError(t, err)
+	sidecar := NewDataColumnSidecar()
+	sidecar.Index = 7
+	sidecar.SignedBlockHeader = block.SignedBeaconBlockHeader()
+	for i := 0; i < block.Block.Body.BlobKzgCommitments.Len(); i++ {
+		sidecar.Column.Append(&Cell{byte(i)})
+		sidecar.KzgCommitments.Append(block.Block.Body.BlobKzgCommitments.Get(i))
+		sidecar.KzgProofs.Append(&KZGProof{byte(i)})
+	}
+	for i, node := range proof {
+		sidecar.KzgCommitmentsInclusionProof.Set(i, node)
+	}
+	require.True(t, VerifyDataColumnSidecarInclusionProof(sidecar))
+
+	// ssz round trip
+	encoded, err := sidecar.EncodeSSZ(nil)
+	require.NoError(t, err)
+	require.Len(t, encoded, sidecar.EncodingSizeSSZ())
+	decoded := &DataColumnSidecar{}
+	require.NoError(t, decoded.DecodeSSZ(encoded, int(clparams.DenebVersion)))
+	expectedRoot, err := sidecar.HashSSZ()
+	require.NoError(t, err)
+	root, err := decoded.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, expectedRoot, root)
+	require.True(t, VerifyDataColumnSidecarInclusionProof(decoded))
+
+	// json round trip
+	encoded, err = json.Marshal(sidecar)
+	require.NoError(t, err)
+	decoded = &DataColumnSidecar{}
+	require.NoError(t, json.Unmarshal(encoded, decoded))
+	root, err = decoded.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, expectedRoot, root)
+
+	blockRoot, err := block.Block.HashSSZ()
+	require.NoError(t, err)
+	sidecarBlockRoot, err := sidecar.BlockRoot()
+	require.NoError(t, err)
+	require.Equal(t, libcommon.Hash(blockRoot), sidecarBlockRoot)
+
+	// the commitments must be the ones of the block
+	decoded.KzgCommitments.Truncate(2)
+	require.False(t, VerifyDataColumnSidecarInclusionProof(decoded))
+}
@@ -0,0 +1,185 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package cltypes
+
+import (
+	"encoding/json"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/types/clonable"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/cltypes/solid"
+	"github.com/erigontech/erigon/cl/merkle_tree"
+	ssz2 "github.com/erigontech/erigon/cl/ssz"
+)
+
+const (
+	depositRequestSSZSize       = 192
+	withdrawalRequestSSZSize    = 76
+	consolidationRequestSSZSize = 116
+)
+
+// DepositRequest is a deposit made to the deposit contract and passed by the execution layer (EIP-6110).
+type DepositRequest struct {
+	PubKey                libcommon.Bytes48 `json:"pubkey"`
+	WithdrawalCredentials libcommon.Hash    `json:"withdrawal_credentials"`
+	Amount                uint64            `json:"amount,string"`
+	Signature             libcommon.Bytes96 `json:"signature"`
+	Index                 uint64            `json:"index,string"`
+}
+
+func (d *DepositRequest) EncodeSSZ(buf []byte) ([]byte, error) {
+	return ssz2.MarshalSSZ(buf, d.PubKey[:], d.WithdrawalCredentials[:], d.Amount, d.Signature[:], d.Index)
+}
+
+func (d *DepositRequest) DecodeSSZ(buf []byte, version int) error {
+	return ssz2.UnmarshalSSZ(buf, version, d.PubKey[:], d.WithdrawalCredentials[:], &d.Amount, d.Signature[:], &d.Index)
+}
+
+func (*DepositRequest) EncodingSizeSSZ() int {
+	return depositRequestSSZSize
+}
+
+func (d *DepositRequest) HashSSZ() ([32]byte, error) {
+	return merkle_tree.HashTreeRoot(d.PubKey[:], d.WithdrawalCredentials[:], d.Amount, d.Signature[:], d.Index)
+}
+
+func (*DepositRequest) Static() bool {
+	return true
+}
+
+func (*DepositRequest) Clone() clonable.Clonable {
+	return &DepositRequest{}
+}
+
+// WithdrawalRequest is a withdrawal triggered from the execution layer withdrawal credentials (EIP-7002).
+type WithdrawalRequest struct {
+	SourceAddress   libcommon.Address `json:"source_address"`
+	ValidatorPubKey libcommon.Bytes48 `json:"validator_pubkey"`
+	Amount          uint64            `json:"amount,string"`
+}
+
+func (w *WithdrawalRequest) EncodeSSZ(buf []byte) ([]byte, error) {
+	return ssz2.MarshalSSZ(buf, w.SourceAddress[:], w.ValidatorPubKey[:], w.Amount)
+}
+
+func (w *WithdrawalRequest) DecodeSSZ(buf []byte, version int) error {
+	return ssz2.UnmarshalSSZ(buf, version, w.SourceAddress[:], w.ValidatorPubKey[:], &w.Amount)
+}
+
+func (*WithdrawalRequest) EncodingSizeSSZ() int {
+	return withdrawalRequestSSZSize
+}
+
+func (w *WithdrawalRequest) HashSSZ() ([32]byte, error) {
+	return merkle_tree.HashTreeRoot(w.SourceAddress[:], w.ValidatorPubKey[:], w.Amount)
+}
+
+func (*WithdrawalRequest) Static() bool {
+	return true
+}
+
+func (*WithdrawalRequest) Clone() clonable.Clonable {
+	return &WithdrawalRequest{}
+}
+
+// ConsolidationRequest asks to move the balance of the source validator to the target one (EIP-7251).
+type ConsolidationRequest struct {
+	SourceAddress libcommon.Address `json:"source_address"`
+	SourcePubKey  libcommon.Bytes48 `json:"source_pubkey"`
+	TargetPubKey  libcommon.Bytes48 `json:"target_pubkey"`
+}
+
+func (c *ConsolidationRequest) EncodeSSZ(buf []byte) ([]byte, error) {
+	return ssz2.MarshalSSZ(buf, c.SourceAddress[:], c.SourcePubKey[:], c.TargetPubKey[:])
+}
+
+func (c *ConsolidationRequest) DecodeSSZ(buf []byte, version int) error {
+	return ssz2.UnmarshalSSZ(buf, version, c.SourceAddress[:], c.SourcePubKey[:], c.TargetPubKey[:])
+}
+
+func (*ConsolidationRequest) EncodingSizeSSZ() int {
+	return consolidationRequestSSZSize
+}
+
+func (c *ConsolidationRequest) HashSSZ() ([32]byte, error) {
+	return merkle_tree.HashTreeRoot(c.SourceAddress[:], c.SourcePubKey[:], c.TargetPubKey[:])
+}
+
+func (*ConsolidationRequest) Static() bool {
+	return true
+}
+
+func (*ConsolidationRequest) Clone() clonable.Clonable {
+	return &ConsolidationRequest{}
+}
+
+// ExecutionRequests are the requests of the execution layer carried by the beacon block body since Electra.
+type ExecutionRequests struct {
+	Deposits       *solid.ListSSZ[*DepositRequest]       `json:"deposits"`
+	Withdrawals    *solid.ListSSZ[*WithdrawalRequest]    `json:"withdrawals"`
+	Consolidations *solid.ListSSZ[*ConsolidationRequest] `json:"consolidations"`
+
+	beaconCfg *clparams.BeaconChainConfig
+}
+
+func NewExecutionRequests(beaconCfg *clparams.BeaconChainConfig) *ExecutionRequests {
+	return &ExecutionRequests{
+		Deposits:       solid.NewStaticListSSZ[*DepositRequest](int(beaconCfg.MaxDepositRequestsPerPayload), depositRequestSSZSize),
+		Withdrawals:    solid.NewStaticListSSZ[*WithdrawalRequest](int(beaconCfg.MaxWithdrawalRequestsPerPayload), withdrawalRequestSSZSize),
+		Consolidations: solid.NewStaticListSSZ[*ConsolidationRequest](int(beaconCfg.MaxConsolidationRequestsPerPayload), consolidationRequestSSZSize),
+		beaconCfg:      beaconCfg,
+	}
+}
+
+func (e *ExecutionRequests) EncodeSSZ(buf []byte) ([]byte, error) {
+	return ssz2.MarshalSSZ(buf, e.Deposits, e.Withdrawals, e.Consolidations)
+}
+
+func (e *ExecutionRequests) DecodeSSZ(buf []byte, version int) error {
+	*e = *NewExecutionRequests(e.beaconCfg)
+	return ssz2.UnmarshalSSZ(buf, version, e.Deposits, e.Withdrawals, e.Consolidations)
+}
+
+func (e *ExecutionRequests) EncodingSizeSSZ() int {
+	return 12 + e.Deposits.EncodingSizeSSZ() + e.Withdrawals.EncodingSizeSSZ() + e.Consolidations.EncodingSizeSSZ()
+}
+
+func (e *ExecutionRequests) HashSSZ() ([32]byte, error) {
+	return merkle_tree.HashTreeRoot(e.Deposits, e.Withdrawals, e.Consolidations)
+}
+
+func (*ExecutionRequests) Static() bool {
+	return false
+}
+
+func (e *ExecutionRequests) Clone() clonable.Clonable {
+	return NewExecutionRequests(e.beaconCfg)
+}
+
+func (e *ExecutionRequests) UnmarshalJSON(buf []byte) error {
+	tmp := NewExecutionRequests(e.beaconCfg)
+	if err := json.Unmarshal(buf, &struct {
+		Deposits       *solid.ListSSZ[*DepositRequest]       `json:"deposits"`
+		Withdrawals    *solid.ListSSZ[*WithdrawalRequest]    `json:"withdrawals"`
+		Consolidations *solid.ListSSZ[*ConsolidationRequest] `json:"consolidations"`
+	}{tmp.Deposits, tmp.Withdrawals, tmp.Consolidations}); err != nil {
+		return err
+	}
+	*e = *tmp
+	return nil
+}
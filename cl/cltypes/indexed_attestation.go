@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 
 	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/cl/clparams"
 	"github.com/erigontech/erigon/cl/cltypes/solid"
 	"github.com/erigontech/erigon/cl/merkle_tree"
 	ssz2 "github.com/erigontech/erigon/cl/ssz"
@@ -34,6 +35,14 @@ type IndexedAttestation struct {
 	Signature        libcommon.Bytes96     `json:"signature"`
 }
 
+// IndexedAttestationIndicesLimit - since Electra (EIP-7549) an attestation can span all committees of the slot.
+func IndexedAttestationIndicesLimit(version clparams.StateVersion) int {
+	if version >= clparams.ElectraVersion {
+		return 2048 * 64
+	}
+	return 2048
+}
+
 func NewIndexedAttestation() *IndexedAttestation {
 	return &IndexedAttestation{
 		AttestingIndices: solid.NewRawUint64List(2048, nil),
@@ -69,7 +78,7 @@ func (i *IndexedAttestation) EncodeSSZ(buf []byte) (dst []byte, err error) {
 // DecodeSSZ ssz unmarshals the IndexedAttestation object
 func (i *IndexedAttestation) DecodeSSZ(buf []byte, version int) error {
 	i.Data = solid.NewAttestationData()
-	i.AttestingIndices = solid.NewRawUint64List(2048, nil)
+	i.AttestingIndices = solid.NewRawUint64List(IndexedAttestationIndicesLimit(clparams.StateVersion(version)), nil)
 
 	return ssz2.UnmarshalSSZ(buf, version, i.AttestingIndices, i.Data, i.Signature[:])
 }
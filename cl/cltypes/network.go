@@ -25,6 +25,7 @@ import (
 	"github.com/erigontech/erigon-lib/types/clonable"
 	"github.com/erigontech/erigon-lib/types/ssz"
 
+	"github.com/erigontech/erigon/cl/cltypes/solid"
 	ssz2 "github.com/erigontech/erigon/cl/ssz"
 )
 
@@ -197,3 +198,34 @@ func (l *BlobsByRangeRequest) EncodingSizeSSZ() int {
 func (*BlobsByRangeRequest) Clone() clonable.Clonable {
 	return &BlobsByRangeRequest{}
 }
+
+type DataColumnSidecarsByRangeRequest struct {
+	StartSlot uint64
+	Count     uint64
+	Columns   solid.Uint64ListSSZ
+}
+
+func NewDataColumnSidecarsByRangeRequest(startSlot, count uint64, columns []uint64) *DataColumnSidecarsByRangeRequest {
+	return &DataColumnSidecarsByRangeRequest{
+		StartSlot: startSlot,
+		Count:     count,
+		Columns:   solid.NewUint64ListSSZFromSlice(NumberOfColumns, columns),
+	}
+}
+
+func (l *DataColumnSidecarsByRangeRequest) EncodeSSZ(buf []byte) ([]byte, error) {
+	return ssz2.MarshalSSZ(buf, &l.StartSlot, &l.Count, l.Columns)
+}
+
+func (l *DataColumnSidecarsByRangeRequest) DecodeSSZ(buf []byte, _ int) error {
+	l.Columns = solid.NewUint64ListSSZ(NumberOfColumns)
+	return ssz2.UnmarshalSSZ(buf, 0, &l.StartSlot, &l.Count, l.Columns)
+}
+
+func (l *DataColumnSidecarsByRangeRequest) EncodingSizeSSZ() int {
+	return 20 + l.Columns.EncodingSizeSSZ()
+}
+
+func (*DataColumnSidecarsByRangeRequest) Clone() clonable.Clonable {
+	return &DataColumnSidecarsByRangeRequest{}
+}
@@ -70,6 +70,8 @@ var testBlobRequestByRange = &cltypes.BlobsByRangeRequest{
 	Count:     10,
 }
 
+var testDataColumnSidecarsRequestByRange = cltypes.NewDataColumnSidecarsByRangeRequest(100, 10, []uint64{1, 17, 127})
+
 func TestMarshalNetworkTypes(t *testing.T) {
 	cases := []ssz.EncodableSSZ{
 		testMetadata,
@@ -79,6 +81,7 @@ func TestMarshalNetworkTypes(t *testing.T) {
 		testBlockRoot,
 		testLightClientUpdatesByRange,
 		testBlobRequestByRange,
+		testDataColumnSidecarsRequestByRange,
 	}
 
 	unmarshalDestinations := []ssz.EncodableSSZ{
@@ -89,6 +92,7 @@ func TestMarshalNetworkTypes(t *testing.T) {
 		&cltypes.Root{},
 		&cltypes.LightClientUpdatesByRangeRequest{},
 		&cltypes.BlobsByRangeRequest{},
+		&cltypes.DataColumnSidecarsByRangeRequest{},
 	}
 	for i, tc := range cases {
 		marshalledBytes, err := tc.EncodeSSZ(nil)
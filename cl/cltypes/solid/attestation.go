@@ -25,6 +25,7 @@ import (
 	"github.com/erigontech/erigon-lib/common/length"
 	"github.com/erigontech/erigon-lib/types/clonable"
 	"github.com/erigontech/erigon-lib/types/ssz"
+	"github.com/erigontech/erigon/cl/clparams"
 	"github.com/erigontech/erigon/cl/merkle_tree"
 )
 
@@ -36,6 +37,13 @@ const (
 
 	// offset is usually always the same
 	aggregationBitsOffset = 228
+
+	// EIP-7549: committee bits (Bitvector[MAX_COMMITTEES_PER_SLOT]) follow the signature
+	committeeBitsSize            = 8
+	aggregationBitsOffsetElectra = aggregationBitsOffset + committeeBitsSize
+
+	aggregationBitsLimit        = 2048
+	aggregationBitsLimitElectra = aggregationBitsLimit * 64
 )
 
 // Attestation type represents a statement or confirmation of some occurrence or phenomenon.
@@ -44,6 +52,8 @@ type Attestation struct {
 	staticBuffer [attestationStaticBufferSize]byte
 	// Dynamic field to store aggregation bits
 	aggregationBitsBuffer []byte
+	// Committee bits, only present in Electra attestations (nil for older ones)
+	committeeBits []byte
 }
 
 // Static returns whether the attestation is static or not. For Attestation, it's always false.
@@ -56,6 +66,7 @@ func (a *Attestation) Copy() *Attestation {
 	copy(new.staticBuffer[:], a.staticBuffer[:])
 	new.aggregationBitsBuffer = make([]byte, len(a.aggregationBitsBuffer))
 	copy(new.aggregationBitsBuffer, a.aggregationBitsBuffer)
+	new.committeeBits = libcommon.CopyBytes(a.committeeBits)
 	return new
 }
 
@@ -78,10 +89,12 @@ func (a Attestation) MarshalJSON() ([]byte, error) {
 		AggregationBits hexutility.Bytes  `json:"aggregation_bits"`
 		Signature       libcommon.Bytes96 `json:"signature"`
 		Data            AttestationData   `json:"data"`
+		CommitteeBits   hexutility.Bytes  `json:"committee_bits,omitempty"`
 	}{
 		AggregationBits: a.aggregationBitsBuffer,
 		Signature:       a.Signature(),
 		Data:            a.AttestantionData(),
+		CommitteeBits:   a.committeeBits,
 	})
 }
 
@@ -90,6 +103,7 @@ func (a *Attestation) UnmarshalJSON(buf []byte) error {
 		AggregationBits hexutility.Bytes  `json:"aggregation_bits"`
 		Signature       libcommon.Bytes96 `json:"signature"`
 		Data            AttestationData   `json:"data"`
+		CommitteeBits   hexutility.Bytes  `json:"committee_bits,omitempty"`
 	}
 	tmp.Data = NewAttestationData()
 	if err := json.Unmarshal(buf, &tmp); err != nil {
@@ -99,6 +113,9 @@ func (a *Attestation) UnmarshalJSON(buf []byte) error {
 	a.SetAggregationBits(tmp.AggregationBits)
 	a.SetSignature(tmp.Signature)
 	a.SetAttestationData(tmp.Data)
+	if tmp.CommitteeBits != nil {
+		a.SetCommitteeBits(tmp.CommitteeBits)
+	}
 	return nil
 }
 
@@ -137,22 +154,60 @@ func (a *Attestation) SetSignature(signature [96]byte) {
 	copy(a.staticBuffer[132:], signature[:])
 }
 
+// CommitteeBits returns the committee bits of an Electra attestation, nil for older attestations.
+func (a *Attestation) CommitteeBits() []byte {
+	return libcommon.CopyBytes(a.committeeBits)
+}
+
+// SetCommitteeBits sets the committee bits, turning the attestation into the Electra (EIP-7549) format.
+func (a *Attestation) SetCommitteeBits(bits []byte) {
+	a.committeeBits = make([]byte, committeeBitsSize)
+	copy(a.committeeBits, bits)
+	binary.LittleEndian.PutUint32(a.staticBuffer[:4], aggregationBitsOffsetElectra)
+}
+
+// IsElectra returns whether the attestation is in the Electra (EIP-7549) format.
+func (a *Attestation) IsElectra() bool {
+	return a.committeeBits != nil
+}
+
+// CommitteeIndices returns the indices of the committees set in the committee bits (get_committee_indices).
+func (a *Attestation) CommitteeIndices() []uint64 {
+	indices := []uint64{}
+	for i := 0; i < len(a.committeeBits)*8; i++ {
+		if a.committeeBits[i/8]&(1<<(i%8)) > 0 {
+			indices = append(indices, uint64(i))
+		}
+	}
+	return indices
+}
+
 // EncodingSizeSSZ returns the size of the Attestation instance when encoded in SSZ format.
 func (a *Attestation) EncodingSizeSSZ() (size int) {
 	size = attestationStaticBufferSize
 	if a == nil {
 		return
 	}
-	return size + len(a.aggregationBitsBuffer)
+	return size + len(a.committeeBits) + len(a.aggregationBitsBuffer)
 }
 
 // DecodeSSZ decodes the provided buffer into the Attestation instance.
-func (a *Attestation) DecodeSSZ(buf []byte, _ int) error {
-	if len(buf) < attestationStaticBufferSize {
+func (a *Attestation) DecodeSSZ(buf []byte, version int) error {
+	if clparams.StateVersion(version) < clparams.ElectraVersion {
+		if len(buf) < attestationStaticBufferSize {
+			return ssz.ErrLowBufferSize
+		}
+		copy(a.staticBuffer[:], buf)
+		a.aggregationBitsBuffer = libcommon.CopyBytes(buf[aggregationBitsOffset:])
+		a.committeeBits = nil
+		return nil
+	}
+	if len(buf) < attestationStaticBufferSize+committeeBitsSize {
 		return ssz.ErrLowBufferSize
 	}
 	copy(a.staticBuffer[:], buf)
-	a.aggregationBitsBuffer = libcommon.CopyBytes(buf[aggregationBitsOffset:])
+	a.committeeBits = libcommon.CopyBytes(buf[aggregationBitsOffset:aggregationBitsOffsetElectra])
+	a.aggregationBitsBuffer = libcommon.CopyBytes(buf[aggregationBitsOffsetElectra:])
 	return nil
 }
 
@@ -160,6 +215,7 @@ func (a *Attestation) DecodeSSZ(buf []byte, _ int) error {
 func (a *Attestation) EncodeSSZ(dst []byte) ([]byte, error) {
 	buf := dst
 	buf = append(buf, a.staticBuffer[:]...)
+	buf = append(buf, a.committeeBits...)
 	buf = append(buf, a.aggregationBitsBuffer...)
 	return buf, nil
 }
@@ -169,7 +225,11 @@ func (a *Attestation) CopyHashBufferTo(o []byte) error {
 	for i := 0; i < 128; i++ {
 		o[i] = 0
 	}
-	aggBytesRoot, err := merkle_tree.BitlistRootWithLimit(a.AggregationBits(), 2048)
+	bitsLimit := uint64(aggregationBitsLimit)
+	if a.IsElectra() {
+		bitsLimit = aggregationBitsLimitElectra
+	}
+	aggBytesRoot, err := merkle_tree.BitlistRootWithLimit(a.AggregationBits(), bitsLimit)
 	if err != nil {
 		return err
 	}
@@ -184,6 +244,11 @@ func (a *Attestation) CopyHashBufferTo(o []byte) error {
 	copy(o[64:], o[:32])
 	copy(o[:32], aggBytesRoot[:])
 	copy(o[32:64], dataRoot[:])
+	// committee bits fit in one chunk, older attestations leave the 4th leaf zeroed
+	for i := 96; i < 128; i++ {
+		o[i] = 0
+	}
+	copy(o[96:], a.committeeBits)
 	return nil
 }
 
@@ -215,5 +280,6 @@ func (a *Attestation) Clone() clonable.Clonable {
 	return &Attestation{
 		aggregationBitsBuffer: bitsBuffer,
 		staticBuffer:          staticBuffer,
+		committeeBits:         libcommon.CopyBytes(a.committeeBits),
 	}
 }
@@ -172,6 +172,12 @@ func (l *ListSSZ[T]) Truncate(length int) {
 	l.root = libcommon.Hash{}
 }
 
+// Cut removes the first length elements of the list.
+func (l *ListSSZ[T]) Cut(length int) {
+	l.list = l.list[length:]
+	l.root = libcommon.Hash{}
+}
+
 func (l *ListSSZ[T]) ElementProof(i int) [][32]byte {
 	leaves := make([]interface{}, l.limit)
 	for i := range leaves {
@@ -0,0 +1,157 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package das
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"sort"
+
+	"github.com/holiman/uint256"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/utils"
+	"github.com/erigontech/erigon/p2p/enode"
+)
+
+// ComputeSubnetForDataColumnSidecar returns the gossip subnet a column is published on.
+func ComputeSubnetForDataColumnSidecar(beaconConfig *clparams.BeaconChainConfig, columnIndex uint64) uint64 {
+	return columnIndex % beaconConfig.DataColumnSidecarSubnetCount
+}
+
+// CustodySubnets returns the column subnets a node has to custody, see get_custody_columns in the EIP-7594 spec.
+func CustodySubnets(beaconConfig *clparams.BeaconChainConfig, nodeID enode.ID, custodySubnetCount uint64) []uint64 {
+	if custodySubnetCount > beaconConfig.DataColumnSidecarSubnetCount {
+		custodySubnetCount = beaconConfig.DataColumnSidecarSubnetCount
+	}
+	currentID := new(uint256.Int).SetBytes32(nodeID[:])
+	maxID := new(uint256.Int).SetAllOne()
+	one := uint256.NewInt(1)
+
+	seen := make(map[uint64]struct{}, custodySubnetCount)
+	subnets := make([]uint64, 0, custodySubnetCount)
+	for uint64(len(subnets)) < custodySubnetCount {
+		// uint_to_bytes(current_id) is little-endian
+		be := currentID.Bytes32()
+		var le [32]byte
+		for i := range be {
+			le[i] = be[len(be)-1-i]
+		}
+		hash := utils.Sha256(le[:])
+		subnet := binary.LittleEndian.Uint64(hash[:8]) % beaconConfig.DataColumnSidecarSubnetCount
+		if _, ok := seen[subnet]; !ok {
+			seen[subnet] = struct{}{}
+			subnets = append(subnets, subnet)
+		}
+		if currentID.Eq(maxID) {
+			currentID.Clear()
+		}
+		currentID.Add(currentID, one)
+	}
+	sort.Slice(subnets, func(i, j int) bool { return subnets[i] < subnets[j] })
+	return subnets
+}
+
+// CustodyColumns returns the sorted column indices a node has to custody.
+func CustodyColumns(beaconConfig *clparams.BeaconChainConfig, nodeID enode.ID, custodySubnetCount uint64) []uint64 {
+	columnsPerSubnet := beaconConfig.NumberOfColumns / beaconConfig.DataColumnSidecarSubnetCount
+	subnets := CustodySubnets(beaconConfig, nodeID, custodySubnetCount)
+	columns := make([]uint64, 0, uint64(len(subnets))*columnsPerSubnet)
+	for i := uint64(0); i < columnsPerSubnet; i++ {
+		for _, subnet := range subnets {
+			columns = append(columns, beaconConfig.DataColumnSidecarSubnetCount*i+subnet)
+		}
+	}
+	sort.Slice(columns, func(i, j int) bool { return columns[i] < columns[j] })
+	return columns
+}
+
+// Custody is the set of columns and subnets assigned to the local node.
+type Custody struct {
+	beaconConfig *clparams.BeaconChainConfig
+	subnets      []uint64
+	columns      []uint64
+	custodied    map[uint64]struct{}
+	// sampleSeed is drawn locally at startup and never shared, so peers cannot predict which columns we sample
+	sampleSeed libcommon.Hash
+}
+
+// NewCustody computes the custody assignment of a node.
+func NewCustody(beaconConfig *clparams.BeaconChainConfig, nodeID enode.ID, custodySubnetCount uint64) *Custody {
+	c := &Custody{
+		beaconConfig: beaconConfig,
+		subnets:      CustodySubnets(beaconConfig, nodeID, custodySubnetCount),
+		columns:      CustodyColumns(beaconConfig, nodeID, custodySubnetCount),
+		custodied:    make(map[uint64]struct{}),
+	}
+	for _, column := range c.columns {
+		c.custodied[column] = struct{}{}
+	}
+	if _, err := rand.Read(c.sampleSeed[:]); err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Columns returns the custodied columns in ascending order.
+func (c *Custody) Columns() []uint64 {
+	return c.columns
+}
+
+// Subnets returns the custodied subnets in ascending order.
+func (c *Custody) Subnets() []uint64 {
+	return c.subnets
+}
+
+// IsCustodied returns whether the column is custodied by the node.
+func (c *Custody) IsCustodied(columnIndex uint64) bool {
+	_, ok := c.custodied[columnIndex]
+	return ok
+}
+
+// SampledColumns returns the columns that must be available before a block can be imported: the custodied ones
+// plus SamplesPerSlot columns picked at random by this node, in ascending order. The pick is stable for a given
+// block root but is keyed on a local secret, so a proposer cannot publish just the columns we are going to sample.
+func (c *Custody) SampledColumns(blockRoot libcommon.Hash) []uint64 {
+	sampled := make(map[uint64]struct{}, len(c.columns)+int(c.beaconConfig.SamplesPerSlot))
+	for _, column := range c.columns {
+		sampled[column] = struct{}{}
+	}
+	samples := c.beaconConfig.SamplesPerSlot
+	if remaining := c.beaconConfig.NumberOfColumns - uint64(len(c.columns)); samples > remaining {
+		samples = remaining
+	}
+	seed := utils.Sha256(c.sampleSeed[:], blockRoot[:])
+	for added := uint64(0); added < samples; {
+		seed = utils.Sha256(seed[:])
+		for i := 0; i+8 <= len(seed) && added < samples; i += 8 {
+			column := binary.LittleEndian.Uint64(seed[i:i+8]) % c.beaconConfig.NumberOfColumns
+			if _, ok := sampled[column]; ok {
+				continue
+			}
+			sampled[column] = struct{}{}
+			added++
+		}
+	}
+	columns := make([]uint64, 0, len(sampled))
+	for column := range sampled {
+		columns = append(columns, column)
+	}
+	sort.Slice(columns, func(i, j int) bool { return columns[i] < columns[j] })
+	return columns
+}
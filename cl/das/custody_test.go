@@ -0,0 +1,96 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package das
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/p2p/enode"
+)
+
+func TestCustodyColumns(t *testing.T) {
+	cfg := &clparams.MainnetBeaconConfig
+	nodeID := enode.HexID("a448f24c6d18e575453db13171562b71999873db5b286df957af199ec94617f7")
+
+	columnsPerSubnet := cfg.NumberOfColumns / cfg.DataColumnSidecarSubnetCount
+	for _, count := range []uint64{1, 4, cfg.DataColumnSidecarSubnetCount, cfg.DataColumnSidecarSubnetCount + 1} {
+		subnets := CustodySubnets(cfg, nodeID, count)
+		columns := CustodyColumns(cfg, nodeID, count)
+		expected := count
+		if expected > cfg.DataColumnSidecarSubnetCount {
+			expected = cfg.DataColumnSidecarSubnetCount
+		}
+		require.Len(t, subnets, int(expected))
+		require.Len(t, columns, int(expected*columnsPerSubnet))
+		require.True(t, sort.SliceIsSorted(columns, func(i, j int) bool { return columns[i] < columns[j] }))
+		for _, column := range columns {
+			require.Contains(t, subnets, ComputeSubnetForDataColumnSidecar(cfg, column))
+		}
+	}
+	// the assignment is deterministic and grows with the subnet count
+	require.Equal(t, CustodyColumns(cfg, nodeID, 4), CustodyColumns(cfg, nodeID, 4))
+	require.Subset(t, CustodySubnets(cfg, nodeID, 4), CustodySubnets(cfg, nodeID, 1))
+
+	// the node id wraps around at 2**256-1
+	maxID := enode.HexID("ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	require.Len(t, CustodySubnets(cfg, maxID, cfg.DataColumnSidecarSubnetCount), int(cfg.DataColumnSidecarSubnetCount))
+}
+
+func TestSampledColumns(t *testing.T) {
+	cfg := &clparams.MainnetBeaconConfig
+	custody := NewCustody(cfg, enode.HexID("a448f24c6d18e575453db13171562b71999873db5b286df957af199ec94617f7"), cfg.CustodyRequirement)
+	for _, column := range custody.Columns() {
+		require.True(t, custody.IsCustodied(column))
+	}
+
+	sampled := custody.SampledColumns(libcommon.Hash{1})
+	require.Len(t, sampled, len(custody.Columns())+int(cfg.SamplesPerSlot))
+	require.Subset(t, sampled, custody.Columns())
+	require.Equal(t, sampled, custody.SampledColumns(libcommon.Hash{1}))
+	require.NotEqual(t, sampled, custody.SampledColumns(libcommon.Hash{2}))
+
+	// another node with the same ID custodies the same columns but samples different ones
+	other := NewCustody(cfg, enode.HexID("a448f24c6d18e575453db13171562b71999873db5b286df957af199ec94617f7"), cfg.CustodyRequirement)
+	require.Equal(t, custody.Columns(), other.Columns())
+	require.NotEqual(t, sampled, other.SampledColumns(libcommon.Hash{1}))
+
+	// a node custodying every column samples nothing more
+	full := NewCustody(cfg, enode.ID{}, cfg.DataColumnSidecarSubnetCount)
+	require.Len(t, full.SampledColumns(libcommon.Hash{1}), int(cfg.NumberOfColumns))
+}
+
+func TestVerifyDataColumnSidecar(t *testing.T) {
+	cfg := &clparams.MainnetBeaconConfig
+	sidecar := cltypes.NewDataColumnSidecar()
+	require.ErrorIs(t, VerifyDataColumnSidecar(cfg, sidecar), ErrEmptyColumn)
+
+	sidecar.Column.Append(&cltypes.Cell{})
+	sidecar.KzgCommitments.Append(&cltypes.KZGCommitment{})
+	require.ErrorIs(t, VerifyDataColumnSidecar(cfg, sidecar), ErrColumnLengthMismatch)
+
+	sidecar.KzgProofs.Append(&cltypes.KZGProof{})
+	require.NoError(t, VerifyDataColumnSidecar(cfg, sidecar))
+
+	sidecar.Index = cfg.NumberOfColumns
+	require.ErrorIs(t, VerifyDataColumnSidecar(cfg, sidecar), ErrInvalidColumnIndex)
+}
@@ -0,0 +1,66 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package das
+
+import (
+	"errors"
+
+	goethkzg "github.com/crate-crypto/go-eth-kzg"
+
+	"github.com/erigontech/erigon-lib/crypto/kzg"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/cltypes"
+)
+
+var (
+	ErrInvalidColumnIndex   = errors.New("data column sidecar index out of range")
+	ErrEmptyColumn          = errors.New("data column sidecar has no cells")
+	ErrColumnLengthMismatch = errors.New("data column sidecar cells, commitments and proofs lengths mismatch")
+)
+
+// VerifyDataColumnSidecar implements verify_data_column_sidecar: it checks the structure of the sidecar.
+func VerifyDataColumnSidecar(beaconConfig *clparams.BeaconChainConfig, sidecar *cltypes.DataColumnSidecar) error {
+	if sidecar.Index >= beaconConfig.NumberOfColumns {
+		return ErrInvalidColumnIndex
+	}
+	if sidecar.Column.Len() == 0 {
+		return ErrEmptyColumn
+	}
+	if sidecar.Column.Len() != sidecar.KzgCommitments.Len() || sidecar.Column.Len() != sidecar.KzgProofs.Len() {
+		return ErrColumnLengthMismatch
+	}
+	return nil
+}
+
+// VerifyDataColumnSidecarKZGProofs checks the cells of the sidecar against the blobs commitments.
+func VerifyDataColumnSidecarKZGProofs(sidecar *cltypes.DataColumnSidecar) error {
+	n := sidecar.Column.Len()
+	if n != sidecar.KzgCommitments.Len() || n != sidecar.KzgProofs.Len() {
+		return ErrColumnLengthMismatch
+	}
+	commitments := make([]goethkzg.KZGCommitment, n)
+	cellIndices := make([]uint64, n)
+	cells := make([]*kzg.Cell, n)
+	proofs := make([]goethkzg.KZGProof, n)
+	for i := 0; i < n; i++ {
+		commitments[i] = goethkzg.KZGCommitment(*sidecar.KzgCommitments.Get(i))
+		cellIndices[i] = sidecar.Index
+		cells[i] = (*kzg.Cell)(sidecar.Column.Get(i))
+		proofs[i] = goethkzg.KZGProof(*sidecar.KzgProofs.Get(i))
+	}
+	return kzg.VerifyCellKZGProofBatch(commitments, cellIndices, cells, proofs)
+}
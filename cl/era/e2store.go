@@ -0,0 +1,126 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package era
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/golang/snappy"
+)
+
+// e2store entry types, the 2 type bytes are read as a little endian integer.
+const (
+	TypeVersion                     uint16 = 0x3265
+	TypeCompressedSignedBeaconBlock uint16 = 0x0001
+	TypeCompressedBeaconState       uint16 = 0x0002
+	TypeSlotIndex                   uint16 = 0x3269
+)
+
+// headerSize is the size of an e2store entry header: type (2 bytes), length (4 bytes) and 2 reserved bytes.
+const headerSize = 8
+
+var ErrInvalidEntry = errors.New("invalid e2store entry")
+
+// writeEntry writes an e2store entry and returns the amount of bytes written.
+func writeEntry(w io.Writer, typ uint16, data []byte) (int64, error) {
+	var header [headerSize]byte
+	binary.LittleEndian.PutUint16(header[:2], typ)
+	binary.LittleEndian.PutUint32(header[2:6], uint32(len(data)))
+	if _, err := w.Write(header[:]); err != nil {
+		return 0, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return 0, err
+	}
+	return int64(headerSize + len(data)), nil
+}
+
+// readHeader reads the header of the entry starting at offset.
+func readHeader(r io.ReaderAt, offset int64) (typ uint16, length uint32, err error) {
+	var header [headerSize]byte
+	if _, err = r.ReadAt(header[:], offset); err != nil {
+		return 0, 0, err
+	}
+	if header[6] != 0 || header[7] != 0 {
+		return 0, 0, fmt.Errorf("%w: non-zero reserved bytes at offset %d", ErrInvalidEntry, offset)
+	}
+	return binary.LittleEndian.Uint16(header[:2]), binary.LittleEndian.Uint32(header[2:6]), nil
+}
+
+// readEntry reads the entry starting at offset and checks its type.
+func readEntry(r io.ReaderAt, offset int64, expectedType uint16) ([]byte, error) {
+	typ, length, err := readHeader(r, offset)
+	if err != nil {
+		return nil, err
+	}
+	if typ != expectedType {
+		return nil, fmt.Errorf("%w: expected type %#04x at offset %d, got %#04x", ErrInvalidEntry, expectedType, offset, typ)
+	}
+	data := make([]byte, length)
+	if _, err := r.ReadAt(data, offset+headerSize); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// compress encodes data with the snappy framing format used by era files.
+func compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := snappy.NewBufferedWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decompress(data []byte) ([]byte, error) {
+	return io.ReadAll(snappy.NewReader(bytes.NewReader(data)))
+}
+
+// encodeSlotIndex encodes a SlotIndex record: starting-slot | offset * count | count.
+func encodeSlotIndex(startSlot uint64, offsets []int64) []byte {
+	buf := make([]byte, 8*(len(offsets)+2))
+	binary.LittleEndian.PutUint64(buf, startSlot)
+	for i, offset := range offsets {
+		binary.LittleEndian.PutUint64(buf[8*(i+1):], uint64(offset))
+	}
+	binary.LittleEndian.PutUint64(buf[len(buf)-8:], uint64(len(offsets)))
+	return buf
+}
+
+func decodeSlotIndex(buf []byte) (startSlot uint64, offsets []int64, err error) {
+	if len(buf) < 16 || len(buf)%8 != 0 {
+		return 0, nil, fmt.Errorf("%w: bad slot index length %d", ErrInvalidEntry, len(buf))
+	}
+	count := binary.LittleEndian.Uint64(buf[len(buf)-8:])
+	if count != uint64(len(buf)/8-2) {
+		return 0, nil, fmt.Errorf("%w: slot index count %d does not match its length", ErrInvalidEntry, count)
+	}
+	startSlot = binary.LittleEndian.Uint64(buf)
+	offsets = make([]int64, count)
+	for i := range offsets {
+		offsets[i] = int64(binary.LittleEndian.Uint64(buf[8*(i+1):]))
+	}
+	return startSlot, offsets, nil
+}
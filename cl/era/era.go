@@ -0,0 +1,329 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package era implements the .era archive format for beacon chain history.
+//
+// An era file is an e2store file laid out as:
+//
+//	Version | block* | state | block-index | state-index
+//
+// era N holds the blocks of slots [(N-1)*SLOTS_PER_HISTORICAL_ROOT, N*SLOTS_PER_HISTORICAL_ROOT) and the state
+// at slot N*SLOTS_PER_HISTORICAL_ROOT. The genesis era (0) only holds the genesis state and no block index.
+package era
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/phase1/core/state"
+)
+
+var (
+	ErrBlockOutOfRange  = errors.New("block is outside of the era slot range")
+	ErrBlockOutOfOrder  = errors.New("blocks must be written in increasing slot order")
+	ErrWriterFinalized  = errors.New("era writer is already finalized")
+	ErrInvalidStateSlot = errors.New("era state is not at the era boundary")
+)
+
+// Filename returns the canonical name of an era file: <config-name>-<era-number>-<short-historical-root>.era.
+// root is the historical root of the era, or the genesis validators root for era 0.
+func Filename(beaconCfg *clparams.BeaconChainConfig, era uint64, root libcommon.Hash) string {
+	return fmt.Sprintf("%s-%05d-%x.era", beaconCfg.ConfigName, era, root[:4])
+}
+
+// FindFile returns the path of the file of the given era in dir, if there is one.
+func FindFile(dir string, beaconCfg *clparams.BeaconChainConfig, era uint64) (string, bool, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, fmt.Sprintf("%s-%05d-*.era", beaconCfg.ConfigName, era)))
+	if err != nil || len(matches) == 0 {
+		return "", false, err
+	}
+	return matches[0], true, nil
+}
+
+// StartSlot returns the first slot whose block belongs to the given era.
+func StartSlot(beaconCfg *clparams.BeaconChainConfig, era uint64) uint64 {
+	if era == 0 {
+		return 0
+	}
+	return (era - 1) * beaconCfg.SlotsPerHistoricalRoot
+}
+
+// StateSlot returns the slot of the state stored in the given era.
+func StateSlot(beaconCfg *clparams.BeaconChainConfig, era uint64) uint64 {
+	return era * beaconCfg.SlotsPerHistoricalRoot
+}
+
+// Writer writes a single era file. Blocks are written first, then Finalize writes the state and the indices.
+type Writer struct {
+	w         io.Writer
+	beaconCfg *clparams.BeaconChainConfig
+	era       uint64
+
+	offset       int64
+	blockOffsets []int64 // absolute offsets of the blocks, 0 for empty slots
+	lastSlot     *uint64
+	finalized    bool
+}
+
+// NewWriter creates a writer for the given era and writes the version entry.
+func NewWriter(w io.Writer, beaconCfg *clparams.BeaconChainConfig, era uint64) (*Writer, error) {
+	n, err := writeEntry(w, TypeVersion, nil)
+	if err != nil {
+		return nil, err
+	}
+	ew := &Writer{
+		w:         w,
+		beaconCfg: beaconCfg,
+		era:       era,
+		offset:    n,
+	}
+	if era > 0 {
+		ew.blockOffsets = make([]int64, beaconCfg.SlotsPerHistoricalRoot)
+	}
+	return ew, nil
+}
+
+// WriteBlock appends a block to the era, blocks must come in increasing slot order.
+func (e *Writer) WriteBlock(block *cltypes.SignedBeaconBlock) error {
+	if e.finalized {
+		return ErrWriterFinalized
+	}
+	slot := block.Block.Slot
+	startSlot := StartSlot(e.beaconCfg, e.era)
+	if e.era == 0 || slot < startSlot || slot >= startSlot+e.beaconCfg.SlotsPerHistoricalRoot {
+		return fmt.Errorf("%w: slot %d, era %d", ErrBlockOutOfRange, slot, e.era)
+	}
+	if e.lastSlot != nil && slot <= *e.lastSlot {
+		return fmt.Errorf("%w: slot %d after %d", ErrBlockOutOfOrder, slot, *e.lastSlot)
+	}
+	encoded, err := block.EncodeSSZ(nil)
+	if err != nil {
+		return err
+	}
+	compressed, err := compress(encoded)
+	if err != nil {
+		return err
+	}
+	n, err := writeEntry(e.w, TypeCompressedSignedBeaconBlock, compressed)
+	if err != nil {
+		return err
+	}
+	e.blockOffsets[slot-startSlot] = e.offset
+	e.offset += n
+	e.lastSlot = &slot
+	return nil
+}
+
+// Finalize writes the era state followed by the block and state indices.
+func (e *Writer) Finalize(s *state.CachingBeaconState) error {
+	if e.finalized {
+		return ErrWriterFinalized
+	}
+	e.finalized = true
+	if s.Slot() != StateSlot(e.beaconCfg, e.era) {
+		return fmt.Errorf("%w: slot %d, era %d", ErrInvalidStateSlot, s.Slot(), e.era)
+	}
+	encoded, err := s.EncodeSSZ(nil)
+	if err != nil {
+		return err
+	}
+	compressed, err := compress(encoded)
+	if err != nil {
+		return err
+	}
+	stateOffset := e.offset
+	n, err := writeEntry(e.w, TypeCompressedBeaconState, compressed)
+	if err != nil {
+		return err
+	}
+	e.offset += n
+
+	if e.era > 0 {
+		// offsets in the index are relative to the start of the index entry itself.
+		relative := make([]int64, len(e.blockOffsets))
+		for i, offset := range e.blockOffsets {
+			if offset != 0 {
+				relative[i] = offset - e.offset
+			}
+		}
+		n, err := writeEntry(e.w, TypeSlotIndex, encodeSlotIndex(StartSlot(e.beaconCfg, e.era), relative))
+		if err != nil {
+			return err
+		}
+		e.offset += n
+	}
+	n, err = writeEntry(e.w, TypeSlotIndex, encodeSlotIndex(s.Slot(), []int64{stateOffset - e.offset}))
+	if err != nil {
+		return err
+	}
+	e.offset += n
+	return nil
+}
+
+// Reader gives random access to the blocks and the state of an era file.
+type Reader struct {
+	r         io.ReaderAt
+	closer    io.Closer
+	beaconCfg *clparams.BeaconChainConfig
+
+	era          uint64
+	stateOffset  int64
+	blockOffsets []int64 // absolute offsets of the blocks, 0 for empty slots
+}
+
+// Open opens the era file at path.
+func Open(path string, beaconCfg *clparams.BeaconChainConfig) (*Reader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	r, err := NewReader(f, info.Size(), beaconCfg)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	r.closer = f
+	return r, nil
+}
+
+// NewReader parses the indices of an era file of the given size.
+func NewReader(r io.ReaderAt, size int64, beaconCfg *clparams.BeaconChainConfig) (*Reader, error) {
+	if _, err := readEntry(r, 0, TypeVersion); err != nil {
+		return nil, err
+	}
+	// the state index always holds a single offset.
+	stateIndexOffset := size - headerSize - 8*3
+	if stateIndexOffset < headerSize {
+		return nil, fmt.Errorf("%w: file too small", ErrInvalidEntry)
+	}
+	buf, err := readEntry(r, stateIndexOffset, TypeSlotIndex)
+	if err != nil {
+		return nil, err
+	}
+	stateSlot, offsets, err := decodeSlotIndex(buf)
+	if err != nil {
+		return nil, err
+	}
+	if len(offsets) != 1 || stateSlot%beaconCfg.SlotsPerHistoricalRoot != 0 {
+		return nil, fmt.Errorf("%w: bad state index", ErrInvalidEntry)
+	}
+	era := &Reader{
+		r:           r,
+		beaconCfg:   beaconCfg,
+		era:         stateSlot / beaconCfg.SlotsPerHistoricalRoot,
+		stateOffset: stateIndexOffset + offsets[0],
+	}
+	_, stateLength, err := readHeader(r, era.stateOffset)
+	if err != nil {
+		return nil, err
+	}
+	blockIndexOffset := era.stateOffset + headerSize + int64(stateLength)
+	if era.era == 0 {
+		if blockIndexOffset != stateIndexOffset {
+			return nil, fmt.Errorf("%w: unexpected block index in genesis era", ErrInvalidEntry)
+		}
+		return era, nil
+	}
+
+	if buf, err = readEntry(r, blockIndexOffset, TypeSlotIndex); err != nil {
+		return nil, err
+	}
+	startSlot, offsets, err := decodeSlotIndex(buf)
+	if err != nil {
+		return nil, err
+	}
+	if startSlot != StartSlot(beaconCfg, era.era) || uint64(len(offsets)) != beaconCfg.SlotsPerHistoricalRoot {
+		return nil, fmt.Errorf("%w: bad block index", ErrInvalidEntry)
+	}
+	era.blockOffsets = make([]int64, len(offsets))
+	for i, offset := range offsets {
+		if offset != 0 {
+			era.blockOffsets[i] = blockIndexOffset + offset
+		}
+	}
+	return era, nil
+}
+
+// Era returns the era number of the file.
+func (r *Reader) Era() uint64 {
+	return r.era
+}
+
+// ReadBlock reads the block at the given slot, it returns nil if the slot is empty.
+func (r *Reader) ReadBlock(slot uint64) (*cltypes.SignedBeaconBlock, error) {
+	startSlot := StartSlot(r.beaconCfg, r.era)
+	if r.era == 0 || slot < startSlot || slot >= startSlot+uint64(len(r.blockOffsets)) {
+		return nil, fmt.Errorf("%w: slot %d, era %d", ErrBlockOutOfRange, slot, r.era)
+	}
+	offset := r.blockOffsets[slot-startSlot]
+	if offset == 0 {
+		return nil, nil
+	}
+	buf, err := readEntry(r.r, offset, TypeCompressedSignedBeaconBlock)
+	if err != nil {
+		return nil, err
+	}
+	if buf, err = decompress(buf); err != nil {
+		return nil, err
+	}
+	block := cltypes.NewSignedBeaconBlock(r.beaconCfg)
+	version := r.beaconCfg.GetCurrentStateVersion(slot / r.beaconCfg.SlotsPerEpoch)
+	if err := block.DecodeSSZ(buf, int(version)); err != nil {
+		return nil, err
+	}
+	if block.Block.Slot != slot {
+		return nil, fmt.Errorf("%w: block at index slot %d has slot %d", ErrInvalidEntry, slot, block.Block.Slot)
+	}
+	return block, nil
+}
+
+// ReadState reads the state at the end of the era.
+func (r *Reader) ReadState() (*state.CachingBeaconState, error) {
+	buf, err := readEntry(r.r, r.stateOffset, TypeCompressedBeaconState)
+	if err != nil {
+		return nil, err
+	}
+	if buf, err = decompress(buf); err != nil {
+		return nil, err
+	}
+	s := state.New(r.beaconCfg)
+	slot := StateSlot(r.beaconCfg, r.era)
+	if err := s.DecodeSSZ(buf, int(r.beaconCfg.GetCurrentStateVersion(slot/r.beaconCfg.SlotsPerEpoch))); err != nil {
+		return nil, err
+	}
+	if s.Slot() != slot {
+		return nil, fmt.Errorf("%w: slot %d, era %d", ErrInvalidStateSlot, s.Slot(), r.era)
+	}
+	return s, nil
+}
+
+func (r *Reader) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+	return r.closer.Close()
+}
@@ -0,0 +1,177 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package era
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv/memdb"
+	"github.com/erigontech/erigon/cl/antiquary/tests"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/persistence/beacon_indicies"
+	"github.com/erigontech/erigon/cl/phase1/core/state"
+)
+
+// testBeaconConfig returns a mainnet config where the bellatrix test chain is in the bellatrix fork.
+func testBeaconConfig() *clparams.BeaconChainConfig {
+	cfg := clparams.MainnetBeaconConfig
+	cfg.AltairForkEpoch = 0
+	cfg.BellatrixForkEpoch = 0
+	return &cfg
+}
+
+// writeTestEra writes the bellatrix test chain as era 1, with its post state moved to the era boundary.
+// The block at index skip, if any, is left out of the era file.
+func writeTestEra(t *testing.T, skip int) (*bytes.Reader, *state.CachingBeaconState) {
+	cfg := testBeaconConfig()
+	blocks, _, postState := tests.GetBellatrixRandom()
+	eraState, err := postState.Copy()
+	require.NoError(t, err)
+	// fill the block roots vector as the slot processing up to the era boundary would: an empty slot repeats
+	// the root of the previous block, and the slots before the chain repeat its parent.
+	blockRoot := libcommon.Hash(blocks[0].Block.ParentRoot)
+	next := 0
+	for slot := uint64(0); slot < cfg.SlotsPerHistoricalRoot; slot++ {
+		if next < len(blocks) && blocks[next].Block.Slot == slot {
+			blockRoot, err = blocks[next].Block.HashSSZ()
+			require.NoError(t, err)
+			next++
+		}
+		eraState.SetBlockRootAt(int(slot), blockRoot)
+	}
+	eraState.SetSlot(StateSlot(cfg, 1))
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, cfg, 1)
+	require.NoError(t, err)
+	for i, block := range blocks {
+		if i == skip {
+			continue
+		}
+		require.NoError(t, w.WriteBlock(block))
+	}
+	require.ErrorIs(t, w.WriteBlock(blocks[0]), ErrBlockOutOfOrder)
+	require.NoError(t, w.Finalize(eraState))
+	return bytes.NewReader(buf.Bytes()), eraState
+}
+
+func TestEraRoundTrip(t *testing.T) {
+	cfg := testBeaconConfig()
+	blocks, _, _ := tests.GetBellatrixRandom()
+	raw, eraState := writeTestEra(t, -1)
+
+	r, err := NewReader(raw, raw.Size(), cfg)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), r.Era())
+
+	for _, expected := range blocks {
+		block, err := r.ReadBlock(expected.Block.Slot)
+		require.NoError(t, err)
+		expectedRoot, err := expected.HashSSZ()
+		require.NoError(t, err)
+		root, err := block.HashSSZ()
+		require.NoError(t, err)
+		require.Equal(t, expectedRoot, root)
+	}
+	block, err := r.ReadBlock(1)
+	require.NoError(t, err)
+	require.Nil(t, block)
+	_, err = r.ReadBlock(StateSlot(cfg, 1))
+	require.ErrorIs(t, err, ErrBlockOutOfRange)
+
+	s, err := r.ReadState()
+	require.NoError(t, err)
+	expectedRoot, err := eraState.HashSSZ()
+	require.NoError(t, err)
+	root, err := s.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, expectedRoot, root)
+}
+
+func TestImportBlocks(t *testing.T) {
+	ctx := context.Background()
+	blocks, _, _ := tests.GetBellatrixRandom()
+	raw, eraState := writeTestEra(t, -1)
+	r, err := NewReader(raw, raw.Size(), testBeaconConfig())
+	require.NoError(t, err)
+
+	summary, err := HistoricalSummary(eraState)
+	require.NoError(t, err)
+	historicalRoot, err := summary.HashSSZ()
+	require.NoError(t, err)
+
+	// a trusted state which does not commit to the era yet
+	trusted, err := eraState.Copy()
+	require.NoError(t, err)
+	db := memdb.NewTestDB(t)
+	_, err = ImportBlocks(ctx, db, r, trusted)
+	require.ErrorIs(t, err, ErrEraNotCovered)
+
+	// a trusted state which commits to a different era
+	wrong, err := eraState.Copy()
+	require.NoError(t, err)
+	wrong.AddHistoricalRoot(libcommon.Hash{1})
+	_, err = ImportBlocks(ctx, db, r, wrong)
+	require.ErrorIs(t, err, ErrHistoricalMismatch)
+
+	trusted.AddHistoricalRoot(historicalRoot)
+	imported, err := ImportBlocks(ctx, db, r, trusted)
+	require.NoError(t, err)
+	require.Equal(t, uint64(len(blocks)), imported)
+
+	tx, err := db.BeginRo(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	for _, block := range blocks {
+		blockRoot, err := block.Block.HashSSZ()
+		require.NoError(t, err)
+		canonical, err := beacon_indicies.ReadCanonicalBlockRoot(tx, block.Block.Slot)
+		require.NoError(t, err)
+		require.Equal(t, libcommon.Hash(blockRoot), canonical)
+	}
+}
+
+func TestVerifyBlockMissing(t *testing.T) {
+	cfg := testBeaconConfig()
+	blocks, _, _ := tests.GetBellatrixRandom()
+	skipped := blocks[len(blocks)/2]
+	raw, eraState := writeTestEra(t, len(blocks)/2)
+	r, err := NewReader(raw, raw.Size(), cfg)
+	require.NoError(t, err)
+
+	block, err := r.ReadBlock(skipped.Block.Slot)
+	require.NoError(t, err)
+	require.Nil(t, block)
+	require.ErrorIs(t, VerifyBlock(eraState, skipped.Block.Slot, nil), ErrMissingBlock)
+	// an empty slot of the chain repeats the previous block root
+	require.NoError(t, VerifyBlock(eraState, blocks[len(blocks)-1].Block.Slot+1, nil))
+
+	summary, err := HistoricalSummary(eraState)
+	require.NoError(t, err)
+	historicalRoot, err := summary.HashSSZ()
+	require.NoError(t, err)
+	trusted, err := eraState.Copy()
+	require.NoError(t, err)
+	trusted.AddHistoricalRoot(historicalRoot)
+	_, err = ImportBlocks(context.Background(), memdb.NewTestDB(t), r, trusted)
+	require.ErrorIs(t, err, ErrMissingBlock)
+}
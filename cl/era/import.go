@@ -0,0 +1,181 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package era
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/persistence/beacon_indicies"
+	"github.com/erigontech/erigon/cl/phase1/core/state"
+)
+
+var (
+	ErrEraNotCovered      = errors.New("era is not covered by the trusted state's historical roots and summaries")
+	ErrHistoricalMismatch = errors.New("era state does not match the trusted historical root")
+	ErrBlockRootMismatch  = errors.New("block root does not match the era state's block roots")
+	ErrMissingBlock       = errors.New("era file misses a block of the era state's block roots")
+)
+
+// HistoricalSummary computes the historical summary of an era from the state at its boundary.
+func HistoricalSummary(eraState *state.CachingBeaconState) (*cltypes.HistoricalSummary, error) {
+	blockSummaryRoot, err := eraState.BlockRoots().HashSSZ()
+	if err != nil {
+		return nil, err
+	}
+	stateSummaryRoot, err := eraState.StateRoots().HashSSZ()
+	if err != nil {
+		return nil, err
+	}
+	return &cltypes.HistoricalSummary{
+		BlockSummaryRoot: blockSummaryRoot,
+		StateSummaryRoot: stateSummaryRoot,
+	}, nil
+}
+
+// TrustedHistory holds the historical_roots (pre-capella eras) and the historical_summaries (post-capella eras) of a
+// trusted state, such as a finalized checkpoint state, so that eras can be verified without keeping the state around.
+type TrustedHistory struct {
+	genesisValidatorsRoot libcommon.Hash
+	historicalRoots       []libcommon.Hash
+	historicalSummaries   []cltypes.HistoricalSummary
+}
+
+func NewTrustedHistory(trusted *state.CachingBeaconState) *TrustedHistory {
+	h := &TrustedHistory{
+		genesisValidatorsRoot: trusted.GenesisValidatorsRoot(),
+		historicalRoots:       make([]libcommon.Hash, 0, trusted.HistoricalRootsLength()),
+		historicalSummaries:   make([]cltypes.HistoricalSummary, 0, trusted.HistoricalSummariesLength()),
+	}
+	for i := 0; i < int(trusted.HistoricalRootsLength()); i++ {
+		h.historicalRoots = append(h.historicalRoots, trusted.HistoricalRoot(i))
+	}
+	for i := 0; i < int(trusted.HistoricalSummariesLength()); i++ {
+		h.historicalSummaries = append(h.historicalSummaries, *trusted.HistoricalSummary(i))
+	}
+	return h
+}
+
+// VerifyState checks the state of an era against the history of a trusted state.
+func VerifyState(era uint64, eraState, trusted *state.CachingBeaconState) error {
+	return NewTrustedHistory(trusted).VerifyState(era, eraState)
+}
+
+// VerifyState checks the state of an era against the trusted history.
+func (h *TrustedHistory) VerifyState(era uint64, eraState *state.CachingBeaconState) error {
+	if eraState.Slot() != StateSlot(eraState.BeaconConfig(), era) {
+		return fmt.Errorf("%w: slot %d, era %d", ErrInvalidStateSlot, eraState.Slot(), era)
+	}
+	if era == 0 {
+		if eraState.GenesisValidatorsRoot() != h.genesisValidatorsRoot {
+			return fmt.Errorf("%w: genesis validators root mismatch", ErrHistoricalMismatch)
+		}
+		return nil
+	}
+	summary, err := HistoricalSummary(eraState)
+	if err != nil {
+		return err
+	}
+	idx := era - 1
+	// historical_roots were frozen at capella, the eras after it are accumulated in historical_summaries.
+	if rootsLength := uint64(len(h.historicalRoots)); idx < rootsLength {
+		// hash_tree_root(HistoricalBatch) has the same layout as a historical summary.
+		root, err := summary.HashSSZ()
+		if err != nil {
+			return err
+		}
+		if root != h.historicalRoots[idx] {
+			return fmt.Errorf("%w: era %d", ErrHistoricalMismatch, era)
+		}
+		return nil
+	} else if idx-rootsLength < uint64(len(h.historicalSummaries)) {
+		if *summary != h.historicalSummaries[idx-rootsLength] {
+			return fmt.Errorf("%w: era %d", ErrHistoricalMismatch, era)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: era %d", ErrEraNotCovered, era)
+}
+
+// VerifyBlock checks the block of an era file at slot, nil for an empty slot, against the block roots of the verified
+// era state. The block root of an empty slot repeats the one of the previous slot, so a block missing from the era
+// file is caught, except at the first slot of the era, whose previous block root is not in the era state.
+func VerifyBlock(eraState *state.CachingBeaconState, slot uint64, block *cltypes.SignedBeaconBlock) error {
+	slotsPerHistoricalRoot := eraState.BeaconConfig().SlotsPerHistoricalRoot
+	expected := eraState.BlockRoots().Get(int(slot % slotsPerHistoricalRoot))
+	if block == nil {
+		if slot%slotsPerHistoricalRoot != 0 && expected != eraState.BlockRoots().Get(int((slot-1)%slotsPerHistoricalRoot)) {
+			return fmt.Errorf("%w: slot %d", ErrMissingBlock, slot)
+		}
+		return nil
+	}
+	blockRoot, err := block.Block.HashSSZ()
+	if err != nil {
+		return err
+	}
+	// the verified block roots vector commits to every block of the era.
+	if blockRoot != expected {
+		return fmt.Errorf("%w: slot %d", ErrBlockRootMismatch, slot)
+	}
+	return nil
+}
+
+// ImportBlocks verifies an era file against the trusted state and writes its blocks to the indicies database
+// as canonical. It returns the amount of blocks imported.
+func ImportBlocks(ctx context.Context, db kv.RwDB, r *Reader, trusted *state.CachingBeaconState) (uint64, error) {
+	eraState, err := r.ReadState()
+	if err != nil {
+		return 0, err
+	}
+	if err := VerifyState(r.Era(), eraState, trusted); err != nil {
+		return 0, err
+	}
+	if r.Era() == 0 {
+		return 0, nil
+	}
+
+	beaconCfg := eraState.BeaconConfig()
+	tx, err := db.BeginRw(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var imported uint64
+	startSlot := StartSlot(beaconCfg, r.Era())
+	for slot := startSlot; slot < startSlot+beaconCfg.SlotsPerHistoricalRoot; slot++ {
+		block, err := r.ReadBlock(slot)
+		if err != nil {
+			return 0, err
+		}
+		if err := VerifyBlock(eraState, slot, block); err != nil {
+			return 0, err
+		}
+		if block == nil {
+			continue
+		}
+		if err := beacon_indicies.WriteBeaconBlockAndIndicies(ctx, tx, block, true); err != nil {
+			return 0, err
+		}
+		imported++
+	}
+	return imported, tx.Commit()
+}
@@ -35,6 +35,7 @@ const (
 	TopicNameLightClientOptimisticUpdate = "light_client_optimistic_update"
 
 	TopicNamePrefixBlobSidecar       = "blob_sidecar_%d"
+	TopicNamePrefixDataColumnSidecar = "data_column_sidecar_%d"
 	TopicNamePrefixBeaconAttestation = "beacon_attestation_%d"
 	TopicNamePrefixSyncCommittee     = "sync_committee_%d"
 )
@@ -43,6 +44,10 @@ func TopicNameBlobSidecar(d uint64) string {
 	return fmt.Sprintf(TopicNamePrefixBlobSidecar, d)
 }
 
+func TopicNameDataColumnSidecar(d uint64) string {
+	return fmt.Sprintf(TopicNamePrefixDataColumnSidecar, d)
+}
+
 func TopicNameBeaconAttestation(d uint64) string {
 	return fmt.Sprintf(TopicNamePrefixBeaconAttestation, d)
 }
@@ -55,6 +60,10 @@ func IsTopicBlobSidecar(d string) bool {
 	return strings.Contains(d, "blob_sidecar_")
 }
 
+func IsTopicDataColumnSidecar(d string) bool {
+	return strings.Contains(d, "data_column_sidecar_")
+}
+
 func IsTopicSyncCommittee(d string) bool {
 	return strings.Contains(d, "sync_committee_") && !strings.Contains(d, TopicNameSyncCommitteeContributionAndProof)
 }
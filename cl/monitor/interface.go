@@ -1,6 +1,7 @@
 package monitor
 
 import (
+	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon/cl/cltypes"
 	"github.com/erigontech/erigon/cl/phase1/core/state"
 )
@@ -8,7 +9,12 @@ import (
 //go:generate mockgen -typed=true -destination=mock_services/validator_monitor_mock.go -package=mock_services . ValidatorMonitor
 type ValidatorMonitor interface {
 	ObserveValidator(vid uint64)
+	// ObserveValidatorPubkey observes the validator with the given public key as soon as it is in the state.
+	ObserveValidatorPubkey(pubkey common.Bytes48)
 	RemoveValidator(vid uint64)
+	ObservedValidators() []uint64
+	// ValidatorSummary returns the performance of an observed validator over the last reported epochs.
+	ValidatorSummary(vid uint64) (*ValidatorSummary, bool)
 	OnNewBlock(state *state.CachingBeaconState, block *cltypes.BeaconBlock) error
 }
 
@@ -16,8 +22,18 @@ type dummyValdatorMonitor struct{}
 
 func (d *dummyValdatorMonitor) ObserveValidator(vid uint64) {}
 
+func (d *dummyValdatorMonitor) ObserveValidatorPubkey(pubkey common.Bytes48) {}
+
 func (d *dummyValdatorMonitor) RemoveValidator(vid uint64) {}
 
+func (d *dummyValdatorMonitor) ObservedValidators() []uint64 {
+	return nil
+}
+
+func (d *dummyValdatorMonitor) ValidatorSummary(vid uint64) (*ValidatorSummary, bool) {
+	return nil, false
+}
+
 func (d *dummyValdatorMonitor) OnNewBlock(_ *state.CachingBeaconState, _ *cltypes.BeaconBlock) error {
 	return nil
 }
@@ -1,6 +1,10 @@
 package monitor
 
-import "github.com/erigontech/erigon-lib/metrics"
+import (
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/metrics"
+)
 
 var (
 	// metricAttestHit is the number of attestations that hit for those validators we observe within current_epoch-2
@@ -12,3 +16,53 @@ var (
 	// metricProposerMiss is the number of proposals that miss for those validators we observe in previous slot
 	metricProposerMiss = metrics.GetOrCreateCounter("validator_proposal_miss")
 )
+
+// per-validator metrics, labelled with the validator index
+const (
+	metricNameAttestationHit    = "validator_monitor_attestation_hit"
+	metricNameAttestationMiss   = "validator_monitor_attestation_miss"
+	metricNameHeadHit           = "validator_monitor_attestation_head_hit"
+	metricNameHeadMiss          = "validator_monitor_attestation_head_miss"
+	metricNameTargetHit         = "validator_monitor_attestation_target_hit"
+	metricNameTargetMiss        = "validator_monitor_attestation_target_miss"
+	metricNameSourceHit         = "validator_monitor_attestation_source_hit"
+	metricNameSourceMiss        = "validator_monitor_attestation_source_miss"
+	metricNameInclusionDistance = "validator_monitor_attestation_inclusion_distance"
+	metricNameProposalHit       = "validator_monitor_proposal_hit"
+	metricNameProposalMiss      = "validator_monitor_proposal_miss"
+	metricNameSyncCommitteeHit  = "validator_monitor_sync_committee_hit"
+	metricNameSyncCommitteeMiss = "validator_monitor_sync_committee_miss"
+	metricNameBalance           = "validator_monitor_balance_gwei"
+	metricNameBalanceDelta      = "validator_monitor_balance_delta_gwei"
+)
+
+func validatorCounter(name string, vid uint64) metrics.Counter {
+	return metrics.GetOrCreateCounter(fmt.Sprintf(`%s{validator="%d"}`, name, vid))
+}
+
+func validatorGauge(name string, vid uint64) metrics.Gauge {
+	return metrics.GetOrCreateGauge(fmt.Sprintf(`%s{validator="%d"}`, name, vid))
+}
+
+func hitOrMiss(hit bool, hitName, missName string, vid uint64) {
+	if hit {
+		validatorCounter(hitName, vid).AddInt(1)
+	} else {
+		validatorCounter(missName, vid).AddInt(1)
+	}
+}
+
+// reportValidatorMetrics updates the metrics of an observed validator with the summary of a reported epoch.
+func reportValidatorMetrics(vid uint64, summary *EpochSummary) {
+	hitOrMiss(summary.AttestationIncluded, metricNameAttestationHit, metricNameAttestationMiss, vid)
+	hitOrMiss(summary.HeadCorrect, metricNameHeadHit, metricNameHeadMiss, vid)
+	hitOrMiss(summary.TargetCorrect, metricNameTargetHit, metricNameTargetMiss, vid)
+	hitOrMiss(summary.SourceCorrect, metricNameSourceHit, metricNameSourceMiss, vid)
+	if summary.AttestationIncluded {
+		validatorGauge(metricNameInclusionDistance, vid).SetUint64(summary.InclusionDistance)
+	}
+	validatorCounter(metricNameSyncCommitteeHit, vid).AddUint64(summary.SyncCommitteeHits)
+	validatorCounter(metricNameSyncCommitteeMiss, vid).AddUint64(summary.SyncCommitteeMisses)
+	validatorGauge(metricNameBalance, vid).SetUint64(summary.Balance)
+	validatorGauge(metricNameBalanceDelta, vid).SetInt(int(summary.BalanceDelta))
+}
@@ -12,7 +12,9 @@ package mock_services
 import (
 	reflect "reflect"
 
+	common "github.com/erigontech/erigon-lib/common"
 	cltypes "github.com/erigontech/erigon/cl/cltypes"
+	monitor "github.com/erigontech/erigon/cl/monitor"
 	state "github.com/erigontech/erigon/cl/phase1/core/state"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -76,6 +78,80 @@ func (c *MockValidatorMonitorObserveValidatorCall) DoAndReturn(f func(uint64)) *
 	return c
 }
 
+// ObserveValidatorPubkey mocks base method.
+func (m *MockValidatorMonitor) ObserveValidatorPubkey(arg0 common.Bytes48) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ObserveValidatorPubkey", arg0)
+}
+
+// ObserveValidatorPubkey indicates an expected call of ObserveValidatorPubkey.
+func (mr *MockValidatorMonitorMockRecorder) ObserveValidatorPubkey(arg0 any) *MockValidatorMonitorObserveValidatorPubkeyCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObserveValidatorPubkey", reflect.TypeOf((*MockValidatorMonitor)(nil).ObserveValidatorPubkey), arg0)
+	return &MockValidatorMonitorObserveValidatorPubkeyCall{Call: call}
+}
+
+// MockValidatorMonitorObserveValidatorPubkeyCall wrap *gomock.Call
+type MockValidatorMonitorObserveValidatorPubkeyCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockValidatorMonitorObserveValidatorPubkeyCall) Return() *MockValidatorMonitorObserveValidatorPubkeyCall {
+	c.Call = c.Call.Return()
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockValidatorMonitorObserveValidatorPubkeyCall) Do(f func(common.Bytes48)) *MockValidatorMonitorObserveValidatorPubkeyCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockValidatorMonitorObserveValidatorPubkeyCall) DoAndReturn(f func(common.Bytes48)) *MockValidatorMonitorObserveValidatorPubkeyCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ObservedValidators mocks base method.
+func (m *MockValidatorMonitor) ObservedValidators() []uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ObservedValidators")
+	ret0, _ := ret[0].([]uint64)
+	return ret0
+}
+
+// ObservedValidators indicates an expected call of ObservedValidators.
+func (mr *MockValidatorMonitorMockRecorder) ObservedValidators() *MockValidatorMonitorObservedValidatorsCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObservedValidators", reflect.TypeOf((*MockValidatorMonitor)(nil).ObservedValidators))
+	return &MockValidatorMonitorObservedValidatorsCall{Call: call}
+}
+
+// MockValidatorMonitorObservedValidatorsCall wrap *gomock.Call
+type MockValidatorMonitorObservedValidatorsCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockValidatorMonitorObservedValidatorsCall) Return(arg0 []uint64) *MockValidatorMonitorObservedValidatorsCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockValidatorMonitorObservedValidatorsCall) Do(f func() []uint64) *MockValidatorMonitorObservedValidatorsCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockValidatorMonitorObservedValidatorsCall) DoAndReturn(f func() []uint64) *MockValidatorMonitorObservedValidatorsCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // OnNewBlock mocks base method.
 func (m *MockValidatorMonitor) OnNewBlock(arg0 *state.CachingBeaconState, arg1 *cltypes.BeaconBlock) error {
 	m.ctrl.T.Helper()
@@ -149,3 +225,42 @@ func (c *MockValidatorMonitorRemoveValidatorCall) DoAndReturn(f func(uint64)) *M
 	c.Call = c.Call.DoAndReturn(f)
 	return c
 }
+
+// ValidatorSummary mocks base method.
+func (m *MockValidatorMonitor) ValidatorSummary(arg0 uint64) (*monitor.ValidatorSummary, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidatorSummary", arg0)
+	ret0, _ := ret[0].(*monitor.ValidatorSummary)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// ValidatorSummary indicates an expected call of ValidatorSummary.
+func (mr *MockValidatorMonitorMockRecorder) ValidatorSummary(arg0 any) *MockValidatorMonitorValidatorSummaryCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidatorSummary", reflect.TypeOf((*MockValidatorMonitor)(nil).ValidatorSummary), arg0)
+	return &MockValidatorMonitorValidatorSummaryCall{Call: call}
+}
+
+// MockValidatorMonitorValidatorSummaryCall wrap *gomock.Call
+type MockValidatorMonitorValidatorSummaryCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockValidatorMonitorValidatorSummaryCall) Return(arg0 *monitor.ValidatorSummary, arg1 bool) *MockValidatorMonitorValidatorSummaryCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockValidatorMonitorValidatorSummaryCall) Do(f func(uint64) (*monitor.ValidatorSummary, bool)) *MockValidatorMonitorValidatorSummaryCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockValidatorMonitorValidatorSummaryCall) DoAndReturn(f func(uint64) (*monitor.ValidatorSummary, bool)) *MockValidatorMonitorValidatorSummaryCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
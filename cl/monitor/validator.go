@@ -1,6 +1,7 @@
 package monitor
 
 import (
+	"slices"
 	"sync"
 	"time"
 
@@ -15,6 +16,39 @@ import (
 	"github.com/erigontech/erigon/cl/utils/eth_clock"
 )
 
+// summaryHistoryEpochs is the number of reported epochs kept for every observed validator.
+const summaryHistoryEpochs = 64
+
+// EpochSummary is the performance of an observed validator during one epoch.
+type EpochSummary struct {
+	Epoch uint64 `json:"epoch,string"`
+	// AttestationIncluded is true if an attestation of the validator was included on chain. The distance and the
+	// correctness refer to the best of its included attestations.
+	AttestationIncluded bool   `json:"attestation_included"`
+	InclusionDistance   uint64 `json:"inclusion_distance,string"`
+	HeadCorrect         bool   `json:"head_correct"`
+	TargetCorrect       bool   `json:"target_correct"`
+	// SourceCorrect is implied by the inclusion, attestations with a wrong source are invalid.
+	SourceCorrect bool `json:"source_correct"`
+	// ProposedSlots and MissedProposals are the slots of the epoch where the validator was the proposer.
+	ProposedSlots   []uint64 `json:"proposed_slots"`
+	MissedProposals []uint64 `json:"missed_proposals"`
+	// SyncCommitteeHits and SyncCommitteeMisses count the sync aggregates of the blocks of the epoch the validator
+	// participated in or missed, as a member of the current sync committee.
+	SyncCommitteeHits   uint64 `json:"sync_committee_hits,string"`
+	SyncCommitteeMisses uint64 `json:"sync_committee_misses,string"`
+	// Balance is the balance of the validator after the last block of the epoch, BalanceDelta its change since
+	// the previous reported epoch.
+	Balance      uint64 `json:"balance,string"`
+	BalanceDelta int64  `json:"balance_delta,string"`
+}
+
+// ValidatorSummary is the performance of an observed validator over the last reported epochs, oldest first.
+type ValidatorSummary struct {
+	Index  uint64         `json:"index,string"`
+	Epochs []EpochSummary `json:"epochs"`
+}
+
 type validatorMonitorImpl struct {
 	syncedData       *synced_data.SyncedDataManager
 	ethClock         eth_clock.EthereumClock
@@ -32,25 +66,41 @@ func NewValidatorMonitor(
 		return &dummyValdatorMonitor{}
 	}
 
-	m := &validatorMonitorImpl{
+	m := newValidatorMonitor(ethClock, beaconConfig, syncedData)
+	go m.runReportAttesterStatus()
+	go m.runReportProposerStatus()
+	return m
+}
+
+func newValidatorMonitor(ethClock eth_clock.EthereumClock, beaconConfig *clparams.BeaconChainConfig, syncedData *synced_data.SyncedDataManager) *validatorMonitorImpl {
+	return &validatorMonitorImpl{
 		ethClock:         ethClock,
 		beaconCfg:        beaconConfig,
 		syncedData:       syncedData,
 		vaidatorStatuses: newValidatorStatuses(),
 	}
-	go m.runReportAttesterStatus()
-	go m.runReportProposerStatus()
-	return m
 }
 
 func (m *validatorMonitorImpl) ObserveValidator(vid uint64) {
 	m.vaidatorStatuses.addValidator(vid)
 }
 
+func (m *validatorMonitorImpl) ObserveValidatorPubkey(pubkey common.Bytes48) {
+	m.vaidatorStatuses.addPubkey(pubkey)
+}
+
 func (m *validatorMonitorImpl) RemoveValidator(vid uint64) {
 	m.vaidatorStatuses.removeValidator(vid)
 }
 
+func (m *validatorMonitorImpl) ObservedValidators() []uint64 {
+	return m.vaidatorStatuses.observed()
+}
+
+func (m *validatorMonitorImpl) ValidatorSummary(vid uint64) (*ValidatorSummary, bool) {
+	return m.vaidatorStatuses.summary(vid)
+}
+
 func (m *validatorMonitorImpl) OnNewBlock(state *state.CachingBeaconState, block *cltypes.BeaconBlock) error {
 	var (
 		atts         = block.Body.Attestations
@@ -61,6 +111,11 @@ func (m *validatorMonitorImpl) OnNewBlock(state *state.CachingBeaconState, block
 		// skip old blocks
 		return nil
 	}
+	m.vaidatorStatuses.resolvePubkeys(state)
+	observed := m.vaidatorStatuses.observed()
+	if len(observed) == 0 {
+		return nil
+	}
 
 	// todo: maybe launch a goroutine to update attester status
 	// update attester status
@@ -70,54 +125,132 @@ func (m *validatorMonitorImpl) OnNewBlock(state *state.CachingBeaconState, block
 			log.Warn("failed to get attesting indicies", "err", err, "slot", block.Slot, "stateRoot", block.StateRoot)
 			return false
 		}
-		slot := att.AttestantionData().Slot()
+		data := att.AttestantionData()
+		slot := data.Slot()
 		attEpoch := m.ethClock.GetEpochAtSlot(slot)
+		inclusionDistance := block.Slot - slot
+		headCorrect := isBlockRootAt(state, slot, data.BeaconBlockRoot())
+		targetCorrect := isBlockRootAt(state, data.Target().Epoch()*m.beaconCfg.SlotsPerEpoch, data.Target().BlockRoot())
 		for _, vidx := range indicies {
-			status := m.vaidatorStatuses.getValidatorStatus(vidx, attEpoch)
-			if status == nil {
-				continue
-			}
-			status.updateAttesterStatus(att)
+			m.vaidatorStatuses.updateValidatorStatus(vidx, attEpoch, func(status *validatorStatus) {
+				status.updateAttesterStatus(att, inclusionDistance, headCorrect, targetCorrect)
+			})
 		}
 		return true
 	})
 	// update proposer status
-	pIndex := block.ProposerIndex
-	if status := m.vaidatorStatuses.getValidatorStatus(pIndex, blockEpoch); status != nil {
+	m.vaidatorStatuses.updateValidatorStatus(block.ProposerIndex, blockEpoch, func(status *validatorStatus) {
 		status.proposeSlots.Add(block.Slot)
+	})
+	// update sync committee status
+	if block.Version() >= clparams.AltairVersion && block.Body.SyncAggregate != nil {
+		bits := block.Body.SyncAggregate.SyncCommiteeBits
+		for i, pubkey := range state.CurrentSyncCommittee().GetCommittee() {
+			vidx, ok := state.ValidatorIndexByPubkey(pubkey)
+			if !ok {
+				continue
+			}
+			participated := bits[i/8]&(1<<(i%8)) != 0
+			m.vaidatorStatuses.updateValidatorStatus(vidx, blockEpoch, func(status *validatorStatus) {
+				if participated {
+					status.summary.SyncCommitteeHits++
+				} else {
+					status.summary.SyncCommitteeMisses++
+				}
+			})
+		}
+	}
+	// update balances
+	for _, vidx := range observed {
+		balance, err := state.ValidatorBalance(int(vidx))
+		if err != nil {
+			continue
+		}
+		m.vaidatorStatuses.updateValidatorStatus(vidx, blockEpoch, func(status *validatorStatus) {
+			status.summary.Balance = balance
+		})
 	}
 
 	return nil
 }
 
+// isBlockRootAt tells whether root is the canonical block root at slot according to s.
+func isBlockRootAt(s *state.CachingBeaconState, slot uint64, root common.Hash) bool {
+	if slot >= s.Slot() {
+		return false
+	}
+	canonicalRoot, err := s.GetBlockRootAtSlot(slot)
+	return err == nil && canonicalRoot == root
+}
+
 func (m *validatorMonitorImpl) runReportAttesterStatus() {
 	// every epoch seconds
 	epochDuration := time.Duration(m.beaconCfg.SlotsPerEpoch) * time.Duration(m.beaconCfg.SecondsPerSlot) * time.Second
 	ticker := time.NewTicker(epochDuration)
 	for range ticker.C {
 		currentEpoch := m.ethClock.GetCurrentEpoch()
+		if currentEpoch < 2 {
+			continue
+		}
 		// report attester status for current_epoch - 2
-		epoch := currentEpoch - 2
-		hitCount := 0
-		missCount := 0
-		m.vaidatorStatuses.iterate(func(vindex uint64, epochStatuses map[uint64]*validatorStatus) {
-			if status, ok := epochStatuses[epoch]; ok {
-				successAtt := status.attestedBlockRoots.Cardinality()
-				metricAttestHit.AddInt(successAtt)
-				hitCount += successAtt
-				delete(epochStatuses, epoch)
-				log.Debug("[monitor] report attester status hit", "epoch", epoch, "vindex", vindex, "countAttestedBlock", status.attestedBlockRoots.Cardinality())
-			} else {
-				metricAttestMiss.AddInt(1)
-				missCount++
-				log.Debug("[monitor] report attester status miss", "epoch", epoch, "vindex", vindex, "countAttestedBlock", 0)
-			}
-		})
-		log.Info("[monitor] report attester hit/miss", "epoch", epoch, "hitCount", hitCount, "missCount", missCount, "cur_epoch", currentEpoch)
+		m.reportEpoch(currentEpoch-2, currentEpoch)
 	}
 
 }
 
+// reportEpoch closes the statuses of the observed validators for the given epoch, updating the metrics and the
+// summaries.
+func (m *validatorMonitorImpl) reportEpoch(epoch, currentEpoch uint64) {
+	hitCount := 0
+	missCount := 0
+	m.vaidatorStatuses.iterate(func(vindex uint64, epochStatuses map[uint64]*validatorStatus, history []EpochSummary) []EpochSummary {
+		status, ok := epochStatuses[epoch]
+		summary := EpochSummary{Epoch: epoch}
+		if ok {
+			summary = status.summary
+			summary.Epoch = epoch
+			summary.ProposedSlots = status.proposeSlots.ToSlice()
+			slices.Sort(summary.ProposedSlots)
+		}
+		var previous *EpochSummary
+		if len(history) > 0 {
+			previous = &history[len(history)-1]
+		}
+		if summary.Balance == 0 && previous != nil {
+			// no block seen during the epoch
+			summary.Balance = previous.Balance
+		}
+		if previous != nil {
+			summary.BalanceDelta = int64(summary.Balance) - int64(previous.Balance)
+		}
+
+		if ok && status.attestedBlockRoots.Cardinality() > 0 {
+			successAtt := status.attestedBlockRoots.Cardinality()
+			metricAttestHit.AddInt(successAtt)
+			hitCount += successAtt
+			log.Debug("[monitor] report attester status hit", "epoch", epoch, "vindex", vindex, "countAttestedBlock", status.attestedBlockRoots.Cardinality())
+		} else {
+			metricAttestMiss.AddInt(1)
+			missCount++
+			log.Debug("[monitor] report attester status miss", "epoch", epoch, "vindex", vindex, "countAttestedBlock", 0)
+		}
+		reportValidatorMetrics(vindex, &summary)
+
+		// the epoch is done, forget it and whatever is older
+		for e := range epochStatuses {
+			if e <= epoch {
+				delete(epochStatuses, e)
+			}
+		}
+		history = append(history, summary)
+		if len(history) > summaryHistoryEpochs {
+			history = history[len(history)-summaryHistoryEpochs:]
+		}
+		return history
+	})
+	log.Info("[monitor] report attester hit/miss", "epoch", epoch, "hitCount", hitCount, "missCount", missCount, "cur_epoch", currentEpoch)
+}
+
 func (m *validatorMonitorImpl) runReportProposerStatus() {
 	// check proposer in previous slot every slot duration
 	ticker := time.NewTicker(time.Duration(m.beaconCfg.SecondsPerSlot) * time.Second)
@@ -132,51 +265,74 @@ func (m *validatorMonitorImpl) runReportProposerStatus() {
 		proposerIndex, err := headState.GetBeaconProposerIndexForSlot(prevSlot)
 		if err != nil {
 			log.Warn("failed to get proposer index", "slot", prevSlot, "err", err)
-			return
-		}
-		if status := m.vaidatorStatuses.getValidatorStatus(proposerIndex, prevSlot/m.beaconCfg.SlotsPerEpoch); status != nil {
-			if status.proposeSlots.Contains(prevSlot) {
-				metricProposerHit.AddInt(1)
-				log.Info("[monitor] proposer hit", "slot", prevSlot, "proposerIndex", proposerIndex)
-			} else {
-				metricProposerMiss.AddInt(1)
-				log.Info("[monitor] proposer miss", "slot", prevSlot, "proposerIndex", proposerIndex)
-			}
+			continue
 		}
+		m.reportProposer(prevSlot, proposerIndex)
 	}
 }
 
+// reportProposer checks whether the expected proposer of slot, if observed, proposed a block.
+func (m *validatorMonitorImpl) reportProposer(slot, proposerIndex uint64) {
+	m.vaidatorStatuses.updateValidatorStatus(proposerIndex, slot/m.beaconCfg.SlotsPerEpoch, func(status *validatorStatus) {
+		if status.proposeSlots.Contains(slot) {
+			metricProposerHit.AddInt(1)
+			validatorCounter(metricNameProposalHit, proposerIndex).AddInt(1)
+			log.Info("[monitor] proposer hit", "slot", slot, "proposerIndex", proposerIndex)
+		} else {
+			metricProposerMiss.AddInt(1)
+			validatorCounter(metricNameProposalMiss, proposerIndex).AddInt(1)
+			status.summary.MissedProposals = append(status.summary.MissedProposals, slot)
+			log.Info("[monitor] proposer miss", "slot", slot, "proposerIndex", proposerIndex)
+		}
+	})
+}
+
 type validatorStatus struct {
 	// attestedBlockRoots is the set of block roots that the validator has successfully attested during one epoch.
 	attestedBlockRoots mapset.Set[common.Hash]
 	// proposeSlots is the set of slots that the proposer has successfully proposed blocks during one epoch.
 	proposeSlots mapset.Set[uint64]
+	// summary is the summary of the epoch being built.
+	summary EpochSummary
 }
 
-func (s *validatorStatus) updateAttesterStatus(att *solid.Attestation) {
+func (s *validatorStatus) updateAttesterStatus(att *solid.Attestation, inclusionDistance uint64, headCorrect, targetCorrect bool) {
 	data := att.AttestantionData()
 	s.attestedBlockRoots.Add(data.BeaconBlockRoot())
+	if !s.summary.AttestationIncluded || inclusionDistance < s.summary.InclusionDistance {
+		s.summary.InclusionDistance = inclusionDistance
+	}
+	s.summary.AttestationIncluded = true
+	s.summary.SourceCorrect = true
+	s.summary.HeadCorrect = s.summary.HeadCorrect || headCorrect
+	s.summary.TargetCorrect = s.summary.TargetCorrect || targetCorrect
 }
 
 type validatorStatuses struct {
-	statuses     map[uint64]map[uint64]*validatorStatus
+	statuses map[uint64]map[uint64]*validatorStatus
+	// history holds the reported epochs of the observed validators
+	history map[uint64][]EpochSummary
+	// pubkeys are observed validators we do not know the index of yet
+	pubkeys      mapset.Set[common.Bytes48]
 	vStatusMutex sync.RWMutex
 }
 
 func newValidatorStatuses() *validatorStatuses {
 	return &validatorStatuses{
 		statuses: make(map[uint64]map[uint64]*validatorStatus),
+		history:  make(map[uint64][]EpochSummary),
+		pubkeys:  mapset.NewThreadUnsafeSet[common.Bytes48](),
 	}
 }
 
-// getValidatorStatus returns the validator status for the given validator index and epoch.
-// returns nil if validator is not observed.
-func (s *validatorStatuses) getValidatorStatus(vid uint64, epoch uint64) *validatorStatus {
+// updateValidatorStatus runs update on the validator status for the given validator index and epoch.
+// nothing happens if the validator is not observed.
+func (s *validatorStatuses) updateValidatorStatus(vid uint64, epoch uint64, update func(status *validatorStatus)) {
 	s.vStatusMutex.Lock()
 	defer s.vStatusMutex.Unlock()
 	statusByEpoch, ok := s.statuses[vid]
 	if !ok {
-		return nil
+		return
 	}
 	if _, ok := statusByEpoch[epoch]; !ok {
 		statusByEpoch[epoch] = &validatorStatus{
@@ -184,32 +340,75 @@ func (s *validatorStatuses) getValidatorStatus(vid uint64, epoch uint64) *valida
 			proposeSlots:       mapset.NewSet[uint64](),
 		}
 	}
-
-	return statusByEpoch[epoch]
+	update(statusByEpoch[epoch])
 }
 
 func (s *validatorStatuses) addValidator(vid uint64) {
 	s.vStatusMutex.Lock()
 	defer s.vStatusMutex.Unlock()
+	s.addValidatorLocked(vid)
+}
+
+func (s *validatorStatuses) addValidatorLocked(vid uint64) {
 	if _, ok := s.statuses[vid]; !ok {
 		s.statuses[vid] = make(map[uint64]*validatorStatus)
 		log.Info("[monitor] add validator", "vid", vid)
 	}
 }
 
+func (s *validatorStatuses) addPubkey(pubkey common.Bytes48) {
+	s.vStatusMutex.Lock()
+	defer s.vStatusMutex.Unlock()
+	s.pubkeys.Add(pubkey)
+}
+
+// resolvePubkeys starts observing the validators added by public key once they are in the state.
+func (s *validatorStatuses) resolvePubkeys(state *state.CachingBeaconState) {
+	s.vStatusMutex.Lock()
+	defer s.vStatusMutex.Unlock()
+	for _, pubkey := range s.pubkeys.ToSlice() {
+		if vid, ok := state.ValidatorIndexByPubkey(pubkey); ok {
+			s.addValidatorLocked(vid)
+			s.pubkeys.Remove(pubkey)
+		}
+	}
+}
+
 func (s *validatorStatuses) removeValidator(vid uint64) {
 	s.vStatusMutex.Lock()
 	defer s.vStatusMutex.Unlock()
 	if _, ok := s.statuses[vid]; ok {
 		delete(s.statuses, vid)
+		delete(s.history, vid)
 		log.Info("[monitor] remove validator", "vid", vid)
 	}
 }
 
-func (s *validatorStatuses) iterate(run func(vid uint64, statuses map[uint64]*validatorStatus)) {
+func (s *validatorStatuses) observed() []uint64 {
+	s.vStatusMutex.RLock()
+	defer s.vStatusMutex.RUnlock()
+	vids := make([]uint64, 0, len(s.statuses))
+	for vid := range s.statuses {
+		vids = append(vids, vid)
+	}
+	slices.Sort(vids)
+	return vids
+}
+
+func (s *validatorStatuses) summary(vid uint64) (*ValidatorSummary, bool) {
+	s.vStatusMutex.RLock()
+	defer s.vStatusMutex.RUnlock()
+	if _, ok := s.statuses[vid]; !ok {
+		return nil, false
+	}
+	return &ValidatorSummary{Index: vid, Epochs: slices.Clone(s.history[vid])}, true
+}
+
+// iterate runs run on every observed validator, the history returned by run replaces the one of the validator.
+func (s *validatorStatuses) iterate(run func(vid uint64, statuses map[uint64]*validatorStatus, history []EpochSummary) []EpochSummary) {
 	s.vStatusMutex.Lock()
 	defer s.vStatusMutex.Unlock()
 	for vid, statuses := range s.statuses {
-		run(vid, statuses)
+		s.history[vid] = run(vid, statuses, s.history[vid])
 	}
 }
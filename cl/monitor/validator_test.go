@@ -0,0 +1,69 @@
+package monitor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/erigontech/erigon/cl/antiquary/tests"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/utils/eth_clock"
+)
+
+func TestValidatorMonitorSummary(t *testing.T) {
+	blocks, _, post := tests.GetCapellaRandom()
+	block := blocks[len(blocks)-1].Block
+	cfg := &clparams.MainnetBeaconConfig
+
+	ethClock := eth_clock.NewMockEthereumClock(gomock.NewController(t))
+	ethClock.EXPECT().GetEpochAtSlot(gomock.Any()).DoAndReturn(func(slot uint64) uint64 { return slot / cfg.SlotsPerEpoch }).AnyTimes()
+	ethClock.EXPECT().GetCurrentEpoch().Return(block.Slot / cfg.SlotsPerEpoch).AnyTimes()
+	m := newValidatorMonitor(ethClock, cfg, nil)
+
+	// an attester of the block, observed by index
+	att := block.Body.Attestations.Get(0)
+	attesters, err := post.GetAttestingIndicies(att.AttestantionData(), att.AggregationBits(), true)
+	require.NoError(t, err)
+	attester := attesters[0]
+	m.ObserveValidator(attester)
+	// the proposer, observed by public key
+	proposerPubkey, err := post.ValidatorPublicKey(int(block.ProposerIndex))
+	require.NoError(t, err)
+	m.ObserveValidatorPubkey(proposerPubkey)
+	require.Equal(t, []uint64{attester}, m.ObservedValidators())
+
+	require.NoError(t, m.OnNewBlock(post, block))
+	require.ElementsMatch(t, []uint64{attester, block.ProposerIndex}, m.ObservedValidators())
+	m.reportProposer(block.Slot, block.ProposerIndex)
+
+	attEpoch := att.AttestantionData().Slot() / cfg.SlotsPerEpoch
+	blockEpoch := block.Slot / cfg.SlotsPerEpoch
+	m.reportEpoch(attEpoch, blockEpoch)
+	if blockEpoch != attEpoch {
+		m.reportEpoch(blockEpoch, blockEpoch)
+	}
+
+	summary, ok := m.ValidatorSummary(attester)
+	require.True(t, ok)
+	require.Equal(t, attester, summary.Index)
+	attSummary := summary.Epochs[0]
+	require.Equal(t, attEpoch, attSummary.Epoch)
+	require.True(t, attSummary.AttestationIncluded)
+	require.True(t, attSummary.SourceCorrect)
+	require.LessOrEqual(t, attSummary.InclusionDistance, block.Slot-att.AttestantionData().Slot())
+	balance, err := post.ValidatorBalance(int(attester))
+	require.NoError(t, err)
+	require.Equal(t, balance, summary.Epochs[len(summary.Epochs)-1].Balance)
+
+	summary, ok = m.ValidatorSummary(block.ProposerIndex)
+	require.True(t, ok)
+	proposerSummary := summary.Epochs[len(summary.Epochs)-1]
+	require.Equal(t, blockEpoch, proposerSummary.Epoch)
+	require.Equal(t, []uint64{block.Slot}, proposerSummary.ProposedSlots)
+	require.Empty(t, proposerSummary.MissedProposals)
+
+	m.RemoveValidator(attester)
+	_, ok = m.ValidatorSummary(attester)
+	require.False(t, ok)
+}
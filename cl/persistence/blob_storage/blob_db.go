@@ -27,7 +27,7 @@ import (
 	"sync"
 	"sync/atomic"
 
-	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	goethkzg "github.com/crate-crypto/go-eth-kzg"
 	libcommon "github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/crypto/kzg"
 	"github.com/erigontech/erigon-lib/kv"
@@ -48,6 +48,13 @@ type BlobStorage interface {
 	WriteStream(w io.Writer, slot uint64, blockRoot libcommon.Hash, idx uint64) error // Used for P2P networking
 	KzgCommitmentsCount(ctx context.Context, blockRoot libcommon.Hash) (uint32, error)
 	Prune() error
+
+	// PeerDAS data columns
+	WriteDataColumnSidecars(ctx context.Context, blockRoot libcommon.Hash, sidecars []*cltypes.DataColumnSidecar) error
+	ReadDataColumnSidecar(ctx context.Context, slot uint64, blockRoot libcommon.Hash, index uint64) (out *cltypes.DataColumnSidecar, found bool, err error)
+	HasDataColumnSidecars(slot uint64, blockRoot libcommon.Hash, indices []uint64) (bool, error)
+	RemoveDataColumnSidecars(ctx context.Context, slot uint64, blockRoot libcommon.Hash) error
+	WriteDataColumnStream(w io.Writer, slot uint64, blockRoot libcommon.Hash, idx uint64) error // Used for P2P networking
 }
 
 type BlobStore struct {
@@ -71,6 +78,7 @@ func blobSidecarFilePath(slot, index uint64, blockRoot libcommon.Hash) (folderpa
 
 /*
 file system layout: <slot/subdivisionSlot>/<blockRoot>_<index>
+data columns: <slot/subdivisionSlot>/<blockRoot>_column_<index>
 indicies:
 - <blockRoot> -> kzg_commitments_length // block
 */
@@ -292,17 +300,17 @@ func VerifyAgainstIdentifiersAndInsertIntoTheBlobStore(ctx context.Context, stor
 		wg.Add(1)
 		go func(sds *sidecarsPayload) {
 			defer wg.Done()
-			blobs := make([]gokzg4844.Blob, len(sds.sidecars))
+			blobs := make([]goethkzg.Blob, len(sds.sidecars))
 			for i, sidecar := range sds.sidecars {
-				blobs[i] = gokzg4844.Blob(sidecar.Blob)
+				blobs[i] = goethkzg.Blob(sidecar.Blob)
 			}
-			kzgCommitments := make([]gokzg4844.KZGCommitment, len(sds.sidecars))
+			kzgCommitments := make([]goethkzg.KZGCommitment, len(sds.sidecars))
 			for i, sidecar := range sds.sidecars {
-				kzgCommitments[i] = gokzg4844.KZGCommitment(sidecar.KzgCommitment)
+				kzgCommitments[i] = goethkzg.KZGCommitment(sidecar.KzgCommitment)
 			}
-			kzgProofs := make([]gokzg4844.KZGProof, len(sds.sidecars))
+			kzgProofs := make([]goethkzg.KZGProof, len(sds.sidecars))
 			for i, sidecar := range sds.sidecars {
-				kzgProofs[i] = gokzg4844.KZGProof(sidecar.KzgProof)
+				kzgProofs[i] = goethkzg.KZGProof(sidecar.KzgProof)
 			}
 			if err := kzgCtx.VerifyBlobKZGProofBatch(blobs, kzgCommitments, kzgProofs); err != nil {
 				errAtomic.Store(errors.New("sidecar is wrong"))
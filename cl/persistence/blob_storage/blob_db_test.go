@@ -66,3 +66,48 @@ func TestBlobDB(t *testing.T) {
 	require.Equal(t, s1.SignedBlockHeader, sidecars[0].SignedBlockHeader)
 	require.Equal(t, s2.SignedBlockHeader, sidecars[1].SignedBlockHeader)
 }
+
+func TestDataColumnSidecars(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	newColumn := func(index uint64) *cltypes.DataColumnSidecar {
+		sidecar := cltypes.NewDataColumnSidecar()
+		sidecar.Index = index
+		sidecar.SignedBlockHeader.Header.Slot = 1
+		sidecar.Column.Append(&cltypes.Cell{byte(index)})
+		sidecar.KzgCommitments.Append(&cltypes.KZGCommitment{2})
+		sidecar.KzgProofs.Append(&cltypes.KZGProof{3})
+		return sidecar
+	}
+	s1, s2 := newColumn(3), newColumn(70)
+
+	bs := NewBlobStore(db, afero.NewMemMapFs(), 12, &clparams.MainnetBeaconConfig, nil)
+	blockRoot := libcommon.Hash{1}
+	require.NoError(t, bs.WriteDataColumnSidecars(context.Background(), blockRoot, []*cltypes.DataColumnSidecar{s1, s2}))
+
+	has, err := bs.HasDataColumnSidecars(1, blockRoot, []uint64{3, 70})
+	require.NoError(t, err)
+	require.True(t, has)
+	has, err = bs.HasDataColumnSidecars(1, blockRoot, []uint64{3, 4})
+	require.NoError(t, err)
+	require.False(t, has)
+
+	sidecar, found, err := bs.ReadDataColumnSidecar(context.Background(), 1, blockRoot, 70)
+	require.NoError(t, err)
+	require.True(t, found)
+	expectedRoot, err := s2.HashSSZ()
+	require.NoError(t, err)
+	root, err := sidecar.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, expectedRoot, root)
+
+	_, found, err = bs.ReadDataColumnSidecar(context.Background(), 1, blockRoot, 4)
+	require.NoError(t, err)
+	require.False(t, found)
+
+	require.NoError(t, bs.RemoveDataColumnSidecars(context.Background(), 1, blockRoot))
+	has, err = bs.HasDataColumnSidecars(1, blockRoot, []uint64{3})
+	require.NoError(t, err)
+	require.False(t, has)
+}
@@ -0,0 +1,163 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package blob_storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/cltypes/solid"
+	"github.com/erigontech/erigon/cl/das"
+	"github.com/erigontech/erigon/cl/sentinel/communication/ssz_snappy"
+	"github.com/spf13/afero"
+)
+
+func dataColumnSidecarFilePath(slot, index uint64, blockRoot libcommon.Hash) (folderpath, filepath string) {
+	folderpath, _ = blobSidecarFilePath(slot, index, blockRoot)
+	filepath = fmt.Sprintf("%s/%s_column_%d", folderpath, blockRoot.String(), index)
+	return
+}
+
+// WriteDataColumnSidecars writes the sidecars on disk. unlike blobs, columns are stored one by one as we only custody some of them.
+func (bs *BlobStore) WriteDataColumnSidecars(ctx context.Context, blockRoot libcommon.Hash, sidecars []*cltypes.DataColumnSidecar) error {
+	for _, sidecar := range sidecars {
+		if err := bs.writeDataColumnSidecar(blockRoot, sidecar); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (bs *BlobStore) writeDataColumnSidecar(blockRoot libcommon.Hash, sidecar *cltypes.DataColumnSidecar) error {
+	folderPath, filePath := dataColumnSidecarFilePath(sidecar.SignedBlockHeader.Header.Slot, sidecar.Index, blockRoot)
+	// mkdir the whole folder and subfolders
+	bs.fs.MkdirAll(folderPath, 0755)
+	file, err := bs.fs.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := ssz_snappy.EncodeAndWrite(file, sidecar); err != nil {
+		return err
+	}
+	return file.Sync()
+}
+
+// ReadDataColumnSidecar reads a single column of a block.
+func (bs *BlobStore) ReadDataColumnSidecar(ctx context.Context, slot uint64, blockRoot libcommon.Hash, index uint64) (*cltypes.DataColumnSidecar, bool, error) {
+	_, filePath := dataColumnSidecarFilePath(slot, index, blockRoot)
+	file, err := bs.fs.Open(filePath)
+	if err != nil {
+		if errors.Is(err, afero.ErrFileNotFound) || errors.Is(err, os.ErrNotExist) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	defer file.Close()
+
+	sidecar := cltypes.NewDataColumnSidecar()
+	if err := ssz_snappy.DecodeAndReadNoForkDigest(file, sidecar, clparams.DenebVersion); err != nil {
+		return nil, false, err
+	}
+	return sidecar, true, nil
+}
+
+// HasDataColumnSidecars returns whether all the given columns of a block are stored.
+func (bs *BlobStore) HasDataColumnSidecars(slot uint64, blockRoot libcommon.Hash, indices []uint64) (bool, error) {
+	for _, index := range indices {
+		_, filePath := dataColumnSidecarFilePath(slot, index, blockRoot)
+		if _, err := bs.fs.Stat(filePath); err != nil {
+			if errors.Is(err, afero.ErrFileNotFound) || errors.Is(err, os.ErrNotExist) {
+				return false, nil
+			}
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (bs *BlobStore) RemoveDataColumnSidecars(ctx context.Context, slot uint64, blockRoot libcommon.Hash) error {
+	for index := uint64(0); index < bs.beaconChainConfig.NumberOfColumns; index++ {
+		_, filePath := dataColumnSidecarFilePath(slot, index, blockRoot)
+		if err := bs.fs.Remove(filePath); err != nil && !errors.Is(err, afero.ErrFileNotFound) && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (bs *BlobStore) WriteDataColumnStream(w io.Writer, slot uint64, blockRoot libcommon.Hash, idx uint64) error {
+	_, filePath := dataColumnSidecarFilePath(slot, idx, blockRoot)
+	file, err := bs.fs.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = io.Copy(w, file)
+	return err
+}
+
+// VerifyAgainstIdentifiersAndInsertDataColumns does all due verification for data columns before database insertion and returns how many were inserted.
+// Peers may answer with a subset of the requested columns, so sidecars are matched against the identifiers regardless of the order.
+func VerifyAgainstIdentifiersAndInsertDataColumns(ctx context.Context, storage BlobStorage, beaconConfig *clparams.BeaconChainConfig, identifiers *solid.ListSSZ[*cltypes.DataColumnIdentifier], sidecars []*cltypes.DataColumnSidecar, verifySignatureFn verifyHeaderSignatureFn) (uint64, error) {
+	if len(sidecars) > identifiers.Len() {
+		return 0, errors.New("sidecars length is greater than identifiers length")
+	}
+	requested := make(map[cltypes.DataColumnIdentifier]struct{}, identifiers.Len())
+	identifiers.Range(func(_ int, identifier *cltypes.DataColumnIdentifier, _ int) bool {
+		requested[*identifier] = struct{}{}
+		return true
+	})
+
+	var inserted uint64
+	for _, sidecar := range sidecars {
+		blockRoot, err := sidecar.BlockRoot()
+		if err != nil {
+			return inserted, err
+		}
+		if _, ok := requested[cltypes.DataColumnIdentifier{BlockRoot: blockRoot, Index: sidecar.Index}]; !ok {
+			return inserted, errors.New("data column sidecar was not requested")
+		}
+		if err := das.VerifyDataColumnSidecar(beaconConfig, sidecar); err != nil {
+			return inserted, err
+		}
+		if !cltypes.VerifyDataColumnSidecarInclusionProof(sidecar) {
+			return inserted, errors.New("could not verify data column sidecar's inclusion proof")
+		}
+		if verifySignatureFn != nil {
+			// verify the signature of the sidecar head, we leave this step up to the caller to define
+			if err := verifySignatureFn(sidecar.SignedBlockHeader); err != nil {
+				return inserted, err
+			}
+		}
+		if err := das.VerifyDataColumnSidecarKZGProofs(sidecar); err != nil {
+			return inserted, err
+		}
+		if err := storage.WriteDataColumnSidecars(ctx, blockRoot, []*cltypes.DataColumnSidecar{sidecar}); err != nil {
+			return inserted, err
+		}
+		inserted++
+	}
+	return inserted, nil
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types/ssz"
 	"github.com/erigontech/erigon/cl/clparams"
 	"github.com/erigontech/erigon/cl/cltypes"
 	"github.com/erigontech/erigon/cl/cltypes/solid"
@@ -50,14 +51,31 @@ type HistoricalStatesReader struct {
 
 	// cache for shuffled sets
 	shuffledSetsCache *lru.Cache[uint64, []uint64]
+	// caches for reconstructed states, nil when disabled, and for the balances at the epoch boundaries which
+	// other slots are reconstructed from.
+	statesCache   *lru.Cache[uint64, *state.CachingBeaconState]
+	balancesCache *lru.Cache[epochBalancesKey, []byte]
 }
 
-func NewHistoricalStatesReader(cfg *clparams.BeaconChainConfig, blockReader freezeblocks.BeaconSnapshotReader, validatorTable *state_accessors.StaticValidatorTable, genesisState *state.CachingBeaconState) *HistoricalStatesReader {
+// NewHistoricalStatesReader creates a reader of the states processed by the antiquary. statesCacheSize is the amount
+// of reconstructed full states kept around for the requests which hit the same slot, 0 disables the cache.
+func NewHistoricalStatesReader(cfg *clparams.BeaconChainConfig, blockReader freezeblocks.BeaconSnapshotReader, validatorTable *state_accessors.StaticValidatorTable, genesisState *state.CachingBeaconState, statesCacheSize int) *HistoricalStatesReader {
 
 	cache, err := lru.New[uint64, []uint64]("shuffledSetsCache_reader", 125)
 	if err != nil {
 		panic(err)
 	}
+	var statesCache *lru.Cache[uint64, *state.CachingBeaconState]
+	if statesCacheSize > 0 {
+		statesCache, err = lru.New[uint64, *state.CachingBeaconState]("historicalStatesCache_reader", statesCacheSize)
+		if err != nil {
+			panic(err)
+		}
+	}
+	balancesCache, err := lru.New[epochBalancesKey, []byte]("epochBalancesCache_reader", epochBalancesCacheSize)
+	if err != nil {
+		panic(err)
+	}
 
 	return &HistoricalStatesReader{
 		cfg:               cfg,
@@ -65,10 +83,12 @@ func NewHistoricalStatesReader(cfg *clparams.BeaconChainConfig, blockReader free
 		genesisState:      genesisState,
 		validatorTable:    validatorTable,
 		shuffledSetsCache: cache,
+		statesCache:       statesCache,
+		balancesCache:     balancesCache,
 	}
 }
 
-func (r *HistoricalStatesReader) ReadHistoricalState(ctx context.Context, tx kv.Tx, slot uint64) (*state.CachingBeaconState, error) {
+func (r *HistoricalStatesReader) reconstructState(ctx context.Context, tx kv.Tx, slot uint64) (*state.CachingBeaconState, error) {
 	ret := state.New(r.cfg)
 	latestProcessedState, err := state_accessors.GetStateProcessingProgress(tx)
 	if err != nil {
@@ -273,9 +293,72 @@ func (r *HistoricalStatesReader) ReadHistoricalState(ctx context.Context, tx kv.
 		return nil, fmt.Errorf("failed to read historical summaries: %w", err)
 	}
 	ret.SetHistoricalSummaries(historicalSummaries)
+	if ret.Version() < clparams.ElectraVersion {
+		return ret, nil
+	}
+
+	// Electra churn and queues
+	ret.SetDepositRequestsStartIndex(slotData.DepositRequestsStartIndex)
+	ret.SetDepositBalanceToConsume(slotData.DepositBalanceToConsume)
+	ret.SetExitBalanceToConsume(slotData.ExitBalanceToConsume)
+	ret.SetEarliestExitEpoch(slotData.EarliestExitEpoch)
+	ret.SetConsolidationBalanceToConsume(slotData.ConsolidationBalanceToConsume)
+	ret.SetEarliestConsolidationEpoch(slotData.EarliestConsolidationEpoch)
+	pendingDeposits := solid.NewStaticListSSZ[*cltypes.PendingDeposit](int(r.cfg.PendingDepositsLimit), 192)
+	if err := r.ReadPendingQueue(tx, slot, kv.PendingDeposits, pendingDeposits); err != nil {
+		return nil, fmt.Errorf("failed to read pending deposits: %w", err)
+	}
+	ret.SetPendingDeposits(pendingDeposits)
+	pendingPartialWithdrawals := solid.NewStaticListSSZ[*cltypes.PendingPartialWithdrawal](int(r.cfg.PendingPartialWithdrawalsLimit), 24)
+	if err := r.ReadPendingQueue(tx, slot, kv.PendingPartialWithdrawals, pendingPartialWithdrawals); err != nil {
+		return nil, fmt.Errorf("failed to read pending partial withdrawals: %w", err)
+	}
+	ret.SetPendingPartialWithdrawals(pendingPartialWithdrawals)
+	pendingConsolidations := solid.NewStaticListSSZ[*cltypes.PendingConsolidation](int(r.cfg.PendingConsolidationsLimit), 16)
+	if err := r.ReadPendingQueue(tx, slot, kv.PendingConsolidations, pendingConsolidations); err != nil {
+		return nil, fmt.Errorf("failed to read pending consolidations: %w", err)
+	}
+	ret.SetPendingConsolidations(pendingConsolidations)
 	return ret, nil
 }
 
+// ReadPendingQueue reads one of the electra pending queues (deposits, partial withdrawals or consolidations) as of the given slot.
+// The queues are only dumped when they change, so the latest dump at or before the slot is the one we want.
+func (r *HistoricalStatesReader) ReadPendingQueue(tx kv.Tx, slot uint64, bkt string, out ssz.Unmarshaler) error {
+	cursor, err := tx.Cursor(bkt)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	k, v, err := cursor.Seek(base_encoding.Encode64ToBytes4(slot))
+	if err != nil {
+		return err
+	}
+	if k == nil {
+		k, v, err = cursor.Last()
+	} else if base_encoding.Decode64FromBytes4(k) > slot {
+		k, v, err = cursor.Prev()
+	}
+	if err != nil {
+		return err
+	}
+	if k == nil {
+		// the queue was never filled.
+		return nil
+	}
+	zstdReader, err := zstd.NewReader(bytes.NewReader(v))
+	if err != nil {
+		return err
+	}
+	defer zstdReader.Close()
+	encoded, err := io.ReadAll(zstdReader)
+	if err != nil {
+		return err
+	}
+	return out.DecodeSSZ(encoded, int(clparams.ElectraVersion))
+}
+
 func (r *HistoricalStatesReader) readHistoryHashVector(tx kv.Tx, genesisVector solid.HashVectorSSZ, slot, size uint64, table string, out solid.HashVectorSSZ) (err error) {
 	var needFromGenesis, inserted uint64
 	if size > slot || slot-size <= r.genesisState.Slot() {
@@ -485,88 +568,10 @@ func (r *HistoricalStatesReader) reconstructDiffedUint64List(tx kv.Tx, validator
 }
 
 func (r *HistoricalStatesReader) reconstructBalances(tx kv.Tx, validatorSetLength, slot uint64, diffBucket, dumpBucket string) ([]byte, error) {
-	remainder := slot % clparams.SlotsPerDump
-	freshDumpSlot := slot - remainder
-
-	buffer := buffersPool.Get().(*bytes.Buffer)
-	defer buffersPool.Put(buffer)
-	buffer.Reset()
-
-	var compressed []byte
-	currentStageProgress, err := state_accessors.GetStateProcessingProgress(tx)
-	if err != nil {
-		return nil, err
-	}
-	midpoint := uint64(clparams.SlotsPerDump / 2)
-	forward := remainder <= midpoint || currentStageProgress <= freshDumpSlot+clparams.SlotsPerDump
-	if forward {
-		compressed, err = tx.GetOne(dumpBucket, base_encoding.Encode64ToBytes4(freshDumpSlot))
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		compressed, err = tx.GetOne(dumpBucket, base_encoding.Encode64ToBytes4(freshDumpSlot+clparams.SlotsPerDump))
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	if len(compressed) == 0 {
-		return nil, fmt.Errorf("dump not found for slot %d", freshDumpSlot)
-	}
-	if _, err := buffer.Write(compressed); err != nil {
-		return nil, err
-	}
-	zstdReader, err := zstd.NewReader(buffer)
-	if err != nil {
-		return nil, err
-	}
-	defer zstdReader.Close()
-	currentList := make([]byte, validatorSetLength*8)
-	if _, err = io.ReadFull(zstdReader, currentList); err != nil && err != io.ErrUnexpectedEOF {
-		return nil, err
-	}
-
-	roundedSlot := r.cfg.RoundSlotToEpoch(slot)
-
-	if forward {
-		for i := freshDumpSlot; i <= roundedSlot; i += r.cfg.SlotsPerEpoch {
-			if i == freshDumpSlot {
-				continue
-			}
-			diff, err := tx.GetOne(diffBucket, base_encoding.Encode64ToBytes4(i))
-			if err != nil {
-				return nil, err
-			}
-			if len(diff) == 0 {
-				continue
-			}
-			currentList, err = base_encoding.ApplyCompressedSerializedUint64ListDiff(currentList, currentList, diff, false)
-			if err != nil {
-				return nil, err
-			}
-		}
-	} else {
-		for i := freshDumpSlot + clparams.SlotsPerDump; i > roundedSlot; i -= r.cfg.SlotsPerEpoch {
-			diff, err := tx.GetOne(diffBucket, base_encoding.Encode64ToBytes4(i))
-			if err != nil {
-				return nil, err
-			}
-			if len(diff) == 0 {
-				continue
-			}
-			currentList, err = base_encoding.ApplyCompressedSerializedUint64ListDiff(currentList, currentList, diff, true)
-			if err != nil {
-				return nil, err
-			}
-		}
-	}
-
-	diffCursor, err := tx.Cursor(diffBucket)
+	currentList, err := r.reconstructEpochBalances(tx, validatorSetLength, r.cfg.RoundSlotToEpoch(slot), diffBucket, dumpBucket)
 	if err != nil {
 		return nil, err
 	}
-	defer diffCursor.Close()
 	if slot%r.cfg.SlotsPerEpoch == 0 {
 		currentList = currentList[:validatorSetLength*8]
 		return currentList, nil
@@ -0,0 +1,193 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package historical_states_reader
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/persistence/base_encoding"
+	state_accessors "github.com/erigontech/erigon/cl/persistence/state"
+	"github.com/erigontech/erigon/cl/phase1/core/state"
+)
+
+// consecutive slots are reconstructed from the balances of the closest epoch boundary, which makes
+// them a lot cheaper than going through the dumps every time.
+const epochBalancesCacheSize = 16
+
+// epochBalancesKey identifies cached epoch balances, which are reconstructed from the diffs and dumps of a bucket pair.
+type epochBalancesKey struct {
+	diffBucket, dumpBucket string
+	slot                   uint64
+}
+
+var ErrStateRootMismatch = errors.New("reconstructed state root does not match the stored one")
+
+// ReadHistoricalState reconstructs the state at the given slot. Reconstructed states are checked against the state
+// roots stored by the antiquary and cached if the cache is enabled, the returned state can be modified by the caller.
+func (r *HistoricalStatesReader) ReadHistoricalState(ctx context.Context, tx kv.Tx, slot uint64) (*state.CachingBeaconState, error) {
+	if r.statesCache != nil {
+		if cached, ok := r.statesCache.Get(slot); ok {
+			return cached.Copy()
+		}
+	}
+	ret, err := r.reconstructState(ctx, tx, slot)
+	if err != nil || ret == nil {
+		return ret, err
+	}
+	if err := r.verifyStateRoot(tx, ret); err != nil {
+		return nil, err
+	}
+	if r.statesCache == nil {
+		return ret, nil
+	}
+	r.statesCache.Add(slot, ret)
+	return ret.Copy()
+}
+
+// verifyStateRoot checks the root of a reconstructed state against the one stored by the antiquary. The root of the
+// latest processed slot is only stored once the next slot is processed, so it may not be there yet.
+func (r *HistoricalStatesReader) verifyStateRoot(tx kv.Tx, s *state.CachingBeaconState) error {
+	expectedRoot, err := state_accessors.ReadStateRoot(tx, s.Slot())
+	if err != nil {
+		return err
+	}
+	if expectedRoot == (common.Hash{}) {
+		return nil
+	}
+	root, err := s.HashSSZ()
+	if err != nil {
+		return err
+	}
+	if root != expectedRoot {
+		return fmt.Errorf("%w: slot %d, expected %x, got %x", ErrStateRootMismatch, s.Slot(), expectedRoot, common.Hash(root))
+	}
+	return nil
+}
+
+// reconstructEpochBalances reconstructs the balances at the epoch boundary roundedSlot. It starts from the closest
+// of the dumps and the cached epoch balances, the returned list can be modified by the caller.
+func (r *HistoricalStatesReader) reconstructEpochBalances(tx kv.Tx, validatorSetLength, roundedSlot uint64, diffBucket, dumpBucket string) ([]byte, error) {
+	freshDumpSlot := roundedSlot - roundedSlot%clparams.SlotsPerDump
+	currentStageProgress, err := state_accessors.GetStateProcessingProgress(tx)
+	if err != nil {
+		return nil, err
+	}
+	midpoint := uint64(clparams.SlotsPerDump / 2)
+	baseSlot := freshDumpSlot
+	if roundedSlot-freshDumpSlot > midpoint && currentStageProgress > freshDumpSlot+clparams.SlotsPerDump {
+		baseSlot = freshDumpSlot + clparams.SlotsPerDump
+	}
+
+	currentList, cachedSlot, ok := r.closestEpochBalances(validatorSetLength, roundedSlot, baseSlot, diffBucket, dumpBucket)
+	if ok {
+		baseSlot = cachedSlot
+	} else if currentList, err = r.readBalancesDump(tx, validatorSetLength, baseSlot, dumpBucket); err != nil {
+		return nil, err
+	}
+
+	applyDiff := func(slot uint64, reverse bool) error {
+		diff, err := tx.GetOne(diffBucket, base_encoding.Encode64ToBytes4(slot))
+		if err != nil {
+			return err
+		}
+		if len(diff) == 0 {
+			return nil
+		}
+		currentList, err = base_encoding.ApplyCompressedSerializedUint64ListDiff(currentList, currentList, diff, reverse)
+		return err
+	}
+	if baseSlot <= roundedSlot {
+		for i := baseSlot + r.cfg.SlotsPerEpoch; i <= roundedSlot; i += r.cfg.SlotsPerEpoch {
+			if err := applyDiff(i, false); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		for i := baseSlot; i > roundedSlot; i -= r.cfg.SlotsPerEpoch {
+			if err := applyDiff(i, true); err != nil {
+				return nil, err
+			}
+		}
+	}
+	r.balancesCache.Add(epochBalancesKey{diffBucket: diffBucket, dumpBucket: dumpBucket, slot: roundedSlot}, common.Copy(currentList))
+	return currentList, nil
+}
+
+// closestEpochBalances looks for cached epoch balances of the buckets which are closer to roundedSlot than the dump
+// at dumpSlot.
+func (r *HistoricalStatesReader) closestEpochBalances(validatorSetLength, roundedSlot, dumpSlot uint64, diffBucket, dumpBucket string) ([]byte, uint64, bool) {
+	lowest := roundedSlot - roundedSlot%clparams.SlotsPerDump
+	highest := lowest + clparams.SlotsPerDump
+	distance := roundedSlot - dumpSlot
+	if dumpSlot > roundedSlot {
+		distance = dumpSlot - roundedSlot
+	}
+	for d := uint64(0); d < distance; d += r.cfg.SlotsPerEpoch {
+		for _, candidate := range []uint64{roundedSlot - d, roundedSlot + d} {
+			key := epochBalancesKey{diffBucket: diffBucket, dumpBucket: dumpBucket, slot: candidate}
+			if candidate < lowest || candidate > highest || !r.balancesCache.Contains(key) {
+				continue
+			}
+			cached, ok := r.balancesCache.Get(key)
+			if !ok {
+				continue
+			}
+			// pad it like the dumps are, the validator set may have grown since.
+			currentList := make([]byte, max(len(cached), int(validatorSetLength*8)))
+			copy(currentList, cached)
+			return currentList, candidate, true
+		}
+	}
+	return nil, 0, false
+}
+
+func (r *HistoricalStatesReader) readBalancesDump(tx kv.Tx, validatorSetLength, dumpSlot uint64, dumpBucket string) ([]byte, error) {
+	compressed, err := tx.GetOne(dumpBucket, base_encoding.Encode64ToBytes4(dumpSlot))
+	if err != nil {
+		return nil, err
+	}
+	if len(compressed) == 0 {
+		return nil, fmt.Errorf("dump not found for slot %d", dumpSlot)
+	}
+
+	buffer := buffersPool.Get().(*bytes.Buffer)
+	defer buffersPool.Put(buffer)
+	buffer.Reset()
+
+	if _, err := buffer.Write(compressed); err != nil {
+		return nil, err
+	}
+	zstdReader, err := zstd.NewReader(buffer)
+	if err != nil {
+		return nil, err
+	}
+	defer zstdReader.Close()
+	currentList := make([]byte, validatorSetLength*8)
+	if _, err = io.ReadFull(zstdReader, currentList); err != nil && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	return currentList, nil
+}
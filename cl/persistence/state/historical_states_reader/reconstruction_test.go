@@ -0,0 +1,122 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package historical_states_reader
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/memdb"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/persistence/base_encoding"
+	state_accessors "github.com/erigontech/erigon/cl/persistence/state"
+)
+
+// testBalances are the balances at every slot, with a validator joining every 100 slots.
+func testBalances(slot uint64) []byte {
+	validators := 16 + slot/100
+	balances := make([]byte, validators*8)
+	for i := uint64(0); i < validators; i++ {
+		binary.LittleEndian.PutUint64(balances[i*8:], 32_000_000_000+slot*(i%5)-i)
+	}
+	return balances
+}
+
+// writeTestBalances writes the balances dumps and diffs the way the state antiquary does.
+func writeTestBalances(t *testing.T, tx kv.RwTx, cfg *clparams.BeaconChainConfig, lastSlot uint64) {
+	var epochBalances []byte
+	for slot := uint64(0); slot <= lastSlot; slot++ {
+		balances := testBalances(slot)
+		if slot%clparams.SlotsPerDump == 0 {
+			var dump bytes.Buffer
+			compressor, err := zstd.NewWriter(&dump)
+			require.NoError(t, err)
+			_, err = compressor.Write(balances)
+			require.NoError(t, err)
+			require.NoError(t, compressor.Close())
+			require.NoError(t, tx.Put(kv.BalancesDump, base_encoding.Encode64ToBytes4(slot), dump.Bytes()))
+		}
+		var diff bytes.Buffer
+		require.NoError(t, base_encoding.ComputeCompressedSerializedUint64ListDiff(&diff, epochBalances, balances))
+		require.NoError(t, tx.Put(kv.ValidatorBalance, base_encoding.Encode64ToBytes4(slot), diff.Bytes()))
+		if slot%cfg.SlotsPerEpoch == 0 {
+			epochBalances = balances
+		}
+	}
+	require.NoError(t, state_accessors.SetStateProcessingProgress(tx, lastSlot))
+}
+
+func TestReconstructBalancesIncrementally(t *testing.T) {
+	cfg := &clparams.MainnetBeaconConfig
+	lastSlot := 3 * uint64(clparams.SlotsPerDump)
+	db := memdb.NewTestDB(t)
+	tx, err := db.BeginRw(context.Background())
+	require.NoError(t, err)
+	defer tx.Rollback()
+	writeTestBalances(t, tx, cfg, lastSlot)
+
+	// consecutive slots around a dump, in both directions, so that both the forward and backward reconstruction
+	// start from the cached epochs.
+	cached := NewHistoricalStatesReader(cfg, nil, nil, nil, 0)
+	check := func(slot uint64) {
+		expected := testBalances(slot)
+		validatorSetLength := uint64(len(expected) / 8)
+		balances, err := cached.reconstructBalances(tx, validatorSetLength, slot, kv.ValidatorBalance, kv.BalancesDump)
+		require.NoError(t, err)
+		require.Equal(t, expected, balances, "slot %d", slot)
+		// a reader without a cache goes through the dumps.
+		balances, err = NewHistoricalStatesReader(cfg, nil, nil, nil, 0).reconstructBalances(tx, validatorSetLength, slot, kv.ValidatorBalance, kv.BalancesDump)
+		require.NoError(t, err)
+		require.Equal(t, expected, balances, "slot %d", slot)
+	}
+	for slot := uint64(clparams.SlotsPerDump) - 100; slot < uint64(clparams.SlotsPerDump)+100; slot++ {
+		check(slot)
+	}
+	for slot := 2*uint64(clparams.SlotsPerDump) - 1; slot > 2*uint64(clparams.SlotsPerDump)-200; slot-- {
+		check(slot)
+	}
+	require.Positive(t, cached.balancesCache.Len())
+}
+
+func TestEpochBalancesCacheIsPerBucket(t *testing.T) {
+	cfg := &clparams.MainnetBeaconConfig
+	lastSlot := 2 * uint64(clparams.SlotsPerDump)
+	db := memdb.NewTestDB(t)
+	tx, err := db.BeginRw(context.Background())
+	require.NoError(t, err)
+	defer tx.Rollback()
+	writeTestBalances(t, tx, cfg, lastSlot)
+
+	r := NewHistoricalStatesReader(cfg, nil, nil, nil, 0)
+	slot := uint64(clparams.SlotsPerDump) + 10*cfg.SlotsPerEpoch
+	validatorSetLength := uint64(len(testBalances(slot)) / 8)
+	_, err = r.reconstructBalances(tx, validatorSetLength, slot, kv.ValidatorBalance, kv.BalancesDump)
+	require.NoError(t, err)
+
+	_, cachedSlot, ok := r.closestEpochBalances(validatorSetLength, slot+cfg.SlotsPerEpoch, uint64(clparams.SlotsPerDump), kv.ValidatorBalance, kv.BalancesDump)
+	require.True(t, ok)
+	require.Equal(t, slot, cachedSlot)
+	// the effective balances of the same slots are diffs of other buckets, they must not start from the balances.
+	_, _, ok = r.closestEpochBalances(validatorSetLength, slot+cfg.SlotsPerEpoch, uint64(clparams.SlotsPerDump), kv.ValidatorEffectiveBalance, kv.EffectiveBalancesDump)
+	require.False(t, ok)
+}
@@ -40,6 +40,13 @@ type SlotData struct {
 	// Capella
 	NextWithdrawalIndex          uint64
 	NextWithdrawalValidatorIndex uint64
+	// Electra
+	DepositRequestsStartIndex     uint64
+	DepositBalanceToConsume       uint64
+	ExitBalanceToConsume          uint64
+	EarliestExitEpoch             uint64
+	ConsolidationBalanceToConsume uint64
+	EarliestConsolidationEpoch    uint64
 
 	// BlockRewards for proposer
 	AttestationsRewards  uint64
@@ -62,6 +69,13 @@ func SlotDataFromBeaconState(s *state.CachingBeaconState) *SlotData {
 		NextWithdrawalIndex:          s.NextWithdrawalIndex(),
 		NextWithdrawalValidatorIndex: s.NextWithdrawalValidatorIndex(),
 		Fork:                         s.Fork(),
+
+		DepositRequestsStartIndex:     s.DepositRequestsStartIndex(),
+		DepositBalanceToConsume:       s.DepositBalanceToConsume(),
+		ExitBalanceToConsume:          s.ExitBalanceToConsume(),
+		EarliestExitEpoch:             s.EarliestExitEpoch(),
+		ConsolidationBalanceToConsume: s.ConsolidationBalanceToConsume(),
+		EarliestConsolidationEpoch:    s.EarliestConsolidationEpoch(),
 	}
 }
 
@@ -122,5 +136,9 @@ func (m *SlotData) getSchema() []interface{} {
 	if m.Version >= clparams.CapellaVersion {
 		schema = append(schema, &m.NextWithdrawalIndex, &m.NextWithdrawalValidatorIndex)
 	}
+	if m.Version >= clparams.ElectraVersion {
+		schema = append(schema, &m.DepositRequestsStartIndex, &m.DepositBalanceToConsume, &m.ExitBalanceToConsume,
+			&m.EarliestExitEpoch, &m.ConsolidationBalanceToConsume, &m.EarliestConsolidationEpoch)
+	}
 	return schema
 }
@@ -45,3 +45,24 @@ func TestSlotData(t *testing.T) {
 
 	require.Equal(t, m, m2)
 }
+
+func TestSlotDataElectra(t *testing.T) {
+	m := &SlotData{
+		Version:                       clparams.ElectraVersion,
+		Eth1Data:                      &cltypes.Eth1Data{},
+		Fork:                          &cltypes.Fork{Epoch: 12},
+		NextWithdrawalIndex:           3,
+		DepositRequestsStartIndex:     7,
+		DepositBalanceToConsume:       32_000_000_000,
+		ExitBalanceToConsume:          64_000_000_000,
+		EarliestExitEpoch:             100,
+		ConsolidationBalanceToConsume: 128_000_000_000,
+		EarliestConsolidationEpoch:    101,
+	}
+	var b bytes.Buffer
+	require.NoError(t, m.WriteTo(&b))
+	m2 := &SlotData{}
+	require.NoError(t, m2.ReadFrom(&b))
+
+	require.Equal(t, m, m2)
+}
@@ -18,6 +18,7 @@ package state_accessors
 
 import (
 	"bytes"
+	"fmt"
 
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon/cl/cltypes"
@@ -192,6 +193,22 @@ func ReadEpochData(tx kv.Tx, slot uint64) (*EpochData, error) {
 	return ed, ed.ReadFrom(buf)
 }
 
+// ReadStateRoot reads the state root of the given slot, as stored by the state antiquary. The root is empty if it
+// has not been stored (yet).
+func ReadStateRoot(tx kv.Tx, slot uint64) (libcommon.Hash, error) {
+	v, err := tx.GetOne(kv.StateRoot, base_encoding.Encode64ToBytes4(slot))
+	if err != nil {
+		return libcommon.Hash{}, err
+	}
+	if len(v) == 0 {
+		return libcommon.Hash{}, nil
+	}
+	if len(v) != 32 {
+		return libcommon.Hash{}, fmt.Errorf("invalid state root length %d", len(v))
+	}
+	return libcommon.BytesToHash(v), nil
+}
+
 // ReadCheckpoints reads the checkpoints from the database, Current, Previous and Finalized
 func ReadCheckpoints(tx kv.Tx, slot uint64) (current solid.Checkpoint, previous solid.Checkpoint, finalized solid.Checkpoint, err error) {
 	ed := &EpochData{}
@@ -194,6 +194,10 @@ func GetUnslashedParticipatingIndices(b abstract.BeaconState, flagIndex int, epo
 // Implementation of is_eligible_for_activation_queue.
 // Specs at: https://github.com/ethereum/consensus-specs/blob/dev/specs/phase0/beacon-chain.md#is_eligible_for_activation_queue
 func IsValidatorEligibleForActivationQueue(b abstract.BeaconState, validator solid.Validator) bool {
+	if b.Version() >= clparams.ElectraVersion {
+		return validator.ActivationEligibilityEpoch() == b.BeaconConfig().FarFutureEpoch &&
+			validator.EffectiveBalance() >= b.BeaconConfig().MinActivationBalance
+	}
 	return validator.ActivationEligibilityEpoch() == b.BeaconConfig().FarFutureEpoch &&
 		validator.EffectiveBalance() == b.BeaconConfig().MaxEffectiveBalance
 }
@@ -218,25 +222,77 @@ func ComputeTimestampAtSlot(b abstract.BeaconState, slot uint64) uint64 {
 
 // ExpectedWithdrawals calculates the expected withdrawals that can be made by validators in the current epoch
 func ExpectedWithdrawals(b abstract.BeaconState, currentEpoch uint64) []*cltypes.Withdrawal {
+	withdrawals, _ := ExpectedWithdrawalsAndPartialsCount(b, currentEpoch)
+	return withdrawals
+}
+
+// ExpectedWithdrawalsAndPartialsCount calculates the expected withdrawals, post-electra it also returns the number of
+// pending partial withdrawals which were processed (and must be removed from the state).
+func ExpectedWithdrawalsAndPartialsCount(b abstract.BeaconState, currentEpoch uint64) ([]*cltypes.Withdrawal, uint64) {
 	// Get the current epoch, the next withdrawal index, and the next withdrawal validator index
 	nextWithdrawalIndex := b.NextWithdrawalIndex()
 	nextWithdrawalValidatorIndex := b.NextWithdrawalValidatorIndex()
+	beaconConfig := b.BeaconConfig()
+	version := b.Version()
 
 	// Determine the upper bound for the loop and initialize the withdrawals slice with a capacity of bound
 	maxValidators := uint64(b.ValidatorLength())
-	maxValidatorsPerWithdrawalsSweep := b.BeaconConfig().MaxValidatorsPerWithdrawalsSweep
+	maxValidatorsPerWithdrawalsSweep := beaconConfig.MaxValidatorsPerWithdrawalsSweep
 	bound := min(maxValidators, maxValidatorsPerWithdrawalsSweep)
 	withdrawals := make([]*cltypes.Withdrawal, 0, bound)
 
+	// Sum of the amounts already withdrawn by each validator
+	withdrawnAmount := func(validatorIndex uint64) (total uint64) {
+		for _, w := range withdrawals {
+			if w.Validator == validatorIndex {
+				total += w.Amount
+			}
+		}
+		return
+	}
+
+	// Consume pending partial withdrawals first (EIP-7002)
+	var processedPartialWithdrawalsCount uint64
+	if version >= clparams.ElectraVersion {
+		b.PendingPartialWithdrawals().Range(func(_ int, w *cltypes.PendingPartialWithdrawal, _ int) bool {
+			if w.WithdrawableEpoch > currentEpoch || len(withdrawals) == int(beaconConfig.MaxPendingPartialsPerWithdrawalsSweep) {
+				return false
+			}
+			validator, err := b.ValidatorForValidatorIndex(int(w.ValidatorIndex))
+			if err != nil {
+				return false
+			}
+			balance, _ := b.ValidatorBalance(int(w.ValidatorIndex))
+			balance -= withdrawnAmount(w.ValidatorIndex)
+			if validator.ExitEpoch() == beaconConfig.FarFutureEpoch &&
+				validator.EffectiveBalance() >= beaconConfig.MinActivationBalance &&
+				balance > beaconConfig.MinActivationBalance {
+				wd := validator.WithdrawalCredentials()
+				withdrawals = append(withdrawals, &cltypes.Withdrawal{
+					Index:     nextWithdrawalIndex,
+					Validator: w.ValidatorIndex,
+					Address:   libcommon.BytesToAddress(wd[12:]),
+					Amount:    min(balance-beaconConfig.MinActivationBalance, w.Amount),
+				})
+				nextWithdrawalIndex++
+			}
+			processedPartialWithdrawalsCount++
+			return true
+		})
+	}
+
 	// Loop through the validators to calculate expected withdrawals
-	for validatorCount := uint64(0); validatorCount < bound && len(withdrawals) != int(b.BeaconConfig().MaxWithdrawalsPerPayload); validatorCount++ {
+	for validatorCount := uint64(0); validatorCount < bound && len(withdrawals) != int(beaconConfig.MaxWithdrawalsPerPayload); validatorCount++ {
 		// Get the validator and balance for the current validator index
 		// supposedly this operation is safe because we checked the validator length about
 		currentValidator, _ := b.ValidatorForValidatorIndex(int(nextWithdrawalValidatorIndex))
 		currentBalance, _ := b.ValidatorBalance(int(nextWithdrawalValidatorIndex))
+		if version >= clparams.ElectraVersion {
+			currentBalance -= withdrawnAmount(nextWithdrawalValidatorIndex)
+		}
 		wd := currentValidator.WithdrawalCredentials()
 		// Check if the validator is fully withdrawable
-		if isFullyWithdrawableValidator(b.BeaconConfig(), currentValidator, currentBalance, currentEpoch) {
+		if isFullyWithdrawableValidator(beaconConfig, version, currentValidator, currentBalance, currentEpoch) {
 			// Add a new withdrawal with the validator's withdrawal credentials and balance
 			newWithdrawal := &cltypes.Withdrawal{
 				Index:     nextWithdrawalIndex,
@@ -246,13 +302,13 @@ func ExpectedWithdrawals(b abstract.BeaconState, currentEpoch uint64) []*cltypes
 			}
 			withdrawals = append(withdrawals, newWithdrawal)
 			nextWithdrawalIndex++
-		} else if isPartiallyWithdrawableValidator(b.BeaconConfig(), currentValidator, currentBalance) { // Check if the validator is partially withdrawable
+		} else if isPartiallyWithdrawableValidator(beaconConfig, version, currentValidator, currentBalance) { // Check if the validator is partially withdrawable
 			// Add a new withdrawal with the validator's withdrawal credentials and balance minus the maximum effective balance
 			newWithdrawal := &cltypes.Withdrawal{
 				Index:     nextWithdrawalIndex,
 				Validator: nextWithdrawalValidatorIndex,
 				Address:   libcommon.BytesToAddress(wd[12:]),
-				Amount:    currentBalance - b.BeaconConfig().MaxEffectiveBalance,
+				Amount:    currentBalance - GetMaxEffectiveBalance(beaconConfig, version, currentValidator),
 			}
 			withdrawals = append(withdrawals, newWithdrawal)
 			nextWithdrawalIndex++
@@ -263,5 +319,31 @@ func ExpectedWithdrawals(b abstract.BeaconState, currentEpoch uint64) []*cltypes
 	}
 
 	// Return the withdrawals slice
-	return withdrawals
+	return withdrawals, processedPartialWithdrawalsCount
+}
+
+// GetPendingBalanceToWithdraw returns the sum of the pending partial withdrawals of the validator (EIP-7251).
+func GetPendingBalanceToWithdraw(b abstract.BeaconState, validatorIndex uint64) (total uint64) {
+	b.PendingPartialWithdrawals().Range(func(_ int, w *cltypes.PendingPartialWithdrawal, _ int) bool {
+		if w.ValidatorIndex == validatorIndex {
+			total += w.Amount
+		}
+		return true
+	})
+	return
+}
+
+// IsValidDepositSignature verifies the deposit proof of possession, which is not checked by the deposit contract.
+func IsValidDepositSignature(conf *clparams.BeaconChainConfig, pubkey libcommon.Bytes48, withdrawalCredentials libcommon.Hash, amount uint64, signature libcommon.Bytes96) (bool, error) {
+	// Agnostic domain.
+	domain, err := fork.ComputeDomain(conf.DomainDeposit[:], utils.Uint32ToBytes4(uint32(conf.GenesisForkVersion)), [32]byte{})
+	if err != nil {
+		return false, err
+	}
+	depositMessageRoot, err := (&cltypes.DepositData{PubKey: pubkey, WithdrawalCredentials: withdrawalCredentials, Amount: amount}).MessageHash()
+	if err != nil {
+		return false, err
+	}
+	signedRoot := utils.Sha256(depositMessageRoot[:], domain)
+	return bls.Verify(signature[:], signedRoot[:], pubkey[:])
 }
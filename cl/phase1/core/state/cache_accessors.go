@@ -276,6 +276,13 @@ func (b *CachingBeaconState) ComputeNextSyncCommittee() (*solid.SyncCommittee, e
 	i := uint64(0)
 	syncCommitteePubKeys := make([]libcommon.Bytes48, 0, cltypes.SyncCommitteeSize)
 	preInputs := shuffling.ComputeShuffledIndexPreInputs(b.BeaconConfig(), seed)
+	maxRandomValue := uint64(math.MaxUint8)
+	maxEffectiveBalance := beaconConfig.MaxEffectiveBalance
+	isElectra := b.Version() >= clparams.ElectraVersion
+	if isElectra {
+		maxRandomValue = math.MaxUint16
+		maxEffectiveBalance = beaconConfig.MaxEffectiveBalanceElectra
+	}
 	for len(syncCommitteePubKeys) < cltypes.SyncCommitteeSize {
 		shuffledIndex, err := shuffling.ComputeShuffledIndex(
 			b.BeaconConfig(),
@@ -289,17 +296,24 @@ func (b *CachingBeaconState) ComputeNextSyncCommittee() (*solid.SyncCommittee, e
 			return nil, err
 		}
 		candidateIndex := activeValidatorIndicies[shuffledIndex]
-		// Compute random byte.
+		// Compute random value, post-electra it is 16 bits wide.
 		buf := make([]byte, 8)
-		binary.LittleEndian.PutUint64(buf, i/32)
-		input := append(seed[:], buf...)
-		randomByte := uint64(utils.Sha256(input)[i%32])
+		var randomValue uint64
+		if isElectra {
+			binary.LittleEndian.PutUint64(buf, i/16)
+			randomBytes := utils.Sha256(append(seed[:], buf...))
+			offset := (i % 16) * 2
+			randomValue = uint64(binary.LittleEndian.Uint16(randomBytes[offset : offset+2]))
+		} else {
+			binary.LittleEndian.PutUint64(buf, i/32)
+			randomValue = uint64(utils.Sha256(append(seed[:], buf...))[i%32])
+		}
 		// retrieve validator.
 		validator, err := b.ValidatorForValidatorIndex(int(candidateIndex))
 		if err != nil {
 			return nil, err
 		}
-		if validator.EffectiveBalance()*math.MaxUint8 >= beaconConfig.MaxEffectiveBalance*randomByte {
+		if validator.EffectiveBalance()*maxRandomValue >= maxEffectiveBalance*randomValue {
 			syncCommitteePubKeys = append(syncCommitteePubKeys, validator.PublicKey())
 		}
 		i++
@@ -373,3 +387,63 @@ func (b *CachingBeaconState) GetValidatorActivationChurnLimit() uint64 {
 	}
 	return b.GetValidatorChurnLimit()
 }
+
+// GetAttestingIndiciesForAttestation retrieves attesting indicies of an attestation. Post-electra (EIP-7549) an attestation
+// aggregates all committees set in its committee bits, which share the aggregation bits one after another.
+func (b *CachingBeaconState) GetAttestingIndiciesForAttestation(attestation *solid.Attestation, checkBitsLength bool) ([]uint64, error) {
+	data := attestation.AttestantionData()
+	if !attestation.IsElectra() {
+		return b.GetAttestingIndicies(data, attestation.AggregationBits(), checkBitsLength)
+	}
+	aggregationBits := attestation.AggregationBits()
+	attestingIndices := []uint64{}
+	committeeOffset := 0
+	for _, committeeIndex := range attestation.CommitteeIndices() {
+		committee, err := b.GetBeaconCommitee(data.Slot(), committeeIndex)
+		if err != nil {
+			return nil, err
+		}
+		committeeAttesters := 0
+		for i, member := range committee {
+			bitIndex := committeeOffset + i
+			if bitIndex/8 >= len(aggregationBits) {
+				return nil, errors.New("GetAttestingIndiciesForAttestation: committee is too big")
+			}
+			if (aggregationBits[bitIndex/8] & (1 << (bitIndex % 8))) > 0 {
+				attestingIndices = append(attestingIndices, member)
+				committeeAttesters++
+			}
+		}
+		if checkBitsLength && committeeAttesters == 0 {
+			return nil, fmt.Errorf("GetAttestingIndiciesForAttestation: no attesters in committee %d", committeeIndex)
+		}
+		committeeOffset += len(committee)
+	}
+	if aggregationBitsLen := utils.GetBitlistLength(aggregationBits); checkBitsLength && aggregationBitsLen != committeeOffset {
+		return nil, fmt.Errorf(
+			"GetAttestingIndiciesForAttestation: invalid aggregation bits. agg bits size: %d, expect: %d",
+			aggregationBitsLen,
+			committeeOffset,
+		)
+	}
+	return attestingIndices, nil
+}
+
+// https://github.com/ethereum/consensus-specs/blob/dev/specs/electra/beacon-chain.md#new-get_balance_churn_limit
+func (b *CachingBeaconState) GetBalanceChurnLimit() uint64 {
+	churn := max(
+		b.BeaconConfig().MinPerEpochChurnLimitElectra,
+		b.GetTotalActiveBalance()/b.BeaconConfig().ChurnLimitQuotient,
+	)
+	return churn - churn%b.BeaconConfig().EffectiveBalanceIncrement
+}
+
+// https://github.com/ethereum/consensus-specs/blob/dev/specs/electra/beacon-chain.md#new-get_activation_exit_churn_limit
+func (b *CachingBeaconState) GetActivationExitChurnLimit() uint64 {
+	return min(b.BeaconConfig().MaxPerEpochActivationExitChurnLimit, b.GetBalanceChurnLimit())
+}
+
+// https://github.com/ethereum/consensus-specs/blob/dev/specs/electra/beacon-chain.md#new-get_consolidation_churn_limit
+func (b *CachingBeaconState) GetConsolidationChurnLimit() uint64 {
+	return b.GetBalanceChurnLimit() - b.GetActivationExitChurnLimit()
+}
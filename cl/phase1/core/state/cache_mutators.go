@@ -77,7 +77,7 @@ func (b *CachingBeaconState) SlashValidator(slashedInd uint64, whistleblowerInd
 		whistleblowerInd = new(uint64)
 		*whistleblowerInd = proposerInd
 	}
-	whistleBlowerReward := newEffectiveBalance / b.BeaconConfig().WhistleBlowerRewardQuotient
+	whistleBlowerReward := newEffectiveBalance / b.BeaconConfig().GetWhistleBlowerRewardQuotient(b.Version())
 	proposerReward := b.getSlashingProposerReward(whistleBlowerReward)
 	if err := IncreaseBalance(b, proposerInd, proposerReward); err != nil {
 		return 0, err
@@ -98,6 +98,29 @@ func (b *CachingBeaconState) InitiateValidatorExit(index uint64) error {
 		return nil
 	}
 
+	var exitQueueEpoch uint64
+	if b.Version() >= clparams.ElectraVersion {
+		// Post-electra the exit queue is bounded by the exiting balance rather than by the number of validators.
+		effectiveBalance, err := b.ValidatorEffectiveBalance(int(index))
+		if err != nil {
+			return err
+		}
+		exitQueueEpoch = ComputeExitEpochAndUpdateChurn(b, effectiveBalance)
+	} else {
+		exitQueueEpoch = b.computeExitQueueEpochPreElectra()
+	}
+
+	var overflow bool
+	var newWithdrawableEpoch uint64
+	if newWithdrawableEpoch, overflow = math.SafeAdd(exitQueueEpoch, b.BeaconConfig().MinValidatorWithdrawabilityDelay); overflow {
+		return errors.New("withdrawable epoch is too big")
+	}
+	b.SetExitEpochForValidatorAtIndex(int(index), exitQueueEpoch)
+	b.SetWithdrawableEpochForValidatorAtIndex(int(index), newWithdrawableEpoch)
+	return nil
+}
+
+func (b *CachingBeaconState) computeExitQueueEpochPreElectra() uint64 {
 	currentEpoch := Epoch(b)
 	exitQueueEpoch := ComputeActivationExitEpoch(b.BeaconConfig(), currentEpoch)
 	b.ForEachValidator(func(v solid.Validator, idx, total int) bool {
@@ -117,13 +140,5 @@ func (b *CachingBeaconState) InitiateValidatorExit(index uint64) error {
 	if exitQueueChurn >= int(b.GetValidatorChurnLimit()) {
 		exitQueueEpoch += 1
 	}
-
-	var overflow bool
-	var newWithdrawableEpoch uint64
-	if newWithdrawableEpoch, overflow = math.SafeAdd(exitQueueEpoch, b.BeaconConfig().MinValidatorWithdrawabilityDelay); overflow {
-		return errors.New("withdrawable epoch is too big")
-	}
-	b.SetExitEpochForValidatorAtIndex(int(index), exitQueueEpoch)
-	b.SetWithdrawableEpochForValidatorAtIndex(int(index), newWithdrawableEpoch)
-	return nil
+	return exitQueueEpoch
 }
@@ -16,7 +16,16 @@
 
 package state
 
-import "github.com/erigontech/erigon/cl/abstract"
+import (
+	libcommon "github.com/erigontech/erigon-lib/common"
+
+	"github.com/erigontech/erigon/cl/abstract"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/cltypes"
+)
+
+// G2PointAtInfinity is the compressed BLS signature of the point at infinity, used as placeholder signature of pending deposits.
+var G2PointAtInfinity = libcommon.Bytes96{0xc0}
 
 func IncreaseBalance(b abstract.BeaconState, index, delta uint64) error {
 	currentBalance, err := b.ValidatorBalance(int(index))
@@ -37,3 +46,98 @@ func DecreaseBalance(b abstract.BeaconState, index, delta uint64) error {
 	}
 	return b.SetValidatorBalance(int(index), newBalance)
 }
+
+// ComputeExitEpochAndUpdateChurn implements compute_exit_epoch_and_update_churn (EIP-7251).
+func ComputeExitEpochAndUpdateChurn(b abstract.BeaconState, exitBalance uint64) uint64 {
+	earliestExitEpoch := max(b.EarliestExitEpoch(), ComputeActivationExitEpoch(b.BeaconConfig(), Epoch(b)))
+	perEpochChurn := b.GetActivationExitChurnLimit()
+	// New epoch for exits.
+	exitBalanceToConsume := b.ExitBalanceToConsume()
+	if b.EarliestExitEpoch() < earliestExitEpoch {
+		exitBalanceToConsume = perEpochChurn
+	}
+	// Exit doesn't fit in the current earliest epoch.
+	if exitBalance > exitBalanceToConsume {
+		balanceToProcess := exitBalance - exitBalanceToConsume
+		additionalEpochs := (balanceToProcess-1)/perEpochChurn + 1
+		earliestExitEpoch += additionalEpochs
+		exitBalanceToConsume += additionalEpochs * perEpochChurn
+	}
+	// Consume the balance and update state variables.
+	b.SetExitBalanceToConsume(exitBalanceToConsume - exitBalance)
+	b.SetEarliestExitEpoch(earliestExitEpoch)
+	return earliestExitEpoch
+}
+
+// ComputeConsolidationEpochAndUpdateChurn implements compute_consolidation_epoch_and_update_churn (EIP-7251).
+func ComputeConsolidationEpochAndUpdateChurn(b abstract.BeaconState, consolidationBalance uint64) uint64 {
+	earliestConsolidationEpoch := max(b.EarliestConsolidationEpoch(), ComputeActivationExitEpoch(b.BeaconConfig(), Epoch(b)))
+	perEpochConsolidationChurn := b.GetConsolidationChurnLimit()
+	// New epoch for consolidations.
+	consolidationBalanceToConsume := b.ConsolidationBalanceToConsume()
+	if b.EarliestConsolidationEpoch() < earliestConsolidationEpoch {
+		consolidationBalanceToConsume = perEpochConsolidationChurn
+	}
+	// Consolidation doesn't fit in the current earliest epoch.
+	if consolidationBalance > consolidationBalanceToConsume {
+		balanceToProcess := consolidationBalance - consolidationBalanceToConsume
+		additionalEpochs := (balanceToProcess-1)/perEpochConsolidationChurn + 1
+		earliestConsolidationEpoch += additionalEpochs
+		consolidationBalanceToConsume += additionalEpochs * perEpochConsolidationChurn
+	}
+	// Consume the balance and update state variables.
+	b.SetConsolidationBalanceToConsume(consolidationBalanceToConsume - consolidationBalance)
+	b.SetEarliestConsolidationEpoch(earliestConsolidationEpoch)
+	return earliestConsolidationEpoch
+}
+
+// SwitchToCompoundingValidator implements switch_to_compounding_validator (EIP-7251).
+func SwitchToCompoundingValidator(b abstract.BeaconState, index uint64) error {
+	validator, err := b.ValidatorForValidatorIndex(int(index))
+	if err != nil {
+		return err
+	}
+	credentials := validator.WithdrawalCredentials()
+	credentials[0] = byte(b.BeaconConfig().CompoundingWithdrawalPrefixByte)
+	b.SetWithdrawalCredentialForValidatorAtIndex(int(index), credentials)
+	return QueueExcessActiveBalance(b, index)
+}
+
+// QueueExcessActiveBalance implements queue_excess_active_balance: the balance above MIN_ACTIVATION_BALANCE
+// goes through the deposit churn (EIP-7251).
+func QueueExcessActiveBalance(b abstract.BeaconState, index uint64) error {
+	balance, err := b.ValidatorBalance(int(index))
+	if err != nil {
+		return err
+	}
+	if balance <= b.BeaconConfig().MinActivationBalance {
+		return nil
+	}
+	if err := b.SetValidatorBalance(int(index), b.BeaconConfig().MinActivationBalance); err != nil {
+		return err
+	}
+	validator, err := b.ValidatorForValidatorIndex(int(index))
+	if err != nil {
+		return err
+	}
+	// Use G2_POINT_AT_INFINITY as a signature field placeholder and GENESIS_SLOT to distinguish from a pending deposit request.
+	b.AppendPendingDeposit(&cltypes.PendingDeposit{
+		PubKey:                validator.PublicKey(),
+		WithdrawalCredentials: validator.WithdrawalCredentials(),
+		Amount:                balance - b.BeaconConfig().MinActivationBalance,
+		Signature:             G2PointAtInfinity,
+		Slot:                  b.BeaconConfig().GenesisSlot,
+	})
+	return nil
+}
+
+// AddValidatorToRegistry implements add_validator_to_registry.
+func AddValidatorToRegistry(b abstract.BeaconState, pubkey libcommon.Bytes48, withdrawalCredentials libcommon.Hash, amount uint64) {
+	b.AddValidator(validatorFromDepositData(b.BeaconConfig(), b.Version(), pubkey, withdrawalCredentials, amount), amount)
+	// Altair forward
+	if b.Version() >= clparams.AltairVersion {
+		b.AddCurrentEpochParticipationFlags(cltypes.ParticipationFlags(0))
+		b.AddPreviousEpochParticipationFlags(cltypes.ParticipationFlags(0))
+		b.AddInactivityScore(0)
+	}
+}
@@ -34,42 +34,50 @@ func (b *BeaconState) HashSSZ() (out [32]byte, err error) {
 	// for i := 0; i < len(b.leaves); i += 32 {
 	// 	fmt.Println(i/32, libcommon.BytesToHash(b.leaves[i:i+32]))
 	// }
-	// Pad to 32 of length
-	err = merkle_tree.MerkleRootFromFlatLeaves(b.leaves, out[:])
+	// Pad to 32 (64 since Electra) of length
+	err = merkle_tree.MerkleRootFromFlatLeaves(b.leaves[:b.leavesCount()*32], out[:])
 	return
 }
 
-func (b *BeaconState) CurrentSyncCommitteeBranch() ([][32]byte, error) {
-	if err := b.computeDirtyLeaves(); err != nil {
-		return nil, err
+// leavesCount returns how many leaves the state merkle tree has for the current version.
+func (b *BeaconState) leavesCount() int {
+	return 1 << b.treeDepth()
+}
+
+func (b *BeaconState) treeDepth() int {
+	if b.version >= clparams.ElectraVersion {
+		return stateTreeDepthElectra
 	}
+	return stateTreeDepth
+}
+
+func (b *BeaconState) leavesSchema() []interface{} {
 	schema := []interface{}{}
-	for i := 0; i < len(b.leaves); i += 32 {
+	for i := 0; i < b.leavesCount()*32; i += 32 {
 		schema = append(schema, b.leaves[i:i+32])
 	}
-	return merkle_tree.MerkleProof(5, 22, schema...)
+	return schema
 }
 
-func (b *BeaconState) NextSyncCommitteeBranch() ([][32]byte, error) {
+func (b *BeaconState) CurrentSyncCommitteeBranch() ([][32]byte, error) {
 	if err := b.computeDirtyLeaves(); err != nil {
 		return nil, err
 	}
-	schema := []interface{}{}
-	for i := 0; i < len(b.leaves); i += 32 {
-		schema = append(schema, b.leaves[i:i+32])
+	return merkle_tree.MerkleProof(b.treeDepth(), int(CurrentSyncCommitteeLeafIndex), b.leavesSchema()...)
+}
+
+func (b *BeaconState) NextSyncCommitteeBranch() ([][32]byte, error) {
+	if err := b.computeDirtyLeaves(); err != nil {
+		return nil, err
 	}
-	return merkle_tree.MerkleProof(5, 23, schema...)
+	return merkle_tree.MerkleProof(b.treeDepth(), int(NextSyncCommitteeLeafIndex), b.leavesSchema()...)
 }
 
 func (b *BeaconState) FinalityRootBranch() ([][32]byte, error) {
 	if err := b.computeDirtyLeaves(); err != nil {
 		return nil, err
 	}
-	schema := []interface{}{}
-	for i := 0; i < len(b.leaves); i += 32 {
-		schema = append(schema, b.leaves[i:i+32])
-	}
-	proof, err := merkle_tree.MerkleProof(5, 20, schema...)
+	proof, err := merkle_tree.MerkleProof(b.treeDepth(), int(FinalizedCheckpointLeafIndex), b.leavesSchema()...)
 	if err != nil {
 		return nil, err
 	}
@@ -178,6 +186,20 @@ func (b *BeaconState) computeDirtyLeaves() error {
 	beaconStateHasher.add(NextWithdrawalIndexLeafIndex, b.nextWithdrawalIndex)
 	beaconStateHasher.add(NextWithdrawalValidatorIndexLeafIndex, b.nextWithdrawalValidatorIndex)
 	beaconStateHasher.add(HistoricalSummariesLeafIndex, b.historicalSummaries)
+	if b.version < clparams.ElectraVersion {
+		beaconStateHasher.run()
+		return nil
+	}
+	// Electra fields
+	beaconStateHasher.add(DepositRequestsStartIndexLeafIndex, b.depositRequestsStartIndex)
+	beaconStateHasher.add(DepositBalanceToConsumeLeafIndex, b.depositBalanceToConsume)
+	beaconStateHasher.add(ExitBalanceToConsumeLeafIndex, b.exitBalanceToConsume)
+	beaconStateHasher.add(EarliestExitEpochLeafIndex, b.earliestExitEpoch)
+	beaconStateHasher.add(ConsolidationBalanceToConsumeLeafIndex, b.consolidationBalanceToConsume)
+	beaconStateHasher.add(EarliestConsolidationEpochLeafIndex, b.earliestConsolidationEpoch)
+	beaconStateHasher.add(PendingDepositsLeafIndex, b.pendingDeposits)
+	beaconStateHasher.add(PendingPartialWithdrawalsLeafIndex, b.pendingPartialWithdrawals)
+	beaconStateHasher.add(PendingConsolidationsLeafIndex, b.pendingConsolidations)
 
 	beaconStateHasher.run()
 
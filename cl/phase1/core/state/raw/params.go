@@ -51,10 +51,23 @@ const (
 	NextWithdrawalIndexLeafIndex          StateLeafIndex = 25
 	NextWithdrawalValidatorIndexLeafIndex StateLeafIndex = 26
 	HistoricalSummariesLeafIndex          StateLeafIndex = 27
+	// Electra
+	DepositRequestsStartIndexLeafIndex     StateLeafIndex = 28
+	DepositBalanceToConsumeLeafIndex       StateLeafIndex = 29
+	ExitBalanceToConsumeLeafIndex          StateLeafIndex = 30
+	EarliestExitEpochLeafIndex             StateLeafIndex = 31
+	ConsolidationBalanceToConsumeLeafIndex StateLeafIndex = 32
+	EarliestConsolidationEpochLeafIndex    StateLeafIndex = 33
+	PendingDepositsLeafIndex               StateLeafIndex = 34
+	PendingPartialWithdrawalsLeafIndex     StateLeafIndex = 35
+	PendingConsolidationsLeafIndex         StateLeafIndex = 36
 )
 
 const (
-	StateLeafSize = 28
+	StateLeafSize = 37
+	// Electra state has more than 32 fields, so its merkle tree is one level deeper.
+	stateTreeDepth        = 5
+	stateTreeDepthElectra = 6
 
 	LeafInitValue  = 0
 	LeafCleanValue = 1
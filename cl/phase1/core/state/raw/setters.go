@@ -513,3 +513,63 @@ func (b *BeaconState) SetSlashings(slashings solid.Uint64VectorSSZ) {
 	b.markLeaf(SlashingsLeafIndex)
 	b.slashings = slashings
 }
+
+func (b *BeaconState) SetDepositRequestsStartIndex(index uint64) {
+	b.depositRequestsStartIndex = index
+	b.markLeaf(DepositRequestsStartIndexLeafIndex)
+}
+
+func (b *BeaconState) SetDepositBalanceToConsume(balance uint64) {
+	b.depositBalanceToConsume = balance
+	b.markLeaf(DepositBalanceToConsumeLeafIndex)
+}
+
+func (b *BeaconState) SetExitBalanceToConsume(balance uint64) {
+	b.exitBalanceToConsume = balance
+	b.markLeaf(ExitBalanceToConsumeLeafIndex)
+}
+
+func (b *BeaconState) SetEarliestExitEpoch(epoch uint64) {
+	b.earliestExitEpoch = epoch
+	b.markLeaf(EarliestExitEpochLeafIndex)
+}
+
+func (b *BeaconState) SetConsolidationBalanceToConsume(balance uint64) {
+	b.consolidationBalanceToConsume = balance
+	b.markLeaf(ConsolidationBalanceToConsumeLeafIndex)
+}
+
+func (b *BeaconState) SetEarliestConsolidationEpoch(epoch uint64) {
+	b.earliestConsolidationEpoch = epoch
+	b.markLeaf(EarliestConsolidationEpochLeafIndex)
+}
+
+func (b *BeaconState) AppendPendingDeposit(deposit *cltypes.PendingDeposit) {
+	b.pendingDeposits.Append(deposit)
+	b.markLeaf(PendingDepositsLeafIndex)
+}
+
+func (b *BeaconState) SetPendingDeposits(deposits *solid.ListSSZ[*cltypes.PendingDeposit]) {
+	b.pendingDeposits = deposits
+	b.markLeaf(PendingDepositsLeafIndex)
+}
+
+func (b *BeaconState) AppendPendingPartialWithdrawal(withdrawal *cltypes.PendingPartialWithdrawal) {
+	b.pendingPartialWithdrawals.Append(withdrawal)
+	b.markLeaf(PendingPartialWithdrawalsLeafIndex)
+}
+
+func (b *BeaconState) SetPendingPartialWithdrawals(withdrawals *solid.ListSSZ[*cltypes.PendingPartialWithdrawal]) {
+	b.pendingPartialWithdrawals = withdrawals
+	b.markLeaf(PendingPartialWithdrawalsLeafIndex)
+}
+
+func (b *BeaconState) AppendPendingConsolidation(consolidation *cltypes.PendingConsolidation) {
+	b.pendingConsolidations.Append(consolidation)
+	b.markLeaf(PendingConsolidationsLeafIndex)
+}
+
+func (b *BeaconState) SetPendingConsolidations(consolidations *solid.ListSSZ[*cltypes.PendingConsolidation]) {
+	b.pendingConsolidations = consolidations
+	b.markLeaf(PendingConsolidationsLeafIndex)
+}
@@ -68,6 +68,16 @@ type BeaconState struct {
 	nextWithdrawalIndex          uint64
 	nextWithdrawalValidatorIndex uint64
 	historicalSummaries          *solid.ListSSZ[*cltypes.HistoricalSummary]
+	// Electra
+	depositRequestsStartIndex     uint64
+	depositBalanceToConsume       uint64
+	exitBalanceToConsume          uint64
+	earliestExitEpoch             uint64
+	consolidationBalanceToConsume uint64
+	earliestConsolidationEpoch    uint64
+	pendingDeposits               *solid.ListSSZ[*cltypes.PendingDeposit]
+	pendingPartialWithdrawals     *solid.ListSSZ[*cltypes.PendingPartialWithdrawal]
+	pendingConsolidations         *solid.ListSSZ[*cltypes.PendingConsolidation]
 	// Phase0: genesis fork. these 2 fields replace participation bits.
 	previousEpochAttestations *solid.ListSSZ[*solid.PendingAttestation]
 	currentEpochAttestations  *solid.ListSSZ[*solid.PendingAttestation]
@@ -111,7 +121,10 @@ func New(cfg *clparams.BeaconChainConfig) *BeaconState {
 		previousJustifiedCheckpoint: solid.NewCheckpoint(),
 		currentJustifiedCheckpoint:  solid.NewCheckpoint(),
 		finalizedCheckpoint:         solid.NewCheckpoint(),
-		leaves:                      make([]byte, 32*32),
+		pendingDeposits:             solid.NewStaticListSSZ[*cltypes.PendingDeposit](int(cfg.PendingDepositsLimit), 192),
+		pendingPartialWithdrawals:   solid.NewStaticListSSZ[*cltypes.PendingPartialWithdrawal](int(cfg.PendingPartialWithdrawalsLimit), 24),
+		pendingConsolidations:       solid.NewStaticListSSZ[*cltypes.PendingConsolidation](int(cfg.PendingConsolidationsLimit), 16),
+		leaves:                      make([]byte, 64*32),
 	}
 	state.init()
 	return state
@@ -166,6 +179,17 @@ func (b *BeaconState) MarshalJSON() ([]byte, error) {
 		obj["next_withdrawal_validator_index"] = strconv.FormatInt(int64(b.nextWithdrawalValidatorIndex), 10)
 		obj["historical_summaries"] = b.historicalSummaries
 	}
+	if b.version >= clparams.ElectraVersion {
+		obj["deposit_requests_start_index"] = strconv.FormatUint(b.depositRequestsStartIndex, 10)
+		obj["deposit_balance_to_consume"] = strconv.FormatUint(b.depositBalanceToConsume, 10)
+		obj["exit_balance_to_consume"] = strconv.FormatUint(b.exitBalanceToConsume, 10)
+		obj["earliest_exit_epoch"] = strconv.FormatUint(b.earliestExitEpoch, 10)
+		obj["consolidation_balance_to_consume"] = strconv.FormatUint(b.consolidationBalanceToConsume, 10)
+		obj["earliest_consolidation_epoch"] = strconv.FormatUint(b.earliestConsolidationEpoch, 10)
+		obj["pending_deposits"] = b.pendingDeposits
+		obj["pending_partial_withdrawals"] = b.pendingPartialWithdrawals
+		obj["pending_consolidations"] = b.pendingConsolidations
+	}
 	return json.Marshal(obj)
 }
 
@@ -20,6 +20,7 @@ import (
 	"encoding/binary"
 	"fmt"
 
+	"github.com/erigontech/erigon/cl/clparams"
 	"github.com/erigontech/erigon/cl/phase1/core/state/raw"
 
 	"github.com/erigontech/erigon/cl/utils"
@@ -29,7 +30,14 @@ func ComputeProposerIndex(b *raw.BeaconState, indices []uint64, seed [32]byte) (
 	if len(indices) == 0 {
 		return 0, nil
 	}
-	maxRandomByte := uint64(1<<8 - 1)
+	maxRandomValue := uint64(1<<8 - 1)
+	maxEffectiveBalance := b.BeaconConfig().MaxEffectiveBalance
+	isElectra := b.Version() >= clparams.ElectraVersion
+	if isElectra {
+		// Post-electra the random value is 16 bits wide, so that high-balance validators can be weighted properly.
+		maxRandomValue = uint64(1<<16 - 1)
+		maxEffectiveBalance = b.BeaconConfig().MaxEffectiveBalanceElectra
+	}
 	i := uint64(0)
 	total := uint64(len(indices))
 	input := make([]byte, 40)
@@ -44,13 +52,21 @@ func ComputeProposerIndex(b *raw.BeaconState, indices []uint64, seed [32]byte) (
 			return 0, fmt.Errorf("candidate index out of range: %d for validator set of length: %d", candidateIndex, b.ValidatorLength())
 		}
 		copy(input, seed[:])
-		binary.LittleEndian.PutUint64(input[32:], i/32)
-		randomByte := uint64(utils.Sha256(input)[i%32])
+		var randomValue uint64
+		if isElectra {
+			binary.LittleEndian.PutUint64(input[32:], i/16)
+			randomBytes := utils.Sha256(input)
+			offset := (i % 16) * 2
+			randomValue = uint64(binary.LittleEndian.Uint16(randomBytes[offset : offset+2]))
+		} else {
+			binary.LittleEndian.PutUint64(input[32:], i/32)
+			randomValue = uint64(utils.Sha256(input)[i%32])
+		}
 		validator, err := b.ValidatorForValidatorIndex(int(candidateIndex))
 		if err != nil {
 			return 0, err
 		}
-		if validator.EffectiveBalance()*maxRandomByte >= b.BeaconConfig().MaxEffectiveBalance*randomByte {
+		if validator.EffectiveBalance()*maxRandomValue >= maxEffectiveBalance*randomValue {
 			return candidateIndex, nil
 		}
 		i += 1
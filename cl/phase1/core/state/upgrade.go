@@ -17,6 +17,8 @@
 package state
 
 import (
+	"sort"
+
 	libcommon "github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon/cl/clparams"
 	"github.com/erigontech/erigon/cl/cltypes"
@@ -130,3 +132,85 @@ func (b *CachingBeaconState) UpgradeToDeneb() error {
 	b.SetVersion(clparams.DenebVersion)
 	return nil
 }
+
+func (b *CachingBeaconState) UpgradeToElectra() error {
+	b.previousStateRoot = libcommon.Hash{}
+	epoch := Epoch(b.BeaconState)
+	beaconConfig := b.BeaconConfig()
+	// update version
+	fork := b.Fork()
+	fork.Epoch = epoch
+	fork.PreviousVersion = fork.CurrentVersion
+	fork.CurrentVersion = utils.Uint32ToBytes4(uint32(beaconConfig.ElectraForkVersion))
+	b.SetFork(fork)
+	// Update the state root cache
+	b.SetVersion(clparams.ElectraVersion)
+
+	// Set new fields
+	earliestExitEpoch := ComputeActivationExitEpoch(beaconConfig, epoch)
+	b.ForEachValidator(func(v solid.Validator, _, _ int) bool {
+		if v.ExitEpoch() != beaconConfig.FarFutureEpoch && v.ExitEpoch() > earliestExitEpoch {
+			earliestExitEpoch = v.ExitEpoch()
+		}
+		return true
+	})
+	b.SetDepositRequestsStartIndex(beaconConfig.UnsetDepositRequestsStartIndex)
+	b.SetDepositBalanceToConsume(0)
+	b.SetEarliestExitEpoch(earliestExitEpoch + 1)
+	b.SetEarliestConsolidationEpoch(ComputeActivationExitEpoch(beaconConfig, epoch))
+	b.SetExitBalanceToConsume(b.GetActivationExitChurnLimit())
+	b.SetConsolidationBalanceToConsume(b.GetConsolidationChurnLimit())
+
+	// Add validators that are not yet active to pending deposits
+	preActivation := []uint64{}
+	b.ForEachValidator(func(v solid.Validator, idx, _ int) bool {
+		if v.ActivationEpoch() == beaconConfig.FarFutureEpoch {
+			preActivation = append(preActivation, uint64(idx))
+		}
+		return true
+	})
+	eligibilityEpochs := make(map[uint64]uint64, len(preActivation))
+	for _, index := range preActivation {
+		validator, err := b.ValidatorForValidatorIndex(int(index))
+		if err != nil {
+			return err
+		}
+		eligibilityEpochs[index] = validator.ActivationEligibilityEpoch()
+	}
+	sort.SliceStable(preActivation, func(i, j int) bool {
+		return eligibilityEpochs[preActivation[i]] < eligibilityEpochs[preActivation[j]]
+	})
+	for _, index := range preActivation {
+		balance, err := b.ValidatorBalance(int(index))
+		if err != nil {
+			return err
+		}
+		if err := b.SetValidatorBalance(int(index), 0); err != nil {
+			return err
+		}
+		b.SetEffectiveBalanceForValidatorAtIndex(int(index), 0)
+		b.SetActivationEligibilityEpochForValidatorAtIndex(int(index), beaconConfig.FarFutureEpoch)
+		validator, err := b.ValidatorForValidatorIndex(int(index))
+		if err != nil {
+			return err
+		}
+		// Use G2_POINT_AT_INFINITY as a signature field placeholder and GENESIS_SLOT to distinguish from a pending deposit request.
+		b.AppendPendingDeposit(&cltypes.PendingDeposit{
+			PubKey:                validator.PublicKey(),
+			WithdrawalCredentials: validator.WithdrawalCredentials(),
+			Amount:                balance,
+			Signature:             G2PointAtInfinity,
+			Slot:                  beaconConfig.GenesisSlot,
+		})
+	}
+
+	// Ensure early adopters of compounding credentials go through the activation churn
+	var err error
+	b.ForEachValidator(func(v solid.Validator, idx, _ int) bool {
+		if HasCompoundingWithdrawalCredential(beaconConfig, v) {
+			err = QueueExcessActiveBalance(b, uint64(idx))
+		}
+		return err == nil
+	})
+	return err
+}
@@ -41,3 +41,27 @@ func TestUpgradeAndExpectedWithdrawals(t *testing.T) {
 	assert.Empty(t, w)
 
 }
+
+func TestUpgradeToElectra(t *testing.T) {
+	s := New(&clparams.MainnetBeaconConfig)
+	utils.DecodeSSZSnappy(s, stateEncoded, int(clparams.Phase0Version))
+	require.NoError(t, s.UpgradeToAltair())
+	require.NoError(t, s.UpgradeToBellatrix())
+	require.NoError(t, s.UpgradeToCapella())
+	require.NoError(t, s.UpgradeToDeneb())
+	require.NoError(t, s.UpgradeToElectra())
+	require.Equal(t, clparams.ElectraVersion, s.Version())
+	require.Equal(t, s.BeaconConfig().UnsetDepositRequestsStartIndex, s.DepositRequestsStartIndex())
+	require.Equal(t, s.GetActivationExitChurnLimit(), s.ExitBalanceToConsume())
+	require.Equal(t, s.GetConsolidationChurnLimit(), s.ConsolidationBalanceToConsume())
+	// encoding must round-trip with the new fields
+	encoded, err := s.EncodeSSZ(nil)
+	require.NoError(t, err)
+	decoded := New(&clparams.MainnetBeaconConfig)
+	require.NoError(t, decoded.DecodeSSZ(encoded, int(clparams.ElectraVersion)))
+	expectedRoot, err := s.HashSSZ()
+	require.NoError(t, err)
+	haveRoot, err := decoded.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, expectedRoot, haveRoot)
+}
@@ -19,6 +19,7 @@ package state
 import (
 	"sort"
 
+	libcommon "github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon/cl/clparams"
 	"github.com/erigontech/erigon/cl/cltypes"
 	"github.com/erigontech/erigon/cl/cltypes/solid"
@@ -42,40 +43,79 @@ func GetIndexedAttestation(attestation *solid.Attestation, attestingIndicies []u
 	sort.Slice(attestingIndicies, func(i, j int) bool {
 		return attestingIndicies[i] < attestingIndicies[j]
 	})
+	version := clparams.Phase0Version
+	if attestation.IsElectra() {
+		version = clparams.ElectraVersion
+	}
 	return &cltypes.IndexedAttestation{
-		AttestingIndices: solid.NewRawUint64List(2048, attestingIndicies),
+		AttestingIndices: solid.NewRawUint64List(cltypes.IndexedAttestationIndicesLimit(version), attestingIndicies),
 		Data:             attestation.AttestantionData(),
 		Signature:        attestation.Signature(),
 	}
 }
 
 func ValidatorFromDeposit(conf *clparams.BeaconChainConfig, deposit *cltypes.Deposit) solid.Validator {
-	amount := deposit.Data.Amount
-	effectiveBalance := min(amount-amount%conf.EffectiveBalanceIncrement, conf.MaxEffectiveBalance)
+	return validatorFromDepositData(conf, clparams.Phase0Version, deposit.Data.PubKey, deposit.Data.WithdrawalCredentials, deposit.Data.Amount)
+}
 
+// validatorFromDepositData implements get_validator_from_deposit, post-electra the effective balance is capped by get_max_effective_balance.
+func validatorFromDepositData(conf *clparams.BeaconChainConfig, version clparams.StateVersion, pubkey libcommon.Bytes48, withdrawalCredentials libcommon.Hash, amount uint64) solid.Validator {
 	validator := solid.NewValidator()
-	validator.SetPublicKey(deposit.Data.PubKey)
-	validator.SetWithdrawalCredentials(deposit.Data.WithdrawalCredentials)
+	validator.SetPublicKey(pubkey)
+	validator.SetWithdrawalCredentials(withdrawalCredentials)
 	validator.SetActivationEligibilityEpoch(conf.FarFutureEpoch)
 	validator.SetActivationEpoch(conf.FarFutureEpoch)
 	validator.SetExitEpoch(conf.FarFutureEpoch)
 	validator.SetWithdrawableEpoch(conf.FarFutureEpoch)
-	validator.SetEffectiveBalance(effectiveBalance)
+	validator.SetEffectiveBalance(min(amount-amount%conf.EffectiveBalanceIncrement, GetMaxEffectiveBalance(conf, version, validator)))
 	return validator
 }
 
 // Check whether a validator is fully withdrawable at the given epoch.
-func isFullyWithdrawableValidator(conf *clparams.BeaconChainConfig, validator solid.Validator, balance uint64, epoch uint64) bool {
-	withdrawalCredentials := validator.WithdrawalCredentials()
-	return withdrawalCredentials[0] == byte(conf.ETH1AddressWithdrawalPrefixByte) &&
+func isFullyWithdrawableValidator(conf *clparams.BeaconChainConfig, version clparams.StateVersion, validator solid.Validator, balance uint64, epoch uint64) bool {
+	return hasWithdrawableCredential(conf, version, validator) &&
 		validator.WithdrawableEpoch() <= epoch && balance > 0
 }
 
 // Check whether a validator is partially withdrawable.
-func isPartiallyWithdrawableValidator(conf *clparams.BeaconChainConfig, validator solid.Validator, balance uint64) bool {
-	withdrawalCredentials := validator.WithdrawalCredentials()
-	return withdrawalCredentials[0] == byte(conf.ETH1AddressWithdrawalPrefixByte) &&
-		validator.EffectiveBalance() == conf.MaxEffectiveBalance && balance > conf.MaxEffectiveBalance
+func isPartiallyWithdrawableValidator(conf *clparams.BeaconChainConfig, version clparams.StateVersion, validator solid.Validator, balance uint64) bool {
+	maxEffectiveBalance := GetMaxEffectiveBalance(conf, version, validator)
+	return hasWithdrawableCredential(conf, version, validator) &&
+		validator.EffectiveBalance() == maxEffectiveBalance && balance > maxEffectiveBalance
+}
+
+// hasWithdrawableCredential - before electra only eth1 credentials allow withdrawals, compounding ones are added by EIP-7251.
+func hasWithdrawableCredential(conf *clparams.BeaconChainConfig, version clparams.StateVersion, validator solid.Validator) bool {
+	if version >= clparams.ElectraVersion {
+		return HasExecutionWithdrawalCredential(conf, validator)
+	}
+	return HasEth1WithdrawalCredential(conf, validator)
+}
+
+// HasEth1WithdrawalCredential checks if the validator has the 0x01 withdrawal credential prefix.
+func HasEth1WithdrawalCredential(conf *clparams.BeaconChainConfig, validator solid.Validator) bool {
+	return validator.WithdrawalCredentials()[0] == byte(conf.ETH1AddressWithdrawalPrefixByte)
+}
+
+// HasCompoundingWithdrawalCredential checks if the validator has the 0x02 withdrawal credential prefix (EIP-7251).
+func HasCompoundingWithdrawalCredential(conf *clparams.BeaconChainConfig, validator solid.Validator) bool {
+	return validator.WithdrawalCredentials()[0] == byte(conf.CompoundingWithdrawalPrefixByte)
+}
+
+// HasExecutionWithdrawalCredential checks if the validator has either an eth1 or a compounding withdrawal credential.
+func HasExecutionWithdrawalCredential(conf *clparams.BeaconChainConfig, validator solid.Validator) bool {
+	return HasEth1WithdrawalCredential(conf, validator) || HasCompoundingWithdrawalCredential(conf, validator)
+}
+
+// GetMaxEffectiveBalance returns the maximum effective balance of the validator, post-electra it depends on the credentials.
+func GetMaxEffectiveBalance(conf *clparams.BeaconChainConfig, version clparams.StateVersion, validator solid.Validator) uint64 {
+	if version < clparams.ElectraVersion {
+		return conf.MaxEffectiveBalance
+	}
+	if HasCompoundingWithdrawalCredential(conf, validator) {
+		return conf.MaxEffectiveBalanceElectra
+	}
+	return conf.MinActivationBalance
 }
 
 func ComputeActivationExitEpoch(config *clparams.BeaconChainConfig, epoch uint64) uint64 {
@@ -46,6 +46,8 @@ type ExecutionEngine interface {
 	GetBodiesByRange(ctx context.Context, start, count uint64) ([]*types.RawBody, error)
 	GetBodiesByHashes(ctx context.Context, hashes []libcommon.Hash) ([]*types.RawBody, error)
 	HasBlock(ctx context.Context, hash libcommon.Hash) (bool, error)
+	// HeaderNumber returns the number of the block with the given hash, nil if the block is unknown.
+	HeaderNumber(ctx context.Context, hash libcommon.Hash) (*uint64, error)
 	// Snapshots
 	FrozenBlocks(ctx context.Context) uint64
 	HasGapInSnapshots(ctx context.Context) bool
@@ -27,6 +27,7 @@ import (
 	"github.com/erigontech/erigon/cl/clparams"
 	"github.com/erigontech/erigon/cl/cltypes"
 	"github.com/erigontech/erigon/cl/cltypes/solid"
+	"github.com/erigontech/erigon/cl/das"
 	"github.com/erigontech/erigon/cl/monitor"
 	"github.com/erigontech/erigon/cl/persistence/blob_storage"
 	"github.com/erigontech/erigon/cl/phase1/core/state"
@@ -104,6 +105,10 @@ type ForkChoiceStore struct {
 	equivocatingIndicies []byte
 	forkGraph            fork_graph.ForkGraph
 	blobStorage          blob_storage.BlobStorage
+	// columns sampled before importing blocks after the PeerDAS fork
+	dataColumnsCustody atomic.Pointer[das.Custody]
+	// optional log of all of the inputs, for offline replay
+	recorder atomic.Pointer[Recorder]
 	// I use the cache due to the convenient auto-cleanup feauture.
 	checkpointStates sync.Map // We keep ssz snappy of it as the full beacon state is full of rendundant data.
 
@@ -488,9 +493,19 @@ func (f *ForkChoiceStore) Synced() bool {
 }
 
 func (f *ForkChoiceStore) SetSynced(s bool) {
+	f.recordSynced(s)
 	f.synced.Store(s)
 }
 
+// SetDataColumnsCustody sets the custody of the node, blocks after the PeerDAS fork are imported only once their sampled columns are stored.
+func (f *ForkChoiceStore) SetDataColumnsCustody(custody *das.Custody) {
+	f.dataColumnsCustody.Store(custody)
+}
+
+func (f *ForkChoiceStore) DataColumnsCustody() *das.Custody {
+	return f.dataColumnsCustody.Load()
+}
+
 func (f *ForkChoiceStore) GetLightClientBootstrap(blockRoot libcommon.Hash) (*cltypes.LightClientBootstrap, bool) {
 	return f.forkGraph.GetLightClientBootstrap(blockRoot)
 }
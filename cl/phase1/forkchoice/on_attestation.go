@@ -40,6 +40,7 @@ func (f *ForkChoiceStore) OnAttestation(
 	}
 	f.mu.Lock()
 	defer f.mu.Unlock()
+	f.recordAttestation(attestation, fromBlock, insert)
 	f.headHash = libcommon.Hash{}
 	data := attestation.AttestantionData()
 	if err := f.ValidateOnAttestation(attestation); err != nil {
@@ -86,6 +87,7 @@ func (f *ForkChoiceStore) ProcessAttestingIndicies(
 ) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
+	f.recordAttestingIndicies(attestation, attestionIndicies)
 	f.processAttestingIndicies(attestation, attestionIndicies)
 }
 
@@ -35,6 +35,7 @@ func (f *ForkChoiceStore) OnAttesterSlashing(attesterSlashing *cltypes.AttesterS
 	}
 	f.mu.Lock()
 	defer f.mu.Unlock()
+	f.recordAttesterSlashing(attesterSlashing, test)
 	// Check if this attestation is even slashable.
 	attestation1 := attesterSlashing.Attestation_1
 	attestation2 := attesterSlashing.Attestation_2
@@ -32,6 +32,7 @@ import (
 	"github.com/erigontech/erigon/cl/clparams"
 	"github.com/erigontech/erigon/cl/cltypes"
 	"github.com/erigontech/erigon/cl/cltypes/solid"
+	"github.com/erigontech/erigon/cl/das"
 	"github.com/erigontech/erigon/cl/phase1/core/state"
 	"github.com/erigontech/erigon/cl/phase1/execution_client"
 	"github.com/erigontech/erigon/cl/phase1/forkchoice/fork_graph"
@@ -102,12 +103,15 @@ func (f *ForkChoiceStore) OnBlock(ctx context.Context, block *cltypes.SignedBeac
 	// Check if blob data is available
 	if block.Version() >= clparams.DenebVersion && checkDataAvaiability {
 		if err := f.isDataAvailable(ctx, block.Block.Slot, blockRoot, block.Block.Body.BlobKzgCommitments); err != nil {
+			f.recordBlock(block, newPayload, fullValidation, false)
 			if err == ErrEIP4844DataNotAvailable {
 				return err
 			}
 			return fmt.Errorf("OnBlock: data is not available for block %x: %v", libcommon.Hash(blockRoot), err)
 		}
 	}
+	// blocks are recorded once their data availability is known, the checks above do not depend on external inputs.
+	f.recordBlock(block, newPayload, fullValidation, true)
 
 	startEngine := time.Now()
 	if newPayload && f.engine != nil {
@@ -252,6 +256,9 @@ func (f *ForkChoiceStore) isDataAvailable(ctx context.Context, slot uint64, bloc
 	if f.blobStorage == nil {
 		return nil
 	}
+	if custody := f.dataColumnsCustody.Load(); custody != nil && f.beaconCfg.IsPeerDASEpoch(slot/f.beaconCfg.SlotsPerEpoch) {
+		return f.isDataColumnsAvailable(slot, blockRoot, blobKzgCommitments, custody)
+	}
 
 	commitmentsLeftToCheck := map[libcommon.Bytes48]struct{}{}
 	blobKzgCommitments.Range(func(index int, value *cltypes.KZGCommitment, length int) bool {
@@ -287,3 +294,18 @@ func (f *ForkChoiceStore) isDataAvailable(ctx context.Context, slot uint64, bloc
 	}
 	return nil
 }
+
+// isDataColumnsAvailable implements the PeerDAS is_data_available: all the sampled columns of the block must have been stored.
+func (f *ForkChoiceStore) isDataColumnsAvailable(slot uint64, blockRoot libcommon.Hash, blobKzgCommitments *solid.ListSSZ[*cltypes.KZGCommitment], custody *das.Custody) error {
+	if blobKzgCommitments.Len() == 0 {
+		return nil
+	}
+	has, err := f.blobStorage.HasDataColumnSidecars(slot, blockRoot, custody.SampledColumns(blockRoot))
+	if err != nil {
+		return fmt.Errorf("cannot check data avaiability. failed to read data column sidecars: %v", err)
+	}
+	if !has {
+		return ErrEIP4844DataNotAvailable // This should then schedule the block for reprocessing
+	}
+	return nil
+}
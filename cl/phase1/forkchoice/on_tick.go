@@ -23,6 +23,7 @@ import (
 
 // OnTick executes on_tick operation for forkchoice.
 func (f *ForkChoiceStore) OnTick(time uint64) {
+	f.recordTick(time)
 	tickSlot := (time - f.genesisTime) / f.beaconCfg.SecondsPerSlot
 	for f.Slot() < tickSlot {
 		previousTime := f.genesisTime + (f.Slot()+1)*f.beaconCfg.SecondsPerSlot
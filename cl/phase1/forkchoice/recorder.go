@@ -0,0 +1,316 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package forkchoice
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/golang/snappy"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types/ssz"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/cltypes/solid"
+	"github.com/erigontech/erigon/cl/phase1/core/state"
+)
+
+// RecordKind is the kind of a forkchoice input in the record log.
+type RecordKind byte
+
+const (
+	RecordAnchor RecordKind = iota + 1
+	RecordTick
+	RecordBlock
+	RecordAttestation
+	RecordAttesterSlashing
+	RecordAttestingIndicies
+	RecordSynced
+)
+
+func (k RecordKind) String() string {
+	switch k {
+	case RecordAnchor:
+		return "anchor"
+	case RecordTick:
+		return "tick"
+	case RecordBlock:
+		return "block"
+	case RecordAttestation:
+		return "attestation"
+	case RecordAttesterSlashing:
+		return "attester_slashing"
+	case RecordAttestingIndicies:
+		return "attesting_indicies"
+	case RecordSynced:
+		return "synced"
+	default:
+		return fmt.Sprintf("unknown(%d)", byte(k))
+	}
+}
+
+// Record flags, their meaning depends on the kind of the record.
+const (
+	recordFlagNewPayload      byte = 1 << iota // block
+	recordFlagFullValidation                   // block
+	recordFlagDataUnavailable                  // block: the blob data availability check failed
+)
+
+const (
+	recordFlagFromBlock byte = 1 << iota // attestation
+	recordFlagInsert                     // attestation
+)
+
+const recordFlagTest byte = 1 // attester slashing
+
+const recordFlagSynced byte = 1 // synced
+
+var ErrInvalidRecord = errors.New("invalid forkchoice record")
+
+// Record is a single forkchoice input. The log is a snappy framed stream of records encoded as:
+//
+//	kind (1 byte) | version (1 byte) | flags (1 byte) | payload length (uvarint) | payload
+//
+// where the payload is the SSZ encoding of the input (the state for the anchor, the block, the attestation...).
+type Record struct {
+	Kind    RecordKind
+	Version clparams.StateVersion
+	Flags   byte
+	Payload []byte
+}
+
+// Recorder writes every input of the forkchoice store to a log, so that it can be replayed offline.
+type Recorder struct {
+	mu     sync.Mutex
+	w      *snappy.Writer
+	closer io.Closer
+	err    error
+	buf    []byte
+
+	lastTick atomic.Uint64
+}
+
+// NewRecorder creates a recorder writing to w, starting with the anchor state of the store. If w is an io.Closer,
+// it is closed by Close.
+func NewRecorder(w io.Writer, anchorState *state.CachingBeaconState) (*Recorder, error) {
+	r := &Recorder{w: snappy.NewBufferedWriter(w)}
+	if closer, ok := w.(io.Closer); ok {
+		r.closer = closer
+	}
+	encoded, err := anchorState.EncodeSSZ(nil)
+	if err != nil {
+		return nil, err
+	}
+	r.write(RecordAnchor, anchorState.Version(), 0, encoded)
+	if err := r.Flush(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Recorder) write(kind RecordKind, version clparams.StateVersion, flags byte, payload []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+	r.buf = append(r.buf[:0], byte(kind), byte(version), flags)
+	r.buf = binary.AppendUvarint(r.buf, uint64(len(payload)))
+	if _, r.err = r.w.Write(r.buf); r.err == nil {
+		_, r.err = r.w.Write(payload)
+	}
+	if r.err != nil {
+		log.Warn("[Forkchoice] recording stopped", "err", r.err)
+	}
+}
+
+func (r *Recorder) writeSSZ(kind RecordKind, version clparams.StateVersion, flags byte, obj ssz.Marshaler) {
+	encoded, err := obj.EncodeSSZ(nil)
+	if err != nil {
+		log.Warn("[Forkchoice] could not record input", "kind", kind, "err", err)
+		return
+	}
+	r.write(kind, version, flags, encoded)
+}
+
+// Flush writes the buffered records to the underlying writer.
+func (r *Recorder) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	if r.err = r.w.Flush(); r.err != nil {
+		log.Warn("[Forkchoice] recording stopped", "err", r.err)
+	}
+	return r.err
+}
+
+// Close flushes the log and closes the underlying writer.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	err := r.w.Close()
+	if r.closer != nil {
+		if closeErr := r.closer.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if r.err == nil {
+		r.err = errors.New("recorder is closed")
+	}
+	return err
+}
+
+// RecordReader reads a forkchoice record log.
+type RecordReader struct {
+	r *bufio.Reader
+}
+
+func NewRecordReader(r io.Reader) *RecordReader {
+	return &RecordReader{r: bufio.NewReader(snappy.NewReader(r))}
+}
+
+// Next reads the next record, it returns io.EOF at the end of the log.
+func (r *RecordReader) Next() (*Record, error) {
+	var header [3]byte
+	if _, err := io.ReadFull(r.r, header[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return nil, fmt.Errorf("%w: truncated header", ErrInvalidRecord)
+		}
+		return nil, err
+	}
+	length, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRecord, err)
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r.r, payload); err != nil {
+		return nil, fmt.Errorf("%w: truncated payload: %v", ErrInvalidRecord, err)
+	}
+	return &Record{
+		Kind:    RecordKind(header[0]),
+		Version: clparams.StateVersion(header[1]),
+		Flags:   header[2],
+		Payload: payload,
+	}, nil
+}
+
+// SetRecorder makes the store record all of its inputs, nil stops the recording.
+func (f *ForkChoiceStore) SetRecorder(recorder *Recorder) {
+	f.recorder.Store(recorder)
+}
+
+func (f *ForkChoiceStore) versionAtSlot(slot uint64) clparams.StateVersion {
+	return f.beaconCfg.GetCurrentStateVersion(slot / f.beaconCfg.SlotsPerEpoch)
+}
+
+func (f *ForkChoiceStore) recordTick(time uint64) {
+	recorder := f.recorder.Load()
+	if recorder == nil {
+		return
+	}
+	// the store is ticked way more often than once per second, repeated ticks are no-ops. lastTick is offset by one
+	// so that a first tick at time 0 is recorded.
+	if recorder.lastTick.Swap(time+1) == time+1 {
+		return
+	}
+	recorder.write(RecordTick, 0, 0, binary.LittleEndian.AppendUint64(nil, time))
+	// flush every second so that a crash loses little of the log. Failures stop the recording and are logged once.
+	recorder.Flush()
+}
+
+func (f *ForkChoiceStore) recordBlock(block *cltypes.SignedBeaconBlock, newPayload, fullValidation, dataAvailable bool) {
+	recorder := f.recorder.Load()
+	if recorder == nil {
+		return
+	}
+	var flags byte
+	if newPayload {
+		flags |= recordFlagNewPayload
+	}
+	if fullValidation {
+		flags |= recordFlagFullValidation
+	}
+	if !dataAvailable {
+		flags |= recordFlagDataUnavailable
+	}
+	recorder.writeSSZ(RecordBlock, block.Version(), flags, block)
+}
+
+func (f *ForkChoiceStore) recordAttestation(attestation *solid.Attestation, fromBlock, insert bool) {
+	recorder := f.recorder.Load()
+	if recorder == nil {
+		return
+	}
+	var flags byte
+	if fromBlock {
+		flags |= recordFlagFromBlock
+	}
+	if insert {
+		flags |= recordFlagInsert
+	}
+	recorder.writeSSZ(RecordAttestation, f.versionAtSlot(attestation.AttestantionData().Slot()), flags, attestation)
+}
+
+func (f *ForkChoiceStore) recordAttesterSlashing(attesterSlashing *cltypes.AttesterSlashing, test bool) {
+	recorder := f.recorder.Load()
+	if recorder == nil {
+		return
+	}
+	var flags byte
+	if test {
+		flags |= recordFlagTest
+	}
+	recorder.writeSSZ(RecordAttesterSlashing, f.versionAtSlot(attesterSlashing.Attestation_1.Data.Slot()), flags, attesterSlashing)
+}
+
+func (f *ForkChoiceStore) recordAttestingIndicies(attestation *solid.Attestation, attestingIndicies []uint64) {
+	recorder := f.recorder.Load()
+	if recorder == nil {
+		return
+	}
+	// count (uvarint) | indicies (8 bytes each) | attestation
+	payload := binary.AppendUvarint(nil, uint64(len(attestingIndicies)))
+	for _, idx := range attestingIndicies {
+		payload = binary.LittleEndian.AppendUint64(payload, idx)
+	}
+	payload, err := attestation.EncodeSSZ(payload)
+	if err != nil {
+		log.Warn("[Forkchoice] could not record input", "kind", RecordAttestingIndicies, "err", err)
+		return
+	}
+	recorder.write(RecordAttestingIndicies, f.versionAtSlot(attestation.AttestantionData().Slot()), 0, payload)
+}
+
+func (f *ForkChoiceStore) recordSynced(synced bool) {
+	recorder := f.recorder.Load()
+	if recorder == nil {
+		return
+	}
+	var flags byte
+	if synced {
+		flags |= recordFlagSynced
+	}
+	recorder.write(RecordSynced, 0, flags, nil)
+}
@@ -0,0 +1,99 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package forkchoice_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/cl/beacon/beacon_router_configuration"
+	"github.com/erigontech/erigon/cl/beacon/beaconevents"
+	"github.com/erigontech/erigon/cl/beacon/synced_data"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/cltypes/solid"
+	"github.com/erigontech/erigon/cl/monitor"
+	"github.com/erigontech/erigon/cl/phase1/core/state"
+	"github.com/erigontech/erigon/cl/phase1/forkchoice"
+	"github.com/erigontech/erigon/cl/phase1/forkchoice/fork_graph"
+	"github.com/erigontech/erigon/cl/pool"
+	"github.com/erigontech/erigon/cl/utils"
+)
+
+func TestForkChoiceRecordReplay(t *testing.T) {
+	ctx := context.Background()
+	cfg := &clparams.MainnetBeaconConfig
+	block0x3a, block0xc2, block0xd4 := cltypes.NewSignedBeaconBlock(cfg), cltypes.NewSignedBeaconBlock(cfg), cltypes.NewSignedBeaconBlock(cfg)
+	require.NoError(t, utils.DecodeSSZSnappy(block0x3a, block3aEncoded, int(clparams.AltairVersion)))
+	require.NoError(t, utils.DecodeSSZSnappy(block0xc2, blockc2Encoded, int(clparams.AltairVersion)))
+	require.NoError(t, utils.DecodeSSZSnappy(block0xd4, blockd4Encoded, int(clparams.AltairVersion)))
+	testAttestation := &solid.Attestation{}
+	require.NoError(t, utils.DecodeSSZSnappy(testAttestation, attestationEncoded, int(clparams.AltairVersion)))
+	anchorState := state.New(cfg)
+	require.NoError(t, utils.DecodeSSZSnappy(anchorState, anchorStateEncoded, int(clparams.AltairVersion)))
+	emitters := beaconevents.NewEventEmitter()
+	store, err := forkchoice.NewForkChoiceStore(nil, anchorState, nil, pool.NewOperationsPool(cfg),
+		fork_graph.NewForkGraphDisk(anchorState, afero.NewMemMapFs(), beacon_router_configuration.RouterConfiguration{}, emitters),
+		emitters, synced_data.NewSyncedDataManager(true, cfg), nil, monitor.NewValidatorMonitor(false, nil, nil, nil))
+	require.NoError(t, err)
+
+	var log bytes.Buffer
+	recorder, err := forkchoice.NewRecorder(&log, anchorState)
+	require.NoError(t, err)
+	store.SetRecorder(recorder)
+
+	// same steps as TestForkChoiceBasic, with repeated ticks which are not recorded.
+	store.SetSynced(true)
+	store.OnTick(0)
+	store.OnTick(12)
+	store.OnTick(12)
+	require.NoError(t, store.OnBlock(ctx, block0x3a, false, true, false))
+	store.OnTick(36)
+	require.NoError(t, store.OnBlock(ctx, block0xc2, false, true, false))
+	require.NoError(t, store.OnBlock(ctx, block0xd4, false, true, false))
+	require.NoError(t, store.OnAttestation(testAttestation, false, false))
+	require.NoError(t, recorder.Close())
+	expectedRoot, expectedSlot, err := store.GetHead()
+	require.NoError(t, err)
+
+	var steps []forkchoice.ReplayStep
+	require.NoError(t, forkchoice.Replay(ctx, cfg, &log, func(step forkchoice.ReplayStep) error {
+		require.NoError(t, step.Err, "step %d (%s)", step.Index, step.Kind)
+		steps = append(steps, step)
+		return nil
+	}))
+	kinds := make([]forkchoice.RecordKind, len(steps))
+	for i, step := range steps {
+		kinds[i] = step.Kind
+	}
+	require.Equal(t, []forkchoice.RecordKind{
+		forkchoice.RecordSynced, forkchoice.RecordTick, forkchoice.RecordTick, forkchoice.RecordBlock,
+		forkchoice.RecordTick, forkchoice.RecordBlock, forkchoice.RecordBlock,
+		forkchoice.RecordAttestation,
+	}, kinds)
+	require.Equal(t, libcommon.HexToHash("0xc9bd7bcb6dfa49dc4e5a67ca75e89062c36b5c300bc25a1b31db4e1a89306071"), steps[3].HeadRoot)
+	require.True(t, steps[5].HeadChanged)
+	last := steps[len(steps)-1]
+	require.Equal(t, expectedRoot, last.HeadRoot)
+	require.Equal(t, expectedSlot, last.HeadSlot)
+	require.NotEmpty(t, last.WeightChanges)
+}
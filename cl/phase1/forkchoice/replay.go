@@ -0,0 +1,172 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package forkchoice
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/afero"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/cl/beacon/beacon_router_configuration"
+	"github.com/erigontech/erigon/cl/beacon/beaconevents"
+	"github.com/erigontech/erigon/cl/beacon/synced_data"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/cltypes/solid"
+	"github.com/erigontech/erigon/cl/monitor"
+	"github.com/erigontech/erigon/cl/phase1/core/state"
+	"github.com/erigontech/erigon/cl/phase1/forkchoice/fork_graph"
+	"github.com/erigontech/erigon/cl/pool"
+)
+
+// WeightChange is the change of the weight of a block between two replay steps.
+type WeightChange struct {
+	BlockRoot libcommon.Hash
+	Slot      uint64
+	Old       uint64
+	New       uint64
+}
+
+// ReplayStep is the outcome of feeding one recorded input to the store.
+type ReplayStep struct {
+	Index int
+	Kind  RecordKind
+	// Err is the error returned by the store for the input, as it would have been returned to the caller.
+	Err           error
+	HeadRoot      libcommon.Hash
+	HeadSlot      uint64
+	HeadChanged   bool
+	WeightChanges []WeightChange
+}
+
+// Replay feeds a log written by a Recorder to a new store built from the recorded anchor state, and calls onStep
+// after every input. The execution layer is not part of the replay: payloads are not sent to it, which makes the
+// blocks optimistic, and blobs are considered available unless the recorded check failed.
+func Replay(ctx context.Context, beaconCfg *clparams.BeaconChainConfig, r io.Reader, onStep func(step ReplayStep) error) error {
+	reader := NewRecordReader(r)
+	record, err := reader.Next()
+	if err != nil {
+		return fmt.Errorf("could not read the anchor record: %w", err)
+	}
+	if record.Kind != RecordAnchor {
+		return fmt.Errorf("%w: log starts with %s instead of anchor", ErrInvalidRecord, record.Kind)
+	}
+	anchorState := state.New(beaconCfg)
+	if err := anchorState.DecodeSSZ(record.Payload, int(record.Version)); err != nil {
+		return fmt.Errorf("could not decode the anchor state: %w", err)
+	}
+	emitters := beaconevents.NewEventEmitter()
+	store, err := NewForkChoiceStore(nil, anchorState, nil, pool.NewOperationsPool(beaconCfg),
+		fork_graph.NewForkGraphDisk(anchorState, afero.NewMemMapFs(), beacon_router_configuration.RouterConfiguration{}, emitters),
+		emitters, synced_data.NewSyncedDataManager(false, beaconCfg), nil, monitor.NewValidatorMonitor(false, nil, nil, nil))
+	if err != nil {
+		return err
+	}
+
+	weights := map[libcommon.Hash]uint64{}
+	var headRoot libcommon.Hash
+	for index := 1; ; index++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		record, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("record %d: %w", index, err)
+		}
+		step := ReplayStep{Index: index, Kind: record.Kind}
+		if step.Err, err = store.applyRecord(ctx, record); err != nil {
+			return fmt.Errorf("record %d (%s): %w", index, record.Kind, err)
+		}
+		if step.HeadRoot, step.HeadSlot, err = store.GetHead(); err != nil {
+			return fmt.Errorf("record %d (%s): could not compute head: %w", index, record.Kind, err)
+		}
+		step.HeadChanged = step.HeadRoot != headRoot
+		headRoot = step.HeadRoot
+		for _, node := range store.ForkNodes() {
+			if old := weights[node.BlockRoot]; old != node.Weight {
+				step.WeightChanges = append(step.WeightChanges, WeightChange{BlockRoot: node.BlockRoot, Slot: node.Slot, Old: old, New: node.Weight})
+				weights[node.BlockRoot] = node.Weight
+			}
+		}
+		if err := onStep(step); err != nil {
+			return err
+		}
+	}
+}
+
+// applyRecord feeds a recorded input to the store. The first error is the one of the store, the second one means
+// the record could not be decoded.
+func (f *ForkChoiceStore) applyRecord(ctx context.Context, record *Record) (error, error) {
+	switch record.Kind {
+	case RecordTick:
+		if len(record.Payload) != 8 {
+			return nil, fmt.Errorf("%w: tick of %d bytes", ErrInvalidRecord, len(record.Payload))
+		}
+		f.OnTick(binary.LittleEndian.Uint64(record.Payload))
+		return nil, nil
+	case RecordBlock:
+		block := cltypes.NewSignedBeaconBlock(f.beaconCfg)
+		if err := block.DecodeSSZ(record.Payload, int(record.Version)); err != nil {
+			return nil, err
+		}
+		if record.Flags&recordFlagDataUnavailable != 0 {
+			return ErrEIP4844DataNotAvailable, nil
+		}
+		return f.OnBlock(ctx, block, false, record.Flags&recordFlagFullValidation != 0, false), nil
+	case RecordAttestation:
+		attestation := &solid.Attestation{}
+		if err := attestation.DecodeSSZ(record.Payload, int(record.Version)); err != nil {
+			return nil, err
+		}
+		return f.OnAttestation(attestation, record.Flags&recordFlagFromBlock != 0, record.Flags&recordFlagInsert != 0), nil
+	case RecordAttesterSlashing:
+		attesterSlashing := cltypes.NewAttesterSlashing()
+		if err := attesterSlashing.DecodeSSZ(record.Payload, int(record.Version)); err != nil {
+			return nil, err
+		}
+		return f.OnAttesterSlashing(attesterSlashing, record.Flags&recordFlagTest != 0), nil
+	case RecordAttestingIndicies:
+		count, n := binary.Uvarint(record.Payload)
+		if n <= 0 || uint64(len(record.Payload)-n) < count*8 {
+			return nil, fmt.Errorf("%w: bad attesting indicies", ErrInvalidRecord)
+		}
+		buf := record.Payload[n:]
+		indicies := make([]uint64, count)
+		for i := range indicies {
+			indicies[i] = binary.LittleEndian.Uint64(buf[i*8:])
+		}
+		attestation := &solid.Attestation{}
+		if err := attestation.DecodeSSZ(buf[count*8:], int(record.Version)); err != nil {
+			return nil, err
+		}
+		f.ProcessAttestingIndicies(attestation, indicies)
+		return nil, nil
+	case RecordSynced:
+		f.SetSynced(record.Flags&recordFlagSynced != 0)
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("%w: unexpected %s record", ErrInvalidRecord, record.Kind)
+	}
+}
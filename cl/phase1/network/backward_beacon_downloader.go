@@ -119,6 +119,47 @@ func (b *BackwardBeaconDownloader) Peers() (uint64, error) {
 	return b.rpc.Peers()
 }
 
+// ImportBlock processes a block which was not downloaded from peers, such as a block of an era file, the same way as
+// RequestMore processes the downloaded ones. Blocks must come in decreasing slot order. It returns true once the
+// download is finished.
+func (b *BackwardBeaconDownloader) ImportBlock(block *cltypes.SignedBeaconBlock) bool {
+	return b.processBlock(block)
+}
+
+// processBlock hands the block to the callback if it is the expected one, and moves the download to its parent.
+// It returns true once the download is finished.
+func (b *BackwardBeaconDownloader) processBlock(segment *cltypes.SignedBeaconBlock) bool {
+	if b.finished.Load() {
+		return true
+	}
+	// is this new block root equal to the expected root?
+	blockRoot, err := segment.Block.HashSSZ()
+	if err != nil {
+		log.Debug("Could not compute block root while processing packet", "err", err)
+		return false
+	}
+	// No? Reject.
+	if blockRoot != b.expectedRoot {
+		log.Debug("Gotten unexpected root", "got", libcommon.Hash(blockRoot), "expected", b.expectedRoot)
+		return false
+	}
+	// Yes? then go for the callback.
+	finished, err := b.onNewBlock(segment)
+	b.finished.Store(finished)
+	if err != nil {
+		log.Warn("Found error while processing packet", "err", err)
+		return false
+	}
+	// set expected root to the segment parent root
+	b.expectedRoot = segment.Block.ParentRoot
+	if segment.Block.Slot == 0 {
+		b.finished.Store(true)
+		return true
+	}
+	b.slotToDownload.Store(segment.Block.Slot - 1) // update slot (might be inexact but whatever)
+	return false
+}
+
 // RequestMore downloads a range of blocks in a backward manner.
 // The function sends a request for a range of blocks starting from a given slot and ending count blocks before it.
 // It then processes the response by iterating over the blocks in reverse order and calling a provided callback function onNewBlock on each block.
@@ -150,7 +191,7 @@ Loop:
 					return
 				}
 				if len(responses) == 0 {
-					b.rpc.BanPeer(peerId)
+					b.rpc.PenalizePeer(peerId)
 					return
 				}
 				atomicResp.Store(responses)
@@ -167,35 +208,9 @@ Loop:
 	responses := atomicResp.Load().([]*cltypes.SignedBeaconBlock)
 	// Import new blocks, order is forward so reverse the whole packet
 	for i := len(responses) - 1; i >= 0; i-- {
-		if b.finished.Load() {
-			return nil
-		}
-		segment := responses[i]
-		// is this new block root equal to the expected root?
-		blockRoot, err := segment.Block.HashSSZ()
-		if err != nil {
-			log.Debug("Could not compute block root while processing packet", "err", err)
-			continue
-		}
-		// No? Reject.
-		if blockRoot != b.expectedRoot {
-			log.Debug("Gotten unexpected root", "got", libcommon.Hash(blockRoot), "expected", b.expectedRoot)
-			continue
-		}
-		// Yes? then go for the callback.
-		finished, err := b.onNewBlock(segment)
-		b.finished.Store(finished)
-		if err != nil {
-			log.Warn("Found error while processing packet", "err", err)
-			continue
-		}
-		// set expected root to the segment parent root
-		b.expectedRoot = segment.Block.ParentRoot
-		if segment.Block.Slot == 0 {
-			b.finished.Store(true)
+		if b.processBlock(responses[i]) {
 			return nil
 		}
-		b.slotToDownload.Store(segment.Block.Slot - 1) // update slot (might be inexact but whatever)
 	}
 	if !b.neverSkip {
 		return nil
@@ -97,7 +97,7 @@ Loop:
 					return
 				}
 				if len(responses) == 0 {
-					f.rpc.BanPeer(peerId)
+					f.rpc.PenalizePeer(peerId)
 					return
 				}
 				if len(atomicResp.Load().(peerAndBlocks).blocks) > 0 {
@@ -123,9 +123,12 @@ Loop:
 	blocks := atomicResp.Load().(peerAndBlocks).blocks
 	pid := atomicResp.Load().(peerAndBlocks).peerId
 	if highestSlotProcessed, err = f.process(f.highestSlotProcessed, blocks); err != nil {
-		f.rpc.BanPeer(pid)
+		f.rpc.PenalizePeer(pid)
 		return
 	}
+	if highestSlotProcessed > f.highestSlotProcessed {
+		f.rpc.RewardPeer(pid)
+	}
 	f.highestSlotProcessed = highestSlotProcessed
 }
 
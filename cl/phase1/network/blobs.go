@@ -83,47 +83,62 @@ type PeerAndSidecars struct {
 
 // RequestBlobsFrantically requests blobs from the network frantically.
 func RequestBlobsFrantically(ctx context.Context, r *rpc.BeaconRpcP2P, req *solid.ListSSZ[*cltypes.BlobIdentifier]) (*PeerAndSidecars, error) {
+	pid, responses, err := requestFrantically(ctx, "RequestBlobsFrantically", func(ctx context.Context) ([]*cltypes.BlobSidecar, string, error) {
+		// this is so we do not get stuck on a side-fork
+		return r.SendBlobsSidecarByIdentifierReq(ctx, req)
+	})
+	if err != nil || responses == nil {
+		return nil, err
+	}
+	return &PeerAndSidecars{Peer: pid, Responses: responses}, nil
+}
+
+// requestFrantically sends the request every 100ms until a peer answers with a non-empty response. It gives up
+// after requestBlobBatchExpiration and returns no responses.
+func requestFrantically[T any](ctx context.Context, name string, send func(context.Context) ([]T, string, error)) (string, []T, error) {
+	type peerAndResponses struct {
+		peer      string
+		responses []T
+	}
 	var atomicResp atomic.Value
 
-	atomicResp.Store(&PeerAndSidecars{})
+	atomicResp.Store(&peerAndResponses{})
 	reqInterval := time.NewTicker(100 * time.Millisecond)
 	defer reqInterval.Stop()
+	timeout := time.NewTimer(requestBlobBatchExpiration)
+	defer timeout.Stop()
 Loop:
 	for {
 		select {
 		case <-reqInterval.C:
 			go func() {
-				if len(atomicResp.Load().(*PeerAndSidecars).Responses) > 0 {
-					return
-				}
-				// this is so we do not get stuck on a side-fork
-				responses, pid, err := r.SendBlobsSidecarByIdentifierReq(ctx, req)
-
-				if err != nil {
+				if len(atomicResp.Load().(*peerAndResponses).responses) > 0 {
 					return
 				}
-				if responses == nil {
+				responses, pid, err := send(ctx)
+				if err != nil || len(responses) == 0 {
 					return
 				}
-				if len(atomicResp.Load().(*PeerAndSidecars).Responses) > 0 {
+				if len(atomicResp.Load().(*peerAndResponses).responses) > 0 {
 					return
 				}
-				atomicResp.Store(&PeerAndSidecars{
-					Peer:      pid,
-					Responses: responses,
+				atomicResp.Store(&peerAndResponses{
+					peer:      pid,
+					responses: responses,
 				})
 			}()
 		case <-ctx.Done():
-			return nil, ctx.Err()
-		case <-time.After(requestBlobBatchExpiration):
-			log.Debug("RequestBlobsFrantically: timeout")
-			return nil, nil
+			return "", nil, ctx.Err()
+		case <-timeout.C:
+			log.Debug(name + ": timeout")
+			return "", nil, nil
 		default:
-			if len(atomicResp.Load().(*PeerAndSidecars).Responses) > 0 {
+			if len(atomicResp.Load().(*peerAndResponses).responses) > 0 {
 				break Loop
 			}
 			time.Sleep(10 * time.Millisecond)
 		}
 	}
-	return atomicResp.Load().(*PeerAndSidecars), nil
+	resp := atomicResp.Load().(*peerAndResponses)
+	return resp.peer, resp.responses, nil
 }
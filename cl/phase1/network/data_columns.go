@@ -0,0 +1,60 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package network
+
+import (
+	"golang.org/x/net/context"
+
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/cltypes/solid"
+	"github.com/erigontech/erigon/cl/das"
+	"github.com/erigontech/erigon/cl/rpc"
+)
+
+// DataColumnIdentifiersFromBlocks returns the identifiers of the columns to sample for each block with blobs.
+func DataColumnIdentifiersFromBlocks(blocks []*cltypes.SignedBeaconBlock, custody *das.Custody) (*solid.ListSSZ[*cltypes.DataColumnIdentifier], error) {
+	ids := solid.NewStaticListSSZ[*cltypes.DataColumnIdentifier](0, 40)
+	for _, block := range blocks {
+		if block.Block.Body.BlobKzgCommitments.Len() == 0 {
+			continue
+		}
+		blockRoot, err := block.Block.HashSSZ()
+		if err != nil {
+			return nil, err
+		}
+		for _, column := range custody.SampledColumns(blockRoot) {
+			ids.Append(cltypes.NewDataColumnIdentifier(blockRoot, column))
+		}
+	}
+	return ids, nil
+}
+
+type PeerAndDataColumnSidecars struct {
+	Peer      string
+	Responses []*cltypes.DataColumnSidecar
+}
+
+// RequestDataColumnsFrantically requests data columns from the network frantically.
+func RequestDataColumnsFrantically(ctx context.Context, r *rpc.BeaconRpcP2P, req *solid.ListSSZ[*cltypes.DataColumnIdentifier]) (*PeerAndDataColumnSidecars, error) {
+	pid, responses, err := requestFrantically(ctx, "RequestDataColumnsFrantically", func(ctx context.Context) ([]*cltypes.DataColumnSidecar, string, error) {
+		return r.SendDataColumnSidecarsByRootReq(ctx, req)
+	})
+	if err != nil || responses == nil {
+		return nil, err
+	}
+	return &PeerAndDataColumnSidecars{Peer: pid, Responses: responses}, nil
+}
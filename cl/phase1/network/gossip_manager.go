@@ -57,6 +57,7 @@ type GossipManager struct {
 	// Services for processing messages from the network
 	blockService                 services.BlockService
 	blobService                  services.BlobSidecarsService
+	dataColumnSidecarService     services.DataColumnSidecarService
 	syncCommitteeMessagesService services.SyncCommitteeMessagesService
 	syncContributionService      services.SyncContributionService
 	aggregateAndProofService     services.AggregateAndProofService
@@ -76,6 +77,7 @@ func NewGossipReceiver(
 	comitteeSub *committee_subscription.CommitteeSubscribeMgmt,
 	blockService services.BlockService,
 	blobService services.BlobSidecarsService,
+	dataColumnSidecarService services.DataColumnSidecarService,
 	syncCommitteeMessagesService services.SyncCommitteeMessagesService,
 	syncContributionService services.SyncContributionService,
 	aggregateAndProofService services.AggregateAndProofService,
@@ -94,6 +96,7 @@ func NewGossipReceiver(
 		committeeSub:                 comitteeSub,
 		blockService:                 blockService,
 		blobService:                  blobService,
+		dataColumnSidecarService:     dataColumnSidecarService,
 		syncCommitteeMessagesService: syncCommitteeMessagesService,
 		syncContributionService:      syncContributionService,
 		aggregateAndProofService:     aggregateAndProofService,
@@ -137,16 +140,17 @@ func (g *GossipManager) onRecv(ctx context.Context, data *sentinel.GossipData, l
 		Data:     common.CopyBytes(data.Data),
 	}
 
-	if err := g.routeAndProcess(ctx, data); err != nil {
-		return err
-	}
+	err = g.routeAndProcess(ctx, data)
 	if errors.Is(err, services.ErrIgnore) {
-		return nil
+		return err
 	}
 	if err != nil {
-		g.sentinel.BanPeer(ctx, data.Peer)
+		g.sentinel.PenalizePeer(ctx, data.Peer)
 		return err
 	}
+	if data.Name == gossip.TopicNameBeaconBlock {
+		g.sentinel.RewardPeer(ctx, data.Peer)
+	}
 	if _, err := g.sentinel.PublishGossip(ctx, data); err != nil {
 		log.Warn("failed publish gossip", "err", err)
 	}
@@ -242,6 +246,13 @@ func (g *GossipManager) routeAndProcess(ctx context.Context, data *sentinel.Goss
 			defer log.Debug("Received blob sidecar via gossip", "index", *data.SubnetId, "size", datasize.ByteSize(len(blobSideCar.Blob)))
 			// The background checks above are enough for now.
 			return g.blobService.ProcessMessage(ctx, data.SubnetId, blobSideCar)
+		case gossip.IsTopicDataColumnSidecar(data.Name):
+			sidecar := cltypes.NewDataColumnSidecar()
+			if err := sidecar.DecodeSSZ(common.CopyBytes(data.Data), int(version)); err != nil {
+				return err
+			}
+			defer log.Debug("Received data column sidecar via gossip", "index", sidecar.Index, "blobs", sidecar.Column.Len())
+			return g.dataColumnSidecarService.ProcessMessage(ctx, data.SubnetId, sidecar)
 		case gossip.IsTopicSyncCommittee(data.Name):
 			msg := &cltypes.SyncCommitteeMessage{}
 			if err := msg.DecodeSSZ(common.CopyBytes(data.Data), int(version)); err != nil {
@@ -309,7 +320,7 @@ func (g *GossipManager) Start(ctx context.Context) {
 
 	sendOrDrop := func(ch chan<- *sentinel.GossipData, data *sentinel.GossipData) {
 		// Skip processing the received data if the node is not ready to process operations.
-		if !g.isReadyToProcessOperations() && data.Name != gossip.TopicNameBeaconBlock && !gossip.IsTopicBlobSidecar(data.Name) {
+		if !g.isReadyToProcessOperations() && data.Name != gossip.TopicNameBeaconBlock && !gossip.IsTopicBlobSidecar(data.Name) && !gossip.IsTopicDataColumnSidecar(data.Name) {
 			return
 		}
 		select {
@@ -346,7 +357,7 @@ Reconnect:
 			switch {
 			case data.Name == gossip.TopicNameBeaconBlock:
 				sendOrDrop(blocksCh, data)
-			case gossip.IsTopicBlobSidecar(data.Name):
+			case gossip.IsTopicBlobSidecar(data.Name) || gossip.IsTopicDataColumnSidecar(data.Name):
 				sendOrDrop(blobsCh, data)
 			case gossip.IsTopicSyncCommittee(data.Name) || data.Name == gossip.TopicNameSyncCommitteeContributionAndProof:
 				sendOrDrop(syncCommitteesCh, data)
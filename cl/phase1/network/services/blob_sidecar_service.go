@@ -24,7 +24,7 @@ import (
 	"time"
 
 	"github.com/Giulio2002/bls"
-	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	goethkzg "github.com/crate-crypto/go-eth-kzg"
 
 	"github.com/erigontech/erigon-lib/crypto/kzg"
 	"github.com/erigontech/erigon-lib/log/v3"
@@ -142,7 +142,7 @@ func (b *blobSidecarService) verifyAndStoreBlobSidecar(headState *state.CachingB
 		return ErrCommitmentsInclusionProofFailed
 	}
 
-	if err := kzgCtx.VerifyBlobKZGProof(gokzg4844.Blob(msg.Blob), gokzg4844.KZGCommitment(msg.KzgCommitment), gokzg4844.KZGProof(msg.KzgProof)); err != nil {
+	if err := kzgCtx.VerifyBlobKZGProof((*goethkzg.Blob)(&msg.Blob), goethkzg.KZGCommitment(msg.KzgCommitment), goethkzg.KZGProof(msg.KzgProof)); err != nil {
 		return fmt.Errorf("blob KZG proof verification failed: %v", err)
 	}
 	if !b.test {
@@ -155,13 +155,18 @@ func (b *blobSidecarService) verifyAndStoreBlobSidecar(headState *state.CachingB
 }
 
 func (b *blobSidecarService) verifySidecarsSignature(headState *state.CachingBeaconState, header *cltypes.SignedBeaconBlockHeader) error {
-	parentHeader, ok := b.forkchoiceStore.GetHeader(header.Header.ParentRoot)
+	return verifySidecarHeaderSignature(b.forkchoiceStore, b.beaconCfg, headState, header)
+}
+
+// verifySidecarHeaderSignature checks the proposer signature of the block header carried by blob and data column sidecars.
+func verifySidecarHeaderSignature(forkchoiceStore forkchoice.ForkChoiceStorageReader, beaconCfg *clparams.BeaconChainConfig, headState *state.CachingBeaconState, header *cltypes.SignedBeaconBlockHeader) error {
+	parentHeader, ok := forkchoiceStore.GetHeader(header.Header.ParentRoot)
 	if !ok {
 		return errors.New("parent header not found")
 	}
-	currentVersion := b.beaconCfg.GetCurrentStateVersion(parentHeader.Slot / b.beaconCfg.SlotsPerEpoch)
-	forkVersion := b.beaconCfg.GetForkVersionByVersion(currentVersion)
-	domain, err := fork.ComputeDomain(b.beaconCfg.DomainBeaconProposer[:], utils.Uint32ToBytes4(forkVersion), headState.GenesisValidatorsRoot())
+	currentVersion := beaconCfg.GetCurrentStateVersion(parentHeader.Slot / beaconCfg.SlotsPerEpoch)
+	forkVersion := beaconCfg.GetForkVersionByVersion(currentVersion)
+	domain, err := fork.ComputeDomain(beaconCfg.DomainBeaconProposer[:], utils.Uint32ToBytes4(forkVersion), headState.GenesisValidatorsRoot())
 	if err != nil {
 		return err
 	}
@@ -42,4 +42,5 @@ var (
 	ErrCommitmentsInclusionProofFailed = errors.New("commitments inclusion proof failed")
 	ErrInvalidSidecarSlot              = errors.New("invalid sidecar slot")
 	ErrBlobIndexOutOfRange             = errors.New("blob index out of range")
+	ErrDataColumnSubnetMismatch        = errors.New("data column sidecar subnet mismatch")
 )
@@ -0,0 +1,192 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/cl/beacon/synced_data"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/das"
+	"github.com/erigontech/erigon/cl/persistence/blob_storage"
+	"github.com/erigontech/erigon/cl/phase1/core/state"
+	"github.com/erigontech/erigon/cl/phase1/forkchoice"
+	"github.com/erigontech/erigon/cl/utils/eth_clock"
+)
+
+type dataColumnSidecarService struct {
+	forkchoiceStore   forkchoice.ForkChoiceStorage
+	beaconCfg         *clparams.BeaconChainConfig
+	syncedDataManager *synced_data.SyncedDataManager
+	ethClock          eth_clock.EthereumClock
+	blobStorage       blob_storage.BlobStorage
+
+	dataColumnSidecarsScheduledForLaterExecution sync.Map
+	test                                         bool
+}
+
+type dataColumnSidecarJob struct {
+	sidecar      *cltypes.DataColumnSidecar
+	creationTime time.Time
+}
+
+// NewDataColumnSidecarService creates a new data column sidecar service, verified columns go straight to the blob storage.
+func NewDataColumnSidecarService(
+	ctx context.Context,
+	beaconCfg *clparams.BeaconChainConfig,
+	forkchoiceStore forkchoice.ForkChoiceStorage,
+	syncedDataManager *synced_data.SyncedDataManager,
+	ethClock eth_clock.EthereumClock,
+	blobStorage blob_storage.BlobStorage,
+	test bool,
+) DataColumnSidecarService {
+	d := &dataColumnSidecarService{
+		beaconCfg:         beaconCfg,
+		forkchoiceStore:   forkchoiceStore,
+		syncedDataManager: syncedDataManager,
+		ethClock:          ethClock,
+		blobStorage:       blobStorage,
+		test:              test,
+	}
+	go d.loop(ctx)
+	return d
+}
+
+// ProcessMessage processes a data column sidecar message
+func (d *dataColumnSidecarService) ProcessMessage(ctx context.Context, subnetId *uint64, msg *cltypes.DataColumnSidecar) error {
+	// [REJECT] The sidecar is valid as verified by verify_data_column_sidecar(sidecar).
+	if err := das.VerifyDataColumnSidecar(d.beaconCfg, msg); err != nil {
+		return err
+	}
+	if d.test {
+		return d.verifyAndStoreDataColumnSidecar(ctx, nil, msg)
+	}
+
+	headState := d.syncedDataManager.HeadState()
+	if headState == nil {
+		d.scheduleDataColumnSidecarForLaterExecution(msg)
+		return ErrIgnore
+	}
+
+	// [REJECT] The sidecar is for the correct subnet -- i.e. compute_subnet_for_data_column_sidecar(sidecar.index) == subnet_id.
+	if subnetId == nil || das.ComputeSubnetForDataColumnSidecar(d.beaconCfg, msg.Index) != *subnetId {
+		return ErrDataColumnSubnetMismatch
+	}
+	currentSlot := d.ethClock.GetCurrentSlot()
+	sidecarSlot := msg.SignedBlockHeader.Header.Slot
+	// [IGNORE] The sidecar is not from a future slot (with a MAXIMUM_GOSSIP_CLOCK_DISPARITY allowance).
+	if currentSlot < sidecarSlot && !d.ethClock.IsSlotCurrentSlotWithMaximumClockDisparity(sidecarSlot) {
+		return ErrIgnore
+	}
+	// [IGNORE] The sidecar is from a slot greater than the latest finalized slot.
+	if d.forkchoiceStore.FinalizedSlot() >= sidecarSlot {
+		return ErrIgnore
+	}
+
+	blockRoot, err := msg.BlockRoot()
+	if err != nil {
+		return err
+	}
+	// [IGNORE] The sidecar is the first sidecar for the tuple (block_header.slot, block_header.proposer_index, sidecar.index).
+	if has, err := d.blobStorage.HasDataColumnSidecars(sidecarSlot, blockRoot, []uint64{msg.Index}); err != nil || has {
+		return ErrIgnore
+	}
+
+	parentHeader, has := d.forkchoiceStore.GetHeader(msg.SignedBlockHeader.Header.ParentRoot)
+	if !has {
+		d.scheduleDataColumnSidecarForLaterExecution(msg)
+		return ErrIgnore
+	}
+	// [REJECT] The sidecar is from a higher slot than the sidecar's block's parent.
+	if sidecarSlot <= parentHeader.Slot {
+		return ErrInvalidSidecarSlot
+	}
+
+	return d.verifyAndStoreDataColumnSidecar(ctx, headState, msg)
+}
+
+func (d *dataColumnSidecarService) verifyAndStoreDataColumnSidecar(ctx context.Context, headState *state.CachingBeaconState, msg *cltypes.DataColumnSidecar) error {
+	if !d.test && !cltypes.VerifyDataColumnSidecarInclusionProof(msg) {
+		return ErrCommitmentsInclusionProofFailed
+	}
+	if err := das.VerifyDataColumnSidecarKZGProofs(msg); err != nil {
+		return fmt.Errorf("data column KZG proofs verification failed: %v", err)
+	}
+	if !d.test {
+		if err := verifySidecarHeaderSignature(d.forkchoiceStore, d.beaconCfg, headState, msg.SignedBlockHeader); err != nil {
+			return err
+		}
+	}
+	blockRoot, err := msg.BlockRoot()
+	if err != nil {
+		return err
+	}
+	return d.blobStorage.WriteDataColumnSidecars(ctx, blockRoot, []*cltypes.DataColumnSidecar{msg})
+}
+
+func (d *dataColumnSidecarService) scheduleDataColumnSidecarForLaterExecution(sidecar *cltypes.DataColumnSidecar) {
+	sidecarHash, err := sidecar.HashSSZ()
+	if err != nil {
+		return
+	}
+	d.dataColumnSidecarsScheduledForLaterExecution.Store(sidecarHash, &dataColumnSidecarJob{
+		sidecar:      sidecar,
+		creationTime: time.Now(),
+	})
+}
+
+// loop retries the sidecars whose parent block was not known yet.
+func (d *dataColumnSidecarService) loop(ctx context.Context) {
+	ticker := time.NewTicker(blobJobsIntervalTick)
+	defer ticker.Stop()
+	if d.test {
+		return
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		headState := d.syncedDataManager.HeadState()
+		if headState == nil {
+			continue
+		}
+		d.dataColumnSidecarsScheduledForLaterExecution.Range(func(key, value any) bool {
+			job := value.(*dataColumnSidecarJob)
+			// check if it has expired
+			if time.Since(job.creationTime) > blobJobExpiry {
+				d.dataColumnSidecarsScheduledForLaterExecution.Delete(key.([32]byte))
+				return true
+			}
+			if _, has := d.forkchoiceStore.GetHeader(job.sidecar.SignedBlockHeader.Header.ParentRoot); !has {
+				return true
+			}
+			if err := d.verifyAndStoreDataColumnSidecar(ctx, headState, job.sidecar); err != nil {
+				log.Trace("data column sidecar verification failed", "err", err,
+					"slot", job.sidecar.SignedBlockHeader.Header.Slot, "index", job.sidecar.Index)
+			}
+			d.dataColumnSidecarsScheduledForLaterExecution.Delete(key.([32]byte))
+			return true
+		})
+	}
+}
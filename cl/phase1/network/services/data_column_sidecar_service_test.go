@@ -0,0 +1,134 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/erigontech/erigon-lib/kv/memdb"
+	"github.com/erigontech/erigon/cl/beacon/synced_data"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/persistence/blob_storage"
+	"github.com/erigontech/erigon/cl/phase1/forkchoice/mock_services"
+	"github.com/erigontech/erigon/cl/utils/eth_clock"
+)
+
+func setupDataColumnSidecarService(t *testing.T, ctrl *gomock.Controller, test bool) (DataColumnSidecarService, *synced_data.SyncedDataManager, *eth_clock.MockEthereumClock, *mock_services.ForkChoiceStorageMock, blob_storage.BlobStorage) {
+	ctx := context.Background()
+	ctx2, cn := context.WithTimeout(ctx, 1)
+	cn()
+	cfg := &clparams.MainnetBeaconConfig
+	syncedDataManager := synced_data.NewSyncedDataManager(true, cfg)
+	ethClock := eth_clock.NewMockEthereumClock(ctrl)
+	forkchoiceMock := mock_services.NewForkChoiceStorageMock(t)
+	blobStorage := blob_storage.NewBlobStore(memdb.NewTestDB(t), afero.NewMemMapFs(), 12, cfg, ethClock)
+	service := NewDataColumnSidecarService(ctx2, cfg, forkchoiceMock, syncedDataManager, ethClock, blobStorage, test)
+	return service, syncedDataManager, ethClock, forkchoiceMock, blobStorage
+}
+
+// zeroDataColumnSidecar returns a column of blobs which are all zeros: every cell, commitment and proof is the identity.
+func zeroDataColumnSidecar(index uint64, blobs int) *cltypes.DataColumnSidecar {
+	sidecar := cltypes.NewDataColumnSidecar()
+	sidecar.Index = index
+	sidecar.SignedBlockHeader.Header.Slot = 10
+	for i := 0; i < blobs; i++ {
+		sidecar.Column.Append(&cltypes.Cell{})
+		sidecar.KzgCommitments.Append(&cltypes.KZGCommitment{0xc0})
+		sidecar.KzgProofs.Append(&cltypes.KZGProof{0xc0})
+	}
+	return sidecar
+}
+
+func TestDataColumnSidecarServiceStore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, _, _, _, blobStorage := setupDataColumnSidecarService(t, ctrl, true)
+	sidecar := zeroDataColumnSidecar(5, 2)
+	require.NoError(t, service.ProcessMessage(context.Background(), nil, sidecar))
+
+	blockRoot, err := sidecar.BlockRoot()
+	require.NoError(t, err)
+	has, err := blobStorage.HasDataColumnSidecars(10, blockRoot, []uint64{5})
+	require.NoError(t, err)
+	require.True(t, has)
+
+	// a cell which does not match its proof
+	sidecar = zeroDataColumnSidecar(6, 2)
+	sidecar.Column.Get(1)[31] = 1
+	require.Error(t, service.ProcessMessage(context.Background(), nil, sidecar))
+}
+
+func TestDataColumnSidecarServiceInvalidSidecar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, _, _, _, _ := setupDataColumnSidecarService(t, ctrl, true)
+	require.Error(t, service.ProcessMessage(context.Background(), nil, zeroDataColumnSidecar(5, 0)))
+	require.Error(t, service.ProcessMessage(context.Background(), nil, zeroDataColumnSidecar(clparams.MainnetBeaconConfig.NumberOfColumns, 1)))
+}
+
+func TestDataColumnSidecarServiceInvalidSubnet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, syncedData, _, _, _ := setupDataColumnSidecarService(t, ctrl, false)
+	stateObj, _, _ := getObjectsForBlobSidecarServiceTests(t)
+	syncedData.OnHeadState(stateObj)
+	sn := uint64(6)
+
+	require.ErrorIs(t, service.ProcessMessage(context.Background(), &sn, zeroDataColumnSidecar(5, 1)), ErrDataColumnSubnetMismatch)
+}
+
+func TestDataColumnSidecarServiceBadTimings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, syncedData, ethClock, _, _ := setupDataColumnSidecarService(t, ctrl, false)
+	stateObj, _, _ := getObjectsForBlobSidecarServiceTests(t)
+	syncedData.OnHeadState(stateObj)
+	sn := uint64(5)
+
+	ethClock.EXPECT().GetCurrentSlot().Return(uint64(0)).AnyTimes()
+	ethClock.EXPECT().IsSlotCurrentSlotWithMaximumClockDisparity(gomock.Any()).Return(false).AnyTimes()
+
+	require.ErrorIs(t, service.ProcessMessage(context.Background(), &sn, zeroDataColumnSidecar(5, 1)), ErrIgnore)
+}
+
+func TestDataColumnSidecarServiceInvalidSidecarSlot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, syncedData, ethClock, fcu, _ := setupDataColumnSidecarService(t, ctrl, false)
+	stateObj, _, _ := getObjectsForBlobSidecarServiceTests(t)
+	syncedData.OnHeadState(stateObj)
+	sn := uint64(5)
+	sidecar := zeroDataColumnSidecar(5, 1)
+
+	// no parent yet
+	ethClock.EXPECT().GetCurrentSlot().Return(uint64(10)).AnyTimes()
+	require.ErrorIs(t, service.ProcessMessage(context.Background(), &sn, sidecar), ErrIgnore)
+
+	fcu.Headers[sidecar.SignedBlockHeader.Header.ParentRoot] = sidecar.SignedBlockHeader.Header.Copy()
+	require.ErrorIs(t, service.ProcessMessage(context.Background(), &sn, sidecar), ErrInvalidSidecarSlot)
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/erigontech/erigon/cl/phase1/network/services (interfaces: DataColumnSidecarService)
+//
+// Generated by this command:
+//
+//	mockgen -typed=true -destination=./mock_services/data_column_sidecar_service_mock.go -package=mock_services . DataColumnSidecarService
+//
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	cltypes "github.com/erigontech/erigon/cl/cltypes"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDataColumnSidecarService is a mock of DataColumnSidecarService interface.
+type MockDataColumnSidecarService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDataColumnSidecarServiceMockRecorder
+}
+
+// MockDataColumnSidecarServiceMockRecorder is the mock recorder for MockDataColumnSidecarService.
+type MockDataColumnSidecarServiceMockRecorder struct {
+	mock *MockDataColumnSidecarService
+}
+
+// NewMockDataColumnSidecarService creates a new mock instance.
+func NewMockDataColumnSidecarService(ctrl *gomock.Controller) *MockDataColumnSidecarService {
+	mock := &MockDataColumnSidecarService{ctrl: ctrl}
+	mock.recorder = &MockDataColumnSidecarServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDataColumnSidecarService) EXPECT() *MockDataColumnSidecarServiceMockRecorder {
+	return m.recorder
+}
+
+// ProcessMessage mocks base method.
+func (m *MockDataColumnSidecarService) ProcessMessage(arg0 context.Context, arg1 *uint64, arg2 *cltypes.DataColumnSidecar) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessMessage", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ProcessMessage indicates an expected call of ProcessMessage.
+func (mr *MockDataColumnSidecarServiceMockRecorder) ProcessMessage(arg0, arg1, arg2 any) *MockDataColumnSidecarServiceProcessMessageCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessMessage", reflect.TypeOf((*MockDataColumnSidecarService)(nil).ProcessMessage), arg0, arg1, arg2)
+	return &MockDataColumnSidecarServiceProcessMessageCall{Call: call}
+}
+
+// MockDataColumnSidecarServiceProcessMessageCall wrap *gomock.Call
+type MockDataColumnSidecarServiceProcessMessageCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockDataColumnSidecarServiceProcessMessageCall) Return(arg0 error) *MockDataColumnSidecarServiceProcessMessageCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockDataColumnSidecarServiceProcessMessageCall) Do(f func(context.Context, *uint64, *cltypes.DataColumnSidecar) error) *MockDataColumnSidecarServiceProcessMessageCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockDataColumnSidecarServiceProcessMessageCall) DoAndReturn(f func(context.Context, *uint64, *cltypes.DataColumnSidecar) error) *MockDataColumnSidecarServiceProcessMessageCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
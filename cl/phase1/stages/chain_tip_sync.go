@@ -70,8 +70,15 @@ func fetchBlocksFromReqResp(ctx context.Context, cfg *Cfg, from uint64, count ui
 		return nil, nil
 	}
 
+	blobBlocks, columnBlocks := splitBlocksByDataAvailability(cfg, blocks)
+	if len(columnBlocks) > 0 {
+		if err := downloadAndProcessPeerDASColumns(ctx, cfg, columnBlocks); err != nil {
+			return nil, err
+		}
+	}
+
 	// Generate blob identifiers from the retrieved blocks
-	ids, err := network2.BlobsIdentifiersFromBlocks(blocks)
+	ids, err := network2.BlobsIdentifiersFromBlocks(blobBlocks)
 	if err != nil {
 		return nil, err
 	}
@@ -28,6 +28,7 @@ import (
 	"github.com/erigontech/erigon/cl/clparams"
 	"github.com/erigontech/erigon/cl/clstages"
 	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/era"
 	"github.com/erigontech/erigon/cl/monitor"
 	"github.com/erigontech/erigon/cl/persistence/beacon_indicies"
 	"github.com/erigontech/erigon/cl/persistence/blob_storage"
@@ -64,6 +65,7 @@ type Cfg struct {
 	blobStore               blob_storage.BlobStorage
 	attestationDataProducer attestation_producer.AttestationDataProducer
 	validatorMonitor        monitor.ValidatorMonitor
+	eraDir                  string // .era files the history is imported from instead of downloading it
 
 	hasDownloaded, backfilling, blobBackfilling bool
 }
@@ -93,6 +95,7 @@ func ClStagesCfg(
 	syncBackLoopLimit uint64,
 	backfilling bool,
 	blobBackfilling bool,
+	eraDir string,
 	syncedData *synced_data.SyncedDataManager,
 	emitters *beaconevents.EventEmitter,
 	blobStore blob_storage.BlobStorage,
@@ -118,6 +121,7 @@ func ClStagesCfg(
 		blobStore:               blobStore,
 		blockCollector:          block_collector.NewBlockCollector(log.Root(), executionClient, beaconCfg, syncBackLoopLimit, dirs.Tmp),
 		blobBackfilling:         blobBackfilling,
+		eraDir:                  eraDir,
 		attestationDataProducer: attestationDataProducer,
 		validatorMonitor:        validatorMonitor,
 	}
@@ -253,8 +257,13 @@ func ConsensusClStages(ctx context.Context,
 
 					startingSlot := cfg.state.LatestBlockHeader().Slot
 					downloader := network2.NewBackwardBeaconDownloader(ctx, cfg.rpc, cfg.sn, cfg.executionClient, cfg.indiciesDB)
+					// the eras are verified against the history of the state the node started from
+					var eraHistory *era.TrustedHistory
+					if cfg.eraDir != "" {
+						eraHistory = era.NewTrustedHistory(cfg.state)
+					}
 
-					if err := SpawnStageHistoryDownload(StageHistoryReconstruction(downloader, cfg.antiquary, cfg.sn, cfg.indiciesDB, cfg.executionClient, cfg.beaconCfg, cfg.backfilling, cfg.blobBackfilling, false, startingRoot, startingSlot, cfg.dirs.Tmp, 600*time.Millisecond, cfg.blockCollector, cfg.blockReader, cfg.blobStore, logger), context.Background(), logger); err != nil {
+					if err := SpawnStageHistoryDownload(StageHistoryReconstruction(downloader, cfg.antiquary, cfg.sn, cfg.indiciesDB, cfg.executionClient, cfg.beaconCfg, cfg.backfilling, cfg.blobBackfilling, false, startingRoot, startingSlot, cfg.dirs.Tmp, 600*time.Millisecond, cfg.blockCollector, cfg.blockReader, cfg.blobStore, cfg.eraDir, eraHistory, logger), context.Background(), logger); err != nil {
 						cfg.hasDownloaded = false
 						return err
 					}
@@ -2,6 +2,7 @@ package stages
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"sync/atomic"
@@ -31,6 +32,67 @@ func shouldProcessBlobs(blocks []*cltypes.SignedBeaconBlock) bool {
 	return false
 }
 
+// splitBlocksByDataAvailability separates the blocks whose data availability is checked through blob sidecars
+// from the ones, after the PeerDAS fork, whose data availability is checked by sampling data columns.
+func splitBlocksByDataAvailability(cfg *Cfg, blocks []*cltypes.SignedBeaconBlock) (blobBlocks, columnBlocks []*cltypes.SignedBeaconBlock) {
+	if cfg.forkChoice.DataColumnsCustody() == nil {
+		return blocks, nil
+	}
+	for _, block := range blocks {
+		if cfg.beaconCfg.IsPeerDASEpoch(block.Block.Slot / cfg.beaconCfg.SlotsPerEpoch) {
+			columnBlocks = append(columnBlocks, block)
+		} else {
+			blobBlocks = append(blobBlocks, block)
+		}
+	}
+	return
+}
+
+// downloadAndProcessPeerDASColumns downloads the sampled data columns of the given blocks until all of them are stored.
+func downloadAndProcessPeerDASColumns(ctx context.Context, cfg *Cfg, blocks []*cltypes.SignedBeaconBlock) error {
+	ids, err := network2.DataColumnIdentifiersFromBlocks(blocks, cfg.forkChoice.DataColumnsCustody())
+	if err != nil {
+		return fmt.Errorf("failed to get data column identifiers: %w", err)
+	}
+	slots := make(map[common.Hash]uint64, len(blocks))
+	for _, block := range blocks {
+		blockRoot, err := block.Block.HashSSZ()
+		if err != nil {
+			return err
+		}
+		slots[blockRoot] = block.Block.Slot
+	}
+
+	for {
+		// peers only custody some of the columns, so we keep asking for the ones we are missing.
+		missing := solid.NewStaticListSSZ[*cltypes.DataColumnIdentifier](0, 40)
+		for i := 0; i < ids.Len(); i++ {
+			id := ids.Get(i)
+			has, err := cfg.blobStore.HasDataColumnSidecars(slots[id.BlockRoot], id.BlockRoot, []uint64{id.Index})
+			if err != nil {
+				return err
+			}
+			if !has {
+				missing.Append(id)
+			}
+		}
+		if missing.Len() == 0 {
+			return nil
+		}
+		columns, err := network2.RequestDataColumnsFrantically(ctx, cfg.rpc, missing)
+		if err != nil {
+			return fmt.Errorf("failed to get data columns: %w", err)
+		}
+		if columns == nil {
+			return errors.New("timed out while requesting data columns")
+		}
+		if _, err := blob_storage.VerifyAgainstIdentifiersAndInsertDataColumns(ctx, cfg.blobStore, cfg.beaconCfg, missing, columns.Responses, nil); err != nil {
+			cfg.rpc.BanPeer(columns.Peer)
+			return fmt.Errorf("failed to verify data columns: %w", err)
+		}
+	}
+}
+
 // downloadAndProcessEip4844DA handles downloading and processing of EIP-4844 data availability blobs.
 // It takes highest slot processed, and a list of signed beacon blocks as input.
 // It returns the highest blob slot processed and an error if any.
@@ -184,14 +246,22 @@ func forwardSync(ctx context.Context, logger log.Logger, cfg *Cfg, args Args) er
 			return initialHighestSlotProcessed, err
 		}
 
+		blobBlocks, columnBlocks := splitBlocksByDataAvailability(cfg, blocks)
+		if shouldProcessBlobs(columnBlocks) {
+			if err := downloadAndProcessPeerDASColumns(ctx, cfg, columnBlocks); err != nil {
+				logger.Warn("[Caplin] Failed to process data columns", "err", err)
+				return initialHighestSlotProcessed, err
+			}
+		}
+
 		// Exit if we are pre-EIP-4844
-		if !shouldProcessBlobs(blocks) {
+		if !shouldProcessBlobs(blobBlocks) {
 			currentSlot.Store(highestSlotProcessed)
 			return highestSlotProcessed, nil
 		}
 
 		// Process blobs for EIP-4844
-		highestBlobSlotProcessed, err := downloadAndProcessEip4844DA(ctx, logger, cfg, initialHighestSlotProcessed, blocks)
+		highestBlobSlotProcessed, err := downloadAndProcessEip4844DA(ctx, logger, cfg, initialHighestSlotProcessed, blobBlocks)
 		if err != nil {
 			logger.Warn("[Caplin] Failed to process blobs", "err", err)
 			return initialHighestSlotProcessed, err
@@ -29,6 +29,7 @@ import (
 	"github.com/erigontech/erigon-lib/kv/memdb"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon/cl/antiquary"
+	"github.com/erigontech/erigon/cl/era"
 	"github.com/erigontech/erigon/cl/persistence/beacon_indicies"
 	"github.com/erigontech/erigon/cl/persistence/blob_storage"
 	"github.com/erigontech/erigon/cl/phase1/execution_client"
@@ -58,11 +59,13 @@ type StageHistoryReconstructionCfg struct {
 	backfillingThrottling    time.Duration
 	blockReader              freezeblocks.BeaconSnapshotReader
 	blobStorage              blob_storage.BlobStorage
+	eraDir                   string
+	eraHistory               *era.TrustedHistory
 }
 
 const logIntervalTime = 30 * time.Second
 
-func StageHistoryReconstruction(downloader *network.BackwardBeaconDownloader, antiquary *antiquary.Antiquary, sn *freezeblocks.CaplinSnapshots, indiciesDB kv.RwDB, engine execution_client.ExecutionEngine, beaconCfg *clparams.BeaconChainConfig, backfilling, blobsBackfilling, waitForAllRoutines bool, startingRoot libcommon.Hash, startinSlot uint64, tmpdir string, backfillingThrottling time.Duration, executionBlocksCollector block_collector.BlockCollector, blockReader freezeblocks.BeaconSnapshotReader, blobStorage blob_storage.BlobStorage, logger log.Logger) StageHistoryReconstructionCfg {
+func StageHistoryReconstruction(downloader *network.BackwardBeaconDownloader, antiquary *antiquary.Antiquary, sn *freezeblocks.CaplinSnapshots, indiciesDB kv.RwDB, engine execution_client.ExecutionEngine, beaconCfg *clparams.BeaconChainConfig, backfilling, blobsBackfilling, waitForAllRoutines bool, startingRoot libcommon.Hash, startinSlot uint64, tmpdir string, backfillingThrottling time.Duration, executionBlocksCollector block_collector.BlockCollector, blockReader freezeblocks.BeaconSnapshotReader, blobStorage blob_storage.BlobStorage, eraDir string, eraHistory *era.TrustedHistory, logger log.Logger) StageHistoryReconstructionCfg {
 	return StageHistoryReconstructionCfg{
 		beaconCfg:                beaconCfg,
 		downloader:               downloader,
@@ -81,6 +84,8 @@ func StageHistoryReconstruction(downloader *network.BackwardBeaconDownloader, an
 		blockReader:              blockReader,
 		blobsBackfilling:         blobsBackfilling,
 		blobStorage:              blobStorage,
+		eraDir:                   eraDir,
+		eraHistory:               eraHistory,
 	}
 }
 
@@ -217,7 +222,25 @@ func SpawnStageHistoryDownload(cfg StageHistoryReconstructionCfg, ctx context.Co
 	}()
 
 	go func() {
+		eraDir := cfg.eraDir
+		skippedEra := uint64(math.MaxUint64)
 		for !cfg.downloader.Finished() {
+			if eraNumber := cfg.downloader.Progress()/cfg.beaconCfg.SlotsPerHistoricalRoot + 1; eraDir != "" && cfg.eraHistory != nil && eraNumber != skippedEra {
+				imported, err := importEraHistory(cfg, eraDir, eraNumber, logger)
+				switch {
+				case errors.Is(err, era.ErrEraNotCovered):
+					// the era is not finalized in the trusted state yet, its blocks come from peers.
+					skippedEra = eraNumber
+				case err != nil:
+					logger.Warn("Could not import era history, downloading it from peers instead", "err", err)
+					eraDir = ""
+				case !imported:
+					skippedEra = eraNumber
+				}
+				if imported {
+					continue
+				}
+			}
 			if err := cfg.downloader.RequestMore(ctx); err != nil {
 				log.Debug("closing backfilling routine", "err", err)
 				return
@@ -277,6 +300,50 @@ func SpawnStageHistoryDownload(cfg StageHistoryReconstructionCfg, ctx context.Co
 	return nil
 }
 
+// importEraHistory feeds the blocks of the era file covering the slot to download to the downloader, in place of
+// downloading them from peers. It returns false when there is no such era file, or when it did not move the download.
+func importEraHistory(cfg StageHistoryReconstructionCfg, eraDir string, eraNumber uint64, logger log.Logger) (bool, error) {
+	slot := cfg.downloader.Progress()
+	path, ok, err := era.FindFile(eraDir, cfg.beaconCfg, eraNumber)
+	if err != nil || !ok {
+		return false, err
+	}
+	r, err := era.Open(path, cfg.beaconCfg)
+	if err != nil {
+		return false, err
+	}
+	defer r.Close()
+	eraState, err := r.ReadState()
+	if err != nil {
+		return false, err
+	}
+	if err := cfg.eraHistory.VerifyState(eraNumber, eraState); err != nil {
+		return false, fmt.Errorf("%s: %w", path, err)
+	}
+
+	startSlot := era.StartSlot(cfg.beaconCfg, eraNumber)
+	for s := slot; s >= startSlot && !cfg.downloader.Finished(); s-- {
+		block, err := r.ReadBlock(s)
+		if err != nil {
+			return false, err
+		}
+		if err := era.VerifyBlock(eraState, s, block); err != nil {
+			return false, fmt.Errorf("%s: %w", path, err)
+		}
+		if block != nil && cfg.downloader.ImportBlock(block) {
+			break
+		}
+		if s == 0 {
+			break
+		}
+	}
+	if cfg.downloader.Progress() == slot && !cfg.downloader.Finished() {
+		return false, nil
+	}
+	logger.Info("Imported era history", "era", eraNumber, "slot", cfg.downloader.Progress())
+	return true, nil
+}
+
 // downloadBlobHistoryWorker is a worker that downloads the blob history by using the already downloaded beacon blocks
 func downloadBlobHistoryWorker(cfg StageHistoryReconstructionCfg, ctx context.Context, shouldLog bool, logger log.Logger) error {
 	currentSlot := cfg.startingSlot + 1
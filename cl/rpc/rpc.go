@@ -37,6 +37,7 @@ import (
 	"github.com/erigontech/erigon-lib/gointerfaces"
 	sentinel "github.com/erigontech/erigon-lib/gointerfaces/sentinelproto"
 	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types/ssz"
 
 	"github.com/erigontech/erigon/cl/clparams"
 	"github.com/erigontech/erigon/cl/cltypes"
@@ -144,8 +145,17 @@ func (b *BeaconRpcP2P) sendBlocksRequest(ctx context.Context, topic string, reqD
 }
 
 func (b *BeaconRpcP2P) sendBlobsSidecar(ctx context.Context, topic string, reqData []byte, count uint64) ([]*cltypes.BlobSidecar, string, error) {
+	return sendSidecarsRequest(ctx, b, topic, reqData, count, func() *cltypes.BlobSidecar { return &cltypes.BlobSidecar{} })
+}
+
+func (b *BeaconRpcP2P) sendDataColumnSidecars(ctx context.Context, topic string, reqData []byte, count uint64) ([]*cltypes.DataColumnSidecar, string, error) {
+	return sendSidecarsRequest(ctx, b, topic, reqData, count, cltypes.NewDataColumnSidecar)
+}
+
+// sendSidecarsRequest sends a request whose response is a stream of sidecar chunks, each prefixed by its fork digest.
+func sendSidecarsRequest[T ssz.Unmarshaler](ctx context.Context, b *BeaconRpcP2P, topic string, reqData []byte, count uint64, newChunk func() T) ([]T, string, error) {
 	// Prepare output slice.
-	responsePacket := []*cltypes.BlobSidecar{}
+	responsePacket := []T{}
 
 	ctx, cn := context.WithTimeout(ctx, time.Second*2)
 	defer cn()
@@ -204,7 +214,7 @@ func (b *BeaconRpcP2P) sendBlobsSidecar(ctx context.Context, topic string, reqDa
 		if err != nil {
 			return nil, message.Peer.Pid, err
 		}
-		responseChunk := &cltypes.BlobSidecar{}
+		responseChunk := newChunk()
 
 		if err = responseChunk.DecodeSSZ(raw, int(version)); err != nil {
 			return nil, message.Peer.Pid, err
@@ -242,6 +252,28 @@ func (b *BeaconRpcP2P) SendBlobsSidecarByRangerReq(ctx context.Context, start, c
 	return b.sendBlobsSidecar(ctx, communication.BlobSidecarByRangeProtocolV1, data, count*b.beaconConfig.MaxBlobsPerBlock)
 }
 
+// SendDataColumnSidecarsByRootReq retrieves data columns by block root and column index.
+func (b *BeaconRpcP2P) SendDataColumnSidecarsByRootReq(ctx context.Context, req *solid.ListSSZ[*cltypes.DataColumnIdentifier]) ([]*cltypes.DataColumnSidecar, string, error) {
+	var buffer buffer.Buffer
+	if err := ssz_snappy.EncodeAndWrite(&buffer, req); err != nil {
+		return nil, "", err
+	}
+
+	data := libcommon.CopyBytes(buffer.Bytes())
+	return b.sendDataColumnSidecars(ctx, communication.DataColumnSidecarsByRootProtocolV1, data, uint64(req.Len()))
+}
+
+// SendDataColumnSidecarsByRangeReq retrieves the given data columns of a range of slots.
+func (b *BeaconRpcP2P) SendDataColumnSidecarsByRangeReq(ctx context.Context, start, count uint64, columns []uint64) ([]*cltypes.DataColumnSidecar, string, error) {
+	var buffer buffer.Buffer
+	if err := ssz_snappy.EncodeAndWrite(&buffer, cltypes.NewDataColumnSidecarsByRangeRequest(start, count, columns)); err != nil {
+		return nil, "", err
+	}
+
+	data := libcommon.CopyBytes(buffer.Bytes())
+	return b.sendDataColumnSidecars(ctx, communication.DataColumnSidecarsByRangeProtocolV1, data, count*uint64(len(columns)))
+}
+
 // SendBeaconBlocksByRangeReq retrieves blocks range from beacon chain.
 func (b *BeaconRpcP2P) SendBeaconBlocksByRangeReq(ctx context.Context, start, count uint64) ([]*cltypes.SignedBeaconBlock, string, error) {
 	req := &cltypes.BeaconBlocksByRangeRequest{
@@ -311,3 +343,13 @@ func (b *BeaconRpcP2P) PropagateBlock(block *cltypes.SignedBeaconBlock) error {
 func (b *BeaconRpcP2P) BanPeer(pid string) {
 	b.sentinel.BanPeer(b.ctx, &sentinel.Peer{Pid: pid})
 }
+
+// PenalizePeer lowers the score of a peer which sent a useless or invalid response.
+func (b *BeaconRpcP2P) PenalizePeer(pid string) {
+	b.sentinel.PenalizePeer(b.ctx, &sentinel.Peer{Pid: pid})
+}
+
+// RewardPeer raises the score of a peer which sent a useful response.
+func (b *BeaconRpcP2P) RewardPeer(pid string) {
+	b.sentinel.RewardPeer(b.ctx, &sentinel.Peer{Pid: pid})
+}
@@ -61,6 +61,9 @@ const (
 	// blsToExecutionChangeWeight specifies the scoring weight that we apply to
 	// our bls to execution topic.
 	blsToExecutionChangeWeight = 0.05
+	// sidecarSubnetsTotalWeight specifies the scoring weight that we apply to
+	// our blob sidecar, or data column sidecar, subnet topics.
+	sidecarSubnetsTotalWeight = 0.4
 
 	// maxInMeshScore describes the max score a peer can attain from being in the mesh.
 	maxInMeshScore = 10
@@ -260,21 +263,39 @@ func (s *Sentinel) Unsubscribe(topic GossipTopic, opts ...pubsub.TopicOpt) (err
 }
 
 func (s *Sentinel) topicScoreParams(topic string) *pubsub.TopicScoreParams {
+	activeValidators := s.activeValidators.Load()
 	switch {
-	case strings.Contains(topic, gossip.TopicNameBeaconBlock) || gossip.IsTopicBlobSidecar(topic):
+	case strings.Contains(topic, gossip.TopicNameBeaconBlock):
 		return s.defaultBlockTopicParams()
+	case gossip.IsTopicBlobSidecar(topic):
+		// one blob per subnet and per block at most.
+		return s.defaultSidecarSubnetTopicParams(s.cfg.BeaconConfig.MaxBlobsPerBlock, 1)
+	case gossip.IsTopicDataColumnSidecar(topic):
+		subnetCount := s.cfg.BeaconConfig.DataColumnSidecarSubnetCount
+		return s.defaultSidecarSubnetTopicParams(subnetCount, s.cfg.BeaconConfig.NumberOfColumns/subnetCount)
 	case strings.Contains(topic, gossip.TopicNameVoluntaryExit):
 		return s.defaultVoluntaryExitTopicParams()
+	case strings.Contains(topic, gossip.TopicNameBeaconAggregateAndProof):
+		return s.defaultAggregateTopicParams(activeValidators)
+	case strings.Contains(topic, gossip.TopicNameSyncCommitteeContributionAndProof):
+		return s.defaultSyncContributionTopicParams()
 	case gossip.IsTopicBeaconAttestation(topic):
-		return s.defaultAggregateSubnetTopicParams()
+		return s.defaultAttestationSubnetTopicParams(activeValidators)
 	case gossip.IsTopicSyncCommittee(topic):
-		return s.defaultSyncSubnetTopicParams(s.cfg.ActiveIndicies)
+		return s.defaultSyncSubnetTopicParams(activeValidators)
 
 	default:
 		return nil
 	}
 }
 
+// topicScoreParamsDependOnValidators tells whether the score parameters of the topic depend on the amount of active
+// validators.
+func topicScoreParamsDependOnValidators(topic string) bool {
+	return strings.Contains(topic, gossip.TopicNameBeaconAggregateAndProof) || gossip.IsTopicBeaconAttestation(topic) ||
+		gossip.IsTopicSyncCommittee(topic)
+}
+
 // Based on the prysm parameters.
 // https://gist.github.com/blacktemplar/5c1862cb3f0e32a1a7fb0b25e79e6e2c
 func (s *Sentinel) defaultBlockTopicParams() *pubsub.TopicScoreParams {
@@ -390,8 +411,7 @@ func decayLimit(decayRate, rate float64) (float64, error) {
 	return rate / (1 - decayRate), nil
 }
 
-func (s *Sentinel) committeeCountPerSlot() uint64 {
-	activeValidatorCount := s.cfg.ActiveIndicies
+func (s *Sentinel) committeeCountPerSlot(activeValidatorCount uint64) uint64 {
 	cfg := s.cfg.BeaconConfig
 	var committeesPerSlot = activeValidatorCount / cfg.SlotsPerEpoch / cfg.TargetCommitteeSize
 
@@ -409,7 +429,7 @@ func (s *Sentinel) committeeCountPerSlot() uint64 {
 func maxScore() float64 {
 	totalWeight := beaconBlockWeight + aggregateWeight + syncContributionWeight +
 		attestationTotalWeight + syncCommitteesTotalWeight + attesterSlashingWeight +
-		proposerSlashingWeight + voluntaryExitWeight + blsToExecutionChangeWeight
+		proposerSlashingWeight + voluntaryExitWeight + blsToExecutionChangeWeight + sidecarSubnetsTotalWeight
 	return (maxInMeshScore + maxFirstDeliveryScore) * totalWeight
 }
 
@@ -424,13 +444,13 @@ func decayThreshold(decayRate, rate float64) (float64, error) {
 	return d * decayRate, nil
 }
 
-func (s *Sentinel) defaultAggregateSubnetTopicParams() *pubsub.TopicScoreParams {
+func (s *Sentinel) defaultAttestationSubnetTopicParams(activeValidators uint64) *pubsub.TopicScoreParams {
 	subnetCount := s.cfg.NetworkConfig.AttestationSubnetCount
 	// Get weight for each specific subnet.
 	topicWeight := float64(attestationTotalWeight) / float64(subnetCount)
-	subnetWeight := s.cfg.ActiveIndicies / subnetCount
+	subnetWeight := activeValidators / subnetCount
 	if subnetWeight == 0 {
-		log.Warn("Subnet weight is 0, skipping initializing topic scoring", "activeValidatorCount", s.cfg.ActiveIndicies)
+		log.Warn("Subnet weight is 0, skipping initializing topic scoring", "activeValidatorCount", activeValidators)
 		return nil
 	}
 	// Determine the amount of validators expected in a subnet in a single slot.
@@ -439,7 +459,7 @@ func (s *Sentinel) defaultAggregateSubnetTopicParams() *pubsub.TopicScoreParams
 		log.Trace("numPerSlot is 0, skipping initializing topic scoring")
 		return nil
 	}
-	comsPerSlot := s.committeeCountPerSlot()
+	comsPerSlot := s.committeeCountPerSlot(activeValidators)
 	exceedsThreshold := comsPerSlot >= 2*subnetCount/s.cfg.BeaconConfig.SlotsPerEpoch
 	firstDecayDuration := 1 * s.oneEpochDuration()
 	meshDecayDuration := 4 * s.oneEpochDuration()
@@ -486,6 +506,87 @@ func (s *Sentinel) defaultAggregateSubnetTopicParams() *pubsub.TopicScoreParams
 	}
 }
 
+func (s *Sentinel) defaultAggregateTopicParams(activeValidators uint64) *pubsub.TopicScoreParams {
+	// Determine the amount of aggregates expected in a single slot.
+	aggregatesPerSlot := s.committeeCountPerSlot(activeValidators) * s.cfg.BeaconConfig.TargetAggregatorsPerCommittee
+	return s.aggregateTopicParams(aggregateWeight, aggregatesPerSlot)
+}
+
+func (s *Sentinel) defaultSyncContributionTopicParams() *pubsub.TopicScoreParams {
+	// Determine the amount of contributions expected in a single slot.
+	contributionsPerSlot := s.cfg.BeaconConfig.SyncCommitteeSubnetCount * s.cfg.BeaconConfig.TargetAggregatorsPerSyncSubcommittee
+	return s.aggregateTopicParams(syncContributionWeight, contributionsPerSlot)
+}
+
+// aggregateTopicParams are the parameters of a topic which receives messagesPerSlot aggregates in every slot.
+func (s *Sentinel) aggregateTopicParams(topicWeight float64, messagesPerSlot uint64) *pubsub.TopicScoreParams {
+	decayDuration := 1 * s.oneEpochDuration()
+	rate := messagesPerSlot * 2 / gossipSubD
+	if rate == 0 {
+		log.Trace("rate is 0, skipping initializing topic scoring")
+		return nil
+	}
+	// Determine expected first deliveries based on the message rate.
+	firstMessageCap, err := decayLimit(s.scoreDecay(decayDuration), float64(rate))
+	if err != nil {
+		log.Trace("skipping initializing topic scoring", "err", err)
+		return nil
+	}
+	firstMessageWeight := float64(maxFirstDeliveryScore) / firstMessageCap
+	// Determine expected mesh deliveries based on message rate applied with a dampening factor.
+	meshThreshold, err := decayThreshold(s.scoreDecay(decayDuration), float64(messagesPerSlot)/float64(dampeningFactor))
+	if err != nil {
+		log.Trace("skipping initializing topic scoring", "err", err)
+		return nil
+	}
+	meshCap := 4 * meshThreshold
+
+	return &pubsub.TopicScoreParams{
+		TopicWeight:                     topicWeight,
+		TimeInMeshWeight:                maxInMeshScore / s.inMeshCap(),
+		TimeInMeshQuantum:               s.oneSlotDuration(),
+		TimeInMeshCap:                   s.inMeshCap(),
+		FirstMessageDeliveriesWeight:    firstMessageWeight,
+		FirstMessageDeliveriesDecay:     s.scoreDecay(decayDuration),
+		FirstMessageDeliveriesCap:       firstMessageCap,
+		MeshMessageDeliveriesDecay:      s.scoreDecay(decayDuration),
+		MeshMessageDeliveriesCap:        meshCap,
+		MeshMessageDeliveriesThreshold:  meshThreshold,
+		MeshMessageDeliveriesWindow:     2 * time.Second,
+		MeshMessageDeliveriesActivation: 1 * s.oneEpochDuration(),
+		MeshFailurePenaltyDecay:         s.scoreDecay(decayDuration),
+		InvalidMessageDeliveriesWeight:  -maxScore() / topicWeight,
+		InvalidMessageDeliveriesDecay:   s.scoreDecay(50 * s.oneEpochDuration()),
+	}
+}
+
+// defaultSidecarSubnetTopicParams are the parameters of the blob sidecar and data column sidecar subnets, which
+// receive at most messagesPerBlock sidecars for every block.
+func (s *Sentinel) defaultSidecarSubnetTopicParams(subnetCount, messagesPerBlock uint64) *pubsub.TopicScoreParams {
+	if subnetCount == 0 || messagesPerBlock == 0 {
+		return nil
+	}
+	topicWeight := sidecarSubnetsTotalWeight / float64(subnetCount)
+	messagesPerEpoch := s.cfg.BeaconConfig.SlotsPerEpoch * messagesPerBlock
+	return &pubsub.TopicScoreParams{
+		TopicWeight:                     topicWeight,
+		TimeInMeshWeight:                maxInMeshScore / s.inMeshCap(),
+		TimeInMeshQuantum:               s.oneSlotDuration(),
+		TimeInMeshCap:                   s.inMeshCap(),
+		FirstMessageDeliveriesWeight:    1,
+		FirstMessageDeliveriesDecay:     s.scoreDecay(20 * s.oneEpochDuration()),
+		FirstMessageDeliveriesCap:       float64(maxFirstDeliveryScore),
+		MeshMessageDeliveriesDecay:      s.scoreDecay(5 * s.oneEpochDuration()),
+		MeshMessageDeliveriesCap:        float64(messagesPerEpoch * 5),
+		MeshMessageDeliveriesThreshold:  float64(messagesPerEpoch*5) / 10,
+		MeshMessageDeliveriesWindow:     2 * time.Second,
+		MeshMessageDeliveriesActivation: 4 * s.oneEpochDuration(),
+		MeshFailurePenaltyDecay:         s.scoreDecay(5 * s.oneEpochDuration()),
+		InvalidMessageDeliveriesWeight:  -maxScore() / topicWeight,
+		InvalidMessageDeliveriesDecay:   s.scoreDecay(50 * s.oneEpochDuration()),
+	}
+}
+
 func (g *GossipManager) Close() {
 	g.subscriptions.Range(func(key, value interface{}) bool {
 		if value != nil {
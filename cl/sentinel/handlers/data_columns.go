@@ -0,0 +1,134 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package handlers
+
+import (
+	"github.com/libp2p/go-libp2p/core/network"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/cltypes/solid"
+	"github.com/erigontech/erigon/cl/persistence/beacon_indicies"
+	"github.com/erigontech/erigon/cl/sentinel/communication/ssz_snappy"
+	"github.com/erigontech/erigon/cl/utils"
+)
+
+const maxDataColumnsThroughoutputPerRequest = 512
+
+func (c *ConsensusHandlers) dataColumnSidecarsByRangeHandler(s network.Stream) error {
+	peerId := s.Conn().RemotePeer().String()
+
+	req := &cltypes.DataColumnSidecarsByRangeRequest{}
+	if err := ssz_snappy.DecodeAndReadNoForkDigest(s, req, clparams.DenebVersion); err != nil {
+		return err
+	}
+	if err := c.checkRateLimit(peerId, "dataColumnSidecar", rateLimits.dataColumnSidecarsLimit, min(int(req.Count)*req.Columns.Length(), maxDataColumnsThroughoutputPerRequest)); err != nil {
+		ssz_snappy.EncodeAndWrite(s, &emptyString{}, RateLimitedPrefix)
+		return err
+	}
+
+	tx, err := c.indiciesDB.BeginRo(c.ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	written := 0
+	for slot := req.StartSlot; slot < req.StartSlot+req.Count && written < maxDataColumnsThroughoutputPerRequest; slot++ {
+		blockRoot, err := beacon_indicies.ReadCanonicalBlockRoot(tx, slot)
+		if err != nil {
+			return err
+		}
+		if blockRoot == (libcommon.Hash{}) {
+			continue
+		}
+		for i := 0; i < req.Columns.Length() && written < maxDataColumnsThroughoutputPerRequest; i++ {
+			ok, err := c.writeDataColumnSidecar(s, slot, blockRoot, req.Columns.Get(i))
+			if err != nil {
+				return err
+			}
+			if ok {
+				written++
+			}
+		}
+	}
+	return nil
+}
+
+func (c *ConsensusHandlers) dataColumnSidecarsByRootHandler(s network.Stream) error {
+	peerId := s.Conn().RemotePeer().String()
+
+	req := solid.NewStaticListSSZ[*cltypes.DataColumnIdentifier](int(c.beaconConfig.MaxRequestDataColumnSidecars), 40)
+	if err := ssz_snappy.DecodeAndReadNoForkDigest(s, req, clparams.DenebVersion); err != nil {
+		return err
+	}
+	if err := c.checkRateLimit(peerId, "dataColumnSidecar", rateLimits.dataColumnSidecarsLimit, min(req.Len(), maxDataColumnsThroughoutputPerRequest)); err != nil {
+		ssz_snappy.EncodeAndWrite(s, &emptyString{}, RateLimitedPrefix)
+		return err
+	}
+
+	tx, err := c.indiciesDB.BeginRo(c.ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	written := 0
+	for i := 0; i < req.Len() && written < maxDataColumnsThroughoutputPerRequest; i++ {
+		id := req.Get(i)
+		slot, err := beacon_indicies.ReadBlockSlotByBlockRoot(tx, id.BlockRoot)
+		if err != nil {
+			return err
+		}
+		if slot == nil {
+			continue
+		}
+		ok, err := c.writeDataColumnSidecar(s, *slot, id.BlockRoot, id.Index)
+		if err != nil {
+			return err
+		}
+		if ok {
+			written++
+		}
+	}
+	return nil
+}
+
+// writeDataColumnSidecar writes a response chunk for the column if we custody it, columns we do not have are skipped.
+func (c *ConsensusHandlers) writeDataColumnSidecar(s network.Stream, slot uint64, blockRoot libcommon.Hash, index uint64) (bool, error) {
+	has, err := c.blobsStorage.HasDataColumnSidecars(slot, blockRoot, []uint64{index})
+	if err != nil || !has {
+		return false, err
+	}
+	version := c.beaconConfig.GetCurrentStateVersion(slot / c.beaconConfig.SlotsPerEpoch)
+	// Read the fork digest
+	forkDigest, err := c.ethClock.ComputeForkDigestForVersion(utils.Uint32ToBytes4(c.beaconConfig.GetForkVersionByVersion(version)))
+	if err != nil {
+		return false, err
+	}
+	if _, err := s.Write([]byte{0}); err != nil {
+		return false, err
+	}
+	if _, err := s.Write(forkDigest[:]); err != nil {
+		return false, err
+	}
+	if err := c.blobsStorage.WriteDataColumnStream(s, slot, blockRoot, index); err != nil {
+		return false, err
+	}
+	return true, nil
+}
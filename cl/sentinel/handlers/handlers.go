@@ -55,6 +55,7 @@ type RateLimits struct {
 	beaconBlocksByRootLimit  int
 	lightClientLimit         int
 	blobSidecarsLimit        int
+	dataColumnSidecarsLimit  int
 }
 
 const (
@@ -63,6 +64,7 @@ const (
 	blockHandlerRateLimit = 200
 	lightClientRateLimit  = 500
 	blobHandlerRateLimit  = 50 // very generous here.
+	dataColumnRateLimit   = 2 * maxDataColumnsThroughoutputPerRequest
 )
 
 var rateLimits = RateLimits{
@@ -75,6 +77,7 @@ var rateLimits = RateLimits{
 	beaconBlocksByRootLimit:  blockHandlerRateLimit,
 	lightClientLimit:         lightClientRateLimit,
 	blobSidecarsLimit:        blobHandlerRateLimit,
+	dataColumnSidecarsLimit:  dataColumnRateLimit,
 }
 
 type ConsensusHandlers struct {
@@ -139,6 +142,10 @@ func NewConsensusHandlers(ctx context.Context, db freezeblocks.BeaconSnapshotRea
 		hm[communication.BeaconBlocksByRootProtocolV2] = c.beaconBlocksByRootHandler
 		hm[communication.BlobSidecarByRangeProtocolV1] = c.blobsSidecarsByRangeHandler
 		hm[communication.BlobSidecarByRootProtocolV1] = c.blobsSidecarsByIdsHandler
+		if c.beaconConfig.Eip7594ForkEpoch != math.MaxUint64 {
+			hm[communication.DataColumnSidecarsByRangeProtocolV1] = c.dataColumnSidecarsByRangeHandler
+			hm[communication.DataColumnSidecarsByRootProtocolV1] = c.dataColumnSidecarsByRootHandler
+		}
 	}
 
 	c.handlers = map[protocol.ID]network.StreamHandler{}
@@ -21,7 +21,6 @@ import (
 	"time"
 
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
-	"github.com/libp2p/go-libp2p/core/peer"
 )
 
 // determines the decay rate from the provided time period till
@@ -40,11 +39,9 @@ func (s *Sentinel) pubsubOptions() []pubsub.Option {
 		OpportunisticGraftThreshold: 5,
 	}
 	scoreParams := &pubsub.PeerScoreParams{
-		Topics:        make(map[string]*pubsub.TopicScoreParams),
-		TopicScoreCap: 32.72,
-		AppSpecificScore: func(p peer.ID) float64 {
-			return 0
-		},
+		Topics:                      make(map[string]*pubsub.TopicScoreParams),
+		TopicScoreCap:               32.72,
+		AppSpecificScore:            s.scorer.AppSpecificScore,
 		AppSpecificWeight:           1,
 		IPColocationFactorWeight:    -35.11,
 		IPColocationFactorThreshold: 10,
@@ -65,6 +62,7 @@ func (s *Sentinel) pubsubOptions() []pubsub.Option {
 		pubsub.WithMaxMessageSize(int(s.cfg.NetworkConfig.GossipMaxSizeBellatrix)),
 		pubsub.WithValidateQueueSize(pubsubQueueSize),
 		pubsub.WithPeerScore(scoreParams, thresholds),
+		pubsub.WithPeerScoreInspect(pubsub.ExtendedPeerScoreInspectFn(s.scorer.Inspect), s.oneSlotDuration()),
 		pubsub.WithGossipSubParams(pubsubGossipParam()),
 	}
 	return psOpts
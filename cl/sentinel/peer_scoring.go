@@ -0,0 +1,93 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package sentinel
+
+import (
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+// banPeer bans and disconnects a peer, it is called by the scorer.
+func (s *Sentinel) banPeer(pid peer.ID) {
+	s.peers.SetBanStatus(pid, true)
+	s.host.Peerstore().RemovePeer(pid)
+	s.host.Network().ClosePeer(pid)
+}
+
+// peerScoresLoop decays the application specific scores every slot, and keeps the topic score parameters in line
+// with the amount of active validators every epoch.
+func (s *Sentinel) peerScoresLoop() {
+	decayInterval := time.NewTicker(s.oneSlotDuration())
+	defer decayInterval.Stop()
+	validatorsInterval := time.NewTicker(s.oneEpochDuration())
+	defer validatorsInterval.Stop()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-decayInterval.C:
+			s.scorer.Decay()
+		case <-validatorsInterval.C:
+			if count, ok := s.estimateActiveValidators(); ok {
+				s.SetActiveValidatorCount(count)
+			}
+		}
+	}
+}
+
+// estimateActiveValidators estimates the amount of active validators from the total active balance of the head. It
+// overestimates the validators with a balance above the max effective balance, which only makes the topic scoring
+// expect more messages than it gets.
+func (s *Sentinel) estimateActiveValidators() (uint64, bool) {
+	if s.forkChoiceReader == nil {
+		return 0, false
+	}
+	headRoot, _, err := s.forkChoiceReader.GetHead()
+	if err != nil {
+		return 0, false
+	}
+	totalActiveBalance, ok := s.forkChoiceReader.TotalActiveBalance(headRoot)
+	if !ok {
+		return 0, false
+	}
+	return totalActiveBalance / s.cfg.BeaconConfig.MaxEffectiveBalance, true
+}
+
+// SetActiveValidatorCount recomputes the score parameters of the topics which depend on the amount of active
+// validators.
+func (s *Sentinel) SetActiveValidatorCount(count uint64) {
+	if count == 0 || s.activeValidators.Swap(count) == count || s.subManager == nil {
+		return
+	}
+	s.subManager.subscriptions.Range(func(_, value any) bool {
+		sub := value.(*GossipSubscription)
+		if !topicScoreParamsDependOnValidators(sub.gossip_topic.Name) {
+			return true
+		}
+		params := s.topicScoreParams(sub.gossip_topic.Name)
+		if params == nil {
+			return true
+		}
+		if err := sub.topic.SetScoreParams(params); err != nil {
+			log.Debug("[Sentinel] Could not update topic score parameters", "topic", sub.gossip_topic.Name, "err", err)
+		}
+		return true
+	})
+}
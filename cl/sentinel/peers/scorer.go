@@ -0,0 +1,241 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package peers
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/erigontech/erigon-lib/metrics"
+)
+
+// Changes of the application specific score, which is added as is to the gossipsub score of the peer.
+const (
+	// reqRespFailurePenalty is applied when a peer does not answer a request, or answers it with an error code.
+	reqRespFailurePenalty = -10
+	// reqRespSuccessReward is applied when a peer answers a request.
+	reqRespSuccessReward = 1
+	maxReqRespScore      = 50
+	// applicationPenalty is applied when the node rejects what the peer sent: an invalid gossip message or an invalid
+	// req/resp response.
+	applicationPenalty = -100
+	// applicationReward is applied when the node makes use of what the peer sent: a valid block, either gossiped or
+	// served over req/resp.
+	applicationReward   = 5
+	maxApplicationScore = 100
+
+	// BanThreshold is the application specific score below which peers are banned.
+	BanThreshold = -1000
+
+	// scores closer to 0 than this are rounded to 0 on decay.
+	scoreDecayToZero = 0.1
+)
+
+var peerScoreGauge = metrics.GetOrCreateGaugeVec("sentinel_peer_score", []string{"peer", "component"}, "Score components of the sentinel peers")
+
+var peerScoreComponents = []string{"gossip", "app_specific", "ip_colocation_factor", "behaviour_penalty", "req_resp", "application"}
+
+// PeerScore holds the score components of a peer.
+type PeerScore struct {
+	Pid peer.ID `json:"peer_id"`
+	// Gossip is the total gossipsub score of the peer, which includes the application specific score. It, and the
+	// gossipsub components below, are only known for the peers gossipsub keeps track of.
+	Gossip             float64 `json:"gossip"`
+	IPColocationFactor float64 `json:"ip_colocation_factor"`
+	BehaviourPenalty   float64 `json:"behaviour_penalty"`
+	// ReqResp and Application are the two parts of the application specific score: the first one is fed by the
+	// outcome of the requests to the peer, the second one by the verdicts of the node on what the peer sent.
+	ReqResp     float64 `json:"req_resp"`
+	Application float64 `json:"application"`
+
+	inspected bool
+}
+
+// AppSpecific is the application specific score of the peer.
+func (p *PeerScore) AppSpecific() float64 {
+	return p.ReqResp + p.Application
+}
+
+func (p *PeerScore) components() []float64 {
+	return []float64{p.Gossip, p.AppSpecific(), p.IPColocationFactor, p.BehaviourPenalty, p.ReqResp, p.Application}
+}
+
+// Scorer keeps the score of the peers, it provides the application specific score to gossipsub and bans the peers
+// whose application specific score drops below BanThreshold.
+type Scorer struct {
+	mu     sync.Mutex
+	scores map[peer.ID]*PeerScore
+
+	decay float64
+	onBan func(pid peer.ID)
+}
+
+// NewScorer creates a scorer whose application specific scores are multiplied by decay on every call to Decay.
+// onBan is called, without holding any lock, when a peer has to be banned.
+func NewScorer(decay float64, onBan func(pid peer.ID)) *Scorer {
+	return &Scorer{
+		scores: make(map[peer.ID]*PeerScore),
+		decay:  decay,
+		onBan:  onBan,
+	}
+}
+
+// update applies fn to the score of pid, and bans the peer if that makes it cross the ban threshold.
+func (s *Scorer) update(pid peer.ID, fn func(score *PeerScore)) {
+	s.mu.Lock()
+	score := s.get(pid)
+	wasBanned := score.AppSpecific() <= BanThreshold
+	fn(score)
+	ban := !wasBanned && score.AppSpecific() <= BanThreshold
+	s.mu.Unlock()
+	if ban {
+		s.onBan(pid)
+	}
+}
+
+// get returns the score of pid, assumes the lock is held.
+func (s *Scorer) get(pid peer.ID) *PeerScore {
+	score, ok := s.scores[pid]
+	if !ok {
+		score = &PeerScore{Pid: pid}
+		s.scores[pid] = score
+	}
+	return score
+}
+
+// AppSpecificScore is the application specific score of gossipsub.
+func (s *Scorer) AppSpecificScore(pid peer.ID) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if score, ok := s.scores[pid]; ok {
+		return score.AppSpecific()
+	}
+	return 0
+}
+
+// ReqRespFailed records a request the peer did not answer, or answered with an error code.
+func (s *Scorer) ReqRespFailed(pid peer.ID) {
+	s.update(pid, func(score *PeerScore) {
+		score.ReqResp += reqRespFailurePenalty
+	})
+}
+
+// ReqRespSucceeded records a request the peer answered.
+func (s *Scorer) ReqRespSucceeded(pid peer.ID) {
+	s.update(pid, func(score *PeerScore) {
+		score.ReqResp = math.Min(score.ReqResp+reqRespSuccessReward, maxReqRespScore)
+	})
+}
+
+// Reward records that the node made use of what the peer sent.
+func (s *Scorer) Reward(pid peer.ID) {
+	s.update(pid, func(score *PeerScore) {
+		score.Application = math.Min(score.Application+applicationReward, maxApplicationScore)
+	})
+}
+
+// Penalize records that the node rejected what the peer sent.
+func (s *Scorer) Penalize(pid peer.ID) {
+	s.update(pid, func(score *PeerScore) {
+		score.Application += applicationPenalty
+	})
+}
+
+// Ban drops the application specific score of the peer to the ban threshold, and bans it.
+func (s *Scorer) Ban(pid peer.ID) {
+	s.mu.Lock()
+	score := s.get(pid)
+	score.Application = math.Min(score.Application, BanThreshold-score.ReqResp)
+	s.mu.Unlock()
+	s.onBan(pid)
+}
+
+// Unban resets the application specific score of the peer.
+func (s *Scorer) Unban(pid peer.ID) {
+	s.update(pid, func(score *PeerScore) {
+		score.ReqResp, score.Application = 0, 0
+	})
+}
+
+// Decay moves the application specific scores towards 0. Peers with no score left which gossipsub forgot about are
+// dropped.
+func (s *Scorer) Decay() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for pid, score := range s.scores {
+		score.ReqResp = decayScore(score.ReqResp, s.decay)
+		score.Application = decayScore(score.Application, s.decay)
+		if !score.inspected && score.ReqResp == 0 && score.Application == 0 {
+			delete(s.scores, pid)
+			for _, component := range peerScoreComponents {
+				peerScoreGauge.DeleteLabelValues(pid.String(), component)
+			}
+		}
+	}
+}
+
+func decayScore(score, decay float64) float64 {
+	score *= decay
+	if math.Abs(score) < scoreDecayToZero {
+		return 0
+	}
+	return score
+}
+
+// Inspect updates the gossipsub components of the scores with the snapshot of the gossipsub peer scores, and
+// reports all of the scores as metrics.
+func (s *Scorer) Inspect(snapshots map[peer.ID]*pubsub.PeerScoreSnapshot) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, score := range s.scores {
+		score.inspected = false
+		score.Gossip, score.IPColocationFactor, score.BehaviourPenalty = 0, 0, 0
+	}
+	for pid, snapshot := range snapshots {
+		score := s.get(pid)
+		score.inspected = true
+		score.Gossip = snapshot.Score
+		score.IPColocationFactor = snapshot.IPColocationFactor
+		score.BehaviourPenalty = snapshot.BehaviourPenalty
+	}
+	for pid, score := range s.scores {
+		for i, value := range score.components() {
+			peerScoreGauge.WithLabelValues(pid.String(), peerScoreComponents[i]).Set(value)
+		}
+	}
+}
+
+// Scores returns the scores of all of the known peers, best first.
+func (s *Scorer) Scores() []PeerScore {
+	s.mu.Lock()
+	scores := make([]PeerScore, 0, len(s.scores))
+	for _, score := range s.scores {
+		scores = append(scores, *score)
+	}
+	s.mu.Unlock()
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Gossip != scores[j].Gossip {
+			return scores[i].Gossip > scores[j].Gossip
+		}
+		return scores[i].AppSpecific() > scores[j].AppSpecific()
+	})
+	return scores
+}
@@ -0,0 +1,79 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package peers
+
+import (
+	"testing"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScorerBan(t *testing.T) {
+	var banned []peer.ID
+	s := NewScorer(0.5, func(pid peer.ID) { banned = append(banned, pid) })
+	pid := peer.ID("peer")
+
+	s.Reward(pid)
+	s.ReqRespSucceeded(pid)
+	require.Equal(t, float64(applicationReward+reqRespSuccessReward), s.AppSpecificScore(pid))
+
+	for s.AppSpecificScore(pid) > BanThreshold {
+		s.Penalize(pid)
+	}
+	// further penalties do not ban the peer again.
+	s.Penalize(pid)
+	s.ReqRespFailed(pid)
+	require.Equal(t, []peer.ID{pid}, banned)
+
+	s.Unban(pid)
+	require.Zero(t, s.AppSpecificScore(pid))
+	s.Ban(pid)
+	require.LessOrEqual(t, s.AppSpecificScore(pid), float64(BanThreshold))
+	require.Equal(t, []peer.ID{pid, pid}, banned)
+}
+
+func TestScorerDecay(t *testing.T) {
+	s := NewScorer(0.5, func(peer.ID) {})
+	forgotten, inspected := peer.ID("forgotten"), peer.ID("inspected")
+	s.Penalize(forgotten)
+	s.Inspect(map[peer.ID]*pubsub.PeerScoreSnapshot{inspected: {Score: 3, BehaviourPenalty: 1}})
+
+	s.Decay()
+	require.Equal(t, applicationPenalty*0.5, s.AppSpecificScore(forgotten))
+	for i := 0; i < 20; i++ {
+		s.Decay()
+	}
+	require.Zero(t, s.AppSpecificScore(forgotten))
+
+	scores := s.Scores()
+	require.Len(t, scores, 1)
+	require.Equal(t, inspected, scores[0].Pid)
+	require.Equal(t, float64(3), scores[0].Gossip)
+	require.Equal(t, float64(1), scores[0].BehaviourPenalty)
+}
+
+func TestScorerCaps(t *testing.T) {
+	s := NewScorer(1, func(peer.ID) {})
+	pid := peer.ID("peer")
+	for i := 0; i < 1000; i++ {
+		s.Reward(pid)
+		s.ReqRespSucceeded(pid)
+	}
+	require.Equal(t, float64(maxApplicationScore+maxReqRespScore), s.AppSpecificScore(pid))
+}
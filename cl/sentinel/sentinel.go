@@ -26,6 +26,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -86,6 +87,10 @@ type Sentinel struct {
 	host     host.Host
 	cfg      *SentinelConfig
 	peers    *peers.Pool
+	scorer   *peers.Scorer
+
+	// activeValidators is the amount of active validators the topic score parameters are computed for.
+	activeValidators atomic.Uint64
 
 	httpApi http.Handler
 
@@ -275,6 +280,8 @@ func New(
 	s.host = host
 
 	s.peers = peers.NewPool()
+	s.scorer = peers.NewScorer(s.scoreDecay(10*s.oneEpochDuration()), s.banPeer)
+	s.activeValidators.Store(cfg.ActiveIndicies)
 
 	mux := chi.NewRouter()
 	//	mux := httpreqresp.NewRequestHandler(host)
@@ -326,6 +333,7 @@ func (s *Sentinel) Start() error {
 
 	go s.listenForPeers()
 	go s.forkWatcher()
+	go s.peerScoresLoop()
 
 	return nil
 }
@@ -340,6 +348,11 @@ func (s *Sentinel) String() string {
 	return s.listener.Self().String()
 }
 
+// NodeID is the discovery id of the node, which also determines the data columns it custodies.
+func (s *Sentinel) NodeID() enode.ID {
+	return s.listener.Self().ID()
+}
+
 func (s *Sentinel) HasTooManyPeers() bool {
 	active, _, _ := s.GetPeersCount()
 	return active >= peers.DefaultMaxPeers
@@ -536,6 +549,10 @@ func (s *Sentinel) Peers() *peers.Pool {
 	return s.peers
 }
 
+func (s *Sentinel) PeerScorer() *peers.Scorer {
+	return s.scorer
+}
+
 func (s *Sentinel) GossipManager() *GossipManager {
 	return s.subManager
 }
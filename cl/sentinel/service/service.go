@@ -91,9 +91,37 @@ func (s *SentinelServer) BanPeer(_ context.Context, p *sentinelrpc.Peer) (*senti
 	if err := pid.UnmarshalText([]byte(p.Pid)); err != nil {
 		return nil, err
 	}
-	s.sentinel.Peers().SetBanStatus(pid, true)
-	s.sentinel.Host().Peerstore().RemovePeer(pid)
-	s.sentinel.Host().Network().ClosePeer(pid)
+	s.sentinel.PeerScorer().Ban(pid)
+	return &sentinelrpc.EmptyMessage{}, nil
+}
+
+func (s *SentinelServer) UnbanPeer(_ context.Context, p *sentinelrpc.Peer) (*sentinelrpc.EmptyMessage, error) {
+	var pid peer.ID
+	if err := pid.UnmarshalText([]byte(p.Pid)); err != nil {
+		return nil, err
+	}
+	s.sentinel.PeerScorer().Unban(pid)
+	s.sentinel.Peers().SetBanStatus(pid, false)
+	return &sentinelrpc.EmptyMessage{}, nil
+}
+
+// PenalizePeer is called when the node rejects what the peer sent, repeated penalties get the peer banned.
+func (s *SentinelServer) PenalizePeer(_ context.Context, p *sentinelrpc.Peer) (*sentinelrpc.EmptyMessage, error) {
+	var pid peer.ID
+	if err := pid.UnmarshalText([]byte(p.Pid)); err != nil {
+		return nil, err
+	}
+	s.sentinel.PeerScorer().Penalize(pid)
+	return &sentinelrpc.EmptyMessage{}, nil
+}
+
+// RewardPeer is called when the node makes use of what the peer sent.
+func (s *SentinelServer) RewardPeer(_ context.Context, p *sentinelrpc.Peer) (*sentinelrpc.EmptyMessage, error) {
+	var pid peer.ID
+	if err := pid.UnmarshalText([]byte(p.Pid)); err != nil {
+		return nil, err
+	}
+	s.sentinel.PeerScorer().Reward(pid)
 	return &sentinelrpc.EmptyMessage{}, nil
 }
 
@@ -123,6 +151,11 @@ func (s *SentinelServer) PublishGossip(_ context.Context, msg *sentinelrpc.Gossi
 				return nil, errors.New("subnetId is required for blob sidecar")
 			}
 			subscription = manager.GetMatchingSubscription(gossip.TopicNameBlobSidecar(*msg.SubnetId))
+		case gossip.IsTopicDataColumnSidecar(msg.Name):
+			if msg.SubnetId == nil {
+				return nil, errors.New("subnetId is required for data column sidecar")
+			}
+			subscription = manager.GetMatchingSubscription(gossip.TopicNameDataColumnSidecar(*msg.SubnetId))
 		case gossip.IsTopicSyncCommittee(msg.Name):
 			if msg.SubnetId == nil {
 				return nil, errors.New("subnetId is required for sync_committee")
@@ -215,6 +248,7 @@ func (s *SentinelServer) requestPeer(ctx context.Context, pid peer.ID, req *sent
 	resp, err := httpreqresp.Do(s.sentinel.ReqRespHandler(), httpReq)
 	if err != nil {
 		// we remove, but dont ban the peer if we fail. this is because its probably not their fault, but maybe it is.
+		s.sentinel.PeerScorer().ReqRespFailed(pid)
 		return nil, err
 	}
 	defer resp.Body.Close()
@@ -222,6 +256,7 @@ func (s *SentinelServer) requestPeer(ctx context.Context, pid peer.ID, req *sent
 	if resp.StatusCode < 200 || resp.StatusCode > 399 {
 		errBody, _ := io.ReadAll(resp.Body)
 		errorMessage := fmt.Errorf("SentinelHttp: %s", string(errBody))
+		s.sentinel.PeerScorer().ReqRespFailed(pid)
 		s.sentinel.Peers().RemovePeer(pid)
 		s.sentinel.Host().Peerstore().RemovePeer(pid)
 		s.sentinel.Host().Network().ClosePeer(pid)
@@ -236,6 +271,7 @@ func (s *SentinelServer) requestPeer(ctx context.Context, pid peer.ID, req *sent
 	}
 	// known error codes, just remove the peer
 	if isError != 0 {
+		s.sentinel.PeerScorer().ReqRespFailed(pid)
 		s.sentinel.Peers().RemovePeer(pid)
 		s.sentinel.Host().Peerstore().RemovePeer(pid)
 		s.sentinel.Host().Network().ClosePeer(pid)
@@ -246,8 +282,10 @@ func (s *SentinelServer) requestPeer(ctx context.Context, pid peer.ID, req *sent
 	// read the body from the response
 	data, err := io.ReadAll(resp.Body)
 	if err != nil {
+		s.sentinel.PeerScorer().ReqRespFailed(pid)
 		return nil, err
 	}
+	s.sentinel.PeerScorer().ReqRespSucceeded(pid)
 	ans := &sentinelrpc.ResponseData{
 		Data:  data,
 		Error: isError != 0,
@@ -273,10 +311,7 @@ func (s *SentinelServer) SendRequest(ctx context.Context, req *sentinelrpc.Reque
 	resp, err := s.requestPeer(ctx, pid, req)
 	if err != nil {
 		if strings.Contains(err.Error(), "protocols not supported") {
-			s.sentinel.Peers().RemovePeer(pid)
-			s.sentinel.Host().Peerstore().RemovePeer(pid)
-			s.sentinel.Host().Network().ClosePeer(pid)
-			s.sentinel.Peers().SetBanStatus(pid, true)
+			s.sentinel.PeerScorer().Ban(pid)
 		}
 		s.logger.Trace("[sentinel] peer gave us bad data", "peer", pid, "err", err)
 		return nil, err
@@ -406,6 +441,7 @@ func (s *SentinelServer) handleGossipPacket(pkt *sentinel.GossipMessage) error {
 	default:
 		// case for:
 		// TopicNamePrefixBlobSidecar
+		// TopicNamePrefixDataColumnSidecar
 		// TopicNamePrefixBeaconAttestation
 		// TopicNamePrefixSyncCommittee
 		subnet := extractSubnetIndexByGossipTopic(gossipTopic)
@@ -23,10 +23,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/erigontech/erigon/cl/das"
 	"github.com/erigontech/erigon/cl/gossip"
 	"github.com/erigontech/erigon/cl/persistence/blob_storage"
 	"github.com/erigontech/erigon/cl/phase1/forkchoice"
 	"github.com/erigontech/erigon/cl/sentinel"
+	"github.com/erigontech/erigon/cl/sentinel/peers"
 	"github.com/erigontech/erigon/cl/utils/eth_clock"
 	"github.com/erigontech/erigon/common/math"
 	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
@@ -121,6 +123,15 @@ func createSentinel(
 			gossip.TopicNamePrefixSyncCommittee,
 			int(cfg.BeaconConfig.SyncCommitteeSubnetCount),
 		)...)
+	if cfg.BeaconConfig.Eip7594ForkEpoch != math.MaxUint64 {
+		// only listen to the data column subnets we custody.
+		for _, subnet := range das.CustodySubnets(cfg.BeaconConfig, sent.NodeID(), cfg.BeaconConfig.CustodyRequirement) {
+			gossipTopics = append(gossipTopics, sentinel.GossipTopic{
+				Name:     gossip.TopicNameDataColumnSidecar(subnet),
+				CodecStr: sentinel.SSZSnappyCodec,
+			})
+		}
+	}
 
 	for _, v := range gossipTopics {
 		if err := sent.Unsubscribe(v); err != nil {
@@ -145,7 +156,7 @@ func StartSentinelService(
 	srvCfg *ServerConfig,
 	ethClock eth_clock.EthereumClock,
 	forkChoiceReader forkchoice.ForkChoiceStorageReader,
-	logger log.Logger) (sentinelrpc.SentinelClient, error) {
+	logger log.Logger) (sentinelrpc.SentinelClient, *peers.Scorer, error) {
 	ctx := context.Background()
 	sent, err := createSentinel(
 		cfg,
@@ -157,7 +168,7 @@ func StartSentinelService(
 		logger,
 	)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	// rcmgrObs.MustRegisterWith(prometheus.DefaultRegisterer)
 	logger.Info("[Sentinel] Sentinel started", "enr", sent.String())
@@ -167,7 +178,7 @@ func StartSentinelService(
 	server := NewSentinelServer(ctx, sent, logger)
 	go StartServe(server, srvCfg, srvCfg.Creds)
 
-	return direct.NewSentinelClientDirect(server), nil
+	return direct.NewSentinelClientDirect(server), sent.PeerScorer(), nil
 }
 
 func StartServe(
@@ -48,7 +48,9 @@ func init() {
 		With("rewards_and_penalties", rewardsAndPenaltiesTest).
 		With("slashings", slashingsTest).
 		With("slashings_reset", slashingsResetTest).
-		With("participation_record_updates", participationRecordUpdatesTest)
+		With("participation_record_updates", participationRecordUpdatesTest).
+		With("pending_deposits", pendingDepositsTest).
+		With("pending_consolidations", pendingConsolidationsTest)
 	TestFormats.Add("finality").
 		With("finality", FinalityFinality)
 	TestFormats.Add("fork_choice").
@@ -76,7 +78,10 @@ func init() {
 		WithFn("voluntary_exit", operationVoluntaryExitHandler).
 		WithFn("sync_aggregate", operationSyncAggregateHandler).
 		WithFn("withdrawals", operationWithdrawalHandler).
-		WithFn("bls_to_execution-change", operationSignedBlsChangeHandler)
+		WithFn("bls_to_execution-change", operationSignedBlsChangeHandler).
+		WithFn("deposit_request", operationDepositRequestHandler).
+		WithFn("withdrawal_request", operationWithdrawalRequestHandler).
+		WithFn("consolidation_request", operationConsolidationRequestHandler)
 	TestFormats.Add("random").
 		With("random", SanityBlocks)
 	TestFormats.Add("rewards").
@@ -112,13 +117,16 @@ func addSszTests() {
 		With("BlobSidecar", getSSZStaticConsensusTest(&cltypes.BlobSidecar{})).
 		With("BLSToExecutionChange", getSSZStaticConsensusTest(&cltypes.BLSToExecutionChange{})).
 		With("Checkpoint", getSSZStaticConsensusTest(solid.Checkpoint{})).
+		With("ConsolidationRequest", getSSZStaticConsensusTest(&cltypes.ConsolidationRequest{})).
 		With("ContributionAndProof", getSSZStaticConsensusTest(&cltypes.ContributionAndProof{})).
 		With("Deposit", getSSZStaticConsensusTest(&cltypes.Deposit{})).
 		With("DepositData", getSSZStaticConsensusTest(&cltypes.DepositData{})).
+		With("DepositRequest", getSSZStaticConsensusTest(&cltypes.DepositRequest{})).
 		//	With("DepositMessage", getSSZStaticConsensusTest(&cltypes.DepositMessage{})).
 		// With("Eth1Block", getSSZStaticConsensusTest(&cltypes.Eth1Block{})).
 		With("Eth1Data", getSSZStaticConsensusTest(&cltypes.Eth1Data{})).
 		With("ExecutionPayload", getSSZStaticConsensusTest(cltypes.NewEth1Block(clparams.Phase0Version, &clparams.MainnetBeaconConfig))).
+		With("ExecutionRequests", getSSZStaticConsensusTest(cltypes.NewExecutionRequests(&clparams.MainnetBeaconConfig))).
 		//With("ExecutionPayloadHeader", getSSZStaticConsensusTest(&cltypes.Eth1Header{})).
 		With("Fork", getSSZStaticConsensusTest(&cltypes.Fork{})).
 		//With("ForkData", getSSZStaticConsensusTest(&cltypes.ForkData{})).
@@ -131,6 +139,9 @@ func addSszTests() {
 		With("LightClientOptimisticUpdate", getSSZStaticConsensusTest(&cltypes.LightClientOptimisticUpdate{})).
 		With("LightClientUpdate", getSSZStaticConsensusTest(&cltypes.LightClientUpdate{})).
 		With("PendingAttestation", getSSZStaticConsensusTest(&solid.PendingAttestation{})).
+		With("PendingConsolidation", getSSZStaticConsensusTest(&cltypes.PendingConsolidation{})).
+		With("PendingDeposit", getSSZStaticConsensusTest(&cltypes.PendingDeposit{})).
+		With("PendingPartialWithdrawal", getSSZStaticConsensusTest(&cltypes.PendingPartialWithdrawal{})).
 		//		With("PowBlock", getSSZStaticConsensusTest(&cltypes.PowBlock{})). Unimplemented
 		With("ProposerSlashing", getSSZStaticConsensusTest(&cltypes.ProposerSlashing{})).
 		With("SignedAggregateAndProof", getSSZStaticConsensusTest(&cltypes.SignedAggregateAndProof{})).
@@ -146,7 +157,8 @@ func addSszTests() {
 		With("SyncCommittee", getSSZStaticConsensusTest(&solid.SyncCommittee{})).
 		//	With("SyncCommitteeContribution", getSSZStaticConsensusTest(&cltypes.SyncCommitteeContribution{})).
 		//	With("SyncCommitteeMessage", getSSZStaticConsensusTest(&cltypes.SyncCommitteeMessage{})).
-		With("Validator", getSSZStaticConsensusTest(solid.NewValidator()))
+		With("Validator", getSSZStaticConsensusTest(solid.NewValidator())).
+		With("WithdrawalRequest", getSSZStaticConsensusTest(&cltypes.WithdrawalRequest{}))
 	// With("VoluntaryExit", getSSZStaticConsensusTest(&cltypes.VoluntaryExit{})) TODO
 	// With("Withdrawal", getSSZStaticConsensusTest(&types.Withdrawal{})) TODO
 }
@@ -97,6 +97,10 @@ var participationFlagUpdatesTest = NewEpochProcessing(func(s abstract.BeaconStat
 })
 var participationRecordUpdatesTest = NewEpochProcessing(statechange.ProcessParticipationRecordUpdates)
 
+var pendingDepositsTest = NewEpochProcessing(statechange.ProcessPendingDeposits)
+
+var pendingConsolidationsTest = NewEpochProcessing(statechange.ProcessPendingConsolidations)
+
 var randaoMixesTest = NewEpochProcessing(func(s abstract.BeaconState) error {
 	statechange.ProcessRandaoMixesReset(s)
 	return nil
@@ -191,6 +191,9 @@ func (I *impl) ProcessDeposit(s abstract.BeaconState, deposit *cltypes.Deposit)
 
 	// Increment index
 	s.SetEth1DepositIndex(depositIndex + 1)
+	if s.Version() >= clparams.ElectraVersion {
+		return applyDepositElectra(s, deposit.Data)
+	}
 	publicKey := deposit.Data.PubKey
 	amount := deposit.Data.Amount
 	// Check if pub key is in validator set
@@ -231,6 +234,27 @@ func (I *impl) ProcessDeposit(s abstract.BeaconState, deposit *cltypes.Deposit)
 	return state.IncreaseBalance(s, validatorIndex, amount)
 }
 
+// applyDepositElectra only registers new validators, the deposited amount is queued in pending deposits
+// and applied during epoch processing once the churn allows it (EIP-7251).
+func applyDepositElectra(s abstract.BeaconState, data *cltypes.DepositData) error {
+	if _, has := s.ValidatorIndexByPubkey(data.PubKey); !has {
+		valid, err := state.IsValidDepositSignature(s.BeaconConfig(), data.PubKey, data.WithdrawalCredentials, data.Amount, data.Signature)
+		if !valid || err != nil {
+			log.Debug("Validator BLS verification failed", "valid", valid, "err", err)
+			return nil
+		}
+		state.AddValidatorToRegistry(s, data.PubKey, data.WithdrawalCredentials, 0)
+	}
+	s.AppendPendingDeposit(&cltypes.PendingDeposit{
+		PubKey:                data.PubKey,
+		WithdrawalCredentials: data.WithdrawalCredentials,
+		Amount:                data.Amount,
+		Signature:             data.Signature,
+		Slot:                  s.BeaconConfig().GenesisSlot,
+	})
+	return nil
+}
+
 func IsVoluntaryExitApplicable(s abstract.BeaconState, voluntaryExit *cltypes.VoluntaryExit) error {
 	currentEpoch := state.Epoch(s)
 	validator, err := s.ValidatorForValidatorIndex(int(voluntaryExit.ValidatorIndex))
@@ -251,6 +275,9 @@ func IsVoluntaryExitApplicable(s abstract.BeaconState, voluntaryExit *cltypes.Vo
 	if currentEpoch < validator.ActivationEpoch()+s.BeaconConfig().ShardCommitteePeriod {
 		return errors.New("ProcessVoluntaryExit: exit is happening too fast")
 	}
+	if s.Version() >= clparams.ElectraVersion && state.GetPendingBalanceToWithdraw(s, voluntaryExit.ValidatorIndex) != 0 {
+		return errors.New("ProcessVoluntaryExit: validator has pending partial withdrawals in the queue")
+	}
 	return nil
 }
 
@@ -310,8 +337,15 @@ func (I *impl) ProcessWithdrawals(
 	numValidators := uint64(s.ValidatorLength())
 
 	// Check if full validation is required and verify expected withdrawals.
+	// Post-electra the number of processed pending partial withdrawals is needed as well.
+	var (
+		expectedWithdrawals              []*cltypes.Withdrawal
+		processedPartialWithdrawalsCount uint64
+	)
+	if I.FullValidation || s.Version() >= clparams.ElectraVersion {
+		expectedWithdrawals, processedPartialWithdrawalsCount = state.ExpectedWithdrawalsAndPartialsCount(s, state.Epoch(s))
+	}
 	if I.FullValidation {
-		expectedWithdrawals := state.ExpectedWithdrawals(s, state.Epoch(s))
 		if len(expectedWithdrawals) != withdrawals.Len() {
 			return fmt.Errorf(
 				"ProcessWithdrawals: expected %d withdrawals, but got %d",
@@ -338,6 +372,13 @@ func (I *impl) ProcessWithdrawals(
 		return err
 	}
 
+	// Remove the processed pending partial withdrawals from the queue.
+	if s.Version() >= clparams.ElectraVersion {
+		pendingPartialWithdrawals := s.PendingPartialWithdrawals()
+		pendingPartialWithdrawals.Cut(int(processedPartialWithdrawalsCount))
+		s.SetPendingPartialWithdrawals(pendingPartialWithdrawals)
+	}
+
 	// Update next withdrawal index based on number of withdrawals.
 	if withdrawals.Len() > 0 {
 		lastWithdrawalIndex := withdrawals.Get(withdrawals.Len() - 1).Index
@@ -594,7 +635,7 @@ func (I *impl) processAttestationPostAltair(
 
 	c = h.Tag("step", "get_attesting_indices")
 
-	attestingIndicies, err := s.GetAttestingIndicies(data, attestation.AggregationBits(), true)
+	attestingIndicies, err := s.GetAttestingIndiciesForAttestation(attestation, true)
 	if err != nil {
 		return nil, err
 	}
@@ -787,6 +828,22 @@ func IsAttestationApplicable(s abstract.BeaconState, attestation *solid.Attestat
 		data.Slot()+beaconConfig.MinAttestationInclusionDelay > stateSlot {
 		return errors.New("ProcessAttestation: attestation slot not in range")
 	}
+	if s.Version() >= clparams.ElectraVersion {
+		// Post-electra the committees are set in the committee bits (EIP-7549).
+		if !attestation.IsElectra() {
+			return errors.New("ProcessAttestation: missing committee bits")
+		}
+		if data.CommitteeIndex() != 0 {
+			return errors.New("ProcessAttestation: attestation data index must be 0")
+		}
+		committeeCount := s.CommitteeCount(data.Target().Epoch())
+		for _, committeeIndex := range attestation.CommitteeIndices() {
+			if committeeIndex >= committeeCount {
+				return errors.New("ProcessAttestation: committee index out of range")
+			}
+		}
+		return nil
+	}
 	if data.CommitteeIndex() >= s.CommitteeCount(data.Target().Epoch()) {
 		return errors.New("ProcessAttestation: attester index out of range")
 	}
@@ -1027,6 +1084,191 @@ func (I *impl) ProcessSlots(s abstract.BeaconState, slot uint64) error {
 				return err
 			}
 		}
+		if state.Epoch(s) == beaconConfig.ElectraForkEpoch {
+			if err := s.UpgradeToElectra(); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
+
+// ProcessDepositRequest queues a deposit coming from the execution layer (EIP-6110).
+func (I *impl) ProcessDepositRequest(s abstract.BeaconState, depositRequest *cltypes.DepositRequest) error {
+	// Set deposit request start index
+	if s.DepositRequestsStartIndex() == s.BeaconConfig().UnsetDepositRequestsStartIndex {
+		s.SetDepositRequestsStartIndex(depositRequest.Index)
+	}
+	s.AppendPendingDeposit(&cltypes.PendingDeposit{
+		PubKey:                depositRequest.PubKey,
+		WithdrawalCredentials: depositRequest.WithdrawalCredentials,
+		Amount:                depositRequest.Amount,
+		Signature:             depositRequest.Signature,
+		Slot:                  s.Slot(),
+	})
+	return nil
+}
+
+// ProcessWithdrawalRequest processes a withdrawal triggered from the execution layer (EIP-7002).
+// Invalid requests are ignored rather than invalidating the block.
+func (I *impl) ProcessWithdrawalRequest(s abstract.BeaconState, withdrawalRequest *cltypes.WithdrawalRequest) error {
+	beaconConfig := s.BeaconConfig()
+	amount := withdrawalRequest.Amount
+	isFullExitRequest := amount == beaconConfig.FullExitRequestAmount
+	// If partial withdrawal queue is full, only full exits are processed
+	if uint64(s.PendingPartialWithdrawals().Len()) == beaconConfig.PendingPartialWithdrawalsLimit && !isFullExitRequest {
+		return nil
+	}
+	// Verify pubkey exists
+	validatorIndex, has := s.ValidatorIndexByPubkey(withdrawalRequest.ValidatorPubKey)
+	if !has {
+		return nil
+	}
+	validator, err := s.ValidatorForValidatorIndex(int(validatorIndex))
+	if err != nil {
+		return err
+	}
+	// Verify withdrawal credentials
+	wc := validator.WithdrawalCredentials()
+	if !state.HasExecutionWithdrawalCredential(beaconConfig, validator) || !bytes.Equal(wc[12:], withdrawalRequest.SourceAddress[:]) {
+		return nil
+	}
+	currentEpoch := state.Epoch(s)
+	// Verify the validator is active, its exit has not been initiated and it has been active long enough
+	if !validator.Active(currentEpoch) || validator.ExitEpoch() != beaconConfig.FarFutureEpoch ||
+		currentEpoch < validator.ActivationEpoch()+beaconConfig.ShardCommitteePeriod {
+		return nil
+	}
+
+	pendingBalanceToWithdraw := state.GetPendingBalanceToWithdraw(s, validatorIndex)
+	if isFullExitRequest {
+		// Only exit validator if it has no pending withdrawals in the queue
+		if pendingBalanceToWithdraw == 0 {
+			return s.InitiateValidatorExit(validatorIndex)
+		}
+		return nil
+	}
+
+	balance, err := s.ValidatorBalance(int(validatorIndex))
+	if err != nil {
+		return err
+	}
+	hasSufficientEffectiveBalance := validator.EffectiveBalance() >= beaconConfig.MinActivationBalance
+	hasExcessBalance := balance > beaconConfig.MinActivationBalance+pendingBalanceToWithdraw
+	// Only allow partial withdrawals with compounding withdrawal credentials
+	if state.HasCompoundingWithdrawalCredential(beaconConfig, validator) && hasSufficientEffectiveBalance && hasExcessBalance {
+		toWithdraw := min(balance-beaconConfig.MinActivationBalance-pendingBalanceToWithdraw, amount)
+		exitQueueEpoch := state.ComputeExitEpochAndUpdateChurn(s, toWithdraw)
+		s.AppendPendingPartialWithdrawal(&cltypes.PendingPartialWithdrawal{
+			ValidatorIndex:    validatorIndex,
+			Amount:            toWithdraw,
+			WithdrawableEpoch: exitQueueEpoch + beaconConfig.MinValidatorWithdrawabilityDelay,
+		})
+	}
+	return nil
+}
+
+// ProcessConsolidationRequest processes a consolidation triggered from the execution layer (EIP-7251), a request with
+// the same source and target switches the validator to compounding credentials. Invalid requests are ignored.
+func (I *impl) ProcessConsolidationRequest(s abstract.BeaconState, consolidationRequest *cltypes.ConsolidationRequest) error {
+	beaconConfig := s.BeaconConfig()
+	if sourceIndex, ok := isValidSwitchToCompoundingRequest(s, consolidationRequest); ok {
+		return state.SwitchToCompoundingValidator(s, sourceIndex)
+	}
+	// Verify that source != target, so a consolidation cannot be used as an exit
+	if consolidationRequest.SourcePubKey == consolidationRequest.TargetPubKey {
+		return nil
+	}
+	// If the pending consolidations queue is full, consolidation requests are ignored
+	if uint64(s.PendingConsolidations().Len()) == beaconConfig.PendingConsolidationsLimit {
+		return nil
+	}
+	// If there is too little available consolidation churn limit, consolidation requests are ignored
+	if s.GetConsolidationChurnLimit() <= beaconConfig.MinActivationBalance {
+		return nil
+	}
+	// Verify pubkeys exists
+	sourceIndex, has := s.ValidatorIndexByPubkey(consolidationRequest.SourcePubKey)
+	if !has {
+		return nil
+	}
+	targetIndex, has := s.ValidatorIndexByPubkey(consolidationRequest.TargetPubKey)
+	if !has {
+		return nil
+	}
+	sourceValidator, err := s.ValidatorForValidatorIndex(int(sourceIndex))
+	if err != nil {
+		return err
+	}
+	targetValidator, err := s.ValidatorForValidatorIndex(int(targetIndex))
+	if err != nil {
+		return err
+	}
+	// Verify source withdrawal credentials
+	wc := sourceValidator.WithdrawalCredentials()
+	if !state.HasExecutionWithdrawalCredential(beaconConfig, sourceValidator) || !bytes.Equal(wc[12:], consolidationRequest.SourceAddress[:]) {
+		return nil
+	}
+	// Verify that target has compounding withdrawal credentials
+	if !state.HasCompoundingWithdrawalCredential(beaconConfig, targetValidator) {
+		return nil
+	}
+	currentEpoch := state.Epoch(s)
+	// Verify the source and the target are active and their exits have not been initiated
+	if !sourceValidator.Active(currentEpoch) || !targetValidator.Active(currentEpoch) ||
+		sourceValidator.ExitEpoch() != beaconConfig.FarFutureEpoch || targetValidator.ExitEpoch() != beaconConfig.FarFutureEpoch {
+		return nil
+	}
+	// Verify the source has been active long enough
+	if currentEpoch < sourceValidator.ActivationEpoch()+beaconConfig.ShardCommitteePeriod {
+		return nil
+	}
+	// Verify the source has no pending withdrawals in the queue
+	if state.GetPendingBalanceToWithdraw(s, sourceIndex) > 0 {
+		return nil
+	}
+
+	// Initiate source validator exit and append pending consolidation
+	exitEpoch := state.ComputeConsolidationEpochAndUpdateChurn(s, sourceValidator.EffectiveBalance())
+	s.SetExitEpochForValidatorAtIndex(int(sourceIndex), exitEpoch)
+	if err := s.SetWithdrawableEpochForValidatorAtIndex(int(sourceIndex), exitEpoch+beaconConfig.MinValidatorWithdrawabilityDelay); err != nil {
+		return err
+	}
+	s.AppendPendingConsolidation(&cltypes.PendingConsolidation{
+		SourceIndex: sourceIndex,
+		TargetIndex: targetIndex,
+	})
+	return nil
+}
+
+// isValidSwitchToCompoundingRequest implements is_valid_switch_to_compounding_request and returns the source validator index.
+func isValidSwitchToCompoundingRequest(s abstract.BeaconState, consolidationRequest *cltypes.ConsolidationRequest) (uint64, bool) {
+	beaconConfig := s.BeaconConfig()
+	// Switch to compounding requires source and target be equal
+	if consolidationRequest.SourcePubKey != consolidationRequest.TargetPubKey {
+		return 0, false
+	}
+	// Verify pubkey exists
+	sourceIndex, has := s.ValidatorIndexByPubkey(consolidationRequest.SourcePubKey)
+	if !has {
+		return 0, false
+	}
+	sourceValidator, err := s.ValidatorForValidatorIndex(int(sourceIndex))
+	if err != nil {
+		return 0, false
+	}
+	// Verify request has been authorized
+	wc := sourceValidator.WithdrawalCredentials()
+	if !bytes.Equal(wc[12:], consolidationRequest.SourceAddress[:]) {
+		return 0, false
+	}
+	// Verify source withdrawal credentials
+	if !state.HasEth1WithdrawalCredential(beaconConfig, sourceValidator) {
+		return 0, false
+	}
+	// Verify the source is active and its exit has not been initiated
+	if !sourceValidator.Active(state.Epoch(s)) || sourceValidator.ExitEpoch() != beaconConfig.FarFutureEpoch {
+		return 0, false
+	}
+	return sourceIndex, true
+}
@@ -0,0 +1,122 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package statechange
+
+import (
+	"github.com/erigontech/erigon/cl/abstract"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/phase1/core/state"
+)
+
+// ProcessPendingDeposits applies the pending deposits within the activation churn (EIP-7251).
+// Specs at: https://github.com/ethereum/consensus-specs/blob/dev/specs/electra/beacon-chain.md#new-process_pending_deposits
+func ProcessPendingDeposits(s abstract.BeaconState) error {
+	beaconConfig := s.BeaconConfig()
+	nextEpoch := state.Epoch(s) + 1
+	availableForProcessing := s.DepositBalanceToConsume() + s.GetActivationExitChurnLimit()
+	finalizedSlot := s.FinalizedCheckpoint().Epoch() * beaconConfig.SlotsPerEpoch
+
+	var (
+		processedAmount     uint64
+		nextDepositIndex    int
+		depositsToPostpone  []*cltypes.PendingDeposit
+		isChurnLimitReached bool
+		err                 error
+	)
+	s.PendingDeposits().Range(func(_ int, deposit *cltypes.PendingDeposit, _ int) bool {
+		// Do not process deposit requests if Eth1 bridge deposits are not yet applied.
+		if deposit.Slot > beaconConfig.GenesisSlot && s.Eth1DepositIndex() < s.DepositRequestsStartIndex() {
+			return false
+		}
+		// Check if deposit has been finalized, otherwise, stop processing.
+		if deposit.Slot > finalizedSlot {
+			return false
+		}
+		// Check if number of processed deposits has not reached the limit, otherwise, stop processing.
+		if uint64(nextDepositIndex) >= beaconConfig.MaxPendingDepositsPerEpoch {
+			return false
+		}
+
+		var isValidatorExited, isValidatorWithdrawn bool
+		if validatorIndex, has := s.ValidatorIndexByPubkey(deposit.PubKey); has {
+			validator, verr := s.ValidatorForValidatorIndex(int(validatorIndex))
+			if verr != nil {
+				err = verr
+				return false
+			}
+			isValidatorExited = validator.ExitEpoch() < beaconConfig.FarFutureEpoch
+			isValidatorWithdrawn = validator.WithdrawableEpoch() < nextEpoch
+		}
+
+		if isValidatorWithdrawn {
+			// Deposited balance will never become active. Increase balance but do not consume churn.
+			if err = applyPendingDeposit(s, deposit); err != nil {
+				return false
+			}
+		} else if isValidatorExited {
+			// Validator is exiting, postpone the deposit until after withdrawable epoch.
+			depositsToPostpone = append(depositsToPostpone, deposit)
+		} else {
+			// Check if deposit fits in the churn, otherwise, do no more deposit processing in this epoch.
+			isChurnLimitReached = processedAmount+deposit.Amount > availableForProcessing
+			if isChurnLimitReached {
+				return false
+			}
+			// Consume churn and apply deposit.
+			processedAmount += deposit.Amount
+			if err = applyPendingDeposit(s, deposit); err != nil {
+				return false
+			}
+		}
+		// Regardless of how the deposit was handled, we move on in the queue.
+		nextDepositIndex++
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	pendingDeposits := s.PendingDeposits()
+	pendingDeposits.Cut(nextDepositIndex)
+	for _, deposit := range depositsToPostpone {
+		pendingDeposits.Append(deposit)
+	}
+	s.SetPendingDeposits(pendingDeposits)
+
+	// Accumulate churn only if the churn limit has been hit.
+	if isChurnLimitReached {
+		s.SetDepositBalanceToConsume(availableForProcessing - processedAmount)
+	} else {
+		s.SetDepositBalanceToConsume(0)
+	}
+	return nil
+}
+
+// applyPendingDeposit adds the validator to the registry if it does not exist yet, or increases its balance.
+func applyPendingDeposit(s abstract.BeaconState, deposit *cltypes.PendingDeposit) error {
+	validatorIndex, has := s.ValidatorIndexByPubkey(deposit.PubKey)
+	if has {
+		return state.IncreaseBalance(s, validatorIndex, deposit.Amount)
+	}
+	valid, err := state.IsValidDepositSignature(s.BeaconConfig(), deposit.PubKey, deposit.WithdrawalCredentials, deposit.Amount, deposit.Signature)
+	if err != nil || !valid {
+		// Invalid signatures are not an error, the deposit is just dropped.
+		return nil
+	}
+	state.AddValidatorToRegistry(s, deposit.PubKey, deposit.WithdrawalCredentials, deposit.Amount)
+	return nil
+}
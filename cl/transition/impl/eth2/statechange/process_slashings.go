@@ -36,6 +36,12 @@ func processSlashings(s abstract.BeaconState, slashingMultiplier uint64) error {
 		slashing = totalBalance
 	}
 	beaconConfig := s.BeaconConfig()
+	// Post-electra the penalty is computed per effective balance increment, to avoid rounding to zero with high balances.
+	isElectra := s.Version() >= clparams.ElectraVersion
+	var penaltyPerEffectiveBalanceIncrement uint64
+	if isElectra {
+		penaltyPerEffectiveBalanceIncrement = slashing / (totalBalance / beaconConfig.EffectiveBalanceIncrement)
+	}
 	// Apply penalties to validators who have been slashed and reached the withdrawable epoch
 	var err error
 	s.ForEachValidator(func(validator solid.Validator, i, total int) bool {
@@ -44,10 +50,15 @@ func processSlashings(s abstract.BeaconState, slashingMultiplier uint64) error {
 		}
 		// Get the effective balance increment
 		increment := beaconConfig.EffectiveBalanceIncrement
-		// Calculate the penalty numerator by multiplying the validator's effective balance by the total slashing amount
-		penaltyNumerator := validator.EffectiveBalance() / increment * slashing
-		// Calculate the penalty by dividing the penalty numerator by the total balance and multiplying by the increment
-		penalty := penaltyNumerator / totalBalance * increment
+		var penalty uint64
+		if isElectra {
+			penalty = penaltyPerEffectiveBalanceIncrement * (validator.EffectiveBalance() / increment)
+		} else {
+			// Calculate the penalty numerator by multiplying the validator's effective balance by the total slashing amount
+			penaltyNumerator := validator.EffectiveBalance() / increment * slashing
+			// Calculate the penalty by dividing the penalty numerator by the total balance and multiplying by the increment
+			penalty = penaltyNumerator / totalBalance * increment
+		}
 		// Decrease the validator's balance by the calculated penalty
 		if err = state.DecreaseBalance(s, uint64(i), penalty); err != nil {
 			return false
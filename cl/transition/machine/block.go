@@ -157,11 +157,51 @@ func ProcessOperations(impl BlockOperationProcessor, s abstract.BeaconState, blo
 	}); err != nil {
 		return err
 	}
+	if s.Version() < clparams.ElectraVersion {
+		return nil
+	}
+	// Process the requests of the execution layer. this will only have entries after the electra fork.
+	executionRequests := blockBody.GetExecutionRequests()
+	if executionRequests == nil {
+		return errors.New("missing execution requests")
+	}
+	if err := solid.RangeErr[*cltypes.DepositRequest](executionRequests.Deposits, func(index int, depositRequest *cltypes.DepositRequest, length int) error {
+		if err := impl.ProcessDepositRequest(s, depositRequest); err != nil {
+			return fmt.Errorf("ProcessDepositRequest: %s", err)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := solid.RangeErr[*cltypes.WithdrawalRequest](executionRequests.Withdrawals, func(index int, withdrawalRequest *cltypes.WithdrawalRequest, length int) error {
+		if err := impl.ProcessWithdrawalRequest(s, withdrawalRequest); err != nil {
+			return fmt.Errorf("ProcessWithdrawalRequest: %s", err)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if err := solid.RangeErr[*cltypes.ConsolidationRequest](executionRequests.Consolidations, func(index int, consolidationRequest *cltypes.ConsolidationRequest, length int) error {
+		if err := impl.ProcessConsolidationRequest(s, consolidationRequest); err != nil {
+			return fmt.Errorf("ProcessConsolidationRequest: %s", err)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
 func maximumDeposits(s abstract.BeaconState) (maxDeposits uint64) {
-	maxDeposits = s.Eth1Data().DepositCount - s.Eth1DepositIndex()
+	depositIndexLimit := s.Eth1Data().DepositCount
+	// Post-electra, deposits of the eth1 bridge stop once deposit requests take over (EIP-6110).
+	if s.Version() >= clparams.ElectraVersion {
+		depositIndexLimit = min(depositIndexLimit, s.DepositRequestsStartIndex())
+		if s.Eth1DepositIndex() >= depositIndexLimit {
+			return 0
+		}
+	}
+	maxDeposits = depositIndexLimit - s.Eth1DepositIndex()
 	if maxDeposits > s.BeaconConfig().MaxDeposits {
 		maxDeposits = s.BeaconConfig().MaxDeposits
 	}
@@ -0,0 +1,342 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package keystore implements the EIP-2335 BLS12-381 keystore format used by consensus layer validators.
+package keystore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Giulio2002/bls"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	Version = 4
+
+	KdfScrypt = "scrypt"
+	KdfPbkdf2 = "pbkdf2"
+
+	checksumSha256   = "sha256"
+	cipherAes128Ctr  = "aes-128-ctr"
+	prfHmacSha256    = "hmac-sha256"
+	decryptionKeyLen = 32
+)
+
+const (
+	// StandardScryptN is the scrypt work factor recommended by EIP-2335.
+	StandardScryptN = 1 << 18
+	// LightScryptN is a much cheaper work factor, meant for tests and throwaway keys only.
+	LightScryptN = 1 << 12
+)
+
+var (
+	ErrInvalidPassword = errors.New("keystore: invalid password")
+	ErrPubkeyMismatch  = errors.New("keystore: decrypted secret does not match pubkey")
+)
+
+// Module is one of the kdf, checksum or cipher modules of the keystore crypto section.
+type Module struct {
+	Function string          `json:"function"`
+	Params   json.RawMessage `json:"params"`
+	Message  string          `json:"message"`
+}
+
+type Crypto struct {
+	Kdf      Module `json:"kdf"`
+	Checksum Module `json:"checksum"`
+	Cipher   Module `json:"cipher"`
+}
+
+// Keystore is the json representation of an EIP-2335 keystore.
+type Keystore struct {
+	Crypto      Crypto `json:"crypto"`
+	Description string `json:"description"`
+	Pubkey      string `json:"pubkey"`
+	Path        string `json:"path"`
+	UUID        string `json:"uuid"`
+	Version     uint   `json:"version"`
+}
+
+type scryptParams struct {
+	Dklen int    `json:"dklen"`
+	N     int    `json:"n"`
+	P     int    `json:"p"`
+	R     int    `json:"r"`
+	Salt  string `json:"salt"`
+}
+
+type pbkdf2Params struct {
+	Dklen int    `json:"dklen"`
+	C     int    `json:"c"`
+	Prf   string `json:"prf"`
+	Salt  string `json:"salt"`
+}
+
+type cipherParams struct {
+	IV string `json:"iv"`
+}
+
+// ReadFile parses the keystore stored at path.
+func ReadFile(path string) (*Keystore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	ks := &Keystore{}
+	if err := json.Unmarshal(data, ks); err != nil {
+		return nil, fmt.Errorf("keystore %s: %w", path, err)
+	}
+	if ks.Version != Version {
+		return nil, fmt.Errorf("keystore %s: unsupported version %d", path, ks.Version)
+	}
+	return ks, nil
+}
+
+// WriteFile stores the keystore at path, readable by the owner only.
+func (k *Keystore) WriteFile(path string) error {
+	data, err := json.MarshalIndent(k, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// PublicKey returns the decoded pubkey field.
+func (k *Keystore) PublicKey() ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(k.Pubkey, "0x"))
+}
+
+// Decrypt recovers the BLS secret key protected by password.
+func (k *Keystore) Decrypt(password string) ([]byte, error) {
+	decryptionKey, err := k.decryptionKey(processPassword(password))
+	if err != nil {
+		return nil, err
+	}
+	cipherMessage, err := hex.DecodeString(k.Crypto.Cipher.Message)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: invalid cipher message: %w", err)
+	}
+	if k.Crypto.Checksum.Function != checksumSha256 {
+		return nil, fmt.Errorf("keystore: unsupported checksum function %q", k.Crypto.Checksum.Function)
+	}
+	expectedChecksum, err := hex.DecodeString(k.Crypto.Checksum.Message)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: invalid checksum message: %w", err)
+	}
+	if !bytes.Equal(checksum(decryptionKey, cipherMessage), expectedChecksum) {
+		return nil, ErrInvalidPassword
+	}
+	if k.Crypto.Cipher.Function != cipherAes128Ctr {
+		return nil, fmt.Errorf("keystore: unsupported cipher function %q", k.Crypto.Cipher.Function)
+	}
+	var params cipherParams
+	if err := json.Unmarshal(k.Crypto.Cipher.Params, &params); err != nil {
+		return nil, fmt.Errorf("keystore: invalid cipher params: %w", err)
+	}
+	iv, err := hex.DecodeString(params.IV)
+	if err != nil {
+		return nil, fmt.Errorf("keystore: invalid cipher iv: %w", err)
+	}
+	secret, err := aes128Ctr(decryptionKey[:16], iv, cipherMessage)
+	if err != nil {
+		return nil, err
+	}
+	// the pubkey field is optional, but if it is there it has to match.
+	if k.Pubkey != "" {
+		expectedPubkey, err := k.PublicKey()
+		if err != nil {
+			return nil, fmt.Errorf("keystore: invalid pubkey: %w", err)
+		}
+		privateKey, err := bls.NewPrivateKeyFromBytes(secret)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(bls.CompressPublicKey(privateKey.PublicKey()), expectedPubkey) {
+			return nil, ErrPubkeyMismatch
+		}
+	}
+	return secret, nil
+}
+
+func (k *Keystore) decryptionKey(password []byte) ([]byte, error) {
+	switch k.Crypto.Kdf.Function {
+	case KdfScrypt:
+		var params scryptParams
+		if err := json.Unmarshal(k.Crypto.Kdf.Params, &params); err != nil {
+			return nil, fmt.Errorf("keystore: invalid scrypt params: %w", err)
+		}
+		salt, err := hex.DecodeString(params.Salt)
+		if err != nil {
+			return nil, fmt.Errorf("keystore: invalid salt: %w", err)
+		}
+		if params.Dklen < decryptionKeyLen {
+			return nil, fmt.Errorf("keystore: dklen %d is too short", params.Dklen)
+		}
+		return scrypt.Key(password, salt, params.N, params.R, params.P, params.Dklen)
+	case KdfPbkdf2:
+		var params pbkdf2Params
+		if err := json.Unmarshal(k.Crypto.Kdf.Params, &params); err != nil {
+			return nil, fmt.Errorf("keystore: invalid pbkdf2 params: %w", err)
+		}
+		if params.Prf != prfHmacSha256 {
+			return nil, fmt.Errorf("keystore: unsupported pbkdf2 prf %q", params.Prf)
+		}
+		salt, err := hex.DecodeString(params.Salt)
+		if err != nil {
+			return nil, fmt.Errorf("keystore: invalid salt: %w", err)
+		}
+		if params.Dklen < decryptionKeyLen {
+			return nil, fmt.Errorf("keystore: dklen %d is too short", params.Dklen)
+		}
+		return pbkdf2.Key(password, salt, params.C, params.Dklen, sha256.New), nil
+	default:
+		return nil, fmt.Errorf("keystore: unsupported kdf function %q", k.Crypto.Kdf.Function)
+	}
+}
+
+// Encrypt protects secret with password, using scrypt with work factor scryptN as kdf.
+func Encrypt(secret []byte, password string, scryptN int) (*Keystore, error) {
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	params, err := json.Marshal(scryptParams{Dklen: decryptionKeyLen, N: scryptN, P: 1, R: 8, Salt: hex.EncodeToString(salt)})
+	if err != nil {
+		return nil, err
+	}
+	return encrypt(secret, password, Module{Function: KdfScrypt, Params: params})
+}
+
+func encrypt(secret []byte, password string, kdf Module) (*Keystore, error) {
+	privateKey, err := bls.NewPrivateKeyFromBytes(secret)
+	if err != nil {
+		return nil, err
+	}
+	ks := &Keystore{
+		Crypto:  Crypto{Kdf: kdf},
+		Pubkey:  hex.EncodeToString(bls.CompressPublicKey(privateKey.PublicKey())),
+		Version: Version,
+	}
+	if ks.UUID, err = newUUID(); err != nil {
+		return nil, err
+	}
+	decryptionKey, err := ks.decryptionKey(processPassword(password))
+	if err != nil {
+		return nil, err
+	}
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, err
+	}
+	cipherMessage, err := aes128Ctr(decryptionKey[:16], iv, secret)
+	if err != nil {
+		return nil, err
+	}
+	cipherParams, err := json.Marshal(cipherParams{IV: hex.EncodeToString(iv)})
+	if err != nil {
+		return nil, err
+	}
+	ks.Crypto.Cipher = Module{Function: cipherAes128Ctr, Params: cipherParams, Message: hex.EncodeToString(cipherMessage)}
+	ks.Crypto.Checksum = Module{Function: checksumSha256, Params: json.RawMessage("{}"), Message: hex.EncodeToString(checksum(decryptionKey, cipherMessage))}
+	return ks, nil
+}
+
+// LoadDir decrypts every *.json keystore in dir with the same password and returns the secret keys.
+func LoadDir(dir string, password string) ([][]byte, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	secrets := make([][]byte, 0, len(paths))
+	for _, path := range paths {
+		ks, err := ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		secret, err := ks.Decrypt(password)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		secrets = append(secrets, secret)
+	}
+	return secrets, nil
+}
+
+// ReadPasswordFile reads a password file, ignoring the trailing newline.
+func ReadPasswordFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// processPassword applies the EIP-2335 password normalization: NFKD, then strip the C0, C1 and Delete control codes.
+func processPassword(password string) []byte {
+	normalized := norm.NFKD.String(password)
+	out := make([]byte, 0, len(normalized))
+	for _, r := range normalized {
+		if r <= 0x1f || (r >= 0x7f && r <= 0x9f) {
+			continue
+		}
+		out = append(out, string(r)...)
+	}
+	return out
+}
+
+func checksum(decryptionKey, cipherMessage []byte) []byte {
+	h := sha256.New()
+	h.Write(decryptionKey[16:32])
+	h.Write(cipherMessage)
+	return h.Sum(nil)
+}
+
+func aes128Ctr(key, iv, in []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(iv) != aes.BlockSize {
+		return nil, fmt.Errorf("keystore: invalid iv length %d", len(iv))
+	}
+	out := make([]byte, len(in))
+	cipher.NewCTR(block, iv).XORKeyStream(out, in)
+	return out, nil
+}
+
+func newUUID() (string, error) {
+	var u [16]byte
+	if _, err := rand.Read(u[:]); err != nil {
+		return "", err
+	}
+	u[6] = (u[6] & 0x0f) | 0x40 // version 4
+	u[8] = (u[8] & 0x3f) | 0x80 // variant 10
+	return fmt.Sprintf("%x-%x-%x-%x-%x", u[0:4], u[4:6], u[6:8], u[8:10], u[10:]), nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package keystore
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/Giulio2002/bls"
+	"github.com/stretchr/testify/require"
+)
+
+func generateSecret(t *testing.T) []byte {
+	privateKey, err := bls.GenerateKey()
+	require.NoError(t, err)
+	return privateKey.Bytes()
+}
+
+func TestKeystoreScrypt(t *testing.T) {
+	secret := generateSecret(t)
+	ks, err := Encrypt(secret, "testpassword", LightScryptN)
+	require.NoError(t, err)
+
+	decrypted, err := ks.Decrypt("testpassword")
+	require.NoError(t, err)
+	require.Equal(t, secret, decrypted)
+
+	_, err = ks.Decrypt("wrongpassword")
+	require.ErrorIs(t, err, ErrInvalidPassword)
+}
+
+func TestKeystorePbkdf2(t *testing.T) {
+	secret := generateSecret(t)
+	params, err := json.Marshal(pbkdf2Params{Dklen: 32, C: 1024, Prf: prfHmacSha256, Salt: "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"})
+	require.NoError(t, err)
+	ks, err := encrypt(secret, "testpassword", Module{Function: KdfPbkdf2, Params: params})
+	require.NoError(t, err)
+
+	decrypted, err := ks.Decrypt("testpassword")
+	require.NoError(t, err)
+	require.Equal(t, secret, decrypted)
+}
+
+func TestKeystorePasswordNormalization(t *testing.T) {
+	secret := generateSecret(t)
+	ks, err := Encrypt(secret, "pass\x7fword\n", LightScryptN)
+	require.NoError(t, err)
+	// control codes are stripped before the kdf
+	decrypted, err := ks.Decrypt("password")
+	require.NoError(t, err)
+	require.Equal(t, secret, decrypted)
+	// NFKD: the "fi" ligature decomposes to "fi"
+	ks, err = Encrypt(secret, "ﬁle", LightScryptN)
+	require.NoError(t, err)
+	_, err = ks.Decrypt("file")
+	require.NoError(t, err)
+}
+
+func TestKeystorePubkeyMismatch(t *testing.T) {
+	ks, err := Encrypt(generateSecret(t), "testpassword", LightScryptN)
+	require.NoError(t, err)
+	other, err := Encrypt(generateSecret(t), "testpassword", LightScryptN)
+	require.NoError(t, err)
+	ks.Pubkey = other.Pubkey
+	_, err = ks.Decrypt("testpassword")
+	require.ErrorIs(t, err, ErrPubkeyMismatch)
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	secrets := [][]byte{generateSecret(t), generateSecret(t)}
+	for i, secret := range secrets {
+		ks, err := Encrypt(secret, "testpassword", LightScryptN)
+		require.NoError(t, err)
+		require.NoError(t, ks.WriteFile(filepath.Join(dir, "keystore-"+string(rune('a'+i))+".json")))
+	}
+	loaded, err := LoadDir(dir, "testpassword")
+	require.NoError(t, err)
+	require.ElementsMatch(t, secrets, loaded)
+
+	_, err = LoadDir(dir, "wrongpassword")
+	require.ErrorIs(t, err, ErrInvalidPassword)
+}
@@ -0,0 +1,182 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package slashing_protection
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+)
+
+// InterchangeFormatVersion is the EIP-3076 interchange format we read and write.
+const InterchangeFormatVersion = "5"
+
+type Interchange struct {
+	Metadata InterchangeMetadata `json:"metadata"`
+	Data     []InterchangeData   `json:"data"`
+}
+
+type InterchangeMetadata struct {
+	InterchangeFormatVersion string         `json:"interchange_format_version"`
+	GenesisValidatorsRoot    libcommon.Hash `json:"genesis_validators_root"`
+}
+
+type InterchangeData struct {
+	Pubkey             libcommon.Bytes48   `json:"pubkey"`
+	SignedBlocks       []SignedBlock       `json:"signed_blocks"`
+	SignedAttestations []SignedAttestation `json:"signed_attestations"`
+}
+
+type SignedBlock struct {
+	Slot        uint64          `json:"slot,string"`
+	SigningRoot *libcommon.Hash `json:"signing_root,omitempty"`
+}
+
+type SignedAttestation struct {
+	SourceEpoch uint64          `json:"source_epoch,string"`
+	TargetEpoch uint64          `json:"target_epoch,string"`
+	SigningRoot *libcommon.Hash `json:"signing_root,omitempty"`
+}
+
+// Import merges an interchange file into the database. Conflicting records are merged conservatively: the signing
+// root is forgotten, so that neither message can be signed again.
+func (s *SlashingProtection) Import(ctx context.Context, r io.Reader) error {
+	var interchange Interchange
+	if err := json.NewDecoder(r).Decode(&interchange); err != nil {
+		return fmt.Errorf("slashing protection: invalid interchange file: %w", err)
+	}
+	if interchange.Metadata.InterchangeFormatVersion != InterchangeFormatVersion {
+		return fmt.Errorf("slashing protection: unsupported interchange format version %q", interchange.Metadata.InterchangeFormatVersion)
+	}
+	if interchange.Metadata.GenesisValidatorsRoot != s.genesisValidatorsRoot {
+		return fmt.Errorf("%w: interchange has %x, chain has %x", ErrGenesisValidatorsRootMismatch, interchange.Metadata.GenesisValidatorsRoot, s.genesisValidatorsRoot)
+	}
+	return s.db.Update(ctx, func(tx kv.RwTx) error {
+		for _, data := range interchange.Data {
+			for _, block := range data.SignedBlocks {
+				if err := importRecord(tx, signedBlocksTable, blockKey(data.Pubkey, block.Slot), block.SigningRoot); err != nil {
+					return err
+				}
+			}
+			for _, attestation := range data.SignedAttestations {
+				if attestation.SourceEpoch > attestation.TargetEpoch {
+					return fmt.Errorf("slashing protection: invalid attestation %d=>%d for %x", attestation.SourceEpoch, attestation.TargetEpoch, data.Pubkey)
+				}
+				key := attestationKey(data.Pubkey, attestation.TargetEpoch, attestation.SourceEpoch)
+				if err := importRecord(tx, signedAttestationsTable, key, attestation.SigningRoot); err != nil {
+					return err
+				}
+			}
+			// complete interchange files may have the whole history of the validator
+			if err := pruneBlocks(tx, data.Pubkey); err != nil {
+				return err
+			}
+			if err := pruneAttestations(tx, data.Pubkey); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func importRecord(tx kv.RwTx, table string, key []byte, signingRoot *libcommon.Hash) error {
+	var root libcommon.Hash
+	if signingRoot != nil {
+		root = *signingRoot
+	}
+	stored, err := tx.GetOne(table, key)
+	if err != nil {
+		return err
+	}
+	if stored != nil && !bytes.Equal(stored, root[:]) {
+		root = libcommon.Hash{}
+	}
+	return tx.Put(table, key, root[:])
+}
+
+// Export writes the whole database in interchange format. Low watermarks are not exported: the importer takes the
+// lowest exported record as its watermark, which is above ours.
+func (s *SlashingProtection) Export(ctx context.Context, w io.Writer) error {
+	interchange := Interchange{
+		Metadata: InterchangeMetadata{
+			InterchangeFormatVersion: InterchangeFormatVersion,
+			GenesisValidatorsRoot:    s.genesisValidatorsRoot,
+		},
+		Data: []InterchangeData{},
+	}
+	dataByPubkey := map[libcommon.Bytes48]*InterchangeData{}
+	getData := func(key []byte) *InterchangeData {
+		pubkey := libcommon.Bytes48(key[:48])
+		data, ok := dataByPubkey[pubkey]
+		if !ok {
+			data = &InterchangeData{Pubkey: pubkey, SignedBlocks: []SignedBlock{}, SignedAttestations: []SignedAttestation{}}
+			dataByPubkey[pubkey] = data
+		}
+		return data
+	}
+	var pubkeys []libcommon.Bytes48
+	if err := s.db.View(ctx, func(tx kv.Tx) error {
+		if err := tx.ForEach(signedBlocksTable, nil, func(k, v []byte) error {
+			data := getData(k)
+			data.SignedBlocks = append(data.SignedBlocks, SignedBlock{
+				Slot:        binary.BigEndian.Uint64(k[48:]),
+				SigningRoot: exportSigningRoot(v),
+			})
+			return nil
+		}); err != nil {
+			return err
+		}
+		return tx.ForEach(signedAttestationsTable, nil, func(k, v []byte) error {
+			data := getData(k)
+			target, source := parseAttestationKey(k)
+			data.SignedAttestations = append(data.SignedAttestations, SignedAttestation{
+				SourceEpoch: source,
+				TargetEpoch: target,
+				SigningRoot: exportSigningRoot(v),
+			})
+			return nil
+		})
+	}); err != nil {
+		return err
+	}
+	for pubkey := range dataByPubkey {
+		pubkeys = append(pubkeys, pubkey)
+	}
+	// keep the output stable
+	sort.Slice(pubkeys, func(i, j int) bool { return bytes.Compare(pubkeys[i][:], pubkeys[j][:]) < 0 })
+	for _, pubkey := range pubkeys {
+		interchange.Data = append(interchange.Data, *dataByPubkey[pubkey])
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(interchange)
+}
+
+func exportSigningRoot(v []byte) *libcommon.Hash {
+	root := libcommon.BytesToHash(v)
+	if root == (libcommon.Hash{}) {
+		return nil
+	}
+	return &root
+}
@@ -0,0 +1,362 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package slashing_protection keeps the record of recent messages our validators signed and refuses
+// to sign slashable messages, following the rules of EIP-3076. Older records are pruned: they are replaced by
+// per-validator low watermarks, and nothing at or below a watermark is signed.
+package slashing_protection
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/c2h5oh/datasize"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/mdbx"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+var (
+	ErrSlashableBlock                = errors.New("slashing protection: refusing to sign slashable block")
+	ErrSlashableAttestation          = errors.New("slashing protection: refusing to sign slashable attestation")
+	ErrGenesisValidatorsRootMismatch = errors.New("slashing protection: genesis validators root mismatch")
+)
+
+const (
+	// signedBlocksTable: pubkey + slot => signing root (zero if unknown)
+	signedBlocksTable = "SignedBlocks"
+	// signedAttestationsTable: pubkey + target epoch + source epoch => signing root (zero if unknown)
+	signedAttestationsTable = "SignedAttestations"
+	// blockWatermarksTable: pubkey => slot. blocks at or below it are refused
+	blockWatermarksTable = "BlockWatermarks"
+	// attestationWatermarksTable: pubkey => source epoch + target epoch. attestations with lower source or not higher
+	// target are refused
+	attestationWatermarksTable = "AttestationWatermarks"
+	// metadataTable: key => value
+	metadataTable = "Metadata"
+)
+
+const (
+	// blocksWindow - how many slots of signed blocks are kept per validator, counting back from the latest one
+	blocksWindow = 1024
+	// attestationsWindow - how many target epochs of signed attestations are kept per validator, counting back from
+	// the latest one
+	attestationsWindow = 256
+)
+
+var genesisValidatorsRootKey = []byte("genesis_validators_root")
+
+var tablesCfg = kv.TableCfg{
+	signedBlocksTable:          {},
+	signedAttestationsTable:    {},
+	blockWatermarksTable:       {},
+	attestationWatermarksTable: {},
+	metadataTable:              {},
+}
+
+type SlashingProtection struct {
+	db                    kv.RwDB
+	genesisValidatorsRoot libcommon.Hash
+}
+
+// Open opens (or creates) the slashing protection database at path. The database is bound to one chain, identified
+// by its genesis validators root.
+func Open(ctx context.Context, path string, genesisValidatorsRoot libcommon.Hash, logger log.Logger) (*SlashingProtection, error) {
+	db, err := mdbx.NewMDBX(logger).
+		Path(path).
+		WithTableCfg(func(_ kv.TableCfg) kv.TableCfg { return tablesCfg }).
+		MapSize(1 * datasize.GB).
+		Open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Update(ctx, func(tx kv.RwTx) error {
+		stored, err := tx.GetOne(metadataTable, genesisValidatorsRootKey)
+		if err != nil {
+			return err
+		}
+		if stored == nil {
+			return tx.Put(metadataTable, genesisValidatorsRootKey, genesisValidatorsRoot[:])
+		}
+		if !bytes.Equal(stored, genesisValidatorsRoot[:]) {
+			return fmt.Errorf("%w: database has %x, chain has %x", ErrGenesisValidatorsRootMismatch, stored, genesisValidatorsRoot)
+		}
+		return nil
+	}); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &SlashingProtection{db: db, genesisValidatorsRoot: genesisValidatorsRoot}, nil
+}
+
+func (s *SlashingProtection) Close() {
+	s.db.Close()
+}
+
+// CheckAndRecordBlock returns an error if signing a block at slot would be slashable (or may be, per EIP-3076),
+// otherwise it records the block so that it can be safely signed.
+func (s *SlashingProtection) CheckAndRecordBlock(ctx context.Context, pubkey libcommon.Bytes48, slot uint64, signingRoot libcommon.Hash) error {
+	return s.db.Update(ctx, func(tx kv.RwTx) error {
+		key := blockKey(pubkey, slot)
+		stored, err := tx.GetOne(signedBlocksTable, key)
+		if err != nil {
+			return err
+		}
+		if stored != nil {
+			if isRepeatSigning(stored, signingRoot) {
+				return nil
+			}
+			return fmt.Errorf("%w: double proposal at slot %d", ErrSlashableBlock, slot)
+		}
+		watermark, err := tx.GetOne(blockWatermarksTable, pubkey[:])
+		if err != nil {
+			return err
+		}
+		if watermark != nil && slot <= binary.BigEndian.Uint64(watermark) {
+			return fmt.Errorf("%w: slot %d is not above the low watermark %d", ErrSlashableBlock, slot, binary.BigEndian.Uint64(watermark))
+		}
+		// refuse anything at or below the lowest slot we know about
+		c, err := tx.Cursor(signedBlocksTable)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+		k, _, err := c.Seek(pubkey[:])
+		if err != nil {
+			return err
+		}
+		if k != nil && bytes.HasPrefix(k, pubkey[:]) {
+			if minSlot := binary.BigEndian.Uint64(k[len(pubkey):]); slot <= minSlot {
+				return fmt.Errorf("%w: slot %d is not above the lowest signed slot %d", ErrSlashableBlock, slot, minSlot)
+			}
+		}
+		if err := tx.Put(signedBlocksTable, key, signingRoot[:]); err != nil {
+			return err
+		}
+		return pruneBlocks(tx, pubkey)
+	})
+}
+
+// CheckAndRecordAttestation returns an error if signing an attestation with the given source and target would be
+// slashable (or may be, per EIP-3076), otherwise it records the attestation so that it can be safely signed.
+func (s *SlashingProtection) CheckAndRecordAttestation(ctx context.Context, pubkey libcommon.Bytes48, sourceEpoch, targetEpoch uint64, signingRoot libcommon.Hash) error {
+	if sourceEpoch > targetEpoch {
+		return fmt.Errorf("%w: source epoch %d is after target epoch %d", ErrSlashableAttestation, sourceEpoch, targetEpoch)
+	}
+	return s.db.Update(ctx, func(tx kv.RwTx) error {
+		c, err := tx.Cursor(signedAttestationsTable)
+		if err != nil {
+			return err
+		}
+		defer c.Close()
+		watermark, err := tx.GetOne(attestationWatermarksTable, pubkey[:])
+		if err != nil {
+			return err
+		}
+		if watermark != nil {
+			source, target := binary.BigEndian.Uint64(watermark), binary.BigEndian.Uint64(watermark[8:])
+			if sourceEpoch < source || targetEpoch <= target {
+				return fmt.Errorf("%w: %d=>%d is below the low watermark %d=>%d", ErrSlashableAttestation, sourceEpoch, targetEpoch, source, target)
+			}
+		}
+		var (
+			minSource, minTarget uint64
+			first                = true
+		)
+		for k, v, err := c.Seek(pubkey[:]); k != nil && bytes.HasPrefix(k, pubkey[:]); k, v, err = c.Next() {
+			if err != nil {
+				return err
+			}
+			target, source := parseAttestationKey(k)
+			if first || source < minSource {
+				minSource = source
+			}
+			if first || target < minTarget {
+				minTarget = target
+			}
+			first = false
+			if target == targetEpoch {
+				if source == sourceEpoch && isRepeatSigning(v, signingRoot) {
+					return nil
+				}
+				return fmt.Errorf("%w: double vote for target epoch %d", ErrSlashableAttestation, targetEpoch)
+			}
+			if source < sourceEpoch && targetEpoch < target {
+				return fmt.Errorf("%w: surrounded by attestation %d=>%d", ErrSlashableAttestation, source, target)
+			}
+			if sourceEpoch < source && target < targetEpoch {
+				return fmt.Errorf("%w: surrounds attestation %d=>%d", ErrSlashableAttestation, source, target)
+			}
+		}
+		if !first && (sourceEpoch < minSource || targetEpoch <= minTarget) {
+			return fmt.Errorf("%w: %d=>%d is below the lowest signed attestation %d=>%d", ErrSlashableAttestation, sourceEpoch, targetEpoch, minSource, minTarget)
+		}
+		if err := tx.Put(signedAttestationsTable, attestationKey(pubkey, targetEpoch, sourceEpoch), signingRoot[:]); err != nil {
+			return err
+		}
+		return pruneAttestations(tx, pubkey)
+	})
+}
+
+// pruneBlocks removes blocks more than blocksWindow slots older than the latest block of the validator and raises
+// its low watermark to the highest removed slot
+func pruneBlocks(tx kv.RwTx, pubkey libcommon.Bytes48) error {
+	c, err := tx.RwCursor(signedBlocksTable)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	k, err := lastWithPrefix(c, pubkey[:])
+	if err != nil || k == nil {
+		return err
+	}
+	latest := binary.BigEndian.Uint64(k[len(pubkey):])
+	if latest < blocksWindow {
+		return nil
+	}
+	var (
+		watermark uint64
+		pruned    bool
+	)
+	for k, _, err = c.Seek(pubkey[:]); k != nil && bytes.HasPrefix(k, pubkey[:]); k, _, err = c.Next() {
+		if err != nil {
+			return err
+		}
+		slot := binary.BigEndian.Uint64(k[len(pubkey):])
+		if slot >= latest-blocksWindow {
+			break
+		}
+		if err := c.DeleteCurrent(); err != nil {
+			return err
+		}
+		watermark, pruned = slot, true
+	}
+	if err != nil || !pruned {
+		return err
+	}
+	stored, err := tx.GetOne(blockWatermarksTable, pubkey[:])
+	if err != nil {
+		return err
+	}
+	if stored != nil && binary.BigEndian.Uint64(stored) > watermark {
+		return nil
+	}
+	v := make([]byte, 8)
+	binary.BigEndian.PutUint64(v, watermark)
+	return tx.Put(blockWatermarksTable, pubkey[:], v)
+}
+
+// pruneAttestations removes attestations with target more than attestationsWindow epochs older than the latest
+// target of the validator and raises its low watermark to the highest removed source and target
+func pruneAttestations(tx kv.RwTx, pubkey libcommon.Bytes48) error {
+	c, err := tx.RwCursor(signedAttestationsTable)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	k, err := lastWithPrefix(c, pubkey[:])
+	if err != nil || k == nil {
+		return err
+	}
+	latest, _ := parseAttestationKey(k)
+	if latest < attestationsWindow {
+		return nil
+	}
+	var (
+		watermarkSource, watermarkTarget uint64
+		pruned                           bool
+	)
+	for k, _, err = c.Seek(pubkey[:]); k != nil && bytes.HasPrefix(k, pubkey[:]); k, _, err = c.Next() {
+		if err != nil {
+			return err
+		}
+		target, source := parseAttestationKey(k)
+		if target >= latest-attestationsWindow {
+			break
+		}
+		if err := c.DeleteCurrent(); err != nil {
+			return err
+		}
+		watermarkSource, watermarkTarget, pruned = max(watermarkSource, source), target, true
+	}
+	if err != nil || !pruned {
+		return err
+	}
+	stored, err := tx.GetOne(attestationWatermarksTable, pubkey[:])
+	if err != nil {
+		return err
+	}
+	if stored != nil {
+		watermarkSource = max(watermarkSource, binary.BigEndian.Uint64(stored))
+		watermarkTarget = max(watermarkTarget, binary.BigEndian.Uint64(stored[8:]))
+	}
+	v := make([]byte, 16)
+	binary.BigEndian.PutUint64(v, watermarkSource)
+	binary.BigEndian.PutUint64(v[8:], watermarkTarget)
+	return tx.Put(attestationWatermarksTable, pubkey[:], v)
+}
+
+// lastWithPrefix returns the last key starting with prefix, or nil if there is none
+func lastWithPrefix(c kv.Cursor, prefix []byte) ([]byte, error) {
+	next, ok := kv.NextSubtree(prefix)
+	var (
+		k   []byte
+		err error
+	)
+	if ok {
+		if k, _, err = c.Seek(next); err != nil {
+			return nil, err
+		}
+	}
+	if k == nil {
+		k, _, err = c.Last()
+	} else {
+		k, _, err = c.Prev()
+	}
+	if err != nil || !bytes.HasPrefix(k, prefix) {
+		return nil, err
+	}
+	return k, nil
+}
+
+// isRepeatSigning tells whether the stored signing root allows signing signingRoot again. Unknown (zero) roots never do.
+func isRepeatSigning(stored []byte, signingRoot libcommon.Hash) bool {
+	return signingRoot != (libcommon.Hash{}) && bytes.Equal(stored, signingRoot[:])
+}
+
+func blockKey(pubkey libcommon.Bytes48, slot uint64) []byte {
+	key := make([]byte, len(pubkey)+8)
+	copy(key, pubkey[:])
+	binary.BigEndian.PutUint64(key[len(pubkey):], slot)
+	return key
+}
+
+func attestationKey(pubkey libcommon.Bytes48, targetEpoch, sourceEpoch uint64) []byte {
+	key := make([]byte, len(pubkey)+16)
+	copy(key, pubkey[:])
+	binary.BigEndian.PutUint64(key[len(pubkey):], targetEpoch)
+	binary.BigEndian.PutUint64(key[len(pubkey)+8:], sourceEpoch)
+	return key
+}
+
+func parseAttestationKey(key []byte) (targetEpoch, sourceEpoch uint64) {
+	return binary.BigEndian.Uint64(key[48:]), binary.BigEndian.Uint64(key[56:])
+}
@@ -0,0 +1,228 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package slashing_protection
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testGenesisValidatorsRoot = libcommon.HexToHash("0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673")
+	testPubkey                = libcommon.Bytes48{0xb8, 0x45}
+)
+
+func openTestDB(t *testing.T) *SlashingProtection {
+	sp, err := Open(context.Background(), t.TempDir(), testGenesisValidatorsRoot, log.New())
+	require.NoError(t, err)
+	t.Cleanup(sp.Close)
+	return sp
+}
+
+func TestBlockProtection(t *testing.T) {
+	ctx := context.Background()
+	sp := openTestDB(t)
+
+	require.NoError(t, sp.CheckAndRecordBlock(ctx, testPubkey, 10, libcommon.Hash{1}))
+	// repeat signing of the same block is fine
+	require.NoError(t, sp.CheckAndRecordBlock(ctx, testPubkey, 10, libcommon.Hash{1}))
+	// double proposal
+	require.ErrorIs(t, sp.CheckAndRecordBlock(ctx, testPubkey, 10, libcommon.Hash{2}), ErrSlashableBlock)
+	// below the lowest slot
+	require.ErrorIs(t, sp.CheckAndRecordBlock(ctx, testPubkey, 9, libcommon.Hash{3}), ErrSlashableBlock)
+	require.NoError(t, sp.CheckAndRecordBlock(ctx, testPubkey, 11, libcommon.Hash{4}))
+	// other validators are independent
+	require.NoError(t, sp.CheckAndRecordBlock(ctx, libcommon.Bytes48{0xa1}, 9, libcommon.Hash{3}))
+}
+
+func TestAttestationProtection(t *testing.T) {
+	ctx := context.Background()
+	sp := openTestDB(t)
+
+	require.NoError(t, sp.CheckAndRecordAttestation(ctx, testPubkey, 2, 5, libcommon.Hash{1}))
+	// repeat signing
+	require.NoError(t, sp.CheckAndRecordAttestation(ctx, testPubkey, 2, 5, libcommon.Hash{1}))
+	// double vote
+	require.ErrorIs(t, sp.CheckAndRecordAttestation(ctx, testPubkey, 3, 5, libcommon.Hash{2}), ErrSlashableAttestation)
+	require.ErrorIs(t, sp.CheckAndRecordAttestation(ctx, testPubkey, 2, 5, libcommon.Hash{2}), ErrSlashableAttestation)
+	require.NoError(t, sp.CheckAndRecordAttestation(ctx, testPubkey, 2, 7, libcommon.Hash{3}))
+	require.NoError(t, sp.CheckAndRecordAttestation(ctx, testPubkey, 3, 8, libcommon.Hash{4}))
+	require.NoError(t, sp.CheckAndRecordAttestation(ctx, testPubkey, 4, 9, libcommon.Hash{5}))
+	// surrounds 4=>9
+	require.ErrorIs(t, sp.CheckAndRecordAttestation(ctx, testPubkey, 3, 10, libcommon.Hash{6}), ErrSlashableAttestation)
+	// surrounded by 4=>9
+	require.ErrorIs(t, sp.CheckAndRecordAttestation(ctx, testPubkey, 5, 6, libcommon.Hash{7}), ErrSlashableAttestation)
+	// below the lowest source
+	require.ErrorIs(t, sp.CheckAndRecordAttestation(ctx, testPubkey, 1, 11, libcommon.Hash{8}), ErrSlashableAttestation)
+	// source after target
+	require.ErrorIs(t, sp.CheckAndRecordAttestation(ctx, testPubkey, 12, 11, libcommon.Hash{9}), ErrSlashableAttestation)
+	require.NoError(t, sp.CheckAndRecordAttestation(ctx, testPubkey, 9, 11, libcommon.Hash{10}))
+}
+
+func TestPruneBlocks(t *testing.T) {
+	ctx := context.Background()
+	sp := openTestDB(t)
+
+	require.NoError(t, sp.CheckAndRecordBlock(ctx, testPubkey, 10, libcommon.Hash{1}))
+	require.NoError(t, sp.CheckAndRecordBlock(ctx, testPubkey, 20, libcommon.Hash{2}))
+	require.NoError(t, sp.CheckAndRecordBlock(ctx, testPubkey, 30, libcommon.Hash{3}))
+	// 10 and 20 fall out of the window: 20 becomes the low watermark
+	require.NoError(t, sp.CheckAndRecordBlock(ctx, testPubkey, 25+blocksWindow, libcommon.Hash{4}))
+	require.Equal(t, []uint64{30, 25 + blocksWindow}, signedSlots(t, sp))
+	require.ErrorIs(t, sp.CheckAndRecordBlock(ctx, testPubkey, 20, libcommon.Hash{2}), ErrSlashableBlock)
+	require.ErrorIs(t, sp.CheckAndRecordBlock(ctx, testPubkey, 15, libcommon.Hash{5}), ErrSlashableBlock)
+	// records inside the window are still checked one by one
+	require.NoError(t, sp.CheckAndRecordBlock(ctx, testPubkey, 30, libcommon.Hash{3}))
+	require.ErrorIs(t, sp.CheckAndRecordBlock(ctx, testPubkey, 30, libcommon.Hash{6}), ErrSlashableBlock)
+	require.NoError(t, sp.CheckAndRecordBlock(ctx, testPubkey, 31, libcommon.Hash{7}))
+	// other validators are not pruned
+	require.NoError(t, sp.CheckAndRecordBlock(ctx, libcommon.Bytes48{0xa1}, 10, libcommon.Hash{1}))
+	require.NoError(t, sp.CheckAndRecordBlock(ctx, libcommon.Bytes48{0xa1}, 11, libcommon.Hash{1}))
+
+	// watermark only grows: all records fall out of the window
+	require.NoError(t, sp.CheckAndRecordBlock(ctx, testPubkey, 100+3*blocksWindow, libcommon.Hash{8}))
+	require.Equal(t, []uint64{100 + 3*blocksWindow}, signedSlots(t, sp))
+	require.ErrorIs(t, sp.CheckAndRecordBlock(ctx, testPubkey, 25+blocksWindow, libcommon.Hash{9}), ErrSlashableBlock)
+}
+
+func TestPruneAttestations(t *testing.T) {
+	ctx := context.Background()
+	sp := openTestDB(t)
+
+	require.NoError(t, sp.CheckAndRecordAttestation(ctx, testPubkey, 3, 10, libcommon.Hash{1}))
+	require.NoError(t, sp.CheckAndRecordAttestation(ctx, testPubkey, 10, 20, libcommon.Hash{2}))
+	require.NoError(t, sp.CheckAndRecordAttestation(ctx, testPubkey, 20, 30, libcommon.Hash{3}))
+	// 3=>10 and 10=>20 fall out of the window: 10=>20 becomes the low watermark
+	require.NoError(t, sp.CheckAndRecordAttestation(ctx, testPubkey, 30, 25+attestationsWindow, libcommon.Hash{4}))
+	require.Len(t, signedAttestations(t, sp), 2)
+	// double vote for a pruned target
+	require.ErrorIs(t, sp.CheckAndRecordAttestation(ctx, testPubkey, 10, 20, libcommon.Hash{5}), ErrSlashableAttestation)
+	// surrounds pruned 3=>10: source is below the watermark
+	require.ErrorIs(t, sp.CheckAndRecordAttestation(ctx, testPubkey, 2, 30+attestationsWindow, libcommon.Hash{6}), ErrSlashableAttestation)
+	// surrounded by 20=>30 which is inside the window
+	require.ErrorIs(t, sp.CheckAndRecordAttestation(ctx, testPubkey, 21, 29, libcommon.Hash{7}), ErrSlashableAttestation)
+	require.NoError(t, sp.CheckAndRecordAttestation(ctx, testPubkey, 30, 26+attestationsWindow, libcommon.Hash{8}))
+}
+
+func signedSlots(t *testing.T, sp *SlashingProtection) (slots []uint64) {
+	t.Helper()
+	require.NoError(t, sp.db.View(context.Background(), func(tx kv.Tx) error {
+		return tx.ForEach(signedBlocksTable, testPubkey[:], func(k, _ []byte) error {
+			if bytes.HasPrefix(k, testPubkey[:]) {
+				slots = append(slots, binary.BigEndian.Uint64(k[len(testPubkey):]))
+			}
+			return nil
+		})
+	}))
+	return slots
+}
+
+func signedAttestations(t *testing.T, sp *SlashingProtection) (keys [][]byte) {
+	t.Helper()
+	require.NoError(t, sp.db.View(context.Background(), func(tx kv.Tx) error {
+		return tx.ForEach(signedAttestationsTable, testPubkey[:], func(k, _ []byte) error {
+			if bytes.HasPrefix(k, testPubkey[:]) {
+				keys = append(keys, libcommon.Copy(k))
+			}
+			return nil
+		})
+	}))
+	return keys
+}
+
+func TestGenesisValidatorsRootMismatch(t *testing.T) {
+	dir := t.TempDir()
+	sp, err := Open(context.Background(), dir, testGenesisValidatorsRoot, log.New())
+	require.NoError(t, err)
+	sp.Close()
+	_, err = Open(context.Background(), dir, libcommon.Hash{1}, log.New())
+	require.ErrorIs(t, err, ErrGenesisValidatorsRootMismatch)
+}
+
+const testInterchange = `{
+  "metadata": {
+    "interchange_format_version": "5",
+    "genesis_validators_root": "0x04700007fabc8282644aed6d1c7c9e21d38a03a0c4ba193f3afe428824b3a673"
+  },
+  "data": [
+    {
+      "pubkey": "0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed",
+      "signed_blocks": [
+        {"slot": "81952", "signing_root": "0x4ff6f743a43f3b4f95350831aeaf0a122a1a392922c45d804280284a69eb850b"},
+        {"slot": "81951"}
+      ],
+      "signed_attestations": [
+        {"source_epoch": "2290", "target_epoch": "3007", "signing_root": "0x587d6a4f59a58fe24f406e0502413e77fe1babddee641fda30034ed37ecc884d"},
+        {"source_epoch": "2290", "target_epoch": "3008"}
+      ]
+    }
+  ]
+}`
+
+func TestInterchange(t *testing.T) {
+	ctx := context.Background()
+	sp := openTestDB(t)
+	require.NoError(t, sp.Import(ctx, strings.NewReader(testInterchange)))
+
+	pubkey := libcommon.Bytes48(libcommon.FromHex("0xb845089a1457f811bfc000588fbb4e713669be8ce060ea6be3c6ece09afc3794106c91ca73acda5e5457122d58723bed"))
+	// imported records are enforced
+	require.ErrorIs(t, sp.CheckAndRecordBlock(ctx, pubkey, 81951, libcommon.Hash{}), ErrSlashableBlock)
+	require.NoError(t, sp.CheckAndRecordBlock(ctx, pubkey, 81952, libcommon.HexToHash("0x4ff6f743a43f3b4f95350831aeaf0a122a1a392922c45d804280284a69eb850b")))
+	require.ErrorIs(t, sp.CheckAndRecordAttestation(ctx, pubkey, 2290, 3008, libcommon.Hash{1}), ErrSlashableAttestation)
+	require.ErrorIs(t, sp.CheckAndRecordAttestation(ctx, pubkey, 2289, 3009, libcommon.Hash{1}), ErrSlashableAttestation)
+	require.NoError(t, sp.CheckAndRecordAttestation(ctx, pubkey, 2290, 3009, libcommon.Hash{1}))
+
+	// export and import again into a fresh database: the result must be identical
+	var exported bytes.Buffer
+	require.NoError(t, sp.Export(ctx, &exported))
+	other := openTestDB(t)
+	require.NoError(t, other.Import(ctx, bytes.NewReader(exported.Bytes())))
+	var reexported bytes.Buffer
+	require.NoError(t, other.Export(ctx, &reexported))
+	require.Equal(t, exported.String(), reexported.String())
+
+	// interchange of another chain is refused
+	require.ErrorIs(t, sp.Import(ctx, strings.NewReader(strings.Replace(testInterchange, "0x0470", "0x0570", 1))), ErrGenesisValidatorsRootMismatch)
+}
+
+func TestImportConflictForgetsSigningRoot(t *testing.T) {
+	ctx := context.Background()
+	sp := openTestDB(t)
+	require.NoError(t, sp.CheckAndRecordBlock(ctx, testPubkey, 10, libcommon.Hash{1}))
+	interchange := Interchange{
+		Metadata: InterchangeMetadata{InterchangeFormatVersion: InterchangeFormatVersion, GenesisValidatorsRoot: testGenesisValidatorsRoot},
+		Data: []InterchangeData{{
+			Pubkey:       testPubkey,
+			SignedBlocks: []SignedBlock{{Slot: 10, SigningRoot: &libcommon.Hash{2}}},
+		}},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, json.NewEncoder(&buf).Encode(interchange))
+	require.NoError(t, sp.Import(ctx, &buf))
+	// neither root can be signed again
+	require.ErrorIs(t, sp.CheckAndRecordBlock(ctx, testPubkey, 10, libcommon.Hash{1}), ErrSlashableBlock)
+	require.ErrorIs(t, sp.CheckAndRecordBlock(ctx, testPubkey, 10, libcommon.Hash{2}), ErrSlashableBlock)
+}
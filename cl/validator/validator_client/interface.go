@@ -0,0 +1,37 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package validator_client
+
+import (
+	"context"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/cltypes/solid"
+)
+
+//go:generate mockgen -typed=true -destination=./mock_services/beacon_node_mock.go -package=mock_services . BeaconNode
+
+// BeaconNode is the in-process counterpart of the validator-facing beacon API, implemented by the beacon API handler.
+type BeaconNode interface {
+	ProduceBlock(ctx context.Context, targetSlot uint64, builderBoostFactor uint64, randaoReveal libcommon.Bytes96, graffiti libcommon.Hash) (*cltypes.BlindOrExecutionBeaconBlock, error)
+	PublishBlock(ctx context.Context, block *cltypes.SignedBeaconBlock) error
+	ProduceAttestationData(slot, committeeIndex uint64) (solid.AttestationData, error)
+	SubmitAttestation(ctx context.Context, attestation *solid.Attestation) error
+	GetAggregateAttestation(attestationDataRoot libcommon.Hash) *solid.Attestation
+	SubmitAggregateAndProof(ctx context.Context, aggregate *cltypes.SignedAggregateAndProof) error
+}
@@ -0,0 +1,273 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/erigontech/erigon/cl/validator/validator_client (interfaces: BeaconNode)
+//
+// Generated by this command:
+//
+//	mockgen -typed=true -destination=./mock_services/beacon_node_mock.go -package=mock_services . BeaconNode
+//
+
+// Package mock_services is a generated GoMock package.
+package mock_services
+
+import (
+	context "context"
+	reflect "reflect"
+
+	common "github.com/erigontech/erigon-lib/common"
+	cltypes "github.com/erigontech/erigon/cl/cltypes"
+	solid "github.com/erigontech/erigon/cl/cltypes/solid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBeaconNode is a mock of BeaconNode interface.
+type MockBeaconNode struct {
+	ctrl     *gomock.Controller
+	recorder *MockBeaconNodeMockRecorder
+}
+
+// MockBeaconNodeMockRecorder is the mock recorder for MockBeaconNode.
+type MockBeaconNodeMockRecorder struct {
+	mock *MockBeaconNode
+}
+
+// NewMockBeaconNode creates a new mock instance.
+func NewMockBeaconNode(ctrl *gomock.Controller) *MockBeaconNode {
+	mock := &MockBeaconNode{ctrl: ctrl}
+	mock.recorder = &MockBeaconNodeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBeaconNode) EXPECT() *MockBeaconNodeMockRecorder {
+	return m.recorder
+}
+
+// GetAggregateAttestation mocks base method.
+func (m *MockBeaconNode) GetAggregateAttestation(arg0 common.Hash) *solid.Attestation {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAggregateAttestation", arg0)
+	ret0, _ := ret[0].(*solid.Attestation)
+	return ret0
+}
+
+// GetAggregateAttestation indicates an expected call of GetAggregateAttestation.
+func (mr *MockBeaconNodeMockRecorder) GetAggregateAttestation(arg0 any) *MockBeaconNodeGetAggregateAttestationCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAggregateAttestation", reflect.TypeOf((*MockBeaconNode)(nil).GetAggregateAttestation), arg0)
+	return &MockBeaconNodeGetAggregateAttestationCall{Call: call}
+}
+
+// MockBeaconNodeGetAggregateAttestationCall wrap *gomock.Call
+type MockBeaconNodeGetAggregateAttestationCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBeaconNodeGetAggregateAttestationCall) Return(arg0 *solid.Attestation) *MockBeaconNodeGetAggregateAttestationCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBeaconNodeGetAggregateAttestationCall) Do(f func(common.Hash) *solid.Attestation) *MockBeaconNodeGetAggregateAttestationCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBeaconNodeGetAggregateAttestationCall) DoAndReturn(f func(common.Hash) *solid.Attestation) *MockBeaconNodeGetAggregateAttestationCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ProduceAttestationData mocks base method.
+func (m *MockBeaconNode) ProduceAttestationData(arg0, arg1 uint64) (solid.AttestationData, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProduceAttestationData", arg0, arg1)
+	ret0, _ := ret[0].(solid.AttestationData)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProduceAttestationData indicates an expected call of ProduceAttestationData.
+func (mr *MockBeaconNodeMockRecorder) ProduceAttestationData(arg0, arg1 any) *MockBeaconNodeProduceAttestationDataCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProduceAttestationData", reflect.TypeOf((*MockBeaconNode)(nil).ProduceAttestationData), arg0, arg1)
+	return &MockBeaconNodeProduceAttestationDataCall{Call: call}
+}
+
+// MockBeaconNodeProduceAttestationDataCall wrap *gomock.Call
+type MockBeaconNodeProduceAttestationDataCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBeaconNodeProduceAttestationDataCall) Return(arg0 solid.AttestationData, arg1 error) *MockBeaconNodeProduceAttestationDataCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBeaconNodeProduceAttestationDataCall) Do(f func(uint64, uint64) (solid.AttestationData, error)) *MockBeaconNodeProduceAttestationDataCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBeaconNodeProduceAttestationDataCall) DoAndReturn(f func(uint64, uint64) (solid.AttestationData, error)) *MockBeaconNodeProduceAttestationDataCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// ProduceBlock mocks base method.
+func (m *MockBeaconNode) ProduceBlock(arg0 context.Context, arg1, arg2 uint64, arg3 common.Bytes96, arg4 common.Hash) (*cltypes.BlindOrExecutionBeaconBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProduceBlock", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*cltypes.BlindOrExecutionBeaconBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProduceBlock indicates an expected call of ProduceBlock.
+func (mr *MockBeaconNodeMockRecorder) ProduceBlock(arg0, arg1, arg2, arg3, arg4 any) *MockBeaconNodeProduceBlockCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProduceBlock", reflect.TypeOf((*MockBeaconNode)(nil).ProduceBlock), arg0, arg1, arg2, arg3, arg4)
+	return &MockBeaconNodeProduceBlockCall{Call: call}
+}
+
+// MockBeaconNodeProduceBlockCall wrap *gomock.Call
+type MockBeaconNodeProduceBlockCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBeaconNodeProduceBlockCall) Return(arg0 *cltypes.BlindOrExecutionBeaconBlock, arg1 error) *MockBeaconNodeProduceBlockCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBeaconNodeProduceBlockCall) Do(f func(context.Context, uint64, uint64, common.Bytes96, common.Hash) (*cltypes.BlindOrExecutionBeaconBlock, error)) *MockBeaconNodeProduceBlockCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBeaconNodeProduceBlockCall) DoAndReturn(f func(context.Context, uint64, uint64, common.Bytes96, common.Hash) (*cltypes.BlindOrExecutionBeaconBlock, error)) *MockBeaconNodeProduceBlockCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// PublishBlock mocks base method.
+func (m *MockBeaconNode) PublishBlock(arg0 context.Context, arg1 *cltypes.SignedBeaconBlock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishBlock", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PublishBlock indicates an expected call of PublishBlock.
+func (mr *MockBeaconNodeMockRecorder) PublishBlock(arg0, arg1 any) *MockBeaconNodePublishBlockCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishBlock", reflect.TypeOf((*MockBeaconNode)(nil).PublishBlock), arg0, arg1)
+	return &MockBeaconNodePublishBlockCall{Call: call}
+}
+
+// MockBeaconNodePublishBlockCall wrap *gomock.Call
+type MockBeaconNodePublishBlockCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBeaconNodePublishBlockCall) Return(arg0 error) *MockBeaconNodePublishBlockCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBeaconNodePublishBlockCall) Do(f func(context.Context, *cltypes.SignedBeaconBlock) error) *MockBeaconNodePublishBlockCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBeaconNodePublishBlockCall) DoAndReturn(f func(context.Context, *cltypes.SignedBeaconBlock) error) *MockBeaconNodePublishBlockCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SubmitAggregateAndProof mocks base method.
+func (m *MockBeaconNode) SubmitAggregateAndProof(arg0 context.Context, arg1 *cltypes.SignedAggregateAndProof) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitAggregateAndProof", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubmitAggregateAndProof indicates an expected call of SubmitAggregateAndProof.
+func (mr *MockBeaconNodeMockRecorder) SubmitAggregateAndProof(arg0, arg1 any) *MockBeaconNodeSubmitAggregateAndProofCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitAggregateAndProof", reflect.TypeOf((*MockBeaconNode)(nil).SubmitAggregateAndProof), arg0, arg1)
+	return &MockBeaconNodeSubmitAggregateAndProofCall{Call: call}
+}
+
+// MockBeaconNodeSubmitAggregateAndProofCall wrap *gomock.Call
+type MockBeaconNodeSubmitAggregateAndProofCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBeaconNodeSubmitAggregateAndProofCall) Return(arg0 error) *MockBeaconNodeSubmitAggregateAndProofCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBeaconNodeSubmitAggregateAndProofCall) Do(f func(context.Context, *cltypes.SignedAggregateAndProof) error) *MockBeaconNodeSubmitAggregateAndProofCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBeaconNodeSubmitAggregateAndProofCall) DoAndReturn(f func(context.Context, *cltypes.SignedAggregateAndProof) error) *MockBeaconNodeSubmitAggregateAndProofCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// SubmitAttestation mocks base method.
+func (m *MockBeaconNode) SubmitAttestation(arg0 context.Context, arg1 *solid.Attestation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SubmitAttestation", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SubmitAttestation indicates an expected call of SubmitAttestation.
+func (mr *MockBeaconNodeMockRecorder) SubmitAttestation(arg0, arg1 any) *MockBeaconNodeSubmitAttestationCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SubmitAttestation", reflect.TypeOf((*MockBeaconNode)(nil).SubmitAttestation), arg0, arg1)
+	return &MockBeaconNodeSubmitAttestationCall{Call: call}
+}
+
+// MockBeaconNodeSubmitAttestationCall wrap *gomock.Call
+type MockBeaconNodeSubmitAttestationCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockBeaconNodeSubmitAttestationCall) Return(arg0 error) *MockBeaconNodeSubmitAttestationCall {
+	c.Call = c.Call.Return(arg0)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockBeaconNodeSubmitAttestationCall) Do(f func(context.Context, *solid.Attestation) error) *MockBeaconNodeSubmitAttestationCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockBeaconNodeSubmitAttestationCall) DoAndReturn(f func(context.Context, *solid.Attestation) error) *MockBeaconNodeSubmitAttestationCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
@@ -0,0 +1,99 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package validator_client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"github.com/Giulio2002/bls"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/cl/validator/keystore"
+)
+
+// Signer holds the keys of the validators we run. It signs whatever signing root it is given: slashing protection
+// is checked by the validator client before a slashable message reaches the signer.
+type Signer interface {
+	PublicKeys() []libcommon.Bytes48
+	Sign(ctx context.Context, pubkey libcommon.Bytes48, signingRoot libcommon.Hash) (libcommon.Bytes96, error)
+}
+
+type localSigner struct {
+	keys    map[libcommon.Bytes48]*bls.PrivateKey
+	pubkeys []libcommon.Bytes48
+}
+
+// NewLocalSigner creates a signer which keeps the given BLS secret keys in memory.
+func NewLocalSigner(secrets [][]byte) (Signer, error) {
+	s := &localSigner{keys: make(map[libcommon.Bytes48]*bls.PrivateKey, len(secrets))}
+	for _, secret := range secrets {
+		privateKey, err := bls.NewPrivateKeyFromBytes(secret)
+		if err != nil {
+			return nil, err
+		}
+		pubkey := libcommon.Bytes48(bls.CompressPublicKey(privateKey.PublicKey()))
+		if _, ok := s.keys[pubkey]; ok {
+			continue
+		}
+		s.keys[pubkey] = privateKey
+		s.pubkeys = append(s.pubkeys, pubkey)
+	}
+	sort.Slice(s.pubkeys, func(i, j int) bool { return string(s.pubkeys[i][:]) < string(s.pubkeys[j][:]) })
+	return s, nil
+}
+
+// NewSignerFromKeystores decrypts all the EIP-2335 keystores in dir with password.
+func NewSignerFromKeystores(dir string, password string) (Signer, error) {
+	secrets, err := keystore.LoadDir(dir, password)
+	if err != nil {
+		return nil, err
+	}
+	return NewLocalSigner(secrets)
+}
+
+// NewMockSigner creates a local signer with count deterministic keys, to be used in tests.
+func NewMockSigner(count int) Signer {
+	secrets := make([][]byte, count)
+	for i := range secrets {
+		var seed [8]byte
+		binary.BigEndian.PutUint64(seed[:], uint64(i))
+		secret := sha256.Sum256(seed[:])
+		secret[0] &= 0x3f // keep it below the curve order
+		secrets[i] = secret[:]
+	}
+	s, err := NewLocalSigner(secrets)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func (s *localSigner) PublicKeys() []libcommon.Bytes48 {
+	return s.pubkeys
+}
+
+func (s *localSigner) Sign(_ context.Context, pubkey libcommon.Bytes48, signingRoot libcommon.Hash) (libcommon.Bytes96, error) {
+	privateKey, ok := s.keys[pubkey]
+	if !ok {
+		return libcommon.Bytes96{}, fmt.Errorf("no key for validator %x", pubkey)
+	}
+	return libcommon.Bytes96(privateKey.Sign(signingRoot[:]).Bytes()), nil
+}
@@ -0,0 +1,353 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package validator_client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/cl/beacon/synced_data"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/cltypes/solid"
+	"github.com/erigontech/erigon/cl/fork"
+	"github.com/erigontech/erigon/cl/merkle_tree"
+	"github.com/erigontech/erigon/cl/phase1/core/state"
+	"github.com/erigontech/erigon/cl/transition"
+	"github.com/erigontech/erigon/cl/utils"
+	"github.com/erigontech/erigon/cl/utils/eth_clock"
+	"github.com/erigontech/erigon/cl/validator/slashing_protection"
+)
+
+var ErrHeadStateNotAvailable = errors.New("validator client: head state not available")
+
+// attesterDuty is an attestation we signed in the current slot, kept around to aggregate it.
+type attesterDuty struct {
+	pubkey              libcommon.Bytes48
+	validatorIndex      uint64
+	committeeIndex      uint64
+	committeeLength     uint64
+	attestationDataRoot libcommon.Hash
+}
+
+// ValidatorClient performs the duties of the validators whose keys are held by signer: it proposes blocks at the
+// start of the slot, attests at 1/3 of it and aggregates at 2/3, going through the beacon node in-process.
+type ValidatorClient struct {
+	beaconCfg  *clparams.BeaconChainConfig
+	ethClock   eth_clock.EthereumClock
+	syncedData synced_data.SyncedData
+	node       BeaconNode
+	signer     Signer
+	protection *slashing_protection.SlashingProtection
+	graffiti   libcommon.Hash
+	logger     log.Logger
+
+	attestedMutex sync.Mutex
+	attestedSlot  uint64
+	attested      []attesterDuty
+}
+
+func NewValidatorClient(
+	beaconCfg *clparams.BeaconChainConfig,
+	ethClock eth_clock.EthereumClock,
+	syncedData synced_data.SyncedData,
+	node BeaconNode,
+	signer Signer,
+	protection *slashing_protection.SlashingProtection,
+	graffiti libcommon.Hash,
+	logger log.Logger,
+) *ValidatorClient {
+	return &ValidatorClient{
+		beaconCfg:  beaconCfg,
+		ethClock:   ethClock,
+		syncedData: syncedData,
+		node:       node,
+		signer:     signer,
+		protection: protection,
+		graffiti:   graffiti,
+		logger:     logger,
+	}
+}
+
+// Run performs the duties of every slot until ctx is cancelled.
+func (v *ValidatorClient) Run(ctx context.Context) error {
+	v.logger.Info("[Validator] starting validator client", "validators", len(v.signer.PublicKeys()))
+	slotDuration := time.Duration(v.beaconCfg.SecondsPerSlot) * time.Second
+	for {
+		slot := v.ethClock.GetCurrentSlot() + 1
+		slotStart := v.ethClock.GetSlotTime(slot)
+		if err := sleepUntil(ctx, slotStart); err != nil {
+			return err
+		}
+		if v.syncedData.Syncing() {
+			continue
+		}
+		if err := v.ProposeBlock(ctx, slot); err != nil {
+			v.logger.Warn("[Validator] failed to propose block", "slot", slot, "err", err)
+		}
+		if err := sleepUntil(ctx, slotStart.Add(slotDuration/3)); err != nil {
+			return err
+		}
+		if err := v.Attest(ctx, slot); err != nil {
+			v.logger.Warn("[Validator] failed to attest", "slot", slot, "err", err)
+		}
+		if err := sleepUntil(ctx, slotStart.Add(2*slotDuration/3)); err != nil {
+			return err
+		}
+		if err := v.Aggregate(ctx, slot); err != nil {
+			v.logger.Warn("[Validator] failed to aggregate", "slot", slot, "err", err)
+		}
+	}
+}
+
+func sleepUntil(ctx context.Context, t time.Time) error {
+	timer := time.NewTimer(time.Until(t))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// ourValidators maps the validator indicies of our keys in s to their pubkeys. Keys not (yet) deposited are skipped.
+func (v *ValidatorClient) ourValidators(s *state.CachingBeaconState) map[uint64]libcommon.Bytes48 {
+	validators := map[uint64]libcommon.Bytes48{}
+	for _, pubkey := range v.signer.PublicKeys() {
+		if idx, ok := s.ValidatorIndexByPubkey(pubkey); ok {
+			validators[idx] = pubkey
+		}
+	}
+	return validators
+}
+
+func (v *ValidatorClient) proposerIndex(s *state.CachingBeaconState, slot uint64) (uint64, error) {
+	if state.Epoch(s) == slot/v.beaconCfg.SlotsPerEpoch {
+		return s.GetBeaconProposerIndexForSlot(slot)
+	}
+	// the head is in an older epoch: the proposer depends on the effective balances after the epoch transition.
+	cpy, err := s.Copy()
+	if err != nil {
+		return 0, err
+	}
+	if err := transition.DefaultMachine.ProcessSlots(cpy, slot); err != nil {
+		return 0, err
+	}
+	return cpy.GetBeaconProposerIndex()
+}
+
+// ProposeBlock produces, signs and publishes a block if one of our validators is the proposer of slot.
+func (v *ValidatorClient) ProposeBlock(ctx context.Context, slot uint64) error {
+	s := v.syncedData.HeadState()
+	if s == nil {
+		return ErrHeadStateNotAvailable
+	}
+	proposerIndex, err := v.proposerIndex(s, slot)
+	if err != nil {
+		return err
+	}
+	pubkey, ok := v.ourValidators(s)[proposerIndex]
+	if !ok {
+		return nil
+	}
+	epoch := slot / v.beaconCfg.SlotsPerEpoch
+
+	randaoDomain, err := s.GetDomain(v.beaconCfg.DomainRandao, epoch)
+	if err != nil {
+		return err
+	}
+	randaoReveal, err := v.signer.Sign(ctx, pubkey, utils.Sha256(merkle_tree.Uint64Root(epoch).Bytes(), randaoDomain))
+	if err != nil {
+		return err
+	}
+	// a zero boost factor makes the node always pick the locally built payload
+	block, err := v.node.ProduceBlock(ctx, slot, 0, randaoReveal, v.graffiti)
+	if err != nil {
+		return err
+	}
+	if block.IsBlinded() {
+		return errors.New("blinded blocks are not supported by the validator client")
+	}
+	if block.ProposerIndex != proposerIndex {
+		return fmt.Errorf("produced block has proposer %d, expected %d", block.ProposerIndex, proposerIndex)
+	}
+	beaconBlock := block.ToExecution().Block
+
+	domain, err := s.GetDomain(v.beaconCfg.DomainBeaconProposer, epoch)
+	if err != nil {
+		return err
+	}
+	signingRoot, err := fork.ComputeSigningRoot(beaconBlock, domain)
+	if err != nil {
+		return err
+	}
+	if err := v.protection.CheckAndRecordBlock(ctx, pubkey, slot, signingRoot); err != nil {
+		return err
+	}
+	signature, err := v.signer.Sign(ctx, pubkey, signingRoot)
+	if err != nil {
+		return err
+	}
+	if err := v.node.PublishBlock(ctx, &cltypes.SignedBeaconBlock{Block: beaconBlock, Signature: signature}); err != nil {
+		return err
+	}
+	v.logger.Info("[Validator] proposed block", "slot", slot, "validator", proposerIndex)
+	return nil
+}
+
+// Attest signs and submits the attestations of our validators for slot.
+func (v *ValidatorClient) Attest(ctx context.Context, slot uint64) error {
+	s := v.syncedData.HeadState()
+	if s == nil {
+		return ErrHeadStateNotAvailable
+	}
+	ours := v.ourValidators(s)
+	epoch := slot / v.beaconCfg.SlotsPerEpoch
+	isElectra := v.beaconCfg.GetCurrentStateVersion(epoch) >= clparams.ElectraVersion
+
+	v.attestedMutex.Lock()
+	defer v.attestedMutex.Unlock()
+	v.attestedSlot, v.attested = slot, nil
+
+	committeeCount := s.CommitteeCount(epoch)
+	for committeeIndex := uint64(0); committeeIndex < committeeCount; committeeIndex++ {
+		committee, err := s.GetBeaconCommitee(slot, committeeIndex)
+		if err != nil {
+			return err
+		}
+		for position, validatorIndex := range committee {
+			pubkey, ok := ours[validatorIndex]
+			if !ok {
+				continue
+			}
+			duty := attesterDuty{
+				pubkey:          pubkey,
+				validatorIndex:  validatorIndex,
+				committeeIndex:  committeeIndex,
+				committeeLength: uint64(len(committee)),
+			}
+			if err := v.attest(ctx, s, slot, position, isElectra, &duty); err != nil {
+				v.logger.Warn("[Validator] failed to attest", "slot", slot, "validator", validatorIndex, "err", err)
+				continue
+			}
+			v.attested = append(v.attested, duty)
+		}
+	}
+	return nil
+}
+
+func (v *ValidatorClient) attest(ctx context.Context, s *state.CachingBeaconState, slot uint64, position int, isElectra bool, duty *attesterDuty) error {
+	dataCommitteeIndex := duty.committeeIndex
+	if isElectra {
+		// EIP-7549: the committee index moves out of the signed data
+		dataCommitteeIndex = 0
+	}
+	data, err := v.node.ProduceAttestationData(slot, dataCommitteeIndex)
+	if err != nil {
+		return err
+	}
+	domain, err := s.GetDomain(v.beaconCfg.DomainBeaconAttester, data.Target().Epoch())
+	if err != nil {
+		return err
+	}
+	signingRoot, err := fork.ComputeSigningRoot(data, domain)
+	if err != nil {
+		return err
+	}
+	if err := v.protection.CheckAndRecordAttestation(ctx, duty.pubkey, data.Source().Epoch(), data.Target().Epoch(), signingRoot); err != nil {
+		return err
+	}
+	signature, err := v.signer.Sign(ctx, duty.pubkey, signingRoot)
+	if err != nil {
+		return err
+	}
+	// bitlist with our bit set and the length bit right after the committee
+	aggregationBits := make([]byte, duty.committeeLength/8+1)
+	aggregationBits[position/8] |= 1 << (position % 8)
+	aggregationBits[duty.committeeLength/8] |= 1 << (duty.committeeLength % 8)
+	attestation := solid.NewAttestionFromParameters(aggregationBits, data, signature)
+	if isElectra {
+		committeeBits := make([]byte, 8)
+		committeeBits[duty.committeeIndex/8] |= 1 << (duty.committeeIndex % 8)
+		attestation.SetCommitteeBits(committeeBits)
+	}
+	if err := v.node.SubmitAttestation(ctx, attestation); err != nil {
+		return err
+	}
+	duty.attestationDataRoot, err = data.HashSSZ()
+	return err
+}
+
+// Aggregate publishes the aggregates of the committees we attested to in slot, for the validators selected as aggregators.
+func (v *ValidatorClient) Aggregate(ctx context.Context, slot uint64) error {
+	s := v.syncedData.HeadState()
+	if s == nil {
+		return ErrHeadStateNotAvailable
+	}
+	v.attestedMutex.Lock()
+	defer v.attestedMutex.Unlock()
+	if v.attestedSlot != slot {
+		return nil
+	}
+	epoch := slot / v.beaconCfg.SlotsPerEpoch
+	selectionDomain, err := s.GetDomain(v.beaconCfg.DomainSelectionProof, epoch)
+	if err != nil {
+		return err
+	}
+	aggregateDomain, err := s.GetDomain(v.beaconCfg.DomainAggregateAndProof, epoch)
+	if err != nil {
+		return err
+	}
+	for _, duty := range v.attested {
+		selectionProof, err := v.signer.Sign(ctx, duty.pubkey, utils.Sha256(merkle_tree.Uint64Root(slot).Bytes(), selectionDomain))
+		if err != nil {
+			return err
+		}
+		if !state.IsAggregator(v.beaconCfg, duty.committeeLength, duty.committeeIndex, selectionProof) {
+			continue
+		}
+		aggregate := v.node.GetAggregateAttestation(duty.attestationDataRoot)
+		if aggregate == nil {
+			v.logger.Debug("[Validator] no aggregate to publish", "slot", slot, "validator", duty.validatorIndex)
+			continue
+		}
+		aggregateAndProof := &cltypes.AggregateAndProof{
+			AggregatorIndex: duty.validatorIndex,
+			Aggregate:       aggregate,
+			SelectionProof:  selectionProof,
+		}
+		signingRoot, err := fork.ComputeSigningRoot(aggregateAndProof, aggregateDomain)
+		if err != nil {
+			return err
+		}
+		signature, err := v.signer.Sign(ctx, duty.pubkey, signingRoot)
+		if err != nil {
+			return err
+		}
+		if err := v.node.SubmitAggregateAndProof(ctx, &cltypes.SignedAggregateAndProof{Message: aggregateAndProof, Signature: signature}); err != nil {
+			v.logger.Warn("[Validator] failed to publish aggregate", "slot", slot, "validator", duty.validatorIndex, "err", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package validator_client_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Giulio2002/bls"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/cl/antiquary/tests"
+	"github.com/erigontech/erigon/cl/beacon/synced_data"
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/cltypes/solid"
+	"github.com/erigontech/erigon/cl/fork"
+	"github.com/erigontech/erigon/cl/phase1/core/state"
+	"github.com/erigontech/erigon/cl/validator/slashing_protection"
+	"github.com/erigontech/erigon/cl/validator/validator_client"
+	"github.com/erigontech/erigon/cl/validator/validator_client/mock_services"
+)
+
+type testSetup struct {
+	headState  *state.CachingBeaconState
+	blocks     []*cltypes.SignedBeaconBlock
+	node       *mock_services.MockBeaconNode
+	client     *validator_client.ValidatorClient
+	signer     validator_client.Signer
+	validators []uint64
+}
+
+// setupValidatorClient gives the keys of the mock signer to the given validators of the head state.
+func setupValidatorClient(t *testing.T, pickValidators func(s *state.CachingBeaconState) []uint64) *testSetup {
+	blocks, _, headState := tests.GetCapellaRandom()
+	validators := pickValidators(headState)
+	signer := validator_client.NewMockSigner(len(validators))
+	for i, validatorIndex := range validators {
+		validator := headState.ValidatorSet().Get(int(validatorIndex))
+		validator.SetPublicKey(signer.PublicKeys()[i])
+		headState.SetValidatorAtIndex(int(validatorIndex), validator)
+	}
+	require.NoError(t, headState.InitBeaconState())
+
+	syncedData := synced_data.NewSyncedDataManager(true, &clparams.MainnetBeaconConfig)
+	require.NoError(t, syncedData.OnHeadState(headState))
+	protection, err := slashing_protection.Open(context.Background(), t.TempDir(), headState.GenesisValidatorsRoot(), log.New())
+	require.NoError(t, err)
+	t.Cleanup(protection.Close)
+
+	node := mock_services.NewMockBeaconNode(gomock.NewController(t))
+	client := validator_client.NewValidatorClient(&clparams.MainnetBeaconConfig, nil, syncedData, node, signer, protection, libcommon.Hash{}, log.New())
+	return &testSetup{headState: headState, blocks: blocks, node: node, client: client, signer: signer, validators: validators}
+}
+
+func verifySignature(t *testing.T, signature libcommon.Bytes96, signingRoot [32]byte, pubkey [48]byte) {
+	valid, err := bls.Verify(signature[:], signingRoot[:], pubkey[:])
+	require.NoError(t, err)
+	require.True(t, valid)
+}
+
+func TestAttestAndAggregate(t *testing.T) {
+	ctx := context.Background()
+	var slot uint64
+	setup := setupValidatorClient(t, func(s *state.CachingBeaconState) []uint64 {
+		slot = s.Slot()
+		committee, err := s.GetBeaconCommitee(slot, 0)
+		require.NoError(t, err)
+		return committee
+	})
+	s := setup.headState
+	data := solid.NewAttestionDataFromParameters(slot, 0, libcommon.Hash{1}, s.CurrentJustifiedCheckpoint(),
+		solid.NewCheckpointFromParameters(libcommon.Hash{2}, state.Epoch(s)))
+	domain, err := s.GetDomain(s.BeaconConfig().DomainBeaconAttester, state.Epoch(s))
+	require.NoError(t, err)
+	signingRoot, err := fork.ComputeSigningRoot(data, domain)
+	require.NoError(t, err)
+	dataRoot, err := data.HashSSZ()
+	require.NoError(t, err)
+
+	var submitted []*solid.Attestation
+	setup.node.EXPECT().ProduceAttestationData(slot, uint64(0)).Return(data, nil).Times(len(setup.validators))
+	setup.node.EXPECT().SubmitAttestation(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, att *solid.Attestation) error {
+		submitted = append(submitted, att)
+		return nil
+	}).Times(len(setup.validators))
+	require.NoError(t, setup.client.Attest(ctx, slot))
+
+	require.Len(t, submitted, len(setup.validators))
+	for i, att := range submitted {
+		// one bit per attestation, at the position of the validator in the committee
+		bits := att.AggregationBits()
+		require.Equal(t, byte(1<<(i%8)), bits[i/8]&(1<<(i%8)))
+		verifySignature(t, att.Signature(), signingRoot, s.ValidatorSet().Get(int(setup.validators[i])).PublicKey())
+	}
+
+	// aggregators publish the aggregate of the committee
+	aggregate := submitted[0]
+	setup.node.EXPECT().GetAggregateAttestation(libcommon.Hash(dataRoot)).Return(aggregate).AnyTimes()
+	setup.node.EXPECT().SubmitAggregateAndProof(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, signed *cltypes.SignedAggregateAndProof) error {
+		require.Equal(t, aggregate, signed.Message.Aggregate)
+		require.True(t, state.IsAggregator(s.BeaconConfig(), uint64(len(setup.validators)), 0, signed.Message.SelectionProof))
+		aggregateDomain, err := s.GetDomain(s.BeaconConfig().DomainAggregateAndProof, state.Epoch(s))
+		require.NoError(t, err)
+		aggregateSigningRoot, err := fork.ComputeSigningRoot(signed.Message, aggregateDomain)
+		require.NoError(t, err)
+		verifySignature(t, signed.Signature, aggregateSigningRoot, s.ValidatorSet().Get(int(signed.Message.AggregatorIndex)).PublicKey())
+		return nil
+	}).AnyTimes()
+	require.NoError(t, setup.client.Aggregate(ctx, slot))
+
+	// a different vote for the same target is refused by slashing protection
+	conflicting := solid.NewAttestionDataFromParameters(slot, 0, libcommon.Hash{3}, s.CurrentJustifiedCheckpoint(),
+		solid.NewCheckpointFromParameters(libcommon.Hash{4}, state.Epoch(s)))
+	setup.node.EXPECT().ProduceAttestationData(slot, uint64(0)).Return(conflicting, nil).Times(len(setup.validators))
+	require.NoError(t, setup.client.Attest(ctx, slot))
+}
+
+func TestProposeBlock(t *testing.T) {
+	ctx := context.Background()
+	var (
+		slot          uint64
+		proposerIndex uint64
+	)
+	setup := setupValidatorClient(t, func(s *state.CachingBeaconState) []uint64 {
+		slot = s.Slot() + 1
+		if slot%s.BeaconConfig().SlotsPerEpoch == 0 {
+			slot = s.Slot()
+		}
+		var err error
+		proposerIndex, err = s.GetBeaconProposerIndexForSlot(slot)
+		require.NoError(t, err)
+		return []uint64{proposerIndex}
+	})
+	s := setup.headState
+	body := setup.blocks[1].Block.Body
+	producedBlock := &cltypes.BlindOrExecutionBeaconBlock{
+		Slot:          slot,
+		ProposerIndex: proposerIndex,
+		ParentRoot:    libcommon.Hash{1},
+		BeaconBody:    body,
+		Cfg:           s.BeaconConfig(),
+	}
+	setup.node.EXPECT().ProduceBlock(gomock.Any(), slot, uint64(0), gomock.Any(), libcommon.Hash{}).Return(producedBlock, nil)
+	setup.node.EXPECT().PublishBlock(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, signed *cltypes.SignedBeaconBlock) error {
+		require.Equal(t, slot, signed.Block.Slot)
+		domain, err := s.GetDomain(s.BeaconConfig().DomainBeaconProposer, state.Epoch(s))
+		require.NoError(t, err)
+		signingRoot, err := fork.ComputeSigningRoot(signed.Block, domain)
+		require.NoError(t, err)
+		verifySignature(t, signed.Signature, signingRoot, setup.signer.PublicKeys()[0])
+		return nil
+	}).Times(1)
+	require.NoError(t, setup.client.ProposeBlock(ctx, slot))
+
+	// a second, different block for the same slot must not be signed
+	doubleProposal := *producedBlock
+	doubleProposal.ParentRoot = libcommon.Hash{2}
+	setup.node.EXPECT().ProduceBlock(gomock.Any(), slot, uint64(0), gomock.Any(), libcommon.Hash{}).Return(&doubleProposal, nil)
+	require.ErrorIs(t, setup.client.ProposeBlock(ctx, slot), slashing_protection.ErrSlashableBlock)
+}
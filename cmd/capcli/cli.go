@@ -17,6 +17,7 @@
 package main
 
 import (
+	"bufio"
 	"context"
 	"encoding/binary"
 	"encoding/json"
@@ -27,6 +28,8 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -47,6 +50,7 @@ import (
 	"github.com/erigontech/erigon/cl/clparams"
 	"github.com/erigontech/erigon/cl/clparams/initial_state"
 	"github.com/erigontech/erigon/cl/cltypes"
+	"github.com/erigontech/erigon/cl/era"
 	"github.com/erigontech/erigon/cl/persistence/beacon_indicies"
 	"github.com/erigontech/erigon/cl/persistence/format/snapshot_format"
 	"github.com/erigontech/erigon/cl/persistence/format/snapshot_format/getters"
@@ -78,6 +82,8 @@ var CLI struct {
 	BlobArchiveStoreCheck   BlobArchiveStoreCheck   `cmd:"" help:"blob archive store check"`
 	DumpBlobsSnapshots      DumpBlobsSnapshots      `cmd:"" help:"dump blobs snapshots"`
 	CheckBlobsSnapshots     CheckBlobsSnapshots     `cmd:"" help:"check blobs snapshots"`
+	ExportEra               ExportEra               `cmd:"" help:"export archived blocks and states to .era files"`
+	ImportEra               ImportEra               `cmd:"" help:"verify .era files and import their blocks"`
 }
 
 type chainCfg struct {
@@ -176,7 +182,7 @@ func (c *Chain) Run(ctx *Context) error {
 	}
 
 	downloader := network.NewBackwardBeaconDownloader(ctx, beacon, nil, nil, db)
-	cfg := stages.StageHistoryReconstruction(downloader, antiquary.NewAntiquary(ctx, nil, nil, nil, nil, dirs, nil, nil, nil, nil, nil, false, false, false, nil), csn, db, nil, beaconConfig, true, false, true, bRoot, bs.Slot(), "/tmp", 300*time.Millisecond, nil, nil, blobStorage, log.Root())
+	cfg := stages.StageHistoryReconstruction(downloader, antiquary.NewAntiquary(ctx, nil, nil, nil, nil, dirs, nil, nil, nil, nil, nil, false, false, false, nil), csn, db, nil, beaconConfig, true, false, true, bRoot, bs.Slot(), "/tmp", 300*time.Millisecond, nil, nil, blobStorage, "", nil, log.Root())
 	return stages.SpawnStageHistoryDownload(cfg, ctx, log.Root())
 }
 
@@ -578,7 +584,7 @@ func (r *RetrieveHistoricalState) Run(ctx *Context) error {
 		return err
 	}
 
-	hr := historical_states_reader.NewHistoricalStatesReader(beaconConfig, snr, vt, gSpot)
+	hr := historical_states_reader.NewHistoricalStatesReader(beaconConfig, snr, vt, gSpot, 0)
 	start := time.Now()
 	haveState, err := hr.ReadHistoricalState(ctx, tx, r.CompareSlot)
 	if err != nil {
@@ -1045,3 +1051,177 @@ func (c *CheckBlobsSnapshots) Run(ctx *Context) error {
 	}
 	return nil
 }
+
+type ExportEra struct {
+	chainCfg
+	outputFolder
+	From   uint64  `help:"first era to export" default:"0"`
+	To     *uint64 `help:"last era to export, defaults to the last archived era"`
+	Output string  `help:"folder where to write the .era files" default:"era"`
+}
+
+func (e *ExportEra) Run(ctx *Context) error {
+	vt := state_accessors.NewStaticValidatorTable()
+	_, beaconConfig, t, err := clparams.GetConfigsByNetworkName(e.Chain)
+	if err != nil {
+		return err
+	}
+	log.Root().SetHandler(log.LvlFilterHandler(log.LvlInfo, log.StderrHandler))
+
+	dirs := datadir.New(e.Datadir)
+	db, _, err := caplin1.OpenCaplinDatabase(ctx, beaconConfig, nil, dirs.CaplinIndexing, dirs.CaplinBlobs, nil, false, 0)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	tx, err := db.BeginRo(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	allSnapshots := freezeblocks.NewRoSnapshots(ethconfig.BlocksFreezing{}, dirs.Snap, 0, log.Root())
+	if err := allSnapshots.ReopenFolder(); err != nil {
+		return err
+	}
+	if err := state_accessors.ReadValidatorsTable(tx, vt); err != nil {
+		return err
+	}
+
+	var bor *freezeblocks.BorRoSnapshots
+	blockReader := freezeblocks.NewBlockReader(allSnapshots, bor)
+	eth1Getter := getters.NewExecutionSnapshotReader(ctx, blockReader, db)
+	eth1Getter.SetBeaconChainConfig(beaconConfig)
+	csn := freezeblocks.NewCaplinSnapshots(ethconfig.BlocksFreezing{}, beaconConfig, dirs, log.Root())
+	if err := csn.ReopenFolder(); err != nil {
+		return err
+	}
+	snr := freezeblocks.NewBeaconSnapshotReader(csn, eth1Getter, beaconConfig)
+	gSpot, err := initial_state.GetGenesisState(t)
+	if err != nil {
+		return err
+	}
+	hr := historical_states_reader.NewHistoricalStatesReader(beaconConfig, snr, vt, gSpot, 0)
+
+	var to uint64
+	if e.To != nil {
+		to = *e.To
+	} else {
+		processed, err := state_accessors.GetStateProcessingProgress(tx)
+		if err != nil {
+			return err
+		}
+		to = processed / beaconConfig.SlotsPerHistoricalRoot
+	}
+	if err := os.MkdirAll(e.Output, 0755); err != nil {
+		return err
+	}
+
+	for eraNumber := e.From; eraNumber <= to; eraNumber++ {
+		eraState, err := hr.ReadHistoricalState(ctx, tx, era.StateSlot(beaconConfig, eraNumber))
+		if err != nil {
+			return err
+		}
+		if eraState == nil {
+			return fmt.Errorf("state for era %d is not archived", eraNumber)
+		}
+		root := eraState.GenesisValidatorsRoot()
+		if eraNumber > 0 {
+			summary, err := era.HistoricalSummary(eraState)
+			if err != nil {
+				return err
+			}
+			if root, err = summary.HashSSZ(); err != nil {
+				return err
+			}
+		}
+		path := filepath.Join(e.Output, era.Filename(beaconConfig, eraNumber, root))
+		if err := exportEra(ctx, tx, snr, beaconConfig, eraNumber, eraState, path); err != nil {
+			return err
+		}
+		log.Info("Exported era", "era", eraNumber, "file", path)
+	}
+	return nil
+}
+
+func exportEra(ctx context.Context, tx kv.Tx, snr freezeblocks.BeaconSnapshotReader, beaconConfig *clparams.BeaconChainConfig, eraNumber uint64, eraState *state.CachingBeaconState, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w := bufio.NewWriter(f)
+	eraWriter, err := era.NewWriter(w, beaconConfig, eraNumber)
+	if err != nil {
+		return err
+	}
+	if eraNumber > 0 {
+		startSlot := era.StartSlot(beaconConfig, eraNumber)
+		for slot := startSlot; slot < era.StateSlot(beaconConfig, eraNumber); slot++ {
+			block, err := snr.ReadBlockBySlot(ctx, tx, slot)
+			if err != nil {
+				return err
+			}
+			if block == nil {
+				continue
+			}
+			if err := eraWriter.WriteBlock(block); err != nil {
+				return err
+			}
+		}
+	}
+	if err := eraWriter.Finalize(eraState); err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+type ImportEra struct {
+	chainCfg
+	outputFolder
+	Input string `help:"folder containing the .era files" default:"era"`
+}
+
+func (i *ImportEra) Run(ctx *Context) error {
+	_, beaconConfig, networkType, err := clparams.GetConfigsByNetworkName(i.Chain)
+	if err != nil {
+		return err
+	}
+	log.Root().SetHandler(log.LvlFilterHandler(log.LvlInfo, log.StderrHandler))
+
+	// eras are verified against the historical roots and summaries of the finalized checkpoint.
+	trusted, err := checkpoint_sync.NewRemoteCheckpointSync(beaconConfig, networkType).GetLatestBeaconState(ctx)
+	if err != nil {
+		return err
+	}
+	dirs := datadir.New(i.Datadir)
+	db, _, err := caplin1.OpenCaplinDatabase(ctx, beaconConfig, nil, dirs.CaplinIndexing, dirs.CaplinBlobs, nil, false, 0)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	files, err := filepath.Glob(filepath.Join(i.Input, beaconConfig.ConfigName+"-*.era"))
+	if err != nil {
+		return err
+	}
+	// the era number is zero padded, so lexicographic order is era order.
+	sort.Strings(files)
+	for _, file := range files {
+		r, err := era.Open(file, beaconConfig)
+		if err != nil {
+			return err
+		}
+		imported, err := era.ImportBlocks(ctx, db, r, trusted)
+		r.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+		log.Info("Imported era", "era", r.Era(), "blocks", imported)
+	}
+	return nil
+}
@@ -32,6 +32,7 @@ import (
 	"golang.org/x/sync/semaphore"
 
 	proto_downloader "github.com/erigontech/erigon-lib/gointerfaces/downloaderproto"
+	sentinelrpc "github.com/erigontech/erigon-lib/gointerfaces/sentinelproto"
 	"github.com/erigontech/erigon/cl/aggregation"
 	"github.com/erigontech/erigon/cl/antiquary"
 	"github.com/erigontech/erigon/cl/beacon"
@@ -41,6 +42,7 @@ import (
 	"github.com/erigontech/erigon/cl/clparams/initial_state"
 	"github.com/erigontech/erigon/cl/cltypes"
 	"github.com/erigontech/erigon/cl/cltypes/solid"
+	"github.com/erigontech/erigon/cl/das"
 	"github.com/erigontech/erigon/cl/monitor"
 	"github.com/erigontech/erigon/cl/rpc"
 	"github.com/erigontech/erigon/cl/sentinel"
@@ -48,9 +50,13 @@ import (
 	"github.com/erigontech/erigon/cl/utils/eth_clock"
 	"github.com/erigontech/erigon/cl/validator/attestation_producer"
 	"github.com/erigontech/erigon/cl/validator/committee_subscription"
+	"github.com/erigontech/erigon/cl/validator/keystore"
+	"github.com/erigontech/erigon/cl/validator/slashing_protection"
 	"github.com/erigontech/erigon/cl/validator/sync_contribution_pool"
+	"github.com/erigontech/erigon/cl/validator/validator_client"
 	"github.com/erigontech/erigon/cl/validator/validator_params"
 	"github.com/erigontech/erigon/eth/ethconfig"
+	"github.com/erigontech/erigon/p2p/enode"
 	"github.com/erigontech/erigon/params"
 	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
 
@@ -74,7 +80,9 @@ import (
 
 	"github.com/Giulio2002/bls"
 
+	libcommon "github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/length"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/mdbx"
 	"github.com/erigontech/erigon/cl/clparams"
@@ -267,6 +275,20 @@ func RunCaplinService(ctx context.Context, engine execution_client.ExecutionEngi
 		logger.Error("Could not create forkchoice", "err", err)
 		return err
 	}
+	if config.ForkChoiceRecordFile != "" {
+		f, err := os.Create(config.ForkChoiceRecordFile)
+		if err != nil {
+			return err
+		}
+		recorder, err := forkchoice.NewRecorder(f, state)
+		if err != nil {
+			f.Close()
+			return err
+		}
+		defer recorder.Close()
+		forkChoice.SetRecorder(recorder)
+		logger.Info("[Forkchoice] Recording inputs", "file", config.ForkChoiceRecordFile)
+	}
 	bls.SetEnabledCaching(true)
 	state.ForEachValidator(func(v solid.Validator, idx, total int) bool {
 		pk := v.PublicKey()
@@ -282,7 +304,7 @@ func RunCaplinService(ctx context.Context, engine execution_client.ExecutionEngi
 	}
 	activeIndicies := state.GetActiveValidatorsIndices(state.Slot() / beaconConfig.SlotsPerEpoch)
 
-	sentinel, err := service.StartSentinelService(&sentinel.SentinelConfig{
+	sentinel, peerScorer, err := service.StartSentinelService(&sentinel.SentinelConfig{
 		IpAddr:         config.CaplinDiscoveryAddr,
 		Port:           int(config.CaplinDiscoveryPort),
 		TCPPort:        uint(config.CaplinDiscoveryTCPPort),
@@ -312,6 +334,19 @@ func RunCaplinService(ctx context.Context, engine execution_client.ExecutionEngi
 	// Define gossip services
 	blockService := services.NewBlockService(ctx, indexDB, forkChoice, syncedDataManager, ethClock, beaconConfig, emitters)
 	blobService := services.NewBlobSidecarService(ctx, beaconConfig, forkChoice, syncedDataManager, ethClock, emitters, false)
+	dataColumnSidecarService := services.NewDataColumnSidecarService(ctx, beaconConfig, forkChoice, syncedDataManager, ethClock, blobStorage, false)
+	if beaconConfig.Eip7594ForkEpoch != math.MaxUint64 {
+		// the columns we custody, and sample, depend on our node id.
+		identity, err := sentinel.Identity(ctx, &sentinelrpc.EmptyMessage{})
+		if err != nil {
+			return err
+		}
+		localNode, err := enode.Parse(enode.ValidSchemes, identity.Enr)
+		if err != nil {
+			return err
+		}
+		forkChoice.SetDataColumnsCustody(das.NewCustody(beaconConfig, localNode.ID(), beaconConfig.CustodyRequirement))
+	}
 	syncCommitteeMessagesService := services.NewSyncCommitteeMessagesService(beaconConfig, ethClock, syncedDataManager, syncContributionPool, false)
 	attestationService := services.NewAttestationService(ctx, forkChoice, committeeSub, ethClock, syncedDataManager, beaconConfig, networkConfig, emitters, batchSignatureVerifier)
 	syncContributionService := services.NewSyncContributionService(syncedDataManager, beaconConfig, syncContributionPool, ethClock, emitters, false)
@@ -326,7 +361,7 @@ func RunCaplinService(ctx context.Context, engine execution_client.ExecutionEngi
 
 	// Create the gossip manager
 	gossipManager := network.NewGossipReceiver(sentinel, forkChoice, beaconConfig, networkConfig, ethClock, emitters, committeeSub,
-		blockService, blobService, syncCommitteeMessagesService, syncContributionService, aggregateAndProofService,
+		blockService, blobService, dataColumnSidecarService, syncCommitteeMessagesService, syncContributionService, aggregateAndProofService,
 		attestationService, voluntaryExitService, blsToExecutionChangeService, proposerSlashingService)
 	{ // start ticking forkChoice
 		go func() {
@@ -396,9 +431,9 @@ func RunCaplinService(ctx context.Context, engine execution_client.ExecutionEngi
 		return err
 	}
 
-	statesReader := historical_states_reader.NewHistoricalStatesReader(beaconConfig, rcsn, vTables, genesisState)
+	statesReader := historical_states_reader.NewHistoricalStatesReader(beaconConfig, rcsn, vTables, genesisState, config.HistoricalStatesCacheSize)
 	validatorParameters := validator_params.NewValidatorParams()
-	if config.BeaconAPIRouter.Active {
+	if config.BeaconAPIRouter.Active || config.EnableValidatorClient {
 		apiHandler := handler.NewApiHandler(
 			logger,
 			networkConfig,
@@ -431,11 +466,19 @@ func RunCaplinService(ctx context.Context, engine execution_client.ExecutionEngi
 			proposerSlashingService,
 			option.builderClient,
 			validatorMonitor,
+			peerScorer,
 		)
-		go beacon.ListenAndServe(&beacon.LayeredBeaconHandler{
-			ArchiveApi: apiHandler,
-		}, config.BeaconAPIRouter)
-		log.Info("Beacon API started", "addr", config.BeaconAPIRouter.Address)
+		if config.BeaconAPIRouter.Active {
+			go beacon.ListenAndServe(&beacon.LayeredBeaconHandler{
+				ArchiveApi: apiHandler,
+			}, config.BeaconAPIRouter)
+			log.Info("Beacon API started", "addr", config.BeaconAPIRouter.Address)
+		}
+		if config.EnableValidatorClient {
+			if err := startValidatorClient(ctx, config, dirs, beaconConfig, ethClock, syncedDataManager, apiHandler, validatorMonitor, logger); err != nil {
+				return err
+			}
+		}
 	}
 
 	stageCfg := stages.ClStagesCfg(
@@ -454,6 +497,7 @@ func RunCaplinService(ctx context.Context, engine execution_client.ExecutionEngi
 		config.LoopBlockLimit,
 		backfilling,
 		blobBackfilling,
+		config.EraDir,
 		syncedDataManager,
 		emitters,
 		blobStorage,
@@ -470,3 +514,67 @@ func RunCaplinService(ctx context.Context, engine execution_client.ExecutionEngi
 	}
 	return err
 }
+
+// startValidatorClient runs the embedded validator client against the in-process beacon API handler.
+func startValidatorClient(ctx context.Context, config clparams.CaplinConfig, dirs datadir.Dirs, beaconConfig *clparams.BeaconChainConfig,
+	ethClock eth_clock.EthereumClock, syncedDataManager *synced_data.SyncedDataManager, node validator_client.BeaconNode,
+	validatorMonitor monitor.ValidatorMonitor, logger log.Logger) error {
+	if len(config.ValidatorGraffiti) > length.Hash {
+		return fmt.Errorf("validator graffiti is longer than %d bytes", length.Hash)
+	}
+	var graffiti libcommon.Hash
+	copy(graffiti[:], config.ValidatorGraffiti)
+
+	password, err := keystore.ReadPasswordFile(config.ValidatorPasswordFile)
+	if err != nil {
+		return fmt.Errorf("could not read validator password file: %w", err)
+	}
+	signer, err := validator_client.NewSignerFromKeystores(config.ValidatorKeystoresDir, password)
+	if err != nil {
+		return fmt.Errorf("could not load validator keystores: %w", err)
+	}
+	// our own validators are always worth monitoring
+	for _, pubkey := range signer.PublicKeys() {
+		validatorMonitor.ObserveValidatorPubkey(pubkey)
+	}
+
+	protection, err := slashing_protection.Open(ctx, path.Join(dirs.DataDir, "caplin", "slashing-protection"), ethClock.GenesisValidatorsRoot(), logger)
+	if err != nil {
+		return err
+	}
+	if config.SlashingProtectionImportFile != "" {
+		f, err := os.Open(config.SlashingProtectionImportFile)
+		if err != nil {
+			protection.Close()
+			return err
+		}
+		err = protection.Import(ctx, f)
+		f.Close()
+		if err != nil {
+			protection.Close()
+			return fmt.Errorf("could not import slashing protection interchange: %w", err)
+		}
+	}
+
+	vc := validator_client.NewValidatorClient(beaconConfig, ethClock, syncedDataManager, node, signer, protection, graffiti, logger)
+	go func() {
+		defer protection.Close()
+		if err := vc.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			logger.Error("[Caplin] validator client stopped", "err", err)
+		}
+		if config.SlashingProtectionExportFile == "" {
+			return
+		}
+		f, err := os.Create(config.SlashingProtectionExportFile)
+		if err != nil {
+			logger.Error("[Caplin] could not export slashing protection", "err", err)
+			return
+		}
+		defer f.Close()
+		// the node context is done by now, the export must still complete
+		if err := protection.Export(context.Background(), f); err != nil {
+			logger.Error("[Caplin] could not export slashing protection", "err", err)
+		}
+	}()
+	return nil
+}
@@ -51,6 +51,9 @@ type CaplinCliCfg struct {
 	MevRelayUrl           string        `json:"mev_relay_url"`
 	CustomConfig          string        `json:"custom_config"`
 	CustomGenesisState    string        `json:"custom_genesis_state"`
+	ForkChoiceRecord      string        `json:"forkchoice_record"`
+	EraDir                string        `json:"era_dir"`
+	HistoricalStatesCache int           `json:"historical_states_cache"`
 	JwtSecret             []byte
 
 	AllowedMethods   []string `json:"allowed_methods"`
@@ -107,6 +110,10 @@ func SetupCaplinCli(ctx *cli.Context) (cfg *CaplinCliCfg, err error) {
 	cfg.CustomConfig = ctx.String(caplinflags.CustomConfig.Name)
 	cfg.CustomGenesisState = ctx.String(caplinflags.CustomGenesisState.Name)
 
+	cfg.ForkChoiceRecord = ctx.String(caplinflags.ForkChoiceRecord.Name)
+	cfg.EraDir = ctx.String(caplinflags.EraDir.Name)
+	cfg.HistoricalStatesCache = ctx.Int(caplinflags.HistoricalStatesCache.Name)
+
 	return cfg, err
 }
 
@@ -36,6 +36,9 @@ var CliFlags = []cli.Flag{
 	&JwtSecret,
 	&CustomConfig,
 	&CustomGenesisState,
+	&ForkChoiceRecord,
+	&EraDir,
+	&HistoricalStatesCache,
 	&utils.DataDirFlag,
 	&utils.BeaconApiAllowCredentialsFlag,
 	&utils.BeaconApiAllowMethodsFlag,
@@ -116,4 +119,19 @@ var (
 		Usage: "Path to custom genesis state file",
 		Value: "",
 	}
+	ForkChoiceRecord = cli.StringFlag{
+		Name:  "forkchoice-record",
+		Usage: "File where every forkchoice input is recorded, to be replayed with the replay-forkchoice command",
+		Value: "",
+	}
+	EraDir = cli.StringFlag{
+		Name:  "era-dir",
+		Usage: "Directory of .era files the beacon chain history is imported from before downloading it from peers",
+		Value: "",
+	}
+	HistoricalStatesCache = cli.IntFlag{
+		Name:  "historical-states-cache",
+		Usage: "Amount of reconstructed historical states kept in memory, 0 disables the cache",
+		Value: 0,
+	}
 )
@@ -43,6 +43,7 @@ import (
 
 func main() {
 	app := app.MakeApp("caplin", runCaplinNode, append(caplinflags.CliFlags, sentinelflags.CliFlags...))
+	app.Commands = append(app.Commands, replayForkChoiceCommand)
 	if err := app.Run(os.Args); err != nil {
 		_, printErr := fmt.Fprintln(os.Stderr, err)
 		if printErr != nil {
@@ -109,13 +110,16 @@ func runCaplinNode(cliCtx *cli.Context) error {
 	blockSnapBuildSema := semaphore.NewWeighted(int64(dbg.BuildSnapshotAllowance))
 
 	return caplin1.RunCaplinService(ctx, executionEngine, clparams.CaplinConfig{
-		CaplinDiscoveryAddr:    cfg.Addr,
-		CaplinDiscoveryPort:    uint64(cfg.Port),
-		CaplinDiscoveryTCPPort: uint64(cfg.ServerTcpPort),
-		BeaconAPIRouter:        rcfg,
-		NetworkId:              networkId,
-		MevRelayUrl:            cfg.MevRelayUrl,
-		CustomConfigPath:       cfg.CustomConfig,
-		CustomGenesisStatePath: cfg.CustomGenesisState,
+		CaplinDiscoveryAddr:       cfg.Addr,
+		CaplinDiscoveryPort:       uint64(cfg.Port),
+		CaplinDiscoveryTCPPort:    uint64(cfg.ServerTcpPort),
+		BeaconAPIRouter:           rcfg,
+		NetworkId:                 networkId,
+		MevRelayUrl:               cfg.MevRelayUrl,
+		CustomConfigPath:          cfg.CustomConfig,
+		CustomGenesisStatePath:    cfg.CustomGenesisState,
+		ForkChoiceRecordFile:      cfg.ForkChoiceRecord,
+		EraDir:                    cfg.EraDir,
+		HistoricalStatesCacheSize: cfg.HistoricalStatesCache,
 	}, cfg.Dirs, nil, nil, nil, blockSnapBuildSema)
 }
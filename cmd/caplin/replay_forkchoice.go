@@ -0,0 +1,90 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/erigontech/erigon/cl/clparams"
+	"github.com/erigontech/erigon/cl/phase1/forkchoice"
+	"github.com/erigontech/erigon/cmd/caplin/caplinflags"
+	"github.com/erigontech/erigon/cmd/utils"
+)
+
+var replayForkChoiceFileFlag = cli.StringFlag{
+	Name:     "file",
+	Usage:    "Forkchoice record to replay, as written with --" + caplinflags.ForkChoiceRecord.Name,
+	Required: true,
+}
+
+var replayForkChoiceCommand = &cli.Command{
+	Name:   "replay-forkchoice",
+	Usage:  "Replays a forkchoice record and prints the head and weight changes of every step",
+	Action: replayForkChoice,
+	Flags: []cli.Flag{
+		&replayForkChoiceFileFlag,
+		&utils.ChainFlag,
+		&caplinflags.CustomConfig,
+	},
+}
+
+func replayForkChoice(cliCtx *cli.Context) error {
+	var beaconConfig *clparams.BeaconChainConfig
+	if customConfig := cliCtx.String(caplinflags.CustomConfig.Name); customConfig != "" {
+		cfg, err := clparams.CustomConfig(customConfig)
+		if err != nil {
+			return err
+		}
+		beaconConfig = &cfg
+	} else {
+		var err error
+		if _, beaconConfig, _, err = clparams.GetConfigsByNetworkName(cliCtx.String(utils.ChainFlag.Name)); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.Open(cliCtx.String(replayForkChoiceFileFlag.Name))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+	return forkchoice.Replay(cliCtx.Context, beaconConfig, f, func(step forkchoice.ReplayStep) error {
+		// ticks which change nothing are the bulk of a record, skip them.
+		if step.Err == nil && !step.HeadChanged && len(step.WeightChanges) == 0 && step.Kind == forkchoice.RecordTick {
+			return nil
+		}
+		fmt.Fprintf(out, "#%d %s head=%x slot=%d", step.Index, step.Kind, step.HeadRoot, step.HeadSlot)
+		if step.HeadChanged {
+			fmt.Fprint(out, " (new head)")
+		}
+		if step.Err != nil {
+			fmt.Fprintf(out, " err=%q", step.Err)
+		}
+		fmt.Fprintln(out)
+		for _, change := range step.WeightChanges {
+			fmt.Fprintf(out, "\tweight %x slot=%d %d -> %d\n", change.BlockRoot, change.Slot, change.Old, change.New)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,291 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/spf13/cobra"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/dir"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/backup"
+	kv2 "github.com/erigontech/erigon-lib/kv/mdbx"
+	"github.com/erigontech/erigon-lib/kv/temporal"
+	"github.com/erigontech/erigon-lib/log/v3"
+	libstate "github.com/erigontech/erigon-lib/state"
+
+	"github.com/erigontech/erigon/eth/stagedsync/stages"
+	"github.com/erigontech/erigon/turbo/debug"
+	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
+)
+
+var cmdBackup = &cobra.Command{
+	Use:   "backup",
+	Short: "Backup of chaindata and of snapshot files which are consistent with it. Erigon must be stopped",
+	Long: `Backup of chaindata and of snapshot files which are visible at same moment.
+Takes datadir lock: Erigon must be stopped, otherwise it could merge or delete files which are listed in backup.
+Snapshot files are hardlinked (or copied if target is on another filesystem). If --to.datadir has previous backup - only new files are copied.
+Backup is complete only when it has manifest file: ` + backup.ManifestFileName,
+	Example: "go run ./cmd/integration backup --datadir=<datadir> --to.datadir=<backup_datadir>",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := debug.SetupCobra(cmd, "integration")
+		ctx, _ := common.RootContext()
+		dirs, l, err := datadir.New(datadirCli).MustFlock()
+		if err != nil {
+			logger.Error("Opening datadir", "error", err)
+			return
+		}
+		defer l.Unlock()
+		db, err := openDB(dbCfg(kv.ChainDB, chaindata), false, logger)
+		if err != nil {
+			logger.Error("Opening DB", "error", err)
+			return
+		}
+		defer db.Close()
+		sn, borSn, _, _ := allSnapshots(ctx, db, logger)
+
+		if err := doBackup(ctx, db, sn, borSn, dirs, datadir.New(toDatadirCli), backupChecksum, logger); err != nil {
+			if !errors.Is(err, context.Canceled) {
+				logger.Error(err.Error())
+			}
+			return
+		}
+	},
+}
+
+var cmdRestore = &cobra.Command{
+	Use:     "restore",
+	Short:   "Restore backup (created by `backup` command) into new datadir. Validates backup's manifest before and after restore",
+	Example: "go run ./cmd/integration restore --from.datadir=<backup_datadir> --datadir=<new_datadir>",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := debug.SetupCobra(cmd, "integration")
+		ctx, _ := common.RootContext()
+		dirs, l, err := datadir.New(datadirCli).MustFlock()
+		if err != nil {
+			logger.Error("Opening datadir", "error", err)
+			return
+		}
+		defer l.Unlock()
+		if err := doRestore(ctx, datadir.New(fromDatadirCli), dirs, logger); err != nil {
+			if !errors.Is(err, context.Canceled) {
+				logger.Error(err.Error())
+			}
+			return
+		}
+	},
+}
+
+func init() {
+	withDataDir(cmdBackup)
+	withToDatadir(cmdBackup)
+	cmdBackup.Flags().BoolVar(&backupChecksum, "checksum", false, "calc sha256 of each file and store it in manifest. slow: reads all files")
+	rootCmd.AddCommand(cmdBackup)
+
+	withDataDir(cmdRestore)
+	withFromDatadir(cmdRestore)
+	rootCmd.AddCommand(cmdRestore)
+}
+
+// backupSaltFiles - files which are not part of any snapshot, but required to open them
+var backupSaltFiles = []string{"salt-state.txt", "salt-blocks.txt"}
+
+// doBackup - caller must hold datadir lock: files of `sn`, `borSn` and of aggregator are this process's view of
+// datadir and nobody else may merge or delete them while they are copied
+func doBackup(ctx context.Context, db kv.RwDB, sn *freezeblocks.RoSnapshots, borSn *freezeblocks.BorRoSnapshots, dirs, toDirs datadir.Dirs, withChecksum bool, logger log.Logger) error {
+	if dirs.DataDir == toDirs.DataDir {
+		return fmt.Errorf("--datadir and --to.datadir must be different")
+	}
+
+	prev, err := backup.ReadManifestIfExists(toDirs.DataDir)
+	if err != nil {
+		return err
+	}
+	if prev != nil {
+		logger.Info("[backup] found previous backup, only new files will be copied", "created_at", prev.CreatedAt, "files", len(prev.Files))
+	}
+	// target is going to change: it's not a complete backup anymore
+	if err := backup.RemoveManifest(toDirs.DataDir); err != nil {
+		return err
+	}
+
+	tx, err := db.BeginRo(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// files visible by `tx` are consistent with db. They can't be deleted until `tx` and views are closed
+	aggTx, ok := tx.(libstate.HasAggTx).AggTx().(*libstate.AggregatorRoTx)
+	if !ok {
+		return fmt.Errorf("expected temporal db")
+	}
+	blocksView := sn.View()
+	defer blocksView.Close()
+	borView := borSn.View()
+	defer borView.Close()
+
+	filePaths := aggTx.FilePaths()
+	filePaths = append(filePaths, blocksView.FilePaths()...)
+	filePaths = append(filePaths, borView.FilePaths()...)
+	for _, saltFile := range backupSaltFiles {
+		fPath := filepath.Join(dirs.Snap, saltFile)
+		exists, err := dir.FileExist(fPath)
+		if err != nil {
+			return err
+		}
+		if exists {
+			filePaths = append(filePaths, fPath)
+		}
+	}
+	relPaths := make([]string, 0, len(filePaths))
+	for _, fPath := range filePaths {
+		relPath, err := filepath.Rel(dirs.DataDir, fPath)
+		if err != nil {
+			return err
+		}
+		relPaths = append(relPaths, relPath)
+	}
+
+	m := &backup.Manifest{Version: backup.ManifestVersion, CreatedAt: time.Now().UTC(), Progress: map[string]uint64{}}
+	for _, stage := range stages.AllStages {
+		if m.Progress[string(stage)], err = stages.GetStageProgress(tx, stage); err != nil {
+			return err
+		}
+	}
+	if m.Tables, err = backup.TablesCount(db, tx); err != nil {
+		return err
+	}
+
+	logger.Info("[backup] files", "amount", len(relPaths))
+	if m.Files, err = backup.CopyFiles(ctx, dirs.DataDir, toDirs.DataDir, relPaths, prev, withChecksum, logger); err != nil {
+		return err
+	}
+	m.SortFiles()
+	if err := backup.RemoveStaleFiles(toDirs.DataDir, prev, m); err != nil {
+		return err
+	}
+
+	logger.Info("[backup] chaindata")
+	info, err := db.(*temporal.DB).InternalDB().(*kv2.MdbxKV).Env().Info(nil)
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(toDirs.Chaindata); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(toDirs.Chaindata, 0740); err != nil { //owner: rw, group: r, others: -
+		return err
+	}
+	toDB := backup.OpenTarget(toDirs.Chaindata, kv.ChainDB, datasize.ByteSize(db.PageSize()), datasize.ByteSize(info.Geo.Upper), logger)
+	if err := backup.Tx2kv(ctx, db, tx, toDB, nil, backup.ReadAheadThreads, logger); err != nil {
+		toDB.Close()
+		return err
+	}
+	toDB.Close()
+	tx.Rollback()
+
+	if err := verifyBackup(ctx, m, toDirs, logger); err != nil {
+		return fmt.Errorf("backup verification failed: %w", err)
+	}
+	if err := m.Write(toDirs.DataDir); err != nil {
+		return err
+	}
+	logger.Info("[backup] done", "datadir", toDirs.DataDir, "execution", m.Progress[string(stages.Execution)])
+	return nil
+}
+
+func doRestore(ctx context.Context, fromDirs, dirs datadir.Dirs, logger log.Logger) error {
+	if dirs.DataDir == fromDirs.DataDir {
+		return fmt.Errorf("--datadir and --from.datadir must be different")
+	}
+	m, err := backup.ReadManifest(fromDirs.DataDir)
+	if err != nil {
+		return fmt.Errorf("not a complete backup: %w", err)
+	}
+	if err := verifyBackup(ctx, m, fromDirs, logger); err != nil {
+		return fmt.Errorf("backup verification failed: %w", err)
+	}
+
+	// restore only into fresh datadir: files which are not part of backup - may be inconsistent with it
+	for _, d := range []string{dirs.Chaindata, dirs.Snap} {
+		empty, err := isEmptyDir(d)
+		if err != nil {
+			return err
+		}
+		if !empty {
+			return fmt.Errorf("target dir is not empty: %s", d)
+		}
+	}
+
+	// files are immutable - can be shared with backup by hardlink. db is mutable - must be copied
+	if _, err := backup.CopyFiles(ctx, fromDirs.DataDir, dirs.DataDir, m.FilePaths(), nil, false, logger); err != nil {
+		return err
+	}
+	logger.Info("[restore] chaindata")
+	if err := backup.CopyFile(filepath.Join(fromDirs.Chaindata, "mdbx.dat"), filepath.Join(dirs.Chaindata, "mdbx.dat")); err != nil {
+		return err
+	}
+
+	if err := verifyBackup(ctx, m, dirs, logger); err != nil {
+		return fmt.Errorf("restored datadir verification failed: %w", err)
+	}
+	logger.Info("[restore] done", "datadir", dirs.DataDir, "backup_created_at", m.CreatedAt, "execution", m.Progress[string(stages.Execution)])
+	return nil
+}
+
+// verifyBackup - check that files in `dirs` match manifest, and db has same amount of records as manifest
+func verifyBackup(ctx context.Context, m *backup.Manifest, dirs datadir.Dirs, logger log.Logger) error {
+	logger.Info("[backup] verify", "datadir", dirs.DataDir)
+	if err := m.VerifyFiles(ctx, dirs.DataDir); err != nil {
+		return err
+	}
+	db, err := kv2.NewMDBX(logger).Path(dirs.Chaindata).Label(kv.ChainDB).Accede().Readonly().Open(ctx)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return m.VerifyTables(ctx, db)
+}
+
+// isEmptyDir - true if `d` doesn't exist or has no files (empty sub-dirs are allowed: datadir.New creates them)
+func isEmptyDir(d string) (bool, error) {
+	empty := true
+	err := filepath.WalkDir(d, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !entry.IsDir() {
+			empty = false
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	return empty, err
+}
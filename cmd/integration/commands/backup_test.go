@@ -0,0 +1,126 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package commands
+
+import (
+	"context"
+	"encoding/binary"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/backup"
+	"github.com/erigontech/erigon-lib/kv/mdbx"
+	"github.com/erigontech/erigon-lib/kv/temporal"
+	"github.com/erigontech/erigon-lib/log/v3"
+	libstate "github.com/erigontech/erigon-lib/state"
+
+	"github.com/erigontech/erigon/core/types/accounts"
+	"github.com/erigontech/erigon/eth/ethconfig"
+	"github.com/erigontech/erigon/eth/stagedsync/stages"
+	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
+)
+
+const backupTestStep = 16
+
+func openBackupTestDB(t *testing.T, dirs datadir.Dirs, logger log.Logger) (kv.RwDB, *libstate.Aggregator) {
+	t.Helper()
+	db := mdbx.NewMDBX(logger).Path(dirs.Chaindata).WithTableCfg(func(defaultBuckets kv.TableCfg) kv.TableCfg {
+		return kv.ChaindataTablesCfg
+	}).MustOpen()
+	t.Cleanup(db.Close)
+	agg, err := libstate.NewAggregator(context.Background(), dirs, backupTestStep, db, logger)
+	require.NoError(t, err)
+	t.Cleanup(agg.Close)
+	require.NoError(t, agg.OpenFolder())
+	agg.DisableFsync()
+	tdb, err := temporal.New(db, agg)
+	require.NoError(t, err)
+	return tdb, agg
+}
+
+func backupTestAddr(i uint64) []byte {
+	addr := make([]byte, length.Addr)
+	binary.BigEndian.PutUint64(addr[length.Addr-8:], i)
+	return addr
+}
+
+func TestBackupRestore(t *testing.T) {
+	ctx, logger := context.Background(), log.New()
+	dirs, toDirs, restoredDirs := datadir.New(t.TempDir()), datadir.New(t.TempDir()), datadir.New(t.TempDir())
+
+	// state files and db
+	db, agg := openBackupTestDB(t, dirs, logger)
+	tx, err := db.BeginRw(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	domains, err := libstate.NewSharedDomains(tx, logger)
+	require.NoError(t, err)
+	defer domains.Close()
+	const txs = 3 * backupTestStep
+	for txNum := uint64(1); txNum <= txs; txNum++ {
+		domains.SetTxNum(txNum)
+		acc := accounts.SerialiseV3(&accounts.Account{Nonce: txNum})
+		require.NoError(t, domains.DomainPut(kv.AccountsDomain, backupTestAddr(txNum%5), nil, acc, nil, 0))
+	}
+	require.NoError(t, domains.Flush(ctx, tx))
+	domains.Close()
+	require.NoError(t, stages.SaveStageProgress(tx, stages.Execution, txs))
+	require.NoError(t, tx.Commit())
+	require.NoError(t, agg.BuildFiles(2*backupTestStep))
+
+	sn := freezeblocks.NewRoSnapshots(ethconfig.BlocksFreezing{}, dirs.Snap, 0, logger)
+	defer sn.Close()
+	borSn := freezeblocks.NewBorRoSnapshots(ethconfig.BlocksFreezing{}, dirs.Snap, 0, logger)
+	defer borSn.Close()
+	require.NoError(t, doBackup(ctx, db, sn, borSn, dirs, toDirs, true, logger))
+
+	m, err := backup.ReadManifest(toDirs.DataDir)
+	require.NoError(t, err)
+	require.Equal(t, uint64(txs), m.Progress[string(stages.Execution)])
+	require.Contains(t, m.FilePaths(), filepath.Join("snapshots", "salt-state.txt"))
+	var stateFiles int
+	for _, fPath := range m.FilePaths() {
+		if filepath.Dir(fPath) == filepath.Join("snapshots", "domain") {
+			stateFiles++
+		}
+	}
+	require.NotZero(t, stateFiles)
+
+	require.NoError(t, doRestore(ctx, toDirs, restoredDirs, logger))
+	require.ErrorContains(t, doRestore(ctx, toDirs, restoredDirs, logger), "not empty")
+
+	restoredDB, _ := openBackupTestDB(t, restoredDirs, logger)
+	for _, d := range []kv.RwDB{db, restoredDB} {
+		require.NoError(t, d.View(ctx, func(tx kv.Tx) error {
+			progress, err := stages.GetStageProgress(tx, stages.Execution)
+			require.NoError(t, err)
+			require.Equal(t, uint64(txs), progress)
+			for i := uint64(0); i < 5; i++ {
+				v, _, err := tx.(kv.TemporalTx).DomainGet(kv.AccountsDomain, backupTestAddr(i), nil)
+				require.NoError(t, err)
+				// last write of addr i is the biggest txNum with txNum%5 == i
+				require.Equal(t, accounts.SerialiseV3(&accounts.Account{Nonce: txs - (txs-i)%5}), v, "addr %d", i)
+			}
+			return nil
+		}))
+	}
+}
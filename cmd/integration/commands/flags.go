@@ -40,6 +40,7 @@ var (
 	integrityFast, integritySlow             bool
 	file                                     string
 	HeimdallURL                              string
+	HeimdallVersion                          string
 	txtrace                                  bool // Whether to trace the execution (should only be used together with `block`)
 	pruneFlag                                string
 	pruneB, pruneH, pruneR, pruneT, pruneC   uint64
@@ -58,6 +59,9 @@ var (
 
 	workers, reconWorkers uint64
 	dbWriteMap            bool
+
+	toDatadirCli, fromDatadirCli string
+	backupChecksum               bool
 )
 
 func must(err error) {
@@ -97,6 +101,18 @@ func withToChaindata(cmd *cobra.Command) {
 	must(cmd.MarkFlagDirname("chaindata.to"))
 }
 
+func withToDatadir(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&toDatadirCli, "to.datadir", "", "target datadir")
+	must(cmd.MarkFlagRequired("to.datadir"))
+	must(cmd.MarkFlagDirname("to.datadir"))
+}
+
+func withFromDatadir(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&fromDatadirCli, "from.datadir", "", "source datadir")
+	must(cmd.MarkFlagRequired("from.datadir"))
+	must(cmd.MarkFlagDirname("from.datadir"))
+}
+
 func withBlock(cmd *cobra.Command) {
 	cmd.Flags().Uint64Var(&block, "block", 0, "block test at this block")
 }
@@ -175,7 +191,8 @@ func withChain(cmd *cobra.Command) {
 }
 
 func withHeimdall(cmd *cobra.Command) {
-	cmd.Flags().StringVar(&HeimdallURL, "bor.heimdall", "http://localhost:1317", "URL of Heimdall service")
+	cmd.Flags().StringVar(&HeimdallURL, "bor.heimdall", "http://localhost:1317", "URL of Heimdall service, or sim://<dir> to replay recorded Heimdall data offline")
+	cmd.Flags().StringVar(&HeimdallVersion, "bor.heimdall.version", "auto", "Version of the Heimdall API: auto, v1 or v2")
 }
 
 func withWorkers(cmd *cobra.Command) {
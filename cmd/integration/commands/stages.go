@@ -72,6 +72,7 @@ import (
 	"github.com/erigontech/erigon/params"
 	"github.com/erigontech/erigon/polygon/bor"
 	"github.com/erigontech/erigon/polygon/heimdall"
+	"github.com/erigontech/erigon/polygon/heimdall/heimdallsim"
 	"github.com/erigontech/erigon/turbo/builder"
 	"github.com/erigontech/erigon/turbo/debug"
 	"github.com/erigontech/erigon/turbo/logging"
@@ -1391,6 +1392,7 @@ func newSync(ctx context.Context, db kv.RwDB, miningConfig *params.MiningConfig,
 		chainConfig,
 		genesisBlock,
 		chainConfig.ChainID.Uint64(),
+		blockReader,
 		logger,
 	)
 
@@ -1522,8 +1524,18 @@ func initConsensusEngine(ctx context.Context, cc *chain2.Config, dir string, db
 	} else if cc.Bor != nil {
 		consensusConfig = cc.Bor
 		config.HeimdallURL = HeimdallURL
+		config.HeimdallVersion = HeimdallVersion
 		if !config.WithoutHeimdall {
-			heimdallClient = heimdall.NewHeimdallClient(config.HeimdallURL, logger)
+			var err error
+			heimdallClient, err = heimdallsim.NewHeimdallClient(ctx, heimdallsim.ClientConfig{
+				URL:     config.HeimdallURL,
+				Version: heimdall.ClientVersion(config.HeimdallVersion),
+				SnapDir: datadir.New(dir).Snap,
+				Logger:  logger,
+			})
+			if err != nil {
+				panic(err)
+			}
 		}
 	} else {
 		consensusConfig = &config.Ethash
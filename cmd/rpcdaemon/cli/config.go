@@ -56,6 +56,7 @@ import (
 	"github.com/erigontech/erigon-lib/log/v3"
 	libstate "github.com/erigontech/erigon-lib/state"
 	"github.com/erigontech/erigon/cmd/rpcdaemon/cli/httpcfg"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/follower"
 	"github.com/erigontech/erigon/cmd/rpcdaemon/graphql"
 	"github.com/erigontech/erigon/cmd/rpcdaemon/health"
 	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcservices"
@@ -180,6 +181,8 @@ func RootCommand() (*cobra.Command, *httpcfg.HttpCfg) {
 	rootCmd.PersistentFlags().Uint64Var(&cfg.OtsMaxPageSize, utils.OtsSearchMaxCapFlag.Name, utils.OtsSearchMaxCapFlag.Value, utils.OtsSearchMaxCapFlag.Usage)
 	rootCmd.PersistentFlags().DurationVar(&cfg.RPCSlowLogThreshold, utils.RPCSlowFlag.Name, utils.RPCSlowFlag.Value, utils.RPCSlowFlag.Usage)
 	rootCmd.PersistentFlags().IntVar(&cfg.WebsocketSubscribeLogsChannelSize, utils.WSSubscribeLogsChannelSize.Name, utils.WSSubscribeLogsChannelSize.Value, utils.WSSubscribeLogsChannelSize.Usage)
+	rootCmd.PersistentFlags().StringVar(&cfg.FollowerDir, "follower.dir", "", "Run as read-only replica (requires --datadir): ingest backups which primary ships into this dir (see `integration backup --to.datadir`), apply blocks from primary's state change stream between them. Doesn't require Erigon on same machine")
+	rootCmd.PersistentFlags().DurationVar(&cfg.FollowerInterval, "follower.interval", time.Minute, "How often follower checks --follower.dir for new shipment")
 
 	if err := rootCmd.MarkPersistentFlagFilename("rpc.accessList", "json"); err != nil {
 		panic(err)
@@ -201,6 +204,9 @@ func RootCommand() (*cobra.Command, *httpcfg.HttpCfg) {
 		}
 
 		cfg.WithDatadir = cfg.DataDir != ""
+		if cfg.FollowerDir != "" && !cfg.WithDatadir {
+			return errors.New("--follower.dir requires --datadir")
+		}
 		if cfg.WithDatadir {
 			if cfg.DataDir == "" {
 				cfg.DataDir = paths.DefaultDataDir()
@@ -362,6 +368,7 @@ func RemoteServices(ctx context.Context, cfg *httpcfg.HttpCfg, logger log.Logger
 	var allBorSnapshots *freezeblocks.BorRoSnapshots
 	onNewSnapshot := func() {}
 	roTxLimit := int64(cfg.DBReadConcurrency)
+	var fol *follower.Follower
 
 	var cc *chain.Config
 
@@ -378,10 +385,22 @@ func RemoteServices(ctx context.Context, cfg *httpcfg.HttpCfg, logger log.Logger
 		var rwKv kv.RwDB
 		logger.Warn("Opening chain db", "path", cfg.Dirs.Chaindata)
 		limiter := semaphore.NewWeighted(roTxLimit)
-		rwKv, err = kv2.NewMDBX(logger).RoTxsLimiter(limiter).Path(cfg.Dirs.Chaindata).Accede().Open(ctx)
+		if cfg.FollowerDir != "" {
+			// follower owns its db: it's a copy of primary's hot tail
+			rwKv, err = kv2.NewMDBX(logger).RoTxsLimiter(limiter).Path(cfg.Dirs.Chaindata).Label(kv.ChainDB).Open(ctx)
+		} else {
+			rwKv, err = kv2.NewMDBX(logger).RoTxsLimiter(limiter).Path(cfg.Dirs.Chaindata).Accede().Open(ctx)
+		}
 		if err != nil {
 			return nil, nil, nil, nil, nil, nil, nil, ff, nil, nil, err
 		}
+		if cfg.FollowerDir != "" {
+			fol = follower.New(cfg.FollowerDir, cfg.Dirs, rwKv, logger)
+			// files are not opened yet - nothing to reopen
+			if _, err := fol.Ingest(ctx, func() error { return nil }); err != nil {
+				return nil, nil, nil, nil, nil, nil, nil, ff, nil, nil, fmt.Errorf("follower: %w", err)
+			}
+		}
 		if compatErr := checkDbCompatibility(ctx, rwKv); compatErr != nil {
 			return nil, nil, nil, nil, nil, nil, nil, ff, nil, nil, compatErr
 		}
@@ -465,12 +484,31 @@ func RemoteServices(ctx context.Context, cfg *httpcfg.HttpCfg, logger log.Logger
 				}
 			}()
 		}
+		if fol != nil {
+			// files come from shipments, not from primary's datadir: primary's new files are only a hint to check ship dir
+			onNewSnapshot = fol.Notify
+		}
 		onNewSnapshot()
 
-		db, err = temporal.New(rwKv, agg)
+		tdb, err := temporal.New(rwKv, agg)
 		if err != nil {
 			return nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, err
 		}
+		db = tdb
+		if fol != nil {
+			// between shipments follower applies blocks which primary executes
+			fol.Follow(remoteKvClient, freezeblocks.NewRemoteBlockReader(remoteBackendClient), tdb, blockReader)
+			go fol.Run(ctx, cfg.FollowerInterval, func() error {
+				if err := allSnapshots.ReopenFolder(); err != nil {
+					return err
+				}
+				if err := allBorSnapshots.ReopenFolder(); err != nil {
+					return err
+				}
+				return agg.OpenFolder()
+			})
+			db = fol.DB(tdb)
+		}
 		stateCache = kvcache.NewDummy()
 	}
 	// If DB can't be configured - used PrivateApiAddr as remote DB
@@ -107,4 +107,8 @@ type HttpCfg struct {
 	OtsMaxPageSize uint64
 
 	RPCSlowLogThreshold time.Duration
+
+	// Follower mode: read-only replica which ingests shipments of primary's datadir
+	FollowerDir      string
+	FollowerInterval time.Duration
 }
@@ -0,0 +1,281 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package follower
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/datadir"
+	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/backup"
+	"github.com/erigontech/erigon-lib/kv/mdbx"
+	"github.com/erigontech/erigon-lib/kv/temporal"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/core/rawdb"
+	"github.com/erigontech/erigon/eth/stagedsync/stages"
+)
+
+// Follower - read-only replica of primary's datadir. It doesn't run p2p sync or execution.
+//
+// Primary periodically ships point-in-time backups into "ship dir" (see `integration backup --to.datadir=<ship_dir>`,
+// incremental backup copies only new files). Any other delivery (rsync, downloader, ...) of same layout also works:
+// shipment is complete when it has backup's manifest - manifest is written last.
+//
+// Follower ingests shipment:
+//   - new immutable files (snapshots) are hardlinked (or copied) into own datadir, files which are not in shipment
+//     anymore (merged into bigger files) are removed after reopen
+//   - own db (small hot tail: blocks and state diffs which are not in files yet) is replaced by shipped db
+//     in 1 transaction - if follower is behind shipment. Otherwise it already has shipped blocks (see Follow):
+//     only tables which are not in primary's state change stream are taken from shipped db
+//   - new files are opened and db transaction is committed while no read transaction can begin (see DB):
+//     readers see files and db of either previous or new shipment, never a mix of them
+//
+// Manifest of last ingested shipment is stored in own datadir.
+type Follower struct {
+	shipDirs datadir.Dirs
+	dirs     datadir.Dirs
+	db       kv.RwDB // own db, not temporal
+	swap     sync.RWMutex
+	notify   chan struct{}
+	logger   log.Logger
+
+	// primary's blocks between shipments, see Follow
+	stateChanges StateChangesClient
+	blocks       BlockReader
+	tdb          kv.RwDB
+	frozen       FrozenBlocksReader
+	diverged     bool // own chain is not a prefix of primary's: next shipment must be copied completely
+}
+
+var ErrShipmentChanged = errors.New("shipment changed while ingesting")
+
+func New(shipDir string, dirs datadir.Dirs, db kv.RwDB, logger log.Logger) *Follower {
+	return &Follower{
+		shipDirs: datadir.New(shipDir),
+		dirs:     dirs,
+		db:       db,
+		notify:   make(chan struct{}, 1),
+		logger:   logger,
+	}
+}
+
+// Notify - check ship dir now, without waiting for next tick. Non-blocking.
+func (f *Follower) Notify() {
+	select {
+	case f.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Run - ingest new shipments until ctx is done. `reopen` must reopen files of own datadir.
+func (f *Follower) Run(ctx context.Context, interval time.Duration, reopen func() error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var changes <-chan *remote.StateChangeBatch
+	if f.stateChanges != nil {
+		changes = f.subscribe(ctx)
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case batch := <-changes:
+			if err := f.Apply(ctx, batch); err != nil {
+				if errors.Is(err, context.Canceled) {
+					return
+				}
+				f.logger.Warn("[follower] apply", "err", err)
+			}
+			continue
+		case <-ticker.C:
+		case <-f.notify:
+		}
+		if _, err := f.Ingest(ctx, reopen); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+			f.logger.Warn("[follower] ingest", "err", err)
+		}
+	}
+}
+
+// Ingest - ingest shipment if it's newer than last ingested. Returns false if there is nothing new.
+func (f *Follower) Ingest(ctx context.Context, reopen func() error) (bool, error) {
+	shipped, err := backup.ReadManifestIfExists(f.shipDirs.DataDir)
+	if err != nil {
+		return false, err
+	}
+	if shipped == nil { // no shipment yet, or primary is writing new one
+		return false, nil
+	}
+	local, err := backup.ReadManifestIfExists(f.dirs.DataDir)
+	if err != nil {
+		return false, err
+	}
+	if local != nil && local.CreatedAt.Equal(shipped.CreatedAt) {
+		return false, nil
+	}
+	f.logger.Info("[follower] ingest", "created_at", shipped.CreatedAt, "files", len(shipped.Files))
+
+	// new files are only added: already opened files stay valid until reopen
+	if _, err := backup.CopyFiles(ctx, f.shipDirs.DataDir, f.dirs.DataDir, shipped.FilePaths(), local, false, f.logger); err != nil {
+		return false, err
+	}
+	if err := f.ingestDB(ctx, shipped, reopen); err != nil {
+		return false, err
+	}
+	if err := backup.RemoveStaleFiles(f.dirs.DataDir, local, shipped); err != nil {
+		return false, err
+	}
+	if err := shipped.Write(f.dirs.DataDir); err != nil {
+		return false, err
+	}
+	f.logger.Info("[follower] ingested", "created_at", shipped.CreatedAt)
+	return true, nil
+}
+
+// ingestDB - copy shipped db into own db, then swap both db and files of new shipment in
+func (f *Follower) ingestDB(ctx context.Context, shipped *backup.Manifest, reopen func() error) error {
+	src, err := mdbx.NewMDBX(f.logger).Path(f.shipDirs.Chaindata).Label(kv.ChainDB).Accede().Readonly().Open(ctx)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	srcTx, err := src.BeginRo(ctx)
+	if err != nil {
+		return err
+	}
+	defer srcTx.Rollback()
+
+	tx, err := f.db.BeginRw(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	full, err := f.needFullCopy(srcTx, tx)
+	if err != nil {
+		return err
+	}
+	tables := make([]string, 0, len(f.db.AllTables()))
+	for name, cfg := range f.db.AllTables() {
+		if cfg.IsDeprecated {
+			continue
+		}
+		if _, ok := streamTables[name]; ok && !full {
+			continue
+		}
+		tables = append(tables, name)
+	}
+	if err := backup.CopyTablesTx(ctx, srcTx, tx, tables); err != nil {
+		return err
+	}
+	if !full {
+		if safe := rawdb.ReadForkchoiceSafe(srcTx); safe != (libcommon.Hash{}) {
+			rawdb.WriteForkchoiceSafe(tx, safe)
+		}
+	}
+	// primary removes manifest before starting new backup: if manifest is still same - then we read complete shipment
+	cur, err := backup.ReadManifestIfExists(f.shipDirs.DataDir)
+	if err != nil {
+		return err
+	}
+	if cur == nil || !cur.CreatedAt.Equal(shipped.CreatedAt) {
+		return ErrShipmentChanged
+	}
+
+	// copy is done - hold readers only for files reopen and commit
+	f.swap.Lock()
+	defer f.swap.Unlock()
+	if err := reopen(); err != nil {
+		return fmt.Errorf("reopen: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	if full {
+		f.diverged = false
+	}
+	return nil
+}
+
+// needFullCopy - false if own db already has shipped blocks: they were applied from primary's state change stream
+func (f *Follower) needFullCopy(srcTx kv.Tx, tx kv.Tx) (bool, error) {
+	if f.stateChanges == nil || f.diverged {
+		return true, nil
+	}
+	shippedHead, err := stages.GetStageProgress(srcTx, stages.Execution)
+	if err != nil {
+		return false, err
+	}
+	head, err := stages.GetStageProgress(tx, stages.Execution)
+	if err != nil {
+		return false, err
+	}
+	if head < shippedHead { // too far behind
+		return true, nil
+	}
+	shippedHash, err := rawdb.ReadCanonicalHash(srcTx, shippedHead)
+	if err != nil {
+		return false, err
+	}
+	hash, err := rawdb.ReadCanonicalHash(tx, shippedHead)
+	if err != nil {
+		return false, err
+	}
+	return shippedHash != hash, nil
+}
+
+// DB - temporal db of follower's datadir. Read transactions don't begin while a shipment is being swapped in,
+// and a transaction holds db snapshot and files which were open when it began - so it reads 1 shipment.
+// Block files are not bound to transaction, but blocks in files never change - new shipment only adds blocks.
+func (f *Follower) DB(db *temporal.DB) *DB { return &DB{DB: db, swap: &f.swap} }
+
+type DB struct {
+	*temporal.DB
+	swap *sync.RWMutex
+}
+
+func (db *DB) BeginTemporalRo(ctx context.Context) (kv.TemporalTx, error) {
+	db.swap.RLock()
+	defer db.swap.RUnlock()
+	return db.DB.BeginTemporalRo(ctx)
+}
+func (db *DB) ViewTemporal(ctx context.Context, f func(tx kv.TemporalTx) error) error {
+	tx, err := db.BeginTemporalRo(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	return f(tx)
+}
+func (db *DB) BeginRo(ctx context.Context) (kv.Tx, error) {
+	return db.BeginTemporalRo(ctx)
+}
+func (db *DB) View(ctx context.Context, f func(tx kv.Tx) error) error {
+	tx, err := db.BeginTemporalRo(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	return f(tx)
+}
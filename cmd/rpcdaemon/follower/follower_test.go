@@ -0,0 +1,226 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package follower
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/erigontech/erigon-lib/common/datadir"
+	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/backup"
+	"github.com/erigontech/erigon-lib/kv/mdbx"
+	"github.com/erigontech/erigon-lib/kv/memdb"
+	"github.com/erigontech/erigon-lib/kv/temporal"
+	"github.com/erigontech/erigon-lib/log/v3"
+	libstate "github.com/erigontech/erigon-lib/state"
+	"github.com/erigontech/erigon/eth/stagedsync/stages"
+)
+
+// ship - emulate `integration backup`: files, db, manifest (last)
+func ship(t *testing.T, shipDirs datadir.Dirs, files map[string]string, headers ...byte) *backup.Manifest {
+	t.Helper()
+	ctx, logger := context.Background(), log.New()
+	require.NoError(t, backup.RemoveManifest(shipDirs.DataDir))
+
+	m := &backup.Manifest{Version: backup.ManifestVersion, CreatedAt: time.Now().UTC()}
+	for relPath, content := range files {
+		fPath := filepath.Join(shipDirs.DataDir, relPath)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fPath), 0755))
+		require.NoError(t, os.WriteFile(fPath, []byte(content), 0644))
+		m.Files = append(m.Files, backup.ManifestFile{Path: relPath, Size: int64(len(content))})
+	}
+	m.SortFiles()
+
+	db := mdbx.NewMDBX(logger).Path(shipDirs.Chaindata).Label(kv.ChainDB).MustOpen()
+	defer db.Close()
+	require.NoError(t, db.Update(ctx, func(tx kv.RwTx) error {
+		if err := tx.ClearBucket(kv.Headers); err != nil {
+			return err
+		}
+		for _, h := range headers {
+			if err := tx.Put(kv.Headers, []byte{h}, []byte{h}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+	require.NoError(t, m.Write(shipDirs.DataDir))
+	return m
+}
+
+func TestIngest(t *testing.T) {
+	ctx, logger := context.Background(), log.New()
+	shipDirs, dirs := datadir.New(t.TempDir()), datadir.New(t.TempDir())
+	db := memdb.NewTestDB(t)
+	f := New(shipDirs.DataDir, dirs, db, logger)
+
+	var reopened int
+	reopen := func() error { reopened++; return nil }
+	headers := func() (res []byte) {
+		require.NoError(t, db.View(ctx, func(tx kv.Tx) error {
+			return tx.ForEach(kv.Headers, nil, func(k, v []byte) error {
+				res = append(res, k...)
+				return nil
+			})
+		}))
+		return res
+	}
+
+	ingested, err := f.Ingest(ctx, reopen)
+	require.NoError(t, err)
+	require.False(t, ingested)
+
+	ship(t, shipDirs, map[string]string{"snapshots/domain/v1-accounts.0-1.kv": "a01", "snapshots/domain/v1-accounts.1-2.kv": "a12"}, 1, 2)
+	ingested, err = f.Ingest(ctx, reopen)
+	require.NoError(t, err)
+	require.True(t, ingested)
+	require.Equal(t, 1, reopened)
+	require.Equal(t, []byte{1, 2}, headers())
+	require.FileExists(t, filepath.Join(dirs.DataDir, "snapshots/domain/v1-accounts.0-1.kv"))
+
+	// same shipment - nothing to do
+	ingested, err = f.Ingest(ctx, reopen)
+	require.NoError(t, err)
+	require.False(t, ingested)
+	require.Equal(t, 1, reopened)
+
+	// files merged, hot tail moved forward
+	time.Sleep(time.Millisecond)
+	require.NoError(t, os.Remove(filepath.Join(shipDirs.DataDir, "snapshots/domain/v1-accounts.0-1.kv")))
+	require.NoError(t, os.Remove(filepath.Join(shipDirs.DataDir, "snapshots/domain/v1-accounts.1-2.kv")))
+	ship(t, shipDirs, map[string]string{"snapshots/domain/v1-accounts.0-2.kv": "a02"}, 3)
+	ingested, err = f.Ingest(ctx, reopen)
+	require.NoError(t, err)
+	require.True(t, ingested)
+	require.Equal(t, 2, reopened)
+	require.Equal(t, []byte{3}, headers())
+	require.FileExists(t, filepath.Join(dirs.DataDir, "snapshots/domain/v1-accounts.0-2.kv"))
+	require.NoFileExists(t, filepath.Join(dirs.DataDir, "snapshots/domain/v1-accounts.0-1.kv"))
+
+	// primary started new backup: incomplete shipment is ignored
+	require.NoError(t, backup.RemoveManifest(shipDirs.DataDir))
+	ingested, err = f.Ingest(ctx, reopen)
+	require.NoError(t, err)
+	require.False(t, ingested)
+	require.Equal(t, []byte{3}, headers())
+}
+
+func TestIngestSwapsShipmentAtomically(t *testing.T) {
+	ctx, logger := context.Background(), log.New()
+	shipDirs, dirs := datadir.New(t.TempDir()), datadir.New(t.TempDir())
+	db := memdb.NewTestDB(t)
+	agg, err := libstate.NewAggregator(ctx, dirs, 16, db, logger)
+	require.NoError(t, err)
+	t.Cleanup(agg.Close)
+	tdb, err := temporal.New(db, agg)
+	require.NoError(t, err)
+	f := New(shipDirs.DataDir, dirs, db, logger)
+	readDB := f.DB(tdb)
+
+	read := make(chan []byte, 1)
+	reopen := func() error {
+		// reader which begins during swap must wait for it and then see new shipment
+		go func() {
+			var res []byte
+			if err := readDB.View(ctx, func(tx kv.Tx) error {
+				return tx.ForEach(kv.Headers, nil, func(k, v []byte) error {
+					res = append(res, k...)
+					return nil
+				})
+			}); err != nil {
+				t.Error(err)
+			}
+			read <- res
+		}()
+		select {
+		case <-read:
+			t.Error("read transaction began while shipment was being swapped in")
+		case <-time.After(50 * time.Millisecond):
+		}
+		return nil
+	}
+
+	ship(t, shipDirs, map[string]string{}, 1, 2)
+	ingested, err := f.Ingest(ctx, reopen)
+	require.NoError(t, err)
+	require.True(t, ingested)
+	require.Equal(t, []byte{1, 2}, <-read)
+}
+
+type noStateChanges struct{}
+
+func (noStateChanges) StateChanges(context.Context, *remote.StateChangeRequest, ...grpc.CallOption) (remote.KV_StateChangesClient, error) {
+	return nil, errors.New("not connected")
+}
+
+func TestIngestWhileFollowing(t *testing.T) {
+	ctx, logger := context.Background(), log.New()
+	shipDirs, dirs := datadir.New(t.TempDir()), datadir.New(t.TempDir())
+	db := memdb.NewTestDB(t)
+	f := New(shipDirs.DataDir, dirs, db, logger)
+	f.Follow(noStateChanges{}, chainBlocks{}, db, noFrozenBlocks{})
+	reopen := func() error { return nil }
+	keys := func(table string) (res []byte) {
+		require.NoError(t, db.View(ctx, func(tx kv.Tx) error {
+			return tx.ForEach(table, nil, func(k, v []byte) error {
+				res = append(res, k...)
+				return nil
+			})
+		}))
+		return res
+	}
+
+	// blocks up to 7 were applied from primary's state change stream
+	require.NoError(t, db.Update(ctx, func(tx kv.RwTx) error {
+		if err := tx.Put(kv.Headers, []byte{7}, []byte{7}); err != nil {
+			return err
+		}
+		return stages.SaveStageProgress(tx, stages.Execution, 7)
+	}))
+
+	// shipment has less blocks: only tables which are not in the stream are taken from it
+	ship(t, shipDirs, map[string]string{}, 1, 2)
+	shipped := mdbx.NewMDBX(logger).Path(shipDirs.Chaindata).Label(kv.ChainDB).MustOpen()
+	require.NoError(t, shipped.Update(ctx, func(tx kv.RwTx) error {
+		return tx.Put(kv.TblLogAddressIdx, []byte{1}, []byte{1})
+	}))
+	shipped.Close()
+	ingested, err := f.Ingest(ctx, reopen)
+	require.NoError(t, err)
+	require.True(t, ingested)
+	require.Equal(t, []byte{7}, keys(kv.Headers))
+	require.Equal(t, []byte{1}, keys(kv.TblLogAddressIdx))
+
+	// own chain diverged from primary's: shipment replaces own db
+	f.diverged = true
+	time.Sleep(time.Millisecond)
+	ship(t, shipDirs, map[string]string{}, 3)
+	ingested, err = f.Ingest(ctx, reopen)
+	require.NoError(t, err)
+	require.True(t, ingested)
+	require.Equal(t, []byte{3}, keys(kv.Headers))
+	require.False(t, f.diverged)
+}
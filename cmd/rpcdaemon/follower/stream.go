@@ -0,0 +1,395 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package follower
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"google.golang.org/grpc"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/gointerfaces"
+	"github.com/erigontech/erigon-lib/gointerfaces/grpcutil"
+	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
+	libstate "github.com/erigontech/erigon-lib/state"
+	"github.com/erigontech/erigon/core/rawdb"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/eth/stagedsync/stages"
+	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
+)
+
+// StateChangesClient - primary's stream of state diffs (see remotedbserver.KvServer.StateChanges)
+type StateChangesClient interface {
+	StateChanges(ctx context.Context, in *remote.StateChangeRequest, opts ...grpc.CallOption) (remote.KV_StateChangesClient, error)
+}
+
+// BlockReader - primary's blocks: stream has only state diffs (see freezeblocks.RemoteBlockReader)
+type BlockReader interface {
+	BlockWithSenders(ctx context.Context, tx kv.Getter, hash libcommon.Hash, blockNum uint64) (*types.Block, []libcommon.Address, error)
+}
+
+// FrozenBlocksReader - own block files (see freezeblocks.BlockReader): applied blocks are pruned from own db when they are in files
+type FrozenBlocksReader interface {
+	FrozenBlocks() uint64
+}
+
+// pruneTimeout, pruneBlocksLimit - how much of own db's data which is in files already applying of 1 block may prune
+const (
+	pruneTimeout     = 250 * time.Millisecond
+	pruneBlocksLimit = 100
+)
+
+// headStages - stages which primary moves to the head of canonical chain: follower moves them when it applies a block
+var headStages = []stages.SyncStage{stages.Headers, stages.BlockHashes, stages.Bodies, stages.Senders, stages.Execution, stages.TxLookup, stages.Finish}
+
+// streamTables - tables which applying of blocks writes. Other tables (receipts, logs and traces indices, bor events, ...)
+// are not in the stream: they come only with shipments.
+var streamTables = map[string]struct{}{
+	kv.Headers: {}, kv.HeaderCanonical: {}, kv.HeaderNumber: {}, kv.HeaderTD: {}, kv.BlockBody: {}, kv.EthTx: {}, kv.Sequence: {},
+	kv.Senders: {}, kv.MaxTxNum: {}, kv.TxLookup: {}, kv.SyncStageProgress: {}, kv.ChangeSets3: {},
+	kv.HeadHeaderKey: {}, kv.HeadBlockKey: {}, kv.LastForkchoice: {},
+
+	kv.TblAccountVals: {}, kv.TblAccountHistoryKeys: {}, kv.TblAccountHistoryVals: {}, kv.TblAccountIdx: {},
+	kv.TblStorageVals: {}, kv.TblStorageHistoryKeys: {}, kv.TblStorageHistoryVals: {}, kv.TblStorageIdx: {},
+	kv.TblCodeVals: {}, kv.TblCodeHistoryKeys: {}, kv.TblCodeHistoryVals: {}, kv.TblCodeIdx: {},
+	kv.TblCommitmentVals: {}, kv.TblCommitmentHistoryKeys: {}, kv.TblCommitmentHistoryVals: {}, kv.TblCommitmentIdx: {},
+}
+
+// Follow - apply blocks which primary executes between shipments: state diffs come from primary's state change stream,
+// blocks - from primary's backend, `db` is temporal db of follower's datadir.
+//
+// Stream has diffs of whole block: history of applied blocks has 1 change per block (at block's last txNum) and
+// receipts/logs/traces of applied blocks come with next shipment.
+// If follower misses blocks (stream reconnect, primary was far behind and didn't stream) - it waits for shipment
+// which has them. If own chain is not a prefix of primary's chain anymore (unwind deeper than changesets,
+// state root mismatch) - next shipment is copied completely.
+func (f *Follower) Follow(stateChanges StateChangesClient, blocks BlockReader, db kv.RwDB, frozen FrozenBlocksReader) {
+	f.stateChanges, f.blocks, f.tdb, f.frozen = stateChanges, blocks, db, frozen
+}
+
+// subscribe - re-subscribes to primary's state change stream until ctx is done
+func (f *Follower) subscribe(ctx context.Context) <-chan *remote.StateChangeBatch {
+	ch := make(chan *remote.StateChangeBatch, 16)
+	go func() {
+		for {
+			err := f.recv(ctx, ch)
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil && !grpcutil.IsRetryLater(err) && !grpcutil.IsEndOfStream(err) {
+				f.logger.Warn("[follower] state changes", "err", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(3 * time.Second):
+			}
+		}
+	}()
+	return ch
+}
+
+func (f *Follower) recv(ctx context.Context, ch chan<- *remote.StateChangeBatch) error {
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := f.stateChanges.StateChanges(streamCtx, &remote.StateChangeRequest{WithStorage: true, WithTransactions: false}, grpc.WaitForReady(true))
+	if err != nil {
+		return err
+	}
+	for {
+		batch, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		if batch == nil {
+			return nil
+		}
+		select {
+		case ch <- batch:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Apply - apply primary's state changes to own db. Changes which follower can't apply are skipped: see Follow.
+func (f *Follower) Apply(ctx context.Context, batch *remote.StateChangeBatch) error {
+	for _, change := range batch.ChangeBatch {
+		if f.diverged {
+			return nil
+		}
+		var err error
+		if change.Direction == remote.Direction_UNWIND {
+			err = f.unwind(ctx, change.BlockHeight)
+		} else {
+			err = f.applyBlock(ctx, change, batch.FinalizedBlock)
+		}
+		if err != nil {
+			return fmt.Errorf("block %d: %w", change.BlockHeight, err)
+		}
+	}
+	return nil
+}
+
+func (f *Follower) diverge(reason string, blockNum uint64) {
+	f.diverged = true
+	f.logger.Warn("[follower] can't apply primary's blocks until next shipment", "reason", reason, "block", blockNum)
+}
+
+func (f *Follower) applyBlock(ctx context.Context, change *remote.StateChange, finalized uint64) error {
+	tx, err := f.tdb.BeginRw(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	blockNum, hash := change.BlockHeight, libcommon.Hash(gointerfaces.ConvertH256ToHash(change.BlockHash))
+	head, err := stages.GetStageProgress(tx, stages.Execution)
+	if err != nil {
+		return err
+	}
+	if blockNum <= head { // came with shipment
+		return nil
+	}
+	if blockNum > head+1 {
+		f.logger.Debug("[follower] missed blocks, waiting for shipment", "head", head, "block", blockNum)
+		return nil
+	}
+	block, senders, err := f.blocks.BlockWithSenders(ctx, nil, hash, blockNum)
+	if err != nil {
+		return err
+	}
+	if block == nil {
+		return fmt.Errorf("block %x not found on primary", hash)
+	}
+	headHash, err := rawdb.ReadCanonicalHash(tx, head)
+	if err != nil {
+		return err
+	}
+	if block.ParentHash() != headHash {
+		f.diverge("parent is not own head", blockNum)
+		return nil
+	}
+
+	if err := writeBlock(tx, block, senders); err != nil {
+		return err
+	}
+	maxTxNum, err := rawdbv3.TxNums.Max(tx, blockNum)
+	if err != nil {
+		return err
+	}
+
+	sd, err := libstate.NewSharedDomains(tx, f.logger)
+	if err != nil {
+		return err
+	}
+	defer sd.Close()
+	changeset := &libstate.StateChangeSet{}
+	sd.SetChangesetAccumulator(changeset)
+	sd.SetBlockNum(blockNum)
+	sd.SetTxNum(maxTxNum)
+	for _, accChange := range change.Changes {
+		if err := applyAccountChange(sd, accChange); err != nil {
+			return err
+		}
+	}
+	// stream is not authenticated by itself: diffs must lead to block's state root
+	root, err := sd.ComputeCommitment(ctx, true, blockNum, "follower")
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(root, block.Root().Bytes()) {
+		f.diverge(fmt.Sprintf("state root mismatch: %x != %x", root, block.Root()), blockNum)
+		return nil
+	}
+	// changesets allow to apply primary's unwinds
+	if err := libstate.WriteDiffSet(tx, blockNum, hash, changeset); err != nil {
+		return err
+	}
+	sd.SetChangesetAccumulator(nil)
+	if err := sd.Flush(ctx, tx); err != nil {
+		return err
+	}
+	if err := writeHead(tx, blockNum, hash, finalized); err != nil {
+		return err
+	}
+	if _, err := tx.(libstate.HasAggTx).AggTx().(*libstate.AggregatorRoTx).PruneSmallBatches(ctx, pruneTimeout, tx); err != nil {
+		return err
+	}
+	if _, err := rawdb.PruneBlocks(tx, freezeblocks.CanDeleteTo(blockNum, f.frozen.FrozenBlocks()), pruneBlocksLimit); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (f *Follower) unwind(ctx context.Context, unwindTo uint64) error {
+	tx, err := f.tdb.BeginRw(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	head, err := stages.GetStageProgress(tx, stages.Execution)
+	if err != nil {
+		return err
+	}
+	if unwindTo >= head {
+		return nil
+	}
+	sd, err := libstate.NewSharedDomains(tx, f.logger)
+	if err != nil {
+		return err
+	}
+	defer sd.Close()
+
+	var changeset *[kv.DomainLen][]libstate.DomainEntryDiff
+	for blockNum := head; blockNum > unwindTo; blockNum-- {
+		hash, err := rawdb.ReadCanonicalHash(tx, blockNum)
+		if err != nil {
+			return err
+		}
+		diff, ok, err := sd.GetDiffset(tx, hash, blockNum)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			f.diverge("no changeset to unwind", blockNum)
+			return nil
+		}
+		if changeset == nil {
+			changeset = &diff
+		} else {
+			for i := range diff {
+				changeset[i] = libstate.MergeDiffSets(changeset[i], diff[i])
+			}
+		}
+	}
+	txNum, err := rawdbv3.TxNums.Min(tx, unwindTo+1)
+	if err != nil {
+		return err
+	}
+	if err := sd.Unwind(ctx, tx, unwindTo, txNum, changeset); err != nil {
+		return err
+	}
+	if err := rawdb.TruncateCanonicalHash(tx, unwindTo+1, false); err != nil {
+		return err
+	}
+	if err := rawdbv3.TxNums.Truncate(tx, unwindTo+1); err != nil {
+		return err
+	}
+	hash, err := rawdb.ReadCanonicalHash(tx, unwindTo)
+	if err != nil {
+		return err
+	}
+	if err := writeHead(tx, unwindTo, hash, 0); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func writeBlock(tx kv.RwTx, block *types.Block, senders []libcommon.Address) error {
+	blockNum, hash := block.NumberU64(), block.Hash()
+	if err := rawdb.WriteBlock(tx, block); err != nil {
+		return err
+	}
+	if err := rawdb.WriteCanonicalHash(tx, hash, blockNum); err != nil {
+		return err
+	}
+	if len(senders) > 0 {
+		if err := rawdb.WriteSenders(tx, hash, blockNum, senders); err != nil {
+			return err
+		}
+	}
+	parentTd, err := rawdb.ReadTd(tx, block.ParentHash(), blockNum-1)
+	if err != nil {
+		return err
+	}
+	if parentTd != nil {
+		if err := rawdb.WriteTd(tx, hash, blockNum, new(big.Int).Add(parentTd, block.Difficulty())); err != nil {
+			return err
+		}
+	}
+	rawdb.WriteTxLookupEntries(tx, block)
+	return rawdb.AppendCanonicalTxNums(tx, blockNum)
+}
+
+// writeHead - `finalized` is primary's finalized block, 0 - unknown. Safe block comes with shipments.
+func writeHead(tx kv.RwTx, blockNum uint64, hash libcommon.Hash, finalized uint64) error {
+	for _, stage := range headStages {
+		if err := stages.SaveStageProgress(tx, stage, blockNum); err != nil {
+			return err
+		}
+	}
+	if err := rawdb.WriteHeadHeaderHash(tx, hash); err != nil {
+		return err
+	}
+	rawdb.WriteHeadBlockHash(tx, hash)
+	rawdb.WriteForkchoiceHead(tx, hash)
+	if finalized == 0 || finalized > blockNum {
+		return nil
+	}
+	finalizedHash, err := rawdb.ReadCanonicalHash(tx, finalized)
+	if err != nil {
+		return err
+	}
+	if finalizedHash != (libcommon.Hash{}) {
+		rawdb.WriteForkchoiceFinalized(tx, finalizedHash)
+	}
+	return nil
+}
+
+func applyAccountChange(sd *libstate.SharedDomains, change *remote.AccountChange) error {
+	addr := libcommon.Address(gointerfaces.ConvertH160toAddress(change.Address))
+	switch change.Action {
+	case remote.Action_UPSERT, remote.Action_UPSERT_CODE:
+		if err := sd.DomainPut(kv.AccountsDomain, addr[:], nil, change.Data, nil, 0); err != nil {
+			return err
+		}
+	case remote.Action_REMOVE:
+		// also removes code and storage
+		return sd.DomainDel(kv.AccountsDomain, addr[:], nil, nil, 0)
+	}
+	switch change.Action {
+	case remote.Action_UPSERT_CODE, remote.Action_CODE:
+		if len(change.Code) == 0 {
+			if err := sd.DomainDel(kv.CodeDomain, addr[:], nil, nil, 0); err != nil {
+				return err
+			}
+		} else if err := sd.DomainPut(kv.CodeDomain, addr[:], nil, change.Code, nil, 0); err != nil {
+			return err
+		}
+	}
+	for _, storageChange := range change.StorageChanges {
+		loc := gointerfaces.ConvertH256ToHash(storageChange.Location)
+		if len(storageChange.Data) == 0 {
+			if err := sd.DomainDel(kv.StorageDomain, addr[:], loc[:], nil, 0); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := sd.DomainPut(kv.StorageDomain, addr[:], loc[:], storageChange.Data, nil, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package follower
+
+import (
+	"context"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/rawdb"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/eth/stagedsync/stages"
+	"github.com/erigontech/erigon/params"
+	"github.com/erigontech/erigon/turbo/stages/mock"
+)
+
+type recorder []*remote.StateChangeBatch
+
+func (r *recorder) SendStateChanges(_ context.Context, sc *remote.StateChangeBatch) {
+	*r = append(*r, sc)
+}
+
+type chainBlocks map[libcommon.Hash]*types.Block
+
+func (c chainBlocks) BlockWithSenders(_ context.Context, _ kv.Getter, hash libcommon.Hash, _ uint64) (*types.Block, []libcommon.Address, error) {
+	return c[hash], nil, nil
+}
+
+type noFrozenBlocks struct{}
+
+func (noFrozenBlocks) FrozenBlocks() uint64 { return 0 }
+
+// generate - blocks with transfers and storage writes, `salt` changes blocks starting from `forkAt`
+func generate(t *testing.T, m *mock.MockSentry, n, forkAt int, salt byte) *core.ChainPack {
+	t.Helper()
+	signer := types.LatestSignerForChainID(nil)
+	// init code: SSTORE(0, NUMBER), empty runtime code
+	initCode := []byte{0x43, 0x60, 0x00, 0x55}
+	chain, err := core.GenerateChain(m.ChainConfig, m.Genesis, m.Engine, m.DB, n, func(i int, b *core.BlockGen) {
+		to := libcommon.Address{byte(i + 1)}
+		if i >= forkAt {
+			to[1] = salt
+			b.SetCoinbase(libcommon.Address{salt})
+		}
+		txn, err := types.SignTx(types.NewTransaction(b.TxNonce(m.Address), to, uint256.NewInt(1000), 21000, uint256.NewInt(100*params.GWei), nil), *signer, m.Key)
+		require.NoError(t, err)
+		b.AddTx(txn)
+		txn, err = types.SignTx(types.NewContractCreation(b.TxNonce(m.Address), uint256.NewInt(0), 100_000, uint256.NewInt(100*params.GWei), initCode), *signer, m.Key)
+		require.NoError(t, err)
+		b.AddTx(txn)
+	})
+	require.NoError(t, err)
+	return chain
+}
+
+func head(t *testing.T, db kv.RoDB) (blockNum uint64, hash libcommon.Hash) {
+	t.Helper()
+	require.NoError(t, db.View(context.Background(), func(tx kv.Tx) (err error) {
+		if blockNum, err = stages.GetStageProgress(tx, stages.Execution); err != nil {
+			return err
+		}
+		hash = rawdb.ReadForkchoiceHead(tx)
+		return nil
+	}))
+	return blockNum, hash
+}
+
+func TestApply(t *testing.T) {
+	ctx, logger := context.Background(), log.New()
+	primary := mock.Mock(t)
+	var batches recorder
+	primary.Notifications.StateChangesConsumer = &batches
+
+	chain := generate(t, primary, 10, 10, 0)
+	fork := generate(t, primary, 12, 7, 1)
+	blocks := chainBlocks{}
+	for _, b := range append(chain.Blocks, fork.Blocks...) {
+		blocks[b.Hash()] = b
+	}
+	require.NoError(t, primary.InsertChain(chain))
+	forward := len(batches)
+	require.NoError(t, primary.InsertChain(fork))
+
+	// follower's last shipment has 4 blocks
+	replica := mock.Mock(t)
+	require.NoError(t, replica.InsertChain(chain.Slice(0, 4)))
+	f := New(t.TempDir(), replica.Dirs, replica.DB, logger)
+	f.Follow(nil, blocks, replica.DB, noFrozenBlocks{})
+
+	for _, batch := range batches[:forward] {
+		require.NoError(t, f.Apply(ctx, batch))
+	}
+	require.False(t, f.diverged)
+	blockNum, hash := head(t, replica.DB)
+	require.Equal(t, uint64(10), blockNum)
+	require.Equal(t, chain.TopBlock.Hash(), hash)
+
+	// primary's reorg: unwind by changesets of applied blocks, then blocks of the fork
+	var unwinds int
+	for _, batch := range batches[forward:] {
+		for _, change := range batch.ChangeBatch {
+			if change.Direction == remote.Direction_UNWIND {
+				unwinds++
+			}
+		}
+		require.NoError(t, f.Apply(ctx, batch))
+	}
+	require.Equal(t, 1, unwinds)
+	require.False(t, f.diverged)
+	blockNum, hash = head(t, replica.DB)
+	require.Equal(t, uint64(12), blockNum)
+	require.Equal(t, fork.TopBlock.Hash(), hash)
+
+	// every applied block passed state root check: latest state is primary's
+	require.NoError(t, replica.DB.View(ctx, func(tx kv.Tx) error {
+		return primary.DB.View(ctx, func(primaryTx kv.Tx) error {
+			for _, addr := range []libcommon.Address{{10}, {12, 1}, primary.Address} {
+				v, _, err := tx.(kv.TemporalTx).DomainGet(kv.AccountsDomain, addr[:], nil)
+				require.NoError(t, err)
+				expect, _, err := primaryTx.(kv.TemporalTx).DomainGet(kv.AccountsDomain, addr[:], nil)
+				require.NoError(t, err)
+				require.Equal(t, expect, v, addr)
+			}
+			return nil
+		})
+	}))
+}
+
+func TestApplyDiverged(t *testing.T) {
+	ctx, logger := context.Background(), log.New()
+	primary := mock.Mock(t)
+	var batches recorder
+	primary.Notifications.StateChangesConsumer = &batches
+	chain := generate(t, primary, 6, 6, 0)
+	blocks := chainBlocks{}
+	for _, b := range chain.Blocks {
+		blocks[b.Hash()] = b
+	}
+	require.NoError(t, primary.InsertChain(chain))
+
+	t.Run("missed blocks", func(t *testing.T) {
+		replica := mock.Mock(t)
+		require.NoError(t, replica.InsertChain(chain.Slice(0, 2)))
+		f := New(t.TempDir(), replica.Dirs, replica.DB, logger)
+		f.Follow(nil, blocks, replica.DB, noFrozenBlocks{})
+
+		for _, batch := range batches {
+			missed := &remote.StateChangeBatch{}
+			for _, change := range batch.ChangeBatch {
+				if change.BlockHeight != 3 {
+					missed.ChangeBatch = append(missed.ChangeBatch, change)
+				}
+			}
+			require.NoError(t, f.Apply(ctx, missed))
+		}
+		// not diverged: waits for shipment which has missed blocks
+		require.False(t, f.diverged)
+		blockNum, _ := head(t, replica.DB)
+		require.Equal(t, uint64(2), blockNum)
+	})
+
+	t.Run("state root mismatch", func(t *testing.T) {
+		replica := mock.Mock(t)
+		require.NoError(t, replica.InsertChain(chain.Slice(0, 2)))
+		f := New(t.TempDir(), replica.Dirs, replica.DB, logger)
+		f.Follow(nil, blocks, replica.DB, noFrozenBlocks{})
+
+		for _, batch := range batches {
+			for _, change := range batch.ChangeBatch {
+				if change.BlockHeight == 4 {
+					change.Changes = change.Changes[1:]
+				}
+			}
+			require.NoError(t, f.Apply(ctx, batch))
+		}
+		require.True(t, f.diverged)
+		blockNum, hash := head(t, replica.DB)
+		require.Equal(t, uint64(3), blockNum)
+		require.Equal(t, chain.Blocks[2].Hash(), hash)
+	})
+}
@@ -41,6 +41,7 @@ import (
 	"github.com/erigontech/erigon/eth/ethconfig"
 	"github.com/erigontech/erigon/ethdb/privateapi"
 	"github.com/erigontech/erigon/p2p"
+	"github.com/erigontech/erigon/p2p/enode"
 	"github.com/erigontech/erigon/rlp"
 	"github.com/erigontech/erigon/turbo/services"
 )
@@ -410,6 +411,99 @@ func (back *RemoteBackend) AddPeer(ctx context.Context, request *remote.AddPeerR
 	return result, nil
 }
 
+func (back *RemoteBackend) RemovePeer(ctx context.Context, request *remote.RemovePeerRequest) (*remote.RemovePeerReply, error) {
+	result, err := back.remoteEthBackend.RemovePeer(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("ETHBACKENDClient.RemovePeer() error: %w", err)
+	}
+	return result, nil
+}
+
+func (back *RemoteBackend) AddTrustedPeer(ctx context.Context, request *remote.AddPeerRequest) (*remote.AddPeerReply, error) {
+	result, err := back.remoteEthBackend.AddTrustedPeer(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("ETHBACKENDClient.AddTrustedPeer() error: %w", err)
+	}
+	return result, nil
+}
+
+func (back *RemoteBackend) RemoveTrustedPeer(ctx context.Context, request *remote.RemovePeerRequest) (*remote.RemovePeerReply, error) {
+	result, err := back.remoteEthBackend.RemoveTrustedPeer(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("ETHBACKENDClient.RemoveTrustedPeer() error: %w", err)
+	}
+	return result, nil
+}
+
+func (back *RemoteBackend) SetMaxPeers(ctx context.Context, request *remote.SetMaxPeersRequest) (*remote.SetMaxPeersReply, error) {
+	result, err := back.remoteEthBackend.SetMaxPeers(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("ETHBACKENDClient.SetMaxPeers() error: %w", err)
+	}
+	return result, nil
+}
+
+func (back *RemoteBackend) PeerScores(ctx context.Context) ([]*p2p.PeerScore, error) {
+	reply, err := back.remoteEthBackend.PeerScores(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("ETHBACKENDClient.PeerScores() error: %w", err)
+	}
+
+	scores := make([]*p2p.PeerScore, 0, len(reply.Scores))
+	for _, score := range reply.Scores {
+		scores = append(scores, &p2p.PeerScore{
+			ID:           score.Id,
+			Useless:      score.Useless,
+			InvalidBlock: score.InvalidBlock,
+			Timeout:      score.Timeout,
+			Score:        score.Score,
+			Updated:      score.Updated,
+		})
+	}
+
+	return scores, nil
+}
+
+func (back *RemoteBackend) PeerEvents(ctx context.Context, onEvent func(*p2p.PeerEvent)) error {
+	subscription, err := back.remoteEthBackend.PeerEvents(ctx, &remote.PeerEventsRequest{}, grpc.WaitForReady(true))
+	if err != nil {
+		if s, ok := status.FromError(err); ok {
+			return errors.New(s.Message())
+		}
+		return err
+	}
+	for {
+		event, err := subscription.Recv()
+		if errors.Is(err, io.EOF) {
+			log.Debug("rpcdaemon: the peer events channel was closed")
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		onEvent(peerEvent(event))
+	}
+	return nil
+}
+
+func peerEvent(event *remote.PeerEvent) *p2p.PeerEvent {
+	var eventType p2p.PeerEventType
+	switch event.EventId {
+	case remote.PeerEvent_Connect:
+		eventType = p2p.PeerEventTypeAdd
+	case remote.PeerEvent_Disconnect:
+		eventType = p2p.PeerEventTypeDrop
+	case remote.PeerEvent_MessageError:
+		eventType = p2p.PeerEventTypeMsgError
+	}
+	return &p2p.PeerEvent{
+		Type:  eventType,
+		Peer:  enode.PubkeyEncoded(gointerfaces.ConvertH512ToHash(event.PeerId)).ID(),
+		Error: event.Error,
+	}
+}
+
 func (back *RemoteBackend) Peers(ctx context.Context) ([]*p2p.PeerInfo, error) {
 	rpcPeers, err := back.remoteEthBackend.Peers(ctx, &emptypb.Empty{})
 	if err != nil {
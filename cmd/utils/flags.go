@@ -66,6 +66,7 @@ import (
 	"github.com/erigontech/erigon/p2p/netutil"
 	"github.com/erigontech/erigon/params"
 	borsnaptype "github.com/erigontech/erigon/polygon/bor/snaptype"
+	"github.com/erigontech/erigon/polygon/heimdall"
 	"github.com/erigontech/erigon/rpc/rpccfg"
 	"github.com/erigontech/erigon/turbo/logging"
 )
@@ -696,6 +697,10 @@ var (
 		Name:  ethconfig.FlagSnapStateStop,
 		Usage: "Workaround to stop producing new state files, if you meet some state-related critical bug. It will stop aggregate DB history in a state files. DB will grow and may slightly slow-down - and removing this flag in future will not fix this effect (db size will not greatly reduce).",
 	}
+	SnapExistenceFlag = cli.BoolFlag{
+		Name:  ethconfig.FlagSnapExistence,
+		Usage: "Build existence filters (.efei) of logs and traces index files. Speeds up search of rare addresses/topics - by cost of extra disk space and files build time",
+	}
 	TorrentVerbosityFlag = cli.IntFlag{
 		Name:  "torrent.verbosity",
 		Value: 2,
@@ -783,10 +788,22 @@ var (
 
 	HeimdallURLFlag = cli.StringFlag{
 		Name:  "bor.heimdall",
-		Usage: "URL of Heimdall service",
+		Usage: "URL of Heimdall service, or sim://<dir> to replay recorded Heimdall data offline",
 		Value: "http://localhost:1317",
 	}
 
+	HeimdallRecordFlag = cli.StringFlag{
+		Name:  "bor.heimdall.record",
+		Usage: "Record the Heimdall responses into this directory, for replaying them with --bor.heimdall=sim://<dir>",
+		Value: "",
+	}
+
+	HeimdallVersionFlag = cli.StringFlag{
+		Name:  "bor.heimdall.version",
+		Usage: "Version of the Heimdall API: auto, v1 (REST) or v2 (CometBFT/gRPC gateway)",
+		Value: "auto",
+	}
+
 	// WithoutHeimdallFlag no heimdall (for testing purpose)
 	WithoutHeimdallFlag = cli.BoolFlag{
 		Name:  "bor.withoutheimdall",
@@ -861,6 +878,51 @@ var (
 		Usage: "Enable caplin validator monitoring metrics",
 		Value: false,
 	}
+	CaplinValidatorClientFlag = cli.BoolFlag{
+		Name:  "caplin.validator-client",
+		Usage: "Enable the embedded validator client of caplin",
+		Value: false,
+	}
+	CaplinValidatorKeystoresFlag = cli.StringFlag{
+		Name:  "caplin.validator-keystores",
+		Usage: "Directory of the EIP-2335 keystores of the validators run by the embedded validator client",
+		Value: "",
+	}
+	CaplinValidatorPasswordFileFlag = cli.StringFlag{
+		Name:  "caplin.validator-password-file",
+		Usage: "File containing the password of the validator keystores",
+		Value: "",
+	}
+	CaplinValidatorGraffitiFlag = cli.StringFlag{
+		Name:  "caplin.validator-graffiti",
+		Usage: "Graffiti of the blocks proposed by the embedded validator client (up to 32 bytes)",
+		Value: "",
+	}
+	CaplinSlashingProtectionImportFlag = cli.StringFlag{
+		Name:  "caplin.slashing-protection-import",
+		Usage: "EIP-3076 interchange file to import into the slashing protection database at startup",
+		Value: "",
+	}
+	CaplinSlashingProtectionExportFlag = cli.StringFlag{
+		Name:  "caplin.slashing-protection-export",
+		Usage: "File where the slashing protection database is exported in EIP-3076 interchange format at shutdown",
+		Value: "",
+	}
+	CaplinForkChoiceRecordFlag = cli.StringFlag{
+		Name:  "caplin.forkchoice-record",
+		Usage: "File where every forkchoice input is recorded, to be replayed with `caplin replay-forkchoice`",
+		Value: "",
+	}
+	CaplinEraDirFlag = cli.StringFlag{
+		Name:  "caplin.era-dir",
+		Usage: "Directory of .era files the beacon chain history is imported from before downloading it from peers",
+		Value: "",
+	}
+	CaplinHistoricalStatesCacheFlag = cli.IntFlag{
+		Name:  "caplin.historical-states-cache",
+		Usage: "Amount of reconstructed historical states kept in memory, 0 disables the cache",
+		Value: 0,
+	}
 
 	SentinelAddrFlag = cli.StringFlag{
 		Name:  "sentinel.addr",
@@ -1206,6 +1268,8 @@ func NewP2PConfig(
 		enodeDBPath = filepath.Join(dirs.Nodes, "eth67")
 	case direct.ETH68:
 		enodeDBPath = filepath.Join(dirs.Nodes, "eth68")
+	case direct.ETH69:
+		enodeDBPath = filepath.Join(dirs.Nodes, "eth69")
 	default:
 		return nil, fmt.Errorf("unknown protocol: %v", protocol)
 	}
@@ -1635,6 +1699,11 @@ func setClique(ctx *cli.Context, cfg *params.ConsensusSnapshotConfig, datadir st
 
 func setBorConfig(ctx *cli.Context, cfg *ethconfig.Config) {
 	cfg.HeimdallURL = ctx.String(HeimdallURLFlag.Name)
+	cfg.HeimdallVersion = ctx.String(HeimdallVersionFlag.Name)
+	cfg.HeimdallRecordDir = ctx.String(HeimdallRecordFlag.Name)
+	if _, err := heimdall.ParseClientVersion(cfg.HeimdallVersion); err != nil {
+		Fatalf("Option %s: %v", HeimdallVersionFlag.Name, err)
+	}
 	cfg.WithoutHeimdall = ctx.Bool(WithoutHeimdallFlag.Name)
 	cfg.WithHeimdallMilestones = ctx.Bool(WithHeimdallMilestones.Name)
 	cfg.WithHeimdallWaypointRecording = ctx.Bool(WithHeimdallWaypoints.Name)
@@ -1720,6 +1789,15 @@ func setCaplin(ctx *cli.Context, cfg *ethconfig.Config) {
 	cfg.CaplinConfig.Archive = ctx.Bool(CaplinArchiveFlag.Name)
 	cfg.CaplinConfig.MevRelayUrl = ctx.String(CaplinMevRelayUrl.Name)
 	cfg.CaplinConfig.EnableValidatorMonitor = ctx.Bool(CaplinValidatorMonitorFlag.Name)
+	cfg.CaplinConfig.EnableValidatorClient = ctx.Bool(CaplinValidatorClientFlag.Name)
+	cfg.CaplinConfig.ValidatorKeystoresDir = ctx.String(CaplinValidatorKeystoresFlag.Name)
+	cfg.CaplinConfig.ValidatorPasswordFile = ctx.String(CaplinValidatorPasswordFileFlag.Name)
+	cfg.CaplinConfig.ValidatorGraffiti = ctx.String(CaplinValidatorGraffitiFlag.Name)
+	cfg.CaplinConfig.SlashingProtectionImportFile = ctx.String(CaplinSlashingProtectionImportFlag.Name)
+	cfg.CaplinConfig.SlashingProtectionExportFile = ctx.String(CaplinSlashingProtectionExportFlag.Name)
+	cfg.CaplinConfig.ForkChoiceRecordFile = ctx.String(CaplinForkChoiceRecordFlag.Name)
+	cfg.CaplinConfig.EraDir = ctx.String(CaplinEraDirFlag.Name)
+	cfg.CaplinConfig.HistoricalStatesCacheSize = ctx.Int(CaplinHistoricalStatesCacheFlag.Name)
 	if checkpointUrls := ctx.StringSlice(CaplinCheckpointSyncUrlFlag.Name); len(checkpointUrls) > 0 {
 		clparams.ConfigurableCheckpointsURLs = checkpointUrls
 	}
@@ -1812,6 +1890,7 @@ func SetEthConfig(ctx *cli.Context, nodeConfig *nodecfg.Config, cfg *ethconfig.C
 	cfg.Snapshot.KeepBlocks = ctx.Bool(SnapKeepBlocksFlag.Name)
 	cfg.Snapshot.ProduceE2 = !ctx.Bool(SnapStopFlag.Name)
 	cfg.Snapshot.ProduceE3 = !ctx.Bool(SnapStateStopFlag.Name)
+	cfg.Snapshot.Existence = ctx.Bool(SnapExistenceFlag.Name)
 	cfg.Snapshot.NoDownloader = ctx.Bool(NoDownloaderFlag.Name)
 	cfg.Snapshot.Verify = ctx.Bool(DownloaderVerifyFlag.Name)
 	cfg.Snapshot.DownloaderAddr = strings.TrimSpace(ctx.String(DownloaderAddrFlag.Name))
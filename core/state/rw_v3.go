@@ -114,7 +114,8 @@ func (rs *StateV3) CommitTxNum(sender *common.Address, txNum uint64, in *QueueWi
 	return count
 }
 
-func (rs *StateV3) applyState(txTask *TxTask, domains *libstate.SharedDomains) error {
+// applyState - `accumulator` receives balance increases: they are not in worker's write set
+func (rs *StateV3) applyState(txTask *TxTask, domains *libstate.SharedDomains, accumulator *shards.Accumulator) error {
 	var acc accounts.Account
 
 	//maps are unordered in Go! don't iterate over it. SharedDomains.deleteAccount will call GetLatest(Code) and expecting it not been delete yet
@@ -163,6 +164,9 @@ func (rs *StateV3) applyState(txTask *TxTask, domains *libstate.SharedDomains) e
 			if err := domains.DomainPut(kv.AccountsDomain, addrBytes, nil, enc1, enc0, step0); err != nil {
 				return err
 			}
+			if accumulator != nil {
+				accumulator.ChangeAccount(addr, acc.Incarnation, enc1)
+			}
 		}
 	}
 	return nil
@@ -177,13 +181,13 @@ func (rs *StateV3) SetTxNum(txNum, blockNum uint64) {
 	rs.domains.SetBlockNum(blockNum)
 }
 
-func (rs *StateV3) ApplyState4(ctx context.Context, txTask *TxTask) error {
+func (rs *StateV3) ApplyState4(ctx context.Context, txTask *TxTask, accumulator *shards.Accumulator) error {
 	if txTask.HistoryExecution {
 		return nil
 	}
 	//defer rs.domains.BatchHistoryWriteStart().BatchHistoryWriteEnd()
 
-	if err := rs.applyState(txTask, rs.domains); err != nil {
+	if err := rs.applyState(txTask, rs.domains, accumulator); err != nil {
 		return fmt.Errorf("StateV3.ApplyState: %w", err)
 	}
 	returnReadList(txTask.ReadLists)
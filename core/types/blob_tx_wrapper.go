@@ -23,7 +23,7 @@ import (
 	"math/big"
 	"math/bits"
 
-	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	goethkzg "github.com/crate-crypto/go-eth-kzg"
 	"github.com/holiman/uint256"
 
 	"github.com/erigontech/erigon-lib/chain"
@@ -226,12 +226,12 @@ func (blobs Blobs) ComputeCommitmentsAndProofs() (commitments []KZGCommitment, v
 
 	kzgCtx := libkzg.Ctx()
 	for i, blob := range blobs {
-		commitment, err := kzgCtx.BlobToKZGCommitment(gokzg4844.Blob(blob), 1 /*numGoRoutines*/)
+		commitment, err := kzgCtx.BlobToKZGCommitment((*goethkzg.Blob)(&blob), 1 /*numGoRoutines*/)
 		if err != nil {
 			return nil, nil, nil, fmt.Errorf("could not convert blob to commitment: %v", err)
 		}
 
-		proof, err := kzgCtx.ComputeBlobKZGProof(gokzg4844.Blob(blob), commitment, 1 /*numGoRoutnes*/)
+		proof, err := kzgCtx.ComputeBlobKZGProof((*goethkzg.Blob)(&blob), commitment, 1 /*numGoRoutnes*/)
 		if err != nil {
 			return nil, nil, nil, fmt.Errorf("could not compute proof for blob: %v", err)
 		}
@@ -243,30 +243,30 @@ func (blobs Blobs) ComputeCommitmentsAndProofs() (commitments []KZGCommitment, v
 	return commitments, versionedHashes, proofs, nil
 }
 
-func toBlobs(_blobs Blobs) []gokzg4844.Blob {
-	blobs := make([]gokzg4844.Blob, len(_blobs))
+func toBlobs(_blobs Blobs) []goethkzg.Blob {
+	blobs := make([]goethkzg.Blob, len(_blobs))
 	for i, _blob := range _blobs {
-		blobs[i] = gokzg4844.Blob(_blob)
+		blobs[i] = goethkzg.Blob(_blob)
 	}
 	return blobs
 }
-func toComms(_comms BlobKzgs) []gokzg4844.KZGCommitment {
-	comms := make([]gokzg4844.KZGCommitment, len(_comms))
+func toComms(_comms BlobKzgs) []goethkzg.KZGCommitment {
+	comms := make([]goethkzg.KZGCommitment, len(_comms))
 	for i, _comm := range _comms {
-		comms[i] = gokzg4844.KZGCommitment(_comm)
+		comms[i] = goethkzg.KZGCommitment(_comm)
 	}
 	return comms
 }
-func toProofs(_proofs KZGProofs) []gokzg4844.KZGProof {
-	proofs := make([]gokzg4844.KZGProof, len(_proofs))
+func toProofs(_proofs KZGProofs) []goethkzg.KZGProof {
+	proofs := make([]goethkzg.KZGProof, len(_proofs))
 	for i, _proof := range _proofs {
-		proofs[i] = gokzg4844.KZGProof(_proof)
+		proofs[i] = goethkzg.KZGProof(_proof)
 	}
 	return proofs
 }
 
 func (c KZGCommitment) ComputeVersionedHash() libcommon.Hash {
-	return libcommon.Hash(libkzg.KZGToVersionedHash(gokzg4844.KZGCommitment(c)))
+	return libcommon.Hash(libkzg.KZGToVersionedHash(goethkzg.KZGCommitment(c)))
 }
 
 /* BlobTxWrapper methods */
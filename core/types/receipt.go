@@ -345,6 +345,46 @@ func (r *ReceiptForStorage) DecodeRLP(s *rlp.Stream) error {
 
 }
 
+// ReceiptForNetwork69 is a wrapper around a Receipt with the eth/69 RLP serialization
+// that carries the transaction type and omits the Bloom field.
+type ReceiptForNetwork69 Receipt
+
+// receipt69RLP is the eth/69 network encoding of a receipt.
+type receipt69RLP struct {
+	Type              uint8
+	PostStateOrStatus []byte
+	CumulativeGasUsed uint64
+	Logs              []*Log
+}
+
+// EncodeRLP implements rlp.Encoder, and flattens the consensus fields of a receipt,
+// except the Bloom, into a plain RLP list.
+func (r *ReceiptForNetwork69) EncodeRLP(w io.Writer) error {
+	return rlp.Encode(w, &receipt69RLP{
+		Type:              r.Type,
+		PostStateOrStatus: (*Receipt)(r).statusEncoding(),
+		CumulativeGasUsed: r.CumulativeGasUsed,
+		Logs:              r.Logs,
+	})
+}
+
+// DecodeRLP implements rlp.Decoder, and loads the consensus fields of a receipt,
+// re-computing the Bloom from its logs.
+func (r *ReceiptForNetwork69) DecodeRLP(s *rlp.Stream) error {
+	var dec receipt69RLP
+	if err := s.Decode(&dec); err != nil {
+		return err
+	}
+	if err := (*Receipt)(r).setStatus(dec.PostStateOrStatus); err != nil {
+		return err
+	}
+	r.Type = dec.Type
+	r.CumulativeGasUsed = dec.CumulativeGasUsed
+	r.Logs = dec.Logs
+	r.Bloom = CreateBloom(Receipts{(*Receipt)(r)})
+	return nil
+}
+
 // Receipts implements DerivableList for receipts.
 type Receipts []*Receipt
 
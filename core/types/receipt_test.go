@@ -369,6 +369,47 @@ func TestTypedReceiptEncodingDecoding(t *testing.T) {
 	}
 }
 
+func TestReceiptForNetwork69EncodingDecoding(t *testing.T) {
+	t.Parallel()
+	logs := []*Log{{
+		Address: libcommon.BytesToAddress([]byte{0x11}),
+		Topics:  []libcommon.Hash{libcommon.HexToHash("dead"), libcommon.HexToHash("beef")},
+		Data:    []byte{0x01, 0x00, 0xff},
+	}}
+	receipts := []*Receipt{
+		{Type: LegacyTxType, Status: ReceiptStatusFailed, CumulativeGasUsed: 1, Logs: []*Log{}},
+		{Type: LegacyTxType, PostState: libcommon.HexToHash("0x01").Bytes(), CumulativeGasUsed: 2, Logs: logs},
+		{Type: DynamicFeeTxType, Status: ReceiptStatusSuccessful, CumulativeGasUsed: 3, Logs: logs},
+	}
+	for i, want := range receipts {
+		want.Bloom = CreateBloom(Receipts{want})
+
+		enc, err := rlp.EncodeToBytes((*ReceiptForNetwork69)(want))
+		if err != nil {
+			t.Fatalf("receipt %d: encode: %v", i, err)
+		}
+		// the eth/69 encoding is a plain list, even for typed receipts, and it has no bloom
+		if kind, _, _, err := rlp.Split(enc); err != nil || kind != rlp.List {
+			t.Fatalf("receipt %d: expected an rlp list, got %v (err: %v)", i, kind, err)
+		}
+		if consensus, _ := rlp.EncodeToBytes(want); len(enc) >= len(consensus) {
+			t.Fatalf("receipt %d: expected the encoding without bloom to be smaller, got %d >= %d", i, len(enc), len(consensus))
+		}
+
+		var dec ReceiptForNetwork69
+		if err := rlp.DecodeBytes(enc, &dec); err != nil {
+			t.Fatalf("receipt %d: decode: %v", i, err)
+		}
+		got := (*Receipt)(&dec)
+		assert.Equal(t, want.Type, got.Type, "receipt %d", i)
+		assert.Equal(t, want.Status, got.Status, "receipt %d", i)
+		assert.Equal(t, want.PostState, got.PostState, "receipt %d", i)
+		assert.Equal(t, want.CumulativeGasUsed, got.CumulativeGasUsed, "receipt %d", i)
+		assert.Equal(t, want.Bloom, got.Bloom, "receipt %d", i)
+		assert.Len(t, got.Logs, len(want.Logs), "receipt %d", i)
+	}
+}
+
 func clearComputedFieldsOnReceipts(t *testing.T, receipts Receipts) {
 	t.Helper()
 
@@ -0,0 +1,37 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package kzg
+
+import (
+	goethkzg "github.com/crate-crypto/go-eth-kzg"
+)
+
+// Cell KZG proofs of EIP-7594 (PeerDAS), see
+// https://github.com/ethereum/consensus-specs/blob/dev/specs/_features/eip7594/polynomial-commitments-sampling.md
+const (
+	BytesPerCell    = goethkzg.BytesPerCell
+	CellsPerExtBlob = goethkzg.CellsPerExtBlob
+)
+
+// Cell is a slice of the evaluations of the extended blob polynomial, it is the unit of data availability sampling.
+type Cell = goethkzg.Cell
+
+// VerifyCellKZGProofBatch implements verify_cell_kzg_proof_batch: it checks that each cells[k] is the cell at
+// cellIndices[k] of the extended blob committed to by commitments[k].
+func VerifyCellKZGProofBatch(commitments []goethkzg.KZGCommitment, cellIndices []uint64, cells []*Cell, proofs []goethkzg.KZGProof) error {
+	return Ctx().VerifyCellKZGProofBatch(commitments, cellIndices, cells, proofs)
+}
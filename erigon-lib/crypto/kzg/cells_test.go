@@ -0,0 +1,93 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package kzg
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	goethkzg "github.com/crate-crypto/go-eth-kzg"
+	"github.com/stretchr/testify/require"
+)
+
+func randomBlob(t *testing.T, seed int64) *goethkzg.Blob {
+	r := rand.New(rand.NewSource(seed))
+	blob := &goethkzg.Blob{}
+	var buf [32]byte
+	for i := 0; i < goethkzg.ScalarsPerBlob; i++ {
+		r.Read(buf[:])
+		var e fr.Element
+		e.SetBytes(buf[:])
+		b := e.Bytes()
+		copy(blob[i*goethkzg.SerializedScalarSize:], b[:])
+	}
+	return blob
+}
+
+func testCells(t *testing.T, seed int64, cellIndices []uint64) (goethkzg.KZGCommitment, []*Cell, []goethkzg.KZGProof) {
+	blob := randomBlob(t, seed)
+	commitment, err := Ctx().BlobToKZGCommitment(blob, 0)
+	require.NoError(t, err)
+	allCells, allProofs, err := Ctx().ComputeCellsAndKZGProofs(blob, 0)
+	require.NoError(t, err)
+	cells := make([]*Cell, len(cellIndices))
+	proofs := make([]goethkzg.KZGProof, len(cellIndices))
+	for k, cellIndex := range cellIndices {
+		cells[k] = allCells[cellIndex]
+		proofs[k] = allProofs[cellIndex]
+	}
+	return commitment, cells, proofs
+}
+
+func TestVerifyCellKZGProofBatch(t *testing.T) {
+	commitmentA, cellsA, proofsA := testCells(t, 1, []uint64{0, 5, CellsPerExtBlob - 1})
+	commitmentB, cellsB, proofsB := testCells(t, 2, []uint64{5, 64})
+
+	commitments := []goethkzg.KZGCommitment{commitmentA, commitmentA, commitmentA, commitmentB, commitmentB}
+	cellIndices := []uint64{0, 5, CellsPerExtBlob - 1, 5, 64}
+	cells := append(cellsA, cellsB...)
+	proofs := append(proofsA, proofsB...)
+	require.NoError(t, VerifyCellKZGProofBatch(commitments, cellIndices, cells, proofs))
+	require.NoError(t, VerifyCellKZGProofBatch(nil, nil, nil, nil))
+
+	// wrong cell index
+	wrongIndices := append([]uint64{}, cellIndices...)
+	wrongIndices[1] = 6
+	require.Error(t, VerifyCellKZGProofBatch(commitments, wrongIndices, cells, proofs))
+	wrongIndices[1] = CellsPerExtBlob
+	require.ErrorIs(t, VerifyCellKZGProofBatch(commitments, wrongIndices, cells, proofs), goethkzg.ErrInvalidCellID)
+
+	// wrong commitment
+	wrongCommitments := append([]goethkzg.KZGCommitment{}, commitments...)
+	wrongCommitments[4] = commitmentA
+	require.Error(t, VerifyCellKZGProofBatch(wrongCommitments, cellIndices, cells, proofs))
+
+	// proofs of another cell
+	wrongProofs := append([]goethkzg.KZGProof{}, proofs...)
+	wrongProofs[0], wrongProofs[1] = wrongProofs[1], wrongProofs[0]
+	require.Error(t, VerifyCellKZGProofBatch(commitments, cellIndices, cells, wrongProofs))
+
+	// tampered cell
+	tampered := *cells[2]
+	tampered[31] ^= 1
+	wrongCells := append([]*Cell{}, cells...)
+	wrongCells[2] = &tampered
+	require.Error(t, VerifyCellKZGProofBatch(commitments, cellIndices, wrongCells, proofs))
+
+	require.ErrorIs(t, VerifyCellKZGProofBatch(commitments[1:], cellIndices, cells, proofs), goethkzg.ErrBatchLengthCheck)
+}
@@ -25,7 +25,7 @@ import (
 	"os"
 	"sync"
 
-	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	goethkzg "github.com/crate-crypto/go-eth-kzg"
 )
 
 const (
@@ -43,13 +43,13 @@ var (
 
 	trustedSetupFile string
 
-	gokzgCtx      *gokzg4844.Context
+	gokzgCtx      *goethkzg.Context
 	initCryptoCtx sync.Once
 )
 
 func init() {
-	new(big.Int).SetUint64(gokzg4844.ScalarsPerBlob).FillBytes(precompileReturnValue[:32])
-	copy(precompileReturnValue[32:], gokzg4844.BlsModulus[:])
+	new(big.Int).SetUint64(goethkzg.ScalarsPerBlob).FillBytes(precompileReturnValue[:32])
+	copy(precompileReturnValue[32:], goethkzg.BlsModulus[:])
 }
 
 func SetTrustedSetupFilePath(path string) {
@@ -65,12 +65,12 @@ func InitKZGCtx() {
 				panic(fmt.Sprintf("could not read file, err: %v", err))
 			}
 
-			setup := new(gokzg4844.JSONTrustedSetup)
+			setup := new(goethkzg.JSONTrustedSetup)
 			if err = json.Unmarshal(file, setup); err != nil {
 				panic(fmt.Sprintf("could not unmarshal, err: %v", err))
 			}
 
-			gokzgCtx, err = gokzg4844.NewContext4096(setup)
+			gokzgCtx, err = goethkzg.NewContext4096(setup)
 			if err != nil {
 				panic(fmt.Sprintf("could not create KZG context, err: %v", err))
 			}
@@ -78,7 +78,7 @@ func InitKZGCtx() {
 			var err error
 			// Initialize context to match the configurations that the
 			// specs are using.
-			gokzgCtx, err = gokzg4844.NewContext4096Secure()
+			gokzgCtx, err = goethkzg.NewContext4096Secure()
 			if err != nil {
 				panic(fmt.Sprintf("could not create context, err : %v", err))
 			}
@@ -89,13 +89,13 @@ func InitKZGCtx() {
 // Ctx returns a context object that stores all of the necessary configurations to allow one to
 // create and verify blob proofs.  This function is expensive to run if the crypto context isn't
 // initialized, so production services should pre-initialize by calling InitKZGCtx.
-func Ctx() *gokzg4844.Context {
+func Ctx() *goethkzg.Context {
 	InitKZGCtx()
 	return gokzgCtx
 }
 
 // KZGToVersionedHash implements kzg_to_versioned_hash from EIP-4844
-func KZGToVersionedHash(kzg gokzg4844.KZGCommitment) VersionedHash {
+func KZGToVersionedHash(kzg goethkzg.KZGCommitment) VersionedHash {
 	h := sha256.Sum256(kzg[:])
 	h[0] = BlobCommitmentVersionKZG
 
@@ -224,6 +224,77 @@ func (s *EthBackendClientDirect) AddPeer(ctx context.Context, in *remote.AddPeer
 	return s.server.AddPeer(ctx, in)
 }
 
+func (s *EthBackendClientDirect) RemovePeer(ctx context.Context, in *remote.RemovePeerRequest, opts ...grpc.CallOption) (*remote.RemovePeerReply, error) {
+	return s.server.RemovePeer(ctx, in)
+}
+
+func (s *EthBackendClientDirect) AddTrustedPeer(ctx context.Context, in *remote.AddPeerRequest, opts ...grpc.CallOption) (*remote.AddPeerReply, error) {
+	return s.server.AddTrustedPeer(ctx, in)
+}
+
+func (s *EthBackendClientDirect) RemoveTrustedPeer(ctx context.Context, in *remote.RemovePeerRequest, opts ...grpc.CallOption) (*remote.RemovePeerReply, error) {
+	return s.server.RemoveTrustedPeer(ctx, in)
+}
+
+func (s *EthBackendClientDirect) SetMaxPeers(ctx context.Context, in *remote.SetMaxPeersRequest, opts ...grpc.CallOption) (*remote.SetMaxPeersReply, error) {
+	return s.server.SetMaxPeers(ctx, in)
+}
+
+func (s *EthBackendClientDirect) PeerScores(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*remote.PeerScoresReply, error) {
+	return s.server.PeerScores(ctx, in)
+}
+
+// -- start PeerEvents
+
+func (s *EthBackendClientDirect) PeerEvents(ctx context.Context, in *remote.PeerEventsRequest, opts ...grpc.CallOption) (remote.ETHBACKEND_PeerEventsClient, error) {
+	ch := make(chan *peerEventReply, 16384)
+	streamServer := &PeerEventsStreamS{ch: ch, ctx: ctx}
+	go func() {
+		defer close(ch)
+		streamServer.Err(s.server.PeerEvents(in, streamServer))
+	}()
+	return &PeerEventsStreamC{ch: ch, ctx: ctx}, nil
+}
+
+type peerEventReply struct {
+	r   *remote.PeerEvent
+	err error
+}
+type PeerEventsStreamS struct {
+	ch  chan *peerEventReply
+	ctx context.Context
+	grpc.ServerStream
+}
+
+func (s *PeerEventsStreamS) Send(m *remote.PeerEvent) error {
+	s.ch <- &peerEventReply{r: m}
+	return nil
+}
+func (s *PeerEventsStreamS) Context() context.Context { return s.ctx }
+func (s *PeerEventsStreamS) Err(err error) {
+	if err == nil {
+		return
+	}
+	s.ch <- &peerEventReply{err: err}
+}
+
+type PeerEventsStreamC struct {
+	ch  chan *peerEventReply
+	ctx context.Context
+	grpc.ClientStream
+}
+
+func (c *PeerEventsStreamC) Recv() (*remote.PeerEvent, error) {
+	m, ok := <-c.ch
+	if !ok || m == nil {
+		return nil, io.EOF
+	}
+	return m.r, m.err
+}
+func (c *PeerEventsStreamC) Context() context.Context { return c.ctx }
+
+// -- end PeerEvents
+
 func (s *EthBackendClientDirect) PendingBlock(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*remote.PendingBlockReply, error) {
 	return s.server.PendingBlock(ctx, in)
 }
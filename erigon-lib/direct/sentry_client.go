@@ -36,6 +36,7 @@ const (
 	ETH66 = 66
 	ETH67 = 67
 	ETH68 = 68
+	ETH69 = 69
 )
 
 //go:generate mockgen -typed=true -destination=./sentry_client_mock.go -package=direct . SentryClient
@@ -258,6 +259,26 @@ func (c *SentryClientDirect) AddPeer(ctx context.Context, in *sentryproto.AddPee
 	return c.server.AddPeer(ctx, in)
 }
 
+func (c *SentryClientDirect) RemovePeer(ctx context.Context, in *sentryproto.RemovePeerRequest, opts ...grpc.CallOption) (*sentryproto.RemovePeerReply, error) {
+	return c.server.RemovePeer(ctx, in)
+}
+
+func (c *SentryClientDirect) AddTrustedPeer(ctx context.Context, in *sentryproto.AddPeerRequest, opts ...grpc.CallOption) (*sentryproto.AddPeerReply, error) {
+	return c.server.AddTrustedPeer(ctx, in)
+}
+
+func (c *SentryClientDirect) RemoveTrustedPeer(ctx context.Context, in *sentryproto.RemovePeerRequest, opts ...grpc.CallOption) (*sentryproto.RemovePeerReply, error) {
+	return c.server.RemoveTrustedPeer(ctx, in)
+}
+
+func (c *SentryClientDirect) SetMaxPeers(ctx context.Context, in *sentryproto.SetMaxPeersRequest, opts ...grpc.CallOption) (*sentryproto.SetMaxPeersReply, error) {
+	return c.server.SetMaxPeers(ctx, in)
+}
+
+func (c *SentryClientDirect) PeerScores(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*sentryproto.PeerScoresReply, error) {
+	return c.server.PeerScores(ctx, in)
+}
+
 type peersReply struct {
 	r   *sentryproto.PeerEvent
 	err error
@@ -80,6 +80,45 @@ func (c *MockSentryServerAddPeerCall) DoAndReturn(f func(context.Context, *AddPe
 	return c
 }
 
+// AddTrustedPeer mocks base method.
+func (m *MockSentryServer) AddTrustedPeer(arg0 context.Context, arg1 *AddPeerRequest) (*AddPeerReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTrustedPeer", arg0, arg1)
+	ret0, _ := ret[0].(*AddPeerReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTrustedPeer indicates an expected call of AddTrustedPeer.
+func (mr *MockSentryServerMockRecorder) AddTrustedPeer(arg0, arg1 any) *MockSentryServerAddTrustedPeerCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTrustedPeer", reflect.TypeOf((*MockSentryServer)(nil).AddTrustedPeer), arg0, arg1)
+	return &MockSentryServerAddTrustedPeerCall{Call: call}
+}
+
+// MockSentryServerAddTrustedPeerCall wrap *gomock.Call
+type MockSentryServerAddTrustedPeerCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSentryServerAddTrustedPeerCall) Return(arg0 *AddPeerReply, arg1 error) *MockSentryServerAddTrustedPeerCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSentryServerAddTrustedPeerCall) Do(f func(context.Context, *AddPeerRequest) (*AddPeerReply, error)) *MockSentryServerAddTrustedPeerCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSentryServerAddTrustedPeerCall) DoAndReturn(f func(context.Context, *AddPeerRequest) (*AddPeerReply, error)) *MockSentryServerAddTrustedPeerCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // HandShake mocks base method.
 func (m *MockSentryServer) HandShake(arg0 context.Context, arg1 *emptypb.Empty) (*HandShakeReply, error) {
 	m.ctrl.T.Helper()
@@ -351,6 +390,45 @@ func (c *MockSentryServerPeerMinBlockCall) DoAndReturn(f func(context.Context, *
 	return c
 }
 
+// PeerScores mocks base method.
+func (m *MockSentryServer) PeerScores(arg0 context.Context, arg1 *emptypb.Empty) (*PeerScoresReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PeerScores", arg0, arg1)
+	ret0, _ := ret[0].(*PeerScoresReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PeerScores indicates an expected call of PeerScores.
+func (mr *MockSentryServerMockRecorder) PeerScores(arg0, arg1 any) *MockSentryServerPeerScoresCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PeerScores", reflect.TypeOf((*MockSentryServer)(nil).PeerScores), arg0, arg1)
+	return &MockSentryServerPeerScoresCall{Call: call}
+}
+
+// MockSentryServerPeerScoresCall wrap *gomock.Call
+type MockSentryServerPeerScoresCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSentryServerPeerScoresCall) Return(arg0 *PeerScoresReply, arg1 error) *MockSentryServerPeerScoresCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSentryServerPeerScoresCall) Do(f func(context.Context, *emptypb.Empty) (*PeerScoresReply, error)) *MockSentryServerPeerScoresCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSentryServerPeerScoresCall) DoAndReturn(f func(context.Context, *emptypb.Empty) (*PeerScoresReply, error)) *MockSentryServerPeerScoresCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // Peers mocks base method.
 func (m *MockSentryServer) Peers(arg0 context.Context, arg1 *emptypb.Empty) (*PeersReply, error) {
 	m.ctrl.T.Helper()
@@ -429,6 +507,84 @@ func (c *MockSentryServerPenalizePeerCall) DoAndReturn(f func(context.Context, *
 	return c
 }
 
+// RemovePeer mocks base method.
+func (m *MockSentryServer) RemovePeer(arg0 context.Context, arg1 *RemovePeerRequest) (*RemovePeerReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemovePeer", arg0, arg1)
+	ret0, _ := ret[0].(*RemovePeerReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemovePeer indicates an expected call of RemovePeer.
+func (mr *MockSentryServerMockRecorder) RemovePeer(arg0, arg1 any) *MockSentryServerRemovePeerCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemovePeer", reflect.TypeOf((*MockSentryServer)(nil).RemovePeer), arg0, arg1)
+	return &MockSentryServerRemovePeerCall{Call: call}
+}
+
+// MockSentryServerRemovePeerCall wrap *gomock.Call
+type MockSentryServerRemovePeerCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSentryServerRemovePeerCall) Return(arg0 *RemovePeerReply, arg1 error) *MockSentryServerRemovePeerCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSentryServerRemovePeerCall) Do(f func(context.Context, *RemovePeerRequest) (*RemovePeerReply, error)) *MockSentryServerRemovePeerCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSentryServerRemovePeerCall) DoAndReturn(f func(context.Context, *RemovePeerRequest) (*RemovePeerReply, error)) *MockSentryServerRemovePeerCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
+// RemoveTrustedPeer mocks base method.
+func (m *MockSentryServer) RemoveTrustedPeer(arg0 context.Context, arg1 *RemovePeerRequest) (*RemovePeerReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTrustedPeer", arg0, arg1)
+	ret0, _ := ret[0].(*RemovePeerReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveTrustedPeer indicates an expected call of RemoveTrustedPeer.
+func (mr *MockSentryServerMockRecorder) RemoveTrustedPeer(arg0, arg1 any) *MockSentryServerRemoveTrustedPeerCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTrustedPeer", reflect.TypeOf((*MockSentryServer)(nil).RemoveTrustedPeer), arg0, arg1)
+	return &MockSentryServerRemoveTrustedPeerCall{Call: call}
+}
+
+// MockSentryServerRemoveTrustedPeerCall wrap *gomock.Call
+type MockSentryServerRemoveTrustedPeerCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSentryServerRemoveTrustedPeerCall) Return(arg0 *RemovePeerReply, arg1 error) *MockSentryServerRemoveTrustedPeerCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSentryServerRemoveTrustedPeerCall) Do(f func(context.Context, *RemovePeerRequest) (*RemovePeerReply, error)) *MockSentryServerRemoveTrustedPeerCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSentryServerRemoveTrustedPeerCall) DoAndReturn(f func(context.Context, *RemovePeerRequest) (*RemovePeerReply, error)) *MockSentryServerRemoveTrustedPeerCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // SendMessageById mocks base method.
 func (m *MockSentryServer) SendMessageById(arg0 context.Context, arg1 *SendMessageByIdRequest) (*SentPeers, error) {
 	m.ctrl.T.Helper()
@@ -585,6 +741,45 @@ func (c *MockSentryServerSendMessageToRandomPeersCall) DoAndReturn(f func(contex
 	return c
 }
 
+// SetMaxPeers mocks base method.
+func (m *MockSentryServer) SetMaxPeers(arg0 context.Context, arg1 *SetMaxPeersRequest) (*SetMaxPeersReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMaxPeers", arg0, arg1)
+	ret0, _ := ret[0].(*SetMaxPeersReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetMaxPeers indicates an expected call of SetMaxPeers.
+func (mr *MockSentryServerMockRecorder) SetMaxPeers(arg0, arg1 any) *MockSentryServerSetMaxPeersCall {
+	mr.mock.ctrl.T.Helper()
+	call := mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMaxPeers", reflect.TypeOf((*MockSentryServer)(nil).SetMaxPeers), arg0, arg1)
+	return &MockSentryServerSetMaxPeersCall{Call: call}
+}
+
+// MockSentryServerSetMaxPeersCall wrap *gomock.Call
+type MockSentryServerSetMaxPeersCall struct {
+	*gomock.Call
+}
+
+// Return rewrite *gomock.Call.Return
+func (c *MockSentryServerSetMaxPeersCall) Return(arg0 *SetMaxPeersReply, arg1 error) *MockSentryServerSetMaxPeersCall {
+	c.Call = c.Call.Return(arg0, arg1)
+	return c
+}
+
+// Do rewrite *gomock.Call.Do
+func (c *MockSentryServerSetMaxPeersCall) Do(f func(context.Context, *SetMaxPeersRequest) (*SetMaxPeersReply, error)) *MockSentryServerSetMaxPeersCall {
+	c.Call = c.Call.Do(f)
+	return c
+}
+
+// DoAndReturn rewrite *gomock.Call.DoAndReturn
+func (c *MockSentryServerSetMaxPeersCall) DoAndReturn(f func(context.Context, *SetMaxPeersRequest) (*SetMaxPeersReply, error)) *MockSentryServerSetMaxPeersCall {
+	c.Call = c.Call.DoAndReturn(f)
+	return c
+}
+
 // SetStatus mocks base method.
 func (m *MockSentryServer) SetStatus(arg0 context.Context, arg1 *StatusData) (*SetStatusReply, error) {
 	m.ctrl.T.Helper()
@@ -54,15 +54,20 @@ func OpenPair(from, to string, label kv.Label, targetPageSize datasize.ByteSize,
 	if err != nil {
 		panic(err)
 	}
-	dst := mdbx2.NewMDBX(logger).Path(to).
+	dst := OpenTarget(to, label, targetPageSize, datasize.ByteSize(info.Geo.Upper), logger)
+	return src, dst
+}
+
+// OpenTarget - open (or create) db which will receive backup
+func OpenTarget(to string, label kv.Label, pageSize, mapSize datasize.ByteSize, logger log.Logger) kv.RwDB {
+	return mdbx2.NewMDBX(logger).Path(to).
 		Label(label).
-		PageSize(targetPageSize.Bytes()).
-		MapSize(datasize.ByteSize(info.Geo.Upper)).
+		PageSize(pageSize.Bytes()).
+		MapSize(mapSize).
 		GrowthStep(4 * datasize.GB).
 		Flags(func(flags uint) uint { return flags | mdbx.WriteMap }).
 		WithTableCfg(func(_ kv.TableCfg) kv.TableCfg { return kv.TablesCfgByLabel(label) }).
 		MustOpen()
-	return src, dst
 }
 
 func Kv2kv(ctx context.Context, src kv.RoDB, dst kv.RwDB, tables []string, readAheadThreads int, logger log.Logger) error {
@@ -71,7 +76,12 @@ func Kv2kv(ctx context.Context, src kv.RoDB, dst kv.RwDB, tables []string, readA
 		return err1
 	}
 	defer srcTx.Rollback()
+	return Tx2kv(ctx, src, srcTx, dst, tables, readAheadThreads, logger)
+}
 
+// Tx2kv - same as Kv2kv, but copy data visible by given `srcTx`. Allows caller to take other resources (files)
+// at same point-in-time as db.
+func Tx2kv(ctx context.Context, src kv.RoDB, srcTx kv.Tx, dst kv.RwDB, tables []string, readAheadThreads int, logger log.Logger) error {
 	commitEvery := time.NewTicker(5 * time.Minute)
 	defer commitEvery.Stop()
 	logEvery := time.NewTicker(20 * time.Second)
@@ -98,6 +108,58 @@ func Kv2kv(ctx context.Context, src kv.RoDB, dst kv.RwDB, tables []string, readA
 	return nil
 }
 
+// CopyTablesTx - replace content of `tables` in `dstTx` by content visible in `srcTx`. Unlike Tx2kv - all tables
+// are replaced inside 1 transaction: readers of dst see either old or new content. Use it only for small dbs.
+func CopyTablesTx(ctx context.Context, srcTx kv.Tx, dstTx kv.RwTx, tables []string) error {
+	for _, table := range tables {
+		if err := copyTableTx(ctx, srcTx, dstTx, table); err != nil {
+			return fmt.Errorf("copy %s: %w", table, err)
+		}
+	}
+	return nil
+}
+
+func copyTableTx(ctx context.Context, srcTx kv.Tx, dstTx kv.RwTx, table string) error {
+	if err := dstTx.ClearBucket(table); err != nil {
+		return err
+	}
+	srcC, err := srcTx.Cursor(table)
+	if err != nil {
+		return err
+	}
+	defer srcC.Close()
+	c, err := dstTx.RwCursor(table)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	casted, isDupsort := c.(kv.RwCursorDupSort)
+
+	i := 0
+	for k, v, err := srcC.First(); k != nil; k, v, err = srcC.Next() {
+		if err != nil {
+			return err
+		}
+		if isDupsort {
+			err = casted.AppendDup(k, v)
+		} else {
+			err = c.Append(k, v)
+		}
+		if err != nil {
+			return err
+		}
+		i++
+		if i%100_000 == 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+		}
+	}
+	return nil
+}
+
 func backupTable(ctx context.Context, src kv.RoDB, srcTx kv.Tx, dst kv.RwDB, table string, readAheadThreads int, logEvery *time.Ticker, logger log.Logger) error {
 	var total uint64
 	wg := sync.WaitGroup{}
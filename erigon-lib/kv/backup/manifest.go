@@ -0,0 +1,316 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package backup
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common/dir"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+// Point-in-time backup of datadir is: copy of db + set of immutable files (snapshots) which were visible at the moment of
+// db's read-transaction open. Manifest describes such backup - and used to verify it.
+// Manifest is written last: backup without manifest is incomplete.
+
+const (
+	ManifestFileName = "backup-manifest.json"
+	ManifestVersion  = 1
+)
+
+type ManifestFile struct {
+	Path   string `json:"path"` // relative to datadir, slash-separated
+	Size   int64  `json:"size"`
+	Sha256 string `json:"sha256,omitempty"`
+}
+
+type Manifest struct {
+	Version   int               `json:"version"`
+	CreatedAt time.Time         `json:"createdAt"`
+	Progress  map[string]uint64 `json:"progress"` // stages progress of db at backup moment
+	Tables    map[string]uint64 `json:"tables"`   // table name -> amount of records
+	Files     []ManifestFile    `json:"files"`
+}
+
+func ReadManifest(dataDir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dataDir, ManifestFileName))
+	if err != nil {
+		return nil, err
+	}
+	m := &Manifest{}
+	if err = json.Unmarshal(data, m); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", ManifestFileName, err)
+	}
+	if m.Version != ManifestVersion {
+		return nil, fmt.Errorf("unsupported manifest version: %d, expected: %d", m.Version, ManifestVersion)
+	}
+	return m, nil
+}
+
+// ReadManifestIfExists - returns nil if there is no manifest in `dataDir`
+func ReadManifestIfExists(dataDir string) (*Manifest, error) {
+	exists, err := dir.FileExist(filepath.Join(dataDir, ManifestFileName))
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+	return ReadManifest(dataDir)
+}
+
+// Write - atomic write of manifest into `dataDir`
+func (m *Manifest) Write(dataDir string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	fPath := filepath.Join(dataDir, ManifestFileName)
+	tmpPath := fPath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, fPath)
+}
+
+func RemoveManifest(dataDir string) error {
+	if err := os.Remove(filepath.Join(dataDir, ManifestFileName)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (m *Manifest) file(path string) (ManifestFile, bool) {
+	if m == nil {
+		return ManifestFile{}, false
+	}
+	for _, f := range m.Files {
+		if f.Path == path {
+			return f, true
+		}
+	}
+	return ManifestFile{}, false
+}
+
+// FilePaths - list of files, relative to datadir
+func (m *Manifest) FilePaths() []string {
+	res := make([]string, 0, len(m.Files))
+	for _, f := range m.Files {
+		res = append(res, f.Path)
+	}
+	return res
+}
+
+// TablesCount - amount of records in each not-deprecated table visible by `tx`
+func TablesCount(db kv.RoDB, tx kv.Tx) (map[string]uint64, error) {
+	res := map[string]uint64{}
+	for name, cfg := range db.AllTables() {
+		if cfg.IsDeprecated {
+			continue
+		}
+		cnt, err := tx.Count(name)
+		if err != nil {
+			return nil, fmt.Errorf("count %s: %w", name, err)
+		}
+		res[name] = cnt
+	}
+	return res, nil
+}
+
+// VerifyTables - check that `db` has same amount of records in tables as manifest
+func (m *Manifest) VerifyTables(ctx context.Context, db kv.RoDB) error {
+	return db.View(ctx, func(tx kv.Tx) error {
+		for name, expected := range m.Tables {
+			cnt, err := tx.Count(name)
+			if err != nil {
+				return fmt.Errorf("count %s: %w", name, err)
+			}
+			if cnt != expected {
+				return fmt.Errorf("table %s: has %d records, manifest expects %d", name, cnt, expected)
+			}
+		}
+		return nil
+	})
+}
+
+// VerifyFiles - check that all files of manifest exist in `dataDir` and have expected size (and checksum - if manifest has it)
+func (m *Manifest) VerifyFiles(ctx context.Context, dataDir string) error {
+	for _, f := range m.Files {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		fPath := filepath.Join(dataDir, filepath.FromSlash(f.Path))
+		st, err := os.Stat(fPath)
+		if err != nil {
+			return err
+		}
+		if st.Size() != f.Size {
+			return fmt.Errorf("file %s: size %d, manifest expects %d", f.Path, st.Size(), f.Size)
+		}
+		if f.Sha256 == "" {
+			continue
+		}
+		sum, err := fileSha256(fPath)
+		if err != nil {
+			return err
+		}
+		if sum != f.Sha256 {
+			return fmt.Errorf("file %s: sha256 %s, manifest expects %s", f.Path, sum, f.Sha256)
+		}
+	}
+	return nil
+}
+
+// CopyFiles - hardlink (or copy - if hardlink is not possible) `files` (relative to datadir) from `fromDataDir` to `toDataDir`.
+// Files are immutable: if file with same path and size is already listed in `prev` manifest and exists in `toDataDir` - it's
+// not copied again (incremental backup).
+func CopyFiles(ctx context.Context, fromDataDir, toDataDir string, files []string, prev *Manifest, withChecksum bool, logger log.Logger) ([]ManifestFile, error) {
+	logEvery := time.NewTicker(20 * time.Second)
+	defer logEvery.Stop()
+
+	res := make([]ManifestFile, 0, len(files))
+	var skipped, copied int
+	for i, relPath := range files {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-logEvery.C:
+			logger.Info("[backup] files", "progress", fmt.Sprintf("%d/%d", i, len(files)), "copied", copied, "skipped", skipped)
+		default:
+		}
+
+		from := filepath.Join(fromDataDir, filepath.FromSlash(relPath))
+		to := filepath.Join(toDataDir, filepath.FromSlash(relPath))
+		st, err := os.Stat(from)
+		if err != nil {
+			return nil, err
+		}
+		f := ManifestFile{Path: filepath.ToSlash(relPath), Size: st.Size()}
+
+		if prevF, ok := prev.file(f.Path); ok && prevF.Size == f.Size && (!withChecksum || prevF.Sha256 != "") {
+			if toSt, err := os.Stat(to); err == nil && toSt.Size() == f.Size {
+				f.Sha256 = prevF.Sha256
+				res = append(res, f)
+				skipped++
+				continue
+			}
+		}
+
+		if err := linkOrCopy(from, to); err != nil {
+			return nil, fmt.Errorf("copy %s: %w", relPath, err)
+		}
+		if withChecksum {
+			if f.Sha256, err = fileSha256(to); err != nil {
+				return nil, err
+			}
+		}
+		res = append(res, f)
+		copied++
+	}
+	logger.Info("[backup] files done", "copied", copied, "skipped", skipped)
+	return res, nil
+}
+
+// RemoveStaleFiles - remove from `dataDir` files which were listed in `prev` manifest, but not listed in `cur`.
+// For example: files which were merged into bigger file after previous backup.
+func RemoveStaleFiles(dataDir string, prev, cur *Manifest) error {
+	if prev == nil {
+		return nil
+	}
+	for _, f := range prev.Files {
+		if _, ok := cur.file(f.Path); ok {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dataDir, filepath.FromSlash(f.Path))); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// SortFiles - deterministic order of files in manifest
+func (m *Manifest) SortFiles() {
+	sort.Slice(m.Files, func(i, j int) bool { return m.Files[i].Path < m.Files[j].Path })
+}
+
+func linkOrCopy(from, to string) error {
+	if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+		return err
+	}
+	if err := os.Remove(to); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if err := os.Link(from, to); err == nil {
+		return nil
+	}
+	return CopyFile(from, to)
+}
+
+// CopyFile - copy content of file `from` to `to`. `to` appears only after full content is written and synced.
+func CopyFile(from, to string) error {
+	src, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+		return err
+	}
+	tmpPath := to + ".tmp"
+	dst, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+	if _, err = io.Copy(dst, src); err != nil {
+		return err
+	}
+	if err = dst.Sync(); err != nil {
+		return err
+	}
+	if err = dst.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, to)
+}
+
+func fileSha256(fPath string) (string, error) {
+	f, err := os.Open(fPath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,116 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package backup
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/memdb"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+func TestManifestFiles(t *testing.T) {
+	ctx, logger := context.Background(), log.New()
+	from, to := t.TempDir(), t.TempDir()
+	writeFile := func(relPath, content string) {
+		fPath := filepath.Join(from, relPath)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fPath), 0755))
+		require.NoError(t, os.WriteFile(fPath, []byte(content), 0644))
+	}
+	writeFile("snapshots/domain/v1-accounts.0-1.kv", "a01")
+	writeFile("snapshots/domain/v1-accounts.1-2.kv", "a12")
+	writeFile("snapshots/v1-000000-000500-headers.seg", "headers")
+
+	files := []string{"snapshots/domain/v1-accounts.0-1.kv", "snapshots/domain/v1-accounts.1-2.kv", "snapshots/v1-000000-000500-headers.seg"}
+	m := &Manifest{Version: ManifestVersion, CreatedAt: time.Now().UTC()}
+	var err error
+	m.Files, err = CopyFiles(ctx, from, to, files, nil, true, logger)
+	require.NoError(t, err)
+	require.Len(t, m.Files, 3)
+	m.SortFiles()
+	require.NoError(t, m.VerifyFiles(ctx, to))
+	require.NoError(t, m.Write(to))
+
+	t.Run("read", func(t *testing.T) {
+		m2, err := ReadManifest(to)
+		require.NoError(t, err)
+		require.Equal(t, m.Files, m2.Files)
+
+		m2, err = ReadManifestIfExists(from)
+		require.NoError(t, err)
+		require.Nil(t, m2)
+	})
+
+	t.Run("incremental", func(t *testing.T) {
+		// files 0-1 and 1-2 merged into 0-2
+		writeFile("snapshots/domain/v1-accounts.0-2.kv", "a02")
+		files := []string{"snapshots/domain/v1-accounts.0-2.kv", "snapshots/v1-000000-000500-headers.seg"}
+
+		// must not be touched by incremental backup
+		unchanged := filepath.Join(to, "snapshots/v1-000000-000500-headers.seg")
+		require.NoError(t, os.Chtimes(unchanged, time.Unix(1, 0), time.Unix(1, 0)))
+
+		prev := m
+		cur := &Manifest{Version: ManifestVersion}
+		cur.Files, err = CopyFiles(ctx, from, to, files, prev, true, logger)
+		require.NoError(t, err)
+		require.NoError(t, RemoveStaleFiles(to, prev, cur))
+		require.NoError(t, cur.VerifyFiles(ctx, to))
+
+		st, err := os.Stat(unchanged)
+		require.NoError(t, err)
+		require.Equal(t, time.Unix(1, 0), st.ModTime())
+		_, err = os.Stat(filepath.Join(to, "snapshots/domain/v1-accounts.0-1.kv"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("corrupted", func(t *testing.T) {
+		m := &Manifest{Version: ManifestVersion, Files: []ManifestFile{{Path: "snapshots/v1-000000-000500-headers.seg", Size: 7, Sha256: "00"}}}
+		require.ErrorContains(t, m.VerifyFiles(ctx, to), "sha256")
+
+		m.Files[0].Sha256, m.Files[0].Size = "", 8
+		require.ErrorContains(t, m.VerifyFiles(ctx, to), "size")
+	})
+}
+
+func TestManifestTables(t *testing.T) {
+	ctx := context.Background()
+	db := memdb.NewTestDB(t)
+	require.NoError(t, db.Update(ctx, func(tx kv.RwTx) error {
+		return tx.Put(kv.Headers, []byte{1}, []byte{1})
+	}))
+
+	m := &Manifest{Version: ManifestVersion}
+	require.NoError(t, db.View(ctx, func(tx kv.Tx) (err error) {
+		m.Tables, err = TablesCount(db, tx)
+		return err
+	}))
+	require.Equal(t, uint64(1), m.Tables[kv.Headers])
+	require.NoError(t, m.VerifyTables(ctx, db))
+
+	require.NoError(t, db.Update(ctx, func(tx kv.RwTx) error {
+		return tx.Put(kv.Headers, []byte{2}, []byte{2})
+	}))
+	require.ErrorContains(t, m.VerifyTables(ctx, db), kv.Headers)
+}
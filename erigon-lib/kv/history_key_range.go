@@ -0,0 +1,196 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package kv
+
+import (
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv/order"
+	"github.com/erigontech/erigon-lib/kv/stream"
+)
+
+func domainHistory(name Domain) (History, InvertedIdx, error) {
+	switch name {
+	case AccountsDomain:
+		return AccountsHistory, AccountsHistoryIdx, nil
+	case StorageDomain:
+		return StorageHistory, StorageHistoryIdx, nil
+	case CodeDomain:
+		return CodeHistory, CodeHistoryIdx, nil
+	case CommitmentDomain:
+		return CommitmentHistory, CommitmentHistoryIdx, nil
+	case ReceiptDomain:
+		return ReceiptHistory, ReceiptHistoryIdx, nil
+	default:
+		return "", "", fmt.Errorf("unexpected domain: %s", name)
+	}
+}
+
+// HistoryKeyRange - implementation of `TemporalTx.HistoryKeyRange` on top of `IndexRange`+`HistorySeek`+`DomainGet`.
+// Can be used by any TemporalTx: it doesn't rely on internals of storage.
+//
+// History stores "value before change" - so: prev(ts) = HistorySeek(ts), and "value after change" is prev of next change
+// (or latest value if there are no next changes). It means 1 HistorySeek per change is enough.
+func HistoryKeyRange(tx TemporalTx, name Domain, k []byte, fromTs, toTs int, asc order.By, limit int) (stream.U64VV, error) {
+	h, idx, err := domainHistory(name)
+	if err != nil {
+		return nil, err
+	}
+	it := &HistoryKeyRangeIter{tx: tx, domain: name, h: h, idx: idx, k: k, toTs: toTs, orderAscend: asc, limit: limit}
+	if asc {
+		// unbounded: last change in range needs next change (which may be out of range) to know it's "value after"
+		it.txNums, err = tx.IndexRange(idx, k, fromTs, -1, asc, Unlim)
+	} else {
+		it.txNums, err = tx.IndexRange(idx, k, fromTs, toTs, asc, limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = it.init(); err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
+// HistoryKeyRangeIter - see `HistoryKeyRange`
+type HistoryKeyRangeIter struct {
+	tx          TemporalTx
+	domain      Domain
+	h           History
+	idx         InvertedIdx
+	k           []byte
+	toTs        int
+	orderAscend order.By
+	limit       int
+
+	txNums stream.U64
+
+	hasNext bool
+	nextTs  uint64
+	nextV   []byte // Asc: value before `nextTs`. Desc: value after `nextTs`
+}
+
+func (it *HistoryKeyRangeIter) init() error {
+	if !it.txNums.HasNext() || it.limit == 0 {
+		return nil
+	}
+	ts, err := it.txNums.Next()
+	if err != nil {
+		return err
+	}
+	if it.orderAscend {
+		if it.toTs >= 0 && ts >= uint64(it.toTs) {
+			return nil
+		}
+		it.nextTs, it.hasNext = ts, true
+		it.nextV, err = it.seek(ts)
+		return err
+	}
+
+	// Desc: "value after" of first change - is "value before" of next (newer) change
+	it.nextTs, it.hasNext = ts, true
+	newer, err := it.tx.IndexRange(it.idx, it.k, int(ts)+1, -1, order.Asc, 1)
+	if err != nil {
+		return err
+	}
+	if !newer.HasNext() {
+		it.nextV, err = it.latest()
+		return err
+	}
+	newerTs, err := newer.Next()
+	if err != nil {
+		return err
+	}
+	it.nextV, err = it.seek(newerTs)
+	return err
+}
+
+func (it *HistoryKeyRangeIter) seek(ts uint64) ([]byte, error) {
+	v, ok, err := it.tx.HistorySeek(it.h, it.k, ts)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%s: history not found for key %x at ts=%d, but inverted index has it", it.h, it.k, ts)
+	}
+	return common.Copy(v), nil
+}
+
+func (it *HistoryKeyRangeIter) latest() ([]byte, error) {
+	v, _, err := it.tx.DomainGet(it.domain, it.k, nil)
+	if err != nil {
+		return nil, err
+	}
+	return common.Copy(v), nil
+}
+
+func (it *HistoryKeyRangeIter) HasNext() bool { return it.hasNext }
+
+func (it *HistoryKeyRangeIter) Next() (ts uint64, prev, v []byte, err error) {
+	if it.limit > 0 {
+		it.limit--
+	}
+	ts = it.nextTs
+	if it.orderAscend {
+		return it.nextAsc(ts)
+	}
+	return it.nextDesc(ts)
+}
+
+func (it *HistoryKeyRangeIter) nextAsc(ts uint64) (uint64, []byte, []byte, error) {
+	prev := it.nextV
+	if !it.txNums.HasNext() {
+		it.hasNext = false
+		v, err := it.latest()
+		return ts, prev, v, err
+	}
+	newerTs, err := it.txNums.Next()
+	if err != nil {
+		it.hasNext = false
+		return ts, nil, nil, err
+	}
+	v, err := it.seek(newerTs)
+	if err != nil {
+		it.hasNext = false
+		return ts, nil, nil, err
+	}
+	it.nextTs, it.nextV = newerTs, v
+	it.hasNext = it.limit != 0 && (it.toTs < 0 || newerTs < uint64(it.toTs))
+	return ts, prev, v, nil
+}
+
+func (it *HistoryKeyRangeIter) nextDesc(ts uint64) (uint64, []byte, []byte, error) {
+	v := it.nextV
+	prev, err := it.seek(ts)
+	if err != nil {
+		it.hasNext = false
+		return ts, nil, nil, err
+	}
+	it.hasNext = it.limit != 0 && it.txNums.HasNext()
+	if it.hasNext {
+		if it.nextTs, err = it.txNums.Next(); err != nil {
+			it.hasNext = false
+			return ts, nil, nil, err
+		}
+		it.nextV = prev
+	}
+	return ts, prev, v, nil
+}
+
+// Close - underlying streams are produced by `tx` and closed by `tx.Rollback()`
+func (it *HistoryKeyRangeIter) Close() {}
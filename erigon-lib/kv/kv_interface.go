@@ -584,6 +584,12 @@ type TemporalTx interface {
 	// HistoryRange - producing "state patch" - sorted list of keys updated at [fromTs,toTs) with their most-recent value.
 	//   no duplicates
 	HistoryRange(name History, fromTs, toTs int, asc order.By, limit int) (it stream.KV, err error)
+
+	// HistoryKeyRange - every change of key `k` in Domain at [fromTs,toTs): `ts` of change, value before and value after it.
+	//   empty value means: key didn't exist (before creation or after deletion)
+	// Same from/to/order/limit semantic as IndexRange
+	// Example: HistoryKeyRange(AccountsDomain, addr, 10, 20, order.Asc, -1)
+	HistoryKeyRange(name Domain, k []byte, fromTs, toTs int, asc order.By, limit int) (it stream.U64VV, err error)
 }
 
 type TxnId uint64 // internal auto-increment ID. can't cast to eth-network canonical blocks txNum
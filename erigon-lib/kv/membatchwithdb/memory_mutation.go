@@ -754,6 +754,11 @@ func (m *MemoryMutation) HistoryRange(name kv.History, fromTs, toTs int, asc ord
 	//return m.db.(kv.TemporalTx).HistoryRange(name, fromTs, toTs, asc, limit)
 }
 
+func (m *MemoryMutation) HistoryKeyRange(name kv.Domain, k []byte, fromTs, toTs int, asc order.By, limit int) (stream.U64VV, error) {
+	panic("not supported")
+	//return m.db.(kv.TemporalTx).HistoryKeyRange(name, k, fromTs, toTs, asc, limit)
+}
+
 func (m *MemoryMutation) DomainRange(name kv.Domain, fromKey, toKey []byte, ts uint64, asc order.By, limit int) (it stream.KV, err error) {
 	panic("not supported")
 	//return m.db.(kv.TemporalTx).DomainRange(name, fromKey, toKey, ts, asc, limit)
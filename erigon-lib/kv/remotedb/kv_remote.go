@@ -678,6 +678,10 @@ func (tx *tx) IndexRange(name kv.InvertedIdx, k []byte, fromTs, toTs int, asc or
 	}), nil
 }
 
+func (tx *tx) HistoryKeyRange(name kv.Domain, k []byte, fromTs, toTs int, asc order.By, limit int) (stream.U64VV, error) {
+	return kv.HistoryKeyRange(tx, name, k, fromTs, toTs, asc, limit)
+}
+
 func (tx *tx) Prefix(table string, prefix []byte) (stream.KV, error) {
 	nextPrefix, ok := kv.NextSubtree(prefix)
 	if !ok {
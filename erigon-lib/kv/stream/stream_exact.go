@@ -22,15 +22,17 @@ import (
 
 // often used shortcuts
 type (
-	U64 Uno[uint64]
-	KV  Duo[[]byte, []byte]          // key,  value
-	KVS Trio[[]byte, []byte, uint64] // key, value, step
+	U64   Uno[uint64]
+	KV    Duo[[]byte, []byte]          // key,  value
+	KVS   Trio[[]byte, []byte, uint64] // key, value, step
+	U64VV Trio[uint64, []byte, []byte] // ts, value before ts, value after ts
 )
 
 var (
-	EmptyU64 = &Empty[uint64]{}
-	EmptyKV  = &EmptyDuo[[]byte, []byte]{}
-	EmptyKVS = &EmptyTrio[[]byte, []byte, uint64]{}
+	EmptyU64   = &Empty[uint64]{}
+	EmptyKV    = &EmptyDuo[[]byte, []byte]{}
+	EmptyKVS   = &EmptyTrio[[]byte, []byte, uint64]{}
+	EmptyU64VV = &EmptyTrio[uint64, []byte, []byte]{}
 )
 
 func FilterU64(it U64, filter func(k uint64) bool) *Filtered[uint64] {
@@ -308,6 +308,8 @@ const (
 	NodeRecords = "NodeRecord"
 	// Inodes stores P2P discovery service info about the nodes
 	Inodes = "Inode"
+	// PeerReputations stores decaying P2P peer misbehaviour counters
+	PeerReputations = "PeerReputation"
 
 	// Transaction senders - stored separately from the block bodies
 	Senders = "TxSender" // block_num_u64 + blockHash -> sendersList (no serialization format, every 20 bytes is new sender)
@@ -409,6 +411,11 @@ const (
 	EffectiveBalancesDump = "EffectiveBalancesDump"
 	BalancesDump          = "BalancesDump"
 
+	// [slot] => [compressed ssz list], only written at the slots where the electra queue changes
+	PendingDeposits           = "PendingDeposits"
+	PendingPartialWithdrawals = "PendingPartialWithdrawals"
+	PendingConsolidations     = "PendingConsolidations"
+
 	// [slot] => [Canonical block root]
 	CanonicalBlockRoots = "CanonicalBlockRoots"
 	// [Root (block root] => Slot
@@ -632,6 +639,9 @@ var ChaindataTables = []string{
 	ActiveValidatorIndicies,
 	EffectiveBalancesDump,
 	BalancesDump,
+	PendingDeposits,
+	PendingPartialWithdrawals,
+	PendingConsolidations,
 }
 
 const (
@@ -242,3 +242,12 @@ func (tx *Tx) HistoryRange(name kv.History, fromTs, toTs int, asc order.By, limi
 	tx.resourcesToClose = append(tx.resourcesToClose, it)
 	return it, nil
 }
+
+func (tx *Tx) HistoryKeyRange(name kv.Domain, k []byte, fromTs, toTs int, asc order.By, limit int) (stream.U64VV, error) {
+	it, err := kv.HistoryKeyRange(tx, name, k, fromTs, toTs, asc, limit)
+	if err != nil {
+		return nil, err
+	}
+	tx.resourcesToClose = append(tx.resourcesToClose, it)
+	return it, nil
+}
@@ -712,6 +712,188 @@ func (m *sentryMultiplexer) AddPeer(ctx context.Context, in *sentryproto.AddPeer
 	return &sentryproto.AddPeerReply{Success: success}, nil
 }
 
+func (m *sentryMultiplexer) RemovePeer(ctx context.Context, in *sentryproto.RemovePeerRequest, opts ...grpc.CallOption) (*sentryproto.RemovePeerReply, error) {
+	g, gctx := errgroup.WithContext(ctx)
+
+	var success bool
+	var successMutex sync.RWMutex
+
+	for _, client := range m.clients {
+		client := client
+
+		g.Go(func() error {
+			result, err := client.RemovePeer(gctx, in, opts...)
+
+			if err != nil {
+				return err
+			}
+
+			successMutex.Lock()
+			defer successMutex.Unlock()
+
+			// if any client returns success return success
+			if !success && result.GetSuccess() {
+				success = true
+			}
+
+			return nil
+		})
+	}
+
+	err := g.Wait()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &sentryproto.RemovePeerReply{Success: success}, nil
+}
+
+func (m *sentryMultiplexer) AddTrustedPeer(ctx context.Context, in *sentryproto.AddPeerRequest, opts ...grpc.CallOption) (*sentryproto.AddPeerReply, error) {
+	g, gctx := errgroup.WithContext(ctx)
+
+	var success bool
+	var successMutex sync.RWMutex
+
+	for _, client := range m.clients {
+		client := client
+
+		g.Go(func() error {
+			result, err := client.AddTrustedPeer(gctx, in, opts...)
+
+			if err != nil {
+				return err
+			}
+
+			successMutex.Lock()
+			defer successMutex.Unlock()
+
+			// if any client returns success return success
+			if !success && result.GetSuccess() {
+				success = true
+			}
+
+			return nil
+		})
+	}
+
+	err := g.Wait()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &sentryproto.AddPeerReply{Success: success}, nil
+}
+
+func (m *sentryMultiplexer) RemoveTrustedPeer(ctx context.Context, in *sentryproto.RemovePeerRequest, opts ...grpc.CallOption) (*sentryproto.RemovePeerReply, error) {
+	g, gctx := errgroup.WithContext(ctx)
+
+	var success bool
+	var successMutex sync.RWMutex
+
+	for _, client := range m.clients {
+		client := client
+
+		g.Go(func() error {
+			result, err := client.RemoveTrustedPeer(gctx, in, opts...)
+
+			if err != nil {
+				return err
+			}
+
+			successMutex.Lock()
+			defer successMutex.Unlock()
+
+			// if any client returns success return success
+			if !success && result.GetSuccess() {
+				success = true
+			}
+
+			return nil
+		})
+	}
+
+	err := g.Wait()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &sentryproto.RemovePeerReply{Success: success}, nil
+}
+
+func (m *sentryMultiplexer) SetMaxPeers(ctx context.Context, in *sentryproto.SetMaxPeersRequest, opts ...grpc.CallOption) (*sentryproto.SetMaxPeersReply, error) {
+	g, gctx := errgroup.WithContext(ctx)
+
+	var success bool
+	var successMutex sync.RWMutex
+
+	for _, client := range m.clients {
+		client := client
+
+		g.Go(func() error {
+			result, err := client.SetMaxPeers(gctx, in, opts...)
+
+			if err != nil {
+				return err
+			}
+
+			successMutex.Lock()
+			defer successMutex.Unlock()
+
+			// if any client returns success return success
+			if !success && result.GetSuccess() {
+				success = true
+			}
+
+			return nil
+		})
+	}
+
+	err := g.Wait()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &sentryproto.SetMaxPeersReply{Success: success}, nil
+}
+
+func (m *sentryMultiplexer) PeerScores(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*sentryproto.PeerScoresReply, error) {
+	g, gctx := errgroup.WithContext(ctx)
+
+	var allScores []*typesproto.PeerScore
+	var allMutex sync.Mutex
+
+	for _, client := range m.clients {
+		client := client
+
+		g.Go(func() error {
+			reply, err := client.PeerScores(gctx, in, opts...)
+
+			if err != nil {
+				return err
+			}
+
+			allMutex.Lock()
+			defer allMutex.Unlock()
+
+			allScores = append(allScores, reply.GetScores()...)
+
+			return nil
+		})
+	}
+
+	err := g.Wait()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &sentryproto.PeerScoresReply{Scores: allScores}, nil
+}
+
 func (m *sentryMultiplexer) NodeInfo(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*typesproto.NodeInfoReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, `method "NodeInfo" not implemented: use "NodeInfos" instead`)
 }
@@ -92,7 +92,9 @@ type Aggregator struct {
 
 	ctxAutoIncrement atomic.Uint64
 
-	produce bool
+	produce       bool
+	ownsDatadir   bool // process holds datadir flock: nobody else can build or merge files in it
+	tmpFilesClean bool // partial files of previous run are removed. protected by `dirtyFilesLock`
 }
 
 type OnFreezeFunc func(frozenFileNames []string)
@@ -308,6 +310,14 @@ func (a *Aggregator) OpenFolder() error {
 func (a *Aggregator) openFolder() error {
 	a.dirtyFilesLock.Lock()
 	defer a.dirtyFilesLock.Unlock()
+	// .tmp files left by killed process. only owner of datadir, and only before it started building files, can be sure
+	// that nobody is writing them now: `integration`, `rpcdaemon`, etc. may run next to live Erigon
+	if a.ownsDatadir && !a.tmpFilesClean {
+		if err := removeTmpFiles(a.dirs.SnapDomain, a.dirs.SnapHistory, a.dirs.SnapIdx, a.dirs.SnapAccessors); err != nil {
+			return fmt.Errorf("openFolder: %w", err)
+		}
+		a.tmpFilesClean = true
+	}
 	eg := &errgroup.Group{}
 	for _, d := range a.d {
 		d := d
@@ -395,6 +405,27 @@ func (ac *AggregatorRoTx) Files() []string {
 	}
 	return res
 }
+
+// FilePaths - paths of all visible files (with accessors) of this RoTx. Files are immutable and can't be deleted while RoTx is open.
+func (ac *AggregatorRoTx) FilePaths() []string {
+	var res []string
+	if ac == nil {
+		return res
+	}
+	var visible []visibleFile
+	for _, d := range ac.d {
+		visible = append(visible, d.files...)
+		visible = append(visible, d.ht.files...)
+		visible = append(visible, d.ht.iit.files...)
+	}
+	for _, ii := range ac.iis {
+		visible = append(visible, ii.files...)
+	}
+	for _, item := range visible {
+		res = append(res, item.src.filePaths()...)
+	}
+	return res
+}
 func (a *Aggregator) Files() []string {
 	ac := a.BeginFilesRo()
 	defer ac.Close()
@@ -470,7 +501,21 @@ func (ac *AggregatorRoTx) buildOptionalMissedIndices(ctx context.Context, worker
 			g.Go(func() error { return d.BuildOptionalMissedIndices(ctx, ps) })
 		}
 	}
-	return g.Wait()
+	var builtExistence bool
+	for _, ii := range ac.iis {
+		ii := ii
+		if ii != nil && len(ii.missedExistenceFilters()) > 0 {
+			builtExistence = true
+			g.Go(func() error { return ii.BuildOptionalMissedIndices(ctx, ps) })
+		}
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+	if builtExistence {
+		return ac.a.OpenFolder()
+	}
+	return nil
 }
 
 func (a *Aggregator) BuildMissedIndices(ctx context.Context, workers int) error {
@@ -709,6 +754,10 @@ func (a *Aggregator) buildFiles(ctx context.Context, step uint64) error {
 		return fmt.Errorf("domain collate-build: %w", err)
 	}
 	mxStepTook.ObserveDuration(stepStartedAt)
+	if err := injectFault(faultIntegrateDirtyFiles, ""); err != nil {
+		static.CleanupOnError()
+		return err
+	}
 	a.integrateDirtyFiles(static, txFrom, txTo)
 	a.recalcVisibleFiles(a.DirtyFilesEndTxNumMinimax())
 	a.logger.Info("[snapshots] aggregated", "step", step, "took", time.Since(stepStartedAt))
@@ -813,6 +862,9 @@ func (a *Aggregator) mergeLoopStep(ctx context.Context, toTxNum uint64) (somethi
 			in.Close()
 		}
 	}()
+	if err := injectFault(faultIntegrateMerged, ""); err != nil {
+		return true, err
+	}
 	a.integrateMergedDirtyFiles(outs, in)
 	a.recalcVisibleFiles(a.DirtyFilesEndTxNumMinimax())
 	a.cleanAfterMerge(in)
@@ -1600,6 +1652,22 @@ func (a *Aggregator) SetProduceMod(produce bool) {
 	a.produce = produce
 }
 
+// SetExistenceFilters allows building existence filters (.efei) of inverted index files: by collate, merge and
+// BuildOptionalMissedIndices. Already existing filters are used anyway (default value is false)
+func (a *Aggregator) SetExistenceFilters(enabled bool) {
+	for _, ii := range a.iis {
+		if ii != nil {
+			ii.withExistence = enabled
+		}
+	}
+}
+
+// SetOwnsDatadir must be set only by process which holds datadir flock (see datadir.TryFlock). Then OpenFolder
+// removes partial .tmp files left by killed process (default value is false)
+func (a *Aggregator) SetOwnsDatadir(owns bool) {
+	a.ownsDatadir = owns
+}
+
 // Returns channel which is closed when aggregation is done
 func (a *Aggregator) BuildFilesInBackground(txNum uint64) chan struct{} {
 	fin := make(chan struct{})
@@ -0,0 +1,417 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/c2h5oh/datasize"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/dir"
+	"github.com/erigontech/erigon-lib/common/length"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/mdbx"
+	"github.com/erigontech/erigon-lib/kv/order"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
+	"github.com/erigontech/erigon-lib/kv/stream"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon-lib/types"
+)
+
+// Crash-consistency tests: files lifecycle (build, merge, integrate, prune+commit) is interrupted at fault point,
+// then Aggregator is restarted on same datadir and must see same state as before interruption.
+//
+// "kill" - fault point is reached in child process (this test binary re-executed), which exits immediately:
+// no defers, no cleanup, partial .tmp files are left on disk.
+// "abort" - fault point returns error in-process, error-handling paths are exercised.
+
+const (
+	crashAggStep   = 16
+	crashSteps     = 6 // last step stays in db
+	crashBlockSize = 8
+	crashExitCode  = 3
+	crashChildEnv  = "AGG_CRASH_TEST_CHILD" // "<datadir>|<scenario>"
+)
+
+var errInjectedFault = errors.New("injected fault")
+
+type crashScenario struct {
+	name  string
+	point faultPoint
+	file  string // fault only when building file with this name, empty - any
+	exec  bool   // fault after execution of new block, before db commit
+	prune bool   // fault after prune, before db commit
+}
+
+var crashScenarios = []crashScenario{
+	{name: "build_domain", point: faultBuildFiles, file: "v1-accounts.2-3.kv"},
+	{name: "build_history", point: faultBuildFiles, file: "v1-storage.2-3.v"},
+	{name: "build_history_idx", point: faultBuildFiles, file: "v1-storage.2-3.ef"},
+	{name: "build_ii", point: faultBuildFiles, file: "v1-logaddrs.2-3.ef"},
+	{name: "integrate", point: faultIntegrateDirtyFiles},
+	{name: "merge_domain", point: faultMergeFiles, file: "v1-commitment.0-2.kv"},
+	{name: "merge_history", point: faultMergeFiles, file: "v1-accounts.0-2.v"},
+	{name: "merge_ii", point: faultMergeFiles, file: "v1-logaddrs.0-4.ef"},
+	{name: "integrate_merged", point: faultIntegrateMerged},
+	{name: "commit_exec", exec: true},
+	{name: "commit_prune", prune: true},
+}
+
+type crashWrite struct {
+	txNum uint64
+	v     []byte
+}
+
+type crashExpected struct {
+	root      []byte
+	lastTxNum uint64
+	history   map[kv.Domain]map[string][]crashWrite // domain -> key -> writes in txNum order
+	logAddrs  map[string][]uint64
+}
+
+// asOf - value of key before txNum
+func (e *crashExpected) asOf(domain kv.Domain, key string, txNum uint64) (v []byte) {
+	for _, w := range e.history[domain][key] {
+		if w.txNum >= txNum {
+			break
+		}
+		v = w.v
+	}
+	return v
+}
+
+func openCrashDB(tb testing.TB, dirs datadir.Dirs, logger log.Logger) kv.RwDB {
+	tb.Helper()
+	return mdbx.NewMDBX(logger).Path(dirs.Chaindata).GrowthStep(32 * datasize.MB).MapSize(2 * datasize.GB).WithTableCfg(func(defaultBuckets kv.TableCfg) kv.TableCfg {
+		return kv.ChaindataTablesCfg
+	}).MustOpen()
+}
+
+func openCrashAgg(tb testing.TB, dirs datadir.Dirs, db kv.RoDB, logger log.Logger) *Aggregator {
+	tb.Helper()
+	agg, err := NewAggregator(context.Background(), dirs, crashAggStep, db, logger)
+	require.NoError(tb, err)
+	agg.SetOwnsDatadir(true) // test is the only user of datadir, as Erigon holding datadir flock
+	require.NoError(tb, agg.OpenFolder())
+	agg.DisableFsync()
+	return agg
+}
+
+func crashKeys() (addrs, locs [][]byte) {
+	for i := 0; i < 8; i++ {
+		addr := make([]byte, length.Addr)
+		addr[0], addr[length.Addr-1] = byte(i+1), byte(i)
+		addrs = append(addrs, addr)
+	}
+	for i := 0; i < 4; i++ {
+		loc := make([]byte, length.Hash)
+		loc[0] = byte(i + 1)
+		locs = append(locs, loc)
+	}
+	return addrs, locs
+}
+
+// writeCrashBlocks - small set of keys is updated on every tx: history of every key is non-trivial.
+// Commitment is computed at the end of every block.
+func writeCrashBlocks(t *testing.T, tx kv.RwTx, domains *SharedDomains, fromBlock, toBlock uint64, exp *crashExpected) {
+	t.Helper()
+	ctx := context.Background()
+	addrs, locs := crashKeys()
+	for blockNum := fromBlock; blockNum < toBlock; blockNum++ {
+		for txNum := blockNum * crashBlockSize; txNum < (blockNum+1)*crashBlockSize; txNum++ {
+			domains.SetTxNum(txNum)
+			domains.SetBlockNum(blockNum)
+
+			addr := addrs[txNum%uint64(len(addrs))]
+			acc := types.EncodeAccountBytesV3(txNum, uint256.NewInt(txNum*10), nil, 0)
+			require.NoError(t, domains.DomainPut(kv.AccountsDomain, addr, nil, acc, nil, 0))
+			exp.history[kv.AccountsDomain][string(addr)] = append(exp.history[kv.AccountsDomain][string(addr)], crashWrite{txNum, acc})
+
+			if txNum%2 == 0 {
+				loc := locs[txNum%uint64(len(locs))]
+				val := binary.BigEndian.AppendUint64(nil, txNum)
+				require.NoError(t, domains.DomainPut(kv.StorageDomain, addr, loc, val, nil, 0))
+				key := string(addr) + string(loc)
+				exp.history[kv.StorageDomain][key] = append(exp.history[kv.StorageDomain][key], crashWrite{txNum, val})
+			}
+
+			logAddr := addrs[txNum%5]
+			require.NoError(t, domains.IndexAdd(kv.LogAddrIdx, logAddr))
+			exp.logAddrs[string(logAddr)] = append(exp.logAddrs[string(logAddr)], txNum)
+		}
+		root, err := domains.ComputeCommitment(ctx, true, blockNum, "")
+		require.NoError(t, err)
+		require.NoError(t, rawdbv3.TxNums.Append(tx, blockNum, domains.TxNum()))
+		exp.root, exp.lastTxNum = root, domains.TxNum()
+	}
+}
+
+func writeCrashData(t *testing.T, db kv.RwDB, agg *Aggregator) *crashExpected {
+	t.Helper()
+	exp := &crashExpected{
+		history:  map[kv.Domain]map[string][]crashWrite{kv.AccountsDomain: {}, kv.StorageDomain: {}},
+		logAddrs: map[string][]uint64{},
+	}
+	tx, err := db.BeginRw(context.Background())
+	require.NoError(t, err)
+	defer tx.Rollback()
+	ac := agg.BeginFilesRo()
+	defer ac.Close()
+	domains, err := NewSharedDomains(WrapTxWithCtx(tx, ac), log.New())
+	require.NoError(t, err)
+	defer domains.Close()
+
+	writeCrashBlocks(t, tx, domains, 0, crashSteps*crashAggStep/crashBlockSize, exp)
+	require.NoError(t, domains.Flush(context.Background(), tx))
+	require.NoError(t, tx.Commit())
+	return exp
+}
+
+// buildCrashFiles - same order as `BuildFilesInBackground`: build steps which are not in visible files yet, then merge
+func buildCrashFiles(ctx context.Context, agg *Aggregator) error {
+	for step := agg.EndTxNumMinimax() / crashAggStep; step < crashSteps-1; step++ {
+		if err := agg.buildFiles(ctx, step); err != nil {
+			return err
+		}
+	}
+	return agg.MergeLoop(ctx)
+}
+
+func pruneCrash(ctx context.Context, db kv.RwDB, agg *Aggregator, beforeCommit func() error) error {
+	tx, err := db.BeginRw(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	ac := agg.BeginFilesRo()
+	defer ac.Close()
+	if _, err := ac.PruneSmallBatches(ctx, time.Hour, tx); err != nil {
+		return err
+	}
+	if err := beforeCommit(); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// runUntilFault - runs files lifecycle of scenario and calls `fault` at scenario's fault point
+func runUntilFault(t *testing.T, db kv.RwDB, agg *Aggregator, sc crashScenario, fault func() error) error {
+	t.Helper()
+	ctx := context.Background()
+	switch {
+	case sc.exec:
+		tx, err := db.BeginRw(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+		ac := agg.BeginFilesRo()
+		defer ac.Close()
+		domains, err := NewSharedDomains(WrapTxWithCtx(tx, ac), log.New())
+		require.NoError(t, err)
+		defer domains.Close()
+		_, err = domains.SeekCommitment(ctx, tx)
+		require.NoError(t, err)
+
+		exp := &crashExpected{history: map[kv.Domain]map[string][]crashWrite{kv.AccountsDomain: {}, kv.StorageDomain: {}}, logAddrs: map[string][]uint64{}}
+		nextBlock := domains.BlockNum() + 1
+		writeCrashBlocks(t, tx, domains, nextBlock, nextBlock+1, exp)
+		require.NoError(t, domains.Flush(ctx, tx))
+		return fault()
+	case sc.prune:
+		require.NoError(t, buildCrashFiles(ctx, agg))
+		return pruneCrash(ctx, db, agg, fault)
+	default:
+		faultInjector = func(point faultPoint, fileName string) error {
+			if point != sc.point || (sc.file != "" && fileName != sc.file) {
+				return nil
+			}
+			return fault()
+		}
+		defer func() { faultInjector = nil }()
+		return buildCrashFiles(ctx, agg)
+	}
+}
+
+// killFault - emulates `kill -9` in the middle of writing next file
+func killFault(dirs datadir.Dirs) func() error {
+	return func() error {
+		_ = os.WriteFile(filepath.Join(dirs.SnapDomain, "v1-accounts.5-6.kv.tmp"), []byte("partial"), 0644)
+		_ = os.WriteFile(filepath.Join(dirs.SnapAccessors, "v1-accounts.5-6.bt.tmp"), []byte("partial"), 0644)
+		os.Exit(crashExitCode)
+		return nil
+	}
+}
+
+// TestAggregatorCrashChild - helper process of TestAggregatorCrashConsistency
+func TestAggregatorCrashChild(t *testing.T) {
+	env := os.Getenv(crashChildEnv)
+	if env == "" {
+		t.Skip("helper process of TestAggregatorCrashConsistency")
+	}
+	dataDir, name, _ := strings.Cut(env, "|")
+	var sc crashScenario
+	for _, s := range crashScenarios {
+		if s.name == name {
+			sc = s
+		}
+	}
+	require.NotEmpty(t, sc.name, name)
+
+	logger := log.New()
+	dirs := datadir.New(dataDir)
+	db := openCrashDB(t, dirs, logger)
+	agg := openCrashAgg(t, dirs, db, logger)
+	require.NoError(t, runUntilFault(t, db, agg, sc, killFault(dirs)))
+	// fault point was not reached: parent will fail on exit code
+}
+
+func checkCrashState(t *testing.T, db kv.RwDB, agg *Aggregator, exp *crashExpected) {
+	t.Helper()
+	ctx := context.Background()
+	tx, err := db.BeginRw(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	ac := agg.BeginFilesRo()
+	defer ac.Close()
+
+	domains, err := NewSharedDomains(WrapTxWithCtx(tx, ac), log.New())
+	require.NoError(t, err)
+	defer domains.Close()
+	_, err = domains.SeekCommitment(ctx, tx)
+	require.NoError(t, err)
+	require.Equal(t, exp.lastTxNum, domains.TxNum())
+	root, err := domains.ComputeCommitment(ctx, false, domains.BlockNum(), "")
+	require.NoError(t, err)
+	require.Equal(t, hex.EncodeToString(exp.root), hex.EncodeToString(root))
+
+	for domain, keys := range exp.history {
+		for key := range keys {
+			k1, k2 := []byte(key[:length.Addr]), []byte(key[length.Addr:])
+			v, _, _, err := ac.GetLatest(domain, k1, k2, tx)
+			require.NoError(t, err)
+			require.Equal(t, hex.EncodeToString(exp.asOf(domain, key, exp.lastTxNum+1)), hex.EncodeToString(v), "%s latest %x", domain, key)
+
+			for txNum := uint64(0); txNum <= exp.lastTxNum+1; txNum++ {
+				v, _, err := ac.d[domain].GetAsOf([]byte(key), txNum, tx)
+				require.NoError(t, err)
+				require.Equal(t, hex.EncodeToString(exp.asOf(domain, key, txNum)), hex.EncodeToString(v), "%s as of %d %x", domain, txNum, key)
+			}
+		}
+	}
+
+	for key, txNums := range exp.logAddrs {
+		it, err := ac.IndexRange(kv.LogAddrIdx, []byte(key), 0, -1, order.Asc, -1, tx)
+		require.NoError(t, err)
+		got, err := stream.ToArrayU64(it)
+		require.NoError(t, err)
+		require.Equal(t, txNums, got, "logaddrs %x", key)
+	}
+}
+
+func requireNoTmpFiles(t *testing.T, dirs datadir.Dirs) {
+	t.Helper()
+	for _, d := range []string{dirs.SnapDomain, dirs.SnapHistory, dirs.SnapIdx, dirs.SnapAccessors} {
+		files, err := dir.ListFiles(d, ".tmp")
+		require.NoError(t, err)
+		require.Empty(t, files)
+	}
+}
+
+func TestAggregatorRemovesTmpFilesOnlyIfOwnsDatadir(t *testing.T) {
+	logger := log.New()
+	dirs := datadir.New(t.TempDir())
+	db := openCrashDB(t, dirs, logger)
+	defer db.Close()
+	tmpFile := filepath.Join(dirs.SnapDomain, "v1-accounts.0-1.kv.tmp")
+	require.NoError(t, os.WriteFile(tmpFile, []byte("in progress"), 0644))
+
+	// f.e. `integration` next to live Erigon: must not touch files which Erigon is writing now
+	agg, err := NewAggregator(context.Background(), dirs, crashAggStep, db, logger)
+	require.NoError(t, err)
+	require.NoError(t, agg.OpenFolder())
+	agg.Close()
+	require.FileExists(t, tmpFile)
+
+	agg = openCrashAgg(t, dirs, db, logger)
+	agg.Close()
+	require.NoFileExists(t, tmpFile)
+}
+
+func TestAggregatorCrashConsistency(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	for _, mode := range []string{"kill", "abort"} {
+		for _, sc := range crashScenarios {
+			mode, sc := mode, sc
+			t.Run(mode+"_"+sc.name, func(t *testing.T) {
+				ctx, logger := context.Background(), log.New()
+				dirs := datadir.New(t.TempDir())
+
+				db := openCrashDB(t, dirs, logger)
+				agg := openCrashAgg(t, dirs, db, logger)
+				exp := writeCrashData(t, db, agg)
+				agg.Close()
+				db.Close()
+
+				switch mode {
+				case "kill":
+					cmd := exec.Command(os.Args[0], "-test.run=^TestAggregatorCrashChild$")
+					cmd.Env = append(os.Environ(), fmt.Sprintf("%s=%s|%s", crashChildEnv, dirs.DataDir, sc.name))
+					out, err := cmd.CombinedOutput()
+					var exitErr *exec.ExitError
+					require.ErrorAs(t, err, &exitErr, string(out))
+					require.Equal(t, crashExitCode, exitErr.ExitCode(), string(out))
+				case "abort":
+					db = openCrashDB(t, dirs, logger)
+					agg = openCrashAgg(t, dirs, db, logger)
+					err := runUntilFault(t, db, agg, sc, func() error { return errInjectedFault })
+					require.ErrorIs(t, err, errInjectedFault)
+					agg.Close()
+					db.Close()
+				}
+
+				// restart
+				db = openCrashDB(t, dirs, logger)
+				defer db.Close()
+				agg = openCrashAgg(t, dirs, db, logger)
+				defer agg.Close()
+				requireNoTmpFiles(t, dirs)
+				checkCrashState(t, db, agg, exp)
+
+				// finish interrupted work: same state, but now mostly from files
+				require.NoError(t, agg.BuildMissedIndices(ctx, 1))
+				require.NoError(t, buildCrashFiles(ctx, agg))
+				require.NoError(t, pruneCrash(ctx, db, agg, func() error { return nil }))
+				require.Equal(t, uint64((crashSteps-1)*crashAggStep), agg.EndTxNumMinimax())
+				checkCrashState(t, db, agg, exp)
+			})
+		}
+	}
+}
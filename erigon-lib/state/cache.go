@@ -100,6 +100,7 @@ type IISeekInFilesCache struct {
 }
 type iiSeekInFilesCacheItem struct {
 	requested, found uint64
+	notFound         bool // `found=0` is valid txNum (genesis), can't use it as marker
 }
 
 func NewIISeekInFilesCache() *IISeekInFilesCache {
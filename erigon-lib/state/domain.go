@@ -1111,6 +1111,9 @@ func (d *Domain) buildFiles(ctx context.Context, step uint64, collation Collatio
 	if valuesDecomp, err = seg.NewDecompressor(collation.valuesPath); err != nil {
 		return StaticFiles{}, fmt.Errorf("open %s values decompressor: %w", d.filenameBase, err)
 	}
+	if err = injectFault(faultBuildFiles, collation.valuesPath); err != nil {
+		return StaticFiles{}, err
+	}
 
 	if !UseBpsTree {
 		if err = d.buildAccessor(ctx, step, step+1, valuesDecomp, ps); err != nil {
@@ -0,0 +1,62 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package state
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/erigontech/erigon-lib/common/dir"
+)
+
+// faultPoint - place in files lifecycle where crash-consistency tests (see aggregator_crash_test.go) can
+// abort (return error) or kill process. Production code never sets `faultInjector`.
+type faultPoint string
+
+const (
+	faultBuildFiles          faultPoint = "buildFiles"                // data file (.kv/.v/.ef) of step is on disk, its accessors are not
+	faultMergeFiles          faultPoint = "mergeFiles"                // merged data file is on disk, its accessors are not
+	faultIntegrateDirtyFiles faultPoint = "integrateDirtyFiles"       // all files of step are on disk, but not added to aggregator
+	faultIntegrateMerged     faultPoint = "integrateMergedDirtyFiles" // merged files and their sources are on disk, merged are not added to aggregator
+)
+
+var faultInjector func(point faultPoint, fileName string) error
+
+// injectFault - `fileName` is base name of file which is built at this point, or empty if point is not about 1 file
+func injectFault(point faultPoint, fileName string) error {
+	if faultInjector == nil {
+		return nil
+	}
+	return faultInjector(point, filepath.Base(fileName))
+}
+
+// removeTmpFiles - files are written to .tmp and renamed when ready. Process killed in the middle of writing
+// leaves .tmp file which nobody will finish or remove.
+func removeTmpFiles(dirs ...string) error {
+	for _, d := range dirs {
+		files, err := dir.ListFiles(d, ".tmp")
+		if err != nil {
+			return err
+		}
+		for _, fPath := range files {
+			if err := os.Remove(fPath); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -79,6 +79,26 @@ func filesItemLess(i, j *filesItem) bool {
 	return i.endTxNum < j.endTxNum
 }
 
+// filePaths - paths of all opened files of item: data file and it's accessors
+func (i *filesItem) filePaths() (res []string) {
+	if i.decompressor != nil {
+		res = append(res, i.decompressor.FilePath())
+	}
+	if i.index != nil {
+		res = append(res, i.index.FilePath())
+	}
+	if i.bindex != nil {
+		res = append(res, i.bindex.FilePath())
+	}
+	if i.bm != nil {
+		res = append(res, i.bm.FilePath())
+	}
+	if i.existence != nil {
+		res = append(res, i.existence.FilePath)
+	}
+	return res
+}
+
 func (i *filesItem) closeFiles() {
 	if i.decompressor != nil {
 		i.decompressor.Close()
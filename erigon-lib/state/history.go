@@ -882,6 +882,9 @@ func (h *History) buildFiles(ctx context.Context, step uint64, collation History
 	if err != nil {
 		return HistoryFiles{}, fmt.Errorf("open %s .ef history decompressor: %w", h.filenameBase, err)
 	}
+	if err = injectFault(faultBuildFiles, collation.efHistoryPath); err != nil {
+		return HistoryFiles{}, err
+	}
 	{
 		if err := h.InvertedIndex.buildMapAccessor(ctx, step, step+1, efHistoryDecomp, ps); err != nil {
 			return HistoryFiles{}, fmt.Errorf("build %s .ef history idx: %w", h.filenameBase, err)
@@ -895,6 +898,9 @@ func (h *History) buildFiles(ctx context.Context, step uint64, collation History
 	if err != nil {
 		return HistoryFiles{}, fmt.Errorf("open %s v history decompressor: %w", h.filenameBase, err)
 	}
+	if err = injectFault(faultBuildFiles, collation.historyPath); err != nil {
+		return HistoryFiles{}, err
+	}
 
 	historyIdxPath := h.vAccessorFilePath(step, step+1)
 	historyIdxPath, err = h.buildVI(ctx, historyIdxPath, historyDecomp, efHistoryDecomp, ps)
@@ -97,6 +97,10 @@ type iiCfg struct {
 	salt *uint32
 	dirs datadir.Dirs
 	db   kv.RoDB // global db pointer. mostly for background warmup.
+
+	// build optional .efei files: existence filter of keys. allows to skip .ef file without .efi lookup - if key is not there
+	// (rare keys: logs of rare topic/address). files without filter are still visible, existing filters are opened anyway.
+	withExistence bool
 }
 type iiVisible struct {
 	files  []visibleFile
@@ -132,6 +136,9 @@ func NewInvertedIndex(cfg iiCfg, aggregationStep uint64, filenameBase, indexKeys
 func (ii *InvertedIndex) efAccessorFilePath(fromStep, toStep uint64) string {
 	return filepath.Join(ii.dirs.SnapAccessors, fmt.Sprintf("v1-%s.%d-%d.efi", ii.filenameBase, fromStep, toStep))
 }
+func (ii *InvertedIndex) efExistenceFilePath(fromStep, toStep uint64) string {
+	return filepath.Join(ii.dirs.SnapAccessors, fmt.Sprintf("v1-%s.%d-%d.efei", ii.filenameBase, fromStep, toStep))
+}
 func (ii *InvertedIndex) efFilePath(fromStep, toStep uint64) string {
 	return filepath.Join(ii.dirs.SnapIdx, fmt.Sprintf("v1-%s.%d-%d.ef", ii.filenameBase, fromStep, toStep))
 }
@@ -258,6 +265,67 @@ func (ii *InvertedIndex) missedAccessors() (l []*filesItem) {
 	return l
 }
 
+// missedExistenceFilters - visible files without .efei. Filters are optional: file is visible without it
+func (iit *InvertedIndexRoTx) missedExistenceFilters() (l []*filesItem) {
+	if !iit.ii.withExistence {
+		return nil
+	}
+	for _, item := range iit.files {
+		if item.src.existence != nil {
+			continue
+		}
+		fromStep, toStep := item.startTxNum/iit.ii.aggregationStep, item.endTxNum/iit.ii.aggregationStep
+		exists, err := dir.FileExist(iit.ii.efExistenceFilePath(fromStep, toStep))
+		if err != nil {
+			_, fName := filepath.Split(iit.ii.efExistenceFilePath(fromStep, toStep))
+			iit.ii.logger.Warn("[agg] InvertedIndex missedExistenceFilters", "err", err, "f", fName)
+		}
+		if !exists {
+			l = append(l, item.src)
+		}
+	}
+	return l
+}
+
+// buildExistenceFilter - produce .efei from .ef
+func (ii *InvertedIndex) buildExistenceFilter(ctx context.Context, fromStep, toStep uint64, data *seg.Decompressor, ps *background.ProgressSet) (*ExistenceFilter, error) {
+	fPath := ii.efExistenceFilePath(fromStep, toStep)
+	_, fName := filepath.Split(fPath)
+	keysCount := uint64(data.Count() / 2)
+	p := ps.AddNew(fName, keysCount)
+	defer ps.Delete(p)
+
+	filter, err := NewExistenceFilter(keysCount, fPath)
+	if err != nil {
+		return nil, err
+	}
+	if ii.noFsync {
+		filter.DisableFsync()
+	}
+
+	defer data.EnableReadAhead().DisableReadAhead()
+	g := seg.NewReader(data.MakeGetter(), ii.compression)
+	g.Reset(0)
+	key := make([]byte, 0, 64)
+	for g.HasNext() {
+		key, _ = g.Next(key[:0])
+		hi, _ := murmur3.Sum128WithSeed(key, *ii.salt)
+		filter.AddHash(hi)
+		g.Skip() // ef value
+		p.Processed.Add(1)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+	}
+	if err := filter.Build(); err != nil {
+		return nil, fmt.Errorf("build %s: %w", fName, err)
+	}
+	return filter, nil
+}
+
 func (ii *InvertedIndex) buildEfAccessor(ctx context.Context, item *filesItem, ps *background.ProgressSet) (err error) {
 	if item.decompressor == nil {
 		return fmt.Errorf("buildEfAccessor: passed item with nil decompressor %s %d-%d", ii.filenameBase, item.startTxNum/ii.aggregationStep, item.endTxNum/ii.aggregationStep)
@@ -335,6 +403,22 @@ func (ii *InvertedIndex) openDirtyFiles() error {
 					}
 				}
 			}
+			if item.existence == nil {
+				fPath := ii.efExistenceFilePath(fromStep, toStep)
+				exists, err := dir.FileExist(fPath)
+				if err != nil {
+					_, fName := filepath.Split(fPath)
+					ii.logger.Warn("[agg] InvertedIndex.openDirtyFiles", "err", err, "f", fName)
+					// don't interrupt on error. other files may be good
+				}
+				if exists {
+					if item.existence, err = OpenExistenceFilter(fPath); err != nil {
+						_, fName := filepath.Split(fPath)
+						ii.logger.Warn("[agg] InvertedIndex.openDirtyFiles", "err", err, "f", fName)
+						// don't interrupt on error. other files may be good
+					}
+				}
+			}
 		}
 
 		return true
@@ -598,12 +682,12 @@ func (iit *InvertedIndexRoTx) seekInFiles(key []byte, txNum uint64) (found bool,
 		iit.seekInFilesCache.total++
 		fromCache, ok := iit.seekInFilesCache.Get(hi)
 		if ok && fromCache.requested <= txNum {
-			if txNum <= fromCache.found {
-				iit.seekInFilesCache.hit++
-				return true, fromCache.found
-			} else if fromCache.found == 0 {
+			if fromCache.notFound {
 				iit.seekInFilesCache.hit++
 				return false, 0
+			} else if txNum <= fromCache.found {
+				iit.seekInFilesCache.hit++
+				return true, fromCache.found
 			}
 		}
 	}
@@ -612,6 +696,9 @@ func (iit *InvertedIndexRoTx) seekInFiles(key []byte, txNum uint64) (found bool,
 		if iit.files[i].endTxNum <= txNum {
 			continue
 		}
+		if iit.files[i].src.existence != nil && !iit.files[i].src.existence.ContainsHash(hi) {
+			continue
+		}
 		offset, ok := iit.statelessIdxReader(i).TwoLayerLookupByHash(hi, lo)
 		if !ok {
 			continue
@@ -635,7 +722,7 @@ func (iit *InvertedIndexRoTx) seekInFiles(key []byte, txNum uint64) (found bool,
 	}
 
 	if iit.seekInFilesCache != nil {
-		iit.seekInFilesCache.Add(hi, iiSeekInFilesCacheItem{requested: txNum, found: 0})
+		iit.seekInFilesCache.Add(hi, iiSeekInFilesCacheItem{requested: txNum, notFound: true})
 	}
 	return false, 0
 }
@@ -712,6 +799,7 @@ func (iit *InvertedIndexRoTx) iterateRangeFrozen(key []byte, startTxNum, endTxNu
 		limit:       limit,
 		ef:          eliasfano32.NewEliasFano(1, 1),
 	}
+	hi, _ := iit.hashKey(key)
 	if asc {
 		for i := len(iit.files) - 1; i >= 0; i-- {
 			// [from,to) && from < to
@@ -724,6 +812,9 @@ func (iit *InvertedIndexRoTx) iterateRangeFrozen(key []byte, startTxNum, endTxNu
 			if iit.files[i].src.index.KeyCount() == 0 {
 				continue
 			}
+			if iit.files[i].src.existence != nil && !iit.files[i].src.existence.ContainsHash(hi) {
+				continue
+			}
 			it.stack = append(it.stack, iit.files[i])
 			it.stack[len(it.stack)-1].getter = it.stack[len(it.stack)-1].src.decompressor.MakeGetter()
 			it.stack[len(it.stack)-1].reader = it.stack[len(it.stack)-1].src.index.GetReaderFromPool()
@@ -745,6 +836,9 @@ func (iit *InvertedIndexRoTx) iterateRangeFrozen(key []byte, startTxNum, endTxNu
 			if iit.files[i].src.index.KeyCount() == 0 {
 				continue
 			}
+			if iit.files[i].src.existence != nil && !iit.files[i].src.existence.ContainsHash(hi) {
+				continue
+			}
 			it.stack = append(it.stack, iit.files[i])
 			it.stack[len(it.stack)-1].getter = it.stack[len(it.stack)-1].src.decompressor.MakeGetter()
 			it.stack[len(it.stack)-1].reader = it.stack[len(it.stack)-1].src.index.GetReaderFromPool()
@@ -1560,6 +1654,9 @@ func (sf InvertedFiles) CleanupOnError() {
 	if sf.index != nil {
 		sf.index.Close()
 	}
+	if sf.existence != nil {
+		sf.existence.Close()
+	}
 }
 
 type InvertedIndexCollation struct {
@@ -1618,6 +1715,9 @@ func (ii *InvertedIndex) buildFiles(ctx context.Context, step uint64, coll Inver
 	if decomp, err = seg.NewDecompressor(coll.iiPath); err != nil {
 		return InvertedFiles{}, fmt.Errorf("open %s decompressor: %w", ii.filenameBase, err)
 	}
+	if err = injectFault(faultBuildFiles, coll.iiPath); err != nil {
+		return InvertedFiles{}, err
+	}
 
 	if err := ii.buildMapAccessor(ctx, step, step+1, decomp, ps); err != nil {
 		return InvertedFiles{}, fmt.Errorf("build %s efi: %w", ii.filenameBase, err)
@@ -1625,6 +1725,11 @@ func (ii *InvertedIndex) buildFiles(ctx context.Context, step uint64, coll Inver
 	if index, err = recsplit.OpenIndex(ii.efAccessorFilePath(step, step+1)); err != nil {
 		return InvertedFiles{}, err
 	}
+	if ii.withExistence {
+		if existence, err = ii.buildExistenceFilter(ctx, step, step+1, decomp, ps); err != nil {
+			return InvertedFiles{}, err
+		}
+	}
 
 	closeComp = false
 	return InvertedFiles{decomp: decomp, index: index, existence: existence}, nil
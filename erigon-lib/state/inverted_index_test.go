@@ -31,6 +31,7 @@ import (
 
 	"github.com/erigontech/erigon-lib/common/background"
 	"github.com/erigontech/erigon-lib/common/datadir"
+	"github.com/erigontech/erigon-lib/common/dir"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon-lib/kv/mdbx"
 	"github.com/erigontech/erigon-lib/kv/order"
@@ -546,6 +547,131 @@ func TestInvIndexMerge(t *testing.T) {
 	checkRanges(t, db, ii, txs)
 }
 
+func TestInvIndexSeekInFilesCache(t *testing.T) {
+	logger, require := log.New(), require.New(t)
+	ctx := context.Background()
+	db, ii := testDbAndInvertedIndex(t, 16, logger)
+	key, otherKey := []byte("key"), []byte("other")
+
+	require.NoError(db.Update(ctx, func(tx kv.RwTx) error {
+		ic := ii.BeginFilesRo()
+		defer ic.Close()
+		writer := ic.NewWriter()
+		defer writer.close()
+		for _, txNum := range []uint64{0, 20, 40} {
+			writer.SetTxNum(txNum)
+			if err := writer.Add(key); err != nil {
+				return err
+			}
+		}
+		writer.SetTxNum(5)
+		if err := writer.Add(otherKey); err != nil {
+			return err
+		}
+		return writer.Flush(ctx, tx)
+	}))
+	mergeInverted(t, db, ii, 64)
+
+	ic := ii.BeginFilesRo()
+	defer ic.Close()
+	seek := func(key []byte, txNum uint64) (bool, uint64) {
+		found, foundTxNum := ic.seekInFiles(key, txNum)
+		// same answer from cache
+		fromCache, fromCacheTxNum := ic.seekInFiles(key, txNum)
+		require.Equal(found, fromCache)
+		require.Equal(foundTxNum, fromCacheTxNum)
+		return found, foundTxNum
+	}
+
+	// txNum 0 is cached as found, not as a miss of later lookups
+	found, txNum := seek(key, 0)
+	require.True(found)
+	require.Zero(txNum)
+	found, txNum = seek(key, 1)
+	require.True(found)
+	require.Equal(uint64(20), txNum)
+	found, txNum = seek(key, 21)
+	require.True(found)
+	require.Equal(uint64(40), txNum)
+
+	// miss is cached for later lookups only
+	found, _ = seek(otherKey, 6)
+	require.False(found)
+	found, _ = seek(otherKey, 10)
+	require.False(found)
+	found, txNum = seek(otherKey, 0)
+	require.True(found)
+	require.Equal(uint64(5), txNum)
+}
+
+func TestInvIndexExistenceFilter(t *testing.T) {
+	logger, require := log.New(), require.New(t)
+	ctx := context.Background()
+	db, ii, txs := filledInvIndex(t, logger)
+	ii.withExistence = true
+
+	mergeInverted(t, db, ii, txs)
+	checkRanges(t, db, ii, txs)
+
+	notExistingKey := make([]byte, 8)
+	binary.BigEndian.PutUint64(notExistingKey, 1_000)
+	checkFilters := func(ii *InvertedIndex) {
+		ic := ii.BeginFilesRo()
+		defer ic.Close()
+		require.NotEmpty(ic.files)
+		for _, f := range ic.files {
+			require.NotNil(f.src.existence, f.src.decompressor.FileName())
+		}
+		found, _ := ic.seekInFiles(notExistingKey, 0)
+		require.False(found)
+		it, err := ic.iterateRangeFrozen(notExistingKey, -1, -1, order.Asc, -1)
+		require.NoError(err)
+		require.Empty(it.stack) // all files skipped by filter
+		require.False(it.HasNext())
+	}
+	checkFilters(ii)
+
+	// filters are optional: files are visible without them, and missed filters are built by BuildOptionalMissedIndices
+	efeiFiles, err := dir.ListFiles(ii.dirs.SnapAccessors, ".efei")
+	require.NoError(err)
+	require.NotEmpty(efeiFiles)
+	for _, fPath := range efeiFiles {
+		require.NoError(os.Remove(fPath))
+	}
+	salt := uint32(1)
+	cfg := iiCfg{salt: &salt, dirs: ii.dirs, db: db, withExistence: true}
+	ii2, err := NewInvertedIndex(cfg, ii.aggregationStep, ii.filenameBase, ii.indexKeysTable, ii.indexTable, nil, logger)
+	require.NoError(err)
+	defer ii2.Close()
+	ii2.DisableFsync()
+	require.NoError(ii2.openFolder())
+	ii2.reCalcVisibleFiles(ii2.dirtyFilesEndTxNumMinimax())
+	func() {
+		ic := ii2.BeginFilesRo()
+		defer ic.Close()
+		require.Equal(len(ic.files), len(ic.missedExistenceFilters()))
+		require.NoError(ic.BuildOptionalMissedIndices(ctx, background.NewProgressSet()))
+	}()
+	require.NoError(ii2.openFolder())
+	ii2.reCalcVisibleFiles(ii2.dirtyFilesEndTxNumMinimax())
+	checkFilters(ii2)
+	checkRanges(t, db, ii2, txs)
+
+	// building is disabled by default: existing filters are used, missed ones are not built
+	cfg.withExistence = false
+	ii3, err := NewInvertedIndex(cfg, ii.aggregationStep, ii.filenameBase, ii.indexKeysTable, ii.indexTable, nil, logger)
+	require.NoError(err)
+	defer ii3.Close()
+	require.NoError(ii3.openFolder())
+	ii3.reCalcVisibleFiles(ii3.dirtyFilesEndTxNumMinimax())
+	checkFilters(ii3)
+	func() {
+		ic := ii3.BeginFilesRo()
+		defer ic.Close()
+		require.Empty(ic.missedExistenceFilters())
+	}()
+}
+
 func TestInvIndexScanFiles(t *testing.T) {
 	logger, require := log.New(), require.New(t)
 	db, ii, txs := filledInvIndex(t, logger)
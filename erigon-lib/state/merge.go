@@ -244,6 +244,19 @@ func (dt *DomainRoTx) BuildOptionalMissedIndices(ctx context.Context, ps *backgr
 }
 
 func (iit *InvertedIndexRoTx) BuildOptionalMissedIndices(ctx context.Context, ps *background.ProgressSet) (err error) {
+	for _, item := range iit.missedExistenceFilters() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		fromStep, toStep := item.startTxNum/iit.ii.aggregationStep, item.endTxNum/iit.ii.aggregationStep
+		filter, err := iit.ii.buildExistenceFilter(ctx, fromStep, toStep, item.decompressor, ps)
+		if err != nil {
+			return err
+		}
+		filter.Close() // will be opened by next `OpenFolder`
+	}
 	return nil
 }
 
@@ -525,6 +538,9 @@ func (dt *DomainRoTx) mergeFiles(ctx context.Context, domainFiles, indexFiles, h
 	if valuesIn.decompressor, err = seg.NewDecompressor(kvFilePath); err != nil {
 		return nil, nil, nil, fmt.Errorf("merge %s decompressor [%d-%d]: %w", dt.d.filenameBase, r.values.from, r.values.to, err)
 	}
+	if err = injectFault(faultMergeFiles, kvFilePath); err != nil {
+		return nil, nil, nil, err
+	}
 
 	if UseBpsTree {
 		btPath := dt.d.kvBtFilePath(fromStep, toStep)
@@ -684,6 +700,9 @@ func (iit *InvertedIndexRoTx) mergeFiles(ctx context.Context, files []*filesItem
 		return nil, fmt.Errorf("merge %s decompressor [%d-%d]: %w", iit.ii.filenameBase, startTxNum, endTxNum, err)
 	}
 	ps.Delete(p)
+	if err = injectFault(faultMergeFiles, datPath); err != nil {
+		return nil, err
+	}
 
 	if err := iit.ii.buildMapAccessor(ctx, fromStep, toStep, outItem.decompressor, ps); err != nil {
 		return nil, fmt.Errorf("merge %s buildAccessor [%d-%d]: %w", iit.ii.filenameBase, startTxNum, endTxNum, err)
@@ -691,6 +710,11 @@ func (iit *InvertedIndexRoTx) mergeFiles(ctx context.Context, files []*filesItem
 	if outItem.index, err = recsplit.OpenIndex(iit.ii.efAccessorFilePath(fromStep, toStep)); err != nil {
 		return nil, err
 	}
+	if iit.ii.withExistence {
+		if outItem.existence, err = iit.ii.buildExistenceFilter(ctx, fromStep, toStep, outItem.decompressor, ps); err != nil {
+			return nil, err
+		}
+	}
 
 	closeItem = false
 	return outItem, nil
@@ -826,6 +850,9 @@ func (ht *HistoryRoTx) mergeFiles(ctx context.Context, indexFiles, historyFiles
 			return nil, nil, err
 		}
 		ps.Delete(p)
+		if err = injectFault(faultMergeFiles, datPath); err != nil {
+			return nil, nil, err
+		}
 
 		p = ps.AddNew(path.Base(idxPath), uint64(decomp.Count()/2))
 		defer ps.Delete(p)
@@ -33,7 +33,7 @@ import (
 	"sync/atomic"
 	"time"
 
-	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	goethkzg "github.com/crate-crypto/go-eth-kzg"
 	mapset "github.com/deckarep/golang-set/v2"
 	"github.com/go-stack/stack"
 	"github.com/google/btree"
@@ -834,10 +834,10 @@ func (p *TxPool) AddRemoteTxs(_ context.Context, newTxs types.TxSlots) {
 	}
 }
 
-func toBlobs(_blobs [][]byte) []gokzg4844.Blob {
-	blobs := make([]gokzg4844.Blob, len(_blobs))
+func toBlobs(_blobs [][]byte) []goethkzg.Blob {
+	blobs := make([]goethkzg.Blob, len(_blobs))
 	for i, _blob := range _blobs {
-		var b gokzg4844.Blob
+		var b goethkzg.Blob
 		copy(b[:], _blob)
 		blobs[i] = b
 	}
@@ -24,7 +24,7 @@ import (
 	"math/big"
 	"testing"
 
-	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	goethkzg "github.com/crate-crypto/go-eth-kzg"
 	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -914,20 +914,20 @@ func makeBlobTx() types.TxSlot {
 	blobsRlpPrefix := hexutility.MustDecodeHex("fa040008")
 	blobRlpPrefix := hexutility.MustDecodeHex("ba020000")
 
-	var blob0, blob1 = gokzg4844.Blob{}, gokzg4844.Blob{}
+	var blob0, blob1 = goethkzg.Blob{}, goethkzg.Blob{}
 	copy(blob0[:], hexutility.MustDecodeHex(ValidBlob1Hex))
 	copy(blob1[:], hexutility.MustDecodeHex(ValidBlob2Hex))
 
 	var err error
 	proofsRlpPrefix := hexutility.MustDecodeHex("f862")
-	commitment0, _ := kzg.Ctx().BlobToKZGCommitment(blob0, 0)
-	commitment1, _ := kzg.Ctx().BlobToKZGCommitment(blob1, 0)
+	commitment0, _ := kzg.Ctx().BlobToKZGCommitment(&blob0, 0)
+	commitment1, _ := kzg.Ctx().BlobToKZGCommitment(&blob1, 0)
 
-	proof0, err := kzg.Ctx().ComputeBlobKZGProof(blob0, commitment0, 0)
+	proof0, err := kzg.Ctx().ComputeBlobKZGProof(&blob0, commitment0, 0)
 	if err != nil {
 		fmt.Println("error", err)
 	}
-	proof1, err := kzg.Ctx().ComputeBlobKZGProof(blob1, commitment1, 0)
+	proof1, err := kzg.Ctx().ComputeBlobKZGProof(&blob1, commitment1, 0)
 	if err != nil {
 		fmt.Println("error", err)
 	}
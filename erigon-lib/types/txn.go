@@ -26,7 +26,7 @@ import (
 	"math/bits"
 	"sort"
 
-	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	goethkzg "github.com/crate-crypto/go-eth-kzg"
 	"github.com/holiman/uint256"
 	"golang.org/x/crypto/sha3"
 
@@ -107,8 +107,8 @@ type TxSlot struct {
 	BlobFeeCap  uint256.Int // max_fee_per_blob_gas
 	BlobHashes  []common.Hash
 	Blobs       [][]byte
-	Commitments []gokzg4844.KZGCommitment
-	Proofs      []gokzg4844.KZGProof
+	Commitments []goethkzg.KZGCommitment
+	Proofs      []goethkzg.KZGProof
 
 	// EIP-7702: set code tx
 	Authorizations []Signature
@@ -256,7 +256,7 @@ func (ctx *TxParseContext) ParseTransaction(payload []byte, pos int, slot *TxSlo
 			if err != nil {
 				return 0, fmt.Errorf("%w: commitment: %s", ErrParseTxn, err) //nolint
 			}
-			var commitment gokzg4844.KZGCommitment
+			var commitment goethkzg.KZGCommitment
 			copy(commitment[:], payload[commitmentPos:commitmentPos+48])
 			slot.Commitments = append(slot.Commitments, commitment)
 			commitmentPos += 48
@@ -276,7 +276,7 @@ func (ctx *TxParseContext) ParseTransaction(payload []byte, pos int, slot *TxSlo
 			if err != nil {
 				return 0, fmt.Errorf("%w: proof: %s", ErrParseTxn, err) //nolint
 			}
-			var proof gokzg4844.KZGProof
+			var proof goethkzg.KZGProof
 			copy(proof[:], payload[proofPos:proofPos+48])
 			slot.Proofs = append(slot.Proofs, proof)
 			proofPos += 48
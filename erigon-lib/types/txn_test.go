@@ -22,7 +22,7 @@ import (
 	"strconv"
 	"testing"
 
-	gokzg4844 "github.com/crate-crypto/go-kzg-4844"
+	goethkzg "github.com/crate-crypto/go-eth-kzg"
 	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -231,10 +231,10 @@ func TestBlobTxParsing(t *testing.T) {
 	rand.Read(blob1)
 
 	proofsRlpPrefix := hexutility.MustDecodeHex("f862")
-	var commitment0, commitment1 gokzg4844.KZGCommitment
+	var commitment0, commitment1 goethkzg.KZGCommitment
 	rand.Read(commitment0[:])
 	rand.Read(commitment1[:])
-	var proof0, proof1 gokzg4844.KZGProof
+	var proof0, proof1 goethkzg.KZGProof
 	rand.Read(proof0[:])
 	rand.Read(proof1[:])
 
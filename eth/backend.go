@@ -24,6 +24,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"math/big"
 	"net"
@@ -121,6 +122,7 @@ import (
 	"github.com/erigontech/erigon/polygon/bor/valset"
 	"github.com/erigontech/erigon/polygon/bridge"
 	"github.com/erigontech/erigon/polygon/heimdall"
+	"github.com/erigontech/erigon/polygon/heimdall/heimdallsim"
 	polygonsync "github.com/erigontech/erigon/polygon/sync"
 	"github.com/erigontech/erigon/rpc"
 	"github.com/erigontech/erigon/turbo/builder"
@@ -545,7 +547,20 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 
 	if chainConfig.Bor != nil {
 		if !config.WithoutHeimdall {
-			heimdallClient = heimdall.NewHeimdallClient(config.HeimdallURL, logger)
+			heimdallVersion, err := heimdall.ParseClientVersion(config.HeimdallVersion)
+			if err != nil {
+				return nil, err
+			}
+			heimdallClient, err = heimdallsim.NewHeimdallClient(ctx, heimdallsim.ClientConfig{
+				URL:       config.HeimdallURL,
+				Version:   heimdallVersion,
+				RecordDir: config.HeimdallRecordDir,
+				SnapDir:   dirs.Snap,
+				Logger:    logger,
+			})
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		if config.PolygonSync {
@@ -564,6 +579,8 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 			heimdallConfig := heimdall.ServiceConfig{
 				CalculateSprintNumberFn: borConfig.CalculateSprintNumber,
 				HeimdallURL:             config.HeimdallURL,
+				HeimdallVersion:         heimdall.ClientVersion(config.HeimdallVersion),
+				Client:                  heimdallClient,
 				DataDir:                 dirs.DataDir,
 				TempDir:                 tmpdir,
 				Logger:                  logger,
@@ -613,6 +630,7 @@ func New(ctx context.Context, stack *node.Node, config *ethconfig.Config, logger
 		chainConfig,
 		genesis,
 		backend.config.NetworkID,
+		blockReader,
 		logger,
 	)
 
@@ -1482,6 +1500,8 @@ func setUpBlockReader(ctx context.Context, db kv.RwDB, dirs datadir.Dirs, snConf
 		return nil, nil, nil, nil, nil, err
 	}
 	agg.SetProduceMod(snConfig.Snapshot.ProduceE3)
+	agg.SetExistenceFilters(snConfig.Snapshot.Existence)
+	agg.SetOwnsDatadir(true) // node holds datadir flock
 
 	g.Go(func() error {
 		return agg.OpenFolder()
@@ -1518,6 +1538,95 @@ func (s *Ethereum) AddPeer(ctx context.Context, req *remote.AddPeerRequest) (*re
 	return &remote.AddPeerReply{Success: true}, nil
 }
 
+func (s *Ethereum) RemovePeer(ctx context.Context, req *remote.RemovePeerRequest) (*remote.RemovePeerReply, error) {
+	for _, sentryClient := range s.sentriesClient.Sentries() {
+		_, err := sentryClient.RemovePeer(ctx, &protosentry.RemovePeerRequest{Url: req.Url})
+		if err != nil {
+			return nil, fmt.Errorf("ethereum backend MultiClient.RemovePeer error: %w", err)
+		}
+	}
+	return &remote.RemovePeerReply{Success: true}, nil
+}
+
+func (s *Ethereum) AddTrustedPeer(ctx context.Context, req *remote.AddPeerRequest) (*remote.AddPeerReply, error) {
+	for _, sentryClient := range s.sentriesClient.Sentries() {
+		_, err := sentryClient.AddTrustedPeer(ctx, &protosentry.AddPeerRequest{Url: req.Url})
+		if err != nil {
+			return nil, fmt.Errorf("ethereum backend MultiClient.AddTrustedPeer error: %w", err)
+		}
+	}
+	return &remote.AddPeerReply{Success: true}, nil
+}
+
+func (s *Ethereum) RemoveTrustedPeer(ctx context.Context, req *remote.RemovePeerRequest) (*remote.RemovePeerReply, error) {
+	for _, sentryClient := range s.sentriesClient.Sentries() {
+		_, err := sentryClient.RemoveTrustedPeer(ctx, &protosentry.RemovePeerRequest{Url: req.Url})
+		if err != nil {
+			return nil, fmt.Errorf("ethereum backend MultiClient.RemoveTrustedPeer error: %w", err)
+		}
+	}
+	return &remote.RemovePeerReply{Success: true}, nil
+}
+
+func (s *Ethereum) SetMaxPeers(ctx context.Context, req *remote.SetMaxPeersRequest) (*remote.SetMaxPeersReply, error) {
+	for _, sentryClient := range s.sentriesClient.Sentries() {
+		_, err := sentryClient.SetMaxPeers(ctx, &protosentry.SetMaxPeersRequest{MaxPeers: req.MaxPeers})
+		if err != nil {
+			return nil, fmt.Errorf("ethereum backend MultiClient.SetMaxPeers error: %w", err)
+		}
+	}
+	return &remote.SetMaxPeersReply{Success: true}, nil
+}
+
+// PeerEvents passes the peer events of all sentries to send until ctx is done
+// or one of the event streams fails.
+func (s *Ethereum) PeerEvents(ctx context.Context, send func(*remote.PeerEvent) error) error {
+	g, gctx := errgroup.WithContext(ctx)
+	var sendMu sync.Mutex
+	for _, sentryClient := range s.sentriesClient.Sentries() {
+		sentryClient := sentryClient
+		g.Go(func() error {
+			stream, err := sentryClient.PeerEvents(gctx, &protosentry.PeerEventsRequest{})
+			if err != nil {
+				return fmt.Errorf("ethereum backend MultiClient.PeerEvents error: %w", err)
+			}
+			for {
+				event, err := stream.Recv()
+				if err != nil {
+					if errors.Is(err, io.EOF) || gctx.Err() != nil {
+						return nil
+					}
+					return fmt.Errorf("ethereum backend MultiClient.PeerEvents error: %w", err)
+				}
+				sendMu.Lock()
+				err = send(&remote.PeerEvent{
+					PeerId:  event.PeerId,
+					EventId: remote.PeerEvent_PeerEventId(event.EventId), // both enums share the same values
+					Error:   event.Error,
+				})
+				sendMu.Unlock()
+				if err != nil {
+					return err
+				}
+			}
+		})
+	}
+	return g.Wait()
+}
+
+func (s *Ethereum) PeerScores(ctx context.Context) (*remote.PeerScoresReply, error) {
+	var reply remote.PeerScoresReply
+	for _, sentryClient := range s.sentriesClient.Sentries() {
+		scores, err := sentryClient.PeerScores(ctx, &emptypb.Empty{})
+		if err != nil {
+			return nil, fmt.Errorf("ethereum backend MultiClient.PeerScores error: %w", err)
+		}
+		reply.Scores = append(reply.Scores, scores.Scores...)
+	}
+
+	return &reply, nil
+}
+
 // Protocols returns all the currently configured
 // network protocols to start.
 func (s *Ethereum) Protocols() []p2p.Protocol {
@@ -138,6 +138,7 @@ type BlocksFreezing struct {
 	KeepBlocks     bool // produce new snapshots of blocks but don't remove blocks from DB
 	ProduceE2      bool // produce new block files
 	ProduceE3      bool // produce new state files
+	Existence      bool // build existence filters of inverted index state files
 	NoDownloader   bool // possible to use snapshots without calling Downloader
 	Verify         bool // verify snapshots on startup
 	DownloaderAddr string
@@ -159,6 +160,7 @@ var (
 	FlagSnapKeepBlocks = "snap.keepblocks"
 	FlagSnapStop       = "snap.stop"
 	FlagSnapStateStop  = "snap.state.stop"
+	FlagSnapExistence  = "snap.state.existence"
 )
 
 func NewSnapCfg(keepBlocks, produceE2, produceE3 bool, chainName string) BlocksFreezing {
@@ -227,6 +229,10 @@ type Config struct {
 
 	// URL to connect to Heimdall node
 	HeimdallURL string
+	// Version of the Heimdall API: "auto", "v1" or "v2"
+	HeimdallVersion string
+	// Directory to record Heimdall responses into, for replaying them with a sim:// Heimdall URL
+	HeimdallRecordDir string
 	// No heimdall service
 	WithoutHeimdall bool
 	// Heimdall services active
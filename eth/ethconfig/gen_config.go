@@ -48,6 +48,8 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		RPCTxFeeCap                    float64 `toml:",omitempty"`
 		StateStream                    bool
 		HeimdallURL                    string
+		HeimdallVersion                string
+		HeimdallRecordDir              string
 		WithoutHeimdall                bool
 		WithHeimdallMilestones         bool
 		WithHeimdallWaypointRecording  bool
@@ -101,6 +103,8 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.RPCTxFeeCap = c.RPCTxFeeCap
 	enc.StateStream = c.StateStream
 	enc.HeimdallURL = c.HeimdallURL
+	enc.HeimdallVersion = c.HeimdallVersion
+	enc.HeimdallRecordDir = c.HeimdallRecordDir
 	enc.WithoutHeimdall = c.WithoutHeimdall
 	enc.WithHeimdallMilestones = c.WithHeimdallMilestones
 	enc.WithHeimdallWaypointRecording = c.WithHeimdallWaypointRecording
@@ -158,6 +162,8 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		RPCTxFeeCap                    *float64 `toml:",omitempty"`
 		StateStream                    *bool
 		HeimdallURL                    *string
+		HeimdallVersion                *string
+		HeimdallRecordDir              *string
 		WithoutHeimdall                *bool
 		WithHeimdallMilestones         *bool
 		WithHeimdallWaypointRecording  *bool
@@ -264,6 +270,12 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.HeimdallURL != nil {
 		c.HeimdallURL = *dec.HeimdallURL
 	}
+	if dec.HeimdallVersion != nil {
+		c.HeimdallVersion = *dec.HeimdallVersion
+	}
+	if dec.HeimdallRecordDir != nil {
+		c.HeimdallRecordDir = *dec.HeimdallRecordDir
+	}
 	if dec.WithoutHeimdall != nil {
 		c.WithoutHeimdall = *dec.WithoutHeimdall
 	}
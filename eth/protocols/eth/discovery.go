@@ -21,10 +21,13 @@ package eth
 
 import (
 	"fmt"
+	"math"
 
 	libcommon "github.com/erigontech/erigon-lib/common"
 
 	"github.com/erigontech/erigon/core/forkid"
+	"github.com/erigontech/erigon/crypto"
+	"github.com/erigontech/erigon/p2p/discover"
 	"github.com/erigontech/erigon/p2p/enr"
 	"github.com/erigontech/erigon/rlp"
 )
@@ -49,6 +52,22 @@ func CurrentENREntryFromForks(heightForks, timeForks []uint64, genesisHash libco
 	}
 }
 
+// DiscoveryTopic returns the discovery v5 topic under which nodes of the chain
+// advertise themselves. It is derived from the genesis and the fork which is active
+// at the head, like the fork ID of the `eth` ENR entry, so nodes which schedule
+// different future forks still share the topic until one of them activates.
+func DiscoveryTopic(heightForks, timeForks []uint64, genesisHash libcommon.Hash, headHeight, headTime uint64) discover.Topic {
+	id := forkid.NewIDFromForks(heightForks, timeForks, genesisHash, headHeight, headTime)
+	return discover.Topic(crypto.Keccak256Hash([]byte("eth"), id.Hash[:]))
+}
+
+// LatestDiscoveryTopic returns the discovery v5 topic of the last scheduled fork.
+// Nodes whose head is behind that fork search it too, in order to find the nodes
+// which are already past it.
+func LatestDiscoveryTopic(heightForks, timeForks []uint64, genesisHash libcommon.Hash) discover.Topic {
+	return DiscoveryTopic(heightForks, timeForks, genesisHash, math.MaxUint64, math.MaxUint64)
+}
+
 func LoadENRForkID(r *enr.Record) (*forkid.ID, error) {
 	var entry enrEntry
 	if err := r.Load(&entry); err != nil {
@@ -165,6 +165,23 @@ type ReceiptsGetter interface {
 }
 
 func AnswerGetReceiptsQuery(ctx context.Context, cfg *chain.Config, receiptsGetter ReceiptsGetter, br services.FullBlockReader, db kv.Tx, query GetReceiptsPacket) ([]rlp.RawValue, error) { //nolint:unparam
+	return answerGetReceiptsQuery(ctx, cfg, receiptsGetter, br, db, query, func(receipts types.Receipts) ([]byte, error) {
+		return rlp.EncodeToBytes(receipts)
+	})
+}
+
+// AnswerGetReceiptsQuery69 answers a receipts query of an eth/69 peer, with receipts encoded without bloom
+func AnswerGetReceiptsQuery69(ctx context.Context, cfg *chain.Config, receiptsGetter ReceiptsGetter, br services.FullBlockReader, db kv.Tx, query GetReceiptsPacket) ([]rlp.RawValue, error) {
+	return answerGetReceiptsQuery(ctx, cfg, receiptsGetter, br, db, query, func(receipts types.Receipts) ([]byte, error) {
+		receipts69 := make([]*types.ReceiptForNetwork69, len(receipts))
+		for i, receipt := range receipts {
+			receipts69[i] = (*types.ReceiptForNetwork69)(receipt)
+		}
+		return rlp.EncodeToBytes(receipts69)
+	})
+}
+
+func answerGetReceiptsQuery(ctx context.Context, cfg *chain.Config, receiptsGetter ReceiptsGetter, br services.FullBlockReader, db kv.Tx, query GetReceiptsPacket, encode func(types.Receipts) ([]byte, error)) ([]rlp.RawValue, error) {
 	// Gather state data until the fetch or network limits is reached
 	var (
 		bytes    int
@@ -210,7 +227,7 @@ func AnswerGetReceiptsQuery(ctx context.Context, cfg *chain.Config, receiptsGett
 		//}
 
 		// If known, encode and queue for response packet
-		if encoded, err := rlp.EncodeToBytes(results); err != nil {
+		if encoded, err := encode(results); err != nil {
 			return nil, fmt.Errorf("failed to encode receipt: %w", err)
 		} else {
 			receipts = append(receipts, encoded)
@@ -27,6 +27,7 @@ import (
 	libcommon "github.com/erigontech/erigon-lib/common"
 
 	"github.com/erigontech/erigon/common"
+	"github.com/erigontech/erigon/core/forkid"
 	"github.com/erigontech/erigon/core/types"
 	"github.com/erigontech/erigon/rlp"
 )
@@ -232,3 +233,78 @@ func TestEth66Messages(t *testing.T) {
 		}
 	}
 }
+
+// TestEth69Messages tests the encoding of the eth69 status and block range update messages
+func TestEth69Messages(t *testing.T) {
+	hash := libcommon.HexToHash("deadc0de")
+	status := StatusPacket69{
+		ProtocolVersion: 69,
+		NetworkID:       1,
+		Genesis:         libcommon.HexToHash("01"),
+		ForkID:          forkid.ID{Hash: [4]byte{1, 2, 3, 4}, Next: 5},
+		EarliestBlock:   100,
+		LatestBlock:     200,
+		LatestBlockHash: hash,
+	}
+	enc, err := rlp.EncodeToBytes(&status)
+	if err != nil {
+		t.Fatalf("failed to encode status: %v", err)
+	}
+	var decStatus StatusPacket69
+	if err := rlp.DecodeBytes(enc, &decStatus); err != nil {
+		t.Fatalf("failed to decode status: %v", err)
+	}
+	if decStatus != status {
+		t.Fatalf("status encode decode mismatch: have %+v, want %+v", decStatus, status)
+	}
+	// an eth/69 status can not be mistaken for an eth/68 one, as it has no total difficulty
+	if err := rlp.DecodeBytes(enc, new(StatusPacket)); err == nil {
+		t.Fatalf("eth/69 status decoded as an eth/68 status")
+	}
+
+	update := BlockRangeUpdatePacket{EarliestBlock: 100, LatestBlock: 200, LatestBlockHash: hash}
+	if have, want := mustEncode(t, &update), common.FromHex("e46481c8a0"+hash.Hex()[2:]); !bytes.Equal(have, want) {
+		t.Fatalf("block range update encoding mismatch: have %x, want %x", have, want)
+	}
+	if err := update.Validate(); err != nil {
+		t.Fatalf("valid block range rejected: %v", err)
+	}
+	update.EarliestBlock = 201
+	if err := update.Validate(); err == nil {
+		t.Fatalf("invalid block range accepted")
+	}
+}
+
+func mustEncode(t *testing.T, val interface{}) []byte {
+	t.Helper()
+	enc, err := rlp.EncodeToBytes(val)
+	if err != nil {
+		t.Fatalf("failed to encode %T: %v", val, err)
+	}
+	return enc
+}
+
+// Tests that nodes with different scheduled forks share the discovery topic until one of the forks
+// activates, and that the latest topic is the one of the last scheduled fork.
+func TestDiscoveryTopic(t *testing.T) {
+	genesis := libcommon.Hash{1}
+	heightForks, timeForks := []uint64{10}, []uint64{1000}
+
+	current := DiscoveryTopic(heightForks, timeForks, genesis, 20, 500)
+	if other := DiscoveryTopic(heightForks, []uint64{1000, 2000}, genesis, 20, 500); other != current {
+		t.Fatalf("topic differs for a node scheduling another fork: %v != %v", other, current)
+	}
+	if before := DiscoveryTopic(heightForks, timeForks, genesis, 5, 500); before == current {
+		t.Fatal("topic should change when a fork activates")
+	}
+	if other := DiscoveryTopic(heightForks, timeForks, libcommon.Hash{2}, 20, 500); other == current {
+		t.Fatal("topic should differ for another genesis")
+	}
+	latest := LatestDiscoveryTopic(heightForks, timeForks, genesis)
+	if latest == current {
+		t.Fatal("latest topic should include the scheduled time fork")
+	}
+	if after := DiscoveryTopic(heightForks, timeForks, genesis, 20, 1500); after != latest {
+		t.Fatalf("topic after the last fork differs from the latest topic: %v != %v", after, latest)
+	}
+}
@@ -930,7 +930,7 @@ Loop:
 			}
 
 			// MA applystate
-			if err := rs.ApplyState4(ctx, txTask); err != nil {
+			if err := rs.ApplyState4(ctx, txTask, accumulator); err != nil {
 				return err
 			}
 
@@ -1296,7 +1296,7 @@ func processResultQueue(ctx context.Context, in *state.QueueWithRetry, rws *stat
 
 		if txTask.Final {
 			rs.SetTxNum(txTask.TxNum, txTask.BlockNum)
-			err := rs.ApplyState4(ctx, txTask)
+			err := rs.ApplyState4(ctx, txTask, nil)
 			if err != nil {
 				return outputTxNum, conflicts, triggers, processedBlockNum, false, fmt.Errorf("StateV3.Apply: %w", err)
 			}
@@ -163,8 +163,7 @@ func BorHeimdallForward(
 			if err := s.state.UnwindTo(unwindPoint, ForkReset(hash), tx); err != nil {
 				return err
 			}
-			var reset uint64 = 0
-			finality.BorMilestoneRewind.Store(&reset)
+			finality.ResetMilestoneRewind()
 			return fmt.Errorf("verification failed for header %d: %x", headNumber, header.Hash())
 		}
 	}
@@ -49,7 +49,7 @@ func apply(tx kv.RwTx, logger log.Logger) (beforeBlock, afterBlock testGenHook,
 			}
 			txTask.AccountPrevs, txTask.AccountDels, txTask.StoragePrevs, txTask.CodePrevs = stateWriter.PrevAndDels()
 			rs.SetTxNum(txTask.TxNum, txTask.BlockNum)
-			if err := rs.ApplyState4(context.Background(), txTask); err != nil {
+			if err := rs.ApplyState4(context.Background(), txTask, nil); err != nil {
 				panic(err)
 			}
 			_, err := rs.Domains().ComputeCommitment(context.Background(), true, txTask.BlockNum, "")
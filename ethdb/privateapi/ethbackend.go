@@ -47,7 +47,9 @@ import (
 // 3.1.0 - add Subscribe to logs
 // 3.2.0 - add EngineGetBlobsBundleV1
 // 3.3.0 - merge EngineGetBlobsBundleV1 into EngineGetPayload
-var EthBackendAPIVersion = &types2.VersionReply{Major: 3, Minor: 3, Patch: 0}
+// 3.4.0 - add PeerScores
+// 3.5.0 - add RemovePeer, AddTrustedPeer, RemoveTrustedPeer, SetMaxPeers and PeerEvents
+var EthBackendAPIVersion = &types2.VersionReply{Major: 3, Minor: 5, Patch: 0}
 
 type EthBackendServer struct {
 	remote.UnimplementedETHBACKENDServer // must be embedded to have forward compatible implementations.
@@ -70,6 +72,12 @@ type EthBackend interface {
 	NodesInfo(limit int) (*remote.NodesInfoReply, error)
 	Peers(ctx context.Context) (*remote.PeersReply, error)
 	AddPeer(ctx context.Context, url *remote.AddPeerRequest) (*remote.AddPeerReply, error)
+	RemovePeer(ctx context.Context, url *remote.RemovePeerRequest) (*remote.RemovePeerReply, error)
+	AddTrustedPeer(ctx context.Context, url *remote.AddPeerRequest) (*remote.AddPeerReply, error)
+	RemoveTrustedPeer(ctx context.Context, url *remote.RemovePeerRequest) (*remote.RemovePeerReply, error)
+	SetMaxPeers(ctx context.Context, req *remote.SetMaxPeersRequest) (*remote.SetMaxPeersReply, error)
+	PeerScores(ctx context.Context) (*remote.PeerScoresReply, error)
+	PeerEvents(ctx context.Context, send func(*remote.PeerEvent) error) error
 }
 
 func NewEthBackendServer(ctx context.Context, eth EthBackend, db kv.RwDB, events *shards.Events, blockReader services.FullBlockReader,
@@ -328,6 +336,30 @@ func (s *EthBackendServer) AddPeer(ctx context.Context, req *remote.AddPeerReque
 	return s.eth.AddPeer(ctx, req)
 }
 
+func (s *EthBackendServer) RemovePeer(ctx context.Context, req *remote.RemovePeerRequest) (*remote.RemovePeerReply, error) {
+	return s.eth.RemovePeer(ctx, req)
+}
+
+func (s *EthBackendServer) AddTrustedPeer(ctx context.Context, req *remote.AddPeerRequest) (*remote.AddPeerReply, error) {
+	return s.eth.AddTrustedPeer(ctx, req)
+}
+
+func (s *EthBackendServer) RemoveTrustedPeer(ctx context.Context, req *remote.RemovePeerRequest) (*remote.RemovePeerReply, error) {
+	return s.eth.RemoveTrustedPeer(ctx, req)
+}
+
+func (s *EthBackendServer) SetMaxPeers(ctx context.Context, req *remote.SetMaxPeersRequest) (*remote.SetMaxPeersReply, error) {
+	return s.eth.SetMaxPeers(ctx, req)
+}
+
+func (s *EthBackendServer) PeerScores(ctx context.Context, _ *emptypb.Empty) (*remote.PeerScoresReply, error) {
+	return s.eth.PeerScores(ctx)
+}
+
+func (s *EthBackendServer) PeerEvents(_ *remote.PeerEventsRequest, server remote.ETHBACKEND_PeerEventsServer) error {
+	return s.eth.PeerEvents(server.Context(), server.Send)
+}
+
 func (s *EthBackendServer) SubscribeLogs(server remote.ETHBACKEND_SubscribeLogsServer) (err error) {
 	if s.logsFilter != nil {
 		return s.logsFilter.subscribeLogs(server)
@@ -50,6 +50,11 @@ const (
 	// Endpoint resolution is throttled with bounded backoff.
 	initialResolveDelay = 60 * time.Second
 	maxResolveDelay     = time.Hour
+
+	// Dynamic dial candidates with a reputation score at or below this value are
+	// never dialed. Candidates with a score between zero and this value are skipped
+	// with a probability proportional to their score.
+	dialRejectScore = -20
 )
 
 // NodeDialer is used to connect to nodes in the network, typically by using
@@ -62,6 +67,10 @@ type nodeResolver interface {
 	Resolve(*enode.Node) *enode.Node
 }
 
+type reputationSource interface {
+	Reputation(enode.ID) enode.Reputation
+}
+
 // tcpDialer implements NodeDialer using real TCP connections.
 type tcpDialer struct {
 	d *net.Dialer
@@ -83,6 +92,7 @@ var (
 	errRecentlyDialed   = errors.New("recently dialed")
 	errNotWhitelisted   = errors.New("not contained in netrestrict whitelist")
 	errNoPort           = errors.New("node does not provide TCP port")
+	errLowReputation    = errors.New("low reputation")
 )
 
 // dialer creates outbound connections and submits them into Server.
@@ -107,6 +117,7 @@ type dialScheduler struct {
 	remStaticCh chan *enode.Node
 	addPeerCh   chan *conn
 	remPeerCh   chan *conn
+	maxPeersCh  chan int
 
 	subProtocolVersion uint
 
@@ -141,6 +152,7 @@ type dialConfig struct {
 	maxActiveDials int              // maximum number of active dials
 	netRestrict    *netutil.Netlist // IP whitelist, disabled if nil
 	resolver       nodeResolver
+	reputation     reputationSource // prefers well-behaved peers, disabled if nil
 	dialer         NodeDialer
 	log            log.Logger
 	clock          mclock.Clock
@@ -178,6 +190,7 @@ func newDialScheduler(config dialConfig, it enode.Iterator, setupFunc dialSetupF
 		remStaticCh: make(chan *enode.Node),
 		addPeerCh:   make(chan *conn),
 		remPeerCh:   make(chan *conn),
+		maxPeersCh:  make(chan int),
 
 		subProtocolVersion: subProtocolVersion,
 		errors:             map[string]uint{},
@@ -228,6 +241,14 @@ func (d *dialScheduler) peerRemoved(c *conn) {
 	}
 }
 
+// setMaxDialPeers changes the maximum number of dialed peers.
+func (d *dialScheduler) setMaxDialPeers(n int) {
+	select {
+	case d.maxPeersCh <- n:
+	case <-d.ctx.Done():
+	}
+}
+
 // loop is the main loop of the dialer.
 func (d *dialScheduler) loop(it enode.Iterator) {
 	defer debug.LogPanic()
@@ -262,6 +283,8 @@ loop:
 		case node := <-nodesCh:
 			if err := d.checkDial(node); err != nil {
 				d.log.Trace("Discarding dial candidate", "id", node.ID(), "ip", node.IP(), "reason", err)
+			} else if err := d.checkReputation(node); err != nil {
+				d.log.Trace("Discarding dial candidate", "id", node.ID(), "ip", node.IP(), "reason", err)
 			} else {
 				d.startDial(newDialTask(node, dynDialedConn))
 			}
@@ -292,6 +315,9 @@ loop:
 			delete(d.peers, c.node.ID())
 			d.updateStaticPool(c.node.ID())
 
+		case n := <-d.maxPeersCh:
+			d.maxDialPeers = n
+
 		case node := <-d.addStaticCh:
 			id := node.ID()
 			_, exists := d.static[id]
@@ -426,6 +452,23 @@ func (d *dialScheduler) checkDial(n *enode.Node) error {
 	return nil
 }
 
+// checkReputation returns an error if dynamic dial candidate n should be skipped
+// in favour of better-behaved nodes. Nodes with a bad but not hopeless score are
+// skipped randomly, so that they are retried as their reputation recovers.
+func (d *dialScheduler) checkReputation(n *enode.Node) error {
+	if d.reputation == nil {
+		return nil
+	}
+	score := d.reputation.Reputation(n.ID()).Score()
+	if score >= 0 {
+		return nil
+	}
+	if score <= dialRejectScore || d.rand.Float64() < score/dialRejectScore {
+		return errLowReputation
+	}
+	return nil
+}
+
 // startStaticDials starts n static dial tasks.
 func (d *dialScheduler) startStaticDials() {
 	for len(d.staticPool) > 0 {
@@ -119,6 +119,47 @@ func TestDialSchedDynDial(t *testing.T) {
 	})
 }
 
+// This test checks that changing the peer limit at runtime opens and closes dial slots.
+func TestDialSchedSetMaxDialPeers(t *testing.T) {
+	t.Parallel()
+
+	config := dialConfig{
+		maxActiveDials: 5,
+		maxDialPeers:   1,
+	}
+	runDialTest(t, config, []dialTestRound{
+		// The only dial slot is taken, discovered nodes are not dialed.
+		{
+			peersAdded: []*conn{
+				{flags: dynDialedConn, node: newNode(uintID(0x00), "")},
+			},
+			discovered: []*enode.Node{
+				newNode(uintID(0x01), "127.0.0.1:30303"),
+				newNode(uintID(0x02), "127.0.0.1:30303"),
+				newNode(uintID(0x03), "127.0.0.1:30303"),
+			},
+		},
+		// Raising the limit creates 4 dial slots.
+		{
+			update: func(d *dialScheduler) { d.setMaxDialPeers(3) },
+			wantNewDials: []*enode.Node{
+				newNode(uintID(0x01), "127.0.0.1:30303"),
+				newNode(uintID(0x02), "127.0.0.1:30303"),
+				newNode(uintID(0x03), "127.0.0.1:30303"),
+			},
+		},
+		// Lowering it again stops dialing while the connected peers are kept.
+		{
+			update:    func(d *dialScheduler) { d.setMaxDialPeers(1) },
+			succeeded: []enode.ID{uintID(0x01)},
+			failed:    []enode.ID{uintID(0x02), uintID(0x03)},
+			discovered: []*enode.Node{
+				newNode(uintID(0x04), "127.0.0.1:30303"),
+			},
+		},
+	})
+}
+
 // This test checks that candidates that do not match the netrestrict list are not dialed.
 func TestDialSchedNetRestrict(t *testing.T) {
 	t.Parallel()
@@ -156,6 +197,38 @@ func TestDialSchedNetRestrict(t *testing.T) {
 }
 
 // This test checks that static dials work and obey the limits.
+// This test checks that dynamic dials skip nodes with a bad reputation.
+func TestDialSchedReputation(t *testing.T) {
+	t.Parallel()
+
+	nodes := []*enode.Node{
+		newNode(uintID(0x01), "127.0.0.1:30303"),
+		newNode(uintID(0x02), "127.0.0.2:30303"),
+		newNode(uintID(0x03), "127.0.0.3:30303"),
+		newNode(uintID(0x04), "127.0.0.4:30303"),
+	}
+	config := dialConfig{
+		reputation: dialTestReputation{
+			nodes[1].ID(): {InvalidBlock: 3},
+			nodes[3].ID(): {Useless: 20},
+		},
+		maxActiveDials: 10,
+		maxDialPeers:   10,
+	}
+	runDialTest(t, config, []dialTestRound{
+		{
+			discovered:   nodes,
+			wantNewDials: []*enode.Node{nodes[0], nodes[2]},
+		},
+		{
+			succeeded: []enode.ID{
+				nodes[0].ID(),
+				nodes[2].ID(),
+			},
+		},
+	})
+}
+
 func TestDialSchedStaticDial(t *testing.T) {
 	t.Parallel()
 
@@ -651,3 +724,10 @@ func (t *dialTestResolver) Resolve(n *enode.Node) *enode.Node {
 	t.calls = append(t.calls, n.ID())
 	return t.answers[n.ID()]
 }
+
+// dialTestReputation is a reputationSource backed by a map.
+type dialTestReputation map[enode.ID]enode.Reputation
+
+func (r dialTestReputation) Reputation(id enode.ID) enode.Reputation {
+	return r[id]
+}
@@ -0,0 +1,519 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package discover
+
+import (
+	"context"
+	"crypto/hmac"
+	crand "crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/erigontech/erigon/common/mclock"
+	"github.com/erigontech/erigon/p2p/discover/v5wire"
+	"github.com/erigontech/erigon/p2p/enode"
+	"github.com/erigontech/erigon/p2p/enr"
+	"github.com/erigontech/erigon/p2p/netutil"
+	"github.com/erigontech/erigon/rlp"
+)
+
+const (
+	topicAdLifetime       = 15 * time.Minute // how long a registrar keeps an ad
+	topicQueueLimit       = 50               // max ads per topic in the topic table
+	topicTableLimit       = 500              // max ads in the topic table
+	topicTicketValidity   = 10 * time.Second // time window in which a ticket can be used after its waiting time
+	topicRegistrarCount   = 8                // number of nodes close to the topic that hold our ad
+	topicRegisterInterval = topicAdLifetime / 3
+	topicRegisterRetry    = 30 * time.Second // re-registration interval when fewer than topicRegistrarCount ads were placed
+	topicSearchInterval   = 30 * time.Second // pause between searches that found no new nodes
+	topicQueryResultLimit = totalNodesResponseLimit * nodesResponseItemLimit
+
+	topicIPLimit, topicSubnet = 5, 24 // at most 5 ads per topic from the same /24
+)
+
+var (
+	errTopicTicketMAC     = errors.New("invalid ticket MAC")
+	errTopicTicketNode    = errors.New("ticket issued to different node")
+	errTopicTicketEarly   = errors.New("ticket used before waiting time")
+	errTopicTicketExpired = errors.New("ticket expired")
+	errTopicTicketWait    = errors.New("ticket waiting time too long")
+	errTopicRejected      = errors.New("topic registration rejected")
+)
+
+// Topic identifies a set of nodes advertising themselves under a common name,
+// e.g. nodes on the same chain. Ads for a topic are kept by the registrar nodes
+// whose IDs are closest to the topic, so that searchers can find them with a
+// regular DHT lookup.
+type Topic [32]byte
+
+func (t Topic) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+// topicTicket is issued by registrars in response to REQUESTTICKET. It tells the
+// registrant how long it must wait before its ad can be placed, and is authenticated
+// with a MAC so that registrars don't need to keep state about issued tickets.
+type topicTicket struct {
+	Topic  Topic
+	Node   enode.ID
+	Issued uint64 // registrar clock time when the ticket was issued
+	Wait   uint64 // waiting time in nanoseconds
+	MAC    []byte
+}
+
+// decodeTopicTicket decodes a ticket without verifying its MAC.
+func decodeTopicTicket(blob []byte) (*topicTicket, error) {
+	var tk topicTicket
+	if err := rlp.DecodeBytes(blob, &tk); err != nil {
+		return nil, err
+	}
+	return &tk, nil
+}
+
+// topicAd is a single registration in the topic table.
+type topicAd struct {
+	node    *enode.Node
+	ip      net.IP // address the ad was registered from
+	expires mclock.AbsTime
+}
+
+// topicTable holds the topic ads registered at the local node.
+type topicTable struct {
+	mu     sync.Mutex
+	key    []byte
+	queues map[Topic][]topicAd               // ordered by expiration time
+	ips    map[Topic]*netutil.DistinctNetSet // addresses the ads of each queue were registered from
+	total  int
+}
+
+func newTopicTable() *topicTable {
+	key := make([]byte, 32)
+	crand.Read(key)
+	return &topicTable{key: key, queues: make(map[Topic][]topicAd), ips: make(map[Topic]*netutil.DistinctNetSet)}
+}
+
+// expire removes all ads that have expired at time now.
+func (tt *topicTable) expire(now mclock.AbsTime) {
+	for topic, queue := range tt.queues {
+		i := 0
+		for i < len(queue) && queue[i].expires <= now {
+			tt.removeIP(topic, queue[i].ip)
+			i++
+		}
+		tt.total -= i
+		if i == len(queue) {
+			delete(tt.queues, topic)
+			delete(tt.ips, topic)
+		} else if i > 0 {
+			tt.queues[topic] = queue[i:]
+		}
+	}
+}
+
+// addIP counts an ad registered from ip against the subnet limit of topic.
+func (tt *topicTable) addIP(topic Topic, ip net.IP) bool {
+	if netutil.IsLAN(ip) {
+		return true
+	}
+	ips := tt.ips[topic]
+	if ips == nil {
+		ips = &netutil.DistinctNetSet{Subnet: topicSubnet, Limit: topicIPLimit}
+		tt.ips[topic] = ips
+	}
+	return ips.Add(ip)
+}
+
+func (tt *topicTable) removeIP(topic Topic, ip net.IP) {
+	if ips := tt.ips[topic]; ips != nil && !netutil.IsLAN(ip) {
+		ips.Remove(ip)
+	}
+}
+
+// waitTime returns how long node id has to wait before it can register for topic.
+// Nodes refreshing their ad wait like new registrants, so that the ads of a full
+// queue are taken over by the nodes waiting for it.
+func (tt *topicTable) waitTime(topic Topic, id enode.ID, now mclock.AbsTime) time.Duration {
+	queue := tt.queues[topic]
+	var next mclock.AbsTime
+	switch {
+	case len(queue) >= topicQueueLimit:
+		next = queue[0].expires
+	case tt.total >= topicTableLimit:
+		for _, q := range tt.queues {
+			if next == 0 || q[0].expires < next {
+				next = q[0].expires
+			}
+		}
+	default:
+		return 0
+	}
+	return time.Duration(next - now)
+}
+
+func (tt *topicTable) ticketMAC(tk *topicTicket) []byte {
+	content, _ := rlp.EncodeToBytes([]interface{}{tk.Topic, tk.Node, tk.Issued, tk.Wait})
+	mac := hmac.New(sha256.New, tt.key)
+	mac.Write(content)
+	return mac.Sum(nil)
+}
+
+// issueTicket creates a registration ticket for node id.
+func (tt *topicTable) issueTicket(topic Topic, id enode.ID, now mclock.AbsTime) []byte {
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+
+	tt.expire(now)
+	tk := &topicTicket{
+		Topic:  topic,
+		Node:   id,
+		Issued: uint64(now),
+		Wait:   uint64(tt.waitTime(topic, id, now)),
+	}
+	tk.MAC = tt.ticketMAC(tk)
+	blob, _ := rlp.EncodeToBytes(tk)
+	return blob
+}
+
+// checkTicket verifies that the ticket was issued by this table to node id and
+// that it can be used at time now.
+func (tt *topicTable) checkTicket(blob []byte, id enode.ID, now mclock.AbsTime) (Topic, error) {
+	tk, err := decodeTopicTicket(blob)
+	if err != nil {
+		return Topic{}, err
+	}
+	if !hmac.Equal(tk.MAC, tt.ticketMAC(tk)) {
+		return Topic{}, errTopicTicketMAC
+	}
+	if tk.Node != id {
+		return Topic{}, errTopicTicketNode
+	}
+	validFrom := mclock.AbsTime(tk.Issued).Add(time.Duration(tk.Wait))
+	if now < validFrom {
+		return Topic{}, errTopicTicketEarly
+	}
+	if now > validFrom.Add(topicTicketValidity) {
+		return Topic{}, errTopicTicketExpired
+	}
+	return tk.Topic, nil
+}
+
+// register adds an ad for n, registered from ip. It returns false if there is no
+// space for the ad, or if the subnet of ip already holds too many ads for topic.
+func (tt *topicTable) register(topic Topic, n *enode.Node, ip net.IP, now mclock.AbsTime) bool {
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+
+	tt.expire(now)
+	queue := tt.queues[topic]
+	ad := topicAd{node: n, ip: ip, expires: now.Add(topicAdLifetime)}
+	if i := slices.IndexFunc(queue, func(ad topicAd) bool { return ad.node.ID() == n.ID() }); i >= 0 {
+		// Refresh existing ad, moving it to the back of the queue.
+		tt.removeIP(topic, queue[i].ip)
+		if !tt.addIP(topic, ip) {
+			tt.addIP(topic, queue[i].ip)
+			return false
+		}
+		queue = append(slices.Delete(queue, i, i+1), ad)
+		tt.queues[topic] = queue
+		return true
+	}
+	if len(queue) >= topicQueueLimit || tt.total >= topicTableLimit {
+		return false
+	}
+	if !tt.addIP(topic, ip) {
+		return false
+	}
+	tt.queues[topic] = append(queue, ad)
+	tt.total++
+	return true
+}
+
+// nodes returns up to limit nodes registered for topic, most recent ads first.
+func (tt *topicTable) nodes(topic Topic, now mclock.AbsTime, limit int) []*enode.Node {
+	tt.mu.Lock()
+	defer tt.mu.Unlock()
+
+	tt.expire(now)
+	queue := tt.queues[topic]
+	nodes := make([]*enode.Node, 0, min(limit, len(queue)))
+	for i := len(queue) - 1; i >= 0 && len(nodes) < limit; i-- {
+		nodes = append(nodes, queue[i].node)
+	}
+	return nodes
+}
+
+// RegisterTopic advertises the local node under the given topic until ctx is
+// canceled or the transport is closed. Ads are placed at the nodes closest to
+// the topic and refreshed before they expire.
+func (t *UDPv5) RegisterTopic(ctx context.Context, topic Topic) {
+	for {
+		interval := topicRegisterInterval
+		if placed := t.registerTopicOnce(ctx, topic); placed < topicRegistrarCount {
+			interval = topicRegisterRetry
+		}
+		select {
+		case <-t.clock.After(interval):
+		case <-ctx.Done():
+			return
+		case <-t.closeCtx.Done():
+			return
+		}
+	}
+}
+
+// registerTopicOnce places ads for topic at the current registrars of the topic.
+// It returns the number of registrars that accepted the ad.
+func (t *UDPv5) registerTopicOnce(ctx context.Context, topic Topic) int {
+	var (
+		wg     sync.WaitGroup
+		placed atomic.Int32
+	)
+	for _, n := range t.topicRegistrars(topic) {
+		wg.Add(1)
+		go func(n *enode.Node) {
+			defer wg.Done()
+			if err := t.placeTopicAd(ctx, n, topic); err != nil {
+				t.log.Trace("Topic registration failed", "topic", topic, "id", n.ID(), "err", err)
+				return
+			}
+			placed.Add(1)
+		}(n)
+	}
+	wg.Wait()
+	return int(placed.Load())
+}
+
+// topicRegistrars returns the nodes closest to topic.
+func (t *UDPv5) topicRegistrars(topic Topic) []*enode.Node {
+	nodes := t.Lookup(enode.ID(topic))
+	if len(nodes) > topicRegistrarCount {
+		nodes = nodes[:topicRegistrarCount]
+	}
+	return nodes
+}
+
+// placeTopicAd obtains a ticket from registrar n and uses it to register the
+// local node for topic.
+func (t *UDPv5) placeTopicAd(ctx context.Context, n *enode.Node, topic Topic) error {
+	ticket, wait, err := t.requestTicket(n, topic)
+	if err != nil {
+		return err
+	}
+	if wait > topicAdLifetime {
+		return errTopicTicketWait
+	}
+	if wait > 0 {
+		select {
+		case <-t.clock.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.closeCtx.Done():
+			return errClosed
+		}
+	}
+	ok, err := t.regtopic(n, ticket)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errTopicRejected
+	}
+	return nil
+}
+
+// requestTicket calls REQUESTTICKET on n. It returns the ticket and the time to
+// wait before it can be used.
+func (t *UDPv5) requestTicket(n *enode.Node, topic Topic) ([]byte, time.Duration, error) {
+	resp := t.call(n, v5wire.TicketMsg, &v5wire.RequestTicket{Topic: topic[:]})
+	defer t.callDone(resp)
+
+	select {
+	case p := <-resp.ch:
+		ticket := p.(*v5wire.Ticket).Ticket
+		tk, err := decodeTopicTicket(ticket)
+		if err != nil {
+			return nil, 0, err
+		}
+		return ticket, time.Duration(tk.Wait), nil
+	case err := <-resp.err:
+		return nil, 0, err
+	}
+}
+
+// regtopic calls REGTOPIC on n.
+func (t *UDPv5) regtopic(n *enode.Node, ticket []byte) (bool, error) {
+	resp := t.call(n, v5wire.RegconfirmationMsg, &v5wire.Regtopic{Ticket: ticket, ENR: t.Self().Record()})
+	defer t.callDone(resp)
+
+	select {
+	case p := <-resp.ch:
+		return p.(*v5wire.Regconfirmation).Registered, nil
+	case err := <-resp.err:
+		return false, err
+	}
+}
+
+// topicQuery calls TOPICQUERY on n and waits for responses.
+func (t *UDPv5) topicQuery(n *enode.Node, topic Topic) ([]*enode.Node, error) {
+	resp := t.call(n, v5wire.NodesMsg, &v5wire.TopicQuery{Topic: topic[:]})
+	return t.waitForNodes(resp, nil)
+}
+
+// handleRequestTicket issues a registration ticket to the requester.
+func (t *UDPv5) handleRequestTicket(p *v5wire.RequestTicket, fromID enode.ID, fromAddr *net.UDPAddr) {
+	var topic Topic
+	if len(p.Topic) != len(topic) {
+		t.log.Trace("Invalid topic in "+p.Name(), "id", fromID, "addr", fromAddr)
+		return
+	}
+	copy(topic[:], p.Topic)
+	ticket := t.topics.issueTicket(topic, fromID, t.clock.Now())
+	t.sendResponse(fromID, fromAddr, &v5wire.Ticket{ReqID: p.ReqID, Ticket: ticket}) //nolint:errcheck
+}
+
+// handleRegtopic registers the requester if it presents a valid ticket.
+func (t *UDPv5) handleRegtopic(p *v5wire.Regtopic, fromID enode.ID, fromAddr *net.UDPAddr) {
+	now := t.clock.Now()
+	registered := false
+	topic, err := t.topics.checkTicket(p.Ticket, fromID, now)
+	if err == nil {
+		var n *enode.Node
+		if n, err = t.verifyTopicAdvertiser(p.ENR, fromID, fromAddr); err == nil {
+			registered = t.topics.register(topic, n, fromAddr.IP, now)
+		}
+	}
+	if err != nil {
+		t.log.Trace("Rejected "+p.Name(), "id", fromID, "addr", fromAddr, "err", err)
+	}
+	t.sendResponse(fromID, fromAddr, &v5wire.Regconfirmation{ReqID: p.ReqID, Registered: registered}) //nolint:errcheck
+}
+
+// verifyTopicAdvertiser checks the record sent in REGTOPIC.
+func (t *UDPv5) verifyTopicAdvertiser(r *enr.Record, fromID enode.ID, fromAddr *net.UDPAddr) (*enode.Node, error) {
+	if r == nil {
+		return nil, errors.New("missing record")
+	}
+	n, err := enode.New(t.validSchemes, r)
+	if err != nil {
+		return nil, err
+	}
+	if n.ID() != fromID {
+		return nil, errors.New("record of different node")
+	}
+	if err := netutil.CheckRelayIP(fromAddr.IP, n.IP()); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// handleTopicQuery returns the nodes registered for a topic.
+func (t *UDPv5) handleTopicQuery(p *v5wire.TopicQuery, fromID enode.ID, fromAddr *net.UDPAddr) {
+	var nodes []*enode.Node
+	if len(p.Topic) == len(Topic{}) {
+		for _, n := range t.topics.nodes(Topic(p.Topic), t.clock.Now(), topicQueryResultLimit) {
+			if netutil.CheckRelayIP(fromAddr.IP, n.IP()) == nil {
+				nodes = append(nodes, n)
+			}
+		}
+	}
+	for _, resp := range packNodes(p.ReqID, nodes) {
+		t.sendResponse(fromID, fromAddr, resp) //nolint:errcheck
+	}
+}
+
+// TopicNodes returns an iterator over the nodes advertising the given topic.
+// The iterator keeps querying the registrars of the topic until it is closed.
+func (t *UDPv5) TopicNodes(topic Topic) enode.Iterator {
+	ctx, cancel := context.WithCancel(t.closeCtx)
+	return &topicIterator{t: t, topic: topic, ctx: ctx, cancel: cancel, seen: make(map[enode.ID]struct{})}
+}
+
+// topicIterator yields the results of TOPICQUERY calls against topic registrars.
+type topicIterator struct {
+	t      *UDPv5
+	topic  Topic
+	ctx    context.Context
+	cancel context.CancelFunc
+	seen   map[enode.ID]struct{}
+	buffer []*enode.Node
+}
+
+// Node returns the current node.
+func (it *topicIterator) Node() *enode.Node {
+	if len(it.buffer) == 0 {
+		return nil
+	}
+	return it.buffer[0]
+}
+
+// Next moves to the next node.
+func (it *topicIterator) Next() bool {
+	if len(it.buffer) > 0 {
+		it.buffer = it.buffer[1:]
+	}
+	for len(it.buffer) == 0 {
+		if it.ctx.Err() != nil {
+			it.buffer = nil
+			return false
+		}
+		if it.search() {
+			continue
+		}
+		// Nothing new was found. Wait before searching again, and forget the
+		// nodes seen so far so they are yielded again when they're still advertised.
+		select {
+		case <-it.t.clock.After(topicSearchInterval):
+		case <-it.ctx.Done():
+		}
+		clear(it.seen)
+	}
+	return true
+}
+
+// search queries the topic registrars and adds unseen nodes to the buffer.
+// It returns false if no new nodes were found.
+func (it *topicIterator) search() bool {
+	self := it.t.Self().ID()
+	for _, r := range it.t.topicRegistrars(it.topic) {
+		if it.ctx.Err() != nil {
+			break
+		}
+		nodes, err := it.t.topicQuery(r, it.topic)
+		if err != nil {
+			it.t.log.Trace("Topic query failed", "topic", it.topic, "id", r.ID(), "err", err)
+		}
+		for _, n := range nodes {
+			if _, ok := it.seen[n.ID()]; ok || n.ID() == self {
+				continue
+			}
+			it.seen[n.ID()] = struct{}{}
+			it.buffer = append(it.buffer, n)
+		}
+	}
+	return len(it.buffer) > 0
+}
+
+// Close ends the iterator.
+func (it *topicIterator) Close() {
+	it.cancel()
+}
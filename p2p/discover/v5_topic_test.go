@@ -0,0 +1,210 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package discover
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/common/mclock"
+	"github.com/erigontech/erigon/crypto"
+	"github.com/erigontech/erigon/p2p/enode"
+	"github.com/erigontech/erigon/p2p/enr"
+	"github.com/erigontech/erigon/p2p/simulations/pipes"
+	"github.com/erigontech/erigon/rlp"
+)
+
+func TestTopicTableLimits(t *testing.T) {
+	tt := newTopicTable()
+	topic := Topic{1}
+	now := mclock.AbsTime(0)
+
+	nodes := make([]*enode.Node, topicQueueLimit+1)
+	for i := range nodes {
+		nodes[i] = enode.SignNull(new(enr.Record), enode.ID{byte(i), 1})
+	}
+	for i, n := range nodes[:topicQueueLimit] {
+		require.Zero(t, tt.waitTime(topic, n.ID(), now))
+		require.True(t, tt.register(topic, n, topicTestIP(i), now), "register %d", i)
+		now = now.Add(time.Second)
+	}
+
+	// The queue is full: new and registered nodes have to wait until the oldest ad expires.
+	last := nodes[topicQueueLimit]
+	require.Equal(t, topicAdLifetime-time.Duration(now), tt.waitTime(topic, last.ID(), now))
+	require.Equal(t, topicAdLifetime-time.Duration(now), tt.waitTime(topic, nodes[1].ID(), now))
+	require.False(t, tt.register(topic, last, topicTestIP(topicQueueLimit), now))
+
+	got := tt.nodes(topic, now, 3)
+	require.Equal(t, []*enode.Node{nodes[topicQueueLimit-1], nodes[topicQueueLimit-2], nodes[topicQueueLimit-3]}, got)
+
+	// After the first ad expires, there is space again.
+	now = mclock.AbsTime(topicAdLifetime)
+	require.Zero(t, tt.waitTime(topic, last.ID(), now))
+	require.True(t, tt.register(topic, last, topicTestIP(topicQueueLimit), now))
+	require.Len(t, tt.nodes(topic, now, topicQueueLimit+1), topicQueueLimit)
+	require.Empty(t, tt.nodes(Topic{2}, now, 10))
+}
+
+func TestTopicTableSubnetLimit(t *testing.T) {
+	tt := newTopicTable()
+	topic := Topic{1}
+	now := mclock.AbsTime(0)
+
+	nodes := make([]*enode.Node, topicIPLimit+1)
+	for i := range nodes {
+		nodes[i] = enode.SignNull(new(enr.Record), enode.ID{byte(i), 1})
+	}
+	subnetIP := func(i int) net.IP { return net.IP{1, 2, 3, byte(i)} }
+	for i, n := range nodes[:topicIPLimit] {
+		require.True(t, tt.register(topic, n, subnetIP(i), now), "register %d", i)
+	}
+
+	// The subnet holds as many ads as it may, other subnets and topics can still register.
+	last := nodes[topicIPLimit]
+	require.False(t, tt.register(topic, last, subnetIP(topicIPLimit), now))
+	require.True(t, tt.register(Topic{2}, last, subnetIP(topicIPLimit), now))
+	require.True(t, tt.register(topic, last, topicTestIP(1), now))
+
+	// A registered node can't refresh its ad from the full subnet either.
+	require.False(t, tt.register(topic, last, subnetIP(topicIPLimit), now))
+	require.True(t, tt.register(topic, nodes[0], subnetIP(0), now))
+
+	// Once the ads expire, the subnet can register again.
+	now = mclock.AbsTime(topicAdLifetime)
+	require.True(t, tt.register(topic, last, subnetIP(topicIPLimit), now))
+}
+
+// topicTestIP returns a public address in a subnet of its own.
+func topicTestIP(i int) net.IP {
+	return net.IP{11 + byte(i/256), byte(i), 0, 1}
+}
+
+func TestTopicTicket(t *testing.T) {
+	tt := newTopicTable()
+	topic := Topic{1}
+	id := enode.ID{1}
+	now := mclock.AbsTime(time.Hour)
+
+	ticket := tt.issueTicket(topic, id, now)
+	got, err := tt.checkTicket(ticket, id, now)
+	require.NoError(t, err)
+	require.Equal(t, topic, got)
+
+	_, err = tt.checkTicket(ticket, enode.ID{2}, now)
+	require.ErrorIs(t, err, errTopicTicketNode)
+	_, err = tt.checkTicket(ticket, id, now.Add(topicTicketValidity+1))
+	require.ErrorIs(t, err, errTopicTicketExpired)
+	_, err = newTopicTable().checkTicket(ticket, id, now)
+	require.ErrorIs(t, err, errTopicTicketMAC)
+
+	// Tickets with a waiting time can't be used early.
+	tk, err := decodeTopicTicket(ticket)
+	require.NoError(t, err)
+	tk.Wait = uint64(time.Minute)
+	tk.MAC = tt.ticketMAC(tk)
+	ticket, err = rlp.EncodeToBytes(tk)
+	require.NoError(t, err)
+	_, err = tt.checkTicket(ticket, id, now)
+	require.ErrorIs(t, err, errTopicTicketEarly)
+	_, err = tt.checkTicket(ticket, id, now.Add(time.Minute))
+	require.NoError(t, err)
+}
+
+// This test runs a small discv5 network on the in-memory packet network and checks
+// that nodes advertising a topic are found by searching for it.
+func TestUDPv5_topicSearch(t *testing.T) {
+	const (
+		networkSize = 8
+		advertisers = 3
+	)
+	logger := log.New()
+	network := pipes.NewPacketNetwork()
+	topic := Topic{0xfe, 0xed}
+
+	var nodes []*UDPv5
+	for i := 0; i < networkSize; i++ {
+		var boot []*enode.Node
+		if i > 0 {
+			boot = []*enode.Node{nodes[0].Self()}
+		}
+		nodes = append(nodes, startInMemoryV5(t, network, i, boot, logger))
+	}
+	for _, n := range nodes[1:] {
+		require.Eventually(t, func() bool { return n.tab.len() > 0 }, 10*time.Second, 10*time.Millisecond)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	want := make(map[enode.ID]bool)
+	for _, n := range nodes[1 : advertisers+1] {
+		want[n.Self().ID()] = true
+		go n.RegisterTopic(ctx, topic)
+	}
+	require.Eventually(t, func() bool {
+		registered := make(map[enode.ID]bool)
+		for _, n := range nodes {
+			for _, ad := range n.topics.nodes(topic, n.clock.Now(), topicQueueLimit) {
+				registered[ad.ID()] = true
+			}
+		}
+		return len(registered) == advertisers
+	}, 20*time.Second, 50*time.Millisecond)
+
+	it := nodes[networkSize-1].TopicNodes(topic)
+	time.AfterFunc(20*time.Second, it.Close)
+	found := make(map[enode.ID]bool)
+	for len(found) < advertisers && it.Next() {
+		id := it.Node().ID()
+		require.True(t, want[id], "found node %v which doesn't advertise the topic", id)
+		found[id] = true
+	}
+	it.Close()
+	require.Equal(t, want, found)
+}
+
+func startInMemoryV5(t *testing.T, network *pipes.PacketNetwork, index int, bootnodes []*enode.Node, logger log.Logger) *UDPv5 {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	db, err := enode.OpenDB(context.Background(), "", t.TempDir(), logger)
+	require.NoError(t, err)
+	t.Cleanup(db.Close)
+	ln := enode.NewLocalNode(db, key, logger)
+
+	addr := &net.UDPAddr{IP: net.IP{127, 0, 0, 1}, Port: 30000 + index}
+	conn, err := network.Listen(addr)
+	require.NoError(t, err)
+	ln.SetStaticIP(addr.IP)
+	ln.SetFallbackUDP(addr.Port)
+
+	cfg := Config{
+		PrivateKey: key,
+		Bootnodes:  bootnodes,
+		Log:        logger.New("node", fmt.Sprint(index)),
+	}
+	udp, err := ListenV5(disableLookupSlowdown(context.Background()), "test", conn, ln, cfg)
+	require.NoError(t, err)
+	t.Cleanup(udp.Close)
+	return udp
+}
@@ -94,8 +94,9 @@ func OpenDB(ctx context.Context, path string, tmpDir string, logger log.Logger)
 
 func bucketsConfig(_ kv.TableCfg) kv.TableCfg {
 	return kv.TableCfg{
-		kv.Inodes:      {},
-		kv.NodeRecords: {},
+		kv.Inodes:          {},
+		kv.NodeRecords:     {},
+		kv.PeerReputations: {},
 	}
 }
 
@@ -414,6 +415,7 @@ func (db *DB) expirer() {
 		select {
 		case <-tick.C:
 			db.expireNodes()
+			db.expireReputations(time.Now())
 		case <-db.ctx.Done():
 			return
 		}
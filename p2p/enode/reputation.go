@@ -0,0 +1,213 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package enode
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"time"
+
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+// ReputationEvent is a kind of peer misbehaviour recorded in the node database.
+type ReputationEvent int
+
+const (
+	ReputationUseless      ReputationEvent = iota // peer had nothing useful to offer
+	ReputationInvalidBlock                        // peer served an invalid block or header
+	ReputationTimeout                             // peer did not answer a request in time
+)
+
+const (
+	// reputationHalfLife is the time after which recorded events count half as much.
+	reputationHalfLife = 6 * time.Hour
+	// reputationForgetThreshold is the decayed counter value below which an event
+	// is considered forgotten. Records with all counters below it are expired.
+	reputationForgetThreshold = 0.01
+
+	reputationRecordSize = 4 * 8
+)
+
+// reputationWeights gives the contribution of a single event of each kind to the score.
+var reputationWeights = [...]float64{
+	ReputationUseless:      1,
+	ReputationInvalidBlock: 10,
+	ReputationTimeout:      2,
+}
+
+// Reputation holds the decaying misbehaviour counters of a remote node.
+type Reputation struct {
+	Useless      float64
+	InvalidBlock float64
+	Timeout      float64
+	Updated      time.Time // time of the last recorded event
+}
+
+// Score returns the weighted reputation score. Zero means nothing bad is known
+// about the node, more negative values mean worse behaviour.
+func (r Reputation) Score() float64 {
+	return -(r.Useless*reputationWeights[ReputationUseless] +
+		r.InvalidBlock*reputationWeights[ReputationInvalidBlock] +
+		r.Timeout*reputationWeights[ReputationTimeout])
+}
+
+// decay returns the counters as seen at the given time. Updated is kept, so
+// the result must not be stored without recording a new event.
+func (r Reputation) decay(now time.Time) Reputation {
+	if r.Updated.IsZero() || !now.After(r.Updated) {
+		return r
+	}
+	factor := math.Exp2(-float64(now.Sub(r.Updated)) / float64(reputationHalfLife))
+	r.Useless *= factor
+	r.InvalidBlock *= factor
+	r.Timeout *= factor
+	return r
+}
+
+func (r Reputation) forgotten() bool {
+	return r.Useless < reputationForgetThreshold &&
+		r.InvalidBlock < reputationForgetThreshold &&
+		r.Timeout < reputationForgetThreshold
+}
+
+func (r Reputation) encode() []byte {
+	blob := make([]byte, reputationRecordSize)
+	binary.BigEndian.PutUint64(blob[0:], math.Float64bits(r.Useless))
+	binary.BigEndian.PutUint64(blob[8:], math.Float64bits(r.InvalidBlock))
+	binary.BigEndian.PutUint64(blob[16:], math.Float64bits(r.Timeout))
+	binary.BigEndian.PutUint64(blob[24:], uint64(r.Updated.Unix()))
+	return blob
+}
+
+func decodeReputation(blob []byte) (Reputation, bool) {
+	if len(blob) != reputationRecordSize {
+		return Reputation{}, false
+	}
+	return Reputation{
+		Useless:      math.Float64frombits(binary.BigEndian.Uint64(blob[0:])),
+		InvalidBlock: math.Float64frombits(binary.BigEndian.Uint64(blob[8:])),
+		Timeout:      math.Float64frombits(binary.BigEndian.Uint64(blob[16:])),
+		Updated:      time.Unix(int64(binary.BigEndian.Uint64(blob[24:])), 0),
+	}, true
+}
+
+// Reputation retrieves the current reputation of a node. Nodes without any
+// recorded events have a zero Reputation.
+func (db *DB) Reputation(id ID) Reputation {
+	return db.reputationAt(id, time.Now())
+}
+
+func (db *DB) reputationAt(id ID, now time.Time) Reputation {
+	var rep Reputation
+	if err := db.kv.View(db.ctx, func(tx kv.Tx) error {
+		blob, err := tx.GetOne(kv.PeerReputations, id[:])
+		if err != nil {
+			return err
+		}
+		rep, _ = decodeReputation(blob)
+		return nil
+	}); err != nil {
+		return Reputation{}
+	}
+	return rep.decay(now)
+}
+
+// AddReputationEvent records a misbehaviour event for the given node and
+// returns its updated reputation.
+func (db *DB) AddReputationEvent(id ID, event ReputationEvent) (Reputation, error) {
+	return db.addReputationEventAt(id, event, time.Now())
+}
+
+func (db *DB) addReputationEventAt(id ID, event ReputationEvent, now time.Time) (Reputation, error) {
+	var rep Reputation
+	err := db.kv.Update(db.ctx, func(tx kv.RwTx) error {
+		blob, err := tx.GetOne(kv.PeerReputations, id[:])
+		if err != nil {
+			return err
+		}
+		rep, _ = decodeReputation(blob)
+		rep = rep.decay(now)
+		rep.Updated = now
+		switch event {
+		case ReputationUseless:
+			rep.Useless++
+		case ReputationInvalidBlock:
+			rep.InvalidBlock++
+		case ReputationTimeout:
+			rep.Timeout++
+		}
+		return tx.Put(kv.PeerReputations, id[:], rep.encode())
+	})
+	return rep, err
+}
+
+// Reputations returns the current reputation of all nodes with recorded events.
+func (db *DB) Reputations() (map[ID]Reputation, error) {
+	return db.reputationsAt(time.Now())
+}
+
+func (db *DB) reputationsAt(now time.Time) (map[ID]Reputation, error) {
+	reps := make(map[ID]Reputation)
+	if err := db.kv.View(db.ctx, func(tx kv.Tx) error {
+		return tx.ForEach(kv.PeerReputations, nil, func(k, v []byte) error {
+			rep, ok := decodeReputation(v)
+			if !ok || len(k) != len(ID{}) {
+				return nil
+			}
+			var id ID
+			copy(id[:], k)
+			reps[id] = rep.decay(now)
+			return nil
+		})
+	}); err != nil {
+		return nil, err
+	}
+	return reps, nil
+}
+
+// expireReputations deletes the records of nodes whose events have all decayed
+// below the forget threshold.
+func (db *DB) expireReputations(now time.Time) {
+	var toDelete [][]byte
+	if err := db.kv.View(db.ctx, func(tx kv.Tx) error {
+		return tx.ForEach(kv.PeerReputations, nil, func(k, v []byte) error {
+			if rep, ok := decodeReputation(v); !ok || rep.decay(now).forgotten() {
+				toDelete = append(toDelete, bytes.Clone(k))
+			}
+			return nil
+		})
+	}); err != nil {
+		log.Warn("nodeDB.expireReputations failed", "err", err)
+		return
+	}
+	if len(toDelete) == 0 {
+		return
+	}
+	if err := db.kv.Update(db.ctx, func(tx kv.RwTx) error {
+		for _, k := range toDelete {
+			if err := tx.Delete(kv.PeerReputations, k); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		log.Warn("nodeDB.expireReputations failed", "err", err)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package enode
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+func TestReputationDecay(t *testing.T) {
+	db, err := OpenDB(context.Background(), "", t.TempDir(), log.Root())
+	require.NoError(t, err)
+	defer db.Close()
+
+	var (
+		id    = ID{1}
+		start = time.Unix(1_700_000_000, 0)
+	)
+	require.Zero(t, db.reputationAt(id, start).Score())
+
+	_, err = db.addReputationEventAt(id, ReputationInvalidBlock, start)
+	require.NoError(t, err)
+	_, err = db.addReputationEventAt(id, ReputationTimeout, start)
+	require.NoError(t, err)
+	rep, err := db.addReputationEventAt(id, ReputationUseless, start)
+	require.NoError(t, err)
+	require.Equal(t, Reputation{Useless: 1, InvalidBlock: 1, Timeout: 1, Updated: start}, rep)
+	require.Equal(t, -13.0, rep.Score())
+
+	// Events lose half of their weight every half-life.
+	rep = db.reputationAt(id, start.Add(reputationHalfLife))
+	require.InDelta(t, 0.5, rep.InvalidBlock, 1e-9)
+	require.InDelta(t, -6.5, rep.Score(), 1e-9)
+
+	// New events are added on top of the decayed counters.
+	rep, err = db.addReputationEventAt(id, ReputationInvalidBlock, start.Add(2*reputationHalfLife))
+	require.NoError(t, err)
+	require.InDelta(t, 1.25, rep.InvalidBlock, 1e-9)
+	require.InDelta(t, 0.25, rep.Useless, 1e-9)
+
+	reps, err := db.reputationsAt(start.Add(2 * reputationHalfLife))
+	require.NoError(t, err)
+	require.Len(t, reps, 1)
+	require.InDelta(t, rep.Score(), reps[id].Score(), 1e-9)
+}
+
+func TestReputationExpiration(t *testing.T) {
+	db, err := OpenDB(context.Background(), "", t.TempDir(), log.Root())
+	require.NoError(t, err)
+	defer db.Close()
+
+	now := time.Unix(1_700_000_000, 0)
+	_, err = db.addReputationEventAt(ID{1}, ReputationTimeout, now.Add(-10*reputationHalfLife))
+	require.NoError(t, err)
+	_, err = db.addReputationEventAt(ID{2}, ReputationTimeout, now.Add(-time.Hour))
+	require.NoError(t, err)
+
+	db.expireReputations(now)
+
+	reps, err := db.reputationsAt(now)
+	require.NoError(t, err)
+	require.Len(t, reps, 1)
+	require.Contains(t, reps, ID{2})
+}
+
+func TestReputationPersistency(t *testing.T) {
+	root := t.TempDir()
+	path := filepath.Join(root, "database")
+
+	db, err := OpenDB(context.Background(), path, root, log.Root())
+	require.NoError(t, err)
+	_, err = db.AddReputationEvent(ID{1}, ReputationInvalidBlock)
+	require.NoError(t, err)
+	db.Close()
+
+	db, err = OpenDB(context.Background(), path, root, log.Root())
+	require.NoError(t, err)
+	defer db.Close()
+	require.InDelta(t, -10, db.Reputation(ID{1}).Score(), 0.01)
+}
@@ -94,6 +94,10 @@ const (
 	// PeerEventTypeMsgRecv is the type of event emitted when a
 	// message is received from a peer
 	PeerEventTypeMsgRecv PeerEventType = "msgrecv"
+
+	// PeerEventTypeMsgError is the type of event emitted when a peer
+	// is dropped because of an invalid message
+	PeerEventTypeMsgError PeerEventType = "msgerror"
 )
 
 // PeerEvent is an event emitted when peers are either added or dropped from
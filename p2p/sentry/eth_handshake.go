@@ -19,6 +19,8 @@ package sentry
 import (
 	"fmt"
 
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/direct"
 	"github.com/erigontech/erigon-lib/gointerfaces"
 	proto_sentry "github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
 	"github.com/erigontech/erigon/core/forkid"
@@ -26,18 +28,52 @@ import (
 	"github.com/erigontech/erigon/p2p"
 )
 
+// peerStatus is what the sentry keeps from the status message of a peer
+type peerStatus struct {
+	head       libcommon.Hash
+	blockRange *eth.BlockRangeUpdatePacket // nil before eth/69
+}
+
+// makeStatusPacket converts the status data of the core into the status message of the given eth protocol version
+func makeStatusPacket(status *proto_sentry.StatusData, version uint) eth.Packet {
+	genesisHash := gointerfaces.ConvertH256ToHash(status.ForkData.Genesis)
+	forkID := forkid.NewIDFromForks(status.ForkData.HeightForks, status.ForkData.TimeForks, genesisHash, status.MaxBlockHeight, status.MaxBlockTime)
+	head := gointerfaces.ConvertH256ToHash(status.BestHash)
+
+	if version >= direct.ETH69 {
+		return &eth.StatusPacket69{
+			ProtocolVersion: uint32(version),
+			NetworkID:       status.NetworkId,
+			Genesis:         genesisHash,
+			ForkID:          forkID,
+			EarliestBlock:   min(status.MinBlockHeight, status.MaxBlockHeight),
+			LatestBlock:     status.MaxBlockHeight,
+			LatestBlockHash: head,
+		}
+	}
+
+	return &eth.StatusPacket{
+		ProtocolVersion: uint32(version),
+		NetworkID:       status.NetworkId,
+		TD:              gointerfaces.ConvertH256ToUint256Int(status.TotalDifficulty).ToBig(),
+		Head:            head,
+		Genesis:         genesisHash,
+		ForkID:          forkID,
+	}
+}
+
 func readAndValidatePeerStatusMessage(
 	rw p2p.MsgReadWriter,
 	status *proto_sentry.StatusData,
 	version uint,
 	minVersion uint,
-) (*eth.StatusPacket, *p2p.PeerError) {
+) (*peerStatus, *p2p.PeerError) {
 	msg, err := rw.ReadMsg()
 	if err != nil {
 		return nil, p2p.NewPeerError(p2p.PeerErrorStatusReceive, p2p.DiscNetworkError, err, "readAndValidatePeerStatusMessage rw.ReadMsg error")
 	}
 
-	reply, err := tryDecodeStatusMessage(&msg)
+	reply, blockRange, err := tryDecodeStatusMessage(&msg, version)
 	msg.Discard()
 	if err != nil {
 		return nil, p2p.NewPeerError(p2p.PeerErrorStatusDecode, p2p.DiscProtocolError, err, "readAndValidatePeerStatusMessage tryDecodeStatusMessage error")
@@ -48,24 +84,50 @@ func readAndValidatePeerStatusMessage(
 		return nil, p2p.NewPeerError(p2p.PeerErrorStatusIncompatible, p2p.DiscUselessPeer, err, "readAndValidatePeerStatusMessage checkPeerStatusCompatibility error")
 	}
 
-	return reply, nil
+	return &peerStatus{head: reply.Head, blockRange: blockRange}, nil
 }
 
-func tryDecodeStatusMessage(msg *p2p.Msg) (*eth.StatusPacket, error) {
+// tryDecodeStatusMessage decodes the status message of the given eth protocol version. Since eth/69 the status
+// has no total difficulty, and it carries the block range the peer can serve.
+func tryDecodeStatusMessage(msg *p2p.Msg, version uint) (*eth.StatusPacket, *eth.BlockRangeUpdatePacket, error) {
 	if msg.Code != eth.StatusMsg {
-		return nil, fmt.Errorf("first msg has code %x (!= %x)", msg.Code, eth.StatusMsg)
+		return nil, nil, fmt.Errorf("first msg has code %x (!= %x)", msg.Code, eth.StatusMsg)
 	}
 
 	if msg.Size > eth.ProtocolMaxMsgSize {
-		return nil, fmt.Errorf("message is too large %d, limit %d", msg.Size, eth.ProtocolMaxMsgSize)
+		return nil, nil, fmt.Errorf("message is too large %d, limit %d", msg.Size, eth.ProtocolMaxMsgSize)
+	}
+
+	if version < direct.ETH69 {
+		var reply eth.StatusPacket
+		if err := msg.Decode(&reply); err != nil {
+			return nil, nil, fmt.Errorf("decode message %v: %w", msg, err)
+		}
+
+		return &reply, nil, nil
 	}
 
-	var reply eth.StatusPacket
+	var reply eth.StatusPacket69
 	if err := msg.Decode(&reply); err != nil {
-		return nil, fmt.Errorf("decode message %v: %w", msg, err)
+		return nil, nil, fmt.Errorf("decode message %v: %w", msg, err)
+	}
+
+	blockRange := &eth.BlockRangeUpdatePacket{
+		EarliestBlock:   reply.EarliestBlock,
+		LatestBlock:     reply.LatestBlock,
+		LatestBlockHash: reply.LatestBlockHash,
+	}
+	if err := blockRange.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("status message: %w", err)
 	}
 
-	return &reply, nil
+	return &eth.StatusPacket{
+		ProtocolVersion: reply.ProtocolVersion,
+		NetworkID:       reply.NetworkID,
+		Head:            reply.LatestBlockHash,
+		Genesis:         reply.Genesis,
+		ForkID:          reply.ForkID,
+	}, blockRange, nil
 }
 
 func checkPeerStatusCompatibility(
@@ -17,11 +17,13 @@
 package sentry
 
 import (
+	"context"
 	"math/big"
 	"testing"
 
 	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	libcommon "github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/direct"
@@ -30,7 +32,9 @@ import (
 
 	"github.com/erigontech/erigon/core/forkid"
 	"github.com/erigontech/erigon/eth/protocols/eth"
+	"github.com/erigontech/erigon/p2p"
 	"github.com/erigontech/erigon/params"
+	"github.com/erigontech/erigon/rlp"
 )
 
 func TestCheckPeerStatusCompatibility(t *testing.T) {
@@ -97,3 +101,101 @@ func TestCheckPeerStatusCompatibility(t *testing.T) {
 		assert.ErrorIs(t, err, forkid.ErrLocalIncompatibleOrStale)
 	})
 }
+
+func TestHandShake69(t *testing.T) {
+	networkID := params.MainnetChainConfig.ChainID.Uint64()
+	heightForks, timeForks := forkid.GatherForks(params.MainnetChainConfig, 0 /* genesisTime */)
+	newStatus := func(minBlock, maxBlock uint64, head libcommon.Hash) *proto_sentry.StatusData {
+		return &proto_sentry.StatusData{
+			NetworkId:       networkID,
+			TotalDifficulty: gointerfaces.ConvertUint256IntToH256(new(uint256.Int)),
+			BestHash:        gointerfaces.ConvertHashToH256(head),
+			ForkData: &proto_sentry.Forks{
+				Genesis:     gointerfaces.ConvertHashToH256(params.MainnetGenesisHash),
+				HeightForks: heightForks,
+				TimeForks:   timeForks,
+			},
+			MinBlockHeight: minBlock,
+			MaxBlockHeight: maxBlock,
+		}
+	}
+
+	type result struct {
+		status *peerStatus
+		err    *p2p.PeerError
+	}
+
+	handShakeBoth := func(status1, status2 *proto_sentry.StatusData) (result, result) {
+		rw1, rw2 := p2p.MsgPipe()
+		defer rw1.Close()
+		defer rw2.Close()
+
+		results1, results2 := make(chan result, 1), make(chan result, 1)
+		for _, side := range []struct {
+			status  *proto_sentry.StatusData
+			rw      *p2p.MsgPipeRW
+			results chan result
+		}{{status1, rw1, results1}, {status2, rw2, results2}} {
+			side := side
+			go func() {
+				status, err := handShake(context.Background(), side.status, side.rw, direct.ETH69, direct.ETH69)
+				side.results <- result{status, err}
+			}()
+		}
+
+		return <-results1, <-results2
+	}
+
+	t.Run("block range", func(t *testing.T) {
+		head1, head2 := libcommon.Hash{0x1}, libcommon.Hash{0x2}
+
+		result1, result2 := handShakeBoth(newStatus(0, 100, head1), newStatus(50, 200, head2))
+		require.Nil(t, result1.err)
+		require.Nil(t, result2.err)
+
+		require.Equal(t, head2, result1.status.head)
+		require.Equal(t, &eth.BlockRangeUpdatePacket{EarliestBlock: 50, LatestBlock: 200, LatestBlockHash: head2}, result1.status.blockRange)
+		require.Equal(t, head1, result2.status.head)
+		require.Equal(t, &eth.BlockRangeUpdatePacket{EarliestBlock: 0, LatestBlock: 100, LatestBlockHash: head1}, result2.status.blockRange)
+	})
+
+	t.Run("earliest block after head", func(t *testing.T) {
+		// our own earliest block is capped to the head, so that the announced range is always valid
+		result1, result2 := handShakeBoth(newStatus(0, 100, libcommon.Hash{0x1}), newStatus(300, 200, libcommon.Hash{0x2}))
+		require.Nil(t, result1.err)
+		require.Nil(t, result2.err)
+		require.Equal(t, uint64(200), result1.status.blockRange.EarliestBlock)
+	})
+}
+
+func TestTryDecodeStatusMessage69(t *testing.T) {
+	encode := func(packet interface{}) *p2p.Msg {
+		size, r, err := rlp.EncodeToReader(packet)
+		require.NoError(t, err)
+		return &p2p.Msg{Code: eth.StatusMsg, Size: uint32(size), Payload: r}
+	}
+
+	status := eth.StatusPacket69{
+		ProtocolVersion: direct.ETH69,
+		NetworkID:       1,
+		Genesis:         params.MainnetGenesisHash,
+		EarliestBlock:   10,
+		LatestBlock:     20,
+		LatestBlockHash: libcommon.Hash{0x1},
+	}
+
+	reply, blockRange, err := tryDecodeStatusMessage(encode(&status), direct.ETH69)
+	require.NoError(t, err)
+	require.Equal(t, status.LatestBlockHash, reply.Head)
+	require.Nil(t, reply.TD)
+	require.Equal(t, uint64(10), blockRange.EarliestBlock)
+	require.Equal(t, uint64(20), blockRange.LatestBlock)
+
+	status.EarliestBlock = 21
+	_, _, err = tryDecodeStatusMessage(encode(&status), direct.ETH69)
+	require.ErrorContains(t, err, "invalid block range")
+
+	// an eth/68 status is rejected by an eth/69 peer
+	_, _, err = tryDecodeStatusMessage(encode(&eth.StatusPacket{ProtocolVersion: direct.ETH68, TD: big.NewInt(1)}), direct.ETH69)
+	require.Error(t, err)
+}
@@ -28,6 +28,7 @@ import (
 	"math"
 	"math/rand"
 	"net"
+	"slices"
 	"sort"
 	"sync"
 	"sync/atomic"
@@ -53,7 +54,6 @@ import (
 
 	"github.com/erigontech/erigon/cmd/utils"
 	"github.com/erigontech/erigon/common/debug"
-	"github.com/erigontech/erigon/core/forkid"
 	"github.com/erigontech/erigon/eth/protocols/eth"
 	"github.com/erigontech/erigon/p2p"
 	"github.com/erigontech/erigon/p2p/dnsdisc"
@@ -67,6 +67,9 @@ const (
 	// complete before dropping the connection.= as malicious.
 	handshakeTimeout  = 5 * time.Second
 	maxPermitsPerPeer = 4 // How many outstanding requests per peer we may have
+
+	// blockRangeUpdateInterval is the number of new blocks after which eth/69 peers are sent a BlockRangeUpdate
+	blockRangeUpdateInterval = 32
 )
 
 // PeerInfo collects various extra bits of information about the peer,
@@ -77,6 +80,7 @@ type PeerInfo struct {
 	deadlines     []time.Time // Request deadlines
 	latestDealine time.Time
 	height        uint64
+	earliestBlock uint64 // earliest block the peer can serve, announced since eth/69
 	rw            p2p.MsgReadWriter
 	protocol      uint
 
@@ -197,6 +201,16 @@ func (pi *PeerInfo) SetIncreasedHeight(newHeight uint64) {
 	}
 }
 
+func (pi *PeerInfo) EarliestBlock() uint64 {
+	return atomic.LoadUint64(&pi.earliestBlock)
+}
+
+// SetBlockRange updates the range of blocks an eth/69 peer announced it can serve
+func (pi *PeerInfo) SetBlockRange(blockRange *eth.BlockRangeUpdatePacket) {
+	atomic.StoreUint64(&pi.earliestBlock, blockRange.EarliestBlock)
+	pi.SetIncreasedHeight(blockRange.LatestBlock)
+}
+
 // ClearDeadlines goes through the deadlines of
 // given peers and removes the ones that have passed
 // Optionally, it also clears one extra deadline - this is used when response is received
@@ -302,26 +316,15 @@ func handShake(
 	rw p2p.MsgReadWriter,
 	version uint,
 	minVersion uint,
-) (*libcommon.Hash, *p2p.PeerError) {
+) (*peerStatus, *p2p.PeerError) {
 	// Send out own handshake in a new thread
 	errChan := make(chan *p2p.PeerError, 2)
-	resultChan := make(chan *eth.StatusPacket, 1)
-
-	ourTD := gointerfaces.ConvertH256ToUint256Int(status.TotalDifficulty)
-	// Convert proto status data into the one required by devp2p
-	genesisHash := gointerfaces.ConvertH256ToHash(status.ForkData.Genesis)
+	resultChan := make(chan *peerStatus, 1)
 
 	go func() {
 		defer debug.LogPanic()
-		status := &eth.StatusPacket{
-			ProtocolVersion: uint32(version),
-			NetworkID:       status.NetworkId,
-			TD:              ourTD.ToBig(),
-			Head:            gointerfaces.ConvertH256ToHash(status.BestHash),
-			Genesis:         genesisHash,
-			ForkID:          forkid.NewIDFromForks(status.ForkData.HeightForks, status.ForkData.TimeForks, genesisHash, status.MaxBlockHeight, status.MaxBlockTime),
-		}
-		err := p2p.Send(rw, eth.StatusMsg, status)
+		// Convert proto status data into the one required by devp2p
+		err := p2p.Send(rw, eth.StatusMsg, makeStatusPacket(status, version))
 
 		if err == nil {
 			errChan <- nil
@@ -357,8 +360,7 @@ func handShake(
 		}
 	}
 
-	peerStatus := <-resultChan
-	return &peerStatus.Head, nil
+	return <-resultChan, nil
 }
 
 func runPeer(
@@ -548,6 +550,27 @@ func runPeer(
 				logger.Error(fmt.Sprintf("%s: reading msg into bytes: %v", peerID, err))
 			}
 			send(eth.ToProto[protocol][msg.Code], peerID, b)
+		case eth.BlockRangeUpdateMsg:
+			if protocol < direct.ETH69 {
+				msg.Discard()
+				return p2p.NewPeerError(p2p.PeerErrorInvalidMessageCode, p2p.DiscSubprotocolError, nil, fmt.Sprintf("unexpected BlockRangeUpdateMsg from %s in eth/%d", peerID, protocol))
+			}
+			b := make([]byte, msg.Size)
+			if _, err := io.ReadFull(msg.Payload, b); err != nil {
+				logger.Error(fmt.Sprintf("%s: reading msg into bytes: %v", peerID, err))
+			}
+			var blockRange eth.BlockRangeUpdatePacket
+			if err := rlp.DecodeBytes(b, &blockRange); err != nil {
+				return p2p.NewPeerError(p2p.PeerErrorInvalidMessage, p2p.DiscSubprotocolError, err, "sentry.runPeer: BlockRangeUpdateMsg decode error")
+			}
+			if err := blockRange.Validate(); err != nil {
+				return p2p.NewPeerError(p2p.PeerErrorInvalidMessage, p2p.DiscSubprotocolError, err, "sentry.runPeer: invalid BlockRangeUpdateMsg")
+			}
+			peerInfo.SetBlockRange(&blockRange)
+			if !hasSubscribers(eth.ToProto[protocol][msg.Code]) {
+				continue
+			}
+			send(eth.ToProto[protocol][msg.Code], peerID, b)
 		case 11:
 			// Ignore
 			// TODO: Investigate why BSC peers for eth/67 send these messages
@@ -626,15 +649,21 @@ func NewGrpcServer(ctx context.Context, dialCandidates func() enode.Iterator, re
 		disc = dialCandidates()
 	}
 	protocols := []uint{protocol}
-	if protocol == direct.ETH67 {
+	switch protocol {
+	case direct.ETH67:
 		protocols = append(protocols, direct.ETH66)
+	case direct.ETH69:
+		// serve eth/68 peers too, unless another sentry of the node is configured for them
+		if !slices.Contains(cfg.ProtocolVersion, direct.ETH68) {
+			protocols = append(protocols, direct.ETH68)
+		}
 	}
 	for _, p := range protocols {
 		protocol := p
 		ss.Protocols = append(ss.Protocols, p2p.Protocol{
 			Name:           eth.ProtocolName,
 			Version:        protocol,
-			Length:         17,
+			Length:         eth.ProtocolLengths[protocol],
 			DialCandidates: disc,
 			Run: func(peer *p2p.Peer, rw p2p.MsgReadWriter) *p2p.PeerError {
 				peerID := peer.Pubkey()
@@ -656,25 +685,30 @@ func NewGrpcServer(ctx context.Context, dialCandidates func() enode.Iterator, re
 					return p2p.NewPeerError(p2p.PeerErrorLocalStatusNeeded, p2p.DiscProtocolError, nil, "could not get status message from core")
 				}
 
-				peerBestHash, err := handShake(ctx, status, rw, protocol, protocol)
+				peerStatus, err := handShake(ctx, status, rw, protocol, protocol)
 				if err != nil {
 					return err
 				}
+				if peerStatus.blockRange != nil {
+					peerInfo.SetBlockRange(peerStatus.blockRange)
+				}
 
 				// handshake is successful
 				logger.Trace("[p2p] Received status message OK", "peerId", printablePeerID, "name", peer.Name())
 
 				ss.GoodPeers.Store(peerID, peerInfo)
 				ss.sendNewPeerToClients(gointerfaces.ConvertHashToH512(peerID))
-				defer ss.sendGonePeerToClients(gointerfaces.ConvertHashToH512(peerID))
-				getBlockHeadersErr := ss.getBlockHeaders(ctx, *peerBestHash, peerID)
+				var runErr *p2p.PeerError
+				defer func() { ss.sendGonePeerToClients(gointerfaces.ConvertHashToH512(peerID), runErr) }()
+				getBlockHeadersErr := ss.getBlockHeaders(ctx, peerStatus.head, peerID)
 				if getBlockHeadersErr != nil {
-					return p2p.NewPeerError(p2p.PeerErrorFirstMessageSend, p2p.DiscNetworkError, getBlockHeadersErr, "p2p.Protocol.Run getBlockHeaders failure")
+					runErr = p2p.NewPeerError(p2p.PeerErrorFirstMessageSend, p2p.DiscNetworkError, getBlockHeadersErr, "p2p.Protocol.Run getBlockHeaders failure")
+					return runErr
 				}
 
 				cap := p2p.Cap{Name: eth.ProtocolName, Version: protocol}
 
-				return runPeer(
+				runErr = runPeer(
 					ctx,
 					peerID,
 					cap,
@@ -684,6 +718,10 @@ func NewGrpcServer(ctx context.Context, dialCandidates func() enode.Iterator, re
 					ss.hasSubscribers,
 					logger,
 				)
+				if isMessageError(runErr) {
+					ss.sendPeerMessageErrorToClients(gointerfaces.ConvertHashToH512(peerID), runErr)
+				}
+				return runErr
 			},
 			NodeInfo: func() interface{} {
 				return readNodeInfo()
@@ -738,6 +776,7 @@ type GrpcServer struct {
 	messageStreams       map[proto_sentry.MessageId]map[uint64]chan *proto_sentry.InboundMessage
 	messagesSubscriberID uint64
 	messageStreamsLock   sync.RWMutex
+	announcedBlockRange  *eth.BlockRangeUpdatePacket // latest block range sent to eth/69 peers, guarded by statusDataLock
 	peersStreams         *PeersStreams
 	p2p                  *p2p.Config
 	logger               log.Logger
@@ -820,6 +859,11 @@ func (ss *GrpcServer) getBlockHeaders(ctx context.Context, bestHash libcommon.Ha
 func (ss *GrpcServer) PenalizePeer(_ context.Context, req *proto_sentry.PenalizePeerRequest) (*emptypb.Empty, error) {
 	//log.Warn("Received penalty", "kind", req.GetPenalty().Descriptor().FullName, "from", fmt.Sprintf("%s", req.GetPeerId()))
 	peerID := ConvertH512ToPeerID(req.PeerId)
+	if p2pServer := ss.getP2PServer(); p2pServer != nil {
+		if err := p2pServer.AddPeerReputationEvent(enode.PubkeyEncoded(peerID).ID(), penaltyReputationEvent(req.Penalty)); err != nil {
+			ss.logger.Debug("[sentry] failed to record peer penalty", "peer", hex.EncodeToString(peerID[:])[:20], "err", err)
+		}
+	}
 	peerInfo := ss.getPeer(peerID)
 	if ss.statusData != nil && peerInfo != nil && !peerInfo.peer.Info().Network.Static && !peerInfo.peer.Info().Network.Trusted {
 		ss.removePeer(peerID, p2p.NewPeerError(p2p.PeerErrorDiscReason, p2p.DiscRequested, nil, "penalized peer"))
@@ -827,6 +871,18 @@ func (ss *GrpcServer) PenalizePeer(_ context.Context, req *proto_sentry.Penalize
 	return &emptypb.Empty{}, nil
 }
 
+// penaltyReputationEvent maps a penalty kind to the reputation event persisted for the peer.
+func penaltyReputationEvent(kind proto_sentry.PenaltyKind) enode.ReputationEvent {
+	switch kind {
+	case proto_sentry.PenaltyKind_InvalidBlock:
+		return enode.ReputationInvalidBlock
+	case proto_sentry.PenaltyKind_Timeout:
+		return enode.ReputationTimeout
+	default:
+		return enode.ReputationUseless
+	}
+}
+
 func (ss *GrpcServer) PeerMinBlock(_ context.Context, req *proto_sentry.PeerMinBlockRequest) (*emptypb.Empty, error) {
 	peerID := ConvertH512ToPeerID(req.PeerId)
 	if peerInfo := ss.getPeer(peerID); peerInfo != nil {
@@ -878,7 +934,8 @@ func (ss *GrpcServer) findPeerByMinBlock(minBlock uint64) (*PeerInfo, bool) {
 	var maxPermits int
 	now := time.Now()
 	ss.rangePeers(func(peerInfo *PeerInfo) bool {
-		if peerInfo.Height() >= minBlock {
+		// eth/69 peers announce the blocks they pruned, do not ask them for those
+		if peerInfo.Height() >= minBlock && peerInfo.EarliestBlock() <= minBlock {
 			deadlines := peerInfo.ClearDeadlines(now, false /* givePermit */)
 			//fmt.Printf("%d deadlines for peer %s\n", deadlines, peerID)
 			if deadlines < maxPermitsPerPeer {
@@ -1011,6 +1068,8 @@ func (ss *GrpcServer) HandShake(context.Context, *emptypb.Empty) (*proto_sentry.
 		reply.Protocol = proto_sentry.Protocol_ETH67
 	case direct.ETH68:
 		reply.Protocol = proto_sentry.Protocol_ETH68
+	case direct.ETH69:
+		reply.Protocol = proto_sentry.Protocol_ETH69
 	}
 	return reply, nil
 }
@@ -1073,9 +1132,53 @@ func (ss *GrpcServer) SetStatus(ctx context.Context, statusData *proto_sentry.St
 		// Not overwrite statusData if the message contains zero MaxBlock (comes from standalone transaction pool)
 		ss.statusData = statusData
 	}
+	ss.p2pServer.SetDiscoveryTopic(
+		eth.DiscoveryTopic(ss.statusData.ForkData.HeightForks, ss.statusData.ForkData.TimeForks, genesisHash, ss.statusData.MaxBlockHeight, ss.statusData.MaxBlockTime),
+		eth.LatestDiscoveryTopic(ss.statusData.ForkData.HeightForks, ss.statusData.ForkData.TimeForks, genesisHash),
+	)
+	if statusData.MaxBlockHeight != 0 {
+		// Sending with statusDataLock held is safe only because writePeer queues the write on the peer's task
+		// goroutine, which never takes the lock. Writing synchronously would block SetStatus, and every handshake
+		// waiting in GetStatus, on the slowest peer.
+		ss.announceBlockRange(statusData)
+	}
 	return reply, nil
 }
 
+// announceBlockRange sends a BlockRangeUpdate to the eth/69 peers when the range of blocks the node can serve
+// has changed enough since the last announcement. Must be called with statusDataLock held.
+func (ss *GrpcServer) announceBlockRange(statusData *proto_sentry.StatusData) {
+	blockRange := &eth.BlockRangeUpdatePacket{
+		EarliestBlock:   min(statusData.MinBlockHeight, statusData.MaxBlockHeight),
+		LatestBlock:     statusData.MaxBlockHeight,
+		LatestBlockHash: gointerfaces.ConvertH256ToHash(statusData.BestHash),
+	}
+
+	if announced := ss.announcedBlockRange; announced == nil {
+		// the peers got the current range in the status message
+		ss.announcedBlockRange = blockRange
+		return
+	} else if announced.EarliestBlock == blockRange.EarliestBlock &&
+		blockRange.LatestBlock >= announced.LatestBlock &&
+		blockRange.LatestBlock < announced.LatestBlock+blockRangeUpdateInterval {
+		return
+	}
+
+	data, err := rlp.EncodeToBytes(blockRange)
+	if err != nil {
+		ss.logger.Error("[sentry] could not encode BlockRangeUpdate", "err", err)
+		return
+	}
+
+	ss.announcedBlockRange = blockRange
+	ss.rangePeers(func(peerInfo *PeerInfo) bool {
+		if peerInfo.protocol >= direct.ETH69 {
+			ss.writePeer("[sentry] announceBlockRange", peerInfo, eth.BlockRangeUpdateMsg, data, 0)
+		}
+		return true
+	})
+}
+
 func (ss *GrpcServer) Peers(_ context.Context, _ *emptypb.Empty) (*proto_sentry.PeersReply, error) {
 	p2pServer := ss.getP2PServer()
 	if p2pServer == nil {
@@ -1261,12 +1364,38 @@ func (ss *GrpcServer) sendNewPeerToClients(peerID *proto_types.H512) {
 	}
 }
 
-func (ss *GrpcServer) sendGonePeerToClients(peerID *proto_types.H512) {
-	if err := ss.peersStreams.Broadcast(&proto_sentry.PeerEvent{PeerId: peerID, EventId: proto_sentry.PeerEvent_Disconnect}); err != nil {
+func (ss *GrpcServer) sendGonePeerToClients(peerID *proto_types.H512, reason *p2p.PeerError) {
+	event := &proto_sentry.PeerEvent{PeerId: peerID, EventId: proto_sentry.PeerEvent_Disconnect}
+	if reason != nil {
+		event.Error = reason.Error()
+	}
+	if err := ss.peersStreams.Broadcast(event); err != nil {
 		ss.logger.Warn("Sending gone peer notice to core P2P failed", "err", err)
 	}
 }
 
+func (ss *GrpcServer) sendPeerMessageErrorToClients(peerID *proto_types.H512, reason *p2p.PeerError) {
+	event := &proto_sentry.PeerEvent{PeerId: peerID, EventId: proto_sentry.PeerEvent_MessageError, Error: reason.Error()}
+	if err := ss.peersStreams.Broadcast(event); err != nil {
+		ss.logger.Warn("Sending peer message error notice to core P2P failed", "err", err)
+	}
+}
+
+// isMessageError tells whether the peer was dropped because of a message it sent,
+// as opposed to network failures or disconnects.
+func isMessageError(err *p2p.PeerError) bool {
+	if err == nil {
+		return false
+	}
+	switch err.Code {
+	case p2p.PeerErrorInvalidMessageCode, p2p.PeerErrorInvalidMessage, p2p.PeerErrorMessageSizeLimit,
+		p2p.PeerErrorMessageObsolete, p2p.PeerErrorStatusUnexpected:
+		return true
+	default:
+		return false
+	}
+}
+
 func (ss *GrpcServer) PeerEvents(req *proto_sentry.PeerEventsRequest, server proto_sentry.Sentry_PeerEventsServer) error {
 	clean := ss.peersStreams.Add(server)
 	defer clean()
@@ -1293,6 +1422,86 @@ func (ss *GrpcServer) AddPeer(_ context.Context, req *proto_sentry.AddPeerReques
 	return &proto_sentry.AddPeerReply{Success: true}, nil
 }
 
+func (ss *GrpcServer) RemovePeer(_ context.Context, req *proto_sentry.RemovePeerRequest) (*proto_sentry.RemovePeerReply, error) {
+	node, err := enode.Parse(enode.ValidSchemes, req.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	p2pServer := ss.getP2PServer()
+	if p2pServer == nil {
+		return nil, errors.New("p2p server was not started")
+	}
+	p2pServer.RemovePeer(node)
+
+	return &proto_sentry.RemovePeerReply{Success: true}, nil
+}
+
+func (ss *GrpcServer) AddTrustedPeer(_ context.Context, req *proto_sentry.AddPeerRequest) (*proto_sentry.AddPeerReply, error) {
+	node, err := enode.Parse(enode.ValidSchemes, req.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	p2pServer := ss.getP2PServer()
+	if p2pServer == nil {
+		return nil, errors.New("p2p server was not started")
+	}
+	p2pServer.AddTrustedPeer(node)
+
+	return &proto_sentry.AddPeerReply{Success: true}, nil
+}
+
+func (ss *GrpcServer) RemoveTrustedPeer(_ context.Context, req *proto_sentry.RemovePeerRequest) (*proto_sentry.RemovePeerReply, error) {
+	node, err := enode.Parse(enode.ValidSchemes, req.Url)
+	if err != nil {
+		return nil, err
+	}
+
+	p2pServer := ss.getP2PServer()
+	if p2pServer == nil {
+		return nil, errors.New("p2p server was not started")
+	}
+	p2pServer.RemoveTrustedPeer(node)
+
+	return &proto_sentry.RemovePeerReply{Success: true}, nil
+}
+
+func (ss *GrpcServer) SetMaxPeers(_ context.Context, req *proto_sentry.SetMaxPeersRequest) (*proto_sentry.SetMaxPeersReply, error) {
+	p2pServer := ss.getP2PServer()
+	if p2pServer == nil {
+		return nil, errors.New("p2p server was not started")
+	}
+	p2pServer.SetMaxPeers(int(req.MaxPeers))
+
+	return &proto_sentry.SetMaxPeersReply{Success: true}, nil
+}
+
+func (ss *GrpcServer) PeerScores(_ context.Context, _ *emptypb.Empty) (*proto_sentry.PeerScoresReply, error) {
+	p2pServer := ss.getP2PServer()
+	if p2pServer == nil {
+		return nil, errors.New("p2p server was not started")
+	}
+
+	scores, err := p2pServer.PeerScores()
+	if err != nil {
+		return nil, err
+	}
+
+	reply := &proto_sentry.PeerScoresReply{Scores: make([]*proto_types.PeerScore, 0, len(scores))}
+	for _, score := range scores {
+		reply.Scores = append(reply.Scores, &proto_types.PeerScore{
+			Id:           score.ID,
+			Useless:      score.Useless,
+			InvalidBlock: score.InvalidBlock,
+			Timeout:      score.Timeout,
+			Score:        score.Score,
+			Updated:      score.Updated,
+		})
+	}
+	return reply, nil
+}
+
 func (ss *GrpcServer) NodeInfo(_ context.Context, _ *emptypb.Empty) (*proto_types.NodeInfoReply, error) {
 	p2pServer := ss.getP2PServer()
 	if p2pServer == nil {
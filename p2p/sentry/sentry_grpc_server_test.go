@@ -87,6 +87,7 @@ func startHandshake(
 // Tests that peers are correctly accepted (or rejected) based on the advertised
 // fork IDs in the protocol handshake.
 func TestForkIDSplit66(t *testing.T) { testForkIDSplit(t, direct.ETH66) }
+func TestForkIDSplit69(t *testing.T) { testForkIDSplit(t, direct.ETH69) }
 
 func testForkIDSplit(t *testing.T, protocol uint) {
 	var (
@@ -212,3 +213,21 @@ func TestSentryServerImpl_SetStatusInitPanic(t *testing.T) {
 		t.Fatalf("error expected")
 	}
 }
+
+func TestGrpcServerETH69Protocols(t *testing.T) {
+	versions := func(ss *GrpcServer) (vs []uint) {
+		for _, p := range ss.Protocols {
+			vs = append(vs, p.Version)
+		}
+		return vs
+	}
+	logger := log.New()
+
+	// a standalone eth/69 sentry serves eth/68 peers as well
+	ss := NewGrpcServer(context.Background(), nil, nil, &p2p.Config{ProtocolVersion: []uint{direct.ETH69}}, direct.ETH69, logger)
+	require.Equal(t, []uint{direct.ETH69, direct.ETH68}, versions(ss))
+
+	// unless the node runs another sentry for them
+	ss = NewGrpcServer(context.Background(), nil, nil, &p2p.Config{ProtocolVersion: []uint{direct.ETH69, direct.ETH68}}, direct.ETH69, logger)
+	require.Equal(t, []uint{direct.ETH69}, versions(ss))
+}
@@ -157,7 +157,7 @@ func (cs *MultiClient) Penalize(ctx context.Context, penalties []headerdownload.
 	for i := range penalties {
 		outreq := proto_sentry.PenalizePeerRequest{
 			PeerId:  gointerfaces.ConvertHashToH512(penalties[i].PeerID),
-			Penalty: proto_sentry.PenaltyKind_Kick, // TODO: Extend penalty kinds
+			Penalty: penaltyKind(penalties[i].Penalty),
 		}
 		for i, ok, next := cs.randSentryIndex(); ok; i, ok = next() {
 			if ready, ok := cs.sentries[i].(interface{ Ready() bool }); ok && !ready.Ready() {
@@ -170,3 +170,20 @@ func (cs *MultiClient) Penalize(ctx context.Context, penalties []headerdownload.
 		}
 	}
 }
+
+// penaltyKind maps a header downloader penalty to the kind reported to sentries,
+// which keep a persistent reputation per kind.
+func penaltyKind(penalty headerdownload.Penalty) proto_sentry.PenaltyKind {
+	switch penalty {
+	case headerdownload.BadBlockPenalty, headerdownload.WrongChildBlockHeightPenalty, headerdownload.WrongChildDifficultyPenalty,
+		headerdownload.InvalidSealPenalty, headerdownload.TooFarFuturePenalty, headerdownload.TooFarPastPenalty:
+		return proto_sentry.PenaltyKind_InvalidBlock
+	case headerdownload.AbandonedAnchorPenalty:
+		// anchors are abandoned after repeated request timeouts
+		return proto_sentry.PenaltyKind_Timeout
+	case headerdownload.DuplicateHeaderPenalty, headerdownload.NewBlockGossipAfterMergePenalty:
+		return proto_sentry.PenaltyKind_Useless
+	default:
+		return proto_sentry.PenaltyKind_Kick
+	}
+}
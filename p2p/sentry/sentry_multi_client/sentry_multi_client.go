@@ -80,6 +80,7 @@ func (cs *MultiClient) RecvUploadMessageLoop(
 	ids := []proto_sentry.MessageId{
 		eth.ToProto[direct.ETH66][eth.GetBlockBodiesMsg],
 		eth.ToProto[direct.ETH66][eth.GetReceiptsMsg],
+		eth.ToProto[direct.ETH69][eth.GetReceiptsMsg],
 	}
 	streamFactory := func(streamCtx context.Context, sentry proto_sentry.SentryClient) (grpc.ClientStream, error) {
 		return sentry.Messages(streamCtx, &proto_sentry.MessagesRequest{Ids: ids}, grpc.WaitForReady(true))
@@ -434,7 +435,7 @@ func (cs *MultiClient) newBlock66(ctx context.Context, inreq *proto_sentry.Inbou
 		} else {
 			outreq := proto_sentry.PenalizePeerRequest{
 				PeerId:  inreq.PeerId,
-				Penalty: proto_sentry.PenaltyKind_Kick, // TODO: Extend penalty kinds
+				Penalty: penaltyKind(penalty),
 			}
 			for _, sentry := range cs.sentries {
 				// TODO does this method need to be moved to the grpc api ?
@@ -573,6 +574,17 @@ var (
 )
 
 func (cs *MultiClient) getReceipts66(ctx context.Context, inreq *proto_sentry.InboundMessage, sentryClient proto_sentry.SentryClient) error {
+	return cs.getReceipts(ctx, inreq, sentryClient, eth.AnswerGetReceiptsQuery, proto_sentry.MessageId_RECEIPTS_66)
+}
+
+// getReceipts69 answers the receipts queries of eth/69 peers, which expect receipts without bloom
+func (cs *MultiClient) getReceipts69(ctx context.Context, inreq *proto_sentry.InboundMessage, sentryClient proto_sentry.SentryClient) error {
+	return cs.getReceipts(ctx, inreq, sentryClient, eth.AnswerGetReceiptsQuery69, proto_sentry.MessageId_RECEIPTS_69)
+}
+
+type answerGetReceiptsQuery func(ctx context.Context, cfg *chain.Config, receiptsGetter eth.ReceiptsGetter, br services.FullBlockReader, db kv.Tx, query eth.GetReceiptsPacket) ([]rlp.RawValue, error)
+
+func (cs *MultiClient) getReceipts(ctx context.Context, inreq *proto_sentry.InboundMessage, sentryClient proto_sentry.SentryClient, answer answerGetReceiptsQuery, replyId proto_sentry.MessageId) error {
 	if !EnableP2PReceipts {
 		return nil
 	}
@@ -593,7 +605,7 @@ func (cs *MultiClient) getReceipts66(ctx context.Context, inreq *proto_sentry.In
 	}
 	defer tx.Rollback()
 
-	receiptsList, err := eth.AnswerGetReceiptsQuery(ctx, cs.ChainConfig, cs.ethApiWrapper, cs.blockReader, tx, query.GetReceiptsPacket)
+	receiptsList, err := answer(ctx, cs.ChainConfig, cs.ethApiWrapper, cs.blockReader, tx, query.GetReceiptsPacket)
 	if err != nil {
 		return err
 	}
@@ -607,7 +619,7 @@ func (cs *MultiClient) getReceipts66(ctx context.Context, inreq *proto_sentry.In
 	outreq := proto_sentry.SendMessageByIdRequest{
 		PeerId: inreq.PeerId,
 		Data: &proto_sentry.OutboundMessageData{
-			Id:   proto_sentry.MessageId_RECEIPTS_66,
+			Id:   replyId,
 			Data: b,
 		},
 	}
@@ -668,6 +680,10 @@ func (cs *MultiClient) handleInboundMessage(ctx context.Context, inreq *proto_se
 		return cs.receipts66(ctx, inreq, sentry)
 	case proto_sentry.MessageId_GET_RECEIPTS_66:
 		return cs.getReceipts66(ctx, inreq, sentry)
+	// ========= eth 69 ==========
+
+	case proto_sentry.MessageId_GET_RECEIPTS_69:
+		return cs.getReceipts69(ctx, inreq, sentry)
 	default:
 		return fmt.Errorf("not implemented for message Id: %s", inreq.Id)
 	}
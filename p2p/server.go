@@ -27,6 +27,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"net"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -221,6 +222,8 @@ type Server struct {
 	DiscV5             *discover.UDPv5
 	discmix            *enode.FairMix
 	dialsched          *dialScheduler
+	discTopics         []discover.Topic   // the advertised topic, followed by the other searched topics
+	discTopicCancel    context.CancelFunc // withdraws the current discovery topic, protected by lock
 
 	// Channels into the run loop.
 	quitCtx                 context.Context
@@ -228,6 +231,7 @@ type Server struct {
 	quit                    <-chan struct{}
 	addtrusted              chan *enode.Node
 	removetrusted           chan *enode.Node
+	setmaxpeers             chan int
 	peerOp                  chan peerOpFunc
 	peerOpDone              chan struct{}
 	delpeer                 chan peerDrop
@@ -416,6 +420,15 @@ func (srv *Server) RemoveTrustedPeer(node *enode.Node) {
 	}
 }
 
+// SetMaxPeers changes the maximum number of peers. Lowering the limit doesn't
+// disconnect existing peers, new peers are refused until enough of them leave.
+func (srv *Server) SetMaxPeers(n int) {
+	select {
+	case srv.setmaxpeers <- n:
+	case <-srv.quit:
+	}
+}
+
 // SubscribeEvents subscribes the given channel to peer events.
 func (srv *Server) SubscribeEvents(ch chan *PeerEvent) event.Subscription {
 	return srv.peerFeed.Subscribe(ch)
@@ -516,6 +529,7 @@ func (srv *Server) Start(ctx context.Context, logger log.Logger) error {
 	srv.checkpointAddPeer = make(chan *conn)
 	srv.addtrusted = make(chan *enode.Node)
 	srv.removetrusted = make(chan *enode.Node)
+	srv.setmaxpeers = make(chan int)
 	srv.peerOp = make(chan peerOpFunc)
 	srv.peerOpDone = make(chan struct{})
 
@@ -682,6 +696,52 @@ func (srv *Server) setupDiscovery(ctx context.Context) error {
 	return nil
 }
 
+// SetDiscoveryTopic advertises the local node under topic on discovery v5 and adds
+// the nodes found under topic and searchTopics to the dial candidates. Previously set
+// topics are withdrawn. It does nothing if the server isn't running or discovery v5
+// is disabled.
+func (srv *Server) SetDiscoveryTopic(topic discover.Topic, searchTopics ...discover.Topic) {
+	srv.lock.Lock()
+	defer srv.lock.Unlock()
+
+	if !srv.running.Load() || srv.DiscV5 == nil {
+		return
+	}
+	topics := []discover.Topic{topic}
+	for _, t := range searchTopics {
+		if !slices.Contains(topics, t) {
+			topics = append(topics, t)
+		}
+	}
+	if srv.discTopicCancel != nil {
+		if slices.Equal(srv.discTopics, topics) {
+			return
+		}
+		srv.discTopicCancel()
+	}
+	ctx, cancel := context.WithCancel(srv.quitCtx)
+	srv.discTopics, srv.discTopicCancel = topics, cancel
+
+	its := make([]enode.Iterator, 0, len(topics))
+	for _, t := range topics {
+		it := srv.DiscV5.TopicNodes(t)
+		srv.discmix.AddSource(it)
+		its = append(its, it)
+	}
+	srv.loopWG.Add(1)
+	go func() {
+		defer debug.LogPanic()
+		defer srv.loopWG.Done()
+		defer func() {
+			for _, it := range its {
+				it.Close()
+			}
+		}()
+		srv.DiscV5.RegisterTopic(ctx, topic)
+	}()
+	srv.logger.Debug("[p2p] Discovery topic set", "topic", topic, "search", topics[1:])
+}
+
 func (srv *Server) setupDialScheduler() {
 	config := dialConfig{
 		self:           srv.localnode.ID(),
@@ -695,6 +755,9 @@ func (srv *Server) setupDialScheduler() {
 	if srv.ntab != nil {
 		config.resolver = srv.ntab
 	}
+	if srv.nodedb != nil {
+		config.reputation = srv.nodedb
+	}
 	if config.dialer == nil {
 		config.dialer = tcpDialer{&net.Dialer{Timeout: defaultDialTimeout}}
 	}
@@ -818,6 +881,12 @@ running:
 				p.rw.set(trustedConn, false)
 			}
 
+		case n := <-srv.setmaxpeers:
+			// This channel is used by SetMaxPeers to change the peer limit.
+			srv.logger.Trace("Changing max peers", "old", srv.MaxPeers, "new", n)
+			srv.MaxPeers = n
+			srv.dialsched.setMaxDialPeers(srv.maxDialedConns())
+
 		case op := <-srv.peerOp:
 			// This channel is used by GoodPeers and PeerCount.
 			op(peers)
@@ -1205,6 +1274,56 @@ func (srv *Server) PeersInfo() []*PeerInfo {
 	return infos
 }
 
+// PeerScore represents the persisted reputation of a known node.
+type PeerScore struct {
+	ID           string  `json:"id"`           // Unique node identifier
+	Useless      float64 `json:"useless"`      // Decayed number of useless peer events
+	InvalidBlock float64 `json:"invalidBlock"` // Decayed number of invalid blocks served
+	Timeout      float64 `json:"timeout"`      // Decayed number of request timeouts
+	Score        float64 `json:"score"`        // Weighted score, zero is neutral and lower is worse
+	Updated      uint64  `json:"updated"`      // Unix time of the last recorded event
+}
+
+// AddPeerReputationEvent records a misbehaviour event of the given node in the node
+// database. Node reputations survive restarts and are used to prefer well-behaved
+// nodes when dialing.
+func (srv *Server) AddPeerReputationEvent(id enode.ID, event enode.ReputationEvent) error {
+	if srv.nodedb == nil {
+		return errServerStopped
+	}
+	_, err := srv.nodedb.AddReputationEvent(id, event)
+	return err
+}
+
+// PeerScores returns the reputation of all nodes with recorded events, worst first.
+func (srv *Server) PeerScores() ([]*PeerScore, error) {
+	if srv.nodedb == nil {
+		return nil, errServerStopped
+	}
+	reps, err := srv.nodedb.Reputations()
+	if err != nil {
+		return nil, err
+	}
+	scores := make([]*PeerScore, 0, len(reps))
+	for id, rep := range reps {
+		scores = append(scores, &PeerScore{
+			ID:           id.String(),
+			Useless:      rep.Useless,
+			InvalidBlock: rep.InvalidBlock,
+			Timeout:      rep.Timeout,
+			Score:        rep.Score(),
+			Updated:      uint64(rep.Updated.Unix()),
+		})
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score < scores[j].Score
+		}
+		return scores[i].ID < scores[j].ID
+	})
+	return scores, nil
+}
+
 func (srv *Server) addError(err error) {
 	if err == nil {
 		return
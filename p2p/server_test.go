@@ -330,6 +330,58 @@ func TestServerAtCap(t *testing.T) {
 	}
 }
 
+func TestServerSetMaxPeers(t *testing.T) {
+	logger := log.New()
+	remoteKey := newkey()
+	srv := &Server{
+		Config: Config{
+			PrivateKey:      newkey(),
+			MaxPeers:        2,
+			MaxPendingPeers: 10,
+			NoDial:          true,
+			NoDiscovery:     true,
+		},
+	}
+	if err := srv.TestStart(logger); err != nil {
+		t.Fatalf("could not start: %v", err)
+	}
+	defer srv.Stop()
+
+	addPeer := func() error {
+		fd, _ := net.Pipe()
+		tx := newTestTransport(&remoteKey.PublicKey, fd, nil)
+		node := enode.SignNull(new(enr.Record), randomID())
+		c := &conn{fd: fd, transport: tx, flags: inboundConn, node: node, cont: make(chan error)}
+		if err := srv.checkpoint(c, srv.checkpointPostHandshake); err != nil {
+			t.Fatal("unexpected error @ checkpointPostHandshake:", err)
+		}
+		return srv.checkpoint(c, srv.checkpointAddPeer)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := addPeer(); err != nil {
+			t.Fatalf("could not add conn %d: %v", i, err)
+		}
+	}
+	if err := addPeer(); err != DiscTooManyPeers {
+		t.Fatal("wrong error for insert at cap:", err)
+	}
+
+	srv.SetMaxPeers(3)
+	if err := addPeer(); err != nil {
+		t.Fatal("could not add conn after raising the limit:", err)
+	}
+
+	// Lowering the limit keeps the connected peers.
+	srv.SetMaxPeers(1)
+	if err := addPeer(); err != DiscTooManyPeers {
+		t.Fatal("wrong error for insert after lowering the limit:", err)
+	}
+	if n := srv.PeerCount(); n != 3 {
+		t.Fatalf("wrong peer count after lowering the limit: got %d, want 3", n)
+	}
+}
+
 func TestServerPeerLimits(t *testing.T) {
 	logger := log.New()
 	srvkey := newkey()
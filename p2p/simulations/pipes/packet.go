@@ -0,0 +1,131 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package pipes
+
+import (
+	"fmt"
+	"net"
+	"sync"
+)
+
+// packetQueueSize is the number of packets buffered by a PacketConn. Packets sent
+// to a full queue are dropped, like they would be by a busy UDP socket.
+const packetQueueSize = 256
+
+// PacketNetwork is an in-memory datagram network. Connections created by Listen
+// exchange packets through the network instead of real sockets, which allows
+// running many discovery nodes in a single process.
+type PacketNetwork struct {
+	mu    sync.Mutex
+	conns map[string]*PacketConn
+}
+
+// NewPacketNetwork creates an empty network.
+func NewPacketNetwork() *PacketNetwork {
+	return &PacketNetwork{conns: make(map[string]*PacketConn)}
+}
+
+// Listen creates a connection bound to addr.
+func (n *PacketNetwork) Listen(addr *net.UDPAddr) (*PacketConn, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	key := addr.String()
+	if _, ok := n.conns[key]; ok {
+		return nil, fmt.Errorf("address %v already in use", addr)
+	}
+	c := &PacketConn{
+		network: n,
+		addr:    addr,
+		in:      make(chan packet, packetQueueSize),
+		closed:  make(chan struct{}),
+	}
+	n.conns[key] = c
+	return c, nil
+}
+
+func (n *PacketNetwork) deliver(from, to *net.UDPAddr, data []byte) {
+	n.mu.Lock()
+	c := n.conns[to.String()]
+	n.mu.Unlock()
+	if c == nil {
+		return
+	}
+	p := packet{from: from, data: append([]byte(nil), data...)}
+	select {
+	case c.in <- p:
+	default:
+	}
+}
+
+func (n *PacketNetwork) remove(c *PacketConn) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.conns[c.addr.String()] == c {
+		delete(n.conns, c.addr.String())
+	}
+}
+
+type packet struct {
+	from *net.UDPAddr
+	data []byte
+}
+
+// PacketConn is a connection on a PacketNetwork. It implements the UDP socket
+// methods used by the discovery protocols.
+type PacketConn struct {
+	network   *PacketNetwork
+	addr      *net.UDPAddr
+	in        chan packet
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+// ReadFromUDP reads the next packet sent to the connection.
+func (c *PacketConn) ReadFromUDP(b []byte) (int, *net.UDPAddr, error) {
+	select {
+	case p := <-c.in:
+		return copy(b, p.data), p.from, nil
+	case <-c.closed:
+		return 0, nil, net.ErrClosed
+	}
+}
+
+// WriteToUDP sends a packet to addr. Packets to unknown addresses are dropped.
+func (c *PacketConn) WriteToUDP(b []byte, addr *net.UDPAddr) (int, error) {
+	select {
+	case <-c.closed:
+		return 0, net.ErrClosed
+	default:
+	}
+	c.network.deliver(c.addr, addr, b)
+	return len(b), nil
+}
+
+// Close closes the connection and releases its address.
+func (c *PacketConn) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.closed)
+		c.network.remove(c)
+	})
+	return nil
+}
+
+// LocalAddr returns the address of the connection.
+func (c *PacketConn) LocalAddr() net.Addr {
+	return c.addr
+}
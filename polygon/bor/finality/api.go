@@ -24,8 +24,25 @@ import (
 	"github.com/erigontech/erigon/polygon/bor/finality/whitelist"
 )
 
+// Sources of the finalized block
+const (
+	FinalitySourceMilestone  = "milestone"
+	FinalitySourceCheckpoint = "checkpoint"
+)
+
 func GetFinalizedBlockNumber(tx kv.Tx) uint64 {
+	number, _, _ := GetFinalizedBlock(tx)
+	return number
+}
+
+// GetFinalizedBlock returns the finalized block of the form (block number, block hash, source), where the
+// source is the whitelisted milestone or checkpoint which finalizes it. The block number is 0 when there is
+// no finalized block.
+func GetFinalizedBlock(tx kv.Tx) (uint64, common.Hash, string) {
 	currentBlockNum := rawdb.ReadCurrentHeader(tx)
+	if currentBlockNum == nil {
+		return 0, common.Hash{}, ""
+	}
 
 	service := whitelist.GetWhitelistingService()
 
@@ -35,11 +52,11 @@ func GetFinalizedBlockNumber(tx kv.Tx) uint64 {
 		blockHeader := rawdb.ReadHeaderByNumber(tx, number)
 
 		if blockHeader == nil {
-			return 0
+			return 0, common.Hash{}, ""
 		}
 
 		if blockHeader.Hash() == hash {
-			return number
+			return number, hash, FinalitySourceMilestone
 		}
 	}
 
@@ -49,15 +66,15 @@ func GetFinalizedBlockNumber(tx kv.Tx) uint64 {
 		blockHeader := rawdb.ReadHeaderByNumber(tx, number)
 
 		if blockHeader == nil {
-			return 0
+			return 0, common.Hash{}, ""
 		}
 
 		if blockHeader.Hash() == hash {
-			return number
+			return number, hash, FinalitySourceCheckpoint
 		}
 	}
 
-	return 0
+	return 0, common.Hash{}, ""
 }
 
 // CurrentFinalizedBlock retrieves the current finalized block of the canonical
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 
+	libcommon "github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/log/v3"
 
 	"github.com/erigontech/erigon-lib/metrics"
@@ -79,6 +80,8 @@ func borVerify(ctx context.Context, config *config, start uint64, end uint64, ha
 	}
 
 	head := *currentBlock
+	finishMilestoneRewindIfDone(roTx, head)
+
 	if head < end {
 		log.Debug("[bor] current head block behind incoming", "block", str, "head", head, "end block", end)
 		return hash, errMissingBlocks
@@ -149,7 +152,12 @@ func borVerify(ctx context.Context, config *config, start uint64, end uint64, ha
 			log.Warn("[bor] Rewinding chain due to milestone endblock hash mismatch", "number", rewindTo)
 		}
 
-		rewindBack(head, rewindTo)
+		headHash, err := rawdb.ReadCanonicalHash(roTx, head)
+		if err != nil {
+			log.Debug("[bor] Failed to get head block hash while rewinding", "number", head, "err", err)
+		}
+
+		rewindBack(head, headHash, rewindTo, str)
 
 		return hash, errHashMismatch
 	}
@@ -171,9 +179,12 @@ func borVerify(ctx context.Context, config *config, start uint64, end uint64, ha
 }
 
 // Stop the miner if the mining process is running and rewind back the chain
-func rewindBack(head uint64, rewindTo uint64) {
+func rewindBack(head uint64, headHash libcommon.Hash, rewindTo uint64, source string) {
 	rewindLengthMeter.SetUint64(head - rewindTo)
 
+	// the rewind is kept by the whitelisting service until it is done, so that a restart does not drop it
+	whitelist.GetWhitelistingService().ProcessRewind(head, headHash, rewindTo, source)
+
 	// Chain cannot be rewinded from this routine
 	// hence we are using a shared variable
 	BorMilestoneRewind.Store(&rewindTo)
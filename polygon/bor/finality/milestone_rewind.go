@@ -16,7 +16,15 @@
 
 package finality
 
-import "sync/atomic"
+import (
+	"context"
+	"sync/atomic"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon/core/rawdb"
+	"github.com/erigontech/erigon/polygon/bor/finality/whitelist"
+)
 
 // BorMilestoneRewind is used as a flag/variable
 // Flag: if equals 0, no rewind according to bor whitelisting service
@@ -26,3 +34,60 @@ var BorMilestoneRewind atomic.Pointer[uint64]
 func IsMilestoneRewindPending() bool {
 	return BorMilestoneRewind.Load() != nil && *BorMilestoneRewind.Load() != 0
 }
+
+// ResetMilestoneRewind is called once the sync has been asked to unwind the chain to BorMilestoneRewind.
+// The pending rewind of the whitelisting service is kept until the unwind is committed, see
+// finishMilestoneRewindIfDone.
+func ResetMilestoneRewind() {
+	var reset uint64 = 0
+	BorMilestoneRewind.Store(&reset)
+}
+
+// finishMilestoneRewindIfDone clears the pending rewind once the committed chain no longer has the head
+// it was decided for: either the chain is back at the rewind point, or the head block has been replaced.
+func finishMilestoneRewindIfDone(roTx kv.Tx, head uint64) {
+	service := whitelist.GetWhitelistingService()
+	if service == nil {
+		return
+	}
+
+	rewind := service.GetPendingRewind()
+	if rewind == nil {
+		return
+	}
+
+	if head > rewind.RewindTo {
+		if rewind.HeadHash == (libcommon.Hash{}) {
+			return
+		}
+
+		headHash, err := rawdb.ReadCanonicalHash(roTx, rewind.Head)
+		if err != nil || headHash == rewind.HeadHash {
+			return
+		}
+	}
+
+	service.FinishRewind()
+}
+
+// restoreMilestoneRewind resumes a rewind which the node did not get to do before it was stopped.
+func restoreMilestoneRewind(config *config) {
+	service := whitelist.GetWhitelistingService()
+	if service == nil || service.GetPendingRewind() == nil {
+		return
+	}
+
+	if err := config.chainDB.View(context.Background(), func(roTx kv.Tx) error {
+		if head := rawdb.ReadCurrentBlockNumber(roTx); head != nil {
+			finishMilestoneRewindIfDone(roTx, *head)
+		}
+		return nil
+	}); err != nil {
+		config.logger.Warn("[bor] Unable to check the pending milestone rewind", "err", err)
+	}
+
+	if rewind := service.GetPendingRewind(); rewind != nil {
+		rewindTo := rewind.RewindTo
+		BorMilestoneRewind.Store(&rewindTo)
+	}
+}
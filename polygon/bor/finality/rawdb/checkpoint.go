@@ -32,6 +32,7 @@ var (
 	ErrDBNotResponding                      = errors.New("failed to store the last finality struct")
 	ErrIncorrectLockFieldToStore            = errors.New("failed to marshal the lockField struct ")
 	ErrIncorrectLockField                   = errors.New("lock field in the DB is incorrect")
+	ErrEmptyLockField                       = errors.New("empty response while getting lock field")
 	ErrIncorrectFutureMilestoneFieldToStore = errors.New("failed to marshal the future milestone field struct ")
 	ErrIncorrectFutureMilestoneField        = errors.New("future milestone field  in the DB is incorrect")
 )
@@ -0,0 +1,215 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package rawdb
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+var (
+	milestoneRewindKey    = []byte("MilestoneRewind")
+	finalityHistoryPrefix = []byte("FinalityHistory")
+
+	ErrIncorrectMilestoneRewind = errors.New("milestone rewind in the DB is incorrect")
+	ErrIncorrectFinalityHistory = errors.New("finality history in the DB is incorrect")
+)
+
+// MilestoneRewind is a rewind of the chain decided by the whitelisting service, which is pending until
+// the chain has been unwound.
+type MilestoneRewind struct {
+	Head     uint64
+	HeadHash libcommon.Hash
+	RewindTo uint64
+	Source   string
+}
+
+// FinalityEvent is an entry of the whitelisting history, which explains the finality decisions of the node.
+type FinalityEvent struct {
+	Time        uint64
+	Kind        string
+	Source      string
+	Block       uint64
+	Hash        libcommon.Hash
+	MilestoneID string
+	Head        uint64
+}
+
+// WriteMilestoneRewind stores the pending rewind, a nil rewind clears it.
+func WriteMilestoneRewind(db kv.RwDB, rewind *MilestoneRewind) error {
+	err := db.Update(context.Background(), func(tx kv.RwTx) error {
+		// the table is dupsorted, so the previous rewind is deleted rather than overwritten
+		if err := tx.Delete(kv.BorFinality, milestoneRewindKey); err != nil {
+			return err
+		}
+
+		if rewind == nil {
+			return nil
+		}
+
+		enc, err := json.Marshal(rewind)
+		if err != nil {
+			return err
+		}
+
+		return tx.Put(kv.BorFinality, milestoneRewindKey, enc)
+	})
+
+	if err != nil {
+		log.Error("Failed to store the milestone rewind", "err", err)
+
+		return fmt.Errorf("%w: %v for milestone rewind", ErrDBNotResponding, err)
+	}
+
+	return nil
+}
+
+// ReadMilestoneRewind returns the pending rewind, or nil if there is none.
+func ReadMilestoneRewind(db kv.RwDB) (*MilestoneRewind, error) {
+	var data []byte
+	err := db.View(context.Background(), func(tx kv.Tx) error {
+		res, err := tx.GetOne(kv.BorFinality, milestoneRewindKey)
+		data = libcommon.Copy(res)
+		return err
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: empty response for milestone rewind", err)
+	}
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var rewind MilestoneRewind
+	if err = json.Unmarshal(data, &rewind); err != nil {
+		return nil, fmt.Errorf("%w(%v) for milestone rewind, data %v(%q)",
+			ErrIncorrectMilestoneRewind, err, data, string(data))
+	}
+
+	return &rewind, nil
+}
+
+// AppendFinalityHistory adds the event to the history, which keeps the latest limit events. Every event is
+// a row of its own, keyed by its sequence number, so that appending does not rewrite the whole history.
+func AppendFinalityHistory(db kv.RwDB, event FinalityEvent, limit uint64) error {
+	err := db.Update(context.Background(), func(tx kv.RwTx) error {
+		seq, err := nextFinalityHistorySeq(tx)
+		if err != nil {
+			return err
+		}
+
+		enc, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		if err = tx.Put(kv.BorFinality, finalityHistoryKey(seq), enc); err != nil {
+			return err
+		}
+
+		if seq < limit {
+			return nil
+		}
+
+		return tx.Delete(kv.BorFinality, finalityHistoryKey(seq-limit))
+	})
+
+	if err != nil {
+		log.Error("Failed to store the finality history", "err", err)
+
+		return fmt.Errorf("%w: %v for finality history", ErrDBNotResponding, err)
+	}
+
+	return nil
+}
+
+// ReadFinalityHistory returns the history events, oldest first.
+func ReadFinalityHistory(db kv.RwDB) ([]FinalityEvent, error) {
+	var history []FinalityEvent
+	err := db.View(context.Background(), func(tx kv.Tx) error {
+		it, err := tx.Prefix(kv.BorFinality, finalityHistoryPrefix)
+		if err != nil {
+			return fmt.Errorf("%w: empty response for finality history", err)
+		}
+		defer it.Close()
+
+		for it.HasNext() {
+			k, v, err := it.Next()
+			if err != nil {
+				return err
+			}
+
+			var event FinalityEvent
+			if err = json.Unmarshal(v, &event); err != nil {
+				return fmt.Errorf("%w(%v) for finality history, key %x, data %q", ErrIncorrectFinalityHistory, err, k, string(v))
+			}
+
+			history = append(history, event)
+		}
+
+		return nil
+	})
+
+	return history, err
+}
+
+func finalityHistoryKey(seq uint64) []byte {
+	key := make([]byte, len(finalityHistoryPrefix)+8)
+	copy(key, finalityHistoryPrefix)
+	binary.BigEndian.PutUint64(key[len(finalityHistoryPrefix):], seq)
+	return key
+}
+
+// nextFinalityHistorySeq returns the sequence number following the one of the latest history event.
+func nextFinalityHistorySeq(tx kv.RwTx) (uint64, error) {
+	c, err := tx.Cursor(kv.BorFinality)
+	if err != nil {
+		return 0, err
+	}
+	defer c.Close()
+
+	k, _, err := c.Seek(finalityHistoryKey(math.MaxUint64))
+	if err != nil {
+		return 0, err
+	}
+
+	if k == nil {
+		k, _, err = c.Last()
+	} else {
+		k, _, err = c.Prev()
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	if len(k) != len(finalityHistoryPrefix)+8 || !bytes.HasPrefix(k, finalityHistoryPrefix) {
+		return 0, nil
+	}
+
+	return binary.BigEndian.Uint64(k[len(finalityHistoryPrefix):]) + 1, nil
+}
@@ -191,7 +191,7 @@ func ReadLockField(db kv.RwDB) (bool, uint64, libcommon.Hash, map[string]struct{
 	}
 
 	if len(data) == 0 {
-		return false, 0, libcommon.Hash{}, nil, fmt.Errorf("%w for %s", ErrIncorrectLockField, string(key))
+		return false, 0, libcommon.Hash{}, nil, fmt.Errorf("%w for %s", ErrEmptyLockField, string(key))
 	}
 
 	if err = json.Unmarshal(data, &lockField); err != nil {
@@ -60,6 +60,8 @@ func Whitelist(heimdall heimdall.HeimdallClient, borDB kv.RwDB, chainDB kv.RwDB,
 		closeCh:     closeCh,
 	}
 
+	restoreMilestoneRewind(config)
+
 	go startCheckpointWhitelistService(config)
 	go startMilestoneWhitelistService(config)
 	go startNoAckMilestoneService(config)
@@ -50,6 +50,7 @@ func (w *checkpoint) IsValidChain(currentHeader uint64, chain []*types.Header) b
 		checkpointChainMeter.Inc()
 	} else {
 		checkpointChainMeter.Dec()
+		w.history.recordRefusedReorg(refusedByCheckpoint, currentHeader, chain)
 	}
 
 	return res
@@ -59,6 +60,10 @@ func (w *checkpoint) Process(block uint64, hash common.Hash) {
 	w.finality.Lock()
 	defer w.finality.Unlock()
 
+	if !w.doExist || w.Number != block || w.Hash != hash {
+		w.history.record(rawdb.FinalityEvent{Kind: FinalityEventCheckpoint, Block: block, Hash: hash})
+	}
+
 	w.finality.Process(block, hash)
 
 	whitelistedCheckpointNumberMeter.SetUint64(block)
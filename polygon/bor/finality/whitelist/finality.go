@@ -33,6 +33,7 @@ type finality[T rawdb.BlockFinality[T]] struct {
 	Number   uint64      // Number , populated by reaching out to heimdall
 	interval uint64      // Interval, until which we can allow importing
 	doExist  bool
+	history  *history
 }
 
 type finalityService interface {
@@ -0,0 +1,90 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package whitelist
+
+import (
+	"sync"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/polygon/bor/finality/rawdb"
+)
+
+// Kinds of the finality history events
+const (
+	FinalityEventCheckpoint      = "checkpoint"
+	FinalityEventMilestone       = "milestone"
+	FinalityEventFutureMilestone = "future-milestone"
+	FinalityEventLock            = "lock"
+	FinalityEventUnlock          = "unlock"
+	FinalityEventRewind          = "rewind"
+	FinalityEventRewindDone      = "rewind-done"
+	FinalityEventRefusedReorg    = "refused-reorg"
+)
+
+// Sources of the refused reorgs
+const (
+	refusedByCheckpoint      = "checkpoint"
+	refusedByMilestone       = "milestone"
+	refusedByLockedSprint    = "locked-sprint"
+	refusedByFutureMilestone = "future-milestone"
+)
+
+const finalityHistoryLimit = 256
+
+// history records the finality decisions of the whitelisting service, so that operators can find out
+// why the node refused a reorg or rewound its chain.
+type history struct {
+	db kv.RwDB
+
+	refusedLock sync.Mutex
+	lastRefused common.Hash // peers keep offering the same chain, so a refusal is only recorded once
+}
+
+func (h *history) record(event rawdb.FinalityEvent) {
+	if h == nil {
+		return
+	}
+
+	event.Time = uint64(time.Now().Unix())
+	if err := rawdb.AppendFinalityHistory(h.db, event, finalityHistoryLimit); err != nil {
+		log.Error("[bor] Error in writing finality history to db", "err", err)
+	}
+}
+
+// recordRefusedReorg records that the chain, offered on top of the current header, was refused.
+func (h *history) recordRefusedReorg(source string, currentHeader uint64, chain []*types.Header) {
+	if h == nil || len(chain) == 0 {
+		return
+	}
+
+	tip := chain[len(chain)-1]
+	hash := tip.Hash()
+
+	h.refusedLock.Lock()
+	defer h.refusedLock.Unlock()
+
+	if hash == h.lastRefused {
+		return
+	}
+
+	h.lastRefused = hash
+	h.record(rawdb.FinalityEvent{Kind: FinalityEventRefusedReorg, Source: source, Block: tip.Number.Uint64(), Hash: hash, Head: currentHeader})
+}
@@ -17,6 +17,8 @@
 package whitelist
 
 import (
+	"sort"
+
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon-lib/metrics"
@@ -36,6 +38,8 @@ type milestone struct {
 	FutureMilestoneList  map[uint64]common.Hash // Future Milestone list
 	FutureMilestoneOrder []uint64               // Future Milestone Order
 	MaxCapacity          int                    //Capacity of future Milestone list
+
+	PendingRewind *rawdb.MilestoneRewind // Rewind decided by the verifier, until the chain is unwound
 }
 
 type milestoneService interface {
@@ -47,6 +51,13 @@ type milestoneService interface {
 	UnlockMutex(doLock bool, milestoneId string, endBlockNum uint64, endBlockHash common.Hash)
 	UnlockSprint(endBlockNum uint64)
 	ProcessFutureMilestone(num uint64, hash common.Hash)
+
+	GetLockedMilestone() (bool, uint64, common.Hash, []string)
+	GetFutureMilestones() []rawdb.Finality
+	ProcessRewind(head uint64, headHash common.Hash, rewindTo uint64, source string)
+	GetPendingRewind() *rawdb.MilestoneRewind
+	FinishRewind()
+	GetFinalityHistory() ([]rawdb.FinalityEvent, error)
 }
 
 var (
@@ -87,16 +98,19 @@ func (m *milestone) IsValidChain(currentHeader uint64, chain []*types.Header) bo
 
 	if !res {
 		isValid = false
+		m.history.recordRefusedReorg(refusedByMilestone, currentHeader, chain)
 		return false
 	}
 
 	if m.Locked && !m.IsReorgAllowed(chain, m.LockedMilestoneNumber, m.LockedMilestoneHash) {
 		isValid = false
+		m.history.recordRefusedReorg(refusedByLockedSprint, currentHeader, chain)
 		return false
 	}
 
 	if !m.IsFutureMilestoneCompatible(chain) {
 		isValid = false
+		m.history.recordRefusedReorg(refusedByFutureMilestone, currentHeader, chain)
 		return false
 	}
 
@@ -108,6 +122,10 @@ func (m *milestone) Process(block uint64, hash common.Hash) {
 	m.finality.Lock()
 	defer m.finality.Unlock()
 
+	if !m.doExist || m.Number != block || m.Hash != hash {
+		m.history.record(rawdb.FinalityEvent{Kind: FinalityEventMilestone, Block: block, Hash: hash})
+	}
+
 	m.finality.Process(block, hash)
 
 	for i := 0; i < len(m.FutureMilestoneOrder); i++ {
@@ -142,14 +160,15 @@ func (m *milestone) LockMutex(endBlockNum uint64) bool {
 
 // UnlockMutex This function will unlock the mutex locked in LockMutex
 func (m *milestone) UnlockMutex(doLock bool, milestoneId string, endBlockNum uint64, endBlockHash common.Hash) {
-	m.Locked = m.Locked || doLock
-
 	if doLock {
+		// unlock the previous sprint first, so that only a sprint which was actually locked is recorded as unlocked
 		m.UnlockSprint(m.LockedMilestoneNumber)
 		m.Locked = true
 		m.LockedMilestoneHash = endBlockHash
 		m.LockedMilestoneNumber = endBlockNum
 		m.LockedMilestoneIDs[milestoneId] = struct{}{}
+
+		m.history.record(rawdb.FinalityEvent{Kind: FinalityEventLock, Block: endBlockNum, Hash: endBlockHash, MilestoneID: milestoneId})
 	}
 
 	err := rawdb.WriteLockField(m.db, m.Locked, m.LockedMilestoneNumber, m.LockedMilestoneHash, m.LockedMilestoneIDs)
@@ -172,6 +191,10 @@ func (m *milestone) UnlockSprint(endBlockNum uint64) {
 		defer m.finality.Unlock()
 	}
 
+	if m.Locked {
+		m.history.record(rawdb.FinalityEvent{Kind: FinalityEventUnlock, Block: m.LockedMilestoneNumber, Hash: m.LockedMilestoneHash})
+	}
+
 	m.Locked = false
 
 	m.purgeMilestoneIDsList()
@@ -191,6 +214,11 @@ func (m *milestone) RemoveMilestoneID(milestoneId string) {
 	delete(m.LockedMilestoneIDs, milestoneId)
 
 	if len(m.LockedMilestoneIDs) == 0 {
+		if m.Locked {
+			// the milestones of the locked sprint have not been acknowledged
+			m.history.record(rawdb.FinalityEvent{Kind: FinalityEventUnlock, Block: m.LockedMilestoneNumber, Hash: m.LockedMilestoneHash, MilestoneID: milestoneId})
+		}
+
 		m.Locked = false
 	}
 
@@ -278,6 +306,10 @@ func (m *milestone) ProcessFutureMilestone(num uint64, hash common.Hash) {
 		return
 	}
 
+	if m.Locked {
+		m.history.record(rawdb.FinalityEvent{Kind: FinalityEventUnlock, Block: m.LockedMilestoneNumber, Hash: m.LockedMilestoneHash})
+	}
+
 	m.Locked = false
 	m.purgeMilestoneIDsList()
 	purgedMilestoneIDs := map[string]struct{}{}
@@ -297,6 +329,8 @@ func (m *milestone) enqueueFutureMilestone(key uint64, hash common.Hash) {
 
 	log.Debug("[bor] Enqueing new future milestone", "endBlockNumber", key, "futureMilestoneHash", hash)
 
+	m.history.record(rawdb.FinalityEvent{Kind: FinalityEventFutureMilestone, Block: key, Hash: hash})
+
 	m.FutureMilestoneList[key] = hash
 	m.FutureMilestoneOrder = append(m.FutureMilestoneOrder, key)
 
@@ -318,3 +352,82 @@ func (m *milestone) dequeueFutureMilestone() {
 		log.Error("[bor] Error in writing future milestone data to db", "err", err)
 	}
 }
+
+// GetLockedMilestone returns the locked sprint of the form (locked, end block number, end block hash, milestone ids).
+func (m *milestone) GetLockedMilestone() (bool, uint64, common.Hash, []string) {
+	m.finality.RLock()
+	defer m.finality.RUnlock()
+
+	ids := make([]string, 0, len(m.LockedMilestoneIDs))
+	for id := range m.LockedMilestoneIDs {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	return m.Locked, m.LockedMilestoneNumber, m.LockedMilestoneHash, ids
+}
+
+// GetFutureMilestones returns the future milestones, in the order they were received.
+func (m *milestone) GetFutureMilestones() []rawdb.Finality {
+	m.finality.RLock()
+	defer m.finality.RUnlock()
+
+	futureMilestones := make([]rawdb.Finality, 0, len(m.FutureMilestoneOrder))
+	for _, num := range m.FutureMilestoneOrder {
+		futureMilestones = append(futureMilestones, rawdb.Finality{Block: num, Hash: m.FutureMilestoneList[num]})
+	}
+
+	return futureMilestones
+}
+
+// ProcessRewind keeps the rewind decided by the verifier until the chain has been unwound, so that it
+// survives a restart.
+func (m *milestone) ProcessRewind(head uint64, headHash common.Hash, rewindTo uint64, source string) {
+	m.finality.Lock()
+	defer m.finality.Unlock()
+
+	m.PendingRewind = &rawdb.MilestoneRewind{Head: head, HeadHash: headHash, RewindTo: rewindTo, Source: source}
+
+	if err := rawdb.WriteMilestoneRewind(m.db, m.PendingRewind); err != nil {
+		log.Error("[bor] Error in writing milestone rewind to db", "err", err)
+	}
+
+	m.history.record(rawdb.FinalityEvent{Kind: FinalityEventRewind, Source: source, Block: rewindTo, Hash: headHash, Head: head})
+}
+
+// GetPendingRewind returns the rewind which the chain has yet to do, or nil.
+func (m *milestone) GetPendingRewind() *rawdb.MilestoneRewind {
+	m.finality.RLock()
+	defer m.finality.RUnlock()
+
+	if m.PendingRewind == nil {
+		return nil
+	}
+
+	rewind := *m.PendingRewind
+	return &rewind
+}
+
+// FinishRewind clears the pending rewind once the unwind of the chain has been committed.
+func (m *milestone) FinishRewind() {
+	m.finality.Lock()
+	defer m.finality.Unlock()
+
+	if m.PendingRewind == nil {
+		return
+	}
+
+	m.history.record(rawdb.FinalityEvent{Kind: FinalityEventRewindDone, Source: m.PendingRewind.Source, Block: m.PendingRewind.RewindTo, Hash: m.PendingRewind.HeadHash, Head: m.PendingRewind.Head})
+
+	m.PendingRewind = nil
+
+	if err := rawdb.WriteMilestoneRewind(m.db, nil); err != nil {
+		log.Error("[bor] Error in writing milestone rewind to db", "err", err)
+	}
+}
+
+// GetFinalityHistory returns the recorded finality decisions, oldest first.
+func (m *milestone) GetFinalityHistory() ([]rawdb.FinalityEvent, error) {
+	return rawdb.ReadFinalityHistory(m.db)
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon/core/types"
 	"github.com/erigontech/erigon/polygon/bor/finality/rawdb"
 )
@@ -61,10 +62,24 @@ func NewService(db kv.RwDB) *Service {
 	}
 
 	locked, lockedMilestoneNumber, lockedMilestoneHash, lockedMilestoneIDs, err := rawdb.ReadLockField(db)
+	if err != nil && !errors.Is(err, rawdb.ErrEmptyLockField) {
+		log.Warn("[bor] Unable to restore the locked sprint", "err", err)
+	}
 	if err != nil || !locked {
 		locked = false
 		lockedMilestoneIDs = make(map[string]struct{})
 	}
+	if lockedMilestoneIDs == nil {
+		lockedMilestoneIDs = make(map[string]struct{})
+	}
+	if locked && milestoneDoExist && lockedMilestoneNumber <= milestoneNumber {
+		// the sprint was unlocked by its milestone, but the node stopped before the lock field was written
+		locked = false
+		lockedMilestoneIDs = make(map[string]struct{})
+		if err := rawdb.WriteLockField(db, locked, lockedMilestoneNumber, lockedMilestoneHash, lockedMilestoneIDs); err != nil {
+			log.Error("[bor] Error in writing lock data of milestone to db", "err", err)
+		}
+	}
 
 	order, list, err := rawdb.ReadFutureMilestoneList(db)
 	if err != nil {
@@ -72,6 +87,13 @@ func NewService(db kv.RwDB) *Service {
 		list = make(map[uint64]common.Hash)
 	}
 
+	pendingRewind, err := rawdb.ReadMilestoneRewind(db)
+	if err != nil {
+		log.Warn("[bor] Unable to restore the pending milestone rewind", "err", err)
+	}
+
+	history := &history{db: db}
+
 	return &Service{
 		&checkpoint{
 			finality[*rawdb.Checkpoint]{
@@ -80,6 +102,7 @@ func NewService(db kv.RwDB) *Service {
 				Hash:     checkpointHash,
 				interval: 256,
 				db:       db,
+				history:  history,
 			},
 		},
 
@@ -90,6 +113,7 @@ func NewService(db kv.RwDB) *Service {
 				Hash:     milestoneHash,
 				interval: 256,
 				db:       db,
+				history:  history,
 			},
 
 			Locked:                locked,
@@ -99,6 +123,7 @@ func NewService(db kv.RwDB) *Service {
 			FutureMilestoneList:   list,
 			FutureMilestoneOrder:  order,
 			MaxCapacity:           10,
+			PendingRewind:         pendingRewind,
 		},
 	}
 }
@@ -954,3 +954,104 @@ func addTestCaseParams(mXNM map[int]map[int]map[int]struct{}, x, n, m int) {
 
 	mXNM[x][n][m] = struct{}{}
 }
+
+// TestServiceRestart checks that the locked sprint, the future milestones, the pending rewind and the
+// finality history survive a restart of the whitelisting service.
+func TestServiceRestart(t *testing.T) {
+	t.Parallel()
+
+	db := memdb.NewTestDB(t)
+
+	s := NewService(db)
+
+	s.ProcessCheckpoint(uint64(256), common.Hash{0x1})
+	s.ProcessMilestone(uint64(300), common.Hash{0x2})
+
+	require.True(t, s.LockMutex(uint64(320)))
+	s.UnlockMutex(true, "milestoneID1", uint64(320), common.Hash{0x3})
+
+	s.ProcessFutureMilestone(uint64(310), common.Hash{0x4})
+	s.ProcessRewind(uint64(330), common.Hash{0x5}, uint64(300), "milestone")
+
+	s = NewService(db)
+
+	locked, lockedNumber, lockedHash, lockedIDs := s.GetLockedMilestone()
+	require.True(t, locked, "the locked sprint should survive a restart")
+	require.Equal(t, uint64(320), lockedNumber)
+	require.Equal(t, common.Hash{0x3}, lockedHash)
+	require.Equal(t, []string{"milestoneID1"}, lockedIDs)
+
+	require.Equal(t, []rawdb.Finality{{Block: 310, Hash: common.Hash{0x4}}}, s.GetFutureMilestones())
+	require.Equal(t, &rawdb.MilestoneRewind{Head: 330, HeadHash: common.Hash{0x5}, RewindTo: 300, Source: "milestone"}, s.GetPendingRewind())
+
+	history, err := s.GetFinalityHistory()
+	require.NoError(t, err)
+
+	kinds := make([]string, 0, len(history))
+	for _, event := range history {
+		kinds = append(kinds, event.Kind)
+	}
+
+	require.Equal(t, []string{
+		FinalityEventCheckpoint,
+		FinalityEventMilestone,
+		FinalityEventLock,
+		FinalityEventFutureMilestone,
+		FinalityEventRewind,
+	}, kinds)
+
+	s.FinishRewind()
+
+	s = NewService(db)
+	require.Nil(t, s.GetPendingRewind(), "a finished rewind should not be restored")
+
+	// the sprint was unlocked by its milestone, but the lock field was not written before the restart
+	require.NoError(t, rawdb.WriteLastFinality[*rawdb.Milestone](db, 320, common.Hash{0x3}))
+
+	s = NewService(db)
+
+	locked, _, _, _ = s.GetLockedMilestone()
+	require.False(t, locked, "a sprint covered by the whitelisted milestone should not stay locked")
+
+	locked, _, _, _, err = rawdb.ReadLockField(db)
+	require.NoError(t, err)
+	require.False(t, locked)
+}
+
+// TestFinalityHistory checks that the refused reorgs are recorded once, and that the history keeps the
+// latest events across restarts.
+func TestFinalityHistory(t *testing.T) {
+	t.Parallel()
+
+	db := memdb.NewTestDB(t)
+
+	s := NewService(db)
+
+	s.ProcessCheckpoint(uint64(256), common.Hash{0x1})
+
+	chain := createMockChain(250, 260)
+	require.False(t, s.IsValidChain(uint64(256), chain))
+	require.False(t, s.IsValidChain(uint64(256), chain), "the same chain should be refused again")
+
+	history, err := s.GetFinalityHistory()
+	require.NoError(t, err)
+	require.Len(t, history, 2, "a chain refused twice should be recorded once")
+	require.Equal(t, FinalityEventRefusedReorg, history[1].Kind)
+	require.Equal(t, refusedByCheckpoint, history[1].Source)
+	require.Equal(t, uint64(260), history[1].Block)
+	require.Equal(t, chain[len(chain)-1].Hash(), history[1].Hash)
+	require.Equal(t, uint64(256), history[1].Head)
+
+	for i := uint64(1); i <= finalityHistoryLimit; i++ {
+		s.ProcessMilestone(256+i, common.Hash{0x2})
+	}
+
+	s = NewService(db)
+	s.ProcessMilestone(uint64(1000), common.Hash{0x3})
+
+	history, err = s.GetFinalityHistory()
+	require.NoError(t, err)
+	require.Len(t, history, finalityHistoryLimit)
+	require.Equal(t, uint64(256+2), history[0].Block, "the oldest events should be trimmed")
+	require.Equal(t, uint64(1000), history[len(history)-1].Block, "the sequence should survive a restart")
+}
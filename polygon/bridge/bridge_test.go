@@ -16,6 +16,7 @@ import (
 	"github.com/erigontech/erigon/core/types"
 	"github.com/erigontech/erigon/polygon/bor/borcfg"
 	"github.com/erigontech/erigon/polygon/heimdall"
+	"github.com/erigontech/erigon/polygon/heimdall/heimdallfixture"
 	"github.com/erigontech/erigon/turbo/testlog"
 )
 
@@ -429,3 +430,75 @@ func TestBridge_ProcessNewBlocksWithZeroOverride(t *testing.T) {
 	cancel()
 	wg.Wait()
 }
+
+func TestBridgeWithHeimdallFixture(t *testing.T) {
+	for _, version := range []heimdall.ClientVersion{heimdall.ClientVersionV1, heimdall.ClientVersionV2} {
+		t.Run(string(version), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			t.Cleanup(cancel)
+
+			logger := testlog.Logger(t, log.LvlDebug)
+			events := []*heimdall.EventRecordWithTime{
+				// pre-indore: event1 and event2 fall in block4 (toTime=preSprintBlockTime=100)
+				{EventRecord: heimdall.EventRecord{ID: 1, ChainID: "80002", Data: hexutil.MustDecode("0x01")}, Time: time.Unix(50, 0).UTC()},
+				{EventRecord: heimdall.EventRecord{ID: 2, ChainID: "80002", Data: hexutil.MustDecode("0x02")}, Time: time.Unix(99, 0).UTC()},
+				// pre-indore: event3 falls in block6 (toTime=preSprintBlockTime=200)
+				{EventRecord: heimdall.EventRecord{ID: 3, ChainID: "80002", Data: hexutil.MustDecode("0x03")}, Time: time.Unix(199, 0).UTC()},
+				// post-indore: event4 falls in block10 (toTime=currentSprintBlockTime-delay=500-1=499)
+				{EventRecord: heimdall.EventRecord{ID: 4, ChainID: "80002", Data: hexutil.MustDecode("0x04")}, Time: time.Unix(498, 0).UTC()},
+			}
+			server := heimdallfixture.NewServer(t, version, heimdallfixture.Entities{Events: events})
+
+			borConfig := defaultBorConfig
+			b := Assemble(Config{
+				DataDir:      t.TempDir(),
+				Logger:       logger,
+				BorConfig:    &borConfig,
+				EventFetcher: server.NewClient(t, heimdall.ClientVersionAuto, logger),
+				RoTxLimit:    1,
+			})
+			t.Cleanup(b.Close)
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := b.Run(ctx); err != nil && !errors.Is(err, ctx.Err()) {
+					t.Error(err)
+				}
+			}()
+
+			err := b.store.Prepare(ctx)
+			require.NoError(t, err)
+
+			genesis := types.NewBlockWithHeader(&types.Header{Time: 1, Number: big.NewInt(0)})
+			err = b.ReplayInitialBlock(ctx, genesis)
+			require.NoError(t, err)
+
+			err = b.ProcessNewBlocks(ctx, getBlocks(t, 10))
+			require.NoError(t, err)
+
+			err = b.Synchronize(ctx, 10)
+			require.NoError(t, err)
+
+			for blockNum, wantEvents := range map[uint64][]*heimdall.EventRecordWithTime{
+				2:  nil,
+				4:  events[0:2],
+				6:  events[2:3],
+				10: events[3:4],
+			} {
+				res, err := b.Events(ctx, blockNum)
+				require.NoError(t, err)
+				require.Len(t, res, len(wantEvents))
+				for i, event := range wantEvents {
+					eventData, err := event.MarshallBytes()
+					require.NoError(t, err)
+					require.Equal(t, eventData, res[i].Data())
+				}
+			}
+
+			cancel()
+			wg.Wait()
+		})
+	}
+}
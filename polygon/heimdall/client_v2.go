@@ -0,0 +1,504 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package heimdall
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/polygon/bor/valset"
+)
+
+// ClientV2 talks to the REST gateway of Heimdall v2. Heimdall v2 is built on CometBFT and serves the protobuf types
+// of its gRPC API as JSON: 64 bit integers are strings, byte arrays are base64 and lists are paginated with offsets.
+type ClientV2 struct {
+	// client provides the transport, retries and metrics, which are the same as the ones of v1.
+	client *Client
+}
+
+var _ HeimdallClient = &ClientV2{}
+
+func NewHeimdallClientV2(urlString string, logger log.Logger) *ClientV2 {
+	httpClient := &http.Client{
+		Timeout: apiHeimdallTimeout,
+	}
+	return newHeimdallClientV2(urlString, httpClient, retryBackOff, maxRetries, logger)
+}
+
+func newHeimdallClientV2(urlString string, httpClient HttpClient, retryBackOff time.Duration, maxRetries int, logger log.Logger) *ClientV2 {
+	return &ClientV2{client: newHeimdallClient(urlString, httpClient, retryBackOff, maxRetries, logger)}
+}
+
+const (
+	fetchStateSyncEventsV2Format = "from_id=%d&to_time=%s&pagination.limit=%d"
+	fetchStateSyncEventsV2Path   = "clerk/time"
+	fetchStateSyncEventV2        = "clerk/event-records/%d"
+
+	fetchCheckpointV2                = "/checkpoints/%s"
+	fetchCheckpointCountV2           = "/checkpoints/count"
+	fetchCheckpointListV2            = "/checkpoints/list"
+	fetchCheckpointListQueryFormatV2 = "pagination.offset=%d&pagination.limit=%d"
+
+	fetchMilestoneAtV2     = "/milestones/%d"
+	fetchMilestoneLatestV2 = "/milestones/latest"
+	fetchMilestoneCountV2  = "/milestones/count"
+
+	fetchSpanFormatV2     = "bor/spans/%d"
+	fetchSpanLatestV2     = "bor/spans/latest"
+	fetchSpanListFormatV2 = "pagination.offset=%d&pagination.limit=%d"
+	fetchSpanListPathV2   = "bor/spans/list"
+)
+
+// errNoAckMilestonesNotSupported is returned for the no-ack milestone queries, which Heimdall v2 dropped. It wraps
+// ErrServiceUnavailable, which the callers already treat as an endpoint which is not there (yet).
+var errNoAckMilestonesNotSupported = fmt.Errorf("%w: no-ack milestones are not served by heimdall v2", ErrServiceUnavailable)
+
+func isNotFoundError(err error) bool {
+	return errors.Is(err, ErrNotSuccessfulResponse) && strings.Contains(err.Error(), fmt.Sprintf("status=%d", http.StatusNotFound))
+}
+
+func (c *ClientV2) FetchStateSyncEvents(ctx context.Context, fromID uint64, to time.Time, limit int) ([]*EventRecordWithTime, error) {
+	eventRecords := make([]*EventRecordWithTime, 0)
+
+	for {
+		url, err := makeURL(c.client.urlString, fetchStateSyncEventsV2Path,
+			fmt.Sprintf(fetchStateSyncEventsV2Format, fromID, url.QueryEscape(to.UTC().Format(time.RFC3339)), StateEventsFetchLimit))
+		if err != nil {
+			return nil, err
+		}
+
+		c.client.logger.Trace(heimdallLogPrefix("Fetching state sync events"), "queryParams", url.RawQuery)
+
+		response, err := FetchWithRetry[stateSyncEventsResponseV2](withRequestType(ctx, stateSyncRequest), c.client, url, c.client.logger)
+		if err != nil {
+			return nil, err
+		}
+		if response == nil || len(response.EventRecords) == 0 {
+			break
+		}
+
+		for _, record := range response.EventRecords {
+			eventRecords = append(eventRecords, record.toEventRecord())
+		}
+
+		if len(response.EventRecords) < StateEventsFetchLimit || (limit > 0 && len(eventRecords) >= limit) {
+			break
+		}
+
+		fromID += uint64(StateEventsFetchLimit)
+	}
+
+	sort.SliceStable(eventRecords, func(i, j int) bool {
+		return eventRecords[i].ID < eventRecords[j].ID
+	})
+
+	return eventRecords, nil
+}
+
+func (c *ClientV2) FetchStateSyncEvent(ctx context.Context, id uint64) (*EventRecordWithTime, error) {
+	url, err := makeURL(c.client.urlString, fmt.Sprintf(fetchStateSyncEventV2, id), "")
+	if err != nil {
+		return nil, err
+	}
+
+	isRecoverableError := func(err error) bool {
+		return !isNotFoundError(err)
+	}
+
+	response, err := FetchWithRetryEx[stateSyncEventResponseV2](withRequestType(ctx, stateSyncRequest), c.client, url, isRecoverableError, c.client.logger)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, ErrEventRecordNotFound
+		}
+		return nil, err
+	}
+
+	return response.Record.toEventRecord(), nil
+}
+
+func (c *ClientV2) FetchLatestSpan(ctx context.Context) (*Span, error) {
+	url, err := makeURL(c.client.urlString, fetchSpanLatestV2, "")
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := FetchWithRetry[spanResponseV2](withRequestType(ctx, spanRequest), c.client, url, c.client.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Span.toSpan(), nil
+}
+
+func (c *ClientV2) FetchSpan(ctx context.Context, spanID uint64) (*Span, error) {
+	url, err := makeURL(c.client.urlString, fmt.Sprintf(fetchSpanFormatV2, spanID), "")
+	if err != nil {
+		return nil, fmt.Errorf("%w, spanID=%d", err, spanID)
+	}
+
+	response, err := FetchWithRetry[spanResponseV2](withRequestType(ctx, spanRequest), c.client, url, c.client.logger)
+	if err != nil {
+		return nil, fmt.Errorf("%w, spanID=%d", err, spanID)
+	}
+
+	return response.Span.toSpan(), nil
+}
+
+func (c *ClientV2) FetchSpans(ctx context.Context, page uint64, limit uint64) ([]*Span, error) {
+	url, err := makeURL(c.client.urlString, fetchSpanListPathV2, fmt.Sprintf(fetchSpanListFormatV2, pageOffset(page, limit), limit))
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := FetchWithRetry[spanListResponseV2](withRequestType(ctx, checkpointListRequest), c.client, url, c.client.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	spans := make([]*Span, len(response.SpanList))
+	for i, span := range response.SpanList {
+		spans[i] = span.toSpan()
+	}
+
+	return spans, nil
+}
+
+func (c *ClientV2) FetchCheckpoint(ctx context.Context, number int64) (*Checkpoint, error) {
+	id := "latest"
+	if number != -1 {
+		id = strconv.FormatInt(number, 10)
+	}
+	url, err := makeURL(c.client.urlString, fmt.Sprintf(fetchCheckpointV2, id), "")
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := FetchWithRetry[checkpointResponseV2](withRequestType(ctx, checkpointRequest), c.client, url, c.client.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Checkpoint.toCheckpoint(), nil
+}
+
+func (c *ClientV2) FetchCheckpointCount(ctx context.Context) (int64, error) {
+	url, err := makeURL(c.client.urlString, fetchCheckpointCountV2, "")
+	if err != nil {
+		return 0, err
+	}
+
+	response, err := FetchWithRetry[checkpointCountResponseV2](withRequestType(ctx, checkpointCountRequest), c.client, url, c.client.logger)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(response.AckCount), nil
+}
+
+func (c *ClientV2) FetchCheckpoints(ctx context.Context, page uint64, limit uint64) ([]*Checkpoint, error) {
+	url, err := makeURL(c.client.urlString, fetchCheckpointListV2, fmt.Sprintf(fetchCheckpointListQueryFormatV2, pageOffset(page, limit), limit))
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := FetchWithRetry[checkpointListResponseV2](withRequestType(ctx, checkpointListRequest), c.client, url, c.client.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoints := make([]*Checkpoint, len(response.CheckpointList))
+	for i, checkpoint := range response.CheckpointList {
+		checkpoints[i] = checkpoint.toCheckpoint()
+	}
+
+	return checkpoints, nil
+}
+
+// FetchMilestone fetches a milestone from heimdall. Heimdall v2 milestones do not carry a number, like in v1 it is
+// the one which was asked for.
+func (c *ClientV2) FetchMilestone(ctx context.Context, number int64) (*Milestone, error) {
+	path := fetchMilestoneLatestV2
+	if number != -1 {
+		path = fmt.Sprintf(fetchMilestoneAtV2, number)
+	}
+	url, err := makeURL(c.client.urlString, path, "")
+	if err != nil {
+		return nil, err
+	}
+
+	isRecoverableError := func(err error) bool {
+		return !isNotFoundError(err) || number == -1
+	}
+
+	response, err := FetchWithRetryEx[milestoneResponseV2](withRequestType(ctx, milestoneRequest), c.client, url, isRecoverableError, c.client.logger)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, fmt.Errorf("%w: number %d", ErrNotInMilestoneList, number)
+		}
+		return nil, err
+	}
+
+	milestone := response.Milestone.toMilestone()
+	milestone.Id = MilestoneId(number)
+
+	return milestone, nil
+}
+
+func (c *ClientV2) FetchMilestoneCount(ctx context.Context) (int64, error) {
+	url, err := makeURL(c.client.urlString, fetchMilestoneCountV2, "")
+	if err != nil {
+		return 0, err
+	}
+
+	response, err := FetchWithRetry[milestoneCountResponseV2](withRequestType(ctx, milestoneCountRequest), c.client, url, c.client.logger)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(response.Count), nil
+}
+
+// FetchFirstMilestoneNum only looks at the latest milestones, same as for v1, as there is no use in syncing the
+// older ones.
+func (c *ClientV2) FetchFirstMilestoneNum(ctx context.Context) (int64, error) {
+	count, err := c.FetchMilestoneCount(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if count < milestonePruneNumber {
+		return 1, nil
+	}
+
+	return count - milestonePruneNumber + 1, nil
+}
+
+func (c *ClientV2) FetchNoAckMilestone(ctx context.Context, milestoneID string) error {
+	return errNoAckMilestonesNotSupported
+}
+
+func (c *ClientV2) FetchLastNoAckMilestone(ctx context.Context) (string, error) {
+	return "", errNoAckMilestonesNotSupported
+}
+
+func (c *ClientV2) FetchMilestoneID(ctx context.Context, milestoneID string) error {
+	return errNoAckMilestonesNotSupported
+}
+
+func (c *ClientV2) Close() {
+	c.client.Close()
+}
+
+// pageOffset converts the 1-based pages of v1 to the offsets of v2.
+func pageOffset(page uint64, limit uint64) uint64 {
+	if page == 0 {
+		return 0
+	}
+	return (page - 1) * limit
+}
+
+// protoUint64 and protoInt64 decode the 64 bit integers of the protobuf JSON encoding, which are strings, and also
+// accept plain numbers.
+type protoUint64 uint64
+
+func (n *protoUint64) UnmarshalJSON(b []byte) error {
+	v, err := strconv.ParseUint(string(bytes.Trim(b, `"`)), 10, 64)
+	if err != nil {
+		return err
+	}
+	*n = protoUint64(v)
+	return nil
+}
+
+type protoInt64 int64
+
+func (n *protoInt64) UnmarshalJSON(b []byte) error {
+	v, err := strconv.ParseInt(string(bytes.Trim(b, `"`)), 10, 64)
+	if err != nil {
+		return err
+	}
+	*n = protoInt64(v)
+	return nil
+}
+
+type eventRecordV2 struct {
+	ID         protoUint64       `json:"id"`
+	Contract   libcommon.Address `json:"contract"`
+	Data       []byte            `json:"data"`
+	TxHash     libcommon.Hash    `json:"tx_hash"`
+	LogIndex   protoUint64       `json:"log_index"`
+	ChainID    string            `json:"bor_chain_id"`
+	RecordTime time.Time         `json:"record_time"`
+}
+
+func (r *eventRecordV2) toEventRecord() *EventRecordWithTime {
+	return &EventRecordWithTime{
+		EventRecord: EventRecord{
+			ID:       uint64(r.ID),
+			Contract: r.Contract,
+			Data:     r.Data,
+			TxHash:   r.TxHash,
+			LogIndex: uint64(r.LogIndex),
+			ChainID:  r.ChainID,
+		},
+		Time: r.RecordTime,
+	}
+}
+
+type stateSyncEventsResponseV2 struct {
+	EventRecords []*eventRecordV2 `json:"event_records"`
+}
+
+type stateSyncEventResponseV2 struct {
+	Record eventRecordV2 `json:"record"`
+}
+
+type validatorV2 struct {
+	ValID            protoUint64       `json:"val_id"`
+	VotingPower      protoInt64        `json:"voting_power"`
+	Signer           libcommon.Address `json:"signer"`
+	ProposerPriority protoInt64        `json:"proposer_priority"`
+}
+
+func (v *validatorV2) toValidator() *valset.Validator {
+	return &valset.Validator{
+		ID:               uint64(v.ValID),
+		Address:          v.Signer,
+		VotingPower:      int64(v.VotingPower),
+		ProposerPriority: int64(v.ProposerPriority),
+	}
+}
+
+type spanV2 struct {
+	ID           protoUint64 `json:"id"`
+	StartBlock   protoUint64 `json:"start_block"`
+	EndBlock     protoUint64 `json:"end_block"`
+	ValidatorSet struct {
+		Validators []*validatorV2 `json:"validators"`
+		Proposer   *validatorV2   `json:"proposer"`
+	} `json:"validator_set"`
+	SelectedProducers []*validatorV2 `json:"selected_producers"`
+	ChainID           string         `json:"bor_chain_id"`
+}
+
+func (s *spanV2) toSpan() *Span {
+	span := &Span{
+		Id:                SpanId(s.ID),
+		StartBlock:        uint64(s.StartBlock),
+		EndBlock:          uint64(s.EndBlock),
+		SelectedProducers: make([]valset.Validator, len(s.SelectedProducers)),
+		ChainID:           s.ChainID,
+	}
+	span.ValidatorSet.Validators = make([]*valset.Validator, len(s.ValidatorSet.Validators))
+	for i, validator := range s.ValidatorSet.Validators {
+		span.ValidatorSet.Validators[i] = validator.toValidator()
+	}
+	if s.ValidatorSet.Proposer != nil {
+		span.ValidatorSet.Proposer = s.ValidatorSet.Proposer.toValidator()
+	}
+	for i, producer := range s.SelectedProducers {
+		span.SelectedProducers[i] = *producer.toValidator()
+	}
+	return span
+}
+
+type spanResponseV2 struct {
+	Span spanV2 `json:"span"`
+}
+
+type spanListResponseV2 struct {
+	SpanList []*spanV2 `json:"span_list"`
+}
+
+type checkpointV2 struct {
+	ID         protoUint64       `json:"id"`
+	Proposer   libcommon.Address `json:"proposer"`
+	StartBlock protoUint64       `json:"start_block"`
+	EndBlock   protoUint64       `json:"end_block"`
+	RootHash   []byte            `json:"root_hash"`
+	ChainID    string            `json:"bor_chain_id"`
+	Timestamp  protoUint64       `json:"timestamp"`
+}
+
+func (c *checkpointV2) toCheckpoint() *Checkpoint {
+	return &Checkpoint{
+		Id: CheckpointId(c.ID),
+		Fields: WaypointFields{
+			Proposer:   c.Proposer,
+			StartBlock: new(big.Int).SetUint64(uint64(c.StartBlock)),
+			EndBlock:   new(big.Int).SetUint64(uint64(c.EndBlock)),
+			RootHash:   libcommon.BytesToHash(c.RootHash),
+			ChainID:    c.ChainID,
+			Timestamp:  uint64(c.Timestamp),
+		},
+	}
+}
+
+type checkpointResponseV2 struct {
+	Checkpoint checkpointV2 `json:"checkpoint"`
+}
+
+type checkpointListResponseV2 struct {
+	CheckpointList []*checkpointV2 `json:"checkpoint_list"`
+}
+
+type checkpointCountResponseV2 struct {
+	AckCount protoUint64 `json:"ack_count"`
+}
+
+type milestoneV2 struct {
+	Proposer    libcommon.Address `json:"proposer"`
+	StartBlock  protoUint64       `json:"start_block"`
+	EndBlock    protoUint64       `json:"end_block"`
+	Hash        []byte            `json:"hash"`
+	ChainID     string            `json:"bor_chain_id"`
+	MilestoneID string            `json:"milestone_id"`
+	Timestamp   protoUint64       `json:"timestamp"`
+}
+
+func (m *milestoneV2) toMilestone() *Milestone {
+	return &Milestone{
+		MilestoneId: m.MilestoneID,
+		Fields: WaypointFields{
+			Proposer:   m.Proposer,
+			StartBlock: new(big.Int).SetUint64(uint64(m.StartBlock)),
+			EndBlock:   new(big.Int).SetUint64(uint64(m.EndBlock)),
+			RootHash:   libcommon.BytesToHash(m.Hash),
+			ChainID:    m.ChainID,
+			Timestamp:  uint64(m.Timestamp),
+		},
+	}
+}
+
+type milestoneResponseV2 struct {
+	Milestone milestoneV2 `json:"milestone"`
+}
+
+type milestoneCountResponseV2 struct {
+	Count protoUint64 `json:"count"`
+}
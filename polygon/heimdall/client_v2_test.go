@@ -0,0 +1,84 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package heimdall
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/turbo/testlog"
+)
+
+func TestHeimdallClientV2FetchCheckpointDecodesProtoJson(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	httpClient := NewMockHttpClient(ctrl)
+	httpClient.EXPECT().
+		Do(gomock.Any()).
+		DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			require.Equal(t, "/checkpoints/7", req.URL.Path)
+			body := `{"checkpoint":{"id":"7","proposer":"0x0000000000000000000000000000000000000001","start_block":"100",` +
+				`"end_block":199,"root_hash":"AQI=","bor_chain_id":"137","timestamp":"1700000000"}}`
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(body))}, nil
+		}).
+		Times(1)
+	logger := testlog.Logger(t, log.LvlDebug)
+	heimdallClient := newHeimdallClientV2("https://dummyheimdal.com", httpClient, time.Millisecond, 2, logger)
+
+	checkpoint, err := heimdallClient.FetchCheckpoint(ctx, 7)
+	require.NoError(t, err)
+	require.Equal(t, CheckpointId(7), checkpoint.Id)
+	require.Equal(t, uint64(100), checkpoint.StartBlock().Uint64())
+	require.Equal(t, uint64(199), checkpoint.EndBlock().Uint64())
+	require.Equal(t, byte(1), checkpoint.RootHash()[30])
+	require.Equal(t, byte(2), checkpoint.RootHash()[31])
+	require.Equal(t, uint64(1700000000), checkpoint.Timestamp())
+}
+
+func TestHeimdallClientV2NoAckMilestonesAreUnavailable(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	logger := testlog.Logger(t, log.LvlDebug)
+	heimdallClient := newHeimdallClientV2("https://dummyheimdal.com", NewMockHttpClient(ctrl), time.Millisecond, 2, logger)
+
+	_, err := heimdallClient.FetchLastNoAckMilestone(ctx)
+	require.ErrorIs(t, err, ErrServiceUnavailable)
+	err = heimdallClient.FetchNoAckMilestone(ctx, "id")
+	require.ErrorIs(t, err, ErrServiceUnavailable)
+}
+
+func TestParseClientVersion(t *testing.T) {
+	version, err := ParseClientVersion("")
+	require.NoError(t, err)
+	require.Equal(t, ClientVersionAuto, version)
+
+	version, err = ParseClientVersion("v2")
+	require.NoError(t, err)
+	require.Equal(t, ClientVersionV2, version)
+
+	_, err = ParseClientVersion("v3")
+	require.Error(t, err)
+}
@@ -0,0 +1,249 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package heimdall
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+)
+
+// ClientVersion selects the heimdall API which the client speaks.
+type ClientVersion string
+
+const (
+	// ClientVersionAuto detects the version on the first request.
+	ClientVersionAuto ClientVersion = "auto"
+	// ClientVersionV1 is the REST API of the tendermint based heimdall.
+	ClientVersionV1 ClientVersion = "v1"
+	// ClientVersionV2 is the gRPC gateway of the CometBFT based heimdall v2.
+	ClientVersionV2 ClientVersion = "v2"
+)
+
+func ParseClientVersion(s string) (ClientVersion, error) {
+	switch version := ClientVersion(s); version {
+	case ClientVersionAuto, ClientVersionV1, ClientVersionV2:
+		return version, nil
+	case "":
+		return ClientVersionAuto, nil
+	default:
+		return "", fmt.Errorf("unknown heimdall version %q, expected one of: %s, %s, %s", s, ClientVersionAuto, ClientVersionV1, ClientVersionV2)
+	}
+}
+
+// NewHeimdallClientForVersion creates the client for the given version of the heimdall API.
+func NewHeimdallClientForVersion(urlString string, version ClientVersion, logger log.Logger) HeimdallClient {
+	httpClient := &http.Client{
+		Timeout: apiHeimdallTimeout,
+	}
+	return newHeimdallClientForVersion(urlString, version, httpClient, retryBackOff, maxRetries, logger)
+}
+
+func newHeimdallClientForVersion(
+	urlString string,
+	version ClientVersion,
+	httpClient HttpClient,
+	retryBackOff time.Duration,
+	maxRetries int,
+	logger log.Logger,
+) HeimdallClient {
+	switch version {
+	case ClientVersionV1:
+		return newHeimdallClient(urlString, httpClient, retryBackOff, maxRetries, logger)
+	case ClientVersionV2:
+		return newHeimdallClientV2(urlString, httpClient, retryBackOff, maxRetries, logger)
+	default:
+		v1 := newHeimdallClient(urlString, httpClient, retryBackOff, maxRetries, logger)
+		return &autoClient{
+			v1:     v1,
+			v2:     &ClientV2{client: v1},
+			logger: logger,
+		}
+	}
+}
+
+// autoClient detects the heimdall version from the checkpoint count, which both versions serve at the same path:
+// v1 wraps it in a "result" object and v2 returns an "ack_count" field.
+type autoClient struct {
+	v1     *Client
+	v2     *ClientV2
+	logger log.Logger
+
+	mu       sync.Mutex
+	detected HeimdallClient
+}
+
+var _ HeimdallClient = &autoClient{}
+
+type versionProbeResponse struct {
+	Result   json.RawMessage `json:"result"`
+	AckCount json.RawMessage `json:"ack_count"`
+}
+
+func (c *autoClient) client(ctx context.Context) (HeimdallClient, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.detected != nil {
+		return c.detected, nil
+	}
+
+	url, err := checkpointCountURL(c.v1.urlString)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := FetchWithRetry[versionProbeResponse](withRequestType(ctx, checkpointCountRequest), c.v1, url, c.logger)
+	if err != nil {
+		return nil, fmt.Errorf("heimdall version detection failed: %w", err)
+	}
+
+	switch {
+	case len(response.AckCount) > 0:
+		c.detected = c.v2
+		c.logger.Info(heimdallLogPrefix("detected heimdall version"), "version", ClientVersionV2)
+	case len(response.Result) > 0:
+		c.detected = c.v1
+		c.logger.Info(heimdallLogPrefix("detected heimdall version"), "version", ClientVersionV1)
+	default:
+		return nil, fmt.Errorf("heimdall version detection failed: unexpected checkpoint count response from %s", url)
+	}
+
+	return c.detected, nil
+}
+
+func (c *autoClient) FetchStateSyncEvents(ctx context.Context, fromId uint64, to time.Time, limit int) ([]*EventRecordWithTime, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.FetchStateSyncEvents(ctx, fromId, to, limit)
+}
+
+func (c *autoClient) FetchStateSyncEvent(ctx context.Context, id uint64) (*EventRecordWithTime, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.FetchStateSyncEvent(ctx, id)
+}
+
+func (c *autoClient) FetchLatestSpan(ctx context.Context) (*Span, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.FetchLatestSpan(ctx)
+}
+
+func (c *autoClient) FetchSpan(ctx context.Context, spanID uint64) (*Span, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.FetchSpan(ctx, spanID)
+}
+
+func (c *autoClient) FetchSpans(ctx context.Context, page uint64, limit uint64) ([]*Span, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.FetchSpans(ctx, page, limit)
+}
+
+func (c *autoClient) FetchCheckpoint(ctx context.Context, number int64) (*Checkpoint, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.FetchCheckpoint(ctx, number)
+}
+
+func (c *autoClient) FetchCheckpointCount(ctx context.Context) (int64, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return client.FetchCheckpointCount(ctx)
+}
+
+func (c *autoClient) FetchCheckpoints(ctx context.Context, page uint64, limit uint64) ([]*Checkpoint, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.FetchCheckpoints(ctx, page, limit)
+}
+
+func (c *autoClient) FetchMilestone(ctx context.Context, number int64) (*Milestone, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client.FetchMilestone(ctx, number)
+}
+
+func (c *autoClient) FetchMilestoneCount(ctx context.Context) (int64, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return client.FetchMilestoneCount(ctx)
+}
+
+func (c *autoClient) FetchFirstMilestoneNum(ctx context.Context) (int64, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return client.FetchFirstMilestoneNum(ctx)
+}
+
+func (c *autoClient) FetchNoAckMilestone(ctx context.Context, milestoneID string) error {
+	client, err := c.client(ctx)
+	if err != nil {
+		return err
+	}
+	return client.FetchNoAckMilestone(ctx, milestoneID)
+}
+
+func (c *autoClient) FetchLastNoAckMilestone(ctx context.Context) (string, error) {
+	client, err := c.client(ctx)
+	if err != nil {
+		return "", err
+	}
+	return client.FetchLastNoAckMilestone(ctx)
+}
+
+func (c *autoClient) FetchMilestoneID(ctx context.Context, milestoneID string) error {
+	client, err := c.client(ctx)
+	if err != nil {
+		return err
+	}
+	return client.FetchMilestoneID(ctx, milestoneID)
+}
+
+// Close closes the transport which is shared by both clients.
+func (c *autoClient) Close() {
+	c.v1.Close()
+}
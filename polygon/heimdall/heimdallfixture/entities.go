@@ -0,0 +1,110 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package heimdallfixture
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon/polygon/bor/valset"
+	"github.com/erigontech/erigon/polygon/heimdall"
+)
+
+const (
+	ChainID = "80002"
+
+	SpanLength       = 6400
+	CheckpointLength = 256
+	MilestoneLength  = 16
+)
+
+// NewEntities generates a consistent set of entities: the first span starts at block 0 like on the real networks
+// and is shorter than the rest, checkpoints and milestones cover consecutive block ranges and events happen a second
+// apart starting at eventsStart.
+func NewEntities(numSpans, numCheckpoints, numMilestones, numEvents int, eventsStart time.Time) Entities {
+	validators := []*valset.Validator{
+		{ID: 1, Address: libcommon.HexToAddress("0x01"), VotingPower: 100},
+		{ID: 2, Address: libcommon.HexToAddress("0x02"), VotingPower: 200},
+		{ID: 3, Address: libcommon.HexToAddress("0x03"), VotingPower: 300},
+	}
+
+	var entities Entities
+	for i := 0; i < numSpans; i++ {
+		span := &heimdall.Span{
+			Id:         heimdall.SpanId(i),
+			StartBlock: 0,
+			EndBlock:   255,
+			ChainID:    ChainID,
+		}
+		if i > 0 {
+			span.StartBlock = 256 + uint64(i-1)*SpanLength
+			span.EndBlock = span.StartBlock + SpanLength - 1
+		}
+		span.ValidatorSet.Validators = make([]*valset.Validator, len(validators))
+		for j, validator := range validators {
+			span.ValidatorSet.Validators[j] = validator.Copy()
+		}
+		span.ValidatorSet.Proposer = validators[i%len(validators)].Copy()
+		span.SelectedProducers = []valset.Validator{*validators[i%len(validators)]}
+		entities.Spans = append(entities.Spans, span)
+	}
+
+	for i := 0; i < numCheckpoints; i++ {
+		entities.Checkpoints = append(entities.Checkpoints, &heimdall.Checkpoint{
+			Id:     heimdall.CheckpointId(i + 1),
+			Fields: waypointFields(i, CheckpointLength, validators[i%len(validators)].Address),
+		})
+	}
+
+	for i := 0; i < numMilestones; i++ {
+		entities.Milestones = append(entities.Milestones, &heimdall.Milestone{
+			Id:          heimdall.MilestoneId(i + 1),
+			MilestoneId: fmt.Sprintf("milestone-%d", i+1),
+			Fields:      waypointFields(i, MilestoneLength, validators[i%len(validators)].Address),
+		})
+	}
+
+	for i := 0; i < numEvents; i++ {
+		entities.Events = append(entities.Events, &heimdall.EventRecordWithTime{
+			EventRecord: heimdall.EventRecord{
+				ID:       uint64(i + 1),
+				Contract: libcommon.HexToAddress("0x1001"),
+				Data:     []byte{byte(i), byte(i >> 8), 0xde, 0xad},
+				TxHash:   libcommon.BigToHash(big.NewInt(int64(i + 1))),
+				LogIndex: uint64(i % 4),
+				ChainID:  ChainID,
+			},
+			Time: eventsStart.Add(time.Duration(i) * time.Second).UTC(),
+		})
+	}
+
+	return entities
+}
+
+func waypointFields(i int, length uint64, proposer libcommon.Address) heimdall.WaypointFields {
+	start := uint64(i) * length
+	return heimdall.WaypointFields{
+		Proposer:   proposer,
+		StartBlock: new(big.Int).SetUint64(start),
+		EndBlock:   new(big.Int).SetUint64(start + length - 1),
+		RootHash:   libcommon.BigToHash(new(big.Int).SetUint64(start + 1)),
+		ChainID:    ChainID,
+		Timestamp:  uint64(1_700_000_000 + i),
+	}
+}
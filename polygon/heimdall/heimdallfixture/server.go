@@ -0,0 +1,419 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package heimdallfixture serves a fixed set of heimdall entities over HTTP, in the shape of either version of the
+// heimdall API, so that the heimdall clients and everything built on top of them can be tested end to end.
+package heimdallfixture
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/polygon/bor/valset"
+	"github.com/erigontech/erigon/polygon/heimdall"
+)
+
+// Entities is the data served by a Server. Checkpoints and milestones are numbered from 1, spans from 0 and events
+// are served in ID order.
+type Entities struct {
+	Spans       []*heimdall.Span
+	Checkpoints []*heimdall.Checkpoint
+	Milestones  []*heimdall.Milestone
+	Events      []*heimdall.EventRecordWithTime
+}
+
+type Server struct {
+	*httptest.Server
+	version  heimdall.ClientVersion
+	entities Entities
+}
+
+// NewServer starts a server for the given API version, which must be either heimdall.ClientVersionV1 or
+// heimdall.ClientVersionV2. The server is closed when the test finishes.
+func NewServer(t testing.TB, version heimdall.ClientVersion, entities Entities) *Server {
+	s := &Server{version: version, entities: entities}
+	switch version {
+	case heimdall.ClientVersionV1:
+		s.Server = httptest.NewServer(s.v1Handler())
+	case heimdall.ClientVersionV2:
+		s.Server = httptest.NewServer(s.v2Handler())
+	default:
+		t.Fatalf("heimdall fixture can not serve version %q", version)
+	}
+
+	t.Cleanup(s.Server.Close)
+	return s
+}
+
+// NewClient creates a client for the server, speaking the given version of the API.
+func (s *Server) NewClient(t testing.TB, version heimdall.ClientVersion, logger log.Logger) heimdall.HeimdallClient {
+	client := heimdall.NewHeimdallClientForVersion(s.URL, version, logger)
+	t.Cleanup(client.Close)
+	return client
+}
+
+func (s *Server) v1Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /bor/latest-span", func(w http.ResponseWriter, r *http.Request) {
+		if len(s.entities.Spans) == 0 {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, &heimdall.SpanResponse{Result: *s.entities.Spans[len(s.entities.Spans)-1]})
+	})
+	mux.HandleFunc("GET /bor/span/list", func(w http.ResponseWriter, r *http.Request) {
+		page, limit := queryUint(r, "page"), queryUint(r, "limit")
+		writeJSON(w, &heimdall.SpanListResponse{Result: paginate(s.entities.Spans, (page-1)*limit, limit)})
+	})
+	mux.HandleFunc("GET /bor/span/{id}", func(w http.ResponseWriter, r *http.Request) {
+		span, ok := s.span(r)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, &heimdall.SpanResponse{Result: *span})
+	})
+	mux.HandleFunc("GET /checkpoints/count", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, &heimdall.CheckpointCountResponse{Result: heimdall.CheckpointCount{Result: int64(len(s.entities.Checkpoints))}})
+	})
+	mux.HandleFunc("GET /checkpoints/list", func(w http.ResponseWriter, r *http.Request) {
+		page, limit := queryUint(r, "page"), queryUint(r, "limit")
+		writeJSON(w, &heimdall.CheckpointListResponse{Result: paginate(s.entities.Checkpoints, (page-1)*limit, limit)})
+	})
+	mux.HandleFunc("GET /checkpoints/{number}", func(w http.ResponseWriter, r *http.Request) {
+		checkpoint, ok := s.checkpoint(r)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, &heimdall.CheckpointResponse{Result: *checkpoint})
+	})
+	mux.HandleFunc("GET /milestone/count", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, &heimdall.MilestoneCountResponse{Result: heimdall.MilestoneCount{Count: int64(len(s.entities.Milestones))}})
+	})
+	mux.HandleFunc("GET /milestone/{number}", func(w http.ResponseWriter, r *http.Request) {
+		milestone, ok := s.milestone(r)
+		if !ok {
+			// heimdall v1 reports a missing milestone as an internal error
+			http.Error(w, "Invalid milestone index", http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, &heimdall.MilestoneResponse{Result: *milestone})
+	})
+	mux.HandleFunc("GET /milestone/lastNoAck", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, &heimdall.MilestoneLastNoAckResponse{})
+	})
+	mux.HandleFunc("GET /milestone/noAck/{id}", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, &heimdall.MilestoneNoAckResponse{})
+	})
+	mux.HandleFunc("GET /milestone/ID/{id}", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, &heimdall.MilestoneIDResponse{})
+	})
+	mux.HandleFunc("GET /clerk/event-record/list", func(w http.ResponseWriter, r *http.Request) {
+		to := time.Unix(int64(queryUint(r, "to-time")), 0)
+		events := s.events(queryUint(r, "from-id"), to, queryUint(r, "limit"))
+		writeJSON(w, &heimdall.StateSyncEventsResponse{Result: events})
+	})
+	mux.HandleFunc("GET /clerk/event-record/{id}", func(w http.ResponseWriter, r *http.Request) {
+		event, ok := s.event(r)
+		if !ok {
+			http.Error(w, "could not get state record; No record found", http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, &heimdall.StateSyncEventResponse{Result: *event})
+	})
+	return mux
+}
+
+func (s *Server) v2Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /bor/spans/latest", func(w http.ResponseWriter, r *http.Request) {
+		if len(s.entities.Spans) == 0 {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, map[string]any{"span": newSpanV2(s.entities.Spans[len(s.entities.Spans)-1])})
+	})
+	mux.HandleFunc("GET /bor/spans/list", func(w http.ResponseWriter, r *http.Request) {
+		spans := paginate(s.entities.Spans, queryUint(r, "pagination.offset"), queryUint(r, "pagination.limit"))
+		writeJSON(w, map[string]any{"span_list": mapSlice(spans, newSpanV2)})
+	})
+	mux.HandleFunc("GET /bor/spans/{id}", func(w http.ResponseWriter, r *http.Request) {
+		span, ok := s.span(r)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, map[string]any{"span": newSpanV2(span)})
+	})
+	mux.HandleFunc("GET /checkpoints/count", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"ack_count": strconv.Itoa(len(s.entities.Checkpoints))})
+	})
+	mux.HandleFunc("GET /checkpoints/list", func(w http.ResponseWriter, r *http.Request) {
+		checkpoints := paginate(s.entities.Checkpoints, queryUint(r, "pagination.offset"), queryUint(r, "pagination.limit"))
+		writeJSON(w, map[string]any{"checkpoint_list": mapSlice(checkpoints, newCheckpointV2)})
+	})
+	mux.HandleFunc("GET /checkpoints/{number}", func(w http.ResponseWriter, r *http.Request) {
+		checkpoint, ok := s.checkpoint(r)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, map[string]any{"checkpoint": newCheckpointV2(checkpoint)})
+	})
+	mux.HandleFunc("GET /milestones/count", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"count": strconv.Itoa(len(s.entities.Milestones))})
+	})
+	mux.HandleFunc("GET /milestones/{number}", func(w http.ResponseWriter, r *http.Request) {
+		milestone, ok := s.milestone(r)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, map[string]any{"milestone": newMilestoneV2(milestone)})
+	})
+	mux.HandleFunc("GET /clerk/time", func(w http.ResponseWriter, r *http.Request) {
+		to, err := time.Parse(time.RFC3339, r.URL.Query().Get("to_time"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		events := s.events(queryUint(r, "from_id"), to, queryUint(r, "pagination.limit"))
+		writeJSON(w, map[string]any{"event_records": mapSlice(events, newEventRecordV2)})
+	})
+	mux.HandleFunc("GET /clerk/event-records/{id}", func(w http.ResponseWriter, r *http.Request) {
+		event, ok := s.event(r)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, map[string]any{"record": newEventRecordV2(event)})
+	})
+	return mux
+}
+
+func (s *Server) span(r *http.Request) (*heimdall.Span, bool) {
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil || id >= uint64(len(s.entities.Spans)) {
+		return nil, false
+	}
+	return s.entities.Spans[id], true
+}
+
+func (s *Server) checkpoint(r *http.Request) (*heimdall.Checkpoint, bool) {
+	return waypointAt(r, s.entities.Checkpoints)
+}
+
+func (s *Server) milestone(r *http.Request) (*heimdall.Milestone, bool) {
+	return waypointAt(r, s.entities.Milestones)
+}
+
+func (s *Server) event(r *http.Request) (*heimdall.EventRecordWithTime, bool) {
+	id, err := strconv.ParseUint(r.PathValue("id"), 10, 64)
+	if err != nil {
+		return nil, false
+	}
+	i := slices.IndexFunc(s.entities.Events, func(event *heimdall.EventRecordWithTime) bool {
+		return event.ID == id
+	})
+	if i < 0 {
+		return nil, false
+	}
+	return s.entities.Events[i], true
+}
+
+// events returns the events starting at fromId which happened before to, same as the clerk module of heimdall.
+func (s *Server) events(fromId uint64, to time.Time, limit uint64) []*heimdall.EventRecordWithTime {
+	events := make([]*heimdall.EventRecordWithTime, 0, limit)
+	for _, event := range s.entities.Events {
+		if uint64(len(events)) >= limit {
+			break
+		}
+		if event.ID >= fromId && event.Time.Before(to) {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+func waypointAt[T any](r *http.Request, waypoints []T) (T, bool) {
+	var zero T
+	if len(waypoints) == 0 {
+		return zero, false
+	}
+	number := r.PathValue("number")
+	if number == "latest" {
+		return waypoints[len(waypoints)-1], true
+	}
+	n, err := strconv.ParseUint(number, 10, 64)
+	if err != nil || n < 1 || n > uint64(len(waypoints)) {
+		return zero, false
+	}
+	return waypoints[n-1], true
+}
+
+func queryUint(r *http.Request, name string) uint64 {
+	n, _ := strconv.ParseUint(r.URL.Query().Get(name), 10, 64)
+	return n
+}
+
+func paginate[T any](items []T, offset uint64, limit uint64) []T {
+	if offset >= uint64(len(items)) {
+		return []T{}
+	}
+	return items[offset:min(offset+limit, uint64(len(items)))]
+}
+
+func mapSlice[T any, R any](items []T, f func(T) R) []R {
+	res := make([]R, len(items))
+	for i, item := range items {
+		res[i] = f(item)
+	}
+	return res
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// The v2 types below follow the protobuf JSON encoding of the heimdall v2 gRPC gateway: 64 bit integers are
+// strings and byte arrays are base64.
+
+type validatorV2 struct {
+	ValID            string            `json:"val_id"`
+	VotingPower      string            `json:"voting_power"`
+	Signer           libcommon.Address `json:"signer"`
+	ProposerPriority string            `json:"proposer_priority"`
+}
+
+func newValidatorV2(validator *valset.Validator) *validatorV2 {
+	return &validatorV2{
+		ValID:            strconv.FormatUint(validator.ID, 10),
+		VotingPower:      strconv.FormatInt(validator.VotingPower, 10),
+		Signer:           validator.Address,
+		ProposerPriority: strconv.FormatInt(validator.ProposerPriority, 10),
+	}
+}
+
+type validatorSetV2 struct {
+	Validators []*validatorV2 `json:"validators"`
+	Proposer   *validatorV2   `json:"proposer,omitempty"`
+}
+
+type spanV2 struct {
+	ID                string         `json:"id"`
+	StartBlock        string         `json:"start_block"`
+	EndBlock          string         `json:"end_block"`
+	ValidatorSet      validatorSetV2 `json:"validator_set"`
+	SelectedProducers []*validatorV2 `json:"selected_producers"`
+	ChainID           string         `json:"bor_chain_id"`
+}
+
+func newSpanV2(span *heimdall.Span) *spanV2 {
+	res := &spanV2{
+		ID:         strconv.FormatUint(uint64(span.Id), 10),
+		StartBlock: strconv.FormatUint(span.StartBlock, 10),
+		EndBlock:   strconv.FormatUint(span.EndBlock, 10),
+		ValidatorSet: validatorSetV2{
+			Validators: mapSlice(span.ValidatorSet.Validators, newValidatorV2),
+		},
+		SelectedProducers: make([]*validatorV2, len(span.SelectedProducers)),
+		ChainID:           span.ChainID,
+	}
+	if span.ValidatorSet.Proposer != nil {
+		res.ValidatorSet.Proposer = newValidatorV2(span.ValidatorSet.Proposer)
+	}
+	for i := range span.SelectedProducers {
+		res.SelectedProducers[i] = newValidatorV2(&span.SelectedProducers[i])
+	}
+	return res
+}
+
+type checkpointV2 struct {
+	ID         string            `json:"id"`
+	Proposer   libcommon.Address `json:"proposer"`
+	StartBlock string            `json:"start_block"`
+	EndBlock   string            `json:"end_block"`
+	RootHash   []byte            `json:"root_hash"`
+	ChainID    string            `json:"bor_chain_id"`
+	Timestamp  string            `json:"timestamp"`
+}
+
+func newCheckpointV2(checkpoint *heimdall.Checkpoint) *checkpointV2 {
+	return &checkpointV2{
+		ID:         strconv.FormatUint(uint64(checkpoint.Id), 10),
+		Proposer:   checkpoint.Fields.Proposer,
+		StartBlock: checkpoint.Fields.StartBlock.String(),
+		EndBlock:   checkpoint.Fields.EndBlock.String(),
+		RootHash:   checkpoint.Fields.RootHash.Bytes(),
+		ChainID:    checkpoint.Fields.ChainID,
+		Timestamp:  strconv.FormatUint(checkpoint.Fields.Timestamp, 10),
+	}
+}
+
+type milestoneV2 struct {
+	Proposer    libcommon.Address `json:"proposer"`
+	StartBlock  string            `json:"start_block"`
+	EndBlock    string            `json:"end_block"`
+	Hash        []byte            `json:"hash"`
+	ChainID     string            `json:"bor_chain_id"`
+	MilestoneID string            `json:"milestone_id"`
+	Timestamp   string            `json:"timestamp"`
+}
+
+func newMilestoneV2(milestone *heimdall.Milestone) *milestoneV2 {
+	return &milestoneV2{
+		Proposer:    milestone.Fields.Proposer,
+		StartBlock:  milestone.Fields.StartBlock.String(),
+		EndBlock:    milestone.Fields.EndBlock.String(),
+		Hash:        milestone.Fields.RootHash.Bytes(),
+		ChainID:     milestone.Fields.ChainID,
+		MilestoneID: milestone.MilestoneId,
+		Timestamp:   strconv.FormatUint(milestone.Fields.Timestamp, 10),
+	}
+}
+
+type eventRecordV2 struct {
+	ID         string            `json:"id"`
+	Contract   libcommon.Address `json:"contract"`
+	Data       []byte            `json:"data"`
+	TxHash     libcommon.Hash    `json:"tx_hash"`
+	LogIndex   string            `json:"log_index"`
+	ChainID    string            `json:"bor_chain_id"`
+	RecordTime string            `json:"record_time"`
+}
+
+func newEventRecordV2(event *heimdall.EventRecordWithTime) *eventRecordV2 {
+	return &eventRecordV2{
+		ID:         strconv.FormatUint(event.ID, 10),
+		Contract:   event.Contract,
+		Data:       event.Data,
+		TxHash:     event.TxHash,
+		LogIndex:   strconv.FormatUint(event.LogIndex, 10),
+		ChainID:    event.ChainID,
+		RecordTime: event.Time.UTC().Format(time.RFC3339Nano),
+	}
+}
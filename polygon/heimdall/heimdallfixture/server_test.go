@@ -0,0 +1,171 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package heimdallfixture_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+	"github.com/erigontech/erigon/polygon/bor/borcfg"
+	"github.com/erigontech/erigon/polygon/heimdall"
+	"github.com/erigontech/erigon/polygon/heimdall/heimdallfixture"
+	"github.com/erigontech/erigon/turbo/testlog"
+)
+
+var clientCases = []struct {
+	server heimdall.ClientVersion
+	client heimdall.ClientVersion
+}{
+	{server: heimdall.ClientVersionV1, client: heimdall.ClientVersionV1},
+	{server: heimdall.ClientVersionV1, client: heimdall.ClientVersionAuto},
+	{server: heimdall.ClientVersionV2, client: heimdall.ClientVersionV2},
+	{server: heimdall.ClientVersionV2, client: heimdall.ClientVersionAuto},
+}
+
+func requireJSONEqual(t *testing.T, want any, have any) {
+	wantJSON, err := json.Marshal(want)
+	require.NoError(t, err)
+	haveJSON, err := json.Marshal(have)
+	require.NoError(t, err)
+	require.JSONEq(t, string(wantJSON), string(haveJSON))
+}
+
+func TestClients(t *testing.T) {
+	entities := heimdallfixture.NewEntities(5, 12, 7, 120, time.Unix(1_700_000_000, 0))
+
+	for _, tc := range clientCases {
+		t.Run(fmt.Sprintf("server=%s,client=%s", tc.server, tc.client), func(t *testing.T) {
+			ctx := context.Background()
+			logger := testlog.Logger(t, log.LvlCrit)
+			server := heimdallfixture.NewServer(t, tc.server, entities)
+			client := server.NewClient(t, tc.client, logger)
+
+			latestSpan, err := client.FetchLatestSpan(ctx)
+			require.NoError(t, err)
+			requireJSONEqual(t, entities.Spans[4], latestSpan)
+
+			span, err := client.FetchSpan(ctx, 1)
+			require.NoError(t, err)
+			requireJSONEqual(t, entities.Spans[1], span)
+
+			spans, err := client.FetchSpans(ctx, 2, 2)
+			require.NoError(t, err)
+			requireJSONEqual(t, entities.Spans[2:4], spans)
+
+			checkpointCount, err := client.FetchCheckpointCount(ctx)
+			require.NoError(t, err)
+			require.Equal(t, int64(12), checkpointCount)
+
+			checkpoints, err := client.FetchCheckpoints(ctx, 2, 5)
+			require.NoError(t, err)
+			requireJSONEqual(t, entities.Checkpoints[5:10], checkpoints)
+
+			checkpoint, err := client.FetchCheckpoint(ctx, -1)
+			require.NoError(t, err)
+			requireJSONEqual(t, entities.Checkpoints[11], checkpoint)
+
+			milestoneCount, err := client.FetchMilestoneCount(ctx)
+			require.NoError(t, err)
+			require.Equal(t, int64(7), milestoneCount)
+
+			milestone, err := client.FetchMilestone(ctx, 3)
+			require.NoError(t, err)
+			requireJSONEqual(t, entities.Milestones[2], milestone)
+
+			if tc.server == heimdall.ClientVersionV2 {
+				// v1 retries milestones which are in the prune window, as they may be yet to come
+				_, err = client.FetchMilestone(ctx, 8)
+				require.ErrorIs(t, err, heimdall.ErrNotInMilestoneList)
+			}
+
+			events, err := client.FetchStateSyncEvents(ctx, 1, entities.Events[100].Time, 0)
+			require.NoError(t, err)
+			requireJSONEqual(t, entities.Events[:100], events)
+
+			event, err := client.FetchStateSyncEvent(ctx, 7)
+			require.NoError(t, err)
+			requireJSONEqual(t, entities.Events[6], event)
+
+			_, err = client.FetchStateSyncEvent(ctx, 1000)
+			require.ErrorIs(t, err, heimdall.ErrEventRecordNotFound)
+		})
+	}
+}
+
+func TestServiceSynchronizes(t *testing.T) {
+	entities := heimdallfixture.NewEntities(4, 30, 12, 0, time.Unix(1_700_000_000, 0))
+	borConfig := borcfg.BorConfig{Sprint: map[string]uint64{"0": 16}}
+
+	for _, tc := range clientCases {
+		t.Run(fmt.Sprintf("server=%s,client=%s", tc.server, tc.client), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			logger := testlog.Logger(t, log.LvlCrit)
+			server := heimdallfixture.NewServer(t, tc.server, entities)
+			client := server.NewClient(t, tc.client, logger)
+
+			tempDir := t.TempDir()
+			store := heimdall.NewMdbxServiceStore(logger, fmt.Sprintf("%s/datadir", tempDir), tempDir, 1)
+			reader := heimdall.NewReader(borConfig.CalculateSprintNumber, store, logger)
+			service := heimdall.NewService(borConfig.CalculateSprintNumber, client, store, logger, reader)
+			require.NoError(t, store.Prepare(ctx))
+
+			var eg errgroup.Group
+			eg.Go(func() error {
+				return service.Run(ctx)
+			})
+			t.Cleanup(func() {
+				cancel()
+				err := eg.Wait()
+				require.True(t, errors.Is(err, context.Canceled), err)
+			})
+
+			require.NoError(t, service.SynchronizeMilestones(ctx))
+			require.NoError(t, service.SynchronizeCheckpoints(ctx))
+			require.NoError(t, service.SynchronizeSpans(ctx, math.MaxInt))
+
+			checkpoints, err := service.CheckpointsFromBlock(ctx, 0)
+			require.NoError(t, err)
+			require.Len(t, checkpoints, len(entities.Checkpoints))
+			for i, checkpoint := range checkpoints {
+				requireJSONEqual(t, entities.Checkpoints[i], checkpoint)
+			}
+
+			milestones, err := service.MilestonesFromBlock(ctx, 0)
+			require.NoError(t, err)
+			require.Len(t, milestones, len(entities.Milestones))
+			for i, milestone := range milestones {
+				requireJSONEqual(t, entities.Milestones[i], milestone)
+			}
+
+			for _, want := range entities.Spans {
+				span, ok, err := service.Span(ctx, uint64(want.Id))
+				require.NoError(t, err)
+				require.True(t, ok)
+				requireJSONEqual(t, want, span)
+			}
+		})
+	}
+}
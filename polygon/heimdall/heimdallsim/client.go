@@ -0,0 +1,64 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package heimdallsim
+
+import (
+	"context"
+	"strings"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/polygon/heimdall"
+)
+
+// URLScheme selects the simulator as the heimdall of a node: sim://<dir> replays the bor snapshots and the
+// recordings in dir, a bare sim:// the ones in the snapshots directory of the node.
+const URLScheme = "sim://"
+
+type ClientConfig struct {
+	URL       string
+	Version   heimdall.ClientVersion
+	RecordDir string // when set, the responses of a real heimdall are recorded here
+	SnapDir   string
+	Logger    log.Logger
+}
+
+func IsSimulatorURL(url string) bool {
+	return strings.HasPrefix(url, URLScheme)
+}
+
+// NewHeimdallClient creates the heimdall client of a node, which is either the simulator or a client of a real
+// heimdall, optionally recording its responses.
+func NewHeimdallClient(ctx context.Context, config ClientConfig) (heimdall.HeimdallClient, error) {
+	if IsSimulatorURL(config.URL) {
+		dir := strings.TrimPrefix(config.URL, URLScheme)
+		if dir == "" {
+			dir = config.SnapDir
+		}
+
+		config.Logger.Info("[heimdallsim] running against recorded heimdall data", "dir", dir)
+		return NewOfflineHeimdallSimulator(ctx, dir, config.Logger)
+	}
+
+	client := heimdall.NewHeimdallClientForVersion(config.URL, config.Version, config.Logger)
+	if config.RecordDir != "" {
+		config.Logger.Info("[heimdallsim] recording heimdall responses", "dir", config.RecordDir)
+		return NewRecorder(client, config.RecordDir, config.Logger), nil
+	}
+
+	return client, nil
+}
@@ -0,0 +1,374 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package heimdallsim
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon-lib/downloader/snaptype"
+	"github.com/erigontech/erigon/eth/ethconfig"
+	borsnaptype "github.com/erigontech/erigon/polygon/bor/snaptype"
+	"github.com/erigontech/erigon/polygon/heimdall"
+	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
+)
+
+// HeimdallSimulator serves heimdall data offline. Spans, checkpoints and state sync events are read from the bor
+// snapshot files of a directory and all entities, milestones included, from the recordings of a Recorder in the same
+// directory. Recordings take precedence over snapshots.
+type HeimdallSimulator struct {
+	snapshots   *freezeblocks.BorRoSnapshots
+	blockReader *freezeblocks.BlockReader
+	recordings  recordings
+
+	iterations               []uint64 // list of final block numbers for an iteration
+	lastAvailableBlockNumber uint64
+
+	logger log.Logger
+}
+
+var _ heimdall.HeimdallClient = (*HeimdallSimulator)(nil)
+
+var (
+	ErrNotRecorded  = errors.New("not recorded")
+	ErrNoAckNotKept = fmt.Errorf("%w: no-ack milestones are not kept by the heimdall simulator", heimdall.ErrServiceUnavailable)
+)
+
+// simulatedSnapshotTypes are the bor snapshot types the simulator serves heimdall data from.
+var simulatedSnapshotTypes = []snaptype.Enum{borsnaptype.Enums.BorEvents, borsnaptype.Enums.BorSpans, borsnaptype.Enums.BorCheckpoints}
+
+func NewHeimdallSimulator(ctx context.Context, snapDir string, logger log.Logger, iterations []uint64) (*HeimdallSimulator, error) {
+	snapshots := freezeblocks.NewBorRoSnapshots(ethconfig.Defaults.Snapshot, snapDir, 0, logger)
+
+	// index the local files the simulator reads, which do not need a chain config, unless they are indexed already
+	localFiles, err := os.ReadDir(snapDir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, file := range localFiles {
+		info, _, ok := snaptype.ParseFileName(snapDir, file.Name())
+		if !ok || info.Ext != ".seg" || !slices.Contains(simulatedSnapshotTypes, info.Type.Enum()) {
+			continue
+		}
+		if info.Type.HasIndexFiles(info, logger) {
+			continue
+		}
+
+		err = info.Type.BuildIndexes(ctx, info, nil, snapDir, nil, log.LvlWarn, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = snapshots.ReopenFolder(); err != nil {
+		return nil, err
+	}
+
+	h := HeimdallSimulator{
+		snapshots:   snapshots,
+		blockReader: freezeblocks.NewBlockReader(nil, snapshots),
+		recordings:  recordings{dir: snapDir},
+
+		iterations: iterations,
+
+		logger: logger,
+	}
+
+	h.Next()
+
+	return &h, nil
+}
+
+// NewOfflineHeimdallSimulator creates a simulator which serves all the data of the directory at once, as a node
+// running offline needs.
+func NewOfflineHeimdallSimulator(ctx context.Context, dir string, logger log.Logger) (*HeimdallSimulator, error) {
+	h, err := NewHeimdallSimulator(ctx, dir, logger, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	lastSpanId, ok, err := h.lastSpanId()
+	if err != nil {
+		h.Close()
+		return nil, err
+	}
+	if ok {
+		h.lastAvailableBlockNumber = heimdall.SpanEndBlockNum(heimdall.SpanId(lastSpanId))
+	}
+
+	return h, nil
+}
+
+func (h *HeimdallSimulator) Close() {
+	h.snapshots.Close()
+}
+
+// Next moves to the next iteration
+func (h *HeimdallSimulator) Next() {
+	if len(h.iterations) == 0 {
+		h.lastAvailableBlockNumber++
+	} else {
+		h.lastAvailableBlockNumber = h.iterations[0]
+		h.iterations = h.iterations[1:]
+	}
+}
+
+func (h *HeimdallSimulator) FetchLatestSpan(ctx context.Context) (*heimdall.Span, error) {
+	latestSpan := uint64(heimdall.SpanIdAt(h.lastAvailableBlockNumber))
+
+	span, err := h.getSpan(ctx, latestSpan)
+	if err != nil {
+		return nil, err
+	}
+
+	return &span, nil
+}
+
+func (h *HeimdallSimulator) FetchSpan(ctx context.Context, spanID uint64) (*heimdall.Span, error) {
+	if spanID > uint64(heimdall.SpanIdAt(h.lastAvailableBlockNumber)) {
+		return nil, errors.New("span not found")
+	}
+
+	span, err := h.getSpan(ctx, spanID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &span, err
+}
+
+func (h *HeimdallSimulator) FetchSpans(ctx context.Context, page uint64, limit uint64) ([]*heimdall.Span, error) {
+	if page == 0 {
+		return nil, errors.New("pages start at 1")
+	}
+
+	latestSpan := uint64(heimdall.SpanIdAt(h.lastAvailableBlockNumber))
+	spans := make([]*heimdall.Span, 0, limit)
+	for spanID := (page - 1) * limit; spanID < page*limit && spanID <= latestSpan; spanID++ {
+		span, err := h.getSpan(ctx, spanID)
+		if err != nil {
+			return nil, err
+		}
+		spans = append(spans, &span)
+	}
+
+	return spans, nil
+}
+
+func (h *HeimdallSimulator) FetchStateSyncEvents(_ context.Context, fromId uint64, to time.Time, limit int) ([]*heimdall.EventRecordWithTime, error) {
+	events, maxTime, err := h.blockReader.EventsByIdFromSnapshot(fromId, to, limit)
+	if err != nil || maxTime || (limit > 0 && len(events) >= limit) {
+		return events, err
+	}
+
+	// continue with the recorded events which are newer than the snapshots
+	nextId := max(fromId, h.blockReader.LastFrozenEventId()+1)
+	if len(events) > 0 {
+		nextId = max(nextId, events[len(events)-1].ID+1)
+	}
+	for limit <= 0 || len(events) < limit {
+		var event heimdall.EventRecordWithTime
+		ok, err := h.recordings.read("event", nextId, &event)
+		if err != nil {
+			return nil, err
+		}
+		if !ok || event.Time.After(to) {
+			break
+		}
+		events = append(events, &event)
+		nextId++
+	}
+
+	return events, nil
+}
+
+func (h *HeimdallSimulator) FetchStateSyncEvent(ctx context.Context, id uint64) (*heimdall.EventRecordWithTime, error) {
+	var event heimdall.EventRecordWithTime
+	ok, err := h.recordings.read("event", id, &event)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return &event, nil
+	}
+
+	events, _, err := h.blockReader.EventsByIdFromSnapshot(id, time.Now(), 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 || events[0].ID != id {
+		return nil, heimdall.ErrEventRecordNotFound
+	}
+
+	return events[0], nil
+}
+
+func (h *HeimdallSimulator) FetchCheckpoint(ctx context.Context, number int64) (*heimdall.Checkpoint, error) {
+	if number == -1 {
+		count, err := h.FetchCheckpointCount(ctx)
+		if err != nil {
+			return nil, err
+		}
+		number = count
+	}
+
+	var checkpoint heimdall.Checkpoint
+	ok, err := h.recordings.read("checkpoint", uint64(number), &checkpoint)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return &checkpoint, nil
+	}
+
+	if number < 1 || uint64(number) > h.blockReader.LastFrozenCheckpointId() {
+		return nil, fmt.Errorf("%w: checkpoint %d", heimdall.ErrNotInCheckpointList, number)
+	}
+
+	data, err := h.blockReader.Checkpoint(ctx, nil, uint64(number))
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, err
+	}
+
+	return &checkpoint, nil
+}
+
+func (h *HeimdallSimulator) FetchCheckpointCount(ctx context.Context) (int64, error) {
+	_, lastRecorded, _, err := h.recordings.idRange("checkpoint")
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(max(lastRecorded, h.blockReader.LastFrozenCheckpointId())), nil
+}
+
+func (h *HeimdallSimulator) FetchCheckpoints(ctx context.Context, page uint64, limit uint64) ([]*heimdall.Checkpoint, error) {
+	if page == 0 {
+		return nil, errors.New("pages start at 1")
+	}
+
+	count, err := h.FetchCheckpointCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoints := make([]*heimdall.Checkpoint, 0, limit)
+	for number := (page-1)*limit + 1; number <= page*limit && number <= uint64(count); number++ {
+		checkpoint, err := h.FetchCheckpoint(ctx, int64(number))
+		if err != nil {
+			return nil, err
+		}
+		checkpoints = append(checkpoints, checkpoint)
+	}
+
+	return checkpoints, nil
+}
+
+// FetchMilestone serves recorded milestones, milestones are not kept in snapshots.
+func (h *HeimdallSimulator) FetchMilestone(ctx context.Context, number int64) (*heimdall.Milestone, error) {
+	if number == -1 {
+		count, err := h.FetchMilestoneCount(ctx)
+		if err != nil {
+			return nil, err
+		}
+		number = count
+	}
+
+	var milestone heimdall.Milestone
+	ok, err := h.recordings.read("milestone", uint64(number), &milestone)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: number %d", heimdall.ErrNotInMilestoneList, number)
+	}
+
+	milestone.Id = heimdall.MilestoneId(number)
+	return &milestone, nil
+}
+
+func (h *HeimdallSimulator) FetchMilestoneCount(ctx context.Context) (int64, error) {
+	_, last, _, err := h.recordings.idRange("milestone")
+	return int64(last), err
+}
+
+func (h *HeimdallSimulator) FetchFirstMilestoneNum(ctx context.Context) (int64, error) {
+	first, _, ok, err := h.recordings.idRange("milestone")
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 1, nil
+	}
+
+	return int64(first), nil
+}
+
+func (h *HeimdallSimulator) FetchNoAckMilestone(ctx context.Context, milestoneID string) error {
+	return ErrNoAckNotKept
+}
+
+func (h *HeimdallSimulator) FetchLastNoAckMilestone(ctx context.Context) (string, error) {
+	return "", ErrNoAckNotKept
+}
+
+func (h *HeimdallSimulator) FetchMilestoneID(ctx context.Context, milestoneID string) error {
+	return ErrNoAckNotKept
+}
+
+func (h *HeimdallSimulator) getSpan(ctx context.Context, spanId uint64) (heimdall.Span, error) {
+	var s heimdall.Span
+	ok, err := h.recordings.read("span", spanId, &s)
+	if ok || err != nil {
+		return s, err
+	}
+
+	span, err := h.blockReader.Span(ctx, nil, spanId)
+	if span != nil && err == nil {
+		if err = json.Unmarshal(span, &s); err != nil {
+			return heimdall.Span{}, err
+		}
+		return s, err
+	}
+
+	return heimdall.Span{}, err
+}
+
+// lastSpanId is the last span which is either in the snapshots or recorded.
+func (h *HeimdallSimulator) lastSpanId() (uint64, bool, error) {
+	_, lastRecorded, ok, err := h.recordings.idRange("span")
+	if err != nil {
+		return 0, false, err
+	}
+
+	if h.blockReader.FrozenBorBlocks() > 0 {
+		return max(lastRecorded, h.blockReader.LastFrozenSpanId()), true, nil
+	}
+
+	return lastRecorded, ok, nil
+}
@@ -29,8 +29,8 @@ import (
 
 	"github.com/erigontech/erigon-lib/log/v3"
 
-	"github.com/erigontech/erigon/cmd/devnet/services/polygon/heimdallsim"
 	"github.com/erigontech/erigon/polygon/heimdall"
+	"github.com/erigontech/erigon/polygon/heimdall/heimdallsim"
 )
 
 //go:embed testdata/v1-000000-000500-borevents.seg
@@ -166,3 +166,47 @@ func TestSimulatorSpans(t *testing.T) {
 	assert.Equal(t, uint64(205_056), span5.StartBlock)
 	assert.Equal(t, uint64(211_455), span5.EndBlock)
 }
+
+func TestSimulatorIndexesMissingBorIndexesOnly(t *testing.T) {
+	ctx := context.Background()
+	logger := log.New()
+	dataDir := t.TempDir()
+
+	err := createFiles(dataDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the simulator does not read the other segments, which would need a chain config to be indexed
+	err = os.WriteFile(filepath.Join(dataDir, "v1-000000-000500-headers.seg"), []byte("not a segment"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sim, err := heimdallsim.NewHeimdallSimulator(ctx, dataDir, logger, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim.Close()
+
+	eventsIdx := filepath.Join(dataDir, "v1-000000-000500-borevents.idx")
+	info, err := os.Stat(eventsIdx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = os.Stat(filepath.Join(dataDir, "v1-000000-000500-headers.idx"))
+	assert.True(t, os.IsNotExist(err))
+
+	// existing indexes are not built again
+	sim, err = heimdallsim.NewHeimdallSimulator(ctx, dataDir, logger, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim.Close()
+
+	info2, err := os.Stat(eventsIdx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, info.ModTime(), info2.ModTime())
+}
@@ -0,0 +1,159 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package heimdallsim
+
+import (
+	"context"
+	"time"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/polygon/heimdall"
+)
+
+// Recorder is a heimdall client which stores every entity fetched through it in a recordings directory, which the
+// HeimdallSimulator can later replay offline.
+type Recorder struct {
+	heimdall.HeimdallClient
+	recordings recordings
+	logger     log.Logger
+}
+
+var _ heimdall.HeimdallClient = (*Recorder)(nil)
+
+func NewRecorder(client heimdall.HeimdallClient, dir string, logger log.Logger) *Recorder {
+	return &Recorder{
+		HeimdallClient: client,
+		recordings:     recordings{dir: dir},
+		logger:         logger,
+	}
+}
+
+// record does not fail the fetch, a recording which could not be written is only logged.
+func (r *Recorder) record(entityType string, id uint64, v any) {
+	if err := r.recordings.write(entityType, id, v); err != nil {
+		r.logger.Warn("[heimdallsim] failed to record heimdall response", "type", entityType, "id", id, "err", err)
+	}
+}
+
+func (r *Recorder) FetchStateSyncEvents(ctx context.Context, fromId uint64, to time.Time, limit int) ([]*heimdall.EventRecordWithTime, error) {
+	events, err := r.HeimdallClient.FetchStateSyncEvents(ctx, fromId, to, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, event := range events {
+		r.record("event", event.ID, event)
+	}
+
+	return events, nil
+}
+
+func (r *Recorder) FetchStateSyncEvent(ctx context.Context, id uint64) (*heimdall.EventRecordWithTime, error) {
+	event, err := r.HeimdallClient.FetchStateSyncEvent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	r.record("event", event.ID, event)
+	return event, nil
+}
+
+func (r *Recorder) FetchLatestSpan(ctx context.Context) (*heimdall.Span, error) {
+	span, err := r.HeimdallClient.FetchLatestSpan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r.record("span", uint64(span.Id), span)
+	return span, nil
+}
+
+func (r *Recorder) FetchSpan(ctx context.Context, spanID uint64) (*heimdall.Span, error) {
+	span, err := r.HeimdallClient.FetchSpan(ctx, spanID)
+	if err != nil {
+		return nil, err
+	}
+
+	r.record("span", uint64(span.Id), span)
+	return span, nil
+}
+
+func (r *Recorder) FetchSpans(ctx context.Context, page uint64, limit uint64) ([]*heimdall.Span, error) {
+	spans, err := r.HeimdallClient.FetchSpans(ctx, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, span := range spans {
+		r.record("span", uint64(span.Id), span)
+	}
+
+	return spans, nil
+}
+
+func (r *Recorder) FetchCheckpoint(ctx context.Context, number int64) (*heimdall.Checkpoint, error) {
+	checkpoint, err := r.HeimdallClient.FetchCheckpoint(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+
+	if checkpoint.Id > 0 {
+		r.record("checkpoint", uint64(checkpoint.Id), checkpoint)
+	}
+
+	return checkpoint, nil
+}
+
+func (r *Recorder) FetchCheckpoints(ctx context.Context, page uint64, limit uint64) ([]*heimdall.Checkpoint, error) {
+	checkpoints, err := r.HeimdallClient.FetchCheckpoints(ctx, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, checkpoint := range checkpoints {
+		if checkpoint.Id > 0 {
+			r.record("checkpoint", uint64(checkpoint.Id), checkpoint)
+		}
+	}
+
+	return checkpoints, nil
+}
+
+// FetchMilestone records milestones under their number. The latest milestone does not come with its number, so it
+// is fetched by the milestone count instead, which is its number.
+func (r *Recorder) FetchMilestone(ctx context.Context, number int64) (*heimdall.Milestone, error) {
+	if number == -1 {
+		count, err := r.HeimdallClient.FetchMilestoneCount(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		number = count
+	}
+
+	milestone, err := r.HeimdallClient.FetchMilestone(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+
+	if milestone.Id > 0 {
+		r.record("milestone", uint64(milestone.Id), milestone)
+	}
+
+	return milestone, nil
+}
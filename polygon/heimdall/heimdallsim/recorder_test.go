@@ -0,0 +1,121 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package heimdallsim_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/log/v3"
+
+	"github.com/erigontech/erigon/polygon/heimdall"
+	"github.com/erigontech/erigon/polygon/heimdall/heimdallfixture"
+	"github.com/erigontech/erigon/polygon/heimdall/heimdallsim"
+	"github.com/erigontech/erigon/turbo/testlog"
+)
+
+func requireJSONEqual(t *testing.T, want any, have any) {
+	wantJSON, err := json.Marshal(want)
+	require.NoError(t, err)
+	haveJSON, err := json.Marshal(have)
+	require.NoError(t, err)
+	require.JSONEq(t, string(wantJSON), string(haveJSON))
+}
+
+func TestRecorderReplay(t *testing.T) {
+	ctx := context.Background()
+	logger := testlog.Logger(t, log.LvlCrit)
+	entities := heimdallfixture.NewEntities(3, 6, 4, 20, time.Unix(1_700_000_000, 0))
+	server := heimdallfixture.NewServer(t, heimdall.ClientVersionV2, entities)
+	dir := t.TempDir()
+
+	recorder, err := heimdallsim.NewHeimdallClient(ctx, heimdallsim.ClientConfig{
+		URL:       server.URL,
+		Version:   heimdall.ClientVersionV2,
+		RecordDir: dir,
+		Logger:    logger,
+	})
+	require.NoError(t, err)
+	_, err = recorder.FetchSpans(ctx, 1, 10)
+	require.NoError(t, err)
+	_, err = recorder.FetchCheckpoints(ctx, 1, 10)
+	require.NoError(t, err)
+	for number := int64(1); number <= 3; number++ {
+		_, err = recorder.FetchMilestone(ctx, number)
+		require.NoError(t, err)
+	}
+	// the latest milestone is recorded under its number
+	latestMilestone, err := recorder.FetchMilestone(ctx, -1)
+	require.NoError(t, err)
+	requireJSONEqual(t, entities.Milestones[3], latestMilestone)
+	_, err = recorder.FetchStateSyncEvents(ctx, 1, entities.Events[19].Time.Add(time.Second), 0)
+	require.NoError(t, err)
+	recorder.Close()
+
+	sim, err := heimdallsim.NewHeimdallClient(ctx, heimdallsim.ClientConfig{
+		URL:    heimdallsim.URLScheme + dir,
+		Logger: logger,
+	})
+	require.NoError(t, err)
+	t.Cleanup(sim.Close)
+
+	latestSpan, err := sim.FetchLatestSpan(ctx)
+	require.NoError(t, err)
+	requireJSONEqual(t, entities.Spans[2], latestSpan)
+
+	span, err := sim.FetchSpan(ctx, 1)
+	require.NoError(t, err)
+	requireJSONEqual(t, entities.Spans[1], span)
+
+	checkpointCount, err := sim.FetchCheckpointCount(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(6), checkpointCount)
+
+	checkpoints, err := sim.FetchCheckpoints(ctx, 1, 10)
+	require.NoError(t, err)
+	requireJSONEqual(t, entities.Checkpoints, checkpoints)
+
+	milestoneCount, err := sim.FetchMilestoneCount(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(4), milestoneCount)
+
+	milestone, err := sim.FetchMilestone(ctx, 3)
+	require.NoError(t, err)
+	requireJSONEqual(t, entities.Milestones[2], milestone)
+
+	milestone, err = sim.FetchMilestone(ctx, -1)
+	require.NoError(t, err)
+	requireJSONEqual(t, entities.Milestones[3], milestone)
+
+	_, err = sim.FetchMilestone(ctx, 5)
+	require.ErrorIs(t, err, heimdall.ErrNotInMilestoneList)
+
+	events, err := sim.FetchStateSyncEvents(ctx, 1, entities.Events[9].Time, 0)
+	require.NoError(t, err)
+	requireJSONEqual(t, entities.Events[:10], events)
+
+	event, err := sim.FetchStateSyncEvent(ctx, 7)
+	require.NoError(t, err)
+	requireJSONEqual(t, entities.Events[6], event)
+
+	err = sim.FetchMilestoneID(ctx, "id")
+	require.ErrorIs(t, err, heimdall.ErrServiceUnavailable)
+}
@@ -0,0 +1,135 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package heimdallsim
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+)
+
+// Recordings are stored one entity per JSON file, in the same layout as the heimdall test data:
+//
+//	<dir>/spans/span_<id>.json
+//	<dir>/checkpoints/checkpoint_<id>.json
+//	<dir>/milestones/milestone_<id>.json
+//	<dir>/events/event_<id>.json
+const (
+	spansDir       = "spans"
+	checkpointsDir = "checkpoints"
+	milestonesDir  = "milestones"
+	eventsDir      = "events"
+)
+
+var entityDirs = map[string]string{
+	"span":       spansDir,
+	"checkpoint": checkpointsDir,
+	"milestone":  milestonesDir,
+	"event":      eventsDir,
+}
+
+// recordings reads and writes the entity files of a recordings directory.
+type recordings struct {
+	dir string
+}
+
+func (r recordings) path(entityType string, id uint64) string {
+	return filepath.Join(r.dir, entityDirs[entityType], fmt.Sprintf("%s_%d.json", entityType, id))
+}
+
+// read decodes the recorded entity into v, it returns false if there is no recording of it.
+func (r recordings) read(entityType string, id uint64, v any) (bool, error) {
+	if r.dir == "" {
+		return false, nil
+	}
+
+	data, err := os.ReadFile(r.path(entityType, id))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, fmt.Errorf("invalid recording of %s %d: %w", entityType, id, err)
+	}
+
+	return true, nil
+}
+
+// write stores the entity, replacing an older recording of it.
+func (r recordings) write(entityType string, id uint64, v any) error {
+	path := r.path(entityType, id)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, path)
+}
+
+// idRange returns the lowest and the highest recorded id of the entity type.
+func (r recordings) idRange(entityType string) (first uint64, last uint64, ok bool, err error) {
+	if r.dir == "" {
+		return 0, 0, false, nil
+	}
+
+	files, err := os.ReadDir(filepath.Join(r.dir, entityDirs[entityType]))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, 0, false, nil
+		}
+		return 0, 0, false, err
+	}
+
+	re := regexp.MustCompile(fmt.Sprintf(`^%s_([0-9]+)\.json$`, entityType))
+	for _, file := range files {
+		match := re.FindStringSubmatch(file.Name())
+		if match == nil {
+			continue
+		}
+
+		id, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return 0, 0, false, err
+		}
+
+		if !ok || id < first {
+			first = id
+		}
+		if !ok || id > last {
+			last = id
+		}
+		ok = true
+	}
+
+	return first, last, ok, nil
+}
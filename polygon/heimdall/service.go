@@ -33,10 +33,13 @@ import (
 type ServiceConfig struct {
 	CalculateSprintNumberFn CalculateSprintNumberFunc
 	HeimdallURL             string
-	DataDir                 string
-	TempDir                 string
-	Logger                  log.Logger
-	RoTxLimit               int64
+	HeimdallVersion         ClientVersion
+	// Client is used instead of a client for HeimdallURL when set
+	Client    HeimdallClient
+	DataDir   string
+	TempDir   string
+	Logger    log.Logger
+	RoTxLimit int64
 }
 
 type Service interface {
@@ -63,7 +66,10 @@ type service struct {
 
 func AssembleService(config ServiceConfig) Service {
 	store := NewMdbxServiceStore(config.Logger, config.DataDir, config.TempDir, config.RoTxLimit)
-	client := NewHeimdallClient(config.HeimdallURL, config.Logger)
+	client := config.Client
+	if client == nil {
+		client = NewHeimdallClientForVersion(config.HeimdallURL, config.HeimdallVersion, config.Logger)
+	}
 	reader := NewReader(config.CalculateSprintNumberFn, store, config.Logger)
 	return NewService(config.CalculateSprintNumberFn, client, store, config.Logger, reader)
 }
@@ -21,12 +21,11 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
-	"sync"
-	"sync/atomic"
+	"sort"
 	"time"
 
 	"github.com/c2h5oh/datasize"
-	lru "github.com/hashicorp/golang-lru/v2"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/erigontech/erigon-lib/common"
 	"github.com/erigontech/erigon-lib/log/v3"
@@ -41,6 +40,12 @@ const (
 
 	// conservative over-estimation: 1 MB block size x 1024 blocks per waypoint
 	blockDownloaderEstimatedRamPerWorker = estimate.EstimatedRamPerWorker(1 * datasize.GB)
+
+	// downloaded waypoints which can queue up while the store is busy inserting blocks
+	blockDownloaderWriteBehindLimit = 2
+
+	// blocks of consecutive waypoints are inserted into the store in batches of at least the size of a checkpoint
+	blockDownloaderWriteBatchSize = 1024
 )
 
 type BlockDownloader interface {
@@ -129,6 +134,13 @@ func (d *blockDownloader) DownloadBlocksUsingMilestones(ctx context.Context, sta
 	return d.downloadBlocksUsingWaypoints(ctx, waypoints, d.milestoneVerifier, start)
 }
 
+// downloadBlocksUsingWaypoints downloads the blocks of the waypoints through a pipeline of stages:
+//  1. headers of a waypoint are fetched from an idle peer, with several waypoints being fetched from different peers in parallel
+//  2. headers are verified against the waypoint root hash, concurrently with the body downloads of other waypoints
+//  3. bodies of the verified headers are fetched from the same peer, and the assembled blocks are verified
+//  4. blocks are written to the store in order and in batches, behind the downloads and with a bounded number of pending waypoints
+//
+// A waypoint which fails in any stage is retried from another peer, without holding back the other waypoints.
 func (d *blockDownloader) downloadBlocksUsingWaypoints(
 	ctx context.Context,
 	waypoints heimdall.Waypoints,
@@ -150,187 +162,289 @@ func (d *blockDownloader) downloadBlocksUsingWaypoints(
 		"blockLimit", d.blockLimit,
 	)
 
-	// waypoint rootHash->[blocks part of waypoint]
-	waypointBlocksMemo, err := lru.New[common.Hash, []*types.Block](d.p2pService.MaxPeers())
-	if err != nil {
+	stats := newBlockDownloaderStats()
+	verifications := make(chan *waypointDownload)
+	results := make(chan *waypointDownload)
+	writes := make(chan []*types.Block, blockDownloaderWriteBehindLimit)
+
+	eg, ctx := errgroup.WithContext(ctx)
+	eg.Go(func() error {
+		return d.verifyWaypointHeaders(ctx, eg, verifier, stats, verifications, results)
+	})
+
+	var lastBlock *types.Block
+	eg.Go(func() (err error) {
+		lastBlock, err = d.writeBlocks(ctx, stats, writes)
+		return err
+	})
+
+	eg.Go(func() error {
+		defer close(writes)
+		err := d.scheduleWaypoints(ctx, eg, waypoints, startBlockNum, stats, verifications, results, writes)
+		if err == nil {
+			// all downloads have finished, so nothing sends to the verification stage anymore
+			close(verifications)
+		}
+		return err
+	})
+
+	if err := eg.Wait(); err != nil {
 		return nil, err
 	}
 
+	d.logger.Debug(syncLogPrefix("finished downloading blocks using waypoints"))
+
+	return lastBlock.Header(), nil
+}
+
+// waypointDownload carries a waypoint through the stages of the pipeline.
+type waypointDownload struct {
+	index    int
+	waypoint heimdall.Waypoint
+	peerId   *p2p.PeerId
+	headers  p2p.FetcherResponse[[]*types.Header]
+	blocks   []*types.Block
+	err      error
+}
+
+// scheduleWaypoints assigns the waypoints to idle peers and hands over the downloaded blocks, in order, to the
+// write stage. The waypoints which are downloading or waiting for the ones before them are at most maxWorkers,
+// which bounds the memory used by the pipeline.
+func (d *blockDownloader) scheduleWaypoints(
+	ctx context.Context,
+	eg *errgroup.Group,
+	waypoints heimdall.Waypoints,
+	startBlockNum uint64,
+	stats *blockDownloaderStats,
+	verifications chan<- *waypointDownload,
+	results chan *waypointDownload,
+	writes chan<- []*types.Block,
+) error {
 	progressLogTicker := time.NewTicker(30 * time.Second)
 	defer progressLogTicker.Stop()
 
-	var lastBlock *types.Block
-	batchFetchStartTime := time.Now()
-	fetchStartTime := time.Now()
-	var blockCount, blocksTotalSize atomic.Uint64
-
-	for len(waypoints) > 0 {
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		default:
-			// carry-on
+	endBlockNum := waypoints[len(waypoints)-1].EndBlock().Uint64()
+	nextIndex := 0  // first waypoint which has never been scheduled
+	writeIndex := 0 // next waypoint to hand over to the write stage
+	var retryIndices []int
+	downloaded := map[int][]*types.Block{}
+	failedPeers := map[int]p2p.PeerId{}
+	busyPeers := map[p2p.PeerId]struct{}{}
+
+	// the waypoint the write stage waits for is always scheduled, so that the pipeline cannot stall
+	nextToSchedule := func() (int, bool) {
+		index := nextIndex
+		if len(retryIndices) > 0 {
+			index = retryIndices[0]
 		}
 
-		endBlockNum := waypoints[len(waypoints)-1].EndBlock().Uint64()
-		peers := d.p2pService.ListPeersMayHaveBlockNum(endBlockNum)
-		if len(peers) == 0 {
-			d.logger.Warn(
-				syncLogPrefix("can't use any peers to download blocks, will try again in a bit"),
-				"start", waypoints[0].StartBlock(),
-				"end", endBlockNum,
-				"sleepSeconds", d.notEnoughPeersBackOffDuration.Seconds(),
-			)
+		if index >= len(waypoints) {
+			return 0, false
+		}
 
-			if err := common.Sleep(ctx, d.notEnoughPeersBackOffDuration); err != nil {
-				return nil, err
-			}
+		return index, index == writeIndex || len(busyPeers)+len(downloaded) < d.maxWorkers
+	}
 
-			continue
-		}
+	for writeIndex < len(waypoints) {
+		if _, ok := nextToSchedule(); ok {
+			peers := d.p2pService.ListPeersMayHaveBlockNum(endBlockNum)
+			if len(peers) == 0 && len(busyPeers) == 0 {
+				d.logger.Warn(
+					syncLogPrefix("can't use any peers to download blocks, will try again in a bit"),
+					"start", waypoints[writeIndex].StartBlock(),
+					"end", endBlockNum,
+					"sleepSeconds", d.notEnoughPeersBackOffDuration.Seconds(),
+				)
 
-		numWorkers := min(d.maxWorkers, len(peers), len(waypoints))
-		waypointsBatch := waypoints[:numWorkers]
+				if err := common.Sleep(ctx, d.notEnoughPeersBackOffDuration); err != nil {
+					return err
+				}
 
-		select {
-		case <-progressLogTicker.C:
-			d.logger.Info(
-				syncLogPrefix("downloading blocks progress"),
-				"waypointsBatchLength", len(waypointsBatch),
-				"startBlockNum", waypointsBatch[0].StartBlock(),
-				"endBlockNum", waypointsBatch[len(waypointsBatch)-1].EndBlock(),
-				"kind", reflect.TypeOf(waypointsBatch[0]),
-				"peerCount", len(peers),
-				"maxWorkers", d.maxWorkers,
-				"blk/s", fmt.Sprintf("%.2f", float64(blockCount.Load())/time.Since(fetchStartTime).Seconds()),
-				"bytes/s", common.ByteCount(uint64(float64(blocksTotalSize.Load())/time.Since(fetchStartTime).Seconds())),
-			)
+				continue
+			}
 
-			blockCount.Store(0)
-			blocksTotalSize.Store(0)
-			fetchStartTime = time.Now()
+			idlePeers := make([]*p2p.PeerId, 0, len(peers))
+			for _, peerId := range peers {
+				if _, busy := busyPeers[*peerId]; !busy {
+					idlePeers = append(idlePeers, peerId)
+				}
+			}
 
-		default:
-			// carry on
-		}
+			for len(idlePeers) > 0 {
+				index, ok := nextToSchedule()
+				if !ok {
+					break
+				}
 
-		blockBatches := make([][]*types.Block, len(waypointsBatch))
-		maxWaypointLength := uint64(0)
-		wg := sync.WaitGroup{}
-		for i, waypoint := range waypointsBatch {
-			maxWaypointLength = max(waypoint.Length(), maxWaypointLength)
-			wg.Add(1)
-			go func(i int, waypoint heimdall.Waypoint, peerId *p2p.PeerId) {
-				defer wg.Done()
-
-				if blocks, ok := waypointBlocksMemo.Get(waypoint.RootHash()); ok {
-					blockBatches[i] = blocks
-					return
+				if len(retryIndices) > 0 {
+					retryIndices = retryIndices[1:]
+				} else {
+					nextIndex++
 				}
 
-				blocks, totalSize, err := d.fetchVerifiedBlocks(ctx, waypoint, peerId, verifier)
-				if err != nil {
-					d.logger.Debug(
-						syncLogPrefix("issue downloading waypoint blocks - will try again"),
-						"err", err,
-						"start", waypoint.StartBlock(),
-						"end", waypoint.EndBlock(),
-						"rootHash", waypoint.RootHash(),
-						"kind", reflect.TypeOf(waypoint),
-						"peerId", peerId,
-					)
-
-					return
+				// prefer a different peer than the one which has failed the waypoint
+				peerIndex := 0
+				if failedPeer, ok := failedPeers[index]; ok {
+					for i, peerId := range idlePeers {
+						if *peerId != failedPeer {
+							peerIndex = i
+							break
+						}
+					}
 				}
 
-				blocksTotalSize.Add(uint64(totalSize))
-				blockCount.Add(uint64(len(blocks)))
+				peerId := idlePeers[peerIndex]
+				idlePeers = append(idlePeers[:peerIndex], idlePeers[peerIndex+1:]...)
+				busyPeers[*peerId] = struct{}{}
 
-				waypointBlocksMemo.Add(waypoint.RootHash(), blocks)
-				blockBatches[i] = blocks
-			}(i, waypoint, peers[i])
+				download := &waypointDownload{index: index, waypoint: waypoints[index], peerId: peerId}
+				eg.Go(func() error {
+					d.fetchWaypointHeaders(ctx, download, stats, verifications, results)
+					return nil
+				})
+			}
 		}
 
-		wg.Wait()
-		blocks := make([]*types.Block, 0, int(maxWaypointLength)*len(waypointsBatch))
-		gapIndex := -1
-		for i, blockBatch := range blockBatches {
-			if len(blockBatch) == 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-progressLogTicker.C:
+			d.logger.Info(
+				syncLogPrefix("downloading blocks progress"),
+				append([]interface{}{
+					"writtenWaypoints", writeIndex,
+					"waypoints", len(waypoints),
+					"startBlockNum", waypoints[writeIndex].StartBlock(),
+					"endBlockNum", endBlockNum,
+					"kind", reflect.TypeOf(waypoints[writeIndex]),
+					"busyPeers", len(busyPeers),
+					"maxWorkers", d.maxWorkers,
+				}, stats.throughputLogArgs()...)...,
+			)
+		case download := <-results:
+			delete(busyPeers, *download.peerId)
+
+			if download.err != nil {
 				d.logger.Debug(
-					syncLogPrefix("no blocks - will try again"),
-					"start", waypointsBatch[i].StartBlock(),
-					"end", waypointsBatch[i].EndBlock(),
-					"rootHash", waypointsBatch[i].RootHash(),
-					"kind", reflect.TypeOf(waypointsBatch[i]),
+					syncLogPrefix("issue downloading waypoint blocks - will try again"),
+					"err", download.err,
+					"start", download.waypoint.StartBlock(),
+					"end", download.waypoint.EndBlock(),
+					"rootHash", download.waypoint.RootHash(),
+					"kind", reflect.TypeOf(download.waypoint),
+					"peerId", download.peerId,
 				)
 
-				gapIndex = i
-				break
-			}
-
-			batchStart := blockBatch[0].Number().Uint64()
-			batchEnd := blockBatch[len(blockBatch)-1].Number().Uint64()
-			if batchStart <= startBlockNum && startBlockNum <= batchEnd {
-				// we do not want to re-insert blocks of the first waypoint if the start block
-				// falls in the middle of the waypoint range
-				blockBatch = blockBatch[startBlockNum-batchStart:]
+				failedPeers[download.index] = *download.peerId
+				retryIndices = append(retryIndices, download.index)
+				sort.Ints(retryIndices)
+				continue
 			}
 
-			blocks = append(blocks, blockBatch...)
-		}
+			downloaded[download.index] = download.blocks
+			for blocks, ok := downloaded[writeIndex]; ok; blocks, ok = downloaded[writeIndex] {
+				batchStart := blocks[0].Number().Uint64()
+				batchEnd := blocks[len(blocks)-1].Number().Uint64()
+				if batchStart <= startBlockNum && startBlockNum <= batchEnd {
+					// we do not want to re-insert blocks of the first waypoint if the start block
+					// falls in the middle of the waypoint range
+					blocks = blocks[startBlockNum-batchStart:]
+				}
 
-		if gapIndex >= 0 {
-			waypoints = waypoints[gapIndex:]
-		} else {
-			waypoints = waypoints[len(waypointsBatch):]
-		}
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case writes <- blocks:
+				}
 
-		if len(blocks) == 0 {
-			continue
+				delete(downloaded, writeIndex)
+				writeIndex++
+			}
 		}
+	}
 
-		d.logger.Debug(syncLogPrefix("fetched blocks"), "len", len(blocks), "duration", time.Since(batchFetchStartTime))
-
-		batchFetchStartTime = time.Now() // reset for next time
-
-		if err := d.store.InsertBlocks(ctx, blocks); err != nil {
-			return nil, err
-		}
+	return nil
+}
 
-		lastBlock = blocks[len(blocks)-1]
+func (d *blockDownloader) fetchWaypointHeaders(
+	ctx context.Context,
+	download *waypointDownload,
+	stats *blockDownloaderStats,
+	verifications chan<- *waypointDownload,
+	results chan<- *waypointDownload,
+) {
+	fetchStartTime := time.Now()
+	start := download.waypoint.StartBlock().Uint64()
+	end := download.waypoint.EndBlock().Uint64() + 1 // waypoint end is inclusive, fetch headers is [start, end)
+	headers, err := d.p2pService.FetchHeaders(ctx, start, end, download.peerId)
+	if err == nil && len(headers.Data) == 0 {
+		err = errors.New("no headers")
+	}
+	if err != nil {
+		download.err = err
+		sendWaypointDownload(ctx, results, download)
+		return
 	}
 
-	d.logger.Debug(syncLogPrefix("finished downloading blocks using waypoints"))
-
-	return lastBlock.Header(), nil
+	stats.headers.observe(fetchStartTime, len(headers.Data), headers.TotalSize)
+	download.headers = headers
+	sendWaypointDownload(ctx, verifications, download)
 }
 
-func (d *blockDownloader) fetchVerifiedBlocks(
+// verifyWaypointHeaders verifies the fetched headers match their waypoint root hash, and starts fetching the bodies
+// of the ones which do.
+func (d *blockDownloader) verifyWaypointHeaders(
 	ctx context.Context,
-	waypoint heimdall.Waypoint,
-	peerId *p2p.PeerId,
+	eg *errgroup.Group,
 	verifier WaypointHeadersVerifier,
-) ([]*types.Block, int, error) {
-	// 1. Fetch headers in waypoint from a peer
-	start := waypoint.StartBlock().Uint64()
-	end := waypoint.EndBlock().Uint64() + 1 // waypoint end is inclusive, fetch headers is [start, end)
-	headers, err := d.p2pService.FetchHeaders(ctx, start, end, peerId)
-	if err != nil {
-		return nil, 0, err
-	}
+	stats *blockDownloaderStats,
+	verifications <-chan *waypointDownload,
+	results chan<- *waypointDownload,
+) error {
+	for {
+		var download *waypointDownload
+		var ok bool
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case download, ok = <-verifications:
+			if !ok {
+				return nil
+			}
+		}
 
-	// 2. Verify headers match waypoint root hash
-	if err = verifier(waypoint, headers.Data); err != nil {
-		d.logger.Debug(syncLogPrefix("penalizing peer - invalid headers"), "peerId", peerId, "err", err)
+		verifyStartTime := time.Now()
+		if err := verifier(download.waypoint, download.headers.Data); err != nil {
+			d.logger.Debug(syncLogPrefix("penalizing peer - invalid headers"), "peerId", download.peerId, "err", err)
 
-		if penalizeErr := d.p2pService.Penalize(ctx, peerId); penalizeErr != nil {
-			err = fmt.Errorf("%w: %w", penalizeErr, err)
+			if penalizeErr := d.p2pService.Penalize(ctx, download.peerId); penalizeErr != nil {
+				err = fmt.Errorf("%w: %w", penalizeErr, err)
+			}
+
+			download.err = err
+			sendWaypointDownload(ctx, results, download)
+			continue
 		}
 
-		return nil, 0, err
+		stats.verify.observe(verifyStartTime, len(download.headers.Data), 0)
+		eg.Go(func() error {
+			d.fetchWaypointBodies(ctx, download, stats, results)
+			return nil
+		})
 	}
+}
 
-	// 3. Fetch bodies for the verified waypoint headers
+func (d *blockDownloader) fetchWaypointBodies(
+	ctx context.Context,
+	download *waypointDownload,
+	stats *blockDownloaderStats,
+	results chan<- *waypointDownload,
+) {
+	defer sendWaypointDownload(ctx, results, download)
+
+	fetchStartTime := time.Now()
+	headers := download.headers
+	peerId := download.peerId
 	bodies, err := d.p2pService.FetchBodies(ctx, headers.Data, peerId)
 	if err != nil {
 		if errors.Is(err, &p2p.ErrMissingBodies{}) {
@@ -341,16 +455,17 @@ func (d *blockDownloader) fetchVerifiedBlocks(
 			}
 		}
 
-		return nil, 0, err
+		download.err = err
+		return
 	}
 
-	// 4. Assemble blocks
+	stats.bodies.observe(fetchStartTime, len(bodies.Data), bodies.TotalSize)
+
 	blocks := make([]*types.Block, len(headers.Data))
 	for i, header := range headers.Data {
 		blocks[i] = types.NewBlockFromNetwork(header, bodies.Data[i])
 	}
 
-	// 5. Verify blocks
 	if err = d.blocksVerifier(blocks); err != nil {
 		d.logger.Debug(syncLogPrefix("penalizing peer - invalid blocks"), "peerId", peerId, "err", err)
 
@@ -358,10 +473,61 @@ func (d *blockDownloader) fetchVerifiedBlocks(
 			err = fmt.Errorf("%w: %w", penalizeErr, err)
 		}
 
-		return nil, 0, err
+		download.err = err
+		return
+	}
+
+	download.blocks = blocks
+}
+
+// writeBlocks inserts the downloaded blocks into the store, merging consecutive waypoints into batches of
+// blockDownloaderWriteBatchSize blocks.
+func (d *blockDownloader) writeBlocks(ctx context.Context, stats *blockDownloaderStats, writes <-chan []*types.Block) (*types.Block, error) {
+	var lastBlock *types.Block
+	var batch []*types.Block
+	insert := func() error {
+		writeStartTime := time.Now()
+		if err := d.store.InsertBlocks(ctx, batch); err != nil {
+			return err
+		}
+
+		stats.write.observe(writeStartTime, len(batch), 0)
+		d.logger.Debug(syncLogPrefix("inserted blocks"), "len", len(batch), "duration", time.Since(writeStartTime))
+		lastBlock = batch[len(batch)-1]
+		batch = nil
+		return nil
+	}
+
+	for blocks := range writes {
+		batch = append(batch, blocks...)
+		if len(batch) < blockDownloaderWriteBatchSize {
+			continue
+		}
+
+		if err := insert(); err != nil {
+			return nil, err
+		}
+	}
+
+	// writes is closed on errors too, do not insert a partial batch then
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(batch) > 0 {
+		if err := insert(); err != nil {
+			return nil, err
+		}
 	}
 
-	return blocks, headers.TotalSize + bodies.TotalSize, nil
+	return lastBlock, nil
+}
+
+func sendWaypointDownload(ctx context.Context, ch chan<- *waypointDownload, download *waypointDownload) {
+	select {
+	case <-ctx.Done():
+	case ch <- download:
+	}
 }
 
 func (d *blockDownloader) limitWaypoints(waypoints []heimdall.Waypoint) []heimdall.Waypoint {
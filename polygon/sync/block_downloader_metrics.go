@@ -0,0 +1,102 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package sync
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/metrics"
+)
+
+type blockDownloaderStageMeter struct {
+	blocks   metrics.Counter
+	bytes    metrics.Counter
+	duration metrics.Summary
+}
+
+func newBlockDownloaderStageMeter(stage string) blockDownloaderStageMeter {
+	return blockDownloaderStageMeter{
+		blocks:   metrics.GetOrCreateCounter(fmt.Sprintf(`polygon_sync_block_downloader_blocks{stage="%s"}`, stage)),
+		bytes:    metrics.GetOrCreateCounter(fmt.Sprintf(`polygon_sync_block_downloader_bytes{stage="%s"}`, stage)),
+		duration: metrics.GetOrCreateSummary(fmt.Sprintf(`polygon_sync_block_downloader_seconds{stage="%s"}`, stage)),
+	}
+}
+
+var (
+	blockDownloaderHeadersMeter = newBlockDownloaderStageMeter("headers")
+	blockDownloaderVerifyMeter  = newBlockDownloaderStageMeter("verify")
+	blockDownloaderBodiesMeter  = newBlockDownloaderStageMeter("bodies")
+	blockDownloaderWriteMeter   = newBlockDownloaderStageMeter("write")
+)
+
+// blockDownloaderStageStats measures the throughput of a stage of the block download pipeline, both into the
+// metrics and for the periodic progress logs.
+type blockDownloaderStageStats struct {
+	name   string
+	meter  blockDownloaderStageMeter
+	blocks atomic.Uint64
+	bytes  atomic.Uint64
+}
+
+func (s *blockDownloaderStageStats) observe(start time.Time, blocks int, bytes int) {
+	s.meter.duration.ObserveDuration(start)
+	s.meter.blocks.AddInt(blocks)
+	s.meter.bytes.AddInt(bytes)
+	s.blocks.Add(uint64(blocks))
+	s.bytes.Add(uint64(bytes))
+}
+
+type blockDownloaderStats struct {
+	headers     blockDownloaderStageStats
+	verify      blockDownloaderStageStats
+	bodies      blockDownloaderStageStats
+	write       blockDownloaderStageStats
+	periodStart time.Time
+}
+
+func newBlockDownloaderStats() *blockDownloaderStats {
+	return &blockDownloaderStats{
+		headers:     blockDownloaderStageStats{name: "headers", meter: blockDownloaderHeadersMeter},
+		verify:      blockDownloaderStageStats{name: "verify", meter: blockDownloaderVerifyMeter},
+		bodies:      blockDownloaderStageStats{name: "bodies", meter: blockDownloaderBodiesMeter},
+		write:       blockDownloaderStageStats{name: "write", meter: blockDownloaderWriteMeter},
+		periodStart: time.Now(),
+	}
+}
+
+// throughputLogArgs returns the throughput of each stage since the previous call and starts a new period.
+func (s *blockDownloaderStats) throughputLogArgs() []interface{} {
+	seconds := time.Since(s.periodStart).Seconds()
+	s.periodStart = time.Now()
+
+	var args []interface{}
+	for _, stage := range []*blockDownloaderStageStats{&s.headers, &s.verify, &s.bodies, &s.write} {
+		blocks := stage.blocks.Swap(0)
+		bytes := stage.bytes.Swap(0)
+		throughput := fmt.Sprintf("%.2f blk/s", float64(blocks)/seconds)
+		if bytes > 0 {
+			throughput += fmt.Sprintf(" %s/s", common.ByteCount(uint64(float64(bytes)/seconds)))
+		}
+
+		args = append(args, stage.name, throughput)
+	}
+
+	return args
+}
@@ -22,6 +22,8 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -45,7 +47,6 @@ func newBlockDownloaderTestWithOpts(t *testing.T, opts blockDownloaderTestOpts)
 	ctrl := gomock.NewController(t)
 	waypointReader := NewMockwaypointReader(ctrl)
 	p2pService := p2p.NewMockService(ctrl)
-	p2pService.EXPECT().MaxPeers().Return(100).Times(1)
 	logger := testlog.Logger(t, log.LvlDebug)
 	checkpointVerifier := opts.getOrCreateDefaultCheckpointVerifier()
 	milestoneVerifier := opts.getOrCreateDefaultMilestoneVerifier()
@@ -225,6 +226,47 @@ func (hdt blockDownloaderTest) defaultFetchBodiesMock() fetchBodiesMock {
 	}
 }
 
+func requireBlocksInOrder(t *testing.T, blocks []*types.Block, start uint64) {
+	for i, block := range blocks {
+		require.Equal(t, start+uint64(i), block.NumberU64())
+	}
+}
+
+// fetchHeadersCounter counts the header requests of each waypoint, by waypoint start block.
+type fetchHeadersCounter struct {
+	mu     sync.Mutex
+	counts map[uint64]int
+}
+
+func newFetchHeadersCounter() *fetchHeadersCounter {
+	return &fetchHeadersCounter{counts: map[uint64]int{}}
+}
+
+func (c *fetchHeadersCounter) wrap(fetchHeaders fetchHeadersMock) fetchHeadersMock {
+	return func(ctx context.Context, start uint64, end uint64, peerId *p2p.PeerId) (p2p.FetcherResponse[[]*types.Header], error) {
+		c.mu.Lock()
+		c.counts[start]++
+		c.mu.Unlock()
+		return fetchHeaders(ctx, start, end, peerId)
+	}
+}
+
+func (c *fetchHeadersCounter) total() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var total int
+	for _, count := range c.counts {
+		total += count
+	}
+	return total
+}
+
+func (c *fetchHeadersCounter) count(start uint64) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counts[start]
+}
+
 func (hdt blockDownloaderTest) defaultInsertBlocksMock(capture *[]*types.Block) func(context.Context, []*types.Block) error {
 	return func(ctx context.Context, blocks []*types.Block) error {
 		*capture = append(*capture, blocks...)
@@ -241,7 +283,7 @@ func TestBlockDownloaderDownloadBlocksUsingMilestones(t *testing.T) {
 	test.p2pService.EXPECT().
 		ListPeersMayHaveBlockNum(gomock.Any()).
 		Return(test.fakePeers(8)).
-		Times(1)
+		AnyTimes()
 	test.p2pService.EXPECT().
 		FetchHeaders(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		DoAndReturn(test.defaultFetchHeadersMock()).
@@ -254,7 +296,7 @@ func TestBlockDownloaderDownloadBlocksUsingMilestones(t *testing.T) {
 	test.store.EXPECT().
 		InsertBlocks(gomock.Any(), gomock.Any()).
 		DoAndReturn(test.defaultInsertBlocksMock(&blocks)).
-		Times(1)
+		Times(1) // 4 milestones x 12 blocks each are less than a write batch
 
 	tip, err := test.blockDownloader.DownloadBlocksUsingMilestones(context.Background(), 1)
 	require.NoError(t, err)
@@ -277,7 +319,7 @@ func TestBlockDownloaderDownloadBlocksUsingCheckpoints(t *testing.T) {
 	test.p2pService.EXPECT().
 		ListPeersMayHaveBlockNum(gomock.Any()).
 		Return(test.fakePeers(2)).
-		Times(4)
+		AnyTimes()
 	test.p2pService.EXPECT().
 		FetchHeaders(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		DoAndReturn(test.defaultFetchHeadersMock()).
@@ -290,7 +332,7 @@ func TestBlockDownloaderDownloadBlocksUsingCheckpoints(t *testing.T) {
 	test.store.EXPECT().
 		InsertBlocks(gomock.Any(), gomock.Any()).
 		DoAndReturn(test.defaultInsertBlocksMock(&blocks)).
-		Times(4)
+		Times(8) // a write batch per checkpoint
 
 	tip, err := test.blockDownloader.DownloadBlocksUsingCheckpoints(context.Background(), 1)
 	require.NoError(t, err)
@@ -317,7 +359,7 @@ func TestBlockDownloaderDownloadBlocksUsingCheckpointsWhenStartIsInMiddleOfCheck
 	test.p2pService.EXPECT().
 		ListPeersMayHaveBlockNum(gomock.Any()).
 		Return(test.fakePeers(2)).
-		Times(1)
+		AnyTimes()
 	test.p2pService.EXPECT().
 		FetchHeaders(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		DoAndReturn(test.defaultFetchHeadersMock()).
@@ -330,7 +372,7 @@ func TestBlockDownloaderDownloadBlocksUsingCheckpointsWhenStartIsInMiddleOfCheck
 	test.store.EXPECT().
 		InsertBlocks(gomock.Any(), gomock.Any()).
 		DoAndReturn(test.defaultInsertBlocksMock(&blocks)).
-		Times(1)
+		Times(1) // the 512 blocks of the 1st checkpoint are merged with the 2nd checkpoint
 
 	tip, err := test.blockDownloader.DownloadBlocksUsingCheckpoints(context.Background(), 513)
 	require.NoError(t, err)
@@ -356,21 +398,20 @@ func TestBlockDownloaderDownloadBlocksWhenInvalidHeadersThenPenalizePeerAndReDow
 			return nil
 		},
 	})
+	fetchHeaders := newFetchHeadersCounter()
 	test.waypointReader.EXPECT().
 		CheckpointsFromBlock(gomock.Any(), gomock.Any()).
 		Return(test.fakeCheckpoints(6), nil).
 		Times(1)
 	test.p2pService.EXPECT().
 		FetchHeaders(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-		DoAndReturn(test.defaultFetchHeadersMock()).
+		DoAndReturn(fetchHeaders.wrap(test.defaultFetchHeadersMock())).
 		// request checkpoints 1,2,3 in parallel (we have 3 peers)
 		// -> verifications for checkpoint 2 headers fails, checkpoints 1 and 3 pass verifications
-		// requests 2,3 in parallel (now we have only 2 peers)
-		// -> checkpoint 3 blocks are cached, checkpoint 2 is re-requested from another peer and passes verifications
-		// requests 4,5 in parallel
-		// request 6
+		// checkpoint 2 is re-requested from another peer (now we have only 2 peers) and passes verifications,
+		// while the idle peers carry on with checkpoints 4,5,6
 		// in total 6 requests + 1 request for re-requesting checkpoint 2 headers
-		// total = 7 (note this also tests blocks caching works)
+		// total = 7 (note this also tests only the failed checkpoint is re-requested)
 		Times(7)
 	test.p2pService.EXPECT().
 		FetchBodies(gomock.Any(), gomock.Any(), gomock.Any()).
@@ -386,7 +427,7 @@ func TestBlockDownloaderDownloadBlocksWhenInvalidHeadersThenPenalizePeerAndReDow
 		test.p2pService.EXPECT().
 			ListPeersMayHaveBlockNum(gomock.Any()).
 			Return([]*p2p.PeerId{fakePeers[0], fakePeers[2]}). // but then peer 2 gets penalized
-			Times(3),
+			AnyTimes(),
 	)
 	test.p2pService.EXPECT().
 		Penalize(gomock.Any(), gomock.Eq(p2p.PeerIdFromUint64(2))).
@@ -400,13 +441,17 @@ func TestBlockDownloaderDownloadBlocksWhenInvalidHeadersThenPenalizePeerAndReDow
 		test.store.EXPECT().
 			InsertBlocks(gomock.Any(), gomock.Any()).
 			DoAndReturn(test.defaultInsertBlocksMock(&blocksBatch2)).
-			Times(3),
+			Times(5),
 	)
 
 	_, err := test.blockDownloader.DownloadBlocksUsingCheckpoints(context.Background(), 1)
 	require.NoError(t, err)
 	require.Len(t, blocksBatch1, 1024)
 	require.Len(t, blocksBatch2, 5120)
+	requireBlocksInOrder(t, append(blocksBatch1, blocksBatch2...), 1)
+	// checkpoint 3 blocks are cached while checkpoint 2 is re-requested
+	require.Equal(t, 2, fetchHeaders.count(1025))
+	require.Equal(t, 1, fetchHeaders.count(2049))
 }
 
 func TestBlockDownloaderDownloadBlocksWhenZeroPeersTriesAgain(t *testing.T) {
@@ -427,7 +472,7 @@ func TestBlockDownloaderDownloadBlocksWhenZeroPeersTriesAgain(t *testing.T) {
 	test.store.EXPECT().
 		InsertBlocks(gomock.Any(), gomock.Any()).
 		DoAndReturn(test.defaultInsertBlocksMock(&blocks)).
-		Times(4)
+		Times(8) // a write batch per checkpoint
 	gomock.InOrder(
 		// first time, no peers at all
 		test.p2pService.EXPECT().
@@ -438,7 +483,7 @@ func TestBlockDownloaderDownloadBlocksWhenZeroPeersTriesAgain(t *testing.T) {
 		test.p2pService.EXPECT().
 			ListPeersMayHaveBlockNum(gomock.Any()).
 			Return(test.fakePeers(2)).
-			Times(4),
+			AnyTimes(),
 	)
 
 	tip, err := test.blockDownloader.DownloadBlocksUsingCheckpoints(context.Background(), 1)
@@ -448,33 +493,31 @@ func TestBlockDownloaderDownloadBlocksWhenZeroPeersTriesAgain(t *testing.T) {
 }
 
 func TestBlockDownloaderDownloadBlocksWhenInvalidBodiesThenPenalizePeerAndReDownload(t *testing.T) {
-	var firstTimeInvalidReturned bool
-	firstTimeInvalidReturnedPtr := &firstTimeInvalidReturned
+	// blocks of different checkpoints are verified concurrently
+	var firstTimeInvalidReturned atomic.Bool
 	test := newBlockDownloaderTestWithOpts(t, blockDownloaderTestOpts{
 		blocksVerifier: func(blocks []*types.Block) error {
 			// 1025 is beginning of 2nd checkpoint
-			if blocks[0].NumberU64() == 1025 && !*firstTimeInvalidReturnedPtr {
-				*firstTimeInvalidReturnedPtr = true
+			if blocks[0].NumberU64() == 1025 && firstTimeInvalidReturned.CompareAndSwap(false, true) {
 				return errors.New("invalid block body")
 			}
 			return nil
 		},
 	})
+	fetchHeaders := newFetchHeadersCounter()
 	test.waypointReader.EXPECT().
 		CheckpointsFromBlock(gomock.Any(), gomock.Any()).
 		Return(test.fakeCheckpoints(6), nil).
 		Times(1)
 	test.p2pService.EXPECT().
 		FetchHeaders(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-		DoAndReturn(test.defaultFetchHeadersMock()).
+		DoAndReturn(fetchHeaders.wrap(test.defaultFetchHeadersMock())).
 		// request checkpoints 1,2,3 in parallel (we have 3 peers)
 		// -> verifications for checkpoint 2 bodies fails, checkpoints 1 and 3 pass verifications
-		// requests 2,3 in parallel (now we have only 2 peers)
-		// -> checkpoint 3 blocks are cached, checkpoint 2 is re-requested from another peer and passes verifications
-		// requests 4,5 in parallel
-		// request 6
+		// checkpoint 2 is re-requested from another peer (now we have only 2 peers) and passes verifications,
+		// while the idle peers carry on with checkpoints 4,5,6
 		// in total 6 requests + 1 request for re-requesting checkpoint 2 headers + bodies
-		// total = 7 (note this also tests blocks caching works)
+		// total = 7 (note this also tests only the failed checkpoint is re-requested)
 		Times(7)
 	test.p2pService.EXPECT().
 		FetchBodies(gomock.Any(), gomock.Any(), gomock.Any()).
@@ -490,7 +533,7 @@ func TestBlockDownloaderDownloadBlocksWhenInvalidBodiesThenPenalizePeerAndReDown
 		test.p2pService.EXPECT().
 			ListPeersMayHaveBlockNum(gomock.Any()).
 			Return([]*p2p.PeerId{fakePeers[0], fakePeers[2]}). // but then peer 2 gets penalized
-			Times(3),
+			AnyTimes(),
 	)
 	test.p2pService.EXPECT().
 		Penalize(gomock.Any(), gomock.Eq(p2p.PeerIdFromUint64(2))).
@@ -504,17 +547,22 @@ func TestBlockDownloaderDownloadBlocksWhenInvalidBodiesThenPenalizePeerAndReDown
 		test.store.EXPECT().
 			InsertBlocks(gomock.Any(), gomock.Any()).
 			DoAndReturn(test.defaultInsertBlocksMock(&blocksBatch2)).
-			Times(3),
+			Times(5),
 	)
 
 	_, err := test.blockDownloader.DownloadBlocksUsingCheckpoints(context.Background(), 1)
 	require.NoError(t, err)
 	require.Len(t, blocksBatch1, 1024)
 	require.Len(t, blocksBatch2, 5120)
+	requireBlocksInOrder(t, append(blocksBatch1, blocksBatch2...), 1)
+	// checkpoint 3 blocks are cached while checkpoint 2 is re-requested
+	require.Equal(t, 2, fetchHeaders.count(1025))
+	require.Equal(t, 1, fetchHeaders.count(2049))
 }
 
 func TestBlockDownloaderDownloadBlocksWhenMissingBodiesThenPenalizePeerAndReDownload(t *testing.T) {
 	test := newBlockDownloaderTestWithOpts(t, blockDownloaderTestOpts{})
+	fetchHeaders := newFetchHeadersCounter()
 	test.waypointReader.EXPECT().
 		CheckpointsFromBlock(gomock.Any(), gomock.Any()).
 		Return(test.fakeCheckpoints(6), nil).
@@ -530,16 +578,14 @@ func TestBlockDownloaderDownloadBlocksWhenMissingBodiesThenPenalizePeerAndReDown
 		}).
 		// request checkpoints 1,2,3 in parallel (we have 3 peers)
 		// -> peer 2 returns missing bodies error, checkpoints 1 and 3 fetch succeeds
-		// requests 2,3 in parallel (now we have only 2 peers)
-		// -> checkpoint 3 blocks are cached, checkpoint 2 is re-requested from another peer and passes verifications
-		// requests 4,5 in parallel
-		// request 6
+		// checkpoint 2 is re-requested from another peer (now we have only 2 peers) and passes verifications,
+		// while the idle peers carry on with checkpoints 4,5,6
 		// in total 6 requests + 1 request for re-requesting checkpoint 2 headers + bodies
-		// total = 7 (note this also tests blocks caching works)
+		// total = 7 (note this also tests only the failed checkpoint is re-requested)
 		Times(7)
 	test.p2pService.EXPECT().
 		FetchHeaders(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-		DoAndReturn(test.defaultFetchHeadersMock()).
+		DoAndReturn(fetchHeaders.wrap(test.defaultFetchHeadersMock())).
 		// same explanation as above for FetchBodies.Times(7)
 		Times(7)
 	fakePeers := test.fakePeers(3)
@@ -551,7 +597,7 @@ func TestBlockDownloaderDownloadBlocksWhenMissingBodiesThenPenalizePeerAndReDown
 		test.p2pService.EXPECT().
 			ListPeersMayHaveBlockNum(gomock.Any()).
 			Return([]*p2p.PeerId{fakePeers[0], fakePeers[2]}). // but then peer 2 gets penalized
-			Times(3),
+			AnyTimes(),
 	)
 	test.p2pService.EXPECT().
 		Penalize(gomock.Any(), gomock.Eq(p2p.PeerIdFromUint64(2))).
@@ -565,13 +611,17 @@ func TestBlockDownloaderDownloadBlocksWhenMissingBodiesThenPenalizePeerAndReDown
 		test.store.EXPECT().
 			InsertBlocks(gomock.Any(), gomock.Any()).
 			DoAndReturn(test.defaultInsertBlocksMock(&blocksBatch2)).
-			Times(3),
+			Times(5),
 	)
 
 	_, err := test.blockDownloader.DownloadBlocksUsingCheckpoints(context.Background(), 1)
 	require.NoError(t, err)
 	require.Len(t, blocksBatch1, 1024)
 	require.Len(t, blocksBatch2, 5120)
+	requireBlocksInOrder(t, append(blocksBatch1, blocksBatch2...), 1)
+	// checkpoint 3 blocks are cached while checkpoint 2 is re-requested
+	require.Equal(t, 2, fetchHeaders.count(1025))
+	require.Equal(t, 1, fetchHeaders.count(2049))
 }
 
 func TestBlockDownloaderDownloadBlocksRespectsMaxWorkers(t *testing.T) {
@@ -585,14 +635,24 @@ func TestBlockDownloaderDownloadBlocksRespectsMaxWorkers(t *testing.T) {
 	test.p2pService.EXPECT().
 		ListPeersMayHaveBlockNum(gomock.Any()).
 		Return(test.fakePeers(100)).
-		Times(2)
+		AnyTimes()
+	var fetching, maxFetching atomic.Int32
 	test.p2pService.EXPECT().
 		FetchHeaders(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-		DoAndReturn(test.defaultFetchHeadersMock()).
+		DoAndReturn(func(ctx context.Context, start uint64, end uint64, peerId *p2p.PeerId) (p2p.FetcherResponse[[]*types.Header], error) {
+			n := fetching.Add(1)
+			if n > maxFetching.Load() {
+				maxFetching.Store(n)
+			}
+			return test.defaultFetchHeadersMock()(ctx, start, end, peerId)
+		}).
 		Times(2)
 	test.p2pService.EXPECT().
 		FetchBodies(gomock.Any(), gomock.Any(), gomock.Any()).
-		DoAndReturn(test.defaultFetchBodiesMock()).
+		DoAndReturn(func(ctx context.Context, headers []*types.Header, peerId *p2p.PeerId) (p2p.FetcherResponse[[]*types.Body], error) {
+			defer fetching.Add(-1)
+			return test.defaultFetchBodiesMock()(ctx, headers, peerId)
+		}).
 		Times(2)
 	var blocksBatch1, blocksBatch2 []*types.Block
 	gomock.InOrder(
@@ -609,11 +669,13 @@ func TestBlockDownloaderDownloadBlocksRespectsMaxWorkers(t *testing.T) {
 	// max 1 worker
 	// 100 peers
 	// 2 waypoints
-	// the downloader should fetch the 2 waypoints in 2 separate batches
+	// the downloader should fetch the 2 waypoints one after the other
 	_, err := test.blockDownloader.DownloadBlocksUsingCheckpoints(context.Background(), 1)
 	require.NoError(t, err)
+	require.Equal(t, int32(1), maxFetching.Load())
 	require.Len(t, blocksBatch1, 1024)
 	require.Len(t, blocksBatch2, 1024)
+	requireBlocksInOrder(t, append(blocksBatch1, blocksBatch2...), 1)
 }
 
 func TestBlockDownloaderDownloadBlocksRespectsBlockLimit(t *testing.T) {
@@ -669,7 +731,7 @@ func TestBlockDownloaderDownloadBlocksRespectsBlockLimit(t *testing.T) {
 			test.p2pService.EXPECT().
 				ListPeersMayHaveBlockNum(gomock.Any()).
 				Return(test.fakePeers(100)).
-				Times(1)
+				AnyTimes()
 			test.p2pService.EXPECT().
 				FetchHeaders(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 				DoAndReturn(test.defaultFetchHeadersMock()).
@@ -682,7 +744,7 @@ func TestBlockDownloaderDownloadBlocksRespectsBlockLimit(t *testing.T) {
 			test.store.EXPECT().
 				InsertBlocks(gomock.Any(), gomock.Any()).
 				DoAndReturn(test.defaultInsertBlocksMock(&insertedBlocks)).
-				Times(1)
+				Times(tc.wantNumBlockFetches)
 
 			_, err := test.blockDownloader.DownloadBlocksUsingCheckpoints(context.Background(), 1)
 			require.NoError(t, err)
@@ -690,3 +752,201 @@ func TestBlockDownloaderDownloadBlocksRespectsBlockLimit(t *testing.T) {
 		})
 	}
 }
+
+func TestBlockDownloaderDownloadBlocksWhenPeerIsSlowThenOtherPeersCarryOn(t *testing.T) {
+	test := newBlockDownloaderTest(t)
+	test.waypointReader.EXPECT().
+		CheckpointsFromBlock(gomock.Any(), gomock.Any()).
+		Return(test.fakeCheckpoints(6), nil).
+		Times(1)
+	test.p2pService.EXPECT().
+		ListPeersMayHaveBlockNum(gomock.Any()).
+		Return(test.fakePeers(3)).
+		AnyTimes()
+	lastCheckpointFetched := make(chan struct{})
+	test.p2pService.EXPECT().
+		FetchHeaders(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, start uint64, end uint64, peerId *p2p.PeerId) (p2p.FetcherResponse[[]*types.Header], error) {
+			if start == 1 {
+				// the peer of the 1st checkpoint is slow, the other 2 peers download all the other checkpoints meanwhile
+				select {
+				case <-lastCheckpointFetched:
+				case <-ctx.Done():
+					return p2p.FetcherResponse[[]*types.Header]{}, ctx.Err()
+				}
+			}
+
+			return test.defaultFetchHeadersMock()(ctx, start, end, peerId)
+		}).
+		Times(6)
+	test.p2pService.EXPECT().
+		FetchBodies(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, headers []*types.Header, peerId *p2p.PeerId) (p2p.FetcherResponse[[]*types.Body], error) {
+			if headers[0].Number.Uint64() == 5121 {
+				close(lastCheckpointFetched)
+			}
+
+			return test.defaultFetchBodiesMock()(ctx, headers, peerId)
+		}).
+		Times(6)
+	var blocks []*types.Block
+	test.store.EXPECT().
+		InsertBlocks(gomock.Any(), gomock.Any()).
+		DoAndReturn(test.defaultInsertBlocksMock(&blocks)).
+		Times(6)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	tip, err := test.blockDownloader.DownloadBlocksUsingCheckpoints(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, blocks, 6144)
+	requireBlocksInOrder(t, blocks, 1)
+	require.Equal(t, blocks[len(blocks)-1].Header(), tip)
+}
+
+func TestBlockDownloaderDownloadBlocksWhenWaypointIsSlowThenMaxWorkersBoundsDownloadedWaypoints(t *testing.T) {
+	test := newBlockDownloaderTestWithOpts(t, blockDownloaderTestOpts{
+		maxWorkers: 3,
+	})
+	test.waypointReader.EXPECT().
+		CheckpointsFromBlock(gomock.Any(), gomock.Any()).
+		Return(test.fakeCheckpoints(6), nil).
+		Times(1)
+	test.p2pService.EXPECT().
+		ListPeersMayHaveBlockNum(gomock.Any()).
+		Return(test.fakePeers(100)).
+		AnyTimes()
+	var fetchesWhileSlow atomic.Int32
+	fetchHeaders := newFetchHeadersCounter()
+	slowWaypointFetched := make(chan struct{})
+	othersFetched := make(chan struct{}, 2)
+	test.p2pService.EXPECT().
+		FetchHeaders(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(fetchHeaders.wrap(func(ctx context.Context, start uint64, end uint64, peerId *p2p.PeerId) (p2p.FetcherResponse[[]*types.Header], error) {
+			if start == 1 {
+				// the 1st checkpoint is slow, meanwhile only 2 more checkpoints can be downloaded
+				// and held back for writing
+				for i := 0; i < 2; i++ {
+					select {
+					case <-othersFetched:
+					case <-ctx.Done():
+						return p2p.FetcherResponse[[]*types.Header]{}, ctx.Err()
+					}
+				}
+				// give the downloader the chance to schedule more checkpoints than it should
+				time.Sleep(100 * time.Millisecond)
+				fetchesWhileSlow.Store(int32(fetchHeaders.total()))
+				close(slowWaypointFetched)
+			} else if start > 2049 {
+				select {
+				case <-slowWaypointFetched:
+				default:
+					t.Errorf("checkpoint starting at %d fetched while the 1st checkpoint is slow", start)
+				}
+			}
+
+			return test.defaultFetchHeadersMock()(ctx, start, end, peerId)
+		})).
+		Times(6)
+	test.p2pService.EXPECT().
+		FetchBodies(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, headers []*types.Header, peerId *p2p.PeerId) (p2p.FetcherResponse[[]*types.Body], error) {
+			defer func() {
+				if headers[0].Number.Uint64() != 1 {
+					select {
+					case othersFetched <- struct{}{}:
+					default:
+					}
+				}
+			}()
+
+			return test.defaultFetchBodiesMock()(ctx, headers, peerId)
+		}).
+		Times(6)
+	var blocks []*types.Block
+	test.store.EXPECT().
+		InsertBlocks(gomock.Any(), gomock.Any()).
+		DoAndReturn(test.defaultInsertBlocksMock(&blocks)).
+		Times(6)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_, err := test.blockDownloader.DownloadBlocksUsingCheckpoints(ctx, 1)
+	require.NoError(t, err)
+	// the slow checkpoint and the 2 checkpoints after it, which wait to be written, are the 3 workers
+	require.Equal(t, int32(3), fetchesWhileSlow.Load())
+	require.Len(t, blocks, 6144)
+	requireBlocksInOrder(t, blocks, 1)
+}
+
+func TestBlockDownloaderStageMetrics(t *testing.T) {
+	stages := []blockDownloaderStageMeter{
+		blockDownloaderHeadersMeter,
+		blockDownloaderVerifyMeter,
+		blockDownloaderBodiesMeter,
+		blockDownloaderWriteMeter,
+	}
+	blocksBefore := make([]uint64, len(stages))
+	bytesBefore := make([]uint64, len(stages))
+	for i, stage := range stages {
+		blocksBefore[i] = stage.blocks.GetValueUint64()
+		bytesBefore[i] = stage.bytes.GetValueUint64()
+	}
+
+	test := newBlockDownloaderTest(t)
+	test.waypointReader.EXPECT().
+		CheckpointsFromBlock(gomock.Any(), gomock.Any()).
+		Return(test.fakeCheckpoints(2), nil).
+		Times(1)
+	test.p2pService.EXPECT().
+		ListPeersMayHaveBlockNum(gomock.Any()).
+		Return(test.fakePeers(2)).
+		AnyTimes()
+	test.p2pService.EXPECT().
+		FetchHeaders(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(test.defaultFetchHeadersMock()).
+		Times(2)
+	test.p2pService.EXPECT().
+		FetchBodies(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(test.defaultFetchBodiesMock()).
+		Times(2)
+	var blocks []*types.Block
+	test.store.EXPECT().
+		InsertBlocks(gomock.Any(), gomock.Any()).
+		DoAndReturn(test.defaultInsertBlocksMock(&blocks)).
+		Times(2)
+
+	_, err := test.blockDownloader.DownloadBlocksUsingCheckpoints(context.Background(), 1)
+	require.NoError(t, err)
+	for i, stage := range stages {
+		require.Equal(t, uint64(2048), stage.blocks.GetValueUint64()-blocksBefore[i])
+	}
+	// only the fetch stages transfer bytes
+	require.NotZero(t, blockDownloaderHeadersMeter.bytes.GetValueUint64()-bytesBefore[0])
+	require.Zero(t, blockDownloaderVerifyMeter.bytes.GetValueUint64()-bytesBefore[1])
+	require.NotZero(t, blockDownloaderBodiesMeter.bytes.GetValueUint64()-bytesBefore[2])
+	require.Zero(t, blockDownloaderWriteMeter.bytes.GetValueUint64()-bytesBefore[3])
+}
+
+func TestBlockDownloaderStatsThroughputLogArgs(t *testing.T) {
+	stats := newBlockDownloaderStats()
+	stats.periodStart = time.Now().Add(-2 * time.Second)
+	stats.headers.observe(time.Now(), 1024, 4096*1024)
+	stats.write.observe(time.Now(), 512, 0)
+
+	args := stats.throughputLogArgs()
+	require.Len(t, args, 8)
+	require.Equal(t, "headers", args[0])
+	require.Regexp(t, `^51[0-2]\.\d\d blk/s 2\.0MB/s$`, args[1])
+	require.Equal(t, "verify", args[2])
+	require.Equal(t, "0.00 blk/s", args[3])
+	require.Equal(t, "bodies", args[4])
+	require.Equal(t, "0.00 blk/s", args[5])
+	require.Equal(t, "write", args[6])
+	require.Regexp(t, `^25[5-6]\.\d\d blk/s$`, args[7])
+
+	// a new period starts with every call
+	args = stats.throughputLogArgs()
+	require.Equal(t, "0.00 blk/s", args[1])
+	require.Equal(t, "0.00 blk/s", args[7])
+}
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/erigontech/erigon/cl/clparams"
 	"github.com/erigontech/erigon/cl/transition/machine"
 
 	"gfx.cafe/util/go/generic"
@@ -30,6 +31,11 @@ func RunCases(t *testing.T, app Appendix, machineImpl machine.Interface, root fs
 			m.Range0(func(s string, m *generic.Map4[K1, K2, K3, K4, V]) bool {
 				t.Run(s, func(t *testing.T) {
 					t.Parallel()
+					// forks in development (fulu, eip*) are shipped with release, but not implemented yet
+					if _, err := clparams.StringToClVersion(s); err != nil {
+						t.Skipf("fork not implemented: %s", s)
+						return
+					}
 					m.Range0(func(s string, m *generic.Map3[K1, K2, K3, V]) bool {
 						t.Run(s, func(t *testing.T) {
 							t.Parallel()
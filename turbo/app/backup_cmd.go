@@ -40,7 +40,7 @@ var backupCommand = cli.Command{
 	Description: `Alpha verison of command. Backup all databases without stopping of Erigon.
 While this command has Alpha prefix - we recommend to stop Erigon for backup. 
 Limitations: 
-- no support of datadir/snapshots folder. Recommendation: backup snapshots dir manually AFTER databases backup. Possible to implement in future.
+- no support of datadir/snapshots folder. Recommendation: backup snapshots dir manually AFTER databases backup. Or use 'integration backup' - it does point-in-time backup of chaindata with snapshots.
 - no support of Consensus DB (copy it manually if you need). Possible to implement in future.
 - way to pipe output to compressor (lz4/zstd). Can compress target floder later or use zfs-with-enabled-compression.
 - jwt tocken: copy it manually - if need. 
@@ -111,6 +111,7 @@ var DefaultFlags = []cli.Flag{
 	&utils.SnapKeepBlocksFlag,
 	&utils.SnapStopFlag,
 	&utils.SnapStateStopFlag,
+	&utils.SnapExistenceFlag,
 	&utils.DbPageSizeFlag,
 	&utils.DbSizeLimitFlag,
 	&utils.DbWriteMapFlag,
@@ -167,6 +168,8 @@ var DefaultFlags = []cli.Flag{
 	&utils.DownloaderVerifyFlag,
 	&HealthCheckFlag,
 	&utils.HeimdallURLFlag,
+	&utils.HeimdallVersionFlag,
+	&utils.HeimdallRecordFlag,
 	&utils.WebSeedsFlag,
 	&utils.WithoutHeimdallFlag,
 	&utils.BorBlockPeriodFlag,
@@ -218,6 +221,15 @@ var DefaultFlags = []cli.Flag{
 	&utils.CaplinArchiveFlag,
 	&utils.CaplinMevRelayUrl,
 	&utils.CaplinValidatorMonitorFlag,
+	&utils.CaplinValidatorClientFlag,
+	&utils.CaplinValidatorKeystoresFlag,
+	&utils.CaplinValidatorPasswordFileFlag,
+	&utils.CaplinValidatorGraffitiFlag,
+	&utils.CaplinSlashingProtectionImportFlag,
+	&utils.CaplinSlashingProtectionExportFlag,
+	&utils.CaplinForkChoiceRecordFlag,
+	&utils.CaplinEraDirFlag,
+	&utils.CaplinHistoricalStatesCacheFlag,
 	&utils.CaplinCustomConfigFlag,
 	&utils.CaplinCustomGenesisFlag,
 
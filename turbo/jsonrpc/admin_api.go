@@ -22,8 +22,11 @@ import (
 	"fmt"
 
 	remote "github.com/erigontech/erigon-lib/gointerfaces/remoteproto"
+	"github.com/erigontech/erigon-lib/log/v3"
 	"github.com/erigontech/erigon/p2p"
 
+	"github.com/erigontech/erigon/common/debug"
+	"github.com/erigontech/erigon/rpc"
 	"github.com/erigontech/erigon/turbo/rpchelper"
 )
 
@@ -38,6 +41,24 @@ type AdminAPI interface {
 
 	// AddPeer requests connecting to a remote node.
 	AddPeer(ctx context.Context, url string) (bool, error)
+
+	// RemovePeer disconnects from a remote node and removes it from the static nodes.
+	RemovePeer(ctx context.Context, url string) (bool, error)
+
+	// AddTrustedPeer allows a remote node to connect even when the peer slots are full.
+	AddTrustedPeer(ctx context.Context, url string) (bool, error)
+
+	// RemoveTrustedPeer removes a remote node from the trusted nodes.
+	RemoveTrustedPeer(ctx context.Context, url string) (bool, error)
+
+	// SetMaxPeers changes the maximum number of peers of every sentry.
+	SetMaxPeers(ctx context.Context, maxPeers uint32) (bool, error)
+
+	// PeerEvents notifies about peers being added, dropped, or sending invalid messages.
+	PeerEvents(ctx context.Context) (*rpc.Subscription, error)
+
+	// PeerScores returns the persisted reputation of known nodes, worst first.
+	PeerScores(ctx context.Context) ([]*p2p.PeerScore, error)
 }
 
 // AdminAPIImpl data structure to store things needed for admin_* commands.
@@ -79,3 +100,86 @@ func (api *AdminAPIImpl) AddPeer(ctx context.Context, url string) (bool, error)
 	}
 	return result.Success, nil
 }
+
+func (api *AdminAPIImpl) RemovePeer(ctx context.Context, url string) (bool, error) {
+	result, err := api.ethBackend.RemovePeer(ctx, &remote.RemovePeerRequest{Url: url})
+	if err != nil {
+		return false, err
+	}
+	if result == nil {
+		return false, errors.New("nil removePeer response")
+	}
+	return result.Success, nil
+}
+
+func (api *AdminAPIImpl) AddTrustedPeer(ctx context.Context, url string) (bool, error) {
+	result, err := api.ethBackend.AddTrustedPeer(ctx, &remote.AddPeerRequest{Url: url})
+	if err != nil {
+		return false, err
+	}
+	if result == nil {
+		return false, errors.New("nil addTrustedPeer response")
+	}
+	return result.Success, nil
+}
+
+func (api *AdminAPIImpl) RemoveTrustedPeer(ctx context.Context, url string) (bool, error) {
+	result, err := api.ethBackend.RemoveTrustedPeer(ctx, &remote.RemovePeerRequest{Url: url})
+	if err != nil {
+		return false, err
+	}
+	if result == nil {
+		return false, errors.New("nil removeTrustedPeer response")
+	}
+	return result.Success, nil
+}
+
+func (api *AdminAPIImpl) SetMaxPeers(ctx context.Context, maxPeers uint32) (bool, error) {
+	result, err := api.ethBackend.SetMaxPeers(ctx, &remote.SetMaxPeersRequest{MaxPeers: maxPeers})
+	if err != nil {
+		return false, err
+	}
+	if result == nil {
+		return false, errors.New("nil setMaxPeers response")
+	}
+	return result.Success, nil
+}
+
+func (api *AdminAPIImpl) PeerEvents(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+	// The request context ends when this call returns, the stream lives as long as the subscription.
+	streamCtx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		defer debug.LogPanic()
+		select {
+		case <-rpcSub.Err():
+		case <-streamCtx.Done():
+		}
+		cancel()
+	}()
+
+	go func() {
+		defer debug.LogPanic()
+		defer cancel()
+		err := api.ethBackend.PeerEvents(streamCtx, func(event *p2p.PeerEvent) {
+			if err := notifier.Notify(rpcSub.ID, event); err != nil {
+				log.Warn("[rpc] error while notifying subscription", "err", err)
+			}
+		})
+		if err != nil && streamCtx.Err() == nil {
+			log.Warn("[rpc] peer events stream failed", "err", err)
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+func (api *AdminAPIImpl) PeerScores(ctx context.Context) ([]*p2p.PeerScore, error) {
+	return api.ethBackend.PeerScores(ctx)
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
 	"github.com/erigontech/erigon-lib/kv"
 	"github.com/erigontech/erigon/consensus"
 	"github.com/erigontech/erigon/polygon/bor"
@@ -41,6 +42,15 @@ type BorAPI interface {
 	GetSnapshotProposer(blockNrOrHash *rpc.BlockNumberOrHash) (common.Address, error)
 	GetSnapshotProposerSequence(blockNrOrHash *rpc.BlockNumberOrHash) (BlockSigners, error)
 	GetRootHash(start uint64, end uint64) (string, error)
+
+	// Bor state sync related (see ./bor_state_sync.go)
+	GetStateSyncEvents(ctx context.Context, fromBlock rpc.BlockNumber, toBlock rpc.BlockNumber) ([]*BlockStateSyncEvents, error)
+	GetStateSyncReceipt(ctx context.Context, txHash common.Hash) (map[string]interface{}, error)
+
+	// Bor finality related (see ./bor_finality.go)
+	GetWhitelistState(ctx context.Context) (*BorWhitelistState, error)
+	GetFinalizedBlock(ctx context.Context) (*BorFinalizedBlock, error)
+	GetMilestoneHistory(ctx context.Context, limit *hexutil.Uint64) ([]*BorFinalityEvent, error)
 }
 
 type spanProducersReader interface {
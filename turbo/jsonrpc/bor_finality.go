@@ -0,0 +1,212 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	borfinality "github.com/erigontech/erigon/polygon/bor/finality"
+	"github.com/erigontech/erigon/polygon/bor/finality/rawdb"
+	"github.com/erigontech/erigon/polygon/bor/finality/whitelist"
+)
+
+const finalitySourceNone = "none"
+
+// BorFinalizedBlock is the block finalized by the whitelisted milestone or checkpoint of its source.
+type BorFinalizedBlock struct {
+	Number hexutil.Uint64 `json:"number"`
+	Hash   common.Hash    `json:"hash"`
+	Source string         `json:"source"`
+}
+
+type BorWhitelistEntry struct {
+	Number hexutil.Uint64 `json:"number"`
+	Hash   common.Hash    `json:"hash"`
+}
+
+// BorLockedSprint is the sprint the node voted for, it refuses reorgs which do not include its end block
+// until the sprint is either whitelisted by a milestone or not acknowledged by heimdall.
+type BorLockedSprint struct {
+	Number       hexutil.Uint64 `json:"number"`
+	Hash         common.Hash    `json:"hash"`
+	MilestoneIDs []string       `json:"milestoneIds"`
+}
+
+// BorMilestoneRewind is a rewind of the chain which was decided because the local chain does not match
+// a milestone or checkpoint, and which the node has yet to do.
+type BorMilestoneRewind struct {
+	Head     hexutil.Uint64 `json:"head"`
+	HeadHash common.Hash    `json:"headHash"`
+	RewindTo hexutil.Uint64 `json:"rewindTo"`
+	Source   string         `json:"source"`
+}
+
+type BorWhitelistState struct {
+	FinalitySource   string               `json:"finalitySource"` // milestone, checkpoint or none
+	FinalizedBlock   *BorFinalizedBlock   `json:"finalizedBlock"`
+	Checkpoint       *BorWhitelistEntry   `json:"checkpoint"`
+	Milestone        *BorWhitelistEntry   `json:"milestone"`
+	LockedSprint     *BorLockedSprint     `json:"lockedSprint"`
+	FutureMilestones []*BorWhitelistEntry `json:"futureMilestones"`
+	PendingRewind    *BorMilestoneRewind  `json:"pendingRewind"`
+}
+
+// BorFinalityEvent is a finality decision of the whitelisting service, see the whitelist.FinalityEvent kinds.
+type BorFinalityEvent struct {
+	Time        hexutil.Uint64  `json:"time"`
+	Kind        string          `json:"kind"`
+	Source      string          `json:"source,omitempty"`
+	Block       hexutil.Uint64  `json:"block"`
+	Hash        *common.Hash    `json:"hash,omitempty"`
+	MilestoneID string          `json:"milestoneId,omitempty"`
+	Head        *hexutil.Uint64 `json:"head,omitempty"`
+}
+
+func whitelistingService() (*whitelist.Service, error) {
+	service := whitelist.GetWhitelistingService()
+	if service == nil {
+		return nil, errors.New("only available in Bor engine with milestones enabled")
+	}
+
+	return service, nil
+}
+
+func newBorWhitelistEntry(doExist bool, number uint64, hash common.Hash) *BorWhitelistEntry {
+	if !doExist {
+		return nil
+	}
+
+	return &BorWhitelistEntry{Number: hexutil.Uint64(number), Hash: hash}
+}
+
+// GetFinalizedBlock returns the block finalized by the latest whitelisted milestone or checkpoint, or nil
+// if there is none yet.
+func (api *BorImpl) GetFinalizedBlock(ctx context.Context) (*BorFinalizedBlock, error) {
+	if _, err := whitelistingService(); err != nil {
+		return nil, err
+	}
+
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	number, hash, source := borfinality.GetFinalizedBlock(tx)
+	if number == 0 {
+		return nil, nil
+	}
+
+	return &BorFinalizedBlock{Number: hexutil.Uint64(number), Hash: hash, Source: source}, nil
+}
+
+// GetWhitelistState returns the state the node uses to accept or refuse reorgs: the whitelisted checkpoint
+// and milestone, the locked sprint, the future milestones and the pending rewind.
+func (api *BorImpl) GetWhitelistState(ctx context.Context) (*BorWhitelistState, error) {
+	service, err := whitelistingService()
+	if err != nil {
+		return nil, err
+	}
+
+	finalizedBlock, err := api.GetFinalizedBlock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &BorWhitelistState{
+		FinalitySource:   finalitySourceNone,
+		FinalizedBlock:   finalizedBlock,
+		Checkpoint:       newBorWhitelistEntry(service.GetWhitelistedCheckpoint()),
+		Milestone:        newBorWhitelistEntry(service.GetWhitelistedMilestone()),
+		FutureMilestones: []*BorWhitelistEntry{},
+	}
+
+	if finalizedBlock != nil {
+		state.FinalitySource = finalizedBlock.Source
+	}
+
+	if locked, number, hash, ids := service.GetLockedMilestone(); locked {
+		state.LockedSprint = &BorLockedSprint{Number: hexutil.Uint64(number), Hash: hash, MilestoneIDs: ids}
+	}
+
+	for _, futureMilestone := range service.GetFutureMilestones() {
+		state.FutureMilestones = append(state.FutureMilestones, newBorWhitelistEntry(true, futureMilestone.Block, futureMilestone.Hash))
+	}
+
+	if rewind := service.GetPendingRewind(); rewind != nil {
+		state.PendingRewind = &BorMilestoneRewind{
+			Head:     hexutil.Uint64(rewind.Head),
+			HeadHash: rewind.HeadHash,
+			RewindTo: hexutil.Uint64(rewind.RewindTo),
+			Source:   rewind.Source,
+		}
+	}
+
+	return state, nil
+}
+
+// GetMilestoneHistory returns the latest finality decisions of the node, oldest first: the whitelisted
+// checkpoints and milestones, the future milestones, the sprint locks and unlocks, the refused reorgs
+// and the rewinds.
+// The optional limit restricts the result to the latest events.
+func (api *BorImpl) GetMilestoneHistory(ctx context.Context, limit *hexutil.Uint64) ([]*BorFinalityEvent, error) {
+	service, err := whitelistingService()
+	if err != nil {
+		return nil, err
+	}
+
+	history, err := service.GetFinalityHistory()
+	if err != nil {
+		return nil, err
+	}
+
+	if limit != nil && uint64(*limit) < uint64(len(history)) {
+		history = history[len(history)-int(*limit):]
+	}
+
+	events := make([]*BorFinalityEvent, 0, len(history))
+	for _, event := range history {
+		events = append(events, newBorFinalityEvent(event))
+	}
+
+	return events, nil
+}
+
+func newBorFinalityEvent(event rawdb.FinalityEvent) *BorFinalityEvent {
+	rpcEvent := &BorFinalityEvent{
+		Time:        hexutil.Uint64(event.Time),
+		Kind:        event.Kind,
+		Source:      event.Source,
+		Block:       hexutil.Uint64(event.Block),
+		MilestoneID: event.MilestoneID,
+	}
+
+	if event.Hash != (common.Hash{}) {
+		hash := event.Hash
+		rpcEvent.Hash = &hash
+	}
+
+	if event.Head != 0 {
+		head := hexutil.Uint64(event.Head)
+		rpcEvent.Head = &head
+	}
+
+	return rpcEvent
+}
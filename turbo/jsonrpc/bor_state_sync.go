@@ -0,0 +1,249 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/common/hexutility"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon/core/rawdb"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/eth/ethutils"
+	bortypes "github.com/erigontech/erigon/polygon/bor/types"
+	"github.com/erigontech/erigon/polygon/heimdall"
+	"github.com/erigontech/erigon/rpc"
+)
+
+// maxStateSyncEventsBlockRange caps the number of blocks which bor_getStateSyncEvents scans in one request.
+const maxStateSyncEventsBlockRange = 1000
+
+// StateSyncEvent is a heimdall event record as committed to bor by the state-sync transaction of a block.
+type StateSyncEvent struct {
+	ID       hexutil.Uint64   `json:"id"`
+	Contract common.Address   `json:"contract"`
+	Data     hexutility.Bytes `json:"data"`
+	TxHash   common.Hash      `json:"txHash"` // hash of the transaction on the root chain which emitted the event
+	LogIndex hexutil.Uint64   `json:"logIndex"`
+	ChainID  string           `json:"chainId"`
+	Time     hexutil.Uint64   `json:"time"`
+}
+
+// BlockStateSyncEvents are the state sync events of a block.
+type BlockStateSyncEvents struct {
+	BlockNumber     hexutil.Uint64    `json:"blockNumber"`
+	BlockHash       common.Hash       `json:"blockHash"`
+	TransactionHash common.Hash       `json:"transactionHash"`
+	Events          []*StateSyncEvent `json:"events"`
+}
+
+// blockStateSync holds the state sync events of a block as they are stored by the bridge.
+type blockStateSync struct {
+	events []*heimdall.EventRecordWithTime
+}
+
+// GetStateSyncEvents returns the state sync events committed in the blocks from fromBlock to toBlock. Blocks without
+// events are left out.
+func (api *BorImpl) GetStateSyncEvents(ctx context.Context, fromBlock rpc.BlockNumber, toBlock rpc.BlockNumber) ([]*BlockStateSyncEvents, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	from, err := api.headerByRPCNumber(ctx, fromBlock, tx)
+	if err != nil {
+		return nil, err
+	}
+	to, err := api.headerByRPCNumber(ctx, toBlock, tx)
+	if err != nil {
+		return nil, err
+	}
+	if from == nil || to == nil {
+		return nil, errUnknownBlock
+	}
+
+	fromNum, toNum := from.Number.Uint64(), to.Number.Uint64()
+	if fromNum > toNum {
+		return nil, fmt.Errorf("invalid block range: from %d is after to %d", fromNum, toNum)
+	}
+	if toNum-fromNum >= maxStateSyncEventsBlockRange {
+		return nil, fmt.Errorf("block range too large: %d blocks, max %d", toNum-fromNum+1, maxStateSyncEventsBlockRange)
+	}
+
+	result := make([]*BlockStateSyncEvents, 0)
+	for blockNum := fromNum; blockNum <= toNum; blockNum++ {
+		header, err := api._blockReader.HeaderByNumber(ctx, tx, blockNum)
+		if err != nil {
+			return nil, err
+		}
+		if header == nil {
+			return nil, fmt.Errorf("header not found: %d", blockNum)
+		}
+
+		stateSync, err := api.blockStateSync(ctx, tx, header)
+		if err != nil {
+			return nil, err
+		}
+		if len(stateSync.events) == 0 {
+			continue
+		}
+
+		result = append(result, &BlockStateSyncEvents{
+			BlockNumber:     hexutil.Uint64(blockNum),
+			BlockHash:       header.Hash(),
+			TransactionHash: bortypes.ComputeBorTxHash(blockNum, header.Hash()),
+			Events:          stateSync.rpcEvents(),
+		})
+	}
+
+	return result, nil
+}
+
+// GetStateSyncReceipt returns the receipt of a bor state-sync transaction. It is assembled from the bridge data and
+// the stored bor receipt, without executing the block: when there is no stored receipt the logs are left empty.
+func (api *BorImpl) GetStateSyncReceipt(ctx context.Context, txHash common.Hash) (map[string]interface{}, error) {
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	block, err := api.stateSyncBlock(ctx, tx, txHash)
+	if err != nil || block == nil {
+		return nil, err
+	}
+
+	cc, err := api.chainConfig(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	stateSync, err := api.blockStateSync(ctx, tx, block.HeaderNoCopy())
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, _, err := rawdb.ReadRawBorReceipt(tx, block.NumberU64())
+	if err != nil {
+		return nil, err
+	}
+	if receipt == nil {
+		receipt = &types.Receipt{
+			Status:            types.ReceiptStatusSuccessful,
+			CumulativeGasUsed: block.GasUsed(),
+		}
+	}
+	receipt.TxHash = txHash
+	receipt.TransactionIndex = uint(len(block.Transactions()))
+	receipt.BlockHash = block.Hash()
+	receipt.BlockNumber = block.Number()
+	for i, l := range receipt.Logs {
+		// log indexes are relative to the state-sync transaction, the preceding receipts would have to be
+		// generated to make them relative to the block
+		l.BlockNumber = block.NumberU64()
+		l.BlockHash = block.Hash()
+		l.TxHash = txHash
+		l.TxIndex = receipt.TransactionIndex
+		l.Index = uint(i)
+	}
+
+	fields := ethutils.MarshalReceipt(receipt, bortypes.NewBorTransaction(), cc, block.HeaderNoCopy(), txHash, false)
+	fields["stateSyncEvents"] = stateSync.rpcEvents()
+
+	return fields, nil
+}
+
+// stateSyncBlock returns the block of a state-sync transaction, or nil when there is no such transaction.
+func (api *BorImpl) stateSyncBlock(ctx context.Context, tx kv.Tx, txHash common.Hash) (*types.Block, error) {
+	var blockNum uint64
+	var ok bool
+	var err error
+	if api.bridgeReader != nil {
+		blockNum, ok, err = api.bridgeReader.EventTxnLookup(ctx, txHash)
+	} else {
+		blockNum, ok, err = api._blockReader.EventLookup(ctx, tx, txHash)
+	}
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	block, err := api.blockByNumberWithSenders(ctx, tx, blockNum)
+	if err != nil || block == nil {
+		return nil, err
+	}
+
+	// the lookup is by hash, make sure that it did not hit a state-sync transaction of a non-canonical block
+	if bortypes.ComputeBorTxHash(blockNum, block.Hash()) != txHash {
+		return nil, nil
+	}
+
+	return block, nil
+}
+
+func (api *BorImpl) blockStateSync(ctx context.Context, tx kv.Tx, header *types.Header) (*blockStateSync, error) {
+	blockNum := header.Number.Uint64()
+
+	var data [][]byte
+	if api.bridgeReader != nil {
+		messages, err := api.bridgeReader.Events(ctx, blockNum)
+		if err != nil {
+			return nil, err
+		}
+		for _, msg := range messages {
+			data = append(data, msg.Data())
+		}
+	} else {
+		events, err := api._blockReader.EventsByBlock(ctx, tx, header.Hash(), blockNum)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range events {
+			data = append(data, event)
+		}
+	}
+
+	stateSync := &blockStateSync{}
+	for _, eventData := range data {
+		var event heimdall.EventRecordWithTime
+		if err := event.UnmarshallBytes(eventData); err != nil {
+			return nil, fmt.Errorf("invalid state sync event in block %d: %w", blockNum, err)
+		}
+		stateSync.events = append(stateSync.events, &event)
+	}
+
+	return stateSync, nil
+}
+
+func (s *blockStateSync) rpcEvents() []*StateSyncEvent {
+	events := make([]*StateSyncEvent, len(s.events))
+	for i, event := range s.events {
+		events[i] = &StateSyncEvent{
+			ID:       hexutil.Uint64(event.ID),
+			Contract: event.Contract,
+			Data:     hexutility.Bytes(event.Data),
+			TxHash:   event.TxHash,
+			LogIndex: hexutil.Uint64(event.LogIndex),
+			ChainID:  event.ChainID,
+			Time:     hexutil.Uint64(event.Time.Unix()),
+		}
+	}
+	return events
+}
@@ -0,0 +1,119 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/kv/kvcache"
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/common/u256"
+	"github.com/erigontech/erigon/core/types"
+	bortypes "github.com/erigontech/erigon/polygon/bor/types"
+	"github.com/erigontech/erigon/polygon/heimdall"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/rpc/rpccfg"
+)
+
+type testBridgeReader struct {
+	events map[uint64][][]byte
+	txns   map[common.Hash]uint64
+}
+
+func (r *testBridgeReader) Events(_ context.Context, blockNum uint64) ([]*types.Message, error) {
+	var messages []*types.Message
+	for _, data := range r.events[blockNum] {
+		msg := types.NewMessage(common.Address{}, nil, 0, u256.Num0, 0, u256.Num0, nil, nil, data, nil, false, true, nil)
+		messages = append(messages, &msg)
+	}
+	return messages, nil
+}
+
+func (r *testBridgeReader) EventTxnLookup(_ context.Context, borTxHash common.Hash) (uint64, bool, error) {
+	blockNum, ok := r.txns[borTxHash]
+	return blockNum, ok, nil
+}
+
+func TestBorStateSync(t *testing.T) {
+	ctx := context.Background()
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+
+	tx, err := m.DB.BeginRo(ctx)
+	require.NoError(t, err)
+	header, err := m.BlockReader.HeaderByNumber(ctx, tx, 2)
+	tx.Rollback()
+	require.NoError(t, err)
+
+	var events []*heimdall.EventRecordWithTime
+	var data [][]byte
+	for id := uint64(10); id < 13; id++ {
+		event := &heimdall.EventRecordWithTime{
+			EventRecord: heimdall.EventRecord{
+				ID:       id,
+				Contract: common.HexToAddress("0x1001"),
+				Data:     []byte{byte(id)},
+				ChainID:  "80002",
+			},
+			Time: time.Unix(int64(1000+id), 0),
+		}
+		eventData, err := event.MarshallBytes()
+		require.NoError(t, err)
+		events = append(events, event)
+		data = append(data, eventData)
+	}
+
+	stateSyncTxHash := bortypes.ComputeBorTxHash(2, header.Hash())
+	bridgeReader := &testBridgeReader{
+		events: map[uint64][][]byte{2: data},
+		txns:   map[common.Hash]uint64{stateSyncTxHash: 2},
+	}
+	stateCache := kvcache.New(kvcache.DefaultCoherentConfig)
+	base := NewBaseApi(nil, stateCache, m.BlockReader, false, rpccfg.DefaultEvmCallTimeout, m.Engine, m.Dirs, bridgeReader)
+	api := NewBorAPI(base, m.DB, nil)
+
+	blockEvents, err := api.GetStateSyncEvents(ctx, 0, rpc.LatestBlockNumber)
+	require.NoError(t, err)
+	require.Len(t, blockEvents, 1)
+	require.Equal(t, hexutil.Uint64(2), blockEvents[0].BlockNumber)
+	require.Equal(t, stateSyncTxHash, blockEvents[0].TransactionHash)
+	require.Len(t, blockEvents[0].Events, len(events))
+	for i, event := range events {
+		require.Equal(t, hexutil.Uint64(event.ID), blockEvents[0].Events[i].ID)
+		require.Equal(t, []byte(event.Data), []byte(blockEvents[0].Events[i].Data))
+		require.Equal(t, hexutil.Uint64(event.Time.Unix()), blockEvents[0].Events[i].Time)
+	}
+
+	_, err = api.GetStateSyncEvents(ctx, 3, 0)
+	require.Error(t, err)
+
+	receipt, err := api.GetStateSyncReceipt(ctx, stateSyncTxHash)
+	require.NoError(t, err)
+	require.Equal(t, stateSyncTxHash, receipt["transactionHash"])
+	require.Equal(t, hexutil.Uint64(2), receipt["blockNumber"])
+	require.Equal(t, hexutil.Uint64(types.ReceiptStatusSuccessful), receipt["status"])
+	require.Equal(t, blockEvents[0].Events, receipt["stateSyncEvents"])
+
+	receipt, err = api.GetStateSyncReceipt(ctx, common.HexToHash("0x01"))
+	require.NoError(t, err)
+	require.Nil(t, receipt)
+}
@@ -53,6 +53,10 @@ type ErigonAPI interface {
 	// Gets cannonical block receipt through hash. If the block is not cannonical returns error
 	GetBlockReceiptsByBlockHash(ctx context.Context, cannonicalBlockHash common.Hash) ([]map[string]interface{}, error)
 
+	// History related (see ./erigon_history.go)
+	GetAccountHistory(ctx context.Context, address common.Address, fromBlock, toBlock rpc.BlockNumber, pageToken *hexutil.Uint64, pageSize *hexutil.Uint64) (*AccountHistory, error)
+	GetStorageHistory(ctx context.Context, address common.Address, location common.Hash, fromBlock, toBlock rpc.BlockNumber, pageToken *hexutil.Uint64, pageSize *hexutil.Uint64) (*StorageHistory, error)
+
 	// NodeInfo returns a collection of metadata known about the host.
 	NodeInfo(ctx context.Context) ([]p2p.NodeInfo, error)
 }
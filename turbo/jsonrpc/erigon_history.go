@@ -0,0 +1,225 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/order"
+	"github.com/erigontech/erigon-lib/kv/rawdbv3"
+
+	"github.com/erigontech/erigon/core/types/accounts"
+	"github.com/erigontech/erigon/rpc"
+	"github.com/erigontech/erigon/turbo/rpchelper"
+	"github.com/erigontech/erigon/turbo/snapshotsync/freezeblocks"
+)
+
+const (
+	defaultHistoryPageSize = 100
+	maxHistoryPageSize     = 1000
+)
+
+// AccountState - account fields as they are stored in history. nil means: account doesn't exist
+type AccountState struct {
+	Nonce       hexutil.Uint64 `json:"nonce"`
+	Balance     *hexutil.Big   `json:"balance"`
+	CodeHash    common.Hash    `json:"codeHash"`
+	Incarnation hexutil.Uint64 `json:"incarnation"`
+}
+
+// AccountChange - one change of account, made by txn `TxIndex` of block `BlockNumber`.
+// TxIndex = -1 means: changed by block-begin system txn, TxIndex = len(block.Transactions()) means: changed by block-end system txn (rewards, withdrawals)
+type AccountChange struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	TxIndex     int            `json:"txIndex"`
+	TxNum       hexutil.Uint64 `json:"txNum"`
+	Old         *AccountState  `json:"old"`
+	New         *AccountState  `json:"new"`
+}
+
+// AccountHistory - one page of account changes. NextPageToken is nil on last page
+type AccountHistory struct {
+	Changes       []*AccountChange `json:"changes"`
+	NextPageToken *hexutil.Uint64  `json:"nextPageToken,omitempty"`
+}
+
+// StorageChange - one change of storage slot. See AccountChange
+type StorageChange struct {
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	TxIndex     int            `json:"txIndex"`
+	TxNum       hexutil.Uint64 `json:"txNum"`
+	Old         common.Hash    `json:"old"`
+	New         common.Hash    `json:"new"`
+}
+
+// StorageHistory - one page of storage slot changes. NextPageToken is nil on last page
+type StorageHistory struct {
+	Changes       []*StorageChange `json:"changes"`
+	NextPageToken *hexutil.Uint64  `json:"nextPageToken,omitempty"`
+}
+
+// GetAccountHistory implements erigon_getAccountHistory. Returns all changes of account in blocks [fromBlock, toBlock].
+// To get next page - pass `NextPageToken` of previous page as `pageToken`
+func (api *ErigonImpl) GetAccountHistory(ctx context.Context, address common.Address, fromBlock, toBlock rpc.BlockNumber, pageToken *hexutil.Uint64, pageSize *hexutil.Uint64) (*AccountHistory, error) {
+	res := &AccountHistory{Changes: []*AccountChange{}}
+	next, err := api.historyKeyRange(ctx, kv.AccountsDomain, address[:], fromBlock, toBlock, pageToken, pageSize, func(blockNum uint64, txIndex int, txNum uint64, prev, v []byte) error {
+		oldAcc, err := decodeAccountState(prev)
+		if err != nil {
+			return err
+		}
+		newAcc, err := decodeAccountState(v)
+		if err != nil {
+			return err
+		}
+		res.Changes = append(res.Changes, &AccountChange{BlockNumber: hexutil.Uint64(blockNum), TxIndex: txIndex, TxNum: hexutil.Uint64(txNum), Old: oldAcc, New: newAcc})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	res.NextPageToken = next
+	return res, nil
+}
+
+// GetStorageHistory implements erigon_getStorageHistory. Returns all changes of storage slot in blocks [fromBlock, toBlock].
+// To get next page - pass `NextPageToken` of previous page as `pageToken`
+func (api *ErigonImpl) GetStorageHistory(ctx context.Context, address common.Address, location common.Hash, fromBlock, toBlock rpc.BlockNumber, pageToken *hexutil.Uint64, pageSize *hexutil.Uint64) (*StorageHistory, error) {
+	res := &StorageHistory{Changes: []*StorageChange{}}
+	key := append(common.Copy(address[:]), location[:]...)
+	next, err := api.historyKeyRange(ctx, kv.StorageDomain, key, fromBlock, toBlock, pageToken, pageSize, func(blockNum uint64, txIndex int, txNum uint64, prev, v []byte) error {
+		res.Changes = append(res.Changes, &StorageChange{BlockNumber: hexutil.Uint64(blockNum), TxIndex: txIndex, TxNum: hexutil.Uint64(txNum), Old: common.BytesToHash(prev), New: common.BytesToHash(v)})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	res.NextPageToken = next
+	return res, nil
+}
+
+func decodeAccountState(v []byte) (*AccountState, error) {
+	if len(v) == 0 {
+		return nil, nil
+	}
+	var acc accounts.Account
+	if err := accounts.DeserialiseV3(&acc, v); err != nil {
+		return nil, err
+	}
+	return &AccountState{
+		Nonce:       hexutil.Uint64(acc.Nonce),
+		Balance:     (*hexutil.Big)(acc.Balance.ToBig()),
+		CodeHash:    acc.CodeHash,
+		Incarnation: hexutil.Uint64(acc.Incarnation),
+	}, nil
+}
+
+// historyKeyRange - calls `walker` for each change of `key` in blocks [fromBlock, toBlock], starting from `pageToken` (which is txNum).
+// Returns txNum of first not-visited change - if page is full.
+func (api *ErigonImpl) historyKeyRange(ctx context.Context, domain kv.Domain, key []byte, fromBlock, toBlock rpc.BlockNumber, pageToken *hexutil.Uint64, pageSize *hexutil.Uint64,
+	walker func(blockNum uint64, txIndex int, txNum uint64, prev, v []byte) error) (*hexutil.Uint64, error) {
+	limit := defaultHistoryPageSize
+	if pageSize != nil {
+		limit = int(*pageSize)
+	}
+	if limit <= 0 || limit > maxHistoryPageSize {
+		return nil, fmt.Errorf("pageSize must be in range [1, %d]", maxHistoryPageSize)
+	}
+
+	tx, err := api.db.BeginRo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	ttx, ok := tx.(kv.TemporalTx)
+	if !ok {
+		return nil, fmt.Errorf("history is not available: db is not temporal")
+	}
+
+	fromBlockNum, _, _, err := rpchelper.GetBlockNumber(ctx, rpc.BlockNumberOrHashWithNumber(fromBlock), tx, api._blockReader, api.filters)
+	if err != nil {
+		return nil, err
+	}
+	toBlockNum, _, _, err := rpchelper.GetBlockNumber(ctx, rpc.BlockNumberOrHashWithNumber(toBlock), tx, api._blockReader, api.filters)
+	if err != nil {
+		return nil, err
+	}
+	if fromBlockNum > toBlockNum {
+		return nil, fmt.Errorf("fromBlock %d is greater than toBlock %d", fromBlockNum, toBlockNum)
+	}
+
+	txNumsReader := rawdbv3.TxNums.WithCustomReadTxNumFunc(freezeblocks.ReadTxNumFuncFromBlockReader(ctx, api._blockReader))
+	fromTxNum, err := txNumsReader.Min(tx, fromBlockNum)
+	if err != nil {
+		return nil, err
+	}
+	toTxNum, err := txNumsReader.Max(tx, toBlockNum)
+	if err != nil {
+		return nil, err
+	}
+	if pageToken != nil {
+		if uint64(*pageToken) > toTxNum {
+			return nil, nil
+		}
+		fromTxNum = max(fromTxNum, uint64(*pageToken))
+	}
+
+	// +1 to know if there is a next page
+	it, err := ttx.HistoryKeyRange(domain, key, int(fromTxNum), int(toTxNum+1), order.Asc, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var blockNum, minTxNumInBlock, maxTxNumInBlock uint64
+	for i := 0; it.HasNext(); i++ {
+		txNum, prev, v, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		if i == limit {
+			next := hexutil.Uint64(txNum)
+			return &next, nil
+		}
+
+		// changes are sorted, it means blockNum will not change until `txNum <= maxTxNumInBlock`
+		if i == 0 || txNum > maxTxNumInBlock {
+			ok, bn, err := txNumsReader.FindBlockNum(tx, txNum)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				return nil, fmt.Errorf("can't find blockNumber by txNum=%d", txNum)
+			}
+			blockNum = bn
+			if minTxNumInBlock, err = txNumsReader.Min(tx, blockNum); err != nil {
+				return nil, err
+			}
+			if maxTxNumInBlock, err = txNumsReader.Max(tx, blockNum); err != nil {
+				return nil, err
+			}
+		}
+		txIndex := int(txNum) - int(minTxNumInBlock) - 1 /* system-contract */
+		if err := walker(blockNum, txIndex, txNum, prev, v); err != nil {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
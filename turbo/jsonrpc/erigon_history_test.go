@@ -0,0 +1,183 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package jsonrpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/kv"
+	"github.com/erigontech/erigon-lib/kv/order"
+
+	"github.com/erigontech/erigon/cmd/rpcdaemon/rpcdaemontest"
+	"github.com/erigontech/erigon/crypto"
+	"github.com/erigontech/erigon/rpc"
+)
+
+func TestErigonGetAccountHistory(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil)
+	ctx := context.Background()
+
+	t.Run("created and updated", func(t *testing.T) {
+		theAddr := libcommon.Address{1}
+		res, err := api.GetAccountHistory(ctx, theAddr, 0, rpc.LatestBlockNumber, nil, nil)
+		require.NoError(t, err)
+		require.Nil(t, res.NextPageToken)
+		require.Len(t, res.Changes, 2)
+
+		require.Equal(t, hexutil.Uint64(1), res.Changes[0].BlockNumber)
+		require.Equal(t, 0, res.Changes[0].TxIndex)
+		require.Nil(t, res.Changes[0].Old)
+		require.Equal(t, uint64(1_000_000_000_000_000), res.Changes[0].New.Balance.ToInt().Uint64())
+
+		require.Equal(t, hexutil.Uint64(2), res.Changes[1].BlockNumber)
+		require.Equal(t, res.Changes[0].New, res.Changes[1].Old)
+		require.Equal(t, uint64(2_000_000_000_000_000), res.Changes[1].New.Balance.ToInt().Uint64())
+
+		res, err = api.GetAccountHistory(ctx, theAddr, 2, rpc.LatestBlockNumber, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, res.Changes, 1)
+		require.Equal(t, hexutil.Uint64(2), res.Changes[0].BlockNumber)
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		key, _ := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		addr := crypto.PubkeyToAddress(key.PublicKey)
+
+		all, err := api.GetAccountHistory(ctx, addr, 0, rpc.LatestBlockNumber, nil, nil)
+		require.NoError(t, err)
+		require.Nil(t, all.NextPageToken)
+		require.Greater(t, len(all.Changes), 3)
+		for i := 1; i < len(all.Changes); i++ {
+			require.Less(t, all.Changes[i-1].TxNum, all.Changes[i].TxNum)
+			require.Equal(t, all.Changes[i-1].New, all.Changes[i].Old)
+		}
+
+		pageSize := hexutil.Uint64(3)
+		var paged []*AccountChange
+		var pageToken *hexutil.Uint64
+		for {
+			page, err := api.GetAccountHistory(ctx, addr, 0, rpc.LatestBlockNumber, pageToken, &pageSize)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(page.Changes), int(pageSize))
+			paged = append(paged, page.Changes...)
+			if page.NextPageToken == nil {
+				break
+			}
+			pageToken = page.NextPageToken
+		}
+		require.Equal(t, all.Changes, paged)
+
+		// same changes in reverse order
+		tx, err := m.DB.BeginRo(ctx)
+		require.NoError(t, err)
+		defer tx.Rollback()
+		it, err := tx.(kv.TemporalTx).HistoryKeyRange(kv.AccountsDomain, addr[:], -1, -1, order.Desc, -1)
+		require.NoError(t, err)
+		i := len(all.Changes) - 1
+		for ; it.HasNext(); i-- {
+			txNum, prev, v, err := it.Next()
+			require.NoError(t, err)
+			require.Equal(t, uint64(all.Changes[i].TxNum), txNum)
+			oldAcc, err := decodeAccountState(prev)
+			require.NoError(t, err)
+			newAcc, err := decodeAccountState(v)
+			require.NoError(t, err)
+			require.Equal(t, all.Changes[i].Old, oldAcc)
+			require.Equal(t, all.Changes[i].New, newAcc)
+		}
+		require.Equal(t, -1, i)
+	})
+
+	t.Run("invalid page size", func(t *testing.T) {
+		pageSize := hexutil.Uint64(maxHistoryPageSize + 1)
+		_, err := api.GetAccountHistory(ctx, libcommon.Address{1}, 0, rpc.LatestBlockNumber, nil, &pageSize)
+		require.Error(t, err)
+	})
+}
+
+func TestErigonGetStorageHistory(t *testing.T) {
+	m, _, _ := rpcdaemontest.CreateTestSentry(t)
+	api := NewErigonAPI(newBaseApiForTest(m), m.DB, nil)
+	ctx := context.Background()
+
+	// balance of the token holder in the token contract of the test chain, it changes with every transfer
+	contract := libcommon.HexToAddress("0x920fd5070602feaea2e251e9e7238b6c376bcae5")
+	slot := libcommon.HexToHash("0xf41f8421ae8c8d7bb78783a0bdadb801a5f895bea868c1d867ae007558809ef1")
+
+	all, err := api.GetStorageHistory(ctx, contract, slot, 0, rpc.LatestBlockNumber, nil, nil)
+	require.NoError(t, err)
+	require.Nil(t, all.NextPageToken)
+	require.Greater(t, len(all.Changes), 10)
+	require.Equal(t, libcommon.Hash{}, all.Changes[0].Old)
+	for i := 1; i < len(all.Changes); i++ {
+		require.Less(t, all.Changes[i-1].TxNum, all.Changes[i].TxNum)
+		require.Equal(t, all.Changes[i-1].New, all.Changes[i].Old)
+	}
+
+	// the value after the last change is the latest value of the slot
+	tx, err := m.DB.BeginRo(ctx)
+	require.NoError(t, err)
+	defer tx.Rollback()
+	latest, _, err := tx.(kv.TemporalTx).DomainGet(kv.StorageDomain, contract[:], slot[:])
+	require.NoError(t, err)
+	require.Equal(t, libcommon.BytesToHash(latest), all.Changes[len(all.Changes)-1].New)
+
+	t.Run("pagination", func(t *testing.T) {
+		pageSize := hexutil.Uint64(5)
+		var paged []*StorageChange
+		var pageToken *hexutil.Uint64
+		for {
+			page, err := api.GetStorageHistory(ctx, contract, slot, 0, rpc.LatestBlockNumber, pageToken, &pageSize)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(page.Changes), int(pageSize))
+			paged = append(paged, page.Changes...)
+			if page.NextPageToken == nil {
+				break
+			}
+			// the token is the txNum of the first change of the next page
+			require.Equal(t, all.Changes[len(paged)].TxNum, *page.NextPageToken)
+			pageToken = page.NextPageToken
+		}
+		require.Equal(t, all.Changes, paged)
+	})
+
+	t.Run("block range", func(t *testing.T) {
+		last := all.Changes[len(all.Changes)-1]
+		res, err := api.GetStorageHistory(ctx, contract, slot, rpc.BlockNumber(last.BlockNumber), rpc.BlockNumber(last.BlockNumber), nil, nil)
+		require.NoError(t, err)
+		require.NotEmpty(t, res.Changes)
+		require.Equal(t, last, res.Changes[len(res.Changes)-1])
+		for _, c := range res.Changes {
+			require.Equal(t, last.BlockNumber, c.BlockNumber)
+		}
+	})
+
+	t.Run("other slot of the same contract", func(t *testing.T) {
+		res, err := api.GetStorageHistory(ctx, contract, libcommon.Hash{}, 0, rpc.LatestBlockNumber, nil, nil)
+		require.NoError(t, err)
+		require.Len(t, res.Changes, 1)
+		v, _, err := tx.(kv.TemporalTx).DomainGet(kv.StorageDomain, contract[:], libcommon.Hash{}.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, libcommon.BytesToHash(v), res.Changes[0].New)
+	})
+}
@@ -44,5 +44,11 @@ type ApiBackend interface {
 	NodeInfo(ctx context.Context, limit uint32) ([]p2p.NodeInfo, error)
 	Peers(ctx context.Context) ([]*p2p.PeerInfo, error)
 	AddPeer(ctx context.Context, url *remote.AddPeerRequest) (*remote.AddPeerReply, error)
+	RemovePeer(ctx context.Context, url *remote.RemovePeerRequest) (*remote.RemovePeerReply, error)
+	AddTrustedPeer(ctx context.Context, url *remote.AddPeerRequest) (*remote.AddPeerReply, error)
+	RemoveTrustedPeer(ctx context.Context, url *remote.RemovePeerRequest) (*remote.RemovePeerReply, error)
+	SetMaxPeers(ctx context.Context, req *remote.SetMaxPeersRequest) (*remote.SetMaxPeersReply, error)
+	PeerScores(ctx context.Context) ([]*p2p.PeerScore, error)
+	PeerEvents(ctx context.Context, cb func(*p2p.PeerEvent)) error
 	PendingBlock(ctx context.Context) (*types.Block, error)
 }
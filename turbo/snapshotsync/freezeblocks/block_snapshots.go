@@ -2480,6 +2480,17 @@ func (v *View) Close() {
 	})
 }
 
+// FilePaths - paths of visible segments and their indices. Files can't be deleted while View is open.
+func (v *View) FilePaths() (list []string) {
+	v.VisibleSegments.Scan(func(segtype snaptype.Enum, value *segmentsRotx) bool {
+		for _, seg := range value.VisibleSegments {
+			list = append(list, seg.src.openFiles()...)
+		}
+		return true
+	})
+	return list
+}
+
 var noop = func() {}
 
 func (s *RoSnapshots) ViewType(t snaptype.Type) *segmentsRotx {
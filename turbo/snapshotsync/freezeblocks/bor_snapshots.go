@@ -475,6 +475,8 @@ func (v *BorView) Close() {
 	v.base.Close()
 }
 
+func (v *BorView) FilePaths() []string { return v.base.FilePaths() }
+
 func (v *BorView) Events() []*VisibleSegment      { return v.base.segments(borsnaptype.BorEvents) }
 func (v *BorView) Spans() []*VisibleSegment       { return v.base.segments(borsnaptype.BorSpans) }
 func (v *BorView) Checkpoints() []*VisibleSegment { return v.base.segments(borsnaptype.BorCheckpoints) }
@@ -82,6 +82,7 @@ import (
 	"github.com/erigontech/erigon/polygon/bor"
 	"github.com/erigontech/erigon/rlp"
 	"github.com/erigontech/erigon/turbo/builder"
+	"github.com/erigontech/erigon/turbo/engineapi/engine_block_downloader"
 	"github.com/erigontech/erigon/turbo/engineapi/engine_helpers"
 	"github.com/erigontech/erigon/turbo/execution/eth1"
 	"github.com/erigontech/erigon/turbo/execution/eth1/eth1_chain_reader.go"
@@ -117,11 +118,15 @@ type MockSentry struct {
 	SentryClient         direct.SentryClient
 	PeerId               *ptypes.H512
 	streams              map[proto_sentry.MessageId][]proto_sentry.Sentry_MessagesServer
+	streamsLock          sync.RWMutex
 	sentMessages         []*proto_sentry.OutboundMessageData
 	StreamWg             sync.WaitGroup
 	ReceiveWg            sync.WaitGroup
 	Address              libcommon.Address
 	Eth1ExecutionService *eth1.EthereumExecutionModule
+	BlockDownloader      *engine_block_downloader.EngineBlockDownloader // only set for mocks created with a Transport
+
+	transport Transport
 
 	Notifications *shards.Notifications
 
@@ -162,7 +167,10 @@ func (ms *MockSentry) Close() {
 // Stream returns stream, waiting if necessary
 func (ms *MockSentry) Send(req *proto_sentry.InboundMessage) (errs []error) {
 	ms.StreamWg.Wait()
-	for _, stream := range ms.streams[req.Id] {
+	ms.streamsLock.RLock()
+	streams := ms.streams[req.Id]
+	ms.streamsLock.RUnlock()
+	for _, stream := range streams {
 		if err := stream.Send(req); err != nil {
 			errs = append(errs, err)
 		}
@@ -170,11 +178,17 @@ func (ms *MockSentry) Send(req *proto_sentry.InboundMessage) (errs []error) {
 	return errs
 }
 
-func (ms *MockSentry) SetStatus(context.Context, *proto_sentry.StatusData) (*proto_sentry.SetStatusReply, error) {
+func (ms *MockSentry) SetStatus(_ context.Context, r *proto_sentry.StatusData) (*proto_sentry.SetStatusReply, error) {
+	if ms.transport != nil {
+		ms.transport.SetStatus(ms, r)
+	}
 	return &proto_sentry.SetStatusReply{}, nil
 }
 
-func (ms *MockSentry) PenalizePeer(context.Context, *proto_sentry.PenalizePeerRequest) (*emptypb.Empty, error) {
+func (ms *MockSentry) PenalizePeer(_ context.Context, r *proto_sentry.PenalizePeerRequest) (*emptypb.Empty, error) {
+	if ms.transport != nil {
+		ms.transport.Penalize(ms, r.PeerId, r.Penalty)
+	}
 	return nil, nil
 }
 func (ms *MockSentry) PeerMinBlock(context.Context, *proto_sentry.PeerMinBlockRequest) (*emptypb.Empty, error) {
@@ -185,18 +199,36 @@ func (ms *MockSentry) HandShake(ctx context.Context, in *emptypb.Empty) (*proto_
 	return &proto_sentry.HandShakeReply{Protocol: proto_sentry.Protocol_ETH68}, nil
 }
 func (ms *MockSentry) SendMessageByMinBlock(_ context.Context, r *proto_sentry.SendMessageByMinBlockRequest) (*proto_sentry.SentPeers, error) {
+	if ms.transport != nil {
+		var peers []TransportPeer
+		for _, p := range ms.transport.Peers(ms) {
+			if p.MaxBlock >= r.MinBlock {
+				peers = append(peers, p)
+			}
+		}
+		return ms.sendToPeers(peers, max(int(r.MaxPeers), 1), r.Data), nil
+	}
 	ms.sentMessages = append(ms.sentMessages, r.Data)
 	return nil, nil
 }
 func (ms *MockSentry) SendMessageById(_ context.Context, r *proto_sentry.SendMessageByIdRequest) (*proto_sentry.SentPeers, error) {
+	if ms.transport != nil {
+		return ms.sendToPeers([]TransportPeer{{ID: r.PeerId}}, 1, r.Data), nil
+	}
 	ms.sentMessages = append(ms.sentMessages, r.Data)
 	return nil, nil
 }
 func (ms *MockSentry) SendMessageToRandomPeers(_ context.Context, r *proto_sentry.SendMessageToRandomPeersRequest) (*proto_sentry.SentPeers, error) {
+	if ms.transport != nil {
+		return ms.sendToPeers(ms.transport.Peers(ms), max(int(r.MaxPeers), 1), r.Data), nil
+	}
 	ms.sentMessages = append(ms.sentMessages, r.Data)
 	return nil, nil
 }
 func (ms *MockSentry) SendMessageToAll(_ context.Context, r *proto_sentry.OutboundMessageData) (*proto_sentry.SentPeers, error) {
+	if ms.transport != nil {
+		return ms.sendToPeers(ms.transport.Peers(ms), 0, r), nil
+	}
 	ms.sentMessages = append(ms.sentMessages, r)
 	return nil, nil
 }
@@ -205,6 +237,7 @@ func (ms *MockSentry) SentMessage(i int) *proto_sentry.OutboundMessageData {
 }
 
 func (ms *MockSentry) Messages(req *proto_sentry.MessagesRequest, stream proto_sentry.Sentry_MessagesServer) error {
+	ms.streamsLock.Lock()
 	if ms.streams == nil {
 		ms.streams = map[proto_sentry.MessageId][]proto_sentry.Sentry_MessagesServer{}
 	}
@@ -212,6 +245,7 @@ func (ms *MockSentry) Messages(req *proto_sentry.MessagesRequest, stream proto_s
 	for _, id := range req.Ids {
 		ms.streams[id] = append(ms.streams[id], stream)
 	}
+	ms.streamsLock.Unlock()
 	ms.StreamWg.Done()
 	select {
 	case <-ms.Ctx.Done():
@@ -225,6 +259,9 @@ func (ms *MockSentry) Peers(context.Context, *emptypb.Empty) (*proto_sentry.Peer
 	return &proto_sentry.PeersReply{}, nil
 }
 func (ms *MockSentry) PeerCount(context.Context, *proto_sentry.PeerCountRequest) (*proto_sentry.PeerCountReply, error) {
+	if ms.transport != nil {
+		return &proto_sentry.PeerCountReply{Count: uint64(len(ms.transport.Peers(ms)))}, nil
+	}
 	return &proto_sentry.PeerCountReply{Count: 0}, nil
 }
 func (ms *MockSentry) PeerById(context.Context, *proto_sentry.PeerByIdRequest) (*proto_sentry.PeerByIdReply, error) {
@@ -265,6 +302,21 @@ func MockWithGenesisPruneMode(tb testing.TB, gspec *types.Genesis, key *ecdsa.Pr
 
 func MockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateKey, prune prune.Mode,
 	engine consensus.Engine, blockBufferSize int, withTxPool, withPosDownloader, checkStateRoot bool,
+) *MockSentry {
+	return mockWithEverything(tb, gspec, key, prune, engine, blockBufferSize, withTxPool, withPosDownloader, checkStateRoot, nil)
+}
+
+// MockWithTransport creates a mock which exchanges messages with other nodes through
+// transport. Unlike the other mocks, it downloads blocks from its peers using the real
+// header and body downloaders, which are driven through the mock's BlockDownloader, and
+// its txpool exchanges transactions with the peers. Messages arrive asynchronously, so
+// they are not tracked in ReceiveWg.
+func MockWithTransport(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateKey, engine consensus.Engine, transport Transport) *MockSentry {
+	return mockWithEverything(tb, gspec, key, prune.DefaultMode, engine, blockBufferSize, true, true, true, transport)
+}
+
+func mockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateKey, prune prune.Mode,
+	engine consensus.Engine, blockBufferSize int, withTxPool, withPosDownloader, checkStateRoot bool, transport Transport,
 ) *MockSentry {
 	tmpdir := os.TempDir()
 	if tb != nil {
@@ -308,6 +360,7 @@ func MockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateK
 		BlockReader:    br,
 		ReceiptsReader: receipts.NewGenerator(16, br, engine),
 		HistoryV3:      true,
+		transport:      transport,
 	}
 
 	if tb != nil {
@@ -316,6 +369,11 @@ func MockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateK
 	blockWriter := blockio.NewBlockWriter()
 
 	mock.Address = crypto.PubkeyToAddress(mock.Key.PublicKey)
+	// messages handled by the streams are reported to ReceiveWg, unless they come from a transport
+	receiveWg := &mock.ReceiveWg
+	if transport != nil {
+		receiveWg = nil
+	}
 
 	sendHeaderRequest := func(_ context.Context, r *headerdownload.HeaderRequest) ([64]byte, bool) { return [64]byte{}, false }
 	propagateNewBlockHashes := func(context.Context, []headerdownload.Announce) {}
@@ -326,6 +384,23 @@ func MockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateK
 
 	sendBodyRequest := func(context.Context, *bodydownload.BodyRequest) ([64]byte, bool) { return [64]byte{}, false }
 	blockPropagator := func(Ctx context.Context, header *types.Header, body *types.RawBody, td *big.Int) {}
+	if transport != nil {
+		sendHeaderRequest = func(ctx context.Context, r *headerdownload.HeaderRequest) ([64]byte, bool) {
+			return mock.sentriesClient.SendHeaderRequest(ctx, r)
+		}
+		propagateNewBlockHashes = func(ctx context.Context, announces []headerdownload.Announce) {
+			mock.sentriesClient.PropagateNewBlockHashes(ctx, announces)
+		}
+		penalize = func(ctx context.Context, penalties []headerdownload.PenaltyItem) {
+			mock.sentriesClient.Penalize(ctx, penalties)
+		}
+		sendBodyRequest = func(ctx context.Context, r *bodydownload.BodyRequest) ([64]byte, bool) {
+			return mock.sentriesClient.SendBodyRequest(ctx, r)
+		}
+		blockPropagator = func(ctx context.Context, header *types.Header, body *types.RawBody, td *big.Int) {
+			mock.sentriesClient.BroadcastNewBlock(ctx, header, body, td)
+		}
+	}
 	if !cfg.DeprecatedTxPool.Disable {
 		poolCfg := txpoolcfg.DefaultConfig
 		newTxs := make(chan types2.Announcements, 1024)
@@ -348,7 +423,7 @@ func MockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateK
 		stateChangesClient := direct.NewStateDiffClientDirect(erigonGrpcServeer)
 
 		mock.TxPoolFetch = txpool.NewFetch(mock.Ctx, sentries, mock.TxPool, stateChangesClient, mock.DB, mock.txPoolDB, *chainID, logger)
-		mock.TxPoolFetch.SetWaitGroup(&mock.ReceiveWg)
+		mock.TxPoolFetch.SetWaitGroup(receiveWg)
 		mock.TxPoolSend = txpool.NewSend(mock.Ctx, sentries, mock.TxPool, logger)
 		mock.TxPoolGrpcServer = txpool.NewGrpcServer(mock.Ctx, mock.TxPool, mock.txPoolDB, *chainID, logger)
 
@@ -401,6 +476,7 @@ func MockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateK
 		mock.ChainConfig,
 		mock.Genesis,
 		mock.ChainConfig.ChainID.Uint64(),
+		mock.BlockReader,
 		logger,
 	)
 
@@ -548,7 +624,19 @@ func MockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateK
 		snapDownloader, mock.BlockReader, blockRetire, mock.agg, nil, forkValidator, logger, checkStateRoot)
 	mock.posStagedSync = stagedsync.New(cfg.Sync, pipelineStages, stagedsync.PipelineUnwindOrder, stagedsync.PipelinePruneOrder, logger)
 
-	mock.Eth1ExecutionService = eth1.NewEthereumExecutionModule(mock.BlockReader, mock.DB, mock.posStagedSync, forkValidator, mock.ChainConfig, assembleBlockPOS, nil, mock.Notifications.Accumulator, mock.Notifications.StateChangesConsumer, logger, engine, cfg.Sync, ctx)
+	var hook *stages2.Hook
+	if transport != nil {
+		// Announce the new head to the peers after every fork choice update.
+		hook = stages2.NewHook(mock.Ctx, mock.DB, mock.Notifications, mock.Sync, mock.BlockReader, mock.ChainConfig, logger, mock.sentriesClient.SetStatus)
+	}
+	mock.Eth1ExecutionService = eth1.NewEthereumExecutionModule(mock.BlockReader, mock.DB, mock.posStagedSync, forkValidator, mock.ChainConfig, assembleBlockPOS, hook, mock.Notifications.Accumulator, mock.Notifications.StateChangesConsumer, logger, engine, cfg.Sync, ctx)
+	if transport != nil {
+		executionRpc := direct.NewExecutionClientDirect(mock.Eth1ExecutionService)
+		mock.BlockDownloader = engine_block_downloader.NewEngineBlockDownloader(ctx,
+			logger, mock.sentriesClient.Hd, executionRpc,
+			mock.sentriesClient.Bd, mock.sentriesClient.BroadcastNewBlock, mock.sentriesClient.SendBodyRequest, mock.BlockReader,
+			mock.DB, mock.ChainConfig, dirs.Tmp, cfg.Sync)
+	}
 
 	mock.sentriesClient.Hd.StartPoSDownloader(mock.Ctx, sendHeaderRequest, penalize)
 
@@ -587,13 +675,13 @@ func MockWithEverything(tb testing.TB, gspec *types.Genesis, key *ecdsa.PrivateK
 	cfg.Genesis = gspec
 
 	mock.StreamWg.Add(1)
-	go mock.sentriesClient.RecvMessageLoop(mock.Ctx, mock.SentryClient, &mock.ReceiveWg)
+	go mock.sentriesClient.RecvMessageLoop(mock.Ctx, mock.SentryClient, receiveWg)
 	mock.StreamWg.Wait()
 	mock.StreamWg.Add(1)
-	go mock.sentriesClient.RecvUploadMessageLoop(mock.Ctx, mock.SentryClient, &mock.ReceiveWg)
+	go mock.sentriesClient.RecvUploadMessageLoop(mock.Ctx, mock.SentryClient, receiveWg)
 	mock.StreamWg.Wait()
 	mock.StreamWg.Add(1)
-	go mock.sentriesClient.RecvUploadHeadersMessageLoop(mock.Ctx, mock.SentryClient, &mock.ReceiveWg)
+	go mock.sentriesClient.RecvUploadHeadersMessageLoop(mock.Ctx, mock.SentryClient, receiveWg)
 	mock.StreamWg.Wait()
 
 	//app expecting that genesis will always be in db
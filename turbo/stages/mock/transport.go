@@ -0,0 +1,64 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package mock
+
+import (
+	proto_sentry "github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
+	ptypes "github.com/erigontech/erigon-lib/gointerfaces/typesproto"
+)
+
+// Transport connects a MockSentry to other nodes so that several mocks can sync
+// from each other. Messages the node sends through its sentry are handed to the
+// transport, which delivers them to the receiving mock with Deliver.
+type Transport interface {
+	// Peers returns the peers the node is connected to, in the order in which
+	// they should be preferred.
+	Peers(node *MockSentry) []TransportPeer
+	// Send sends a message from node to peer. It reports whether peer is connected.
+	Send(node *MockSentry, peer *ptypes.H512, data *proto_sentry.OutboundMessageData) bool
+	// SetStatus announces the status of node to its peers.
+	SetStatus(node *MockSentry, status *proto_sentry.StatusData)
+	// Penalize reports that node penalized peer.
+	Penalize(node *MockSentry, peer *ptypes.H512, kind proto_sentry.PenaltyKind)
+}
+
+// TransportPeer is a peer connected through a Transport.
+type TransportPeer struct {
+	ID       *ptypes.H512
+	MaxBlock uint64 // highest block announced by the peer
+}
+
+// Deliver hands a message received from a peer to the node's message streams.
+// The message is handled asynchronously.
+func (ms *MockSentry) Deliver(req *proto_sentry.InboundMessage) []error {
+	return ms.Send(req)
+}
+
+// sendToPeers sends data to the first maxPeers of peers and returns the peers
+// the message was sent to. A non-positive maxPeers means all peers.
+func (ms *MockSentry) sendToPeers(peers []TransportPeer, maxPeers int, data *proto_sentry.OutboundMessageData) *proto_sentry.SentPeers {
+	sent := &proto_sentry.SentPeers{}
+	for _, p := range peers {
+		if maxPeers > 0 && len(sent.Peers) >= maxPeers {
+			break
+		}
+		if ms.transport.Send(ms, p.ID, data) {
+			sent.Peers = append(sent.Peers, p.ID)
+		}
+	}
+	return sent
+}
@@ -0,0 +1,58 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package simnet
+
+import proto_sentry "github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
+
+// Behaviour rewrites a message node sends to peer to. Returning nil drops the
+// message while still reporting it as sent, which is how a misbehaving peer
+// looks from the outside. Behaviours are called with the network lock held
+// and must not call back into the Network.
+type Behaviour func(to int, data *proto_sentry.OutboundMessageData) *proto_sentry.OutboundMessageData
+
+// Silent drops every message, leaving the peer connected but unresponsive.
+func Silent() Behaviour {
+	return func(int, *proto_sentry.OutboundMessageData) *proto_sentry.OutboundMessageData {
+		return nil
+	}
+}
+
+// Withhold drops messages with the given ids and passes through the rest.
+func Withhold(ids ...proto_sentry.MessageId) Behaviour {
+	return func(_ int, data *proto_sentry.OutboundMessageData) *proto_sentry.OutboundMessageData {
+		for _, id := range ids {
+			if data.Id == id {
+				return nil
+			}
+		}
+		return data
+	}
+}
+
+// CorruptHeaders answers header requests with packets which can't be decoded, so
+// the receiver has to kick the peer and fetch the headers from someone else.
+// Headers which decode but don't match what was asked for are not enough: the
+// PoS downloader drops them without a penalty, as it may have sent a request twice.
+func CorruptHeaders() Behaviour {
+	return func(_ int, data *proto_sentry.OutboundMessageData) *proto_sentry.OutboundMessageData {
+		if data.Id != proto_sentry.MessageId_BLOCK_HEADERS_66 {
+			return data
+		}
+		// an empty string where the packet list is expected
+		return &proto_sentry.OutboundMessageData{Id: data.Id, Data: []byte{0x80}}
+	}
+}
@@ -0,0 +1,433 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+// Package simnet runs several Erigon nodes in a single process and connects them
+// through an in-memory network. Each node is a full mock node (staged sync, txpool,
+// execution module and engine API), and the network plays the consensus layer:
+// it hands blocks to nodes over the engine API and lets them download the rest
+// from their peers. Scenarios can partition the network, add latency, make nodes
+// misbehave and switch the canonical chain, then assert on the resulting heads.
+//
+// Runs are not deterministic, and the simulator deliberately does not try to make
+// them so. Deterministic dispatch would need a virtual clock and control over the
+// scheduling of every goroutine of every node (sync loop, downloaders, txpool,
+// execution module), which the nodes don't expose. Messages are delivered on the
+// wall clock, and the nodes' goroutines decide when messages are sent and in which
+// order the seeded random source for jitter and peer selection is consumed.
+// Scenarios must assert on outcomes that every interleaving reaches, such as the
+// final heads, and wait for them with a timeout rather than for a fixed time.
+package simnet
+
+import (
+	"container/heap"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"math/big"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/erigontech/erigon-lib/gointerfaces"
+	proto_sentry "github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
+	ptypes "github.com/erigontech/erigon-lib/gointerfaces/typesproto"
+
+	"github.com/erigontech/erigon/consensus/ethash"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/crypto"
+	"github.com/erigontech/erigon/turbo/stages/mock"
+)
+
+// Config configures a simulated network.
+type Config struct {
+	Nodes   int           // number of nodes
+	Seed    int64         // seed of the random source used for jitter and peer selection, doesn't make runs reproducible
+	Latency time.Duration // default latency of a link
+	Jitter  time.Duration // maximum random latency added to each message
+}
+
+// Penalty records that a node penalized one of its peers.
+type Penalty struct {
+	From, To int
+	Kind     proto_sentry.PenaltyKind
+}
+
+// Network is a simulated network of nodes. Initially, all nodes are connected
+// to each other.
+type Network struct {
+	tb      testing.TB
+	cfg     Config
+	gspec   *types.Genesis
+	builder *mock.MockSentry // holds the genesis state for generating chains
+	nodes   []*Node
+
+	mu         sync.Mutex
+	rand       *rand.Rand
+	links      map[linkKey]*link
+	partition  map[int]int // node index => partition group, nil if not partitioned
+	behaviours map[int]Behaviour
+	penalties  []Penalty
+	queue      messageQueue
+	seq        uint64
+	wake       chan struct{}
+	quit       chan struct{}
+	wg         sync.WaitGroup
+}
+
+type linkKey struct{ a, b int }
+
+func newLinkKey(a, b int) linkKey {
+	if a > b {
+		a, b = b, a
+	}
+	return linkKey{a, b}
+}
+
+// link is a connection between two nodes.
+type link struct {
+	connected bool
+	latency   time.Duration
+	last      map[int]time.Time // delivery time of the last message per sender, keeps links FIFO
+}
+
+// New creates a network of nodes sharing the genesis gspec. The chain must be
+// post-merge from genesis. Nodes are shut down when the test ends.
+func New(tb testing.TB, gspec *types.Genesis, cfg Config) *Network {
+	n := &Network{
+		tb:         tb,
+		cfg:        cfg,
+		gspec:      gspec,
+		rand:       rand.New(rand.NewSource(cfg.Seed)), // nolint: gosec
+		links:      make(map[linkKey]*link),
+		behaviours: make(map[int]Behaviour),
+		wake:       make(chan struct{}, 1),
+		quit:       make(chan struct{}),
+	}
+	n.builder = mock.MockWithGenesisEngine(tb, gspec, ethash.NewFaker(), false, true)
+	for i := 0; i < cfg.Nodes; i++ {
+		for j := i + 1; j < cfg.Nodes; j++ {
+			n.links[newLinkKey(i, j)] = &link{connected: true, latency: cfg.Latency, last: make(map[int]time.Time)}
+		}
+	}
+	// Messages sent while the nodes start up are queued until all of them are running.
+	for i := 0; i < cfg.Nodes; i++ {
+		n.nodes = append(n.nodes, newNode(n, i))
+	}
+	n.wg.Add(1)
+	go n.dispatchLoop()
+	tb.Cleanup(n.Close)
+	return n
+}
+
+func nodeID(i int) *ptypes.H512 {
+	var id [64]byte
+	binary.BigEndian.PutUint64(id[56:], uint64(i)+1)
+	return gointerfaces.ConvertHashToH512(id)
+}
+
+func nodeKey(seed int64, i int) *ecdsa.PrivateKey {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], uint64(seed))
+	binary.BigEndian.PutUint64(b[8:], uint64(i))
+	key, err := crypto.ToECDSA(crypto.Keccak256(b[:]))
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// Close stops message delivery. It is called automatically when the test ends.
+func (n *Network) Close() {
+	n.mu.Lock()
+	select {
+	case <-n.quit:
+		n.mu.Unlock()
+		return
+	default:
+		close(n.quit)
+	}
+	n.mu.Unlock()
+	n.wg.Wait()
+}
+
+// Node returns the i-th node.
+func (n *Network) Node(i int) *Node {
+	return n.nodes[i]
+}
+
+// Nodes returns all nodes.
+func (n *Network) Nodes() []*Node {
+	return n.nodes
+}
+
+// Genesis returns the genesis block of the network.
+func (n *Network) Genesis() *types.Block {
+	return n.builder.Genesis
+}
+
+// GenerateChain generates length blocks on top of genesis. The blocks only depend
+// on what gen does, so a fork is generated by calling GenerateChain with a gen
+// that diverges from another chain's gen at the fork block.
+func (n *Network) GenerateChain(length int, gen func(int, *core.BlockGen)) *core.ChainPack {
+	chain, err := core.GenerateChain(n.builder.ChainConfig, n.builder.Genesis, n.builder.Engine, n.builder.DB, length, func(i int, b *core.BlockGen) {
+		b.SetDifficulty(big.NewInt(0))
+		if gen != nil {
+			gen(i, b)
+		}
+	})
+	if err != nil {
+		n.tb.Fatal(err)
+	}
+	return chain
+}
+
+// Connect connects nodes a and b.
+func (n *Network) Connect(a, b int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.links[newLinkKey(a, b)].connected = true
+}
+
+// Disconnect disconnects nodes a and b. Messages in flight between them are dropped.
+func (n *Network) Disconnect(a, b int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.links[newLinkKey(a, b)].connected = false
+}
+
+// Partition splits the network into the given groups. Nodes can only reach
+// nodes in their own group, and nodes not in any group are isolated.
+func (n *Network) Partition(groups ...[]int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.partition = make(map[int]int)
+	for g, group := range groups {
+		for _, i := range group {
+			n.partition[i] = g
+		}
+	}
+}
+
+// Heal removes the partition created with Partition.
+func (n *Network) Heal() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.partition = nil
+}
+
+// SetLatency sets the latency of the link between a and b.
+func (n *Network) SetLatency(a, b int, latency time.Duration) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.links[newLinkKey(a, b)].latency = latency
+}
+
+// SetBehaviour makes node i alter the messages it sends. A nil behaviour makes
+// the node honest again.
+func (n *Network) SetBehaviour(i int, b Behaviour) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if b == nil {
+		delete(n.behaviours, i)
+		return
+	}
+	n.behaviours[i] = b
+}
+
+// Penalties returns the penalties reported by the nodes so far.
+func (n *Network) Penalties() []Penalty {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return slices.Clone(n.penalties)
+}
+
+// reachable reports whether messages can flow between a and b. It must be called
+// with n.mu held.
+func (n *Network) reachable(a, b int) bool {
+	if a == b || !n.links[newLinkKey(a, b)].connected {
+		return false
+	}
+	if n.partition != nil {
+		ga, oka := n.partition[a]
+		gb, okb := n.partition[b]
+		return oka && okb && ga == gb
+	}
+	return true
+}
+
+func (n *Network) nodeByID(id *ptypes.H512) *Node {
+	for _, nd := range n.nodes {
+		if gointerfaces.ConvertH512ToHash(nd.ID) == gointerfaces.ConvertH512ToHash(id) {
+			return nd
+		}
+	}
+	return nil
+}
+
+// peers returns the peers of node i in random order.
+func (n *Network) peers(i int) []mock.TransportPeer {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var peers []mock.TransportPeer
+	for _, nd := range n.nodes {
+		if n.reachable(i, nd.Index) {
+			peers = append(peers, mock.TransportPeer{ID: nd.ID, MaxBlock: nd.maxBlock})
+		}
+	}
+	n.rand.Shuffle(len(peers), func(a, b int) { peers[a], peers[b] = peers[b], peers[a] })
+	return peers
+}
+
+// send schedules the delivery of a message from node from to node to.
+func (n *Network) send(from, to *Node, data *proto_sentry.OutboundMessageData) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if !n.reachable(from.Index, to.Index) {
+		return false
+	}
+	if b := n.behaviours[from.Index]; b != nil {
+		if data = b(to.Index, data); data == nil {
+			return true
+		}
+	}
+	l := n.links[newLinkKey(from.Index, to.Index)]
+	delay := l.latency
+	if n.cfg.Jitter > 0 {
+		delay += time.Duration(n.rand.Int63n(int64(n.cfg.Jitter)))
+	}
+	at := time.Now().Add(delay)
+	if last := l.last[from.Index]; at.Before(last) {
+		at = last
+	}
+	l.last[from.Index] = at
+	n.seq++
+	heap.Push(&n.queue, &message{
+		at:   at,
+		seq:  n.seq,
+		from: from.Index,
+		to:   to,
+		msg:  &proto_sentry.InboundMessage{Id: data.Id, Data: data.Data, PeerId: from.ID},
+	})
+	select {
+	case n.wake <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// dispatchLoop delivers scheduled messages in order of their delivery time.
+func (n *Network) dispatchLoop() {
+	defer n.wg.Done()
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		n.mu.Lock()
+		var next *message
+		wait := time.Hour
+		if len(n.queue) > 0 {
+			if wait = time.Until(n.queue[0].at); wait <= 0 {
+				next = heap.Pop(&n.queue).(*message)
+				if !n.reachable(next.from, next.to.Index) {
+					next = nil // link went down while the message was in flight
+				}
+				wait = 0
+			}
+		}
+		n.mu.Unlock()
+
+		if next != nil {
+			next.to.Deliver(next.msg)
+			continue
+		}
+		if wait == 0 {
+			continue
+		}
+		timer.Reset(wait)
+		select {
+		case <-timer.C:
+		case <-n.wake:
+			if !timer.Stop() {
+				<-timer.C
+			}
+		case <-n.quit:
+			return
+		}
+	}
+}
+
+// message is a message in flight.
+type message struct {
+	at   time.Time
+	seq  uint64
+	from int
+	to   *Node
+	msg  *proto_sentry.InboundMessage
+}
+
+// messageQueue is a heap of messages ordered by delivery time.
+type messageQueue []*message
+
+func (q messageQueue) Len() int { return len(q) }
+func (q messageQueue) Less(i, j int) bool {
+	if q[i].at.Equal(q[j].at) {
+		return q[i].seq < q[j].seq
+	}
+	return q[i].at.Before(q[j].at)
+}
+func (q messageQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *messageQueue) Push(x any)   { *q = append(*q, x.(*message)) }
+func (q *messageQueue) Pop() any {
+	old := *q
+	m := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return m
+}
+
+// transport connects a node's mock sentry to the network.
+type transport struct {
+	net  *Network
+	node *Node
+}
+
+func (t *transport) Peers(*mock.MockSentry) []mock.TransportPeer {
+	return t.net.peers(t.node.Index)
+}
+
+func (t *transport) Send(_ *mock.MockSentry, peer *ptypes.H512, data *proto_sentry.OutboundMessageData) bool {
+	to := t.net.nodeByID(peer)
+	if to == nil {
+		return false
+	}
+	return t.net.send(t.node, to, data)
+}
+
+func (t *transport) SetStatus(_ *mock.MockSentry, status *proto_sentry.StatusData) {
+	t.net.mu.Lock()
+	defer t.net.mu.Unlock()
+	t.node.maxBlock = status.MaxBlockHeight
+}
+
+func (t *transport) Penalize(_ *mock.MockSentry, peer *ptypes.H512, kind proto_sentry.PenaltyKind) {
+	to := t.net.nodeByID(peer)
+	if to == nil {
+		return
+	}
+	t.net.mu.Lock()
+	defer t.net.mu.Unlock()
+	t.net.penalties = append(t.net.penalties, Penalty{From: t.node.Index, To: to.Index, Kind: kind})
+}
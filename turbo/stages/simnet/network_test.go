@@ -0,0 +1,168 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package simnet
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	proto_sentry "github.com/erigontech/erigon-lib/gointerfaces/sentryproto"
+
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/crypto"
+	"github.com/erigontech/erigon/params"
+)
+
+const syncTimeout = time.Minute
+
+func testGenesis() *types.Genesis {
+	cfg := *params.AllProtocolChanges
+	cfg.ShanghaiTime, cfg.CancunTime, cfg.PragueTime = nil, nil, nil
+	return &types.Genesis{
+		Config:     &cfg,
+		Difficulty: big.NewInt(0),
+		GasLimit:   30_000_000,
+		Alloc:      types.GenesisAlloc{},
+	}
+}
+
+func forkAt(k int, tag byte) func(int, *core.BlockGen) {
+	return func(i int, b *core.BlockGen) {
+		if i >= k {
+			b.SetExtra([]byte{tag})
+		}
+	}
+}
+
+func TestSync(t *testing.T) {
+	net := New(t, testGenesis(), Config{Nodes: 4, Seed: 1, Latency: time.Millisecond})
+	chain := net.GenerateChain(20, nil)
+	require.NoError(t, net.Node(0).Import(chain))
+	require.NoError(t, net.WaitForHead(chain.TopBlock.Hash(), syncTimeout))
+}
+
+func TestSyncWithLatency(t *testing.T) {
+	net := New(t, testGenesis(), Config{Nodes: 4, Seed: 2, Latency: 5 * time.Millisecond, Jitter: 10 * time.Millisecond})
+	net.SetLatency(0, 3, 50*time.Millisecond)
+	chain := net.GenerateChain(20, nil)
+	require.NoError(t, net.Node(0).Import(chain))
+	require.NoError(t, net.WaitForHead(chain.TopBlock.Hash(), syncTimeout))
+}
+
+func TestPartitionReorg(t *testing.T) {
+	net := New(t, testGenesis(), Config{Nodes: 4, Seed: 3, Latency: time.Millisecond})
+	short := net.GenerateChain(10, forkAt(5, 1))
+	long := net.GenerateChain(15, forkAt(5, 2))
+
+	net.Partition([]int{0, 1}, []int{2, 3})
+	require.NoError(t, net.Node(0).Import(short))
+	require.NoError(t, net.Node(2).Import(long))
+	require.NoError(t, net.WaitForHead(short.TopBlock.Hash(), syncTimeout, 0, 1))
+	require.NoError(t, net.WaitForHead(long.TopBlock.Hash(), syncTimeout, 2, 3))
+
+	net.Heal()
+	require.NoError(t, net.WaitForHead(long.TopBlock.Hash(), syncTimeout))
+}
+
+func TestSilentPeer(t *testing.T) {
+	net := New(t, testGenesis(), Config{Nodes: 4, Seed: 4, Latency: time.Millisecond})
+	net.SetBehaviour(3, Silent())
+	chain := net.GenerateChain(20, nil)
+	require.NoError(t, net.Node(0).Import(chain))
+	require.NoError(t, net.Node(3).Import(chain))
+	require.NoError(t, net.WaitForHead(chain.TopBlock.Hash(), syncTimeout, 1, 2))
+}
+
+func TestCorruptHeaders(t *testing.T) {
+	net := New(t, testGenesis(), Config{Nodes: 3, Seed: 5, Latency: time.Millisecond})
+	net.SetBehaviour(0, CorruptHeaders())
+	chain := net.GenerateChain(20, nil)
+	require.NoError(t, net.Node(0).Import(chain))
+	require.NoError(t, net.Node(1).Import(chain))
+
+	// node 2 can only ask the corrupt node for headers until it kicks it.
+	net.Disconnect(1, 2)
+	_, err := net.Node(2).ForkchoiceUpdated(chain.TopBlock.Hash())
+	require.NoError(t, err)
+	kicked := Penalty{From: 2, To: 0, Kind: proto_sentry.PenaltyKind_Kick}
+	require.Eventually(t, func() bool { return slices.Contains(net.Penalties(), kicked) }, syncTimeout, 10*time.Millisecond)
+
+	net.Connect(1, 2)
+	require.NoError(t, net.WaitForHead(chain.TopBlock.Hash(), syncTimeout, 2))
+	for _, p := range net.Penalties() {
+		require.Equal(t, 0, p.To, "honest node penalized: %+v", p)
+	}
+}
+
+func TestWithholdBodies(t *testing.T) {
+	net := New(t, testGenesis(), Config{Nodes: 3, Seed: 6, Latency: time.Millisecond})
+	net.SetBehaviour(0, Withhold(proto_sentry.MessageId_BLOCK_BODIES_66))
+	chain := net.GenerateChain(20, nil)
+	require.NoError(t, net.Node(0).Import(chain))
+	require.NoError(t, net.Node(1).Import(chain))
+	require.NoError(t, net.WaitForHead(chain.TopBlock.Hash(), syncTimeout, 2))
+	// the body downloader retries requests which time out with other peers but
+	// doesn't penalise anyone for them.
+	require.Empty(t, net.Penalties())
+}
+
+func TestTransactionPropagation(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	gspec := testGenesis()
+	gspec.Alloc[crypto.PubkeyToAddress(key.PublicKey)] = types.GenesisAccount{Balance: big.NewInt(params.Ether)}
+	net := New(t, gspec, Config{Nodes: 4, Seed: 7, Latency: time.Millisecond, Jitter: 5 * time.Millisecond})
+
+	// the pools start with the first block they are notified about.
+	chain := net.GenerateChain(5, nil)
+	require.NoError(t, net.Node(0).Import(chain))
+	require.NoError(t, net.WaitForHead(chain.TopBlock.Hash(), syncTimeout))
+
+	signer := types.LatestSignerForChainID(gspec.Config.ChainID)
+	txn, err := types.SignTx(types.NewTransaction(0, libcommon.Address{1}, uint256.NewInt(1), params.TxGas, uint256.NewInt(10*params.GWei), nil), *signer, key)
+	require.NoError(t, err)
+	require.NoError(t, net.Node(3).AddTransaction(txn))
+	allPending := func(n int) func() bool {
+		return func() bool {
+			for _, nd := range net.Nodes() {
+				if nd.PendingTransactions() != n {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	require.Eventually(t, allPending(1), syncTimeout, 10*time.Millisecond)
+
+	// once the transaction is included, every pool drops it, including those
+	// which downloaded the block from their peers.
+	included := net.GenerateChain(6, func(i int, b *core.BlockGen) {
+		if i == 5 {
+			b.AddTx(txn)
+		}
+	})
+	require.NoError(t, net.Node(0).Import(included))
+	require.NoError(t, net.WaitForHead(included.TopBlock.Hash(), syncTimeout))
+	require.Eventually(t, allPending(0), syncTimeout, 10*time.Millisecond)
+}
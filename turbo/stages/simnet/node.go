@@ -0,0 +1,222 @@
+// Copyright 2024 The Erigon Authors
+// This file is part of Erigon.
+//
+// Erigon is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// Erigon is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with Erigon. If not, see <http://www.gnu.org/licenses/>.
+
+package simnet
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	libcommon "github.com/erigontech/erigon-lib/common"
+	"github.com/erigontech/erigon-lib/common/hexutil"
+	"github.com/erigontech/erigon-lib/common/hexutility"
+	"github.com/erigontech/erigon-lib/direct"
+	txpool_proto "github.com/erigontech/erigon-lib/gointerfaces/txpoolproto"
+	ptypes "github.com/erigontech/erigon-lib/gointerfaces/typesproto"
+	"github.com/erigontech/erigon-lib/kv"
+
+	"github.com/erigontech/erigon/consensus/ethash"
+	"github.com/erigontech/erigon/core"
+	"github.com/erigontech/erigon/core/rawdb"
+	"github.com/erigontech/erigon/core/types"
+	"github.com/erigontech/erigon/turbo/engineapi"
+	"github.com/erigontech/erigon/turbo/engineapi/engine_types"
+	"github.com/erigontech/erigon/turbo/stages/mock"
+)
+
+// forkchoiceRetryInterval is how often WaitForHead repeats fork choice updates to
+// nodes which haven't reached the head yet, like a consensus client would.
+const forkchoiceRetryInterval = 100 * time.Millisecond
+
+// Node is a node of a simulated network.
+type Node struct {
+	*mock.MockSentry
+	Index int
+	ID    *ptypes.H512
+
+	net      *Network
+	engine   *engineapi.EngineServer
+	maxBlock uint64 // last announced head, protected by net.mu
+}
+
+func newNode(net *Network, i int) *Node {
+	nd := &Node{Index: i, ID: nodeID(i), net: net}
+	nd.MockSentry = mock.MockWithTransport(net.tb, net.gspec, nodeKey(net.cfg.Seed, i), ethash.NewFaker(), &transport{net: net, node: nd})
+	nd.engine = engineapi.NewEngineServer(
+		nd.Log,
+		nd.ChainConfig,
+		direct.NewExecutionClientDirect(nd.Eth1ExecutionService),
+		nd.HeaderDownload(),
+		nd.BlockDownloader,
+		false, /* caplin */
+		false, /* test */
+		false, /* proposing */
+	)
+	return nd
+}
+
+// NewPayload sends block to the node with engine_newPayloadV1.
+func (nd *Node) NewPayload(block *types.Block) (*engine_types.PayloadStatus, error) {
+	return nd.engine.NewPayloadV1(nd.Ctx, executionPayload(block))
+}
+
+// ForkchoiceUpdated sends engine_forkchoiceUpdatedV1 to the node, making head
+// the head, safe and finalized block.
+func (nd *Node) ForkchoiceUpdated(head libcommon.Hash) (*engine_types.PayloadStatus, error) {
+	resp, err := nd.engine.ForkchoiceUpdatedV1(nd.Ctx, &engine_types.ForkChoiceState{
+		HeadHash:           head,
+		SafeBlockHash:      head,
+		FinalizedBlockHash: head,
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return resp.PayloadStatus, nil
+}
+
+// Import hands the blocks of chain to the node over the engine API and makes
+// the top block the head, like a consensus client following the chain would.
+func (nd *Node) Import(chain *core.ChainPack) error {
+	for _, block := range chain.Blocks {
+		status, err := nd.NewPayload(block)
+		if err != nil {
+			return err
+		}
+		if status.Status != engine_types.ValidStatus {
+			return fmt.Errorf("node %d: new payload %d: %s", nd.Index, block.NumberU64(), status.Status)
+		}
+	}
+	status, err := nd.ForkchoiceUpdated(chain.TopBlock.Hash())
+	if err != nil {
+		return err
+	}
+	if status.Status != engine_types.ValidStatus {
+		return fmt.Errorf("node %d: fork choice update: %s", nd.Index, status.Status)
+	}
+	return nil
+}
+
+// AddTransaction submits txn to the node's txpool, like a user sending it over
+// RPC would. The pool propagates it to the node's peers.
+func (nd *Node) AddTransaction(txn types.Transaction) error {
+	var buf bytes.Buffer
+	if err := txn.MarshalBinary(&buf); err != nil {
+		return err
+	}
+	reply, err := nd.TxPoolGrpcServer.Add(nd.Ctx, &txpool_proto.AddRequest{RlpTxs: [][]byte{buf.Bytes()}})
+	if err != nil {
+		return err
+	}
+	if reply.Imported[0] != txpool_proto.ImportResult_SUCCESS {
+		return fmt.Errorf("node %d: add transaction: %s", nd.Index, reply.Errors[0])
+	}
+	return nil
+}
+
+// PendingTransactions returns the number of transactions in the pending
+// sub-pool of the node's txpool.
+func (nd *Node) PendingTransactions() int {
+	pending, _, _ := nd.TxPool.CountContent()
+	return pending
+}
+
+// Head returns the number and hash of the node's head block.
+func (nd *Node) Head() (number uint64, hash libcommon.Hash, err error) {
+	err = nd.DB.View(nd.Ctx, func(tx kv.Tx) error {
+		hash = rawdb.ReadHeadBlockHash(tx)
+		if n := rawdb.ReadHeaderNumber(tx, hash); n != nil {
+			number = *n
+		}
+		return nil
+	})
+	return number, hash, err
+}
+
+// WaitForHead drives the given nodes to head by repeating fork choice updates
+// until all of them report it as their head, downloading missing blocks from
+// their peers. If no nodes are given, all nodes of the network are used.
+func (n *Network) WaitForHead(head libcommon.Hash, timeout time.Duration, nodes ...int) error {
+	if len(nodes) == 0 {
+		for _, nd := range n.nodes {
+			nodes = append(nodes, nd.Index)
+		}
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		var pending []int
+		for _, i := range nodes {
+			nd := n.nodes[i]
+			if _, hash, err := nd.Head(); err != nil {
+				return err
+			} else if hash == head {
+				continue
+			}
+			if _, err := nd.ForkchoiceUpdated(head); err != nil && !errors.Is(err, context.Canceled) {
+				return fmt.Errorf("node %d: %w", i, err)
+			}
+			pending = append(pending, i)
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timeout waiting for head %x: %s", head, n.describeHeads(pending))
+		}
+		time.Sleep(forkchoiceRetryInterval)
+	}
+}
+
+func (n *Network) describeHeads(nodes []int) string {
+	var b strings.Builder
+	for _, i := range nodes {
+		number, hash, _ := n.nodes[i].Head()
+		fmt.Fprintf(&b, " node %d at %d %x", i, number, hash)
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// executionPayload converts a pre-Shanghai block to an engine API payload.
+func executionPayload(block *types.Block) *engine_types.ExecutionPayload {
+	header := block.Header()
+	txs := make([]hexutility.Bytes, len(block.Transactions()))
+	for i, txn := range block.Transactions() {
+		var buf bytes.Buffer
+		if err := txn.MarshalBinary(&buf); err != nil {
+			panic(err)
+		}
+		txs[i] = buf.Bytes()
+	}
+	return &engine_types.ExecutionPayload{
+		ParentHash:    header.ParentHash,
+		FeeRecipient:  header.Coinbase,
+		StateRoot:     header.Root,
+		ReceiptsRoot:  header.ReceiptHash,
+		LogsBloom:     header.Bloom[:],
+		PrevRandao:    header.MixDigest,
+		BlockNumber:   hexutil.Uint64(header.Number.Uint64()),
+		GasLimit:      hexutil.Uint64(header.GasLimit),
+		GasUsed:       hexutil.Uint64(header.GasUsed),
+		Timestamp:     hexutil.Uint64(header.Time),
+		ExtraData:     header.Extra,
+		BaseFeePerGas: (*hexutil.Big)(header.BaseFee),
+		BlockHash:     block.Hash(),
+		Transactions:  txs,
+	}
+}